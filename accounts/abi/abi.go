@@ -42,8 +42,86 @@ type ABI struct {
 	// can only define one fallback and receive function.
 	Fallback Method // Note it's also used to represent legacy fallback before v0.6.0
 	Receive  Method
+
+	// Options alters the default encoding and decoding behaviour of the ABI methods.
+	Options Options
+
+	index *selectorIndex // Lookup index of the parsed declarations
+}
+
+// selectorIndex maps method selectors, event topics and error selectors to the
+// names of the declarations in an ABI. It is built when the ABI is parsed and
+// never modified afterwards, so it is safe for concurrent use.
+type selectorIndex struct {
+	methods map[[4]byte]string
+	events  map[common.Hash]string
+	errors  map[[4]byte]string
+}
+
+// newSelectorIndex creates the lookup index of the declarations in the ABI.
+func newSelectorIndex(abi *ABI) *selectorIndex {
+	index := &selectorIndex{
+		methods: make(map[[4]byte]string, len(abi.Methods)),
+		events:  make(map[common.Hash]string, len(abi.Events)),
+		errors:  make(map[[4]byte]string, len(abi.Errors)),
+	}
+	for name, method := range abi.Methods {
+		index.methods[[4]byte(method.ID)] = name
+	}
+	for name, event := range abi.Events {
+		index.events[event.ID] = name
+	}
+	for name, errABI := range abi.Errors {
+		index.errors[[4]byte(errABI.ID[:4])] = name
+	}
+	return index
+}
+
+// Options configures optional, stricter or more lenient behaviours of the ABI
+// encoder. The zero value retains the default behaviour.
+type Options struct {
+	// StrictIntegers rejects integer values that don't fit into the bit size
+	// of their declared type, as well as negative values for unsigned types,
+	// instead of silently truncating them to 256 bits.
+	StrictIntegers bool
+
+	// Lenient tolerates non-canonical encodings when unpacking, as produced by
+	// some older compilers and hand-written assembly: dirty high-order bits of
+	// small integers, booleans and function types are ignored, and data that
+	// is not padded to a multiple of 32 bytes is zero-extended. Dynamic values
+	// at non-minimal or out of order offsets are accepted in either mode.
+	Lenient bool
+
+	// Addresses selects how addresses given as hex strings are validated when
+	// packing. Values of type common.Address and byte arrays are unaffected.
+	Addresses AddressPolicy
+
+	// Converters adapts application specific Go types, such as time.Time, to
+	// values accepted by the packer. See StandardConverters.
+	Converters *Converters
+
+	// Limits bounds the number of elements, bytes and the nesting depth of
+	// unpacked values, protecting against maliciously crafted data.
+	Limits DecodeLimits
 }
 
+// AddressPolicy defines the validation applied to string address arguments.
+type AddressPolicy uint8
+
+const (
+	// AddressCaseInsensitive accepts 0x-prefixed hex addresses in any case,
+	// without verifying the EIP-55 checksum. This is the default.
+	AddressCaseInsensitive AddressPolicy = iota
+
+	// AddressChecksum only accepts addresses in their EIP-55 checksummed form,
+	// rejecting all lowercase or uppercase strings.
+	AddressChecksum
+
+	// AddressNoStrings rejects string addresses altogether, requiring callers
+	// to pass common.Address values.
+	AddressNoStrings
+)
+
 // JSON returns a parsed ABI interface and error if it failed.
 func JSON(reader io.Reader) (ABI, error) {
 	dec := json.NewDecoder(reader)
@@ -64,7 +142,7 @@ func (abi ABI) Pack(name string, args ...interface{}) ([]byte, error) {
 	// Fetch the ABI of the requested method
 	if name == "" {
 		// constructor
-		arguments, err := abi.Constructor.Inputs.Pack(args...)
+		arguments, err := abi.Constructor.Inputs.PackWithOptions(abi.Options, args...)
 		if err != nil {
 			return nil, err
 		}
@@ -74,7 +152,7 @@ func (abi ABI) Pack(name string, args ...interface{}) ([]byte, error) {
 	if !exist {
 		return nil, fmt.Errorf("method '%s' not found", name)
 	}
-	arguments, err := method.Inputs.Pack(args...)
+	arguments, err := method.Inputs.PackWithOptions(abi.Options, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +165,7 @@ func (abi ABI) getArguments(name string, data []byte) (Arguments, error) {
 	// we need to decide whether we're calling a method, event or an error
 	var args Arguments
 	if method, ok := abi.Methods[name]; ok {
-		if len(data)%32 != 0 {
+		if len(data)%32 != 0 && !abi.Options.Lenient {
 			return nil, fmt.Errorf("abi: improperly formatted output: %q - Bytes: %+v", data, data)
 		}
 		args = method.Outputs
@@ -110,7 +188,21 @@ func (abi ABI) Unpack(name string, data []byte) ([]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return args.Unpack(data)
+	return args.UnpackWithOptions(abi.Options, data)
+}
+
+// UnpackConstructor decodes the constructor arguments appended to the creation
+// bytecode of a contract deployment. The codeLen is the length of the compiled
+// creation bytecode, which precedes the ABI encoded arguments in deployData.
+func (abi ABI) UnpackConstructor(deployData []byte, codeLen int) ([]interface{}, error) {
+	if codeLen < 0 || codeLen > len(deployData) {
+		return nil, fmt.Errorf("abi: invalid bytecode length %d for deployment data of %d bytes", codeLen, len(deployData))
+	}
+	data := deployData[codeLen:]
+	if len(data) == 0 && len(abi.Constructor.Inputs) != 0 {
+		return nil, errors.New("abi: deployment data contains no constructor arguments")
+	}
+	return abi.Constructor.Inputs.UnpackWithOptions(abi.Options, data)
 }
 
 // UnpackIntoInterface unpacks the output in v according to the abi specification.
@@ -121,7 +213,7 @@ func (abi ABI) UnpackIntoInterface(v interface{}, name string, data []byte) erro
 	if err != nil {
 		return err
 	}
-	unpacked, err := args.Unpack(data)
+	unpacked, err := args.UnpackWithOptions(abi.Options, data)
 	if err != nil {
 		return err
 	}
@@ -134,32 +226,41 @@ func (abi ABI) UnpackIntoMap(v map[string]interface{}, name string, data []byte)
 	if err != nil {
 		return err
 	}
-	return args.UnpackIntoMap(v, data)
+	return args.unpackIntoMap(abi.Options, v, data)
 }
 
-// UnmarshalJSON implements json.Unmarshaler interface.
-func (abi *ABI) UnmarshalJSON(data []byte) error {
-	var fields []struct {
-		Type    string
-		Name    string
-		Inputs  []Argument
-		Outputs []Argument
+// abiField is a single entry of a contract ABI definition, as found in the JSON
+// description of a contract.
+type abiField struct {
+	Type    string
+	Name    string
+	Inputs  []Argument
+	Outputs []Argument
 
-		// Status indicator which can be: "pure", "view",
-		// "nonpayable" or "payable".
-		StateMutability string
+	// Status indicator which can be: "pure", "view",
+	// "nonpayable" or "payable".
+	StateMutability string
 
-		// Deprecated Status indicators, but removed in v0.6.0.
-		Constant bool // True if function is either pure or view
-		Payable  bool // True if function is payable
+	// Deprecated Status indicators, but removed in v0.6.0.
+	Constant bool // True if function is either pure or view
+	Payable  bool // True if function is payable
 
-		// Event relevant indicator represents the event is
-		// declared as anonymous.
-		Anonymous bool
-	}
+	// Event relevant indicator represents the event is
+	// declared as anonymous.
+	Anonymous bool
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (abi *ABI) UnmarshalJSON(data []byte) error {
+	var fields []abiField
 	if err := json.Unmarshal(data, &fields); err != nil {
 		return err
 	}
+	return abi.setFields(fields)
+}
+
+// setFields (re)initializes the ABI from the given list of definitions.
+func (abi *ABI) setFields(fields []abiField) error {
 	abi.Methods = make(map[string]Method)
 	abi.Events = make(map[string]Event)
 	abi.Errors = make(map[string]Error)
@@ -198,6 +299,7 @@ func (abi *ABI) UnmarshalJSON(data []byte) error {
 			return fmt.Errorf("abi: could not recognize type %v of field %v", field.Type, field.Name)
 		}
 	}
+	abi.index = newSelectorIndex(abi)
 	return nil
 }
 
@@ -207,6 +309,13 @@ func (abi *ABI) MethodById(sigdata []byte) (*Method, error) {
 	if len(sigdata) < 4 {
 		return nil, fmt.Errorf("data too short (%d bytes) for abi method lookup", len(sigdata))
 	}
+	// Use the index if available, verifying the result in case the method
+	// map was modified after parsing.
+	if abi.index != nil {
+		if method, ok := abi.Methods[abi.index.methods[[4]byte(sigdata)]]; ok && bytes.Equal(method.ID, sigdata[:4]) {
+			return &method, nil
+		}
+	}
 	for _, method := range abi.Methods {
 		if bytes.Equal(method.ID, sigdata[:4]) {
 			return &method, nil
@@ -218,6 +327,11 @@ func (abi *ABI) MethodById(sigdata []byte) (*Method, error) {
 // EventByID looks an event up by its topic hash in the
 // ABI and returns nil if none found.
 func (abi *ABI) EventByID(topic common.Hash) (*Event, error) {
+	if abi.index != nil {
+		if event, ok := abi.Events[abi.index.events[topic]]; ok && event.ID == topic {
+			return &event, nil
+		}
+	}
 	for _, event := range abi.Events {
 		if bytes.Equal(event.ID.Bytes(), topic.Bytes()) {
 			return &event, nil
@@ -226,9 +340,27 @@ func (abi *ABI) EventByID(topic common.Hash) (*Event, error) {
 	return nil, fmt.Errorf("no event with id: %#x", topic.Hex())
 }
 
+// EventByTopic looks up an event by the first topic of a log, the hash of the
+// event signature. It returns an error for anonymous or unknown events.
+func (abi *ABI) EventByTopic(topic common.Hash) (*Event, error) {
+	event, err := abi.EventByID(topic)
+	if err != nil {
+		return nil, err
+	}
+	if event.Anonymous {
+		return nil, fmt.Errorf("event %s is anonymous", event.Name)
+	}
+	return event, nil
+}
+
 // ErrorByID looks up an error by the 4-byte id,
 // returns nil if none found.
 func (abi *ABI) ErrorByID(sigdata [4]byte) (*Error, error) {
+	if abi.index != nil {
+		if errABI, ok := abi.Errors[abi.index.errors[sigdata]]; ok && bytes.Equal(errABI.ID[:4], sigdata[:]) {
+			return &errABI, nil
+		}
+	}
 	for _, errABI := range abi.Errors {
 		if bytes.Equal(errABI.ID[:4], sigdata[:]) {
 			return &errABI, nil
@@ -299,16 +431,43 @@ func UnpackRevert(data []byte) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		pCode := unpacked[0].(*big.Int)
-		// uint64 safety check for future
-		// but the code is not bigger than MAX(uint64) now
-		if pCode.IsUint64() {
-			if reason, ok := panicReasons[pCode.Uint64()]; ok {
-				return reason, nil
-			}
-		}
-		return fmt.Sprintf("unknown panic code: %#x", pCode), nil
+		return panicReason(unpacked[0].(*big.Int)), nil
 	default:
 		return "", errors.New("invalid data for unpacking")
 	}
 }
+
+// panicReason returns the human-readable description of a panic code.
+func panicReason(code *big.Int) string {
+	// uint64 safety check for future
+	// but the code is not bigger than MAX(uint64) now
+	if code.IsUint64() {
+		if reason, ok := panicReasons[code.Uint64()]; ok {
+			return reason
+		}
+	}
+	return fmt.Sprintf("unknown panic code: %#x", code)
+}
+
+// UnpackError decodes the given revert data into the name and arguments of the
+// error that caused it. The 4-byte selector is matched against the built-in
+// Error(string) and Panic(uint256) errors first, then against the custom errors
+// declared in the ABI.
+//
+// A revert via Error(string) is returned as error "Error" with the reason in
+// the "message" argument. A Panic(uint256) is returned as error "Panic" with
+// the raw "code" and its human-readable "reason".
+func (abi ABI) UnpackError(revertData []byte) (string, map[string]interface{}, error) {
+	name, inputs, values, err := abi.unpackRevert(revertData)
+	if err != nil {
+		return "", nil, err
+	}
+	args := make(map[string]interface{}, len(inputs)+1)
+	for i, input := range inputs {
+		args[input.Name] = values[i]
+	}
+	if bytes.Equal(revertData[:4], panicSelector) {
+		args["reason"] = panicReason(values[0].(*big.Int))
+	}
+	return name, args, nil
+}
@@ -24,6 +24,7 @@ import (
 	"math/big"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -1251,9 +1252,134 @@ func TestUnpackRevert(t *testing.T) {
 	}
 }
 
+func TestUnpackError(t *testing.T) {
+	t.Parallel()
+	json := `[{"inputs":[{"internalType":"address","name":"sender","type":"address"},{"internalType":"uint256","name":"balance","type":"uint256"}],"name":"InsufficientBalance","type":"error"}]`
+	abi, err := JSON(strings.NewReader(json))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Custom error declared in the ABI
+	sender := common.Address{0xaa}
+	data, err := abi.Errors["InsufficientBalance"].Inputs.Pack(sender, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := abi.Errors["InsufficientBalance"].ID
+	name, args, err := abi.UnpackError(append(id[:4:4], data...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "InsufficientBalance" {
+		t.Errorf("name mismatch: have %s, want InsufficientBalance", name)
+	}
+	if args["sender"] != sender || args["balance"].(*big.Int).Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("args mismatch: %v", args)
+	}
+	// Built-in Error(string)
+	name, args, err = abi.UnpackError(common.Hex2Bytes("08c379a00000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000d72657665727420726561736f6e00000000000000000000000000000000000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Error" || args["message"] != "revert reason" {
+		t.Errorf("unexpected revert decoding: %s %v", name, args)
+	}
+	// Built-in Panic(uint256)
+	name, args, err = abi.UnpackError(common.Hex2Bytes("4e487b710000000000000000000000000000000000000000000000000000000000000011"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Panic" || args["code"].(*big.Int).Uint64() != 0x11 || args["reason"] != "arithmetic underflow or overflow" {
+		t.Errorf("unexpected panic decoding: %s %v", name, args)
+	}
+	// Unknown selector and short data
+	if _, _, err := abi.UnpackError(common.Hex2Bytes("deadbeef")); err == nil {
+		t.Error("expected error for unknown selector")
+	}
+	if _, _, err := abi.UnpackError([]byte{0x08, 0xc3}); err == nil {
+		t.Error("expected error for short revert data")
+	}
+}
+
 func TestInternalContractType(t *testing.T) {
 	jsonData := `[{"inputs":[{"components":[{"internalType":"uint256","name":"dailyLimit","type":"uint256"},{"internalType":"uint256","name":"txLimit","type":"uint256"},{"internalType":"uint256","name":"accountDailyLimit","type":"uint256"},{"internalType":"uint256","name":"minAmount","type":"uint256"},{"internalType":"bool","name":"onlyWhitelisted","type":"bool"}],"internalType":"struct IMessagePassingBridge.BridgeLimits","name":"bridgeLimits","type":"tuple"},{"components":[{"internalType":"uint256","name":"lastTransferReset","type":"uint256"},{"internalType":"uint256","name":"bridged24Hours","type":"uint256"}],"internalType":"struct IMessagePassingBridge.AccountLimit","name":"accountDailyLimit","type":"tuple"},{"components":[{"internalType":"uint256","name":"lastTransferReset","type":"uint256"},{"internalType":"uint256","name":"bridged24Hours","type":"uint256"}],"internalType":"struct IMessagePassingBridge.BridgeDailyLimit","name":"bridgeDailyLimit","type":"tuple"},{"internalType":"contract INameService","name":"nameService","type":"INameService"},{"internalType":"bool","name":"isClosed","type":"bool"},{"internalType":"address","name":"from","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"canBridge","outputs":[{"internalType":"bool","name":"isWithinLimit","type":"bool"},{"internalType":"string","name":"error","type":"string"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint8","name":"decimals","type":"uint8"}],"name":"normalizeFrom18ToTokenDecimals","outputs":[{"internalType":"uint256","name":"normalized","type":"uint256"}],"stateMutability":"pure","type":"function"},{"inputs":[{"internalType":"uint256","name":"amount","type":"uint256"},{"internalType":"uint8","name":"decimals","type":"uint8"}],"name":"normalizeFromTokenTo18Decimals","outputs":[{"internalType":"uint256","name":"normalized","type":"uint256"}],"stateMutability":"pure","type":"function"}]`
 	if _, err := JSON(strings.NewReader(jsonData)); err != nil {
 		t.Fatal(err)
 	}
 }
+
+func TestUnpackConstructor(t *testing.T) {
+	t.Parallel()
+
+	abi, err := JSON(strings.NewReader(`[{"type":"constructor","inputs":[{"name":"owner","type":"address"},{"name":"name","type":"string"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := common.FromHex("6080604052348015600f57600080fd5b50")
+	args, err := abi.Pack("", common.HexToAddress("0x1234"), "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := abi.UnpackConstructor(append(code, args...), len(code))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0] != common.HexToAddress("0x1234") || values[1] != "token" {
+		t.Fatalf("wrong constructor arguments: %v", values)
+	}
+	// Invalid bytecode lengths and missing arguments must be rejected
+	for _, n := range []int{-1, len(code) + len(args) + 1, len(code) + len(args)} {
+		if _, err := abi.UnpackConstructor(append(code, args...), n); err == nil {
+			t.Errorf("bytecode length %d: expected error", n)
+		}
+	}
+}
+
+func TestSelectorIndex(t *testing.T) {
+	t.Parallel()
+
+	abi, err := ParseHumanReadable([]string{
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function approve(address spender, uint256 amount) returns (bool)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Secret(uint256 value) anonymous",
+		"error Unauthorized(address caller)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Lookups must be safe for concurrent use
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if m, err := abi.MethodById(abi.Methods["approve"].ID); err != nil || m.Name != "approve" {
+					t.Errorf("wrong method: %v, %v", m, err)
+				}
+				if e, err := abi.EventByTopic(abi.Events["Transfer"].ID); err != nil || e.Name != "Transfer" {
+					t.Errorf("wrong event: %v, %v", e, err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := abi.EventByTopic(abi.Events["Secret"].ID); err == nil {
+		t.Error("expected error looking up anonymous event by topic")
+	}
+	if e, err := abi.ErrorByID([4]byte(abi.Errors["Unauthorized"].ID.Bytes())); err != nil || e.Name != "Unauthorized" {
+		t.Errorf("wrong error: %v, %v", e, err)
+	}
+	// Declarations added after parsing must still be found
+	extra := NewMethod("burn", "burn", Function, "nonpayable", false, false, nil, nil)
+	abi.Methods["burn"] = extra
+	if m, err := abi.MethodById(extra.ID); err != nil || m.Name != "burn" {
+		t.Errorf("wrong method added after parsing: %v, %v", m, err)
+	}
+	delete(abi.Methods, "transfer")
+	if _, err := abi.MethodById(common.FromHex("0xa9059cbb")); err == nil {
+		t.Error("expected removed method not to be found")
+	}
+}
@@ -21,22 +21,36 @@
 package abigen
 
 import (
-	"bytes"
 	"fmt"
 	"go/format"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
-	"text/template"
 	"unicode"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 var (
-	intRegex = regexp.MustCompile(`(u)?int([0-9]*)`)
+	intRegex         = regexp.MustCompile(`(u)?int([0-9]*)`)
+	placeholderRegex = regexp.MustCompile(`__\$[0-9a-fA-F]{34}\$__`)
 )
 
+// metadataHash extracts the hash of the compiler metadata from the end of the hex
+// encoded bytecode, returning an empty string if it has none.
+func metadataHash(bytecode string) string {
+	unlinked := placeholderRegex.ReplaceAllString(bytecode, strings.Repeat("0", 40))
+	meta, err := abi.ParseBytecodeMetadata(common.FromHex(unlinked))
+	if err != nil || meta.Hash() == nil {
+		return ""
+	}
+	return hexutil.Encode(meta.Hash())
+}
+
 func isKeyWord(arg string) bool {
 	switch arg {
 	case "break":
@@ -78,8 +92,27 @@ func isKeyWord(arg string) bool {
 // to be used as is in client code, but rather as an intermediate struct which
 // enforces compile time type safety and naming convention as opposed to having to
 // manually maintain hard coded strings that break on runtime.
+//
+// Aliases rename methods, events and errors, either of all contracts when keyed
+// by the original name, or of a single one when keyed by "<type>.<name>".
 func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]string, pkg string, libs map[string]string, aliases map[string]string) (string, error) {
+	return BindWithOptions(types, abis, bytecodes, fsigs, pkg, libs, aliases, BindOptions{})
+}
+
+// BindOptions contains the optional settings of the code generation.
+type BindOptions struct {
+	// Overrides bind method arguments to different Go integer types, keyed by
+	// "<type>.<method>.<argument>", see typeOverrides.
+	Overrides map[string]string
+}
+
+// BindWithOptions generates a Go wrapper around a contract ABI like Bind, using
+// the given options.
+func BindWithOptions(types []string, abis []string, bytecodes []string, fsigs []map[string]string, pkg string, libs map[string]string, aliases map[string]string, opts BindOptions) (string, error) {
 	var (
+		// retypes tracks the requested Go type overrides of method arguments
+		retypes = newTypeOverrides(opts.Overrides)
+
 		// contracts is the map of each individual contract requested binding
 		contracts = make(map[string]*tmplContract)
 
@@ -108,6 +141,7 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			calls     = make(map[string]*tmplMethod)
 			transacts = make(map[string]*tmplMethod)
 			events    = make(map[string]*tmplEvent)
+			errors    = make(map[string]*tmplError)
 			fallback  *tmplMethod
 			receive   *tmplMethod
 
@@ -118,6 +152,7 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			callIdentifiers     = make(map[string]bool)
 			transactIdentifiers = make(map[string]bool)
 			eventIdentifiers    = make(map[string]bool)
+			errorIdentifiers    = make(map[string]bool)
 		)
 
 		for _, input := range evmABI.Constructor.Inputs {
@@ -126,10 +161,11 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			}
 		}
 
-		for _, original := range evmABI.Methods {
+		for _, name := range slices.Sorted(maps.Keys(evmABI.Methods)) {
+			original := evmABI.Methods[name]
 			// Normalize the method for capital cases and non-anonymous inputs/outputs
 			normalized := original
-			normalizedName := abi.ToCamelCase(alias(aliases, original.Name))
+			normalizedName := abi.ToCamelCase(alias(aliases, types[i], original.Name))
 			// Ensure there is no duplicated identifier
 			var identifiers = callIdentifiers
 			if !original.IsConstant() {
@@ -169,6 +205,9 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 					bindStructType(output.Type, structs)
 				}
 			}
+			if err := retypes.apply(types[i], original, &normalized); err != nil {
+				return "", err
+			}
 			// Append the methods to the call or transact lists
 			if original.IsConstant() {
 				calls[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original.Outputs)}
@@ -176,7 +215,8 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 				transacts[original.Name] = &tmplMethod{Original: original, Normalized: normalized, Structured: structured(original.Outputs)}
 			}
 		}
-		for _, original := range evmABI.Events {
+		for _, name := range slices.Sorted(maps.Keys(evmABI.Events)) {
+			original := evmABI.Events[name]
 			// Skip anonymous events as they don't support explicit filtering
 			if original.Anonymous {
 				continue
@@ -185,7 +225,7 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			normalized := original
 
 			// Ensure there is no duplicated identifier
-			normalizedName := abi.ToCamelCase(alias(aliases, original.Name))
+			normalizedName := abi.ToCamelCase(alias(aliases, types[i], original.Name))
 			// Name shouldn't start with a digit. It will make the generated code invalid.
 			if len(normalizedName) > 0 && unicode.IsDigit(rune(normalizedName[0])) {
 				normalizedName = fmt.Sprintf("E%s", normalizedName)
@@ -223,6 +263,49 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			// Append the event to the accumulator list
 			events[original.Name] = &tmplEvent{Original: original, Normalized: normalized}
 		}
+		for _, name := range slices.Sorted(maps.Keys(evmABI.Errors)) {
+			original := evmABI.Errors[name]
+			// Normalize the error for capital cases and non-anonymous inputs
+			normalized := original
+
+			// Ensure there is no duplicated identifier. Error types share the
+			// namespace of the event types, so check those too.
+			normalizedName := abi.ToCamelCase(alias(aliases, types[i], original.Name))
+			// Name shouldn't start with a digit. It will make the generated code invalid.
+			if len(normalizedName) > 0 && unicode.IsDigit(rune(normalizedName[0])) {
+				normalizedName = fmt.Sprintf("E%s", normalizedName)
+				normalizedName = abi.ResolveNameConflict(normalizedName, func(name string) bool {
+					return errorIdentifiers[name] || eventIdentifiers[name]
+				})
+			}
+			if errorIdentifiers[normalizedName] || eventIdentifiers[normalizedName] {
+				return "", fmt.Errorf("duplicated identifier \"%s\"(normalized \"%s\"), use --alias for renaming", original.Name, normalizedName)
+			}
+			errorIdentifiers[normalizedName] = true
+			normalized.Name = normalizedName
+
+			used := make(map[string]bool)
+			normalized.Inputs = make([]abi.Argument, len(original.Inputs))
+			copy(normalized.Inputs, original.Inputs)
+			for j, input := range normalized.Inputs {
+				if input.Name == "" || isKeyWord(input.Name) {
+					normalized.Inputs[j].Name = fmt.Sprintf("arg%d", j)
+				}
+				// Same as for events, the fields of the error struct must not
+				// collide after camel-casing.
+				for index := 0; ; index++ {
+					if !used[abi.ToCamelCase(normalized.Inputs[j].Name)] {
+						used[abi.ToCamelCase(normalized.Inputs[j].Name)] = true
+						break
+					}
+					normalized.Inputs[j].Name = fmt.Sprintf("%s%d", normalized.Inputs[j].Name, index)
+				}
+				if hasStruct(input.Type) {
+					bindStructType(input.Type, structs)
+				}
+			}
+			errors[original.Name] = &tmplError{Original: original, Normalized: normalized}
+		}
 		// Add two special fallback functions if they exist
 		if evmABI.HasFallback() {
 			fallback = &tmplMethod{Original: evmABI.Fallback}
@@ -241,9 +324,13 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			Fallback:    fallback,
 			Receive:     receive,
 			Events:      events,
+			Errors:      errors,
 			Libraries:   make(map[string]string),
 		}
 
+		contracts[types[i]].Linked = strings.Contains(contracts[types[i]].InputBin, "__$")
+		contracts[types[i]].MetadataHash = metadataHash(contracts[types[i]].InputBin)
+
 		// Function 4-byte signatures are stored in the same sequence
 		// as types, if available.
 		if len(fsigs) > i {
@@ -264,6 +351,9 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 			}
 		}
 	}
+	if err := retypes.checkUnused(); err != nil {
+		return "", err
+	}
 	// Check if that type has already been identified as a library
 	for i := 0; i < len(types); i++ {
 		_, ok := isLib[types[i]]
@@ -277,7 +367,6 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 		Libraries: libs,
 		Structs:   structs,
 	}
-	buffer := new(bytes.Buffer)
 
 	funcs := map[string]interface{}{
 		"bindtype":      bindType,
@@ -285,14 +374,14 @@ func Bind(types []string, abis []string, bytecodes []string, fsigs []map[string]
 		"capitalise":    abi.ToCamelCase,
 		"decapitalise":  decapitalise,
 	}
-	tmpl := template.Must(template.New("").Funcs(funcs).Parse(tmplSource))
-	if err := tmpl.Execute(buffer, data); err != nil {
+	rendered, err := render(GeneratorV1, pkg, funcs, data)
+	if err != nil {
 		return "", err
 	}
 	// Pass the code through gofmt to clean it up
-	code, err := format.Source(buffer.Bytes())
+	code, err := format.Source(rendered)
 	if err != nil {
-		return "", fmt.Errorf("%v\n%s", err, buffer)
+		return "", fmt.Errorf("%v\n%s", err, rendered)
 	}
 	return string(code), nil
 }
@@ -315,6 +404,8 @@ func bindBasicType(kind abi.Type) string {
 		return "[]byte"
 	case abi.FunctionTy:
 		return "[24]byte"
+	case abi.FixedPointTy:
+		return "abi.FixedPoint"
 	default:
 		// string, bool types
 		return kind.String()
@@ -327,7 +418,7 @@ func bindBasicType(kind abi.Type) string {
 func bindType(kind abi.Type, structs map[string]*tmplStruct) string {
 	switch kind.T {
 	case abi.TupleTy:
-		return structs[kind.TupleRawName+kind.String()].Name
+		return structs[structKey(kind)].Name
 	case abi.ArrayTy:
 		return fmt.Sprintf("[%d]", kind.Size) + bindType(*kind.Elem, structs)
 	case abi.SliceTy:
@@ -360,13 +451,7 @@ func bindTopicType(kind abi.Type, structs map[string]*tmplStruct) string {
 func bindStructType(kind abi.Type, structs map[string]*tmplStruct) string {
 	switch kind.T {
 	case abi.TupleTy:
-		// We compose a raw struct name and a canonical parameter expression
-		// together here. The reason is before solidity v0.5.11, kind.TupleRawName
-		// is empty, so we use canonical parameter expression to distinguish
-		// different struct definition. From the consideration of backward
-		// compatibility, we concat these two together so that if kind.TupleRawName
-		// is not empty, it can have unique id.
-		id := kind.TupleRawName + kind.String()
+		id := structKey(kind)
 		if s, exist := structs[id]; exist {
 			return s.Name
 		}
@@ -388,7 +473,17 @@ func bindStructType(kind abi.Type, structs map[string]*tmplStruct) string {
 		if name == "" {
 			name = fmt.Sprintf("Struct%d", len(structs))
 		}
-		name = abi.ToCamelCase(name)
+		// Distinct structs may still end up with the same Go name, e.g. file
+		// level structs of different source files, or ones declared in different
+		// versions of a contract. Disambiguate those by a numeric suffix.
+		name = abi.ResolveNameConflict(abi.ToCamelCase(name), func(name string) bool {
+			for _, s := range structs {
+				if s.Name == name {
+					return true
+				}
+			}
+			return false
+		})
 
 		structs[id] = &tmplStruct{
 			Name:   name,
@@ -404,15 +499,124 @@ func bindStructType(kind abi.Type, structs map[string]*tmplStruct) string {
 	}
 }
 
-// alias returns an alias of the given string based on the aliasing rules
-// or returns itself if no rule is matched.
-func alias(aliases map[string]string, n string) string {
+// structKey returns the identity of a struct type. It is composed of the struct
+// name taken from the internalType metadata and the canonical expression of its
+// fields, including their names. Before solidity v0.5.11 the ABI carried no
+// struct names, in which case only the fields tell structs apart.
+//
+// As the key doesn't depend on the contract the struct is used in, a struct
+// shared between several contracts is bound only once and reused by all.
+func structKey(kind abi.Type) string {
+	var key func(kind abi.Type) string
+	key = func(kind abi.Type) string {
+		switch kind.T {
+		case abi.TupleTy:
+			fields := make([]string, len(kind.TupleElems))
+			for i, elem := range kind.TupleElems {
+				fields[i] = key(*elem) + " " + kind.TupleRawNames[i]
+			}
+			return kind.TupleRawName + "(" + strings.Join(fields, ",") + ")"
+		case abi.ArrayTy:
+			return fmt.Sprintf("%s[%d]", key(*kind.Elem), kind.Size)
+		case abi.SliceTy:
+			return key(*kind.Elem) + "[]"
+		default:
+			return kind.String()
+		}
+	}
+	return key(kind)
+}
+
+// alias returns an alias of the given member of a contract based on the aliasing
+// rules or returns itself if no rule is matched. Aliases scoped to the contract
+// take precedence over global ones.
+func alias(aliases map[string]string, contract string, n string) string {
+	if alias, exist := aliases[contract+"."+n]; exist {
+		return alias
+	}
 	if alias, exist := aliases[n]; exist {
 		return alias
 	}
 	return n
 }
 
+// typeOverrides binds method arguments to Go integer types other than the ones
+// derived from their ABI types, e.g. a uint256 holding a timestamp to a uint64.
+// Overrides are keyed by "<type>.<method>.<argument>", with unnamed arguments
+// addressed by position as argN for inputs and outN for outputs. If an input
+// and an output share their name, both are overridden.
+//
+// The generated code converts the values when packing and unpacking, failing
+// the call if an output doesn't fit into the requested type.
+type typeOverrides struct {
+	types map[string]string
+	used  map[string]bool
+}
+
+func newTypeOverrides(overrides map[string]string) *typeOverrides {
+	return &typeOverrides{types: overrides, used: make(map[string]bool)}
+}
+
+// apply rebinds the arguments of the normalized method to the requested types.
+func (o *typeOverrides) apply(contract string, original abi.Method, normalized *abi.Method) error {
+	method := contract + "." + original.Name
+	if err := o.retype(method, original.Inputs, normalized.Inputs, "arg"); err != nil {
+		return err
+	}
+	return o.retype(method, original.Outputs, normalized.Outputs, "out")
+}
+
+// retype rebinds the normalized arguments of a method to the requested types.
+func (o *typeOverrides) retype(method string, original, normalized abi.Arguments, prefix string) error {
+	for j, arg := range original {
+		key := method + "." + arg.Name
+		if arg.Name == "" {
+			key = fmt.Sprintf("%s.%s%d", method, prefix, j)
+		}
+		goType, ok := o.types[key]
+		if !ok {
+			continue
+		}
+		kind, err := overrideType(arg.Type, goType)
+		if err != nil {
+			return fmt.Errorf("invalid type override %q: %v", key, err)
+		}
+		normalized[j].Type = kind
+		o.used[key] = true
+	}
+	return nil
+}
+
+// checkUnused returns an error if any of the overrides didn't match a method
+// argument, which is most likely a typo.
+func (o *typeOverrides) checkUnused() error {
+	for _, key := range slices.Sorted(maps.Keys(o.types)) {
+		if !o.used[key] {
+			return fmt.Errorf("type override %q matches no method argument", key)
+		}
+	}
+	return nil
+}
+
+// overrideType returns the ABI integer type the given Go type is bound from.
+// Only integer arguments can be overridden, to native integers or *big.Int.
+func overrideType(kind abi.Type, goType string) (abi.Type, error) {
+	if kind.T != abi.IntTy && kind.T != abi.UintTy {
+		return abi.Type{}, fmt.Errorf("%s is not an integer type", kind)
+	}
+	switch goType {
+	case "*big.Int":
+		if kind.T == abi.IntTy {
+			return abi.NewType("int256", "", nil)
+		}
+		return abi.NewType("uint256", "", nil)
+	case "int8", "int16", "int32", "int64", "uint8", "uint16", "uint32", "uint64":
+		return abi.NewType(goType, "", nil)
+	default:
+		return abi.Type{}, fmt.Errorf("unsupported Go type %s", goType)
+	}
+}
+
 // decapitalise makes a camel-case string which starts with a lower case character.
 func decapitalise(input string) string {
 	if len(input) == 0 {
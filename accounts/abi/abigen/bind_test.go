@@ -1383,6 +1383,7 @@ var bindTests = []struct {
 
 			"github.com/ethereum/go-ethereum/accounts/abi/bind"
 			"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+			"github.com/ethereum/go-ethereum/common"
 			"github.com/ethereum/go-ethereum/core/types"
 			"github.com/ethereum/go-ethereum/crypto"
 		`,
@@ -1415,6 +1416,24 @@ var bindTests = []struct {
 			if res.Cmp(big.NewInt(3)) != 0 {
 				t.Fatalf("Add did not return the correct result: %d != %d", res, 3)
 			}
+
+			// Deploy the contract against an already deployed library
+			mathAddr, _, _, err := DeployMath(auth, sim)
+			if err != nil {
+				t.Fatalf("Failed to deploy library: %v", err)
+			}
+			sim.Commit()
+			_, _, linkedContract, err := DeployUseLibraryWithLibraries(auth, sim, map[string]common.Address{"Math": mathAddr})
+			if err != nil {
+				t.Fatalf("Failed to deploy linked contract: %v", err)
+			}
+			sim.Commit()
+			if res, err = linkedContract.Add(nil, big.NewInt(2), big.NewInt(3)); err != nil {
+				t.Fatalf("Failed to call linked contract: %v", err)
+			}
+			if res.Cmp(big.NewInt(5)) != 0 {
+				t.Fatalf("Add did not return the correct result: %d != %d", res, 5)
+			}
 		`,
 		nil,
 		map[string]string{
@@ -1891,6 +1910,7 @@ var bindTests = []struct {
 		[]string{`[{"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"MyError","type":"error"},{"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"MyError1","type":"error"},{"inputs":[{"internalType":"uint256","name":"","type":"uint256"},{"internalType":"uint256","name":"","type":"uint256"}],"name":"MyError2","type":"error"},{"inputs":[{"internalType":"uint256","name":"a","type":"uint256"},{"internalType":"uint256","name":"b","type":"uint256"},{"internalType":"uint256","name":"c","type":"uint256"}],"name":"MyError3","type":"error"},{"inputs":[],"name":"Error","outputs":[],"stateMutability":"pure","type":"function"}]`},
 		`
 			"context"
+			"errors"
 			"math/big"
 
 			"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -1916,11 +1936,20 @@ var bindTests = []struct {
 			if err != nil {
 				t.Error(err)
 			}
-			if err := contract.Error(new(bind.CallOpts)); err == nil {
+			err = contract.Error(new(bind.CallOpts))
+			if err == nil {
 				t.Fatalf("expected contract to throw error")
 			}
-			// TODO (MariusVanDerWijden unpack error using abigen
-			// once that is implemented
+			var myErr *NewErrorsMyError3
+			if !errors.As(err, &myErr) {
+				t.Fatalf("error not decoded into custom error type: %v", err)
+			}
+			if myErr.A.Int64() != 1 || myErr.B.Int64() != 2 || myErr.C.Int64() != 3 {
+				t.Fatalf("custom error fields mismatch: %v", myErr)
+			}
+			if have, want := myErr.Error(), "MyError3(1, 2, 3)"; have != want {
+				t.Fatalf("error string mismatch: have %q, want %q", have, want)
+			}
 	   `,
 		nil,
 		nil,
@@ -2082,6 +2111,133 @@ var bindTests = []struct {
 			}
 `,
 	},
+	// Tests that structs shared by multiple contracts are bound once, and that
+	// distinct structs with the same name don't clash.
+	{
+		name: `SharedStructs`,
+		contract: `
+		struct SharedPoint { uint256 a; }
+
+		library SharedLib {
+			struct Point { uint256 x; uint256 y; }
+		}
+
+		contract SharedA {
+			function get() public pure returns (SharedLib.Point memory p) {}
+			function set(SharedPoint memory p) public pure {}
+		}
+
+		contract SharedB {
+			function get() public pure returns (SharedLib.Point memory p) {}
+		}
+
+		// SharedC lives in a different source file, declaring its own SharedPoint:
+		//
+		// struct SharedPoint { address b; }
+		// contract SharedC { function set(SharedPoint memory p) public pure {} }
+		`,
+		bytecode: []string{"", "", ""},
+		abi: []string{
+			`[{"inputs":[],"name":"get","outputs":[{"components":[{"internalType":"uint256","name":"x","type":"uint256"},{"internalType":"uint256","name":"y","type":"uint256"}],"internalType":"struct SharedLib.Point","name":"","type":"tuple"}],"stateMutability":"pure","type":"function"},{"inputs":[{"components":[{"internalType":"uint256","name":"a","type":"uint256"}],"internalType":"struct SharedPoint","name":"p","type":"tuple"}],"name":"set","outputs":[],"stateMutability":"pure","type":"function"}]`,
+			`[{"inputs":[],"name":"get","outputs":[{"components":[{"internalType":"uint256","name":"x","type":"uint256"},{"internalType":"uint256","name":"y","type":"uint256"}],"internalType":"struct SharedLib.Point","name":"","type":"tuple"}],"stateMutability":"pure","type":"function"}]`,
+			`[{"inputs":[{"components":[{"internalType":"address","name":"b","type":"address"}],"internalType":"struct SharedPoint","name":"p","type":"tuple"}],"name":"set","outputs":[],"stateMutability":"pure","type":"function"}]`,
+		},
+		imports: `
+			"math/big"
+
+			"github.com/ethereum/go-ethereum/common"
+		`,
+		tester: `
+			var (
+				a *SharedACaller
+				b *SharedBCaller
+			)
+			// Both contracts must return the very same struct type
+			_ = func() []SharedLibPoint {
+				p1, _ := a.Get(nil)
+				p2, _ := b.Get(nil)
+				return []SharedLibPoint{p1, p2}
+			}
+			// Distinct structs of the same name are disambiguated
+			_ = SharedPoint{A: big.NewInt(1)}
+			_ = SharedPoint0{B: common.Address{}}
+		`,
+		types: []string{"SharedA", "SharedB", "SharedC"},
+	},
+	// Tests that view calls can be batched through Multicall3.
+	{
+		name: `Multicaller`,
+		contract: `
+		contract Multicaller {
+			function balanceOf(address owner) public view returns (uint256) {}
+			function info() public view returns (string memory name, uint8 decimals) {}
+			function pair() public view returns (uint256, uint256) {}
+		}
+		`,
+		bytecode: []string{""},
+		abi: []string{
+			`[{"inputs":[{"internalType":"address","name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"info","outputs":[{"internalType":"string","name":"name","type":"string"},{"internalType":"uint8","name":"decimals","type":"uint8"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"pair","outputs":[{"internalType":"uint256","name":"","type":"uint256"},{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`,
+		},
+		imports: `
+			"math/big"
+
+			"github.com/ethereum/go-ethereum/accounts/abi/bind"
+			"github.com/ethereum/go-ethereum/common"
+		`,
+		tester: `
+			batch := bind.NewMulticallBatch(nil)
+			multi, err := NewMulticallerMulticall(common.Address{}, batch)
+			if err != nil {
+				t.Fatalf("Failed to create multicall facade: %v", err)
+			}
+			var (
+				balance *bind.MulticallValue[*big.Int]                               = multi.BalanceOf(common.Address{})
+				info    *bind.MulticallValue[struct{ Name string; Decimals uint8 }] = multi.Info()
+			)
+			if batch.Len() != 2 {
+				t.Fatalf("Batch size mismatch: have %d, want 2", batch.Len())
+			}
+			if _, err := balance.Get(); err != bind.ErrMulticallPending {
+				t.Fatalf("Unexecuted call result mismatch: have %v, want %v", err, bind.ErrMulticallPending)
+			}
+			if _, err := info.Get(); err != bind.ErrMulticallPending {
+				t.Fatalf("Unexecuted call result mismatch: have %v, want %v", err, bind.ErrMulticallPending)
+			}
+		`,
+	},
+	// Tests that the contract interfaces are implemented by the bindings.
+	{
+		name: `Mockable`,
+		contract: `
+		contract Mockable {
+			event Changed(address indexed who, uint256 value);
+			function get() public view returns (uint256) {}
+			function set(uint256 value) public {}
+		}
+		`,
+		bytecode: []string{""},
+		abi: []string{
+			`[{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"who","type":"address"},{"indexed":false,"internalType":"uint256","name":"value","type":"uint256"}],"name":"Changed","type":"event"},{"inputs":[],"name":"get","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"internalType":"uint256","name":"value","type":"uint256"}],"name":"set","outputs":[],"stateMutability":"nonpayable","type":"function"}]`,
+		},
+		imports: `
+			"github.com/ethereum/go-ethereum/common"
+		`,
+		tester: `
+			var contract MockableAPI
+			contract, err := NewMockable(common.Address{}, nil)
+			if err != nil {
+				t.Fatalf("Failed to bind contract: %v", err)
+			}
+			var (
+				_ MockableCallerAPI     = contract
+				_ MockableTransactorAPI = contract
+				_ MockableFiltererAPI   = contract
+			)
+			// Mocks only need to implement the methods used
+			var mock MockableAPI = struct{ MockableAPI }{}
+			_ = mock
+		`,
+	},
 }
 
 // Tests that packages generated by the binder can be successfully compiled and
@@ -2161,3 +2317,55 @@ func TestBindings(t *testing.T) {
 		t.Fatalf("failed to run binding test: %v\n%s", err, out)
 	}
 }
+
+// Tests that method arguments can be bound to overridden Go integer types and
+// that methods can be renamed per contract.
+func TestBindTypeOverrides(t *testing.T) {
+	t.Parallel()
+
+	var (
+		types = []string{"Clock", "Timer"}
+		abis  = []string{
+			`[{"type":"function","name":"schedule","stateMutability":"nonpayable","inputs":[{"name":"at","type":"uint256"}],"outputs":[]},{"type":"function","name":"now","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]}]`,
+			`[{"type":"function","name":"now","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint64"}]}]`,
+		}
+		bins    = []string{"", ""}
+		aliases = map[string]string{"Timer.now": "current"}
+	)
+	code, err := BindWithOptions(types, abis, bins, nil, "bindtest", nil, aliases, BindOptions{Overrides: map[string]string{
+		"Clock.schedule.at": "uint64",
+		"Clock.now.out0":    "uint64",
+		"Timer.now.out0":    "*big.Int",
+	}})
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	for _, want := range []string{
+		"func (_Clock *ClockTransactor) Schedule(opts *bind.TransactOpts, at uint64) (*types.Transaction, error)",
+		"func (_Clock *ClockCaller) Now(opts *bind.CallOpts) (uint64, error)",
+		"func (_Timer *TimerCaller) Current(opts *bind.CallOpts) (*big.Int, error)",
+		"conv0, err := abi.TryConvertType(out[0], new(uint64))", // Overflows fail the call
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("binding misses %q", want)
+		}
+	}
+	// The same overrides apply to the v2 bindings
+	code, err = BindV2WithOptions(types, abis, bins, "bindtest", nil, aliases, BindOptions{Overrides: map[string]string{"Clock.schedule.at": "uint64"}})
+	if err != nil {
+		t.Fatalf("failed to generate v2 binding: %v", err)
+	}
+	if want := "func (clock *Clock) PackSchedule(at uint64) []byte"; !strings.Contains(code, want) {
+		t.Errorf("v2 binding misses %q", want)
+	}
+	// Invalid and unused overrides are rejected
+	for _, overrides := range []map[string]string{
+		{"Clock.schedule.at": "string"},
+		{"Clock.schedule.when": "uint64"},
+		{"Timer.now.out0": "float64"},
+	} {
+		if _, err := BindWithOptions(types, abis, bins, nil, "bindtest", nil, nil, BindOptions{Overrides: overrides}); err == nil {
+			t.Errorf("override %v accepted", overrides)
+		}
+	}
+}
@@ -17,7 +17,6 @@
 package abigen
 
 import (
-	"bytes"
 	"fmt"
 	"go/format"
 	"reflect"
@@ -25,7 +24,6 @@ import (
 	"slices"
 	"sort"
 	"strings"
-	"text/template"
 	"unicode"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -91,6 +89,9 @@ type binder struct {
 	// to specified values. it is keyed by source symbol name, and values are
 	// what the replacement name should be.
 	aliases map[string]string
+
+	// overrides tracks the requested Go type overrides of method arguments.
+	overrides *typeOverrides
 }
 
 // BindStructType registers the type to be emitted as a struct in the
@@ -103,7 +104,8 @@ func (b *binder) BindStructType(typ abi.Type) {
 // registry for compiling maps of identifiers that will be emitted in generated
 // bindings.
 type contractBinder struct {
-	binder *binder
+	binder   *binder
+	contract string // type name of the contract being bound
 
 	// all maps are keyed by the original (non-normalized) name of the symbol in question
 	// from the provided ABI definition.
@@ -115,9 +117,10 @@ type contractBinder struct {
 	errorIdentifiers map[string]bool
 }
 
-func newContractBinder(binder *binder) *contractBinder {
+func newContractBinder(binder *binder, contract string) *contractBinder {
 	return &contractBinder{
 		binder,
+		contract,
 		make(map[string]*tmplMethod),
 		make(map[string]*tmplEvent),
 		make(map[string]*tmplError),
@@ -131,7 +134,7 @@ func newContractBinder(binder *binder) *contractBinder {
 // from snake to camel-case), and registers the normalized name in the specified identifier map.
 // It returns an error if the normalized name already exists in the map.
 func (cb *contractBinder) registerIdentifier(identifiers map[string]bool, original string) (normalized string, err error) {
-	normalized = abi.ToCamelCase(alias(cb.binder.aliases, original))
+	normalized = abi.ToCamelCase(alias(cb.binder.aliases, cb.contract, original))
 
 	// Name shouldn't start with a digit. It will make the generated code invalid.
 	if len(normalized) > 0 && unicode.IsDigit(rune(normalized[0])) {
@@ -173,6 +176,9 @@ func (cb *contractBinder) bindMethod(original abi.Method) error {
 		}
 	}
 
+	if err := cb.binder.overrides.apply(cb.contract, original, &normalized); err != nil {
+		return err
+	}
 	var isStructured bool
 	// If the call returns multiple values, gather them into a struct
 	if len(normalized.Outputs) > 1 {
@@ -294,11 +300,20 @@ func iterSorted[V any](inp map[string]V, onItem func(string, V) error) error {
 // to be used as is in client code, but rather as an intermediate struct which
 // enforces compile time type safety and naming convention as opposed to having to
 // manually maintain hard coded strings that break on runtime.
+//
+// Aliases are applied the same way as by Bind.
 func BindV2(types []string, abis []string, bytecodes []string, pkg string, libs map[string]string, aliases map[string]string) (string, error) {
+	return BindV2WithOptions(types, abis, bytecodes, pkg, libs, aliases, BindOptions{})
+}
+
+// BindV2WithOptions generates a Go wrapper around a contract ABI like BindV2,
+// using the given options.
+func BindV2WithOptions(types []string, abis []string, bytecodes []string, pkg string, libs map[string]string, aliases map[string]string, opts BindOptions) (string, error) {
 	b := binder{
 		contracts: make(map[string]*tmplContractV2),
 		structs:   make(map[string]*tmplStruct),
 		aliases:   aliases,
+		overrides: newTypeOverrides(opts.Overrides),
 	}
 	for i := 0; i < len(types); i++ {
 		// Parse the actual ABI to generate the binding for
@@ -313,7 +328,7 @@ func BindV2(types []string, abis []string, bytecodes []string, pkg string, libs
 			}
 		}
 
-		cb := newContractBinder(&b)
+		cb := newContractBinder(&b, types[i])
 		err = iterSorted(evmABI.Methods, func(_ string, original abi.Method) error {
 			return cb.bindMethod(original)
 		})
@@ -334,6 +349,9 @@ func BindV2(types []string, abis []string, bytecodes []string, pkg string, libs
 		}
 		b.contracts[types[i]] = newTmplContractV2(types[i], abis[i], bytecodes[i], evmABI.Constructor, cb)
 	}
+	if err := b.overrides.checkUnused(); err != nil {
+		return "", err
+	}
 
 	invertedLibs := make(map[string]string)
 	for pattern, name := range libs {
@@ -351,7 +369,6 @@ func BindV2(types []string, abis []string, bytecodes []string, pkg string, libs
 			data.Contracts[typ].Libraries[libs[depPattern]] = depPattern
 		}
 	}
-	buffer := new(bytes.Buffer)
 	funcs := map[string]interface{}{
 		"bindtype":           bindType,
 		"bindtopictype":      bindTopicType,
@@ -360,14 +377,14 @@ func BindV2(types []string, abis []string, bytecodes []string, pkg string, libs
 		"ispointertype":      isPointerType,
 		"underlyingbindtype": underlyingBindType,
 	}
-	tmpl := template.Must(template.New("").Funcs(funcs).Parse(tmplSourceV2))
-	if err := tmpl.Execute(buffer, data); err != nil {
+	rendered, err := render(GeneratorV2, pkg, funcs, data)
+	if err != nil {
 		return "", err
 	}
 	// Pass the code through gofmt to clean it up
-	code, err := format.Source(buffer.Bytes())
+	code, err := format.Source(rendered)
 	if err != nil {
-		return "", fmt.Errorf("%v\n%s", err, buffer)
+		return "", fmt.Errorf("%v\n%s", err, rendered)
 	}
 	return string(code), nil
 }
@@ -0,0 +1,182 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abigen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"text/template"
+)
+
+// Generator identifies the binding generator a plugin applies to.
+type Generator int
+
+const (
+	GeneratorV1 Generator = iota + 1 // Bindings generated by Bind
+	GeneratorV2                      // Bindings generated by BindV2
+)
+
+// String implements fmt.Stringer.
+func (g Generator) String() string {
+	switch g {
+	case GeneratorV1:
+		return "v1"
+	case GeneratorV2:
+		return "v2"
+	default:
+		return fmt.Sprintf("Generator(%d)", int(g))
+	}
+}
+
+// Plugin customizes the bindings produced by a generator. Plugins are applied in
+// the order they were registered.
+//
+// The templates of plugins are rendered with the same data as the builtin one,
+// which can be consulted in Template for the available fields.
+type Plugin struct {
+	Name string // Unique name of the plugin, used in errors
+
+	// Source replaces the builtin template the bindings are rendered from. Only
+	// one of the registered plugins may replace it.
+	Source string
+
+	// Template is rendered after the bindings into the same file. Templates of
+	// the bindings may be redefined with {{define}} actions.
+	Template string
+
+	// Funcs are functions made available to all templates, in addition to the
+	// builtin ones such as bindtype and capitalise.
+	Funcs template.FuncMap
+
+	// Process post-processes the rendered bindings of the given package, before
+	// they are formatted. It may e.g. parse the code and wrap every call with
+	// tracing spans.
+	Process func(pkg string, code []byte) ([]byte, error)
+}
+
+var (
+	pluginsLock sync.RWMutex
+	plugins     = make(map[Generator][]*Plugin)
+)
+
+// RegisterPlugin registers a plugin to be applied by the given generator to all
+// bindings generated afterwards.
+func RegisterPlugin(gen Generator, plugin Plugin) error {
+	if gen != GeneratorV1 && gen != GeneratorV2 {
+		return fmt.Errorf("unknown generator %v", gen)
+	}
+	if plugin.Name == "" {
+		return errors.New("plugin without name")
+	}
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+
+	for _, p := range plugins[gen] {
+		if p.Name == plugin.Name {
+			return fmt.Errorf("plugin %q already registered", plugin.Name)
+		}
+		if p.Source != "" && plugin.Source != "" {
+			return fmt.Errorf("plugin %q replaces the template already replaced by %q", plugin.Name, p.Name)
+		}
+	}
+	plugins[gen] = append(plugins[gen], &plugin)
+	return nil
+}
+
+// UnregisterPlugin removes the plugin with the given name from the generator,
+// reporting whether it was registered.
+func UnregisterPlugin(gen Generator, name string) bool {
+	pluginsLock.Lock()
+	defer pluginsLock.Unlock()
+
+	for i, p := range plugins[gen] {
+		if p.Name == name {
+			plugins[gen] = append(plugins[gen][:i:i], plugins[gen][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Template returns the builtin template of the given generator, as a starting
+// point for replacements.
+func Template(gen Generator) string {
+	switch gen {
+	case GeneratorV1:
+		return tmplSource
+	case GeneratorV2:
+		return tmplSourceV2
+	default:
+		return ""
+	}
+}
+
+// render renders the bindings from the template of the given generator, applying
+// the registered plugins.
+func render(gen Generator, pkg string, funcs template.FuncMap, data any) ([]byte, error) {
+	pluginsLock.RLock()
+	active := plugins[gen]
+	pluginsLock.RUnlock()
+
+	source := Template(gen)
+	for _, p := range active {
+		if p.Source != "" {
+			source = p.Source
+		}
+		for name, fn := range p.Funcs {
+			funcs[name] = fn
+		}
+	}
+	tmpl, err := template.New("").Funcs(funcs).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	// Plugin templates are parsed into the same set, so that they can redefine
+	// the nested templates of the bindings
+	var extensions []*template.Template
+	for _, p := range active {
+		if p.Template == "" {
+			continue
+		}
+		ext, err := tmpl.New(p.Name).Parse(p.Template)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %q: %v", p.Name, err)
+		}
+		extensions = append(extensions, ext)
+	}
+	buffer := new(bytes.Buffer)
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return nil, err
+	}
+	for _, ext := range extensions {
+		if err := ext.Execute(buffer, data); err != nil {
+			return nil, fmt.Errorf("plugin %q: %v", ext.Name(), err)
+		}
+	}
+	code := buffer.Bytes()
+	for _, p := range active {
+		if p.Process == nil {
+			continue
+		}
+		if code, err = p.Process(pkg, code); err != nil {
+			return nil, fmt.Errorf("plugin %q: %v", p.Name, err)
+		}
+	}
+	return code, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abigen
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestPlugins tests that registered plugins extend and post-process the
+// generated bindings. It can't run in parallel with the other tests, since
+// plugins apply to all bindings generated while they're registered.
+func TestPlugins(t *testing.T) {
+	abis := []string{`[{"inputs":[],"name":"get","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`}
+
+	plugin := Plugin{
+		Name:     "test",
+		Template: `{{range $name, $_ := .Contracts}}func {{shout $name}}() {}{{end}}`,
+		Funcs: map[string]any{
+			"shout": strings.ToUpper,
+		},
+		Process: func(pkg string, code []byte) ([]byte, error) {
+			return bytes.ReplaceAll(code, []byte("func STORAGE()"), []byte("func "+pkg+"Storage()")), nil
+		},
+	}
+	if err := RegisterPlugin(GeneratorV2, plugin); err != nil {
+		t.Fatalf("failed to register plugin: %v", err)
+	}
+	defer UnregisterPlugin(GeneratorV2, plugin.Name)
+
+	if err := RegisterPlugin(GeneratorV2, plugin); err == nil {
+		t.Fatal("plugin registered twice")
+	}
+	code, err := BindV2([]string{"Storage"}, abis, []string{""}, "bindings", nil, nil)
+	if err != nil {
+		t.Fatalf("failed to generate bindings: %v", err)
+	}
+	if !strings.Contains(code, "func bindingsStorage() {}") {
+		t.Fatalf("plugin not applied:\n%s", code)
+	}
+	// Plugins of the other generator don't apply
+	code, err = Bind([]string{"Storage"}, abis, []string{""}, nil, "bindings", nil, nil)
+	if err != nil {
+		t.Fatalf("failed to generate bindings: %v", err)
+	}
+	if strings.Contains(code, "Storage() {}") {
+		t.Fatal("plugin applied to other generator")
+	}
+	// The builtin template can be replaced only once
+	replacement := Plugin{Name: "replacement", Source: "package {{.Package}}\n"}
+	if err := RegisterPlugin(GeneratorV2, replacement); err != nil {
+		t.Fatalf("failed to register replacement: %v", err)
+	}
+	defer UnregisterPlugin(GeneratorV2, replacement.Name)
+
+	if err := RegisterPlugin(GeneratorV2, Plugin{Name: "other", Source: "package other\n"}); err == nil {
+		t.Fatal("template replaced twice")
+	}
+	code, err = BindV2([]string{"Storage"}, abis, []string{""}, "bindings", nil, nil)
+	if err != nil {
+		t.Fatalf("failed to generate bindings: %v", err)
+	}
+	if want := "package bindings\n\nfunc bindingsStorage() {}\n"; code != want {
+		t.Fatalf("replaced template output mismatch: have %q, want %q", code, want)
+	}
+}
@@ -4,6 +4,8 @@
 package {{.Package}}
 
 import (
+	"bytes"
+	"fmt"
 	"math/big"
 	"strings"
 	"errors"
@@ -19,6 +21,8 @@ import (
 // Reference imports to suppress errors if they are not otherwise used.
 var (
 	_ = errors.New
+	_ = bytes.Equal
+	_ = fmt.Errorf
 	_ = big.NewInt
 	_ = strings.NewReader
 	_ = ethereum.NotFound
@@ -68,7 +72,13 @@ var (
 		var {{.Type}}Bin = {{.Type}}MetaData.Bin
 
 		// Deploy{{.Type}} deploys a new Ethereum contract, binding an instance of {{.Type}} to it.
+		{{- if .Linked}}
+		// The libraries linked by the contract are deployed beforehand.
+		{{- end}}
 		func Deploy{{.Type}}(auth *bind.TransactOpts, backend bind.ContractBackend {{range .Constructor.Inputs}}, {{.Name}} {{bindtype .Type $structs}}{{end}}) (common.Address, *types.Transaction, *{{.Type}}, error) {
+		  {{if .Linked -}}
+		  return Deploy{{.Type}}WithLibraries(auth, backend, nil {{range .Constructor.Inputs}}, {{.Name}}{{end}})
+		  {{- else -}}
 		  parsed, err := {{.Type}}MetaData.GetAbi()
 		  if err != nil {
 		    return common.Address{}, nil, nil, err
@@ -76,16 +86,85 @@ var (
 		  if parsed == nil {
 			return common.Address{}, nil, nil, errors.New("GetABI returned nil")
 		  }
+		  address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex({{.Type}}Bin), backend {{range .Constructor.Inputs}}, {{.Name}}{{end}})
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+		  {{- end}}
+		}
+
+		{{if .MetadataHash}}
+		// {{.Type}}MetadataHash is the hash of the compiler metadata embedded in the {{.Type}} bytecode,
+		// identifying the sources and settings the contract was compiled from.
+		const {{.Type}}MetadataHash = "{{.MetadataHash}}"
+		{{end}}
+
+		// Verify{{.Type}} checks that the code deployed at the given address is the runtime code of {{.Type}},
+		// ignoring the compiler metadata and the values of immutable variables and linked libraries.
+		func Verify{{.Type}}(opts *bind.CallOpts, backend bind.ContractCaller, address common.Address) error {
+		  return bind.VerifyCode(opts, backend, address, {{.Type}}Bin)
+		}
+
+		{{if not .Linked}}
+		// Deploy{{.Type}}2 deploys a new Ethereum contract through a CREATE2 factory, binding an instance
+		// of {{.Type}} to it. The contract address only depends on the factory, the salt and the deployment
+		// code, so it's the same on every chain. A zero factory address selects bind.DefaultCreate2Factory.
+		func Deploy{{.Type}}2(auth *bind.TransactOpts, backend bind.ContractBackend, factory common.Address, salt common.Hash {{range .Constructor.Inputs}}, {{.Name}} {{bindtype .Type $structs}}{{end}}) (common.Address, *types.Transaction, *{{.Type}}, error) {
+		  parsed, err := {{.Type}}MetaData.GetAbi()
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  if parsed == nil {
+			return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+		  }
+		  address, tx, contract, err := bind.DeployContract2(auth, factory, salt, *parsed, common.FromHex({{.Type}}Bin), backend {{range .Constructor.Inputs}}, {{.Name}}{{end}})
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
+		}
+		{{end}}
+
+		{{if .Linked}}
+		// Deploy{{.Type}}WithLibraries deploys a new Ethereum contract, binding an instance of {{.Type}} to it.
+		// The bytecode is linked against the given library addresses, keyed by library name. Libraries
+		// not bound alongside the contract are keyed by fully qualified name (<sourcePath>:<library>)
+		// or link pattern instead. Bound libraries missing from the map are deployed beforehand.
+		func Deploy{{.Type}}WithLibraries(auth *bind.TransactOpts, backend bind.ContractBackend, libraries map[string]common.Address {{range .Constructor.Inputs}}, {{.Name}} {{bindtype .Type $structs}}{{end}}) (common.Address, *types.Transaction, *{{.Type}}, error) {
+		  parsed, err := {{.Type}}MetaData.GetAbi()
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  if parsed == nil {
+			return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+		  }
+		  links := make(map[string]common.Address)
+		  for name, addr := range libraries {
+			links[name] = addr
+		  }
 		  {{range $pattern, $name := .Libraries}}
-			{{decapitalise $name}}Addr, _, _, _ := Deploy{{capitalise $name}}(auth, backend)
-			{{$contract.Type}}Bin = strings.ReplaceAll({{$contract.Type}}Bin, "__${{$pattern}}$__", {{decapitalise $name}}Addr.String()[2:])
+		  if addr, ok := libraries["{{$name}}"]; ok {
+			links["{{$pattern}}"] = addr
+		  } else {
+			addr, _, _, err := Deploy{{capitalise $name}}(auth, backend)
+			if err != nil {
+			  return common.Address{}, nil, nil, err
+			}
+			links["{{$pattern}}"] = addr
+		  }
 		  {{end}}
-		  address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex({{.Type}}Bin), backend {{range .Constructor.Inputs}}, {{.Name}}{{end}})
+		  bin, err := bind.LinkBytecode({{.Type}}Bin, links)
+		  if err != nil {
+		    return common.Address{}, nil, nil, err
+		  }
+		  address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(bin), backend {{range .Constructor.Inputs}}, {{.Name}}{{end}})
 		  if err != nil {
 		    return common.Address{}, nil, nil, err
 		  }
 		  return address, tx, &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
 		}
+		{{end}}
 	{{end}}
 
 	// {{.Type}} is an auto generated Go binding around an Ethereum contract.
@@ -147,6 +226,55 @@ var (
 		Contract *{{.Type}}Transactor // Generic write-only contract binding to access the raw methods on
 	}
 
+	// {{.Type}}CallerAPI is the interface of the read-only methods of {{.Type}}Caller,
+	// allowing the contract to be mocked in tests.
+	type {{.Type}}CallerAPI interface {
+	{{- range .Calls}}
+		{{.Normalized.Name}}(opts *bind.CallOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) ({{if .Structured}}struct{ {{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}};{{end}} },{{else}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}},{{end}}{{end}} error)
+	{{- end}}
+	}
+
+	// {{.Type}}TransactorAPI is the interface of the write-only methods of {{.Type}}Transactor,
+	// allowing the contract to be mocked in tests.
+	type {{.Type}}TransactorAPI interface {
+	{{- range .Transacts}}
+		{{.Normalized.Name}}(opts *bind.TransactOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) (*types.Transaction, error)
+		EstimateGas{{.Normalized.Name}}(opts *bind.TransactOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) (uint64, error)
+		CreateAccessList{{.Normalized.Name}}(opts *bind.TransactOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) (*bind.AccessListResult, error)
+	{{- end}}
+	{{- if .Fallback}}
+		Fallback(opts *bind.TransactOpts, calldata []byte) (*types.Transaction, error)
+	{{- end}}
+	{{- if .Receive}}
+		Receive(opts *bind.TransactOpts) (*types.Transaction, error)
+	{{- end}}
+	}
+
+	// {{.Type}}FiltererAPI is the interface of the log filtering methods of {{.Type}}Filterer,
+	// allowing the contract to be mocked in tests.
+	type {{.Type}}FiltererAPI interface {
+	{{- range .Events}}
+		Filter{{.Normalized.Name}}(opts *bind.FilterOpts{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}} []{{bindtype .Type $structs}}{{end}}{{end}}) (*{{$contract.Type}}{{.Normalized.Name}}Iterator, error)
+		Watch{{.Normalized.Name}}(opts *bind.WatchOpts, sink chan<- *{{$contract.Type}}{{.Normalized.Name}}{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}} []{{bindtype .Type $structs}}{{end}}{{end}}) (event.Subscription, error)
+		Parse{{.Normalized.Name}}(log types.Log) (*{{$contract.Type}}{{.Normalized.Name}}, error)
+	{{- end}}
+	}
+
+	// {{.Type}}API is the interface of all methods of {{.Type}}, allowing application code
+	// to depend on it rather than the concrete binding, and the contract to be mocked in tests.
+	type {{.Type}}API interface {
+	  {{.Type}}CallerAPI
+	  {{.Type}}TransactorAPI
+	  {{.Type}}FiltererAPI
+	}
+
+	var (
+	  _ {{.Type}}API           = (*{{.Type}})(nil)
+	  _ {{.Type}}CallerAPI     = (*{{.Type}}Caller)(nil)
+	  _ {{.Type}}TransactorAPI = (*{{.Type}}Transactor)(nil)
+	  _ {{.Type}}FiltererAPI   = (*{{.Type}}Filterer)(nil)
+	)
+
 	// New{{.Type}} creates a new instance of {{.Type}}, bound to a specific deployed contract.
 	func New{{.Type}}(address common.Address, backend bind.ContractBackend) (*{{.Type}}, error) {
 	  contract, err := bind{{.Type}}(address, backend, backend, backend)
@@ -156,6 +284,21 @@ var (
 	  return &{{.Type}}{ {{.Type}}Caller: {{.Type}}Caller{contract: contract}, {{.Type}}Transactor: {{.Type}}Transactor{contract: contract}, {{.Type}}Filterer: {{.Type}}Filterer{contract: contract} }, nil
 	}
 
+	// New{{.Type}}ThroughProxy creates a new instance of {{.Type}}, bound to a proxy contract
+	// delegating to an implementation of {{.Type}}. Calls, transactions and event filters all
+	// target the proxy, since that is the address the events are emitted with.
+	func New{{.Type}}ThroughProxy(opts *bind.CallOpts, proxy common.Address, backend bind.ProxyBackend) (*{{.Type}}, *bind.ProxyInfo, error) {
+	  info, err := bind.DetectProxy(opts, backend, proxy)
+	  if err != nil {
+	    return nil, nil, err
+	  }
+	  contract, err := New{{.Type}}(proxy, backend)
+	  if err != nil {
+	    return nil, nil, err
+	  }
+	  return contract, info, nil
+	}
+
 	// New{{.Type}}Caller creates a new read-only instance of {{.Type}}, bound to a specific deployed contract.
 	func New{{.Type}}Caller(address common.Address, caller bind.ContractCaller) (*{{.Type}}Caller, error) {
 	  contract, err := bind{{.Type}}(address, caller, nil, nil)
@@ -230,20 +373,27 @@ var (
 		return _{{$contract.Type}}.Contract.contract.Transact(opts, method, params...)
 	}
 
-	{{range .Calls}}
+	{{range $call := .Calls}}
 		// {{.Normalized.Name}} is a free data retrieval call binding the contract method 0x{{printf "%x" .Original.ID}}.
 		//
 		// Solidity: {{.Original.String}}
 		func (_{{$contract.Type}} *{{$contract.Type}}Caller) {{.Normalized.Name}}(opts *bind.CallOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) ({{if .Structured}}struct{ {{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}};{{end}} },{{else}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}},{{end}}{{end}} error) {
 			var out []interface{}
 			err := _{{$contract.Type}}.contract.Call(opts, &out, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			{{if $contract.Errors}}err = unpack{{$contract.Type}}Error(err){{end}}
 			{{if .Structured}}
 			outstruct := new(struct{ {{range .Normalized.Outputs}} {{.Name}} {{bindtype .Type $structs}}; {{end}} })
 			if err != nil {
 				return *outstruct, err
 			}
 			{{range $i, $t := .Normalized.Outputs}}
-			outstruct.{{.Name}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}
+			{{if $call.Retyped $i}}
+			conv{{$i}}, err := abi.TryConvertType(out[{{$i}}], new({{bindtype .Type $structs}}))
+			if err != nil {
+				return *outstruct, err
+			}
+			outstruct.{{.Name}} = *conv{{$i}}.(*{{bindtype .Type $structs}}){{else}}
+			outstruct.{{.Name}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}{{end}}
 
 			return *outstruct, err
 			{{else}}
@@ -251,7 +401,13 @@ var (
 				return {{range $i, $_ := .Normalized.Outputs}}*new({{bindtype .Type $structs}}), {{end}} err
 			}
 			{{range $i, $t := .Normalized.Outputs}}
-			out{{$i}} := *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}
+			{{if $call.Retyped $i}}
+			conv{{$i}}, err := abi.TryConvertType(out[{{$i}}], new({{bindtype .Type $structs}}))
+			if err != nil {
+				return {{range $call.Normalized.Outputs}}*new({{bindtype .Type $structs}}), {{end}} err
+			}
+			out{{$i}} := *conv{{$i}}.(*{{bindtype .Type $structs}}){{else}}
+			out{{$i}} := *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}{{end}}
 
 			return {{range $i, $t := .Normalized.Outputs}}out{{$i}}, {{end}} err
 			{{end}}
@@ -272,12 +428,78 @@ var (
 		}
 	{{end}}
 
+	{{if .Calls}}
+	// {{.Type}}Multicall is an auto generated Go binding around an Ethereum contract,
+	// enqueueing its view calls into a Multicall3 batch instead of executing them.
+	// Methods with multiple unnamed return values are not available for batching.
+	type {{.Type}}Multicall struct {
+	  address common.Address       // Address of the contract the calls are made to
+	  abi     abi.ABI              // ABI of the contract to pack the calls with
+	  batch   *bind.MulticallBatch // Batch to enqueue the calls into
+	}
+
+	// New{{.Type}}Multicall creates a new batching instance of {{.Type}}, bound to a specific deployed contract.
+	func New{{.Type}}Multicall(address common.Address, batch *bind.MulticallBatch) (*{{.Type}}Multicall, error) {
+	  parsed, err := {{.Type}}MetaData.GetAbi()
+	  if err != nil {
+	    return nil, err
+	  }
+	  return &{{.Type}}Multicall{address: address, abi: *parsed, batch: batch}, nil
+	}
+	{{end}}
+
+	{{range $call := .Calls}}
+	{{if or .Structured (eq (len .Normalized.Outputs) 1)}}
+		// {{.Normalized.Name}} enqueues a free data retrieval call binding the contract method 0x{{printf "%x" .Original.ID}}
+		// into the batch. The result is available once the batch is executed.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Multicall) {{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{bindtype .Type $structs}} {{end}}) *bind.MulticallValue[{{if .Structured}}struct{ {{range .Normalized.Outputs}}{{.Name}} {{bindtype .Type $structs}};{{end}} }{{else}}{{range .Normalized.Outputs}}{{bindtype .Type $structs}}{{end}}{{end}}] {
+			call := abi.MulticallCall{Target: _{{$contract.Type}}.address, AllowFailure: true, ABI: _{{$contract.Type}}.abi, Method: "{{.Original.Name}}", Args: []interface{}{ {{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}}{{end}} }}
+			{{- if .Structured}}
+			return bind.AddMulticall(_{{$contract.Type}}.batch, call, func(out []interface{}) (outstruct struct{ {{range .Normalized.Outputs}}
+				{{.Name}} {{bindtype .Type $structs}}{{end}}
+			}, err error) { {{range $i, $t := .Normalized.Outputs}}
+				{{- if $call.Retyped $i}}
+				conv{{$i}}, err := abi.TryConvertType(out[{{$i}}], new({{bindtype .Type $structs}}))
+				if err != nil {
+					return outstruct, err
+				}
+				outstruct.{{.Name}} = *conv{{$i}}.(*{{bindtype .Type $structs}}){{else}}
+				outstruct.{{.Name}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}{{end}}
+
+				return outstruct, nil
+			})
+			{{- else}}
+			return bind.AddMulticall(_{{$contract.Type}}.batch, call, func(out []interface{}) ({{range .Normalized.Outputs}}{{bindtype .Type $structs}}{{end}}, error) {
+				{{- range .Normalized.Outputs}}
+				{{- if $call.Retyped 0}}
+				conv, err := abi.TryConvertType(out[0], new({{bindtype .Type $structs}}))
+				if err != nil {
+					return *new({{bindtype .Type $structs}}), err
+				}
+				return *conv.(*{{bindtype .Type $structs}}), nil
+				{{- else}}
+				return *abi.ConvertType(out[0], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}), nil
+				{{- end}}
+				{{- end}}
+			})
+			{{- end}}
+		}
+	{{end}}
+	{{end}}
+
 	{{range .Transacts}}
 		// {{.Normalized.Name}} is a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
 		//
 		// Solidity: {{.Original.String}}
 		func (_{{$contract.Type}} *{{$contract.Type}}Transactor) {{.Normalized.Name}}(opts *bind.TransactOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) (*types.Transaction, error) {
+			{{if $contract.Errors -}}
+			tx, err := _{{$contract.Type}}.contract.Transact(opts, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			return tx, unpack{{$contract.Type}}Error(err)
+			{{- else -}}
 			return _{{$contract.Type}}.contract.Transact(opts, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			{{- end}}
 		}
 
 		// {{.Normalized.Name}} is a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
@@ -293,6 +515,53 @@ var (
 		func (_{{$contract.Type}} *{{$contract.Type}}TransactorSession) {{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{bindtype .Type $structs}} {{end}}) (*types.Transaction, error) {
 		  return _{{$contract.Type}}.Contract.{{.Normalized.Name}}(&_{{$contract.Type}}.TransactOpts {{range $i, $_ := .Normalized.Inputs}}, {{.Name}}{{end}})
 		}
+
+		// EstimateGas{{.Normalized.Name}} estimates the gas needed by a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Transactor) EstimateGas{{.Normalized.Name}}(opts *bind.TransactOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) (uint64, error) {
+			{{if $contract.Errors -}}
+			gas, err := _{{$contract.Type}}.contract.EstimateGas(opts, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			return gas, unpack{{$contract.Type}}Error(err)
+			{{- else -}}
+			return _{{$contract.Type}}.contract.EstimateGas(opts, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+			{{- end}}
+		}
+
+		// EstimateGas{{.Normalized.Name}} estimates the gas needed by a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Session) EstimateGas{{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{bindtype .Type $structs}} {{end}}) (uint64, error) {
+		  return _{{$contract.Type}}.Contract.EstimateGas{{.Normalized.Name}}(&_{{$contract.Type}}.TransactOpts {{range $i, $_ := .Normalized.Inputs}}, {{.Name}}{{end}})
+		}
+
+		// EstimateGas{{.Normalized.Name}} estimates the gas needed by a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}TransactorSession) EstimateGas{{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{bindtype .Type $structs}} {{end}}) (uint64, error) {
+		  return _{{$contract.Type}}.Contract.EstimateGas{{.Normalized.Name}}(&_{{$contract.Type}}.TransactOpts {{range $i, $_ := .Normalized.Inputs}}, {{.Name}}{{end}})
+		}
+
+		// CreateAccessList{{.Normalized.Name}} generates the access list of a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Transactor) CreateAccessList{{.Normalized.Name}}(opts *bind.TransactOpts {{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}} {{end}}) (*bind.AccessListResult, error) {
+			return _{{$contract.Type}}.contract.CreateAccessList(opts, "{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
+		}
+
+		// CreateAccessList{{.Normalized.Name}} generates the access list of a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}Session) CreateAccessList{{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{bindtype .Type $structs}} {{end}}) (*bind.AccessListResult, error) {
+		  return _{{$contract.Type}}.Contract.CreateAccessList{{.Normalized.Name}}(&_{{$contract.Type}}.TransactOpts {{range $i, $_ := .Normalized.Inputs}}, {{.Name}}{{end}})
+		}
+
+		// CreateAccessList{{.Normalized.Name}} generates the access list of a paid mutator transaction binding the contract method 0x{{printf "%x" .Original.ID}}.
+		//
+		// Solidity: {{.Original.String}}
+		func (_{{$contract.Type}} *{{$contract.Type}}TransactorSession) CreateAccessList{{.Normalized.Name}}({{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}},{{end}} {{.Name}} {{bindtype .Type $structs}} {{end}}) (*bind.AccessListResult, error) {
+		  return _{{$contract.Type}}.Contract.CreateAccessList{{.Normalized.Name}}(&_{{$contract.Type}}.TransactOpts {{range $i, $_ := .Normalized.Inputs}}, {{.Name}}{{end}})
+		}
 	{{end}}
 
 	{{if .Fallback}}
@@ -343,68 +612,7 @@ var (
 
 	{{range .Events}}
 		// {{$contract.Type}}{{.Normalized.Name}}Iterator is returned from Filter{{.Normalized.Name}} and is used to iterate over the raw logs and unpacked data for {{.Normalized.Name}} events raised by the {{$contract.Type}} contract.
-		type {{$contract.Type}}{{.Normalized.Name}}Iterator struct {
-			Event *{{$contract.Type}}{{.Normalized.Name}} // Event containing the contract specifics and raw log
-
-			contract *bind.BoundContract // Generic contract to use for unpacking event data
-			event    string              // Event name to use for unpacking event data
-
-			logs chan types.Log        // Log channel receiving the found contract events
-			sub  ethereum.Subscription // Subscription for errors, completion and termination
-			done bool                  // Whether the subscription completed delivering logs
-			fail error                 // Occurred error to stop iteration
-		}
-		// Next advances the iterator to the subsequent event, returning whether there
-		// are any more events found. In case of a retrieval or parsing error, false is
-		// returned and Error() can be queried for the exact failure.
-		func (it *{{$contract.Type}}{{.Normalized.Name}}Iterator) Next() bool {
-			// If the iterator failed, stop iterating
-			if (it.fail != nil) {
-				return false
-			}
-			// If the iterator completed, deliver directly whatever's available
-			if (it.done) {
-				select {
-				case log := <-it.logs:
-					it.Event = new({{$contract.Type}}{{.Normalized.Name}})
-					if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-						it.fail = err
-						return false
-					}
-					it.Event.Raw = log
-					return true
-
-				default:
-					return false
-				}
-			}
-			// Iterator still in progress, wait for either a data or an error event
-			select {
-			case log := <-it.logs:
-				it.Event = new({{$contract.Type}}{{.Normalized.Name}})
-				if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
-					it.fail = err
-					return false
-				}
-				it.Event.Raw = log
-				return true
-
-			case err := <-it.sub.Err():
-				it.done = true
-				it.fail = err
-				return it.Next()
-			}
-		}
-		// Error returns any retrieval or parsing error occurred during filtering.
-		func (it *{{$contract.Type}}{{.Normalized.Name}}Iterator) Error() error {
-			return it.fail
-		}
-		// Close terminates the iteration process, releasing any pending underlying
-		// resources.
-		func (it *{{$contract.Type}}{{.Normalized.Name}}Iterator) Close() error {
-			it.sub.Unsubscribe()
-			return nil
-		}
+		type {{$contract.Type}}{{.Normalized.Name}}Iterator = bind.EventIterator[{{$contract.Type}}{{.Normalized.Name}}]
 
 		// {{$contract.Type}}{{.Normalized.Name}} represents a {{.Normalized.Name}} event raised by the {{$contract.Type}} contract.
 		type {{$contract.Type}}{{.Normalized.Name}} struct { {{range .Normalized.Inputs}}
@@ -422,11 +630,7 @@ var (
 				{{.Name}}Rule = append({{.Name}}Rule, {{.Name}}Item)
 			}{{end}}{{end}}
 
-			logs, sub, err := _{{$contract.Type}}.contract.FilterLogs(opts, "{{.Original.Name}}"{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}}Rule{{end}}{{end}})
-			if err != nil {
-				return nil, err
-			}
-			return &{{$contract.Type}}{{.Normalized.Name}}Iterator{contract: _{{$contract.Type}}.contract, event: "{{.Original.Name}}", logs: logs, sub: sub}, nil
+			return bind.FilterEvent(_{{$contract.Type}}.contract, opts, "{{.Original.Name}}", _{{$contract.Type}}.Parse{{.Normalized.Name}}{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}}Rule{{end}}{{end}})
  		}
 
 		// Watch{{.Normalized.Name}} is a free log subscription operation binding the contract event 0x{{printf "%x" .Original.ID}}.
@@ -439,36 +643,7 @@ var (
 				{{.Name}}Rule = append({{.Name}}Rule, {{.Name}}Item)
 			}{{end}}{{end}}
 
-			logs, sub, err := _{{$contract.Type}}.contract.WatchLogs(opts, "{{.Original.Name}}"{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}}Rule{{end}}{{end}})
-			if err != nil {
-				return nil, err
-			}
-			return event.NewSubscription(func(quit <-chan struct{}) error {
-				defer sub.Unsubscribe()
-				for {
-					select {
-					case log := <-logs:
-						// New log arrived, parse the event and forward to the user
-						event := new({{$contract.Type}}{{.Normalized.Name}})
-						if err := _{{$contract.Type}}.contract.UnpackLog(event, "{{.Original.Name}}", log); err != nil {
-							return err
-						}
-						event.Raw = log
-
-						select {
-						case sink <- event:
-						case err := <-sub.Err():
-							return err
-						case <-quit:
-							return nil
-						}
-					case err := <-sub.Err():
-						return err
-					case <-quit:
-						return nil
-					}
-				}
-			}), nil
+			return bind.WatchEvent(_{{$contract.Type}}.contract, opts, "{{.Original.Name}}", _{{$contract.Type}}.Parse{{.Normalized.Name}}, sink{{range .Normalized.Inputs}}{{if .Indexed}}, {{.Name}}Rule{{end}}{{end}})
 		}
 
 		// Parse{{.Normalized.Name}} is a log parse operation binding the contract event 0x{{printf "%x" .Original.ID}}.
@@ -484,4 +659,49 @@ var (
 		}
 
  	{{end}}
+
+	{{if .Errors}}
+		// unpack{{.Type}}Error decodes the revert data carried by a failed call into
+		// the matching {{.Type}} custom error. The returned error wraps both the typed
+		// error and the original one, so either can be retrieved with errors.As.
+		// Errors without revert data of a known custom error are returned as is.
+		func unpack{{.Type}}Error(err error) error {
+			data, ok := abi.RevertData(err)
+			if !ok || len(data) < 4 {
+				return err
+			}
+			parsed, perr := {{.Type}}MetaData.GetAbi()
+			if perr != nil {
+				return err
+			}
+			{{range .Errors}}
+			if e := parsed.Errors["{{.Original.Name}}"]; bytes.Equal(data[:4], e.ID[:4]) {
+				out, uerr := e.Inputs.Unpack(data[4:])
+				if uerr != nil {
+					return err
+				}
+				{{if not .Normalized.Inputs}}_ = out{{end}}
+				typed := new({{$contract.Type}}{{.Normalized.Name}})
+				{{range $i, $t := .Normalized.Inputs}}
+				typed.{{capitalise .Name}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}}){{end}}
+				return fmt.Errorf("%w: %w", typed, err)
+			}
+			{{end}}
+			return err
+		}
+	{{end}}
+
+	{{range .Errors}}
+		// {{$contract.Type}}{{.Normalized.Name}} represents a {{.Original.Name}} custom error raised by the {{$contract.Type}} contract.
+		type {{$contract.Type}}{{.Normalized.Name}} struct { {{range .Normalized.Inputs}}
+			{{capitalise .Name}} {{bindtype .Type $structs}}; {{end}}
+		}
+
+		// Error implements the error interface.
+		//
+		// Solidity: {{.Original.String}}
+		func (e *{{$contract.Type}}{{.Normalized.Name}}) Error() string {
+			return fmt.Sprint("{{.Original.Name}}("{{range $i, $_ := .Normalized.Inputs}}{{if ne $i 0}}, ", "{{end}}, e.{{capitalise .Name}}{{end}}, ")")
+		}
+	{{end}}
 {{end}}
\ No newline at end of file
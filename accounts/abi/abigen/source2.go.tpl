@@ -88,7 +88,7 @@ var (
 	}
 	{{ end }}
 
-	{{range .Calls}}
+	{{range $call := .Calls}}
 		// Pack{{.Normalized.Name}} is the Go binding used to pack the parameters required for calling
 		// the contract method with ID 0x{{printf "%x" .Original.ID}}.  This method will panic if any
 		// invalid/nil inputs are passed.
@@ -111,6 +111,32 @@ var (
 			return {{ decapitalise $contract.Type}}.abi.Pack("{{.Original.Name}}" {{range .Normalized.Inputs}}, {{.Name}}{{end}})
 		}
 
+		{{if not .Original.IsConstant}}
+		// EstimateGas{{.Normalized.Name}} estimates the gas needed to invoke the contract method
+		// with ID 0x{{printf "%x" .Original.ID}} on the given instance.
+		//
+		// Solidity: {{.Original.String}}
+		func ({{ decapitalise $contract.Type}} *{{$contract.Type}}) EstimateGas{{.Normalized.Name}}(instance *bind.BoundContract, opts *bind.TransactOpts{{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}}{{end}}) (uint64, error) {
+			enc, err := {{ decapitalise $contract.Type}}.TryPack{{.Normalized.Name}}({{range .Normalized.Inputs}}{{.Name}}, {{end}})
+			if err != nil {
+				return 0, err
+			}
+			return bind.EstimateGas(instance, opts, enc)
+		}
+
+		// CreateAccessList{{.Normalized.Name}} generates the access list of an invocation of the
+		// contract method with ID 0x{{printf "%x" .Original.ID}} on the given instance.
+		//
+		// Solidity: {{.Original.String}}
+		func ({{ decapitalise $contract.Type}} *{{$contract.Type}}) CreateAccessList{{.Normalized.Name}}(instance *bind.BoundContract, opts *bind.TransactOpts{{range .Normalized.Inputs}}, {{.Name}} {{bindtype .Type $structs}}{{end}}) (*bind.AccessListResult, error) {
+			enc, err := {{ decapitalise $contract.Type}}.TryPack{{.Normalized.Name}}({{range .Normalized.Inputs}}{{.Name}}, {{end}})
+			if err != nil {
+				return nil, err
+			}
+			return bind.CreateAccessList(instance, opts, enc)
+		}
+		{{end}}
+
 		{{/* Unpack method is needed only when there are return args */}}
 		{{if .Normalized.Outputs }}
 			{{ if .Structured }}
@@ -137,7 +163,17 @@ var (
 					return *outstruct, err
 				}
 				{{- range $i, $t := .Normalized.Outputs}}
+				{{- if $call.Retyped $i}}
+					conv{{$i}}, err := abi.TryConvertType(out[{{$i}}], new({{if ispointertype .Type}}{{underlyingbindtype .Type}}{{else}}{{bindtype .Type $structs}}{{end}}))
+					if err != nil {
+						return *outstruct, err
+					}
 				{{- if ispointertype .Type}}
+					outstruct.{{capitalise .Name}} = conv{{$i}}.({{bindtype .Type $structs}})
+				{{- else }}
+					outstruct.{{capitalise .Name}} = *conv{{$i}}.(*{{bindtype .Type $structs}})
+				{{- end }}
+				{{- else if ispointertype .Type}}
 					outstruct.{{capitalise .Name}} = abi.ConvertType(out[{{$i}}], new({{underlyingbindtype .Type }})).({{bindtype .Type $structs}})
 				{{- else }}
 					outstruct.{{capitalise .Name}} = *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}})
@@ -148,7 +184,17 @@ var (
 					return {{range $i, $_ := .Normalized.Outputs}}{{if ispointertype .Type}}new({{underlyingbindtype .Type }}), {{else}}*new({{bindtype .Type $structs}}), {{end}}{{end}} err
 				}
 				{{- range $i, $t := .Normalized.Outputs}}
-				{{- if ispointertype .Type }}
+				{{- if $call.Retyped $i}}
+				conv{{$i}}, err := abi.TryConvertType(out[{{$i}}], new({{if ispointertype .Type}}{{underlyingbindtype .Type}}{{else}}{{bindtype .Type $structs}}{{end}}))
+				if err != nil {
+					return {{range $call.Normalized.Outputs}}{{if ispointertype .Type}}new({{underlyingbindtype .Type }}), {{else}}*new({{bindtype .Type $structs}}), {{end}}{{end}} err
+				}
+				{{- if ispointertype .Type}}
+				out{{$i}} := conv{{$i}}.({{bindtype .Type $structs}})
+				{{- else }}
+				out{{$i}} := *conv{{$i}}.(*{{bindtype .Type $structs}})
+				{{- end }}
+				{{- else if ispointertype .Type }}
 				out{{$i}} := abi.ConvertType(out[{{$i}}], new({{underlyingbindtype .Type}})).({{bindtype .Type $structs}})
 				{{- else }}
 				out{{$i}} := *abi.ConvertType(out[{{$i}}], new({{bindtype .Type $structs}})).(*{{bindtype .Type $structs}})
@@ -34,18 +34,21 @@ type tmplData struct {
 
 // tmplContract contains the data needed to generate an individual contract binding.
 type tmplContract struct {
-	Type        string                 // Type name of the main contract binding
-	InputABI    string                 // JSON ABI used as the input to generate the binding from
-	InputBin    string                 // Optional EVM bytecode used to generate deploy code from
-	FuncSigs    map[string]string      // Optional map: string signature -> 4-byte signature
-	Constructor abi.Method             // Contract constructor for deploy parametrization
-	Calls       map[string]*tmplMethod // Contract calls that only read state data
-	Transacts   map[string]*tmplMethod // Contract calls that write state data
-	Fallback    *tmplMethod            // Additional special fallback function
-	Receive     *tmplMethod            // Additional special receive function
-	Events      map[string]*tmplEvent  // Contract events accessors
-	Libraries   map[string]string      // Same as tmplData, but filtered to only keep direct deps that the contract needs
-	Library     bool                   // Indicator whether the contract is a library
+	Type         string                 // Type name of the main contract binding
+	InputABI     string                 // JSON ABI used as the input to generate the binding from
+	InputBin     string                 // Optional EVM bytecode used to generate deploy code from
+	FuncSigs     map[string]string      // Optional map: string signature -> 4-byte signature
+	Constructor  abi.Method             // Contract constructor for deploy parametrization
+	Calls        map[string]*tmplMethod // Contract calls that only read state data
+	Transacts    map[string]*tmplMethod // Contract calls that write state data
+	Fallback     *tmplMethod            // Additional special fallback function
+	Receive      *tmplMethod            // Additional special receive function
+	Events       map[string]*tmplEvent  // Contract events accessors
+	Errors       map[string]*tmplError  // Contract custom errors, decoded from reverts
+	Libraries    map[string]string      // Same as tmplData, but filtered to only keep direct deps that the contract needs
+	Library      bool                   // Indicator whether the contract is a library
+	Linked       bool                   // Indicator whether the bytecode references libraries
+	MetadataHash string                 // Hash of the compiler metadata embedded in the bytecode, if any
 }
 
 type tmplContractV2 struct {
@@ -94,6 +97,12 @@ type tmplMethod struct {
 	Structured bool       // Whether the returns should be accumulated into a struct
 }
 
+// Retyped returns whether the i-th output is bound to a Go type other than the
+// one of its ABI type, in which case converting the value may fail.
+func (m *tmplMethod) Retyped(i int) bool {
+	return m.Original.Outputs[i].Type.String() != m.Normalized.Outputs[i].Type.String()
+}
+
 // tmplEvent is a wrapper around an abi.Event that contains a few preprocessed
 // and cached data fields.
 type tmplEvent struct {
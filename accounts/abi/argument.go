@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 )
 
@@ -78,17 +79,27 @@ func (arguments Arguments) isTuple() bool {
 
 // Unpack performs the operation hexdata -> Go format.
 func (arguments Arguments) Unpack(data []byte) ([]any, error) {
+	return arguments.UnpackWithOptions(Options{}, data)
+}
+
+// UnpackWithOptions performs the operation hexdata -> Go format, with the
+// decoding behaviour altered by opts.
+func (arguments Arguments) UnpackWithOptions(opts Options, data []byte) ([]any, error) {
 	if len(data) == 0 {
 		if len(arguments.NonIndexed()) != 0 {
 			return nil, errors.New("abi: attempting to unmarshal an empty string while arguments are expected")
 		}
 		return make([]any, 0), nil
 	}
-	return arguments.UnpackValues(data)
+	return arguments.unpackValues(opts, data)
 }
 
 // UnpackIntoMap performs the operation hexdata -> mapping of argument name to argument value.
 func (arguments Arguments) UnpackIntoMap(v map[string]any, data []byte) error {
+	return arguments.unpackIntoMap(Options{}, v, data)
+}
+
+func (arguments Arguments) unpackIntoMap(opts Options, v map[string]any, data []byte) error {
 	// Make sure map is not nil
 	if v == nil {
 		return errors.New("abi: cannot unpack into a nil map")
@@ -99,7 +110,7 @@ func (arguments Arguments) UnpackIntoMap(v map[string]any, data []byte) error {
 		}
 		return nil // Nothing to unmarshal, return
 	}
-	marshalledValues, err := arguments.UnpackValues(data)
+	marshalledValues, err := arguments.unpackValues(opts, data)
 	if err != nil {
 		return err
 	}
@@ -133,6 +144,11 @@ func (arguments Arguments) copyAtomic(v any, marshalledValues any) error {
 	src := reflect.ValueOf(marshalledValues)
 
 	if dst.Kind() == reflect.Struct {
+		// A single tuple output may be unpacked directly into a struct
+		// mirroring the tuple, rather than into the struct's first field.
+		if tupleFieldMapping(dst, src) != nil {
+			return set(dst, src)
+		}
 		return set(dst.Field(0), src)
 	}
 	return set(dst, src)
@@ -182,6 +198,16 @@ func (arguments Arguments) copyTuple(v any, marshalledValues []any) error {
 // without supplying a struct to unpack into. Instead, this method returns a list containing the
 // values. An atomic argument will be a list with one element.
 func (arguments Arguments) UnpackValues(data []byte) ([]any, error) {
+	return arguments.unpackValues(Options{}, data)
+}
+
+func (arguments Arguments) unpackValues(opts Options, data []byte) ([]any, error) {
+	if opts.Lenient && len(data)%32 != 0 {
+		// Zero-extend data missing the padding of its last word
+		data = append(data[:len(data):len(data)], make([]byte, 32-len(data)%32)...)
+	}
+	budget := newDecodeBudget(opts.Limits)
+
 	var (
 		retval      = make([]any, 0)
 		virtualArgs = 0
@@ -192,7 +218,7 @@ func (arguments Arguments) UnpackValues(data []byte) ([]any, error) {
 		if arg.Indexed {
 			continue
 		}
-		marshalledValue, err := toGoType((index+virtualArgs)*32, arg.Type, data)
+		marshalledValue, err := toGoType((index+virtualArgs)*32, arg.Type, data, opts, budget)
 		if err != nil {
 			return nil, err
 		}
@@ -219,6 +245,22 @@ func (arguments Arguments) UnpackValues(data []byte) ([]any, error) {
 	return retval, nil
 }
 
+// UnpackAt unpacks only the value at the given position of the non-indexed
+// arguments, i.e. the element that Unpack would return at that index. None of
+// the other values are decoded, so malformed or large neighbouring values do
+// not affect the result.
+func (arguments Arguments) UnpackAt(data []byte, index int) (any, error) {
+	nonIndexed := arguments.NonIndexed()
+	if index < 0 || index >= len(nonIndexed) {
+		return nil, fmt.Errorf("abi: argument index %d out of range (%d arguments)", index, len(nonIndexed))
+	}
+	offset := 0
+	for _, arg := range nonIndexed[:index] {
+		offset += getTypeSize(arg.Type)
+	}
+	return toGoType(offset, nonIndexed[index].Type, data, Options{}, nil)
+}
+
 // PackValues performs the operation Go format -> Hexdata.
 // It is the semantic opposite of UnpackValues.
 func (arguments Arguments) PackValues(args []any) ([]byte, error) {
@@ -227,8 +269,25 @@ func (arguments Arguments) PackValues(args []any) ([]byte, error) {
 
 // Pack performs the operation Go format -> Hexdata.
 func (arguments Arguments) Pack(args ...any) ([]byte, error) {
+	return arguments.PackWithOptions(Options{}, args...)
+}
+
+// PackWithOptions performs the operation Go format -> Hexdata, applying the
+// given encoding options.
+func (arguments Arguments) PackWithOptions(opts Options, args ...any) ([]byte, error) {
 	// Make sure arguments match up and pack them
 	abiArgs := arguments
+	if len(args) == 1 && len(abiArgs) > 1 {
+		// A single struct may be supplied in place of the full argument list,
+		// mirroring unpacking multiple outputs into a struct.
+		expanded, err := abiArgs.structToArgs(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if expanded != nil {
+			args = expanded
+		}
+	}
 	if len(args) != len(abiArgs) {
 		return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(args), len(abiArgs))
 	}
@@ -245,7 +304,7 @@ func (arguments Arguments) Pack(args ...any) ([]byte, error) {
 	for i, a := range args {
 		input := abiArgs[i]
 		// pack the input
-		packed, err := input.Type.pack(reflect.ValueOf(a))
+		packed, err := input.Type.pack(reflect.ValueOf(a), opts)
 		if err != nil {
 			return nil, err
 		}
@@ -272,6 +331,59 @@ func (arguments Arguments) Pack(args ...any) ([]byte, error) {
 	return ret, nil
 }
 
+// PackNamed performs the operation Go format -> Hexdata, resolving the values
+// of the arguments by name instead of position. It fails if a value is missing
+// for any of the arguments, or if values are given for unknown arguments.
+func (arguments Arguments) PackNamed(args map[string]any) ([]byte, error) {
+	values := make([]any, len(arguments))
+	for i, arg := range arguments {
+		if arg.Name == "" {
+			return nil, fmt.Errorf("abi: argument %d is unnamed, cannot pack by name", i)
+		}
+		value, ok := args[arg.Name]
+		if !ok {
+			return nil, fmt.Errorf("abi: missing value for argument '%s'", arg.Name)
+		}
+		values[i] = value
+	}
+	for name := range args {
+		if !slices.ContainsFunc(arguments, func(arg Argument) bool { return arg.Name == name }) {
+			return nil, fmt.Errorf("abi: unknown argument '%s'", name)
+		}
+	}
+	return arguments.Pack(values...)
+}
+
+// structToArgs flattens the fields of the given struct into a positional
+// argument list, resolving the fields by argument name and `abi:""` tags.
+// Nil is returned if v is not a struct.
+func (arguments Arguments) structToArgs(v any) ([]any, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return nil, nil
+	}
+	if value = indirect(value); value.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	names := make([]string, len(arguments))
+	for i, arg := range arguments {
+		names[i] = arg.Name
+	}
+	abi2struct, err := mapArgNamesToStructFields(names, value)
+	if err != nil {
+		return nil, err
+	}
+	args := make([]any, len(arguments))
+	for i, arg := range arguments {
+		field := value.FieldByName(abi2struct[arg.Name])
+		if !field.IsValid() {
+			return nil, fmt.Errorf("abi: field %s can't be found in the given value", arg.Name)
+		}
+		args[i] = field.Interface()
+	}
+	return args, nil
+}
+
 // ToCamelCase converts an under-score string to a camel-case string
 func ToCamelCase(input string) string {
 	parts := strings.Split(input, "_")
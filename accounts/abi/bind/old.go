@@ -27,6 +27,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/abigen"
@@ -36,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -173,6 +175,10 @@ var (
 	// on a backend that doesn't implement BlockHashContractCaller.
 	ErrNoBlockHashState = bind2.ErrNoBlockHashState
 
+	// ErrNoAccessListSupport is raised when attempting to create an access list
+	// with a transactor that doesn't implement AccessListCreator.
+	ErrNoAccessListSupport = bind2.ErrNoAccessListSupport
+
 	// ErrNoCodeAfterDeploy is returned by WaitDeployed if contract creation leaves
 	// an empty contract behind.
 	ErrNoCodeAfterDeploy = bind2.ErrNoCodeAfterDeploy
@@ -198,6 +204,11 @@ type BlockHashContractCaller = bind2.BlockHashContractCaller
 // to the transactor to decide.
 type ContractTransactor = bind2.ContractTransactor
 
+// AccessListCreator defines the methods needed to generate the access list of a
+// transaction. CreateAccessList will try to discover this interface on the
+// transactor. If it's not implemented, CreateAccessList returns ErrNoAccessListSupport.
+type AccessListCreator = bind2.AccessListCreator
+
 // DeployBackend wraps the operations needed by WaitMined and WaitDeployed.
 type DeployBackend = bind2.DeployBackend
 
@@ -220,8 +231,12 @@ type FilterOpts = bind2.FilterOpts
 
 type WatchOpts = bind2.WatchOpts
 
+type ResilientWatchOpts = bind2.ResilientWatchOpts
+
 type BoundContract = bind2.BoundContract
 
+type AccessListResult = bind2.AccessListResult
+
 func NewBoundContract(address common.Address, abi abi.ABI, caller ContractCaller, transactor ContractTransactor, filterer ContractFilterer) *BoundContract {
 	return bind2.NewBoundContract(address, abi, caller, transactor, filterer)
 }
@@ -239,6 +254,31 @@ func DeployContract(opts *TransactOpts, abi abi.ABI, bytecode []byte, backend Co
 	return addr, tx, contract, nil
 }
 
+// DefaultCreate2Factory is the address of the deterministic deployment proxy,
+// used by DeployContract2 if no factory is given.
+var DefaultCreate2Factory = bind2.DefaultCreate2Factory
+
+// DeployContract2 deploys a contract through a CREATE2 factory, at an address
+// depending only on the factory, the salt and the deployment code.
+func DeployContract2(opts *TransactOpts, factory common.Address, salt common.Hash, abi abi.ABI, bytecode []byte, backend ContractBackend, params ...interface{}) (common.Address, *types.Transaction, *BoundContract, error) {
+	packed, err := abi.Pack("", params...)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	addr, tx, err := bind2.DeployContract2(opts, factory, salt, bytecode, backend, packed)
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	contract := NewBoundContract(addr, abi, backend, backend, backend)
+	return addr, tx, contract, nil
+}
+
+// Create2Address returns the address a contract is deployed at by DeployContract2
+// through the given factory with the given salt.
+func Create2Address(factory common.Address, salt common.Hash, bytecode []byte, constructorInput []byte) common.Address {
+	return bind2.Create2Address(factory, salt, bytecode, constructorInput)
+}
+
 // MetaData collects all metadata for a bound contract.
 type MetaData struct {
 	Bin       string            // runtime bytecode (as a hex string)
@@ -264,6 +304,15 @@ func (m *MetaData) GetAbi() (*abi.ABI, error) {
 	return m.parsedABI, nil
 }
 
+// dep_tree.go
+
+// LinkBytecode substitutes the library placeholders of the hex encoded bytecode
+// with the addresses of the deployed libraries, keyed by fully qualified name
+// or link pattern.
+func LinkBytecode(bytecode string, libraries map[string]common.Address) (string, error) {
+	return bind2.LinkBytecode(bytecode, libraries)
+}
+
 // util.go
 
 // WaitMined waits for tx to be mined on the blockchain.
@@ -292,3 +341,211 @@ func WaitDeployed(ctx context.Context, b DeployBackend, tx *types.Transaction) (
 func WaitDeployedHash(ctx context.Context, b DeployBackend, hash common.Hash) (common.Address, error) {
 	return bind2.WaitDeployed(ctx, b, hash)
 }
+
+// events.go
+
+// EventIterator is returned from the event filtering methods of the generated
+// bindings, and is used to iterate over the raw logs and unpacked data of the
+// events of type T raised by a contract.
+type EventIterator[T any] struct {
+	Event *T // Event containing the contract specifics and raw log
+
+	unpack func(types.Log) (*T, error) // Unpacker of the raw logs into events
+
+	logs <-chan types.Log      // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EventIterator[T]) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			return it.deliver(log)
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		return it.deliver(log)
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// deliver unpacks a log into the current event of the iterator.
+func (it *EventIterator[T]) deliver(log types.Log) bool {
+	event, err := it.unpack(log)
+	if err != nil {
+		it.fail = err
+		return false
+	}
+	it.Event = event
+	return true
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EventIterator[T]) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EventIterator[T]) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// FilterEvent retrieves the past logs of the named event matching the topic
+// rules, and returns an iterator over them unpacked by the given function.
+func FilterEvent[T any](c *BoundContract, opts *FilterOpts, name string, unpack func(types.Log) (*T, error), query ...[]interface{}) (*EventIterator[T], error) {
+	logs, sub, err := c.FilterLogs(opts, name, query...)
+	if err != nil {
+		return nil, err
+	}
+	return &EventIterator[T]{unpack: unpack, logs: logs, sub: sub}, nil
+}
+
+// WatchEvent subscribes to future logs of the named event matching the topic
+// rules, unpacking them with the given function and forwarding them to sink.
+func WatchEvent[T any](c *BoundContract, opts *WatchOpts, name string, unpack func(types.Log) (*T, error), sink chan<- *T, query ...[]interface{}) (event.Subscription, error) {
+	logs, sub, err := c.WatchLogs(opts, name, query...)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event, err := unpack(log)
+				if err != nil {
+					return err
+				}
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// multicall.go
+
+// ErrMulticallPending is returned when retrieving the result of a batched call
+// before the batch containing it was executed.
+var ErrMulticallPending = bind2.ErrMulticallPending
+
+type MulticallBatch = bind2.MulticallBatch
+
+type MulticallValue[T any] = bind2.MulticallValue[T]
+
+// NewMulticallBatch creates an empty batch executing its calls through the
+// Multicall3 contract deployed at the canonical address.
+func NewMulticallBatch(caller ContractCaller) *MulticallBatch {
+	return bind2.NewMulticallBatch(caller)
+}
+
+// NewMulticallBatchAt creates an empty batch executing its calls through the
+// Multicall3 contract deployed at the given address.
+func NewMulticallBatchAt(caller ContractCaller, address common.Address) *MulticallBatch {
+	return bind2.NewMulticallBatchAt(caller, address)
+}
+
+// AddMulticall enqueues a call into the batch, converting its return values
+// with the given function once the batch is executed.
+func AddMulticall[T any](b *MulticallBatch, call abi.MulticallCall, convert func([]any) (T, error)) *MulticallValue[T] {
+	return bind2.AddMulticall(b, call, convert)
+}
+
+// proxy.go
+
+// ErrNotProxy is returned when the contract at the given address doesn't
+// follow any of the supported proxy patterns.
+var ErrNotProxy = bind2.ErrNotProxy
+
+type ProxyKind = bind2.ProxyKind
+
+const (
+	ProxyEIP1967       = bind2.ProxyEIP1967
+	ProxyEIP1967Beacon = bind2.ProxyEIP1967Beacon
+	ProxyEIP1167       = bind2.ProxyEIP1167
+)
+
+type ProxyInfo = bind2.ProxyInfo
+
+type ProxyReader = bind2.ProxyReader
+
+type ProxyBackend = bind2.ProxyBackend
+
+type ABILookup = bind2.ABILookup
+
+// DetectProxy checks whether the contract at the given address is an EIP-1967
+// or EIP-1167 proxy, returning ErrNotProxy if it's neither.
+func DetectProxy(opts *CallOpts, backend ProxyReader, address common.Address) (*ProxyInfo, error) {
+	return bind2.DetectProxy(opts, backend, address)
+}
+
+// ResolveImplementation follows the proxies starting at the given address and
+// returns the address of the contract ultimately implementing the calls.
+func ResolveImplementation(opts *CallOpts, backend ProxyReader, address common.Address) (common.Address, error) {
+	return bind2.ResolveImplementation(opts, backend, address)
+}
+
+// ResolveProxyABI resolves the implementation behind the proxy at the given
+// address and looks up its ABI.
+func ResolveProxyABI(opts *CallOpts, backend ProxyReader, address common.Address, lookup ABILookup) (*abi.ABI, error) {
+	return bind2.ResolveProxyABI(opts, backend, address, lookup)
+}
+
+// NewBoundContractThroughProxy resolves the ABI of the implementation behind the
+// proxy at the given address and binds it to the proxy address.
+func NewBoundContractThroughProxy(opts *CallOpts, backend ProxyBackend, address common.Address, lookup ABILookup) (*BoundContract, error) {
+	return bind2.NewBoundContractThroughProxy(opts, backend, address, lookup)
+}
+
+// verify.go
+
+// ErrCodeMismatch is returned by VerifyCode if the deployed code doesn't match
+// the compiled bytecode.
+var ErrCodeMismatch = bind2.ErrCodeMismatch
+
+// VerifyCode checks that the code deployed at the given address is the runtime
+// code embedded in the given hex encoded deployment bytecode, ignoring the
+// compiler metadata, immutable variables and library addresses.
+func VerifyCode(opts *CallOpts, backend ContractCaller, address common.Address, bytecode string) error {
+	return bind2.VerifyCode(opts, backend, address, bytecode)
+}
+
+// nonce.go
+
+// NonceManager assigns the nonces of transactions sent concurrently from the
+// same accounts. It's used by setting it in TransactOpts.NonceManager.
+type NonceManager = bind2.NonceManager
+
+// NewNonceManager creates a nonce manager without any tracked accounts.
+func NewNonceManager() *NonceManager {
+	return bind2.NewNonceManager()
+}
@@ -40,6 +40,10 @@ var (
 	// on a backend that doesn't implement BlockHashContractCaller.
 	ErrNoBlockHashState = errors.New("backend does not support block hash state")
 
+	// ErrNoAccessListSupport is raised when attempting to create an access list
+	// with a transactor that doesn't implement AccessListCreator.
+	ErrNoAccessListSupport = errors.New("backend does not support access list creation")
+
 	// ErrNoCodeAfterDeploy is returned by WaitDeployed if contract creation leaves
 	// an empty contract behind.
 	ErrNoCodeAfterDeploy = errors.New("no contract code after deployment")
@@ -105,6 +109,15 @@ type ContractTransactor interface {
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 }
 
+// AccessListCreator defines the methods needed to generate the access list of a
+// transaction. CreateAccessList will try to discover this interface on the
+// transactor. If it's not implemented, CreateAccessList returns ErrNoAccessListSupport.
+type AccessListCreator interface {
+	// CreateAccessList generates the access list of the given call, returning
+	// it with the gas used and the execution error, if any.
+	CreateAccessList(ctx context.Context, call ethereum.CallMsg) (*types.AccessList, uint64, string, error)
+}
+
 // DeployBackend wraps the operations needed by WaitMined and WaitDeployed.
 type DeployBackend interface {
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
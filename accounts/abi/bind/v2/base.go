@@ -66,6 +66,9 @@ type TransactOpts struct {
 	GasLimit   uint64           // Gas limit to set for the transaction execution (0 = estimate)
 	AccessList types.AccessList // Access list to set for the transaction execution (nil = no access list)
 
+	FeeStrategy  FeeStrategy   // Strategy to suggest missing dynamic fees with (nil = SuggestedFees)
+	NonceManager *NonceManager // Manager to assign missing nonces with (nil = use pending state)
+
 	Context context.Context // Network context to support cancellation and timeouts (nil = no timeout)
 
 	NoSend bool // Do all transact steps but do not send the transaction
@@ -284,28 +287,92 @@ func (c *BoundContract) Transfer(opts *TransactOpts) (*types.Transaction, error)
 	return c.transact(opts, &c.address, nil)
 }
 
+// EstimateGas estimates the gas needed to invoke the (paid) contract method with
+// params as input values.
+func (c *BoundContract) EstimateGas(opts *TransactOpts, method string, params ...any) (uint64, error) {
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return 0, err
+	}
+	return c.RawEstimateGas(opts, input)
+}
+
+// RawEstimateGas estimates the gas needed to invoke the contract with the given
+// raw calldata as the input.
+func (c *BoundContract) RawEstimateGas(opts *TransactOpts, calldata []byte) (uint64, error) {
+	return c.estimateGasLimit(opts, &c.address, calldata, opts.GasPrice, opts.GasTipCap, opts.GasFeeCap, opts.Value)
+}
+
+// AccessListResult is the access list generated for a contract invocation.
+type AccessListResult struct {
+	AccessList types.AccessList // Storage slots accessed by the invocation
+	GasUsed    uint64           // Gas used by the invocation with the access list applied
+	VMError    string           // Execution error of the invocation, if it failed
+}
+
+// CreateAccessList generates the access list of an invocation of the (paid)
+// contract method with params as input values.
+func (c *BoundContract) CreateAccessList(opts *TransactOpts, method string, params ...any) (*AccessListResult, error) {
+	input, err := c.abi.Pack(method, params...)
+	if err != nil {
+		return nil, err
+	}
+	return c.RawCreateAccessList(opts, input)
+}
+
+// RawCreateAccessList generates the access list of an invocation of the contract
+// with the given raw calldata as the input.
+func (c *BoundContract) RawCreateAccessList(opts *TransactOpts, calldata []byte) (*AccessListResult, error) {
+	creator, ok := c.transactor.(AccessListCreator)
+	if !ok {
+		return nil, ErrNoAccessListSupport
+	}
+	msg := ethereum.CallMsg{
+		From:       opts.From,
+		To:         &c.address,
+		Gas:        opts.GasLimit,
+		GasPrice:   opts.GasPrice,
+		GasTipCap:  opts.GasTipCap,
+		GasFeeCap:  opts.GasFeeCap,
+		Value:      opts.Value,
+		Data:       calldata,
+		AccessList: opts.AccessList,
+	}
+	list, gas, vmErr, err := creator.CreateAccessList(ensureContext(opts.Context), msg)
+	if err != nil {
+		return nil, err
+	}
+	result := &AccessListResult{GasUsed: gas, VMError: vmErr}
+	if list != nil {
+		result.AccessList = *list
+	}
+	return result, nil
+}
+
 func (c *BoundContract) createDynamicTx(opts *TransactOpts, contract *common.Address, input []byte, head *types.Header) (*types.Transaction, error) {
 	// Normalize value
 	value := opts.Value
 	if value == nil {
 		value = new(big.Int)
 	}
-	// Estimate TipCap
-	gasTipCap := opts.GasTipCap
+	// Estimate TipCap and FeeCap
+	gasTipCap, gasFeeCap := opts.GasTipCap, opts.GasFeeCap
 	if gasTipCap == nil {
-		tip, err := c.transactor.SuggestGasTipCap(ensureContext(opts.Context))
+		strategy := opts.FeeStrategy
+		if strategy == nil {
+			strategy = SuggestedFees{}
+		}
+		tip, feeCap, err := strategy.SuggestFees(ensureContext(opts.Context), c.transactor, head)
 		if err != nil {
 			return nil, err
 		}
 		gasTipCap = tip
+		if gasFeeCap == nil {
+			gasFeeCap = feeCap
+		}
 	}
-	// Estimate FeeCap
-	gasFeeCap := opts.GasFeeCap
 	if gasFeeCap == nil {
-		gasFeeCap = new(big.Int).Add(
-			gasTipCap,
-			new(big.Int).Mul(head.BaseFee, big.NewInt(basefeeWiggleMultiplier)),
-		)
+		gasFeeCap = feeCapFor(gasTipCap, head)
 	}
 	if gasFeeCap.Cmp(gasTipCap) < 0 {
 		return nil, fmt.Errorf("maxFeePerGas (%v) < maxPriorityFeePerGas (%v)", gasFeeCap, gasTipCap)
@@ -416,6 +483,9 @@ func (c *BoundContract) transact(opts *TransactOpts, contract *common.Address, i
 	if opts.GasPrice != nil && (opts.GasFeeCap != nil || opts.GasTipCap != nil) {
 		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	}
+	if opts.Nonce == nil && opts.NonceManager != nil {
+		return c.transactManaged(opts, contract, input)
+	}
 	// Create the transaction
 	var (
 		rawTx *types.Transaction
@@ -533,49 +603,61 @@ func (c *BoundContract) WatchLogs(opts *WatchOpts, name string, query ...[]any)
 }
 
 // UnpackLog unpacks a retrieved log into the provided output structure.
+//
+// Anonymous events carry no signature topic, so the log is decoded against the
+// named event definition as is, with all of its topics treated as indexed
+// arguments.
 func (c *BoundContract) UnpackLog(out any, event string, log types.Log) error {
-	// Anonymous events are not supported.
-	if len(log.Topics) == 0 {
-		return errNoEventSignature
-	}
-	if log.Topics[0] != c.abi.Events[event].ID {
-		return errEventSignatureMismatch
+	indexed, topics, err := c.logTopics(event, log)
+	if err != nil {
+		return err
 	}
 	if len(log.Data) > 0 {
 		if err := c.abi.UnpackIntoInterface(out, event, log.Data); err != nil {
 			return err
 		}
 	}
-	var indexed abi.Arguments
-	for _, arg := range c.abi.Events[event].Inputs {
-		if arg.Indexed {
-			indexed = append(indexed, arg)
-		}
-	}
-	return abi.ParseTopics(out, indexed, log.Topics[1:])
+	return abi.ParseTopics(out, indexed, topics)
 }
 
 // UnpackLogIntoMap unpacks a retrieved log into the provided map.
 func (c *BoundContract) UnpackLogIntoMap(out map[string]any, event string, log types.Log) error {
-	// Anonymous events are not supported.
-	if len(log.Topics) == 0 {
-		return errNoEventSignature
-	}
-	if log.Topics[0] != c.abi.Events[event].ID {
-		return errEventSignatureMismatch
+	indexed, topics, err := c.logTopics(event, log)
+	if err != nil {
+		return err
 	}
 	if len(log.Data) > 0 {
 		if err := c.abi.UnpackIntoMap(out, event, log.Data); err != nil {
 			return err
 		}
 	}
+	return abi.ParseTopicsIntoMap(out, indexed, topics)
+}
+
+// logTopics verifies that the log matches the event and returns the indexed
+// arguments of the event along with the topics holding their values.
+func (c *BoundContract) logTopics(event string, log types.Log) (abi.Arguments, []common.Hash, error) {
+	ev, ok := c.abi.Events[event]
+	if !ok {
+		return nil, nil, fmt.Errorf("event '%s' not found", event)
+	}
+	topics := log.Topics
+	if !ev.Anonymous {
+		if len(topics) == 0 {
+			return nil, nil, errNoEventSignature
+		}
+		if topics[0] != ev.ID {
+			return nil, nil, errEventSignatureMismatch
+		}
+		topics = topics[1:]
+	}
 	var indexed abi.Arguments
-	for _, arg := range c.abi.Events[event].Inputs {
+	for _, arg := range ev.Inputs {
 		if arg.Indexed {
 			indexed = append(indexed, arg)
 		}
 	}
-	return abi.ParseTopicsIntoMap(out, indexed, log.Topics[1:])
+	return indexed, topics, nil
 }
 
 // ensureContext is a helper method to ensure a context is not nil, even if the
@@ -17,6 +17,7 @@
 package bind_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"math/big"
@@ -226,6 +227,42 @@ func TestUnpackAnonymousLogIntoMap(t *testing.T) {
 	}
 }
 
+func TestUnpackAnonymousEventLog(t *testing.T) {
+	t.Parallel()
+	hash := crypto.Keccak256Hash([]byte("testName"))
+	mockLog := newMockLog([]common.Hash{hash}, common.HexToHash("0x0"))
+
+	abiString := `[{"anonymous":true,"inputs":[{"indexed":true,"name":"name","type":"string"},{"indexed":false,"name":"sender","type":"address"},{"indexed":false,"name":"amount","type":"uint256"},{"indexed":false,"name":"memo","type":"bytes"}],"name":"received","type":"event"}]`
+	parsedAbi, _ := abi.JSON(strings.NewReader(abiString))
+	bc := bind.NewBoundContract(common.HexToAddress("0x0"), parsedAbi, nil, nil, nil)
+
+	expectedReceivedMap := map[string]interface{}{
+		"name":   hash,
+		"sender": common.HexToAddress("0x376c47978271565f56DEB45495afa69E59c16Ab2"),
+		"amount": big.NewInt(1),
+		"memo":   []byte{88},
+	}
+	unpackAndCheck(t, bc, expectedReceivedMap, mockLog)
+
+	var received struct {
+		Name   common.Hash
+		Sender common.Address
+		Amount *big.Int
+		Memo   []byte
+	}
+	if err := bc.UnpackLog(&received, "received", mockLog); err != nil {
+		t.Fatal(err)
+	}
+	if received.Name != hash || received.Amount.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("wrong unpacked log: %+v", received)
+	}
+	// All topics are consumed as indexed arguments, so extra ones are rejected
+	mockLog.Topics = append(mockLog.Topics, hash)
+	if err := bc.UnpackLog(&received, "received", mockLog); err == nil {
+		t.Error("expected topic count mismatch")
+	}
+}
+
 func TestUnpackIndexedSliceTyLogIntoMap(t *testing.T) {
 	t.Parallel()
 	sliceBytes, err := rlp.EncodeToBytes([]string{"name1", "name2", "name3", "name4"})
@@ -374,6 +411,68 @@ func TestTransactGasFee(t *testing.T) {
 	assert.True(mt.suggestGasPriceCalled)
 }
 
+// mockAccessListTransactor is a transactor that records the estimated calls and
+// generates a fixed access list.
+type mockAccessListTransactor struct {
+	*mockTransactor
+	call ethereum.CallMsg
+}
+
+func (mt *mockAccessListTransactor) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	mt.call = call
+	return 21000, nil
+}
+
+func (mt *mockAccessListTransactor) CreateAccessList(ctx context.Context, call ethereum.CallMsg) (*types.AccessList, uint64, string, error) {
+	mt.call = call
+	list := types.AccessList{{Address: *call.To, StorageKeys: []common.Hash{{1}}}}
+	return &list, 23000, "", nil
+}
+
+func TestEstimateGasAndAccessList(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := abi.ParseHumanReadable([]string{"function transfer(address to, uint256 amount) returns (bool)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		address = common.Address{1}
+		to      = common.Address{2}
+		opts    = &bind.TransactOpts{From: common.Address{3}, Value: big.NewInt(1)}
+	)
+	input, _ := parsed.Pack("transfer", to, big.NewInt(10))
+
+	// Access lists need an explicit backend support
+	bc := bind.NewBoundContract(address, parsed, nil, &mockTransactor{}, nil)
+	if _, err := bc.CreateAccessList(opts, "transfer", to, big.NewInt(10)); !errors.Is(err, bind.ErrNoAccessListSupport) {
+		t.Fatalf("error mismatch: have %v, want %v", err, bind.ErrNoAccessListSupport)
+	}
+	mt := &mockAccessListTransactor{mockTransactor: new(mockTransactor)}
+	bc = bind.NewBoundContract(address, parsed, nil, mt, nil)
+
+	gas, err := bc.EstimateGas(opts, "transfer", to, big.NewInt(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gas != 21000 {
+		t.Fatalf("gas mismatch: have %d, want 21000", gas)
+	}
+	if *mt.call.To != address || mt.call.From != opts.From || mt.call.Value != opts.Value || !bytes.Equal(mt.call.Data, input) {
+		t.Fatalf("estimated call mismatch: %+v", mt.call)
+	}
+	result, err := bind.CreateAccessList(bc, opts, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.GasUsed != 23000 || len(result.AccessList) != 1 || result.AccessList[0].Address != address {
+		t.Fatalf("access list mismatch: %+v", result)
+	}
+	if !bytes.Equal(mt.call.Data, input) {
+		t.Fatalf("access list call data mismatch: have %x, want %x", mt.call.Data, input)
+	}
+}
+
 func unpackAndCheck(t *testing.T, bc *bind.BoundContract, expected map[string]interface{}, mockLog types.Log) {
 	received := make(map[string]interface{})
 	if err := bc.UnpackLogIntoMap(received, "received", mockLog); err != nil {
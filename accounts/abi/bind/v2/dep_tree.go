@@ -20,12 +20,50 @@ import (
 	"encoding/hex"
 	"fmt"
 	"maps"
+	"regexp"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// libraryPlaceholder matches the library placeholders of unlinked bytecode.
+var libraryPlaceholder = regexp.MustCompile(`__\$([0-9a-fA-F]{34})\$__`)
+
+// LinkBytecode substitutes the library placeholders of the hex encoded bytecode
+// with the addresses of the deployed libraries. Libraries are keyed either by
+// their fully qualified name (<sourcePath>:<library>) or by the 34 character
+// link pattern (the placeholder without the surrounding "__$" and "$__").
+//
+// An error is returned if the bytecode references a library not given.
+func LinkBytecode(bytecode string, libraries map[string]common.Address) (string, error) {
+	patterns := make(map[string]common.Address, len(libraries))
+	for name, addr := range libraries {
+		pattern := strings.ToLower(name)
+		if !libraryPlaceholder.MatchString("__$" + name + "$__") {
+			pattern = crypto.Keccak256Hash([]byte(name)).Hex()[2:36]
+		}
+		patterns[pattern] = addr
+	}
+	var missing string
+	linked := libraryPlaceholder.ReplaceAllStringFunc(bytecode, func(placeholder string) string {
+		pattern := strings.ToLower(placeholder[3:37])
+		addr, ok := patterns[pattern]
+		if !ok {
+			if missing == "" {
+				missing = pattern
+			}
+			return placeholder
+		}
+		return strings.ToLower(addr.Hex()[2:])
+	})
+	if missing != "" {
+		return "", fmt.Errorf("bytecode references unknown library %s", missing)
+	}
+	return linked, nil
+}
+
 // DeploymentParams contains parameters needed to deploy one or more contracts via LinkAndDeploy
 type DeploymentParams struct {
 	// list of all contracts targeted for the deployment
@@ -368,3 +368,25 @@ func parseLibraryDeps(unlinkedCode string) (res []string) {
 	}
 	return res
 }
+
+func TestLinkBytecode(t *testing.T) {
+	var (
+		name    = "contracts/Math.sol:Math"
+		pattern = crypto.Keccak256Hash([]byte(name)).Hex()[2:36]
+		other   = crypto.Keccak256Hash([]byte("contracts/Strings.sol:Strings")).Hex()[2:36]
+		addr1   = common.HexToAddress("0x00000000000000000000000000000000000000aA")
+		addr2   = common.HexToAddress("0x00000000000000000000000000000000000000bB")
+		code    = "0x6080__$" + pattern + "$__00__$" + other + "$__" + "__$" + pattern + "$__"
+	)
+	linked, err := LinkBytecode(code, map[string]common.Address{name: addr1, other: addr2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "0x6080" + "00000000000000000000000000000000000000aa" + "00" + "00000000000000000000000000000000000000bb" + "00000000000000000000000000000000000000aa"
+	if linked != want {
+		t.Fatalf("linked bytecode mismatch:\nhave %s\nwant %s", linked, want)
+	}
+	if _, err := LinkBytecode(code, map[string]common.Address{name: addr1}); err == nil {
+		t.Fatal("expected error for unlinked library")
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// FeeStrategy suggests the fees of dynamic fee transactions.
+type FeeStrategy interface {
+	// SuggestFees returns the priority fee and the fee cap per gas to use for a
+	// transaction to be included on top of the given head.
+	SuggestFees(ctx context.Context, backend ContractTransactor, head *types.Header) (gasTipCap, gasFeeCap *big.Int, err error)
+}
+
+// SuggestedFees is the default fee strategy. It uses the priority fee suggested
+// by the backend, with a fee cap leaving room for the base fee to double.
+type SuggestedFees struct{}
+
+// SuggestFees implements FeeStrategy.
+func (SuggestedFees) SuggestFees(ctx context.Context, backend ContractTransactor, head *types.Header) (*big.Int, *big.Int, error) {
+	tip, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tip, feeCapFor(tip, head), nil
+}
+
+// PercentileFees suggests the priority fee based on the fees paid by recent
+// transactions: the median across the recent blocks of the given percentile of
+// the priority fees within each block, skipping empty blocks. Backends without
+// access to the fee history fall back to the fees suggested by the backend, as
+// do chains without recent transactions.
+type PercentileFees struct {
+	Blocks     uint64   // Number of recent blocks to consider (0 = 20)
+	Percentile float64  // Percentile of the priority fees paid within each block (0-100)
+	MinTip     *big.Int // Lowest priority fee suggested (nil = no minimum)
+}
+
+// SuggestFees implements FeeStrategy.
+func (s PercentileFees) SuggestFees(ctx context.Context, backend ContractTransactor, head *types.Header) (*big.Int, *big.Int, error) {
+	if s.Percentile < 0 || s.Percentile > 100 {
+		return nil, nil, fmt.Errorf("invalid fee percentile %v", s.Percentile)
+	}
+	tip, err := s.suggestTip(ctx, backend)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.MinTip != nil && tip.Cmp(s.MinTip) < 0 {
+		tip = new(big.Int).Set(s.MinTip)
+	}
+	return tip, feeCapFor(tip, head), nil
+}
+
+// suggestTip returns the priority fee paid by recent transactions, or the one
+// suggested by the backend if not known.
+func (s PercentileFees) suggestTip(ctx context.Context, backend ContractTransactor) (*big.Int, error) {
+	reader, ok := backend.(ethereum.FeeHistoryReader)
+	if !ok {
+		return backend.SuggestGasTipCap(ctx)
+	}
+	blocks := s.Blocks
+	if blocks == 0 {
+		blocks = 20
+	}
+	history, err := reader.FeeHistory(ctx, blocks, nil, []float64{s.Percentile})
+	if err != nil {
+		return nil, err
+	}
+	var tips []*big.Int
+	for i, rewards := range history.Reward {
+		if len(rewards) == 0 || rewards[0] == nil {
+			continue
+		}
+		if i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0 {
+			continue // Empty blocks report zero rewards
+		}
+		tips = append(tips, rewards[0])
+	}
+	if len(tips) == 0 {
+		return backend.SuggestGasTipCap(ctx)
+	}
+	slices.SortFunc(tips, (*big.Int).Cmp)
+	return new(big.Int).Set(tips[len(tips)/2]), nil
+}
+
+// CappedFees limits the fees suggested by another strategy, so that a spike of
+// the fee market can't make transactions arbitrarily expensive.
+type CappedFees struct {
+	Strategy  FeeStrategy // Strategy suggesting the fees (nil = SuggestedFees)
+	MaxTip    *big.Int    // Maximum priority fee per gas (nil = unlimited)
+	MaxFeeCap *big.Int    // Maximum fee per gas (nil = unlimited)
+}
+
+// SuggestFees implements FeeStrategy. It fails if the current base fee already
+// exceeds the maximum fee cap, as the transaction couldn't be included.
+func (s CappedFees) SuggestFees(ctx context.Context, backend ContractTransactor, head *types.Header) (*big.Int, *big.Int, error) {
+	strategy := s.Strategy
+	if strategy == nil {
+		strategy = SuggestedFees{}
+	}
+	tip, feeCap, err := strategy.SuggestFees(ctx, backend, head)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.MaxFeeCap != nil {
+		if head != nil && head.BaseFee != nil && head.BaseFee.Cmp(s.MaxFeeCap) > 0 {
+			return nil, nil, fmt.Errorf("base fee %v exceeds the maximum fee cap %v", head.BaseFee, s.MaxFeeCap)
+		}
+		if feeCap.Cmp(s.MaxFeeCap) > 0 {
+			feeCap = new(big.Int).Set(s.MaxFeeCap)
+		}
+	}
+	if s.MaxTip != nil && tip.Cmp(s.MaxTip) > 0 {
+		tip = new(big.Int).Set(s.MaxTip)
+	}
+	if tip.Cmp(feeCap) > 0 {
+		tip = new(big.Int).Set(feeCap)
+	}
+	return tip, feeCap, nil
+}
+
+// feeCapFor returns the fee cap for the given priority fee, leaving room for
+// the base fee of the head to double.
+func feeCapFor(tip *big.Int, head *types.Header) *big.Int {
+	feeCap := new(big.Int).Set(tip)
+	if head != nil && head.BaseFee != nil {
+		feeCap.Add(feeCap, new(big.Int).Mul(head.BaseFee, big.NewInt(basefeeWiggleMultiplier)))
+	}
+	return feeCap
+}
+
+// minBumpPercent is the minimal fee increase for the transaction pool to accept
+// a replacement transaction.
+const minBumpPercent = 10
+
+// ErrBumpLimit is returned by BumpFees if a transaction can't be bumped without
+// exceeding the maximum fee cap.
+var ErrBumpLimit = errors.New("fee bump exceeds the maximum fee cap")
+
+// BumpPolicy configures the replacement of transactions stalling in the pool
+// with copies paying higher fees.
+type BumpPolicy struct {
+	Interval  time.Duration // Time to wait for inclusion before bumping the fees (0 = 1 minute)
+	Percent   uint64        // Fee increase per bump in percent, at least 10 (0 = 12)
+	MaxBumps  int           // Maximum number of replacements (0 = unlimited)
+	MaxFeeCap *big.Int      // Maximum fee cap or gas price of replacements (nil = unlimited)
+}
+
+// BumpBackend wraps the operations needed by WaitMinedWithBump.
+type BumpBackend interface {
+	DeployBackend
+	ethereum.TransactionSender
+}
+
+// WaitMinedWithBump waits for tx to be mined like WaitMined. Whenever the
+// transaction isn't included within the interval of the policy, it is replaced
+// by a copy paying higher fees, signed with the signer of opts. The receipt of
+// whichever version of the transaction got mined is returned.
+//
+// Bumping stops once the maximum number of replacements or the maximum fee cap
+// is reached, the last version of the transaction is waited for thereafter.
+func WaitMinedWithBump(ctx context.Context, backend BumpBackend, opts *TransactOpts, tx *types.Transaction, policy BumpPolicy) (*types.Receipt, error) {
+	if opts.Signer == nil {
+		return nil, errors.New("no signer to authorize the replacement transactions with")
+	}
+	interval := policy.Interval
+	if interval == 0 {
+		interval = time.Minute
+	}
+	percent := policy.Percent
+	if percent == 0 {
+		percent = 12
+	}
+	percent = max(percent, minBumpPercent)
+
+	queryTicker := time.NewTicker(min(interval, time.Second))
+	defer queryTicker.Stop()
+
+	var (
+		hashes   = []common.Hash{tx.Hash()}
+		bumps    = 0
+		bumpable = true
+		lastSent = time.Now()
+		logger   = log.New("nonce", tx.Nonce())
+	)
+	for {
+		for _, hash := range hashes {
+			if receipt, err := backend.TransactionReceipt(ctx, hash); err == nil {
+				return receipt, nil
+			}
+		}
+		if bumpable && time.Since(lastSent) >= interval {
+			next, err := BumpFees(tx, percent, policy.MaxFeeCap)
+			switch {
+			case errors.Is(err, ErrBumpLimit):
+				logger.Debug("Transaction fees reached the limit", "hash", tx.Hash())
+				bumpable = false
+			case err != nil:
+				return nil, err
+			default:
+				signed, err := opts.Signer(opts.From, next)
+				if err != nil {
+					return nil, err
+				}
+				// The original transaction might have been included meanwhile,
+				// so don't fail on the rejection of the replacement.
+				if err := backend.SendTransaction(ctx, signed); err != nil {
+					logger.Debug("Replacement transaction rejected", "hash", signed.Hash(), "err", err)
+				} else {
+					logger.Debug("Replaced stalling transaction", "old", tx.Hash(), "new", signed.Hash())
+					tx, hashes = signed, append(hashes, signed.Hash())
+				}
+				bumps++
+				if policy.MaxBumps > 0 && bumps >= policy.MaxBumps {
+					bumpable = false
+				}
+			}
+			lastSent = time.Now()
+		}
+		// Wait for the next round.
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-queryTicker.C:
+		}
+	}
+}
+
+// BumpFees returns an unsigned copy of the transaction with its fees increased by
+// the given percentage, to replace it in the transaction pool. Fees are raised by
+// at least one wei, and ErrBumpLimit is returned if the fee cap or gas price of
+// the copy would exceed maxFeeCap.
+func BumpFees(tx *types.Transaction, percent uint64, maxFeeCap *big.Int) (*types.Transaction, error) {
+	bump := func(fee *big.Int) *big.Int {
+		bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+		bumped.Div(bumped, big.NewInt(100))
+		if bumped.Cmp(fee) <= 0 {
+			bumped.Add(fee, common.Big1)
+		}
+		return bumped
+	}
+	var inner types.TxData
+	switch tx.Type() {
+	case types.LegacyTxType:
+		price := bump(tx.GasPrice())
+		if maxFeeCap != nil && price.Cmp(maxFeeCap) > 0 {
+			return nil, ErrBumpLimit
+		}
+		inner = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: price,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	case types.AccessListTxType:
+		price := bump(tx.GasPrice())
+		if maxFeeCap != nil && price.Cmp(maxFeeCap) > 0 {
+			return nil, ErrBumpLimit
+		}
+		inner = &types.AccessListTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasPrice:   price,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	case types.DynamicFeeTxType:
+		tip, feeCap := bump(tx.GasTipCap()), bump(tx.GasFeeCap())
+		if maxFeeCap != nil && feeCap.Cmp(maxFeeCap) > 0 {
+			return nil, ErrBumpLimit
+		}
+		inner = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  tip,
+			GasFeeCap:  feeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("fee bumping not supported for transaction type %d", tx.Type())
+	}
+	return types.NewTx(inner), nil
+}
+
+// TransactOptsBuilder assembles TransactOpts step by step, e.g.
+//
+//	opts := bind.NewTransactOptsBuilder(auth).
+//		WithPercentileTip(20, 60).
+//		WithMaxFees(maxTip, maxFeeCap).
+//		WithContext(ctx).
+//		Build()
+type TransactOptsBuilder struct {
+	opts TransactOpts
+}
+
+// NewTransactOptsBuilder creates a builder starting from a copy of the given
+// options, which usually carry the sender and the signer.
+func NewTransactOptsBuilder(base *TransactOpts) *TransactOptsBuilder {
+	b := new(TransactOptsBuilder)
+	if base != nil {
+		b.opts = *base
+	}
+	return b
+}
+
+// WithValue sets the funds to transfer along the transaction.
+func (b *TransactOptsBuilder) WithValue(value *big.Int) *TransactOptsBuilder {
+	b.opts.Value = value
+	return b
+}
+
+// WithNonce sets the nonce of the transaction.
+func (b *TransactOptsBuilder) WithNonce(nonce uint64) *TransactOptsBuilder {
+	b.opts.Nonce = new(big.Int).SetUint64(nonce)
+	return b
+}
+
+// WithNonceManager sets the manager assigning the nonce of the transaction.
+func (b *TransactOptsBuilder) WithNonceManager(manager *NonceManager) *TransactOptsBuilder {
+	b.opts.NonceManager = manager
+	return b
+}
+
+// WithGasLimit sets the gas limit of the transaction.
+func (b *TransactOptsBuilder) WithGasLimit(gas uint64) *TransactOptsBuilder {
+	b.opts.GasLimit = gas
+	return b
+}
+
+// WithAccessList sets the access list of the transaction.
+func (b *TransactOptsBuilder) WithAccessList(accessList types.AccessList) *TransactOptsBuilder {
+	b.opts.AccessList = accessList
+	return b
+}
+
+// WithContext sets the context of the network requests.
+func (b *TransactOptsBuilder) WithContext(ctx context.Context) *TransactOptsBuilder {
+	b.opts.Context = ctx
+	return b
+}
+
+// WithGasPrice sets the gas price, creating a legacy transaction.
+func (b *TransactOptsBuilder) WithGasPrice(price *big.Int) *TransactOptsBuilder {
+	b.opts.GasPrice = price
+	return b
+}
+
+// WithFees sets fixed dynamic fees for the transaction.
+func (b *TransactOptsBuilder) WithFees(gasTipCap, gasFeeCap *big.Int) *TransactOptsBuilder {
+	b.opts.GasTipCap, b.opts.GasFeeCap = gasTipCap, gasFeeCap
+	return b
+}
+
+// WithFeeStrategy sets the strategy suggesting the dynamic fees.
+func (b *TransactOptsBuilder) WithFeeStrategy(strategy FeeStrategy) *TransactOptsBuilder {
+	b.opts.FeeStrategy = strategy
+	return b
+}
+
+// WithPercentileTip suggests the priority fee based on the given percentile of
+// the fees paid within the recent blocks, see PercentileFees.
+func (b *TransactOptsBuilder) WithPercentileTip(blocks uint64, percentile float64) *TransactOptsBuilder {
+	return b.WithFeeStrategy(PercentileFees{Blocks: blocks, Percentile: percentile})
+}
+
+// WithMaxFees caps the fees suggested by the fee strategy configured so far.
+// Either limit may be nil.
+func (b *TransactOptsBuilder) WithMaxFees(maxTip, maxFeeCap *big.Int) *TransactOptsBuilder {
+	return b.WithFeeStrategy(CappedFees{Strategy: b.opts.FeeStrategy, MaxTip: maxTip, MaxFeeCap: maxFeeCap})
+}
+
+// WithNoSend makes the transaction to be signed but not sent.
+func (b *TransactOptsBuilder) WithNoSend() *TransactOptsBuilder {
+	b.opts.NoSend = true
+	return b
+}
+
+// Build returns the assembled options. The builder may be reused afterwards
+// without affecting the returned options.
+func (b *TransactOptsBuilder) Build() *TransactOpts {
+	opts := b.opts
+	return &opts
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockFeeHistoryTransactor is a transactor with access to the fee history.
+type mockFeeHistoryTransactor struct {
+	mockTransactor
+	rewards []int64
+	ratios  []float64
+}
+
+func (mt *mockFeeHistoryTransactor) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	history := &ethereum.FeeHistory{GasUsedRatio: mt.ratios}
+	for _, reward := range mt.rewards {
+		history.Reward = append(history.Reward, []*big.Int{big.NewInt(reward)})
+	}
+	return history, nil
+}
+
+func TestTransactFeeStrategies(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	mt := &mockFeeHistoryTransactor{
+		mockTransactor: mockTransactor{baseFee: big.NewInt(100), gasTipCap: big.NewInt(5)},
+		rewards:        []int64{9, 3, 7, 1, 8},
+	}
+	bc := bind.NewBoundContract(common.Address{}, abi.ABI{}, nil, mt, nil)
+
+	// The percentile strategy uses the median of the recent tips
+	opts := bind.NewTransactOptsBuilder(&bind.TransactOpts{Signer: mockSign}).WithPercentileTip(5, 50).Build()
+	tx, err := bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(big.NewInt(7), tx.GasTipCap())
+	assert.Equal(big.NewInt(207), tx.GasFeeCap())
+	assert.False(mt.suggestGasTipCapCalled)
+
+	// Empty blocks report zero tips and must be skipped
+	mt.rewards, mt.ratios = []int64{0, 0, 6, 0}, []float64{0, 0, 0.5, 0}
+	tx, err = bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(big.NewInt(6), tx.GasTipCap())
+	mt.rewards, mt.ratios = []int64{9, 3, 7, 1, 8}, nil
+
+	// Capping limits the fees of the underlying strategy
+	opts = bind.NewTransactOptsBuilder(opts).WithMaxFees(big.NewInt(4), big.NewInt(150)).Build()
+	tx, err = bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(big.NewInt(4), tx.GasTipCap())
+	assert.Equal(big.NewInt(150), tx.GasFeeCap())
+
+	// A fee cap below the base fee can't be satisfied
+	opts = bind.NewTransactOptsBuilder(&bind.TransactOpts{Signer: mockSign}).WithMaxFees(nil, big.NewInt(99)).Build()
+	_, err = bc.Transact(opts, "")
+	assert.NotNil(err)
+
+	// Explicit fees take precedence over the strategy
+	opts = bind.NewTransactOptsBuilder(opts).WithFees(big.NewInt(2), big.NewInt(300)).Build()
+	tx, err = bc.Transact(opts, "")
+	assert.Nil(err)
+	assert.Equal(big.NewInt(2), tx.GasTipCap())
+	assert.Equal(big.NewInt(300), tx.GasFeeCap())
+}
+
+// mockBumpBackend mines a transaction once its fee cap reaches a threshold.
+type mockBumpBackend struct {
+	mu        sync.Mutex
+	threshold *big.Int
+	sent      []*types.Transaction
+}
+
+func (mb *mockBumpBackend) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	for _, tx := range mb.sent {
+		if tx.Hash() == hash && tx.GasFeeCap().Cmp(mb.threshold) >= 0 {
+			return &types.Receipt{TxHash: hash}, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+func (mb *mockBumpBackend) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (mb *mockBumpBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	mb.mu.Lock()
+	defer mb.mu.Unlock()
+	mb.sent = append(mb.sent, tx)
+	return nil
+}
+
+func TestWaitMinedWithBump(t *testing.T) {
+	t.Parallel()
+
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 3, GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), Gas: 21000})
+	backend := &mockBumpBackend{threshold: big.NewInt(120), sent: []*types.Transaction{tx}}
+	opts := &bind.TransactOpts{Signer: mockSign}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := bind.WaitMinedWithBump(ctx, backend, opts, tx, bind.BumpPolicy{Interval: time.Millisecond, Percent: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two bumps of 10% are needed to reach the threshold
+	if len(backend.sent) != 3 {
+		t.Fatalf("wrong number of transactions sent: have %d, want 3", len(backend.sent))
+	}
+	mined := backend.sent[2]
+	if receipt.TxHash != mined.Hash() {
+		t.Fatalf("receipt of wrong transaction: have %x, want %x", receipt.TxHash, mined.Hash())
+	}
+	if mined.Nonce() != 3 || mined.GasTipCap().Cmp(big.NewInt(12)) != 0 || mined.GasFeeCap().Cmp(big.NewInt(121)) != 0 {
+		t.Fatalf("wrong replacement: nonce %d, tip %v, fee cap %v", mined.Nonce(), mined.GasTipCap(), mined.GasFeeCap())
+	}
+	// Bumping stops at the maximum fee cap
+	backend = &mockBumpBackend{threshold: big.NewInt(1000), sent: []*types.Transaction{tx}}
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = bind.WaitMinedWithBump(ctx, backend, opts, tx, bind.BumpPolicy{Interval: time.Millisecond, MaxFeeCap: big.NewInt(130)})
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	if len(backend.sent) != 3 {
+		t.Fatalf("wrong number of transactions sent: have %d, want 3", len(backend.sent))
+	}
+}
+
+func TestBumpFees(t *testing.T) {
+	t.Parallel()
+
+	accessList := types.AccessList{{Address: common.Address{1}}}
+	for i, tt := range []struct {
+		tx      types.TxData
+		tip     int64
+		feeCap  int64
+		limited bool
+	}{
+		{tx: &types.LegacyTx{GasPrice: big.NewInt(100)}, tip: 112, feeCap: 112},
+		{tx: &types.AccessListTx{GasPrice: big.NewInt(100), AccessList: accessList}, tip: 112, feeCap: 112},
+		{tx: &types.DynamicFeeTx{GasTipCap: big.NewInt(10), GasFeeCap: big.NewInt(100), AccessList: accessList}, tip: 11, feeCap: 112},
+		// Fees too small for the percentage must still rise
+		{tx: &types.DynamicFeeTx{GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2)}, tip: 2, feeCap: 3},
+		{tx: &types.AccessListTx{GasPrice: big.NewInt(120)}, limited: true},
+	} {
+		tx := types.NewTx(tt.tx)
+		bumped, err := bind.BumpFees(tx, 12, big.NewInt(130))
+		if tt.limited {
+			if err != bind.ErrBumpLimit {
+				t.Errorf("test %d: expected bump limit error, got %v", i, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("test %d: failed to bump fees: %v", i, err)
+		}
+		if bumped.Type() != tx.Type() || len(bumped.AccessList()) != len(tx.AccessList()) {
+			t.Errorf("test %d: transaction mismatch: type %d, access list %v", i, bumped.Type(), bumped.AccessList())
+		}
+		if bumped.GasTipCap().Int64() != tt.tip || bumped.GasFeeCap().Int64() != tt.feeCap {
+			t.Errorf("test %d: fee mismatch: have tip %v, fee cap %v, want %d, %d", i, bumped.GasTipCap(), bumped.GasFeeCap(), tt.tip, tt.feeCap)
+		}
+	}
+}
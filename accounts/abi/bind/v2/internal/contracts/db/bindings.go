@@ -80,6 +80,30 @@ func (dB *DB) TryPackGet(k *big.Int) ([]byte, error) {
 	return dB.abi.Pack("get", k)
 }
 
+// EstimateGasGet estimates the gas needed to invoke the contract method
+// with ID 0x9507d39a on the given instance.
+//
+// Solidity: function get(uint256 k) returns(uint256)
+func (dB *DB) EstimateGasGet(instance *bind.BoundContract, opts *bind.TransactOpts, k *big.Int) (uint64, error) {
+	enc, err := dB.TryPackGet(k)
+	if err != nil {
+		return 0, err
+	}
+	return bind.EstimateGas(instance, opts, enc)
+}
+
+// CreateAccessListGet generates the access list of an invocation of the
+// contract method with ID 0x9507d39a on the given instance.
+//
+// Solidity: function get(uint256 k) returns(uint256)
+func (dB *DB) CreateAccessListGet(instance *bind.BoundContract, opts *bind.TransactOpts, k *big.Int) (*bind.AccessListResult, error) {
+	enc, err := dB.TryPackGet(k)
+	if err != nil {
+		return nil, err
+	}
+	return bind.CreateAccessList(instance, opts, enc)
+}
+
 // UnpackGet is the Go binding that unpacks the parameters returned
 // from invoking the contract method with ID 0x9507d39a.
 //
@@ -242,6 +266,30 @@ func (dB *DB) TryPackInsert(k *big.Int, v *big.Int) ([]byte, error) {
 	return dB.abi.Pack("insert", k, v)
 }
 
+// EstimateGasInsert estimates the gas needed to invoke the contract method
+// with ID 0x1d834a1b on the given instance.
+//
+// Solidity: function insert(uint256 k, uint256 v) returns(uint256)
+func (dB *DB) EstimateGasInsert(instance *bind.BoundContract, opts *bind.TransactOpts, k *big.Int, v *big.Int) (uint64, error) {
+	enc, err := dB.TryPackInsert(k, v)
+	if err != nil {
+		return 0, err
+	}
+	return bind.EstimateGas(instance, opts, enc)
+}
+
+// CreateAccessListInsert generates the access list of an invocation of the
+// contract method with ID 0x1d834a1b on the given instance.
+//
+// Solidity: function insert(uint256 k, uint256 v) returns(uint256)
+func (dB *DB) CreateAccessListInsert(instance *bind.BoundContract, opts *bind.TransactOpts, k *big.Int, v *big.Int) (*bind.AccessListResult, error) {
+	enc, err := dB.TryPackInsert(k, v)
+	if err != nil {
+		return nil, err
+	}
+	return bind.CreateAccessList(instance, opts, enc)
+}
+
 // UnpackInsert is the Go binding that unpacks the parameters returned
 // from invoking the contract method with ID 0x1d834a1b.
 //
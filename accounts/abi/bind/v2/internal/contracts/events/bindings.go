@@ -73,6 +73,30 @@ func (c *C) TryPackEmitMulti() ([]byte, error) {
 	return c.abi.Pack("EmitMulti")
 }
 
+// EstimateGasEmitMulti estimates the gas needed to invoke the contract method
+// with ID 0xcb493749 on the given instance.
+//
+// Solidity: function EmitMulti() returns()
+func (c *C) EstimateGasEmitMulti(instance *bind.BoundContract, opts *bind.TransactOpts) (uint64, error) {
+	enc, err := c.TryPackEmitMulti()
+	if err != nil {
+		return 0, err
+	}
+	return bind.EstimateGas(instance, opts, enc)
+}
+
+// CreateAccessListEmitMulti generates the access list of an invocation of the
+// contract method with ID 0xcb493749 on the given instance.
+//
+// Solidity: function EmitMulti() returns()
+func (c *C) CreateAccessListEmitMulti(instance *bind.BoundContract, opts *bind.TransactOpts) (*bind.AccessListResult, error) {
+	enc, err := c.TryPackEmitMulti()
+	if err != nil {
+		return nil, err
+	}
+	return bind.CreateAccessList(instance, opts, enc)
+}
+
 // PackEmitOne is the Go binding used to pack the parameters required for calling
 // the contract method with ID 0xe8e49a71.  This method will panic if any
 // invalid/nil inputs are passed.
@@ -95,6 +119,30 @@ func (c *C) TryPackEmitOne() ([]byte, error) {
 	return c.abi.Pack("EmitOne")
 }
 
+// EstimateGasEmitOne estimates the gas needed to invoke the contract method
+// with ID 0xe8e49a71 on the given instance.
+//
+// Solidity: function EmitOne() returns()
+func (c *C) EstimateGasEmitOne(instance *bind.BoundContract, opts *bind.TransactOpts) (uint64, error) {
+	enc, err := c.TryPackEmitOne()
+	if err != nil {
+		return 0, err
+	}
+	return bind.EstimateGas(instance, opts, enc)
+}
+
+// CreateAccessListEmitOne generates the access list of an invocation of the
+// contract method with ID 0xe8e49a71 on the given instance.
+//
+// Solidity: function EmitOne() returns()
+func (c *C) CreateAccessListEmitOne(instance *bind.BoundContract, opts *bind.TransactOpts) (*bind.AccessListResult, error) {
+	enc, err := c.TryPackEmitOne()
+	if err != nil {
+		return nil, err
+	}
+	return bind.CreateAccessList(instance, opts, enc)
+}
+
 // CBasic1 represents a basic1 event raised by the C contract.
 type CBasic1 struct {
 	Id   *big.Int
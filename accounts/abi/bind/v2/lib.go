@@ -22,6 +22,8 @@
 //
 // Two methods for contract deployment are provided:
 //   - [DeployContract] is intended to be used for deployment of a single contract.
+//   - [DeployContract2] deploys a single contract at a deterministic address
+//     through a CREATE2 factory.
 //   - [LinkAndDeploy] is intended for the deployment of multiple
 //     contracts, potentially with library dependencies.
 package bind
@@ -29,6 +31,7 @@ package bind
 import (
 	"errors"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -38,6 +41,11 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 )
 
+// DefaultCreate2Factory is the address of the deterministic deployment proxy
+// (https://github.com/Arachnid/deterministic-deployment-proxy), available at the
+// same address on most chains. It's used by DeployContract2 if no factory is given.
+var DefaultCreate2Factory = common.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+
 // ContractEvent is a type constraint for ABI event types.
 type ContractEvent interface {
 	ContractEventName() string
@@ -76,6 +84,24 @@ func WatchEvents[Ev ContractEvent](c *BoundContract, opts *WatchOpts, unpack fun
 	if err != nil {
 		return nil, err
 	}
+	return forwardEvents(logs, sub, unpack, sink), nil
+}
+
+// WatchEventsResilient is like WatchEvents, but the subscription survives
+// failures of the underlying transport, backfilling the events missed while
+// disconnected. See BoundContract.WatchLogsResilient for details.
+func WatchEventsResilient[Ev ContractEvent](c *BoundContract, opts *ResilientWatchOpts, unpack func(*types.Log) (*Ev, error), sink chan<- *Ev, topics ...[]any) (event.Subscription, error) {
+	var e Ev
+	logs, sub, err := c.WatchLogsResilient(opts, e.ContractEventName(), topics...)
+	if err != nil {
+		return nil, err
+	}
+	return forwardEvents(logs, sub, unpack, sink), nil
+}
+
+// forwardEvents unpacks the logs delivered by a subscription and forwards the
+// events to sink.
+func forwardEvents[Ev any](logs <-chan types.Log, sub event.Subscription, unpack func(*types.Log) (*Ev, error), sink chan<- *Ev) event.Subscription {
 	return event.NewSubscription(func(quit <-chan struct{}) error {
 		defer sub.Unsubscribe()
 		for {
@@ -100,7 +126,7 @@ func WatchEvents[Ev ContractEvent](c *BoundContract, opts *WatchOpts, unpack fun
 				return nil
 			}
 		}
-	}), nil
+	})
 }
 
 // EventIterator is an object for iterating over the results of a event log
@@ -211,6 +237,24 @@ func Transact(c *BoundContract, opt *TransactOpts, data []byte) (*types.Transact
 	return c.RawTransact(opt, data)
 }
 
+// EstimateGas estimates the gas needed to invoke a contract with the given
+// input data.
+//
+// EstimateGas is identical to BoundContract.RawEstimateGas, and is provided as
+// a package-level method for consistency with Transact.
+func EstimateGas(c *BoundContract, opts *TransactOpts, data []byte) (uint64, error) {
+	return c.RawEstimateGas(opts, data)
+}
+
+// CreateAccessList generates the access list of an invocation of a contract
+// with the given input data.
+//
+// CreateAccessList is identical to BoundContract.RawCreateAccessList, and is
+// provided as a package-level method for consistency with Transact.
+func CreateAccessList(c *BoundContract, opts *TransactOpts, data []byte) (*AccessListResult, error) {
+	return c.RawCreateAccessList(opts, data)
+}
+
 // DeployContract creates and submits a deployment transaction based on the
 // deployer bytecode and optional ABI-encoded constructor input.  It returns
 // the address and creation transaction of the pending contract, or an error
@@ -228,6 +272,41 @@ func DeployContract(opts *TransactOpts, bytecode []byte, backend ContractBackend
 	return crypto.CreateAddress(opts.From, tx.Nonce()), tx, nil
 }
 
+// DeployContract2 creates and submits a transaction deploying the contract
+// through a CREATE2 factory, making its address depend only on the factory, the
+// salt and the deployment code rather than on the sender and its nonce. The same
+// contract is thus deployed at the same address on every chain the factory is
+// available on.
+//
+// The factory is expected to accept the salt followed by the deployment code as
+// its calldata, as the deterministic deployment proxy at DefaultCreate2Factory
+// does, which is used if the factory is the zero address. It returns the
+// precomputed address of the contract and the deployment transaction.
+func DeployContract2(opts *TransactOpts, factory common.Address, salt common.Hash, bytecode []byte, backend ContractBackend, constructorInput []byte) (common.Address, *types.Transaction, error) {
+	if factory == (common.Address{}) {
+		factory = DefaultCreate2Factory
+	}
+	calldata := append(salt.Bytes(), bytecode...)
+	calldata = append(calldata, constructorInput...)
+
+	c := NewBoundContract(factory, abi.ABI{}, backend, backend, backend)
+	tx, err := c.RawTransact(opts, calldata)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return Create2Address(factory, salt, bytecode, constructorInput), tx, nil
+}
+
+// Create2Address returns the address a contract is deployed at by DeployContract2
+// through the given factory with the given salt.
+func Create2Address(factory common.Address, salt common.Hash, bytecode []byte, constructorInput []byte) common.Address {
+	if factory == (common.Address{}) {
+		factory = DefaultCreate2Factory
+	}
+	initcode := append(slices.Clone(bytecode), constructorInput...)
+	return crypto.CreateAddress2(factory, salt, crypto.Keccak256(initcode))
+}
+
 // DefaultDeployer returns a DeployFn that signs and submits creation transactions
 // using the given signer.
 //
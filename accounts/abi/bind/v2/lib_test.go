@@ -17,6 +17,7 @@
 package bind_test
 
 import (
+	"bytes"
 	"context"
 	"math/big"
 	"testing"
@@ -392,3 +393,44 @@ func TestEventUnpackEmptyTopics(t *testing.T) {
 		}
 	}
 }
+
+// TestDeployContract2 tests that contracts deployed through a CREATE2 factory
+// end up at the precomputed address.
+func TestDeployContract2(t *testing.T) {
+	// Runtime code of the deterministic deployment proxy
+	factoryCode := common.FromHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+	backend := simulated.NewBackend(types.GenesisAlloc{
+		testAddr:                   {Balance: big.NewInt(10000000000000000)},
+		bind.DefaultCreate2Factory: {Code: factoryCode},
+	})
+	defer backend.Close()
+	client := backend.Client()
+
+	chainID, _ := client.ChainID(context.Background())
+	opts := bind.NewKeyedTransactor(testKey, chainID)
+
+	var (
+		// Deployment code of a contract returning 42 to any call
+		initcode = common.FromHex("0x69602a60005260206000f3600052600a6016f3")
+		salt     = common.HexToHash("0x01")
+	)
+	addr, tx, err := bind.DeployContract2(opts, common.Address{}, salt, initcode, client, nil)
+	if err != nil {
+		t.Fatalf("failed to deploy contract: %v", err)
+	}
+	if want := bind.Create2Address(bind.DefaultCreate2Factory, salt, initcode, nil); addr != want {
+		t.Fatalf("address mismatch: have %v, want %v", addr, want)
+	}
+	backend.Commit()
+	if _, err := bind.WaitMined(context.Background(), client, tx.Hash()); err != nil {
+		t.Fatalf("failed to wait for deployment: %v", err)
+	}
+	code, err := client.CodeAt(context.Background(), addr, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.FromHex("0x602a60005260206000f3"); !bytes.Equal(code, want) {
+		t.Fatalf("deployed code mismatch: have %x, want %x", code, want)
+	}
+}
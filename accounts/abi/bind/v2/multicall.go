@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrMulticallPending is returned when retrieving the result of a batched call
+// before the batch containing it was executed.
+var ErrMulticallPending = errors.New("multicall batch not executed")
+
+// MulticallBatch collects contract calls to be executed together through the
+// Multicall3 contract, using a single eth_call.
+type MulticallBatch struct {
+	caller  ContractCaller
+	address common.Address
+
+	lock    sync.Mutex
+	calls   []abi.MulticallCall
+	results []func(abi.MulticallResult)
+}
+
+// NewMulticallBatch creates an empty batch executing its calls through the
+// Multicall3 contract deployed at the canonical address.
+func NewMulticallBatch(caller ContractCaller) *MulticallBatch {
+	return NewMulticallBatchAt(caller, abi.Multicall3Address)
+}
+
+// NewMulticallBatchAt creates an empty batch executing its calls through the
+// Multicall3 contract deployed at the given address.
+func NewMulticallBatchAt(caller ContractCaller, address common.Address) *MulticallBatch {
+	return &MulticallBatch{caller: caller, address: address}
+}
+
+// Add enqueues a call into the batch. The result callback is invoked with the
+// outcome of the call once the batch is executed.
+func (b *MulticallBatch) Add(call abi.MulticallCall, result func(abi.MulticallResult)) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.calls = append(b.calls, call)
+	b.results = append(b.results, result)
+}
+
+// Len returns the number of calls pending in the batch.
+func (b *MulticallBatch) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return len(b.calls)
+}
+
+// Execute runs all pending calls in one Multicall3 aggregation and delivers
+// their results. The batch is emptied afterwards, so it can be reused for a
+// new set of calls. Calls failing individually don't fail the batch, their
+// errors are reported through their results instead.
+func (b *MulticallBatch) Execute(opts *CallOpts) error {
+	b.lock.Lock()
+	calls, results := b.calls, b.results
+	b.calls, b.results = nil, nil
+	b.lock.Unlock()
+
+	if len(calls) == 0 {
+		return nil
+	}
+	input, err := abi.PackMulticall(calls)
+	if err != nil {
+		return err
+	}
+	output, err := NewBoundContract(b.address, abi.ABI{}, b.caller, nil, nil).CallRaw(opts, input)
+	if err != nil {
+		return err
+	}
+	outcomes, err := abi.UnpackMulticall(calls, output)
+	if err != nil {
+		return err
+	}
+	for i, outcome := range outcomes {
+		results[i](outcome)
+	}
+	return nil
+}
+
+// MulticallValue is the typed result of a call enqueued into a MulticallBatch,
+// available once the batch was executed.
+type MulticallValue[T any] struct {
+	value T
+	err   error
+}
+
+// Get returns the result of the call, or ErrMulticallPending if the batch was
+// not executed yet.
+func (v *MulticallValue[T]) Get() (T, error) {
+	return v.value, v.err
+}
+
+// AddMulticall enqueues a call into the batch, converting its return values
+// with the given function once the batch is executed.
+func AddMulticall[T any](b *MulticallBatch, call abi.MulticallCall, convert func([]any) (T, error)) *MulticallValue[T] {
+	value := &MulticallValue[T]{err: ErrMulticallPending}
+	b.Add(call, func(res abi.MulticallResult) {
+		if res.Err != nil {
+			value.err = res.Err
+			return
+		}
+		value.value, value.err = convert(res.Values)
+	})
+	return value
+}
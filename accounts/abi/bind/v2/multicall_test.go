@@ -0,0 +1,95 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMulticallBatch(t *testing.T) {
+	t.Parallel()
+
+	token, err := abi.ParseHumanReadable([]string{"function balanceOf(address owner) view returns (uint256)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	multicall, err := abi.ParseHumanReadable([]string{
+		"function aggregate3((address target, bool allowFailure, bytes callData)[] calls) payable returns ((bool success, bytes returnData)[] returnData)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		mc    = new(mockCaller)
+		batch = bind.NewMulticallBatch(mc)
+		call  = abi.MulticallCall{Target: common.Address{1}, AllowFailure: true, ABI: token, Method: "balanceOf", Args: []any{common.Address{2}}}
+	)
+	convert := func(out []any) (*big.Int, error) {
+		return *abi.ConvertType(out[0], new(*big.Int)).(**big.Int), nil
+	}
+	first := bind.AddMulticall(batch, call, convert)
+	second := bind.AddMulticall(batch, call, convert)
+
+	if _, err := first.Get(); err != bind.ErrMulticallPending {
+		t.Fatalf("unexecuted call error mismatch: have %v, want %v", err, bind.ErrMulticallPending)
+	}
+	// Answer the first call and revert the second one
+	balance, err := token.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reason, err := abi.Arguments{{Type: abi.Type{T: abi.StringTy}}}.Pack("nope")
+	if err != nil {
+		t.Fatal(err)
+	}
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+	mc.callContractBytes, err = multicall.Methods["aggregate3"].Outputs.Pack([]result{
+		{Success: true, ReturnData: balance},
+		{Success: false, ReturnData: append(common.FromHex("0x08c379a0"), reason...)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Execute(&bind.CallOpts{BlockNumber: big.NewInt(7)}); err != nil {
+		t.Fatalf("failed to execute batch: %v", err)
+	}
+	if mc.callContractBlockNumber.Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("wrong block number: have %v, want 7", mc.callContractBlockNumber)
+	}
+	if value, err := first.Get(); err != nil || value.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("wrong result: have %v (%v), want 42", value, err)
+	}
+	if _, err := second.Get(); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Fatalf("wrong revert error: %v", err)
+	}
+	// The executed batch is emptied
+	if batch.Len() != 0 {
+		t.Fatalf("batch not emptied: %d calls left", batch.Len())
+	}
+	if err := batch.Execute(nil); err != nil {
+		t.Fatalf("failed to execute empty batch: %v", err)
+	}
+}
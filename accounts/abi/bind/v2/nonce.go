@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NonceManager assigns the nonces of transactions sent concurrently from the
+// same accounts. Without it, every transaction reads its nonce from the pending
+// state, so transactions created at the same time end up with the same nonce.
+//
+// The manager serializes the transactions of each account, from the assignment
+// of the nonce until the transaction is sent, and tracks the next nonce locally.
+// If a transaction fails to be created or sent, its nonce is re-read from the
+// pending state for the next transaction, so that failures don't leave gaps.
+// Transactions created with NoSend aren't broadcast, so they don't use up the
+// nonce they were assigned either.
+//
+// A manager is used by setting it in TransactOpts.NonceManager, and should be
+// shared by all options sending from the same accounts.
+type NonceManager struct {
+	lock     sync.Mutex
+	accounts map[common.Address]*accountNonce
+}
+
+// accountNonce is the nonce state of a single account.
+type accountNonce struct {
+	lock  sync.Mutex // Held while a transaction of the account is created and sent
+	next  uint64     // Nonce of the next transaction
+	known bool       // Whether next is tracked, or needs to be read from the pending state
+}
+
+// NewNonceManager creates a nonce manager without any tracked accounts.
+func NewNonceManager() *NonceManager {
+	return &NonceManager{accounts: make(map[common.Address]*accountNonce)}
+}
+
+// Reset forgets the nonce of the given account, making the manager read it from
+// the pending state again. It's needed if transactions of the account are sent
+// bypassing the manager.
+func (m *NonceManager) Reset(account common.Address) {
+	state := m.account(account)
+
+	state.lock.Lock()
+	state.known = false
+	state.lock.Unlock()
+}
+
+// account returns the nonce state of the given account.
+func (m *NonceManager) account(account common.Address) *accountNonce {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	state, ok := m.accounts[account]
+	if !ok {
+		state = new(accountNonce)
+		m.accounts[account] = state
+	}
+	return state
+}
+
+// acquire locks the given account and returns the nonce of its next transaction.
+// The returned function must be called once the transaction is sent or failed,
+// which unlocks the account.
+func (m *NonceManager) acquire(ctx context.Context, backend ContractTransactor, account common.Address) (uint64, func(sent bool), error) {
+	state := m.account(account)
+
+	state.lock.Lock()
+	if !state.known {
+		nonce, err := backend.PendingNonceAt(ctx, account)
+		if err != nil {
+			state.lock.Unlock()
+			return 0, nil, err
+		}
+		state.next, state.known = nonce, true
+	}
+	done := func(sent bool) {
+		if sent {
+			state.next++
+		} else {
+			state.known = false
+		}
+		state.lock.Unlock()
+	}
+	return state.next, done, nil
+}
+
+// transactManaged executes a transaction invocation with its nonce assigned by
+// the nonce manager of the options.
+func (c *BoundContract) transactManaged(opts *TransactOpts, contract *common.Address, input []byte) (*types.Transaction, error) {
+	nonce, done, err := opts.NonceManager.acquire(ensureContext(opts.Context), c.transactor, opts.From)
+	if err != nil {
+		return nil, err
+	}
+	// Don't modify the options, they may be shared across goroutines
+	managed := *opts
+	managed.Nonce = new(big.Int).SetUint64(nonce)
+
+	tx, err := c.transact(&managed, contract, input)
+	done(err == nil && !opts.NoSend)
+	return tx, err
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// mockNonceTransactor is a transactor whose pending nonce is the number of
+// transactions it accepted, optionally rejecting transactions.
+type mockNonceTransactor struct {
+	*mockTransactor
+
+	lock    sync.Mutex
+	sent    map[uint64]bool
+	queries int
+	reject  bool
+}
+
+func (mt *mockNonceTransactor) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	mt.queries++
+	return uint64(len(mt.sent)), nil
+}
+
+func (mt *mockNonceTransactor) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	mt.lock.Lock()
+	defer mt.lock.Unlock()
+
+	if mt.reject {
+		return errors.New("rejected")
+	}
+	if mt.sent[tx.Nonce()] {
+		return errors.New("nonce too low")
+	}
+	mt.sent[tx.Nonce()] = true
+	return nil
+}
+
+func TestNonceManager(t *testing.T) {
+	t.Parallel()
+
+	mt := &mockNonceTransactor{
+		mockTransactor: &mockTransactor{gasPrice: big.NewInt(1)},
+		sent:           make(map[uint64]bool),
+	}
+	var (
+		bc   = bind.NewBoundContract(common.Address{1}, abi.ABI{}, nil, mt, nil)
+		opts = bind.NewTransactOptsBuilder(&bind.TransactOpts{Signer: mockSign, GasLimit: 21000}).
+			WithNonceManager(bind.NewNonceManager()).
+			Build()
+	)
+	// Concurrent transactions get consecutive nonces
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := bc.RawTransact(opts, nil); err != nil {
+				t.Errorf("failed to transact: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if len(mt.sent) != 16 || mt.queries != 1 {
+		t.Fatalf("nonce assignment mismatch: %d nonces with %d queries, want 16 nonces with 1 query", len(mt.sent), mt.queries)
+	}
+	if opts.Nonce != nil {
+		t.Fatalf("shared options modified: nonce %v", opts.Nonce)
+	}
+	// A failed transaction doesn't leave a gap
+	mt.reject = true
+	if _, err := bc.RawTransact(opts, nil); err == nil {
+		t.Fatal("rejected transaction succeeded")
+	}
+	mt.reject = false
+	tx, err := bc.RawTransact(opts, nil)
+	if err != nil {
+		t.Fatalf("failed to transact: %v", err)
+	}
+	if tx.Nonce() != 16 {
+		t.Fatalf("nonce mismatch after failure: have %d, want 16", tx.Nonce())
+	}
+	// Transactions which aren't sent don't use up their nonce
+	noSend := bind.NewTransactOptsBuilder(opts).WithNoSend().Build()
+	if tx, err := bc.RawTransact(noSend, nil); err != nil || tx.Nonce() != 17 {
+		t.Fatalf("unsent transaction nonce mismatch: %v", err)
+	}
+	if tx, err := bc.RawTransact(opts, nil); err != nil || tx.Nonce() != 17 {
+		t.Fatalf("unsent transaction left a nonce gap: %v", err)
+	}
+	// Explicit nonces bypass the manager
+	explicit := bind.NewTransactOptsBuilder(opts).WithNonce(100).Build()
+	if tx, err := bc.RawTransact(explicit, nil); err != nil || tx.Nonce() != 100 {
+		t.Fatalf("explicit nonce not used: %v", err)
+	}
+}
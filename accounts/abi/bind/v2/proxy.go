@@ -0,0 +1,206 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxProxyDepth is the maximum number of proxies delegating to each other that
+// are followed when resolving the implementation of a contract.
+const maxProxyDepth = 8
+
+var (
+	// ErrNotProxy is returned when the contract at the given address doesn't
+	// follow any of the supported proxy patterns.
+	ErrNotProxy = errors.New("contract is not a known proxy")
+
+	// eip1967ImplementationSlot is the storage slot holding the implementation
+	// address of an EIP-1967 proxy: keccak256("eip1967.proxy.implementation") - 1.
+	eip1967ImplementationSlot = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// eip1967BeaconSlot is the storage slot holding the beacon address of an
+	// EIP-1967 beacon proxy: keccak256("eip1967.proxy.beacon") - 1.
+	eip1967BeaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+	// eip1167Prefix and eip1167Suffix surround the implementation address in
+	// the runtime code of an EIP-1167 minimal proxy.
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d73")
+	eip1167Suffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+
+	// beaconABI is the interface of an EIP-1967 beacon.
+	beaconABI = func() abi.ABI {
+		parsed, err := abi.ParseHumanReadable([]string{"function implementation() view returns (address)"})
+		if err != nil {
+			panic(err)
+		}
+		return parsed
+	}()
+)
+
+// ProxyKind is the pattern a proxy contract delegates its calls by.
+type ProxyKind int
+
+const (
+	ProxyEIP1967       ProxyKind = iota + 1 // Implementation address in the EIP-1967 storage slot
+	ProxyEIP1967Beacon                      // Implementation provided by the beacon in the EIP-1967 storage slot
+	ProxyEIP1167                            // Implementation address embedded in EIP-1167 minimal proxy code
+)
+
+// String implements fmt.Stringer.
+func (k ProxyKind) String() string {
+	switch k {
+	case ProxyEIP1967:
+		return "EIP-1967"
+	case ProxyEIP1967Beacon:
+		return "EIP-1967 beacon"
+	case ProxyEIP1167:
+		return "EIP-1167"
+	default:
+		return fmt.Sprintf("ProxyKind(%d)", int(k))
+	}
+}
+
+// ProxyInfo describes a detected proxy contract.
+type ProxyInfo struct {
+	Kind           ProxyKind      // Pattern the proxy delegates its calls by
+	Proxy          common.Address // Address of the proxy, which is also the address its events are emitted with
+	Implementation common.Address // Address of the contract the calls are delegated to
+	Beacon         common.Address // Address of the beacon providing the implementation, if any
+}
+
+// ProxyReader defines the methods needed to detect proxy contracts.
+type ProxyReader interface {
+	ContractCaller
+
+	// StorageAt returns the value of the storage slot of the given account.
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// ProxyBackend defines the methods needed to work with contracts through a
+// proxy contract delegating to them.
+type ProxyBackend interface {
+	ContractBackend
+
+	// StorageAt returns the value of the storage slot of the given account.
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// ABILookup retrieves the ABI of the contract deployed at the given address,
+// e.g. from a local registry or a block explorer.
+type ABILookup func(address common.Address) (*abi.ABI, error)
+
+// DetectProxy checks whether the contract at the given address is an EIP-1967
+// or EIP-1167 proxy, returning ErrNotProxy if it's neither. Only the context and
+// block number of the call options are used.
+func DetectProxy(opts *CallOpts, backend ProxyReader, address common.Address) (*ProxyInfo, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	ctx := ensureContext(opts.Context)
+
+	// Minimal proxies embed the implementation into their code
+	code, err := backend.CodeAt(ctx, address, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(code) == len(eip1167Prefix)+common.AddressLength+len(eip1167Suffix) &&
+		bytes.HasPrefix(code, eip1167Prefix) && bytes.HasSuffix(code, eip1167Suffix) {
+		return &ProxyInfo{
+			Kind:           ProxyEIP1167,
+			Proxy:          address,
+			Implementation: common.BytesToAddress(code[len(eip1167Prefix) : len(eip1167Prefix)+common.AddressLength]),
+		}, nil
+	}
+	if len(code) == 0 {
+		return nil, ErrNoCode
+	}
+	// Standard proxies store the implementation or its beacon in reserved slots
+	slot, err := backend.StorageAt(ctx, address, eip1967ImplementationSlot, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if impl := common.BytesToAddress(slot); impl != (common.Address{}) {
+		return &ProxyInfo{Kind: ProxyEIP1967, Proxy: address, Implementation: impl}, nil
+	}
+	slot, err = backend.StorageAt(ctx, address, eip1967BeaconSlot, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	beacon := common.BytesToAddress(slot)
+	if beacon == (common.Address{}) {
+		return nil, ErrNotProxy
+	}
+	var out []any
+	callOpts := &CallOpts{Context: ctx, BlockNumber: opts.BlockNumber}
+	if err := NewBoundContract(beacon, beaconABI, backend, nil, nil).Call(callOpts, &out, "implementation"); err != nil {
+		return nil, fmt.Errorf("failed to query beacon %v: %w", beacon, err)
+	}
+	return &ProxyInfo{
+		Kind:           ProxyEIP1967Beacon,
+		Proxy:          address,
+		Implementation: *abi.ConvertType(out[0], new(common.Address)).(*common.Address),
+		Beacon:         beacon,
+	}, nil
+}
+
+// ResolveImplementation follows the proxies starting at the given address and
+// returns the address of the contract ultimately implementing the calls. The
+// address itself is returned if it isn't a proxy.
+func ResolveImplementation(opts *CallOpts, backend ProxyReader, address common.Address) (common.Address, error) {
+	for range maxProxyDepth {
+		info, err := DetectProxy(opts, backend, address)
+		if errors.Is(err, ErrNotProxy) {
+			return address, nil
+		}
+		if err != nil {
+			return common.Address{}, err
+		}
+		address = info.Implementation
+	}
+	return common.Address{}, fmt.Errorf("proxy chain longer than %d contracts", maxProxyDepth)
+}
+
+// ResolveProxyABI resolves the implementation behind the proxy at the given
+// address and looks up its ABI. Binding the resolved ABI to the proxy address
+// (rather than the implementation) is what makes calls and event filters work,
+// since the events of the implementation are emitted with the proxy address.
+func ResolveProxyABI(opts *CallOpts, backend ProxyReader, address common.Address, lookup ABILookup) (*abi.ABI, error) {
+	impl, err := ResolveImplementation(opts, backend, address)
+	if err != nil {
+		return nil, err
+	}
+	return lookup(impl)
+}
+
+// NewBoundContractThroughProxy resolves the ABI of the implementation behind the
+// proxy at the given address and binds it to the proxy address.
+func NewBoundContractThroughProxy(opts *CallOpts, backend ProxyBackend, address common.Address, lookup ABILookup) (*BoundContract, error) {
+	parsed, err := ResolveProxyABI(opts, backend, address, lookup)
+	if err != nil {
+		return nil, err
+	}
+	return NewBoundContract(address, *parsed, backend, backend, backend), nil
+}
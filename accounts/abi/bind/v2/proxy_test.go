@@ -0,0 +1,118 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// mockProxyReader serves the code and storage of a set of accounts, answering
+// all contract calls with a fixed beacon implementation.
+type mockProxyReader struct {
+	code    map[common.Address][]byte
+	storage map[common.Address]map[common.Hash]common.Hash
+	beacon  common.Address
+}
+
+func (mp *mockProxyReader) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return mp.code[contract], nil
+}
+
+func (mp *mockProxyReader) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return common.LeftPadBytes(mp.beacon.Bytes(), 32), nil
+}
+
+func (mp *mockProxyReader) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	value := mp.storage[account][key]
+	return value.Bytes(), nil
+}
+
+func TestDetectProxy(t *testing.T) {
+	t.Parallel()
+
+	var (
+		impl    = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		minimal = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		eip1967 = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		beacon  = common.HexToAddress("0x4444444444444444444444444444444444444444")
+		proxied = common.HexToAddress("0x5555555555555555555555555555555555555555")
+		plain   = common.HexToAddress("0x6666666666666666666666666666666666666666")
+
+		implSlot   = common.HexToHash("0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+		beaconSlot = common.HexToHash("0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+	)
+	backend := &mockProxyReader{
+		code: map[common.Address][]byte{
+			impl:    {0x60, 0x80},
+			minimal: common.FromHex("0x363d3d373d3d3d363d73" + "3333333333333333333333333333333333333333" + "5af43d82803e903d91602b57fd5bf3"),
+			eip1967: {0x60, 0x80},
+			beacon:  {0x60, 0x80},
+			proxied: {0x60, 0x80},
+			plain:   {0x60, 0x80},
+		},
+		storage: map[common.Address]map[common.Hash]common.Hash{
+			eip1967: {implSlot: common.BytesToHash(proxied.Bytes())},
+			proxied: {beaconSlot: common.BytesToHash(beacon.Bytes())},
+		},
+		beacon: impl,
+	}
+	tests := []struct {
+		address common.Address
+		want    *bind.ProxyInfo
+		err     error
+	}{
+		{address: minimal, want: &bind.ProxyInfo{Kind: bind.ProxyEIP1167, Proxy: minimal, Implementation: eip1967}},
+		{address: eip1967, want: &bind.ProxyInfo{Kind: bind.ProxyEIP1967, Proxy: eip1967, Implementation: proxied}},
+		{address: proxied, want: &bind.ProxyInfo{Kind: bind.ProxyEIP1967Beacon, Proxy: proxied, Implementation: impl, Beacon: beacon}},
+		{address: plain, err: bind.ErrNotProxy},
+		{address: common.Address{}, err: bind.ErrNoCode},
+	}
+	for i, tt := range tests {
+		info, err := bind.DetectProxy(nil, backend, tt.address)
+		if !errors.Is(err, tt.err) {
+			t.Fatalf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+		if tt.want != nil && *info != *tt.want {
+			t.Fatalf("test %d: proxy mismatch: have %+v, want %+v", i, info, tt.want)
+		}
+	}
+	// The chain of proxies is followed to the final implementation
+	resolved, err := bind.ResolveImplementation(nil, backend, minimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolved != impl {
+		t.Fatalf("implementation mismatch: have %v, want %v", resolved, impl)
+	}
+	parsed, err := bind.ResolveProxyABI(nil, backend, minimal, func(address common.Address) (*abi.ABI, error) {
+		if address != impl {
+			return nil, errors.New("unknown contract")
+		}
+		return new(abi.ABI), nil
+	})
+	if err != nil || parsed == nil {
+		t.Fatalf("failed to resolve ABI: %v", err)
+	}
+}
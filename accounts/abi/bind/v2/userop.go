@@ -0,0 +1,289 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// EntryPointVersion is a version of the ERC-4337 EntryPoint contract, which
+// determines how user operations are hashed and encoded.
+type EntryPointVersion int
+
+const (
+	EntryPointV06 EntryPointVersion = iota // EntryPoint v0.6, with packed init code and paymaster data
+	EntryPointV07                          // EntryPoint v0.7, with packed gas limits and fees
+)
+
+// EntryPoint is a deployment of the ERC-4337 EntryPoint contract.
+type EntryPoint struct {
+	Address common.Address
+	Version EntryPointVersion
+}
+
+var (
+	// EntryPoint06 and EntryPoint07 are the canonical deployments of the v0.6
+	// and v0.7 EntryPoint contracts.
+	EntryPoint06 = EntryPoint{Address: common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"), Version: EntryPointV06}
+	EntryPoint07 = EntryPoint{Address: common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032"), Version: EntryPointV07}
+)
+
+// getNonceSelector is the selector of getNonce(address,uint192) on the EntryPoint.
+var getNonceSelector = crypto.Keccak256([]byte("getNonce(address,uint192)"))[:4]
+
+// UserOperation is an ERC-4337 user operation, executing a call through a smart
+// contract account. The fields are those of the unpacked v0.7 representation,
+// which are packed as required by the EntryPoint the operation is meant for.
+type UserOperation struct {
+	Sender      common.Address
+	Nonce       *big.Int
+	Factory     *common.Address // Factory deploying the account, nil if already deployed
+	FactoryData []byte
+	CallData    []byte
+
+	CallGasLimit         uint64
+	VerificationGasLimit uint64
+	PreVerificationGas   uint64
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+
+	Paymaster                     *common.Address // Paymaster sponsoring the operation, nil if none
+	PaymasterVerificationGasLimit uint64          // Not supported by v0.6 entry points
+	PaymasterPostOpGasLimit       uint64          // Not supported by v0.6 entry points
+	PaymasterData                 []byte
+
+	Signature []byte
+}
+
+// initCode returns the factory address followed by its data, as packed for the
+// EntryPoint.
+func (op *UserOperation) initCode() []byte {
+	if op.Factory == nil {
+		return nil
+	}
+	return append(op.Factory.Bytes(), op.FactoryData...)
+}
+
+// paymasterAndData returns the paymaster address followed by its data, as packed
+// for the given EntryPoint version.
+func (op *UserOperation) paymasterAndData(version EntryPointVersion) []byte {
+	if op.Paymaster == nil {
+		return nil
+	}
+	packed := op.Paymaster.Bytes()
+	if version >= EntryPointV07 {
+		packed = append(packed, packUint128s(op.PaymasterVerificationGasLimit, op.PaymasterPostOpGasLimit)...)
+	}
+	return append(packed, op.PaymasterData...)
+}
+
+// Hash returns the hash of the operation signed by the account, which commits to
+// the entry point and chain the operation is valid for. The signature is not
+// part of the hash.
+func (op *UserOperation) Hash(entryPoint EntryPoint, chainID *big.Int) common.Hash {
+	var packed []byte
+	packed = append(packed, common.LeftPadBytes(op.Sender.Bytes(), 32)...)
+	packed = append(packed, uint256Word(op.Nonce)...)
+	packed = append(packed, crypto.Keccak256(op.initCode())...)
+	packed = append(packed, crypto.Keccak256(op.CallData)...)
+
+	switch entryPoint.Version {
+	case EntryPointV06:
+		packed = append(packed, uint256Word(new(big.Int).SetUint64(op.CallGasLimit))...)
+		packed = append(packed, uint256Word(new(big.Int).SetUint64(op.VerificationGasLimit))...)
+		packed = append(packed, uint256Word(new(big.Int).SetUint64(op.PreVerificationGas))...)
+		packed = append(packed, uint256Word(op.MaxFeePerGas)...)
+		packed = append(packed, uint256Word(op.MaxPriorityFeePerGas)...)
+	default:
+		packed = append(packed, packUint128s(op.VerificationGasLimit, op.CallGasLimit)...)
+		packed = append(packed, uint256Word(new(big.Int).SetUint64(op.PreVerificationGas))...)
+		gasFees := make([]byte, 32)
+		math.ReadBits(bigOrZero(op.MaxPriorityFeePerGas), gasFees[:16])
+		math.ReadBits(bigOrZero(op.MaxFeePerGas), gasFees[16:])
+		packed = append(packed, gasFees...)
+	}
+	packed = append(packed, crypto.Keccak256(op.paymasterAndData(entryPoint.Version))...)
+
+	return crypto.Keccak256Hash(
+		crypto.Keccak256(packed),
+		common.LeftPadBytes(entryPoint.Address.Bytes(), 32),
+		uint256Word(chainID),
+	)
+}
+
+// uint256Word encodes a number as a 32 byte word, treating nil as zero.
+func uint256Word(n *big.Int) []byte {
+	if n == nil {
+		return make([]byte, 32)
+	}
+	return math.U256Bytes(new(big.Int).Set(n))
+}
+
+// packUint128s packs two numbers into the high and low halves of a 32 byte word.
+func packUint128s(high, low uint64) []byte {
+	word := make([]byte, 32)
+	new(big.Int).SetUint64(high).FillBytes(word[:16])
+	new(big.Int).SetUint64(low).FillBytes(word[16:])
+	return word
+}
+
+// SignUserOperation signs the operation with the given account of the wallet,
+// storing the signature in the operation. The hash of the operation is signed as
+// an EIP-191 text message, as validated by the common smart contract accounts.
+func SignUserOperation(wallet accounts.Wallet, account accounts.Account, op *UserOperation, entryPoint EntryPoint, chainID *big.Int) error {
+	hash := op.Hash(entryPoint, chainID)
+	sig, err := wallet.SignText(account, hash[:])
+	if err != nil {
+		return err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	op.Signature = sig
+	return nil
+}
+
+// UserOperationNonce retrieves the next nonce of the account in the given nonce
+// key space from the EntryPoint.
+func UserOperationNonce(opts *CallOpts, caller ContractCaller, entryPoint EntryPoint, sender common.Address, key *big.Int) (*big.Int, error) {
+	input := append(common.CopyBytes(getNonceSelector), common.LeftPadBytes(sender.Bytes(), 32)...)
+	input = append(input, uint256Word(key)...)
+
+	output, err := NewBoundContract(entryPoint.Address, abi.ABI{}, caller, nil, nil).CallRaw(opts, input)
+	if err != nil {
+		return nil, err
+	}
+	if len(output) != 32 {
+		return nil, fmt.Errorf("invalid getNonce result length %d", len(output))
+	}
+	return new(big.Int).SetBytes(output), nil
+}
+
+// UserOperationGas is the gas estimated by a bundler for a user operation.
+type UserOperationGas struct {
+	PreVerificationGas            uint64
+	VerificationGasLimit          uint64
+	CallGasLimit                  uint64
+	PaymasterVerificationGasLimit uint64 // Only estimated by v0.7 bundlers
+	PaymasterPostOpGasLimit       uint64 // Only estimated by v0.7 bundlers
+}
+
+// Apply sets the estimated gas limits on the operation.
+func (g *UserOperationGas) Apply(op *UserOperation) {
+	op.PreVerificationGas = g.PreVerificationGas
+	op.VerificationGasLimit = g.VerificationGasLimit
+	op.CallGasLimit = g.CallGasLimit
+	if op.Paymaster != nil {
+		op.PaymasterVerificationGasLimit = g.PaymasterVerificationGasLimit
+		op.PaymasterPostOpGasLimit = g.PaymasterPostOpGasLimit
+	}
+}
+
+// BundlerClient submits user operations to an ERC-4337 bundler for an EntryPoint.
+type BundlerClient struct {
+	client     *rpc.Client
+	entryPoint EntryPoint
+}
+
+// NewBundlerClient creates a client of the bundler handling operations of the
+// given EntryPoint.
+func NewBundlerClient(client *rpc.Client, entryPoint EntryPoint) *BundlerClient {
+	return &BundlerClient{client: client, entryPoint: entryPoint}
+}
+
+// SendUserOperation submits a signed operation to the bundler, returning the
+// hash it is identified by.
+func (c *BundlerClient) SendUserOperation(ctx context.Context, op *UserOperation) (common.Hash, error) {
+	var hash common.Hash
+	err := c.client.CallContext(ctx, &hash, "eth_sendUserOperation", c.encode(op), c.entryPoint.Address)
+	return hash, err
+}
+
+// EstimateUserOperationGas estimates the gas limits of an operation. The
+// operation doesn't need to be signed, but accounts validating signatures
+// usually require a dummy signature of the correct length.
+func (c *BundlerClient) EstimateUserOperationGas(ctx context.Context, op *UserOperation) (*UserOperationGas, error) {
+	var result *struct {
+		PreVerificationGas            hexutil.Uint64 `json:"preVerificationGas"`
+		VerificationGasLimit          hexutil.Uint64 `json:"verificationGasLimit"`
+		CallGasLimit                  hexutil.Uint64 `json:"callGasLimit"`
+		PaymasterVerificationGasLimit hexutil.Uint64 `json:"paymasterVerificationGasLimit"`
+		PaymasterPostOpGasLimit       hexutil.Uint64 `json:"paymasterPostOpGasLimit"`
+	}
+	if err := c.client.CallContext(ctx, &result, "eth_estimateUserOperationGas", c.encode(op), c.entryPoint.Address); err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.New("empty gas estimation result")
+	}
+	return &UserOperationGas{
+		PreVerificationGas:            uint64(result.PreVerificationGas),
+		VerificationGasLimit:          uint64(result.VerificationGasLimit),
+		CallGasLimit:                  uint64(result.CallGasLimit),
+		PaymasterVerificationGasLimit: uint64(result.PaymasterVerificationGasLimit),
+		PaymasterPostOpGasLimit:       uint64(result.PaymasterPostOpGasLimit),
+	}, nil
+}
+
+// encode converts the operation into the JSON representation of the EntryPoint
+// version the bundler handles.
+func (c *BundlerClient) encode(op *UserOperation) map[string]any {
+	enc := map[string]any{
+		"sender":               op.Sender,
+		"nonce":                (*hexutil.Big)(bigOrZero(op.Nonce)),
+		"callData":             hexutil.Bytes(op.CallData),
+		"callGasLimit":         hexutil.Uint64(op.CallGasLimit),
+		"verificationGasLimit": hexutil.Uint64(op.VerificationGasLimit),
+		"preVerificationGas":   hexutil.Uint64(op.PreVerificationGas),
+		"maxFeePerGas":         (*hexutil.Big)(bigOrZero(op.MaxFeePerGas)),
+		"maxPriorityFeePerGas": (*hexutil.Big)(bigOrZero(op.MaxPriorityFeePerGas)),
+		"signature":            hexutil.Bytes(op.Signature),
+	}
+	if c.entryPoint.Version == EntryPointV06 {
+		enc["initCode"] = hexutil.Bytes(op.initCode())
+		enc["paymasterAndData"] = hexutil.Bytes(op.paymasterAndData(EntryPointV06))
+		return enc
+	}
+	if op.Factory != nil {
+		enc["factory"] = op.Factory
+		enc["factoryData"] = hexutil.Bytes(op.FactoryData)
+	}
+	if op.Paymaster != nil {
+		enc["paymaster"] = op.Paymaster
+		enc["paymasterVerificationGasLimit"] = hexutil.Uint64(op.PaymasterVerificationGasLimit)
+		enc["paymasterPostOpGasLimit"] = hexutil.Uint64(op.PaymasterPostOpGasLimit)
+		enc["paymasterData"] = hexutil.Bytes(op.PaymasterData)
+	}
+	return enc
+}
+
+// bigOrZero returns the number, or zero if it is nil.
+func bigOrZero(n *big.Int) *big.Int {
+	if n == nil {
+		return new(big.Int)
+	}
+	return n
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func testUserOperation() *bind.UserOperation {
+	factory, paymaster := common.Address{0xfa}, common.Address{0xba}
+	return &bind.UserOperation{
+		Sender:                        common.Address{0xaa},
+		Nonce:                         big.NewInt(7),
+		Factory:                       &factory,
+		FactoryData:                   []byte{0x01, 0x02},
+		CallData:                      []byte{0xb6, 0x1d, 0x27, 0xf6},
+		CallGasLimit:                  100000,
+		VerificationGasLimit:          200000,
+		PreVerificationGas:            50000,
+		MaxFeePerGas:                  big.NewInt(3e9),
+		MaxPriorityFeePerGas:          big.NewInt(1e9),
+		Paymaster:                     &paymaster,
+		PaymasterVerificationGasLimit: 30000,
+		PaymasterPostOpGasLimit:       10000,
+		PaymasterData:                 []byte{0xde, 0xad},
+	}
+}
+
+// userOperationHash computes the hash of the operation as the EntryPoint does,
+// packing it with the ABI encoder.
+func userOperationHash(t *testing.T, packed abi.Arguments, values []any, entryPoint common.Address, chainID *big.Int) common.Hash {
+	inner, err := packed.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	args := newArguments(t, "bytes32", "address", "uint256")
+	enc, err := args.Pack(crypto.Keccak256Hash(inner), entryPoint, chainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return crypto.Keccak256Hash(enc)
+}
+
+func newArguments(t *testing.T, types ...string) abi.Arguments {
+	args := make(abi.Arguments, len(types))
+	for i, name := range types {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args[i] = abi.Argument{Type: typ}
+	}
+	return args
+}
+
+func TestUserOperationHash(t *testing.T) {
+	t.Parallel()
+
+	var (
+		op      = testUserOperation()
+		chainID = big.NewInt(11155111)
+		word    = func(b []byte) [32]byte { return [32]byte(common.LeftPadBytes(b, 32)) }
+	)
+	// v0.6 packs the init code and paymaster data, but no gas values
+	packed06 := newArguments(t, "address", "uint256", "bytes32", "bytes32", "uint256", "uint256", "uint256", "uint256", "uint256", "bytes32")
+	want06 := userOperationHash(t, packed06, []any{
+		op.Sender, op.Nonce,
+		crypto.Keccak256Hash(append(op.Factory.Bytes(), op.FactoryData...)),
+		crypto.Keccak256Hash(op.CallData),
+		big.NewInt(100000), big.NewInt(200000), big.NewInt(50000), op.MaxFeePerGas, op.MaxPriorityFeePerGas,
+		crypto.Keccak256Hash(append(op.Paymaster.Bytes(), op.PaymasterData...)),
+	}, bind.EntryPoint06.Address, chainID)
+
+	if have := op.Hash(bind.EntryPoint06, chainID); have != want06 {
+		t.Errorf("v0.6 hash mismatch: have %x, want %x", have, want06)
+	}
+	// v0.7 additionally packs the gas limits and fees into words
+	paymasterAndData := append(op.Paymaster.Bytes(), common.LeftPadBytes(big.NewInt(30000).Bytes(), 16)...)
+	paymasterAndData = append(paymasterAndData, common.LeftPadBytes(big.NewInt(10000).Bytes(), 16)...)
+	paymasterAndData = append(paymasterAndData, op.PaymasterData...)
+
+	packed07 := newArguments(t, "address", "uint256", "bytes32", "bytes32", "bytes32", "uint256", "bytes32", "bytes32")
+	want07 := userOperationHash(t, packed07, []any{
+		op.Sender, op.Nonce,
+		crypto.Keccak256Hash(append(op.Factory.Bytes(), op.FactoryData...)),
+		crypto.Keccak256Hash(op.CallData),
+		word(append(common.LeftPadBytes(big.NewInt(200000).Bytes(), 16), common.LeftPadBytes(big.NewInt(100000).Bytes(), 16)...)),
+		big.NewInt(50000),
+		word(append(common.LeftPadBytes(op.MaxPriorityFeePerGas.Bytes(), 16), common.LeftPadBytes(op.MaxFeePerGas.Bytes(), 16)...)),
+		crypto.Keccak256Hash(paymasterAndData),
+	}, bind.EntryPoint07.Address, chainID)
+
+	if have := op.Hash(bind.EntryPoint07, chainID); have != want07 {
+		t.Errorf("v0.7 hash mismatch: have %x, want %x", have, want07)
+	}
+	// The signature must not affect the hash
+	op.Signature = []byte{0x01}
+	if have := op.Hash(bind.EntryPoint07, chainID); have != want07 {
+		t.Errorf("hash changed by signature: have %x, want %x", have, want07)
+	}
+}
+
+func TestSignUserOperation(t *testing.T) {
+	t.Parallel()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		op      = testUserOperation()
+		chainID = big.NewInt(1)
+	)
+	if err := bind.SignUserOperation(ks.Wallets()[0], account, op, bind.EntryPoint07, chainID); err != nil {
+		t.Fatalf("failed to sign user operation: %v", err)
+	}
+	if len(op.Signature) != crypto.SignatureLength || op.Signature[crypto.RecoveryIDOffset] < 27 {
+		t.Fatalf("invalid signature %x", op.Signature)
+	}
+	sig := common.CopyBytes(op.Signature)
+	sig[crypto.RecoveryIDOffset] -= 27
+
+	hash := op.Hash(bind.EntryPoint07, chainID)
+	pubkey, err := crypto.SigToPub(accounts.TextHash(hash[:]), sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != account.Address {
+		t.Errorf("signer mismatch: have %v, want %v", signer, account.Address)
+	}
+}
+
+// testBundler is a bundler service recording the operations it receives.
+type testBundler struct {
+	ops         []map[string]any
+	entryPoints []common.Address
+}
+
+func (b *testBundler) SendUserOperation(op map[string]any, entryPoint common.Address) common.Hash {
+	b.ops = append(b.ops, op)
+	b.entryPoints = append(b.entryPoints, entryPoint)
+	return common.Hash{0x01}
+}
+
+func (b *testBundler) EstimateUserOperationGas(op map[string]any, entryPoint common.Address) map[string]any {
+	return map[string]any{
+		"preVerificationGas":            hexutil.Uint64(1),
+		"verificationGasLimit":          hexutil.Uint64(2),
+		"callGasLimit":                  hexutil.Uint64(3),
+		"paymasterVerificationGasLimit": hexutil.Uint64(4),
+		"paymasterPostOpGasLimit":       hexutil.Uint64(5),
+	}
+}
+
+func TestBundlerClient(t *testing.T) {
+	t.Parallel()
+
+	bundler := new(testBundler)
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", bundler); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	// Operations must be encoded in the format of the EntryPoint version
+	op := testUserOperation()
+	for _, entryPoint := range []bind.EntryPoint{bind.EntryPoint06, bind.EntryPoint07} {
+		hash, err := bind.NewBundlerClient(client, entryPoint).SendUserOperation(context.Background(), op)
+		if err != nil {
+			t.Fatalf("failed to send user operation: %v", err)
+		}
+		if hash != (common.Hash{0x01}) {
+			t.Errorf("hash mismatch: have %x", hash)
+		}
+	}
+	if bundler.entryPoints[0] != bind.EntryPoint06.Address || bundler.entryPoints[1] != bind.EntryPoint07.Address {
+		t.Errorf("entry point mismatch: have %v", bundler.entryPoints)
+	}
+	if bundler.ops[0]["initCode"] != "0xfa000000000000000000000000000000000000000102" {
+		t.Errorf("v0.6 init code mismatch: have %v", bundler.ops[0]["initCode"])
+	}
+	if _, ok := bundler.ops[0]["factory"]; ok {
+		t.Error("v0.6 operation contains factory")
+	}
+	if bundler.ops[1]["paymasterVerificationGasLimit"] != "0x7530" || bundler.ops[1]["factoryData"] != "0x0102" {
+		t.Errorf("v0.7 operation mismatch: have %v", bundler.ops[1])
+	}
+	// Gas estimates must be applied to the operation
+	gas, err := bind.NewBundlerClient(client, bind.EntryPoint07).EstimateUserOperationGas(context.Background(), op)
+	if err != nil {
+		t.Fatalf("failed to estimate gas: %v", err)
+	}
+	gas.Apply(op)
+	if op.PreVerificationGas != 1 || op.VerificationGasLimit != 2 || op.CallGasLimit != 3 || op.PaymasterVerificationGasLimit != 4 || op.PaymasterPostOpGasLimit != 5 {
+		t.Errorf("gas mismatch: have %+v", op)
+	}
+}
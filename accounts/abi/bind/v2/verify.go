@@ -0,0 +1,102 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrCodeMismatch is returned by VerifyCode if the deployed code doesn't match
+// the compiled bytecode.
+var ErrCodeMismatch = errors.New("deployed code does not match compiled bytecode")
+
+// Opcodes pushing immediate values onto the stack.
+const (
+	opPush1  = 0x60
+	opPush32 = 0x7f
+)
+
+// VerifyCode checks that the code deployed at the given address is the runtime
+// code embedded in the given hex encoded deployment bytecode, as generated by
+// the compiler. Only the context and block number of the call options are used.
+//
+// The compiler metadata at the end of the runtime code is ignored, as are values
+// only known at deployment: immutable variables, the addresses of libraries and
+// the address a library is deployed at, all of which the compiler leaves zero.
+func VerifyCode(opts *CallOpts, backend ContractCaller, address common.Address, bytecode string) error {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(CallOpts)
+	}
+	code, err := backend.CodeAt(ensureContext(opts.Context), address, opts.BlockNumber)
+	if err != nil {
+		return err
+	}
+	if len(code) == 0 {
+		return ErrNoCode
+	}
+	unlinked := libraryPlaceholder.ReplaceAllString(strings.TrimPrefix(bytecode, "0x"), strings.Repeat("0", 2*common.AddressLength))
+	compiled := common.FromHex(unlinked)
+
+	// The runtime code is usually at the end of the deployment code, but may be
+	// followed by the code of other contracts created by the constructor.
+	code = abi.StripBytecodeMetadata(code)
+	for offset := len(compiled) - len(code); offset >= 0; offset-- {
+		if matchCode(code, compiled[offset:offset+len(code)]) {
+			return nil
+		}
+	}
+	return ErrCodeMismatch
+}
+
+// matchCode reports whether the deployed code matches the compiled one, with
+// the zero values pushed by the compiled code standing for any address or word.
+func matchCode(deployed, compiled []byte) bool {
+	for pc := 0; pc < len(deployed); pc++ {
+		op := compiled[pc]
+		if deployed[pc] != op {
+			return false
+		}
+		if op < opPush1 || op > opPush32 {
+			continue
+		}
+		size := int(op-opPush1) + 1
+		end := min(pc+1+size, len(deployed))
+		if size < common.AddressLength || !isZero(compiled[pc+1:end]) {
+			if !bytes.Equal(deployed[pc+1:end], compiled[pc+1:end]) {
+				return false
+			}
+		}
+		pc = end - 1
+	}
+	return true
+}
+
+// isZero reports whether all bytes of b are zero.
+func isZero(b []byte) bool {
+	for _, x := range b {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
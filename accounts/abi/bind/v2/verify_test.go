@@ -0,0 +1,58 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestVerifyCode(t *testing.T) {
+	t.Parallel()
+
+	var (
+		// Deployment code of a contract with an immutable word and a linked library
+		initcode = "6080604052348015600f57600080fd5b50"
+		runtime  = "6080604052" + "7f" + strings.Repeat("00", 32) + "73" + "__$" + strings.Repeat("ab", 17) + "$__" + "5af4600080fd"
+		metadata = "a2646970667358221220" + strings.Repeat("11", 32) + "64736f6c634300081a0033"
+		bytecode = "0x" + initcode + runtime + metadata
+
+		// Runtime code deployed with the immutable and library address filled in,
+		// compiled from sources differing in comments only
+		deployed = "6080604052" + "7f" + strings.Repeat("00", 31) + "2a" + "73" + strings.Repeat("22", 20) + "5af4600080fd" +
+			"a2646970667358221220" + strings.Repeat("33", 32) + "64736f6c634300081a0033"
+	)
+	tests := []struct {
+		code string
+		err  error
+	}{
+		{code: deployed},
+		{code: strings.Replace(deployed, "5af4", "5af1", 1), err: bind.ErrCodeMismatch},
+		{code: strings.Replace(deployed, "6080604052", "6080604053", 1), err: bind.ErrCodeMismatch},
+		{code: "", err: bind.ErrNoCode},
+	}
+	for i, tt := range tests {
+		mc := &mockCaller{codeAtBytes: common.FromHex(tt.code)}
+		if err := bind.VerifyCode(nil, mc, common.Address{1}, bytecode); !errors.Is(err, tt.err) {
+			t.Fatalf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
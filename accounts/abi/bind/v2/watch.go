@@ -0,0 +1,263 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// defaultMaxRetryDelay is the upper bound of the backoff between attempts to
+// re-establish a resilient log subscription.
+const defaultMaxRetryDelay = time.Minute
+
+// errSubscriptionClosed is reported when a log subscription terminates without
+// an error, e.g. because the remote end closed it.
+var errSubscriptionClosed = errors.New("log subscription closed")
+
+// ResilientWatchOpts is the collection of options to fine tune subscribing for
+// events within a bound contract, with the subscription surviving failures of
+// the underlying transport.
+type ResilientWatchOpts struct {
+	WatchOpts
+
+	MaxRetryDelay time.Duration   // Maximum backoff between reconnection attempts (0 = 1 minute)
+	OnReconnect   func(err error) // Optional callback with the failure, invoked before each reconnection attempt
+
+	// MaxBackfill limits the backfill to the given number of most recent blocks,
+	// 0 = unlimited. OnGap is called with the range of blocks to backfill before
+	// the logs are replayed, if set. Both need a filterer implementing
+	// ethereum.BlockNumberReader to know the current head.
+	MaxBackfill uint64
+	OnGap       func(from, to uint64)
+}
+
+// WatchLogsResilient subscribes to the logs of the named event like WatchLogs,
+// but transparently re-establishes the subscription whenever it fails. Before
+// live delivery resumes, the logs emitted while disconnected are backfilled
+// starting from the block of the last delivered log, so no events are dropped
+// or delivered twice. If opts.Start is set, the logs since that block are
+// backfilled on the initial subscription too.
+//
+// Logs removed by a reorg are forwarded as such, and the logs replacing them
+// are delivered afterwards.
+//
+// Failing to establish the initial subscription is returned as an error, the
+// returned subscription only terminates when unsubscribed or when the context
+// of the options is canceled.
+func (c *BoundContract) WatchLogsResilient(opts *ResilientWatchOpts, name string, query ...[]any) (chan types.Log, event.Subscription, error) {
+	// Append the event selector to the query parameters and construct the topic set
+	query = append([][]any{{c.abi.Events[name].ID}}, query...)
+
+	topics, err := abi.MakeTopics(query...)
+	if err != nil {
+		return nil, nil, err
+	}
+	logs := make(chan types.Log, 128)
+
+	config := ethereum.FilterQuery{
+		Addresses: []common.Address{c.address},
+		Topics:    topics,
+	}
+	sub, err := WatchFilterLogsResilient(c.filterer, config, opts, logs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return logs, sub, nil
+}
+
+// WatchFilterLogsResilient subscribes to the logs matching the filter query with
+// the guarantees of WatchLogsResilient, sending them to the given channel. Only
+// establishing the initial subscription can fail.
+func WatchFilterLogsResilient(filterer ContractFilterer, query ethereum.FilterQuery, opts *ResilientWatchOpts, logs chan<- types.Log) (event.Subscription, error) {
+	// Don't crash on a lazy user
+	if opts == nil {
+		opts = new(ResilientWatchOpts)
+	}
+	w := &logWatcher{
+		filterer:    filterer,
+		config:      query,
+		ctx:         ensureContext(opts.Context),
+		logs:        logs,
+		maxBackfill: opts.MaxBackfill,
+		onGap:       opts.OnGap,
+	}
+	if opts.Start != nil {
+		w.next = logPosition{block: *opts.Start}
+		w.backfill = true
+	}
+	maxDelay := opts.MaxRetryDelay
+	if maxDelay == 0 {
+		maxDelay = defaultMaxRetryDelay
+	}
+	first, err := w.subscribe(w.ctx)
+	if err != nil {
+		return nil, err
+	}
+	sub := event.ResubscribeErr(maxDelay, func(ctx context.Context, lastErr error) (event.Subscription, error) {
+		if first != nil {
+			sub := first
+			first = nil
+			return sub, nil
+		}
+		if lastErr != nil && opts.OnReconnect != nil {
+			opts.OnReconnect(lastErr)
+		}
+		return w.subscribe(ctx)
+	})
+	return sub, nil
+}
+
+// logPosition is the position of a log within the chain.
+type logPosition struct {
+	block uint64
+	index uint
+}
+
+// before reports whether the position precedes the other one.
+func (p logPosition) before(other logPosition) bool {
+	return p.block < other.block || (p.block == other.block && p.index < other.index)
+}
+
+// logWatcher maintains a log subscription, tracking the delivered logs so it
+// can resume from where it left off after a failure.
+type logWatcher struct {
+	filterer ContractFilterer
+	config   ethereum.FilterQuery
+	ctx      context.Context
+	logs     chan<- types.Log
+
+	next     logPosition // Position of the first log not yet delivered
+	backfill bool        // Whether to backfill logs from next before going live
+
+	maxBackfill uint64                // Maximum number of blocks to backfill (0 = unlimited)
+	onGap       func(from, to uint64) // Callback with the blocks to backfill, may be nil
+}
+
+// subscribe establishes a live subscription and returns a subscription that
+// backfills the missed logs and then forwards the live ones to the user.
+func (w *logWatcher) subscribe(ctx context.Context) (event.Subscription, error) {
+	live := make(chan types.Log, 128)
+	sub, err := w.filterer.SubscribeFilterLogs(ctx, w.config, live)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+
+		// The live logs are buffered while backfilling, overlaps between the
+		// two are dropped based on the position of the delivered logs.
+		if w.backfill {
+			query, ok, err := w.backfillQuery()
+			if err != nil {
+				return err
+			}
+			var past []types.Log
+			if ok {
+				if past, err = w.filterer.FilterLogs(w.ctx, query); err != nil {
+					return err
+				}
+			}
+			for _, log := range past {
+				if !w.deliver(log, quit) {
+					return nil
+				}
+			}
+		}
+		for {
+			select {
+			case log := <-live:
+				if !w.deliver(log, quit) {
+					return nil
+				}
+			case err := <-sub.Err():
+				if err == nil {
+					err = errSubscriptionClosed
+				}
+				return err
+			case <-quit:
+				return nil
+			case <-w.ctx.Done():
+				return nil
+			}
+		}
+	}), nil
+}
+
+// backfillQuery returns the query of the logs to backfill, or false if there are
+// none. If the current head is known, the query ends at it, as later logs are
+// delivered by the live subscription, and is limited to the most recent blocks.
+func (w *logWatcher) backfillQuery() (ethereum.FilterQuery, bool, error) {
+	query := w.config
+	query.FromBlock = new(big.Int).SetUint64(w.next.block)
+
+	reader, ok := w.filterer.(ethereum.BlockNumberReader)
+	if !ok || (w.maxBackfill == 0 && w.onGap == nil) {
+		return query, true, nil
+	}
+	head, err := reader.BlockNumber(w.ctx)
+	if err != nil {
+		return query, false, err
+	}
+	from := w.next.block
+	if from > head {
+		return query, false, nil
+	}
+	if w.onGap != nil {
+		w.onGap(from, head)
+	}
+	if w.maxBackfill > 0 && head-from+1 > w.maxBackfill {
+		from = head - w.maxBackfill + 1
+	}
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = new(big.Int).SetUint64(head)
+	return query, true, nil
+}
+
+// deliver forwards a log to the user unless it was delivered already. It
+// returns false if the subscription was terminated meanwhile.
+func (w *logWatcher) deliver(log types.Log, quit <-chan struct{}) bool {
+	pos := logPosition{block: log.BlockNumber, index: log.Index}
+	if log.Removed {
+		// The chain reorged, logs from this position on need to be delivered
+		// again from the new chain
+		if pos.before(w.next) {
+			w.next = pos
+		}
+	} else {
+		if w.backfill && pos.before(w.next) {
+			return true
+		}
+		w.next, w.backfill = logPosition{block: pos.block, index: pos.index + 1}, true
+	}
+	select {
+	case w.logs <- log:
+		return true
+	case <-quit:
+		return false
+	case <-w.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// mockFilterer is a log filterer whose subscriptions can be failed at will.
+type mockFilterer struct {
+	mu    sync.Mutex
+	chain []types.Log
+	subs  chan *mockLogSub
+}
+
+type mockLogSub struct {
+	logs chan<- types.Log
+	fail chan error
+}
+
+func (mf *mockFilterer) addLog(log types.Log) {
+	mf.mu.Lock()
+	defer mf.mu.Unlock()
+	mf.chain = append(mf.chain, log)
+}
+
+func (mf *mockFilterer) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	mf.mu.Lock()
+	defer mf.mu.Unlock()
+
+	var logs []types.Log
+	for _, log := range mf.chain {
+		if query.FromBlock == nil || log.BlockNumber >= query.FromBlock.Uint64() {
+			logs = append(logs, log)
+		}
+	}
+	return logs, nil
+}
+
+func (mf *mockFilterer) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	ms := &mockLogSub{logs: ch, fail: make(chan error, 1)}
+	mf.subs <- ms
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case err := <-ms.fail:
+			return err
+		case <-quit:
+			return nil
+		}
+	}), nil
+}
+
+func TestWatchLogsResilient(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"event","name":"Ping","inputs":[]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		filterer   = &mockFilterer{subs: make(chan *mockLogSub, 2)}
+		contract   = bind.NewBoundContract(common.Address{}, parsed, nil, nil, filterer)
+		reconnects = make(chan error, 1)
+		start      = uint64(1)
+		logA       = types.Log{BlockNumber: 1, Index: 0}
+		logB       = types.Log{BlockNumber: 2, Index: 0}
+		logC       = types.Log{BlockNumber: 2, Index: 1}
+		logD       = types.Log{BlockNumber: 3, Index: 0}
+	)
+	filterer.addLog(logA)
+
+	opts := &bind.ResilientWatchOpts{
+		WatchOpts:     bind.WatchOpts{Start: &start},
+		MaxRetryDelay: 10 * time.Millisecond,
+		OnReconnect:   func(err error) { reconnects <- err },
+	}
+	logs, sub, err := contract.WatchLogsResilient(opts, "Ping")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	expect := func(want types.Log) {
+		t.Helper()
+		select {
+		case have := <-logs:
+			if have.BlockNumber != want.BlockNumber || have.Index != want.Index {
+				t.Fatalf("log mismatch: have %d/%d, want %d/%d", have.BlockNumber, have.Index, want.BlockNumber, want.Index)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("log %d/%d not delivered", want.BlockNumber, want.Index)
+		}
+	}
+	// The logs since the start block are backfilled, followed by the live ones
+	first := <-filterer.subs
+	expect(logA)
+	filterer.addLog(logB)
+	first.logs <- logB
+	expect(logB)
+
+	// Drop the subscription while a log is emitted, it should be backfilled
+	filterer.addLog(logC)
+	failure := errors.New("connection lost")
+	first.fail <- failure
+
+	second := <-filterer.subs
+	if err := <-reconnects; err != failure {
+		t.Fatalf("reconnect reported wrong error: %v", err)
+	}
+	expect(logC)
+
+	// Live logs already delivered by the backfill must not be repeated
+	second.logs <- logC
+	filterer.addLog(logD)
+	second.logs <- logD
+	expect(logD)
+
+	select {
+	case log := <-logs:
+		t.Fatalf("unexpected log delivered: %d/%d", log.BlockNumber, log.Index)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package calldata decodes raw transaction input into a structured, human
+// readable breakdown of the invoked method and its arguments.
+//
+// Input is matched against a local set of contract ABIs first. If no local
+// method matches, an optional selector directory (such as 4byte.directory) is
+// consulted for candidate signatures, which are only accepted if the input
+// decodes and re-encodes to the exact same bytes.
+package calldata
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrUnknownMethod is returned if the selector of the input doesn't match any
+// known method.
+var ErrUnknownMethod = errors.New("unknown method selector")
+
+// Source describes where the signature used to decode a call was found.
+type Source string
+
+const (
+	SourceABI       Source = "abi"       // Matched a method of a local ABI
+	SourceDirectory Source = "directory" // Matched a signature from the selector directory
+)
+
+// Call is a decoded method invocation.
+type Call struct {
+	Selector  [4]byte
+	Name      string
+	Signature string // Canonical signature, e.g. transfer(address,uint256)
+	Source    Source
+	Args      []Value
+}
+
+// Value is a decoded argument, or a nested element of a tuple or array.
+type Value struct {
+	Name     string
+	Type     abi.Type
+	Value    interface{} // Go value as returned by the abi package
+	Children []Value     // Fields of tuples and elements of arrays
+}
+
+// Decoder decodes call data against a set of ABIs and an optional directory.
+type Decoder struct {
+	abis      []abi.ABI
+	directory SelectorDirectory
+}
+
+// NewDecoder creates a decoder matching input against the given ABIs, falling
+// back to the selector directory (if non-nil) for unknown selectors.
+func NewDecoder(directory SelectorDirectory, abis ...abi.ABI) *Decoder {
+	return &Decoder{abis: abis, directory: directory}
+}
+
+// Decode decodes the given transaction input.
+func (d *Decoder) Decode(ctx context.Context, input []byte) (*Call, error) {
+	if len(input) < 4 {
+		return nil, fmt.Errorf("input too short (%d bytes) for method selector", len(input))
+	}
+	var selector [4]byte
+	copy(selector[:], input)
+
+	for _, contract := range d.abis {
+		method, err := contract.MethodById(input)
+		if err != nil {
+			continue
+		}
+		if call, err := decodeMethod(method, input[4:], false); err == nil {
+			call.Source = SourceABI
+			return call, nil
+		}
+	}
+	if d.directory == nil {
+		return nil, fmt.Errorf("%w: %#x", ErrUnknownMethod, selector)
+	}
+	signatures, err := d.directory.Signatures(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, sig := range signatures {
+		parsed, err := abi.ParseHumanReadable([]string{"function " + sig})
+		if err != nil {
+			continue
+		}
+		for _, method := range parsed.Methods {
+			if !bytes.Equal(method.ID, selector[:]) {
+				continue // Bogus directory entry
+			}
+			if call, err := decodeMethod(&method, input[4:], true); err == nil {
+				call.Source = SourceDirectory
+				return call, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %#x", ErrUnknownMethod, selector)
+}
+
+// decodeMethod unpacks the arguments of the method. If strict is set, the
+// arguments are re-encoded and compared with the input to reject signatures
+// which only happen to decode (e.g. colliding selectors).
+func decodeMethod(method *abi.Method, data []byte, strict bool) (*Call, error) {
+	values, err := method.Inputs.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+	if strict {
+		encoded, err := method.Inputs.PackValues(values)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(encoded, data) {
+			return nil, errors.New("input is not canonically encoded")
+		}
+	}
+	call := &Call{
+		Name:      method.RawName,
+		Signature: method.Sig,
+		Args:      make([]Value, len(values)),
+	}
+	copy(call.Selector[:], method.ID)
+	for i, arg := range method.Inputs {
+		call.Args[i] = newValue(arg.Name, arg.Type, values[i])
+	}
+	return call, nil
+}
+
+// newValue creates the structured representation of a decoded value.
+func newValue(name string, typ abi.Type, value interface{}) Value {
+	v := Value{Name: name, Type: typ, Value: value}
+	rv := reflect.ValueOf(value)
+	switch typ.T {
+	case abi.TupleTy:
+		for i, elem := range typ.TupleElems {
+			v.Children = append(v.Children, newValue(typ.TupleRawNames[i], *elem, rv.Field(i).Interface()))
+		}
+	case abi.SliceTy, abi.ArrayTy:
+		for i := 0; i < rv.Len(); i++ {
+			v.Children = append(v.Children, newValue(fmt.Sprintf("[%d]", i), *typ.Elem, rv.Index(i).Interface()))
+		}
+	}
+	return v
+}
+
+// String renders the call as an indented, human readable tree.
+func (c *Call) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%#x)\n", c.Signature, c.Selector)
+	for _, arg := range c.Args {
+		arg.render(&b, 1)
+	}
+	return b.String()
+}
+
+func (v Value) render(b *strings.Builder, depth int) {
+	b.WriteString(strings.Repeat("  ", depth))
+	if v.Name != "" {
+		b.WriteString(v.Name)
+		b.WriteString(": ")
+	}
+	b.WriteString(v.Type.String())
+	if v.Children == nil && v.Type.T != abi.SliceTy && v.Type.T != abi.ArrayTy {
+		b.WriteString(" = ")
+		b.WriteString(FormatValue(v.Value))
+	}
+	b.WriteString("\n")
+	for _, child := range v.Children {
+		child.render(b, depth+1)
+	}
+}
+
+// FormatValue renders an elementary decoded value in its conventional textual
+// form: addresses checksummed, byte arrays as hex and integers in decimal.
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case *big.Int:
+		return v.String()
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return hexutil.Encode(b)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package calldata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func mustParse(t *testing.T, sigs ...string) abi.ABI {
+	t.Helper()
+	parsed, err := abi.ParseHumanReadable(sigs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestDecodeLocalABI(t *testing.T) {
+	t.Parallel()
+
+	contract := mustParse(t, "function fill((address maker, uint256[] amounts) order, bytes sig)")
+	type order struct {
+		Maker   common.Address
+		Amounts []*big.Int
+	}
+	input, err := contract.Pack("fill", order{common.HexToAddress("0x01"), []*big.Int{big.NewInt(1), big.NewInt(2)}}, []byte{0xca, 0xfe})
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := NewDecoder(nil, contract).Decode(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Source != SourceABI || call.Name != "fill" {
+		t.Fatalf("wrong call: %+v", call)
+	}
+	want := `fill((address,uint256[]),bytes) (0x` + fmt.Sprintf("%x", call.Selector) + `)
+  order: (address,uint256[])
+    maker: address = 0x0000000000000000000000000000000000000001
+    amounts: uint256[]
+      [0]: uint256 = 1
+      [1]: uint256 = 2
+  sig: bytes = 0xcafe
+`
+	if have := call.String(); have != want {
+		t.Fatalf("wrong rendering:\nhave:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestDecodeDirectory(t *testing.T) {
+	t.Parallel()
+
+	erc20 := mustParse(t, "function transfer(address to, uint256 amount)")
+	input, err := erc20.Pack("transfer", common.HexToAddress("0x02"), big.NewInt(100))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var selector [4]byte
+	copy(selector[:], input)
+
+	// Unknown selectors should be rejected without a directory
+	if _, err := NewDecoder(nil).Decode(context.Background(), input); !errors.Is(err, ErrUnknownMethod) {
+		t.Fatalf("expected unknown method, got %v", err)
+	}
+	// Bogus and colliding entries should be skipped
+	dir := MapDirectory{selector: {"garbage(", "approve(address,uint256)", "transfer(address,uint256)"}}
+	call, err := NewDecoder(dir).Decode(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Source != SourceDirectory || call.Signature != "transfer(address,uint256)" {
+		t.Fatalf("wrong call: %+v", call)
+	}
+	if len(call.Args) != 2 || call.Args[1].Value.(*big.Int).Int64() != 100 {
+		t.Fatalf("wrong arguments: %+v", call.Args)
+	}
+	// Stuffed input must not be decoded using an untrusted signature
+	if _, err := NewDecoder(dir).Decode(context.Background(), append(input, 0x00)); !errors.Is(err, ErrUnknownMethod) {
+		t.Fatalf("expected stuffed input to be rejected, got %v", err)
+	}
+}
+
+func TestFourByteDirectory(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("hex_signature") != "0xa9059cbb" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"count":1,"results":[{"id":145,"text_signature":"transfer(address,uint256)","hex_signature":"0xa9059cbb"}]}`)
+	}))
+	defer srv.Close()
+
+	dir := &FourByteDirectory{Endpoint: srv.URL}
+	sigs, err := dir.Signatures(context.Background(), [4]byte{0xa9, 0x05, 0x9c, 0xbb})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sigs) != 1 || sigs[0] != "transfer(address,uint256)" {
+		t.Fatalf("wrong signatures: %v", sigs)
+	}
+	if _, err := dir.Signatures(context.Background(), [4]byte{}); err == nil {
+		t.Fatal("expected error for failed lookup")
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package calldata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SelectorDirectory resolves 4-byte method selectors into candidate textual
+// signatures, e.g. "transfer(address,uint256)". As selectors collide, multiple
+// candidates may be returned; the decoder verifies each against the input.
+type SelectorDirectory interface {
+	Signatures(ctx context.Context, selector [4]byte) ([]string, error)
+}
+
+// MapDirectory is an in-memory selector directory.
+type MapDirectory map[[4]byte][]string
+
+// Signatures implements SelectorDirectory.
+func (d MapDirectory) Signatures(ctx context.Context, selector [4]byte) ([]string, error) {
+	return d[selector], nil
+}
+
+// FourByteDirectory is a selector directory backed by the 4byte.directory API,
+// or any service exposing the same interface.
+type FourByteDirectory struct {
+	Endpoint string       // API base URL, defaults to https://www.4byte.directory
+	Client   *http.Client // HTTP client to use, defaults to http.DefaultClient
+}
+
+// Signatures implements SelectorDirectory.
+func (d *FourByteDirectory) Signatures(ctx context.Context, selector [4]byte) ([]string, error) {
+	endpoint, client := d.Endpoint, d.Client
+	if endpoint == "" {
+		endpoint = "https://www.4byte.directory"
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	query := url.Values{"hex_signature": {hexutil.Encode(selector[:])}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/api/v1/signatures/?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("selector directory returned status %s", res.Status)
+	}
+	var result struct {
+		Results []struct {
+			TextSignature string `json:"text_signature"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	signatures := make([]string, 0, len(result.Results))
+	for _, r := range result.Results {
+		signatures = append(signatures, r.TextSignature)
+	}
+	return signatures, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Collision is a method selector or event topic shared by declarations of
+// multiple contracts.
+type Collision struct {
+	ID      []byte            // Method selector or event topic
+	Event   bool              // Whether the colliding declarations are events
+	Members []CollisionMember // Colliding declarations, ordered by ABI
+}
+
+// CollisionMember is a single declaration taking part in a collision.
+type CollisionMember struct {
+	ABI  int    // Index of the declaring ABI in the list passed to DetectCollisions
+	Name string // Name of the method or event within its ABI
+	Decl string // Human readable declaration
+}
+
+// String implements fmt.Stringer.
+func (c Collision) String() string {
+	decls := make([]string, len(c.Members))
+	for i, m := range c.Members {
+		decls[i] = fmt.Sprintf("#%d %s", m.ABI, m.Decl)
+	}
+	return fmt.Sprintf("%#x: %s", c.ID, strings.Join(decls, ", "))
+}
+
+// DetectCollisions finds method selectors and event topics clashing between the
+// given contract ABIs, such as the facets of a diamond or a proxy and its
+// implementation.
+//
+// A method collides if its selector is declared more than once, as calls
+// can only be dispatched to one of them. An event collides if its topic is
+// declared by more than one ABI with a different layout of indexed arguments,
+// as logs can then not be decoded unambiguously (e.g. the ERC-20 and ERC-721
+// Transfer events). Anonymous events have no topic and are ignored.
+func DetectCollisions(abis ...ABI) []Collision {
+	var (
+		methods = make(map[string][]CollisionMember)
+		events  = make(map[string][]CollisionMember)
+		layouts = make(map[string][]string)
+	)
+	for i, abi := range abis {
+		for name, method := range abi.Methods {
+			id := string(method.ID)
+			methods[id] = append(methods[id], CollisionMember{ABI: i, Name: name, Decl: method.String()})
+		}
+		for name, event := range abi.Events {
+			if event.Anonymous {
+				continue
+			}
+			id := string(event.ID.Bytes())
+			events[id] = append(events[id], CollisionMember{ABI: i, Name: name, Decl: event.String()})
+			layouts[id] = append(layouts[id], eventLayout(event))
+		}
+	}
+	var collisions []Collision
+	for id, members := range methods {
+		if len(members) > 1 {
+			collisions = append(collisions, newCollision(id, false, members))
+		}
+	}
+	for id, members := range events {
+		if len(members) > 1 && len(slices.Compact(slices.Sorted(slices.Values(layouts[id])))) > 1 {
+			collisions = append(collisions, newCollision(id, true, members))
+		}
+	}
+	slices.SortFunc(collisions, func(a, b Collision) int {
+		if a.Event != b.Event {
+			if a.Event {
+				return 1
+			}
+			return -1
+		}
+		return bytes.Compare(a.ID, b.ID)
+	})
+	return collisions
+}
+
+// newCollision creates a collision with its members in a deterministic order.
+func newCollision(id string, event bool, members []CollisionMember) Collision {
+	slices.SortFunc(members, func(a, b CollisionMember) int {
+		if a.ABI != b.ABI {
+			return a.ABI - b.ABI
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return Collision{ID: []byte(id), Event: event, Members: members}
+}
+
+// eventLayout returns a string describing which arguments of the event are
+// indexed, which determines how its logs are decoded.
+func eventLayout(event Event) string {
+	layout := make([]byte, len(event.Inputs))
+	for i, input := range event.Inputs {
+		layout[i] = '0'
+		if input.Indexed {
+			layout[i] = '1'
+		}
+	}
+	return string(layout)
+}
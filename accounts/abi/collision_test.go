@@ -0,0 +1,67 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"testing"
+)
+
+func TestDetectCollisions(t *testing.T) {
+	t.Parallel()
+
+	parse := func(sigs ...string) ABI {
+		abi, err := ParseHumanReadable(sigs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return abi
+	}
+	erc20 := parse(
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+	)
+	erc721 := parse(
+		"function ownerOf(uint256 id) view returns (address)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"event Transfer(address indexed from, address indexed to, uint256 indexed id)",
+	)
+	// Known selector collision: both hash to 0x42966c68
+	clash := parse(
+		"function burn(uint256 amount)",
+		"function collate_propagate_storage(bytes16 x)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+	)
+	collisions := DetectCollisions(erc20, erc721, clash)
+	if len(collisions) != 3 {
+		t.Fatalf("wrong number of collisions: have %d, want 3: %v", len(collisions), collisions)
+	}
+	// Methods are sorted by selector: balanceOf (0x70a08231) after burn (0x42966c68)
+	if c := collisions[0]; c.Event || len(c.Members) != 2 || c.Members[0].Name != "burn" || c.Members[1].Name != "collate_propagate_storage" {
+		t.Errorf("wrong selector collision: %v", c)
+	}
+	if c := collisions[1]; c.Event || len(c.Members) != 2 || c.Members[0].ABI != 0 || c.Members[1].ABI != 1 {
+		t.Errorf("wrong shared method collision: %v", c)
+	}
+	if c := collisions[2]; !c.Event || len(c.Members) != 3 || c.Members[2].ABI != 2 {
+		t.Errorf("wrong event collision: %v", c)
+	}
+	// Identical event layouts don't collide
+	if collisions := DetectCollisions(erc20, parse("event Transfer(address indexed from, address indexed to, uint256 value)")); len(collisions) != 0 {
+		t.Errorf("unexpected collisions: %v", collisions)
+	}
+}
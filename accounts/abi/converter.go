@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ConverterFunc adapts a Go value to a representation the packer supports for
+// the ABI type t, e.g. a *big.Int for an integer type.
+type ConverterFunc func(t Type, value interface{}) (interface{}, error)
+
+// Converters is a registry of adapters from application specific Go types to
+// values natively packable by the ABI encoder. It is consulted for every value
+// being packed, including elements of arrays and tuple fields, when set in the
+// Options of an ABI. It is safe for concurrent use.
+type Converters struct {
+	funcs map[reflect.Type]ConverterFunc
+	lock  sync.RWMutex
+}
+
+// NewConverters creates an empty converter registry.
+func NewConverters() *Converters {
+	return &Converters{funcs: make(map[reflect.Type]ConverterFunc)}
+}
+
+// StandardConverters creates a converter registry with adapters for commonly
+// used types:
+//   - time.Time is packed into integer types as unix seconds
+//   - common.Hash is packed into bytes32 and bytes
+func StandardConverters() *Converters {
+	c := NewConverters()
+	c.Register(reflect.TypeFor[time.Time](), convertTime)
+	c.Register(reflect.TypeFor[common.Hash](), convertHash)
+	return c
+}
+
+// Register adds a converter for values of the given Go type, replacing any
+// previously registered one.
+func (c *Converters) Register(typ reflect.Type, fn ConverterFunc) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.funcs[typ] = fn
+}
+
+// convert applies the converter registered for the type of v, if any.
+func (c *Converters) convert(t Type, v reflect.Value) (reflect.Value, error) {
+	if c == nil {
+		return v, nil
+	}
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = indirect(v.Elem())
+	}
+	if !v.IsValid() {
+		return v, nil
+	}
+	c.lock.RLock()
+	fn, ok := c.funcs[v.Type()]
+	c.lock.RUnlock()
+	if !ok {
+		return v, nil
+	}
+	out, err := fn(t, v.Interface())
+	if err != nil {
+		return v, err
+	}
+	return reflect.ValueOf(out), nil
+}
+
+func convertTime(t Type, value interface{}) (interface{}, error) {
+	if t.T != UintTy && t.T != IntTy {
+		return nil, fmt.Errorf("abi: cannot pack time.Time into %v", t)
+	}
+	return big.NewInt(value.(time.Time).Unix()), nil
+}
+
+func convertHash(t Type, value interface{}) (interface{}, error) {
+	hash := value.(common.Hash)
+	switch {
+	case t.T == FixedBytesTy && t.Size == common.HashLength:
+		return [common.HashLength]byte(hash), nil
+	case t.T == BytesTy:
+		return hash.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("abi: cannot pack common.Hash into %v", t)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestStandardConverters(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"function schedule(uint256 at, bytes32 id, bytes data, uint64[] deadlines)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		at   = time.Unix(1700000000, 0)
+		hash = common.HexToHash("0xdeadbeef")
+	)
+	want, err := abi.Pack("schedule", big.NewInt(1700000000), [32]byte(hash), hash.Bytes(), []*big.Int{big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Without converters, time values are rejected
+	if _, err := abi.Pack("schedule", at, hash, hash, []time.Time{time.Unix(1, 0), time.Unix(2, 0)}); err == nil {
+		t.Fatal("expected error without converters")
+	}
+	abi.Options.Converters = StandardConverters()
+	have, err := abi.Pack("schedule", at, hash, hash, []time.Time{time.Unix(1, 0), time.Unix(2, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("pack mismatch:\nhave %x\nwant %x", have, want)
+	}
+	encoded, err := NewEncoder(abi).Pack("schedule", &at, hash, hash, []time.Time{time.Unix(1, 0), time.Unix(2, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("encoder mismatch:\nhave %x\nwant %x", encoded, want)
+	}
+	// Converters reject unsuitable target types
+	if _, err := abi.Pack("schedule", big.NewInt(0), at, hash, []uint64{}); err == nil {
+		t.Error("expected error packing time into bytes32")
+	}
+}
+
+func TestCustomConverter(t *testing.T) {
+	t.Parallel()
+	type celsius float64
+
+	abi, err := ParseHumanReadable([]string{"function set((int16 temp, string unit) reading)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi.Options.Converters = NewConverters()
+	abi.Options.Converters.Register(reflect.TypeFor[celsius](), func(t Type, value interface{}) (interface{}, error) {
+		return int16(value.(celsius) * 10), nil
+	})
+	type reading struct {
+		Temp celsius
+		Unit string
+	}
+	have, err := abi.Pack("set", reading{Temp: -2.5, Unit: "dC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	abi.Options.Converters = nil
+	want, err := abi.Pack("set", struct {
+		Temp int16
+		Unit string
+	}{-25, "dC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Errorf("pack mismatch:\nhave %x\nwant %x", have, want)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// maxTrailingElemSize is the maximum size of the last dynamic element of a
+// streamed array. As nothing bounds it but the end of the stream, reading it
+// is capped to avoid buffering arbitrarily large inputs.
+const maxTrailingElemSize = 16 * 1024 * 1024
+
+// Decoder reads ABI encoded data from a stream, allowing very large arrays (e.g.
+// the return data of a call or the payload of a log) to be processed element by
+// element without materializing the whole value in memory.
+type Decoder struct {
+	r   *bufio.Reader
+	pos uint64 // Number of bytes consumed from the stream
+}
+
+// NewDecoder creates a decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// DecodeArray decodes a single top-level array of type t from the stream, such
+// as the output of a method returning uint256[] or bytes[], invoking fn with
+// each element in order. The element values have the same Go types as the ones
+// produced by Unpack. Decoding stops at the first error returned by fn.
+//
+// Elements of static types are read directly from the stream. For elements of
+// dynamic types, only their offsets are retained while decoding, so the memory
+// used is bounded by the size of the largest single element.
+func (d *Decoder) DecodeArray(t Type, fn func(index int, value interface{}) error) error {
+	if t.T != SliceTy && t.T != ArrayTy {
+		return fmt.Errorf("abi: cannot stream decode non-array type %v", t)
+	}
+	// Dynamic values are referenced from the head by an offset
+	if isDynamicType(t) {
+		offset, err := d.readUint64()
+		if err != nil {
+			return err
+		}
+		if err := d.skipTo(offset); err != nil {
+			return err
+		}
+	}
+	size := uint64(t.Size)
+	if t.T == SliceTy {
+		length, err := d.readUint64()
+		if err != nil {
+			return err
+		}
+		size = length
+	}
+	// The elements are addressed relative to the beginning of the element area
+	d.pos = 0
+	if !isDynamicType(*t.Elem) {
+		elemSize := getTypeSize(*t.Elem)
+		for i := uint64(0); i < size; i++ {
+			word, err := d.read(uint64(elemSize))
+			if err != nil {
+				return err
+			}
+			value, err := toGoType(0, *t.Elem, word, Options{}, nil)
+			if err != nil {
+				return err
+			}
+			if err := fn(int(i), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// Dynamic elements are preceded by the list of their offsets. Each element
+	// spans the range up to the next offset (the last one to the end of stream).
+	if size > (1<<63)/32 {
+		return fmt.Errorf("abi: array length %d too large", size)
+	}
+	offsets := make([]uint64, 0, min(size, 1024))
+	for i := uint64(0); i < size; i++ {
+		offset, err := d.readUint64()
+		if err != nil {
+			return err
+		}
+		if offset < size*32 || (i > 0 && offset < offsets[i-1]) {
+			return fmt.Errorf("abi: invalid offset %d for array element %d", offset, i)
+		}
+		offsets = append(offsets, offset)
+	}
+	for i, offset := range offsets {
+		if err := d.skipTo(offset); err != nil {
+			return err
+		}
+		var (
+			elem []byte
+			err  error
+		)
+		switch {
+		case i+1 < len(offsets):
+			elem, err = d.read(offsets[i+1] - offset)
+		case t.Elem.T == StringTy || t.Elem.T == BytesTy:
+			elem, err = d.readBytesElem()
+		default:
+			elem, err = d.readTrailing()
+		}
+		if err != nil {
+			return err
+		}
+		// Prefix the element with a head pointing at it, so the regular
+		// decoder can be used to unpack it.
+		buf := make([]byte, 32, 32+len(elem))
+		buf[31] = 32
+		value, err := toGoType(0, *t.Elem, append(buf, elem...), Options{}, nil)
+		if err != nil {
+			return err
+		}
+		if err := fn(i, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBytesElem reads a length prefixed, padded bytes or string element.
+func (d *Decoder) readBytesElem() ([]byte, error) {
+	head, err := d.read(32)
+	if err != nil {
+		return nil, err
+	}
+	length := new(big.Int).SetBytes(head)
+	if !length.IsUint64() || length.Uint64() > (1<<62) {
+		return nil, fmt.Errorf("abi: bytes length %v too large", length)
+	}
+	data, err := d.read((length.Uint64() + 31) / 32 * 32)
+	if err != nil {
+		return nil, err
+	}
+	return append(head, data...), nil
+}
+
+// readTrailing reads the remainder of the stream, up to maxTrailingElemSize.
+func (d *Decoder) readTrailing() ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(d.r, maxTrailingElemSize+1))
+	d.pos += uint64(len(data))
+	if err != nil {
+		return nil, fmt.Errorf("abi: reading trailing element at offset %d: %w", d.pos, err)
+	}
+	if len(data) > maxTrailingElemSize {
+		return nil, fmt.Errorf("abi: trailing element larger than %d bytes", maxTrailingElemSize)
+	}
+	return data, nil
+}
+
+// readUint64 reads a 32 byte word, ensuring it fits into 63 bits.
+func (d *Decoder) readUint64() (uint64, error) {
+	word, err := d.read(32)
+	if err != nil {
+		return 0, err
+	}
+	value := new(big.Int).SetBytes(word)
+	if value.BitLen() > 63 {
+		return 0, fmt.Errorf("abi: value %v larger than int64", value)
+	}
+	return value.Uint64(), nil
+}
+
+// read consumes exactly n bytes from the stream. Large buffers are grown as the
+// data arrives, so bogus lengths can't cause large allocations up front.
+func (d *Decoder) read(n uint64) ([]byte, error) {
+	var (
+		buf []byte
+		err error
+	)
+	if n <= 4096 {
+		buf = make([]byte, n)
+		var read int
+		read, err = io.ReadFull(d.r, buf)
+		buf = buf[:read]
+	} else {
+		buf, err = io.ReadAll(io.LimitReader(d.r, int64(n)))
+	}
+	d.pos += uint64(len(buf))
+	if (err == nil || err == io.EOF) && uint64(len(buf)) < n {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("abi: reading %d bytes at offset %d: %w", n, d.pos, err)
+	}
+	return buf, nil
+}
+
+// skipTo discards the stream up to the given position.
+func (d *Decoder) skipTo(pos uint64) error {
+	if pos < d.pos {
+		return fmt.Errorf("abi: offset %d points backwards (position %d)", pos, d.pos)
+	}
+	n, err := d.r.Discard(int(pos - d.pos))
+	d.pos += uint64(n)
+	if err != nil {
+		return fmt.Errorf("abi: skipping to offset %d: %w", pos, io.ErrUnexpectedEOF)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestDecoderDecodeArray(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		def   string
+		value any
+	}{
+		{`[{"type":"uint256[]"}]`, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
+		{`[{"type":"uint8[]"}]`, []uint8{}},
+		{`[{"type":"address[3]"}]`, [3]common.Address{{1}, {2}, {3}}},
+		{`[{"type":"bytes[]"}]`, [][]byte{{1, 2, 3}, {}, bytes.Repeat([]byte{0xff}, 100)}},
+		{`[{"type":"string[2]"}]`, [2]string{"hello", "world"}},
+		{`[{"type":"uint16[2][]"}]`, [][2]uint16{{1, 2}, {3, 4}}},
+		{`[{"type":"uint16[][]"}]`, [][]uint16{{1, 2}, {}, {3}}},
+		{`[{"type":"tuple[]","components":[{"name":"a","type":"uint256"},{"name":"b","type":"string"}]}]`, []struct {
+			A *big.Int
+			B string
+		}{{big.NewInt(1), "one"}, {big.NewInt(2), "two"}}},
+	}
+	for i, tt := range tests {
+		var args Arguments
+		if err := json.Unmarshal([]byte(tt.def), &args); err != nil {
+			t.Fatalf("test %d: invalid definition: %v", i, err)
+		}
+		enc, err := args.Pack(tt.value)
+		if err != nil {
+			t.Fatalf("test %d: pack failed: %v", i, err)
+		}
+		want, err := args.Unpack(enc)
+		if err != nil {
+			t.Fatalf("test %d: unpack failed: %v", i, err)
+		}
+		var have []any
+		err = NewDecoder(bytes.NewReader(enc)).DecodeArray(args[0].Type, func(index int, value interface{}) error {
+			if index != len(have) {
+				t.Errorf("test %d: unexpected index %d", i, index)
+			}
+			have = append(have, value)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("test %d: decode failed: %v", i, err)
+		}
+		wantVal := reflect.ValueOf(want[0])
+		if len(have) != wantVal.Len() {
+			t.Fatalf("test %d: element count mismatch: have %d, want %d", i, len(have), wantVal.Len())
+		}
+		for j := range have {
+			if !reflect.DeepEqual(have[j], wantVal.Index(j).Interface()) {
+				t.Errorf("test %d: element %d mismatch: have %v, want %v", i, j, have[j], wantVal.Index(j))
+			}
+		}
+	}
+}
+
+func TestDecoderErrors(t *testing.T) {
+	t.Parallel()
+	typ, _ := NewType("bytes[]", "", nil)
+	enc, err := Arguments{{Type: typ}}.Pack([][]byte{{1}, {2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Abort on callback errors
+	errStop := errors.New("stop")
+	var calls int
+	err = NewDecoder(bytes.NewReader(enc)).DecodeArray(typ, func(int, interface{}) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("expected decoding to stop after first element, have %d calls, err %v", calls, err)
+	}
+	// Truncated streams
+	nop := func(int, interface{}) error { return nil }
+	if err := NewDecoder(bytes.NewReader(enc[:len(enc)-32])).DecodeArray(typ, nop); err == nil {
+		t.Error("expected error for truncated stream")
+	}
+	// Huge declared lengths fail without allocating
+	huge := common.FromHex("0000000000000000000000000000000000000000000000000000000000000020" +
+		"00000000000000000000000000000000000000000000000000ffffffffffffff")
+	if err := NewDecoder(bytes.NewReader(huge)).DecodeArray(typ, nop); err == nil {
+		t.Error("expected error for huge array length")
+	}
+	// Offsets must not point backwards
+	bad := common.CopyBytes(enc)
+	bad[4*32-1] = 0 // second element offset
+	if err := NewDecoder(bytes.NewReader(bad)).DecodeArray(typ, nop); err == nil {
+		t.Error("expected error for backwards offset")
+	}
+	// The last dynamic element can't grow unbounded
+	nestedTyp, _ := NewType("uint256[][]", "", nil)
+	nested, err := Arguments{{Type: nestedTyp}}.Pack([][]*big.Int{{big.NewInt(1)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	endless := io.MultiReader(bytes.NewReader(nested), zeroReader{})
+	if err := NewDecoder(endless).DecodeArray(nestedTyp, nop); err == nil {
+		t.Error("expected error for oversized trailing element")
+	}
+	// Non-array types are rejected
+	uintTyp, _ := NewType("uint256", "", nil)
+	if err := NewDecoder(bytes.NewReader(enc)).DecodeArray(uintTyp, nop); err == nil {
+		t.Error("expected error for non-array type")
+	}
+}
+
+// zeroReader is an endless stream of zero bytes.
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -0,0 +1,278 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeKind classifies a difference between two ABIs.
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+// String implements fmt.Stringer.
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "added"
+	case ChangeRemoved:
+		return "removed"
+	case ChangeModified:
+		return "modified"
+	default:
+		return fmt.Sprintf("ChangeKind(%d)", int(k))
+	}
+}
+
+// Change is a single difference between two versions of a contract ABI.
+type Change struct {
+	Kind     ChangeKind
+	Item     string // One of "method", "event", "error", "fallback" or "receive"
+	Sig      string // Signature of the changed item, identifying it in both ABIs
+	Breaking bool   // Whether existing callers or log consumers are affected
+	Detail   string // Description of a modification
+}
+
+// String implements fmt.Stringer.
+func (c Change) String() string {
+	s := fmt.Sprintf("%s %s %s", c.Kind, c.Item, c.Sig)
+	if c.Detail != "" {
+		s += ": " + c.Detail
+	}
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+// Changes is the list of differences between two ABIs.
+type Changes []Change
+
+// Breaking reports whether any of the changes is breaking.
+func (cs Changes) Breaking() bool {
+	return slices.ContainsFunc(cs, func(c Change) bool { return c.Breaking })
+}
+
+// Diff compares two versions of a contract ABI, such as two implementations of
+// an upgradeable proxy, and returns their differences ordered by item and
+// signature. Methods, events and errors are matched by their signature, so a
+// change of argument types is reported as a removal and an addition.
+//
+// Removing a method, event, fallback or receive function is breaking, as is
+// changing the outputs of a method, making it non-payable or allowing it to
+// modify state, and changing which arguments of an event are indexed. Removing
+// an error is not breaking, as callers only need to decode the errors which can
+// still be raised. Additions and argument renames are not breaking.
+//
+// The constructor is not compared, as it doesn't affect deployed contracts.
+func Diff(old, new ABI) Changes {
+	var changes Changes
+
+	oldMethods, newMethods := methodsBySig(old), methodsBySig(new)
+	for sig, o := range oldMethods {
+		n, ok := newMethods[sig]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Item: "method", Sig: sig, Breaking: true})
+			continue
+		}
+		changes = append(changes, diffMethod(sig, o, n)...)
+	}
+	for sig := range newMethods {
+		if _, ok := oldMethods[sig]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Item: "method", Sig: sig})
+		}
+	}
+
+	oldEvents, newEvents := eventsBySig(old), eventsBySig(new)
+	for sig, o := range oldEvents {
+		n, ok := newEvents[sig]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Item: "event", Sig: sig, Breaking: true})
+			continue
+		}
+		changes = append(changes, diffEvent(sig, o, n)...)
+	}
+	for sig := range newEvents {
+		if _, ok := oldEvents[sig]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Item: "event", Sig: sig})
+		}
+	}
+
+	oldErrors, newErrors := errorsBySig(old), errorsBySig(new)
+	for sig, o := range oldErrors {
+		n, ok := newErrors[sig]
+		if !ok {
+			changes = append(changes, Change{Kind: ChangeRemoved, Item: "error", Sig: sig})
+			continue
+		}
+		if detail := diffNames(o.Inputs, n.Inputs); detail != "" {
+			changes = append(changes, Change{Kind: ChangeModified, Item: "error", Sig: sig, Detail: detail})
+		}
+	}
+	for sig := range newErrors {
+		if _, ok := oldErrors[sig]; !ok {
+			changes = append(changes, Change{Kind: ChangeAdded, Item: "error", Sig: sig})
+		}
+	}
+
+	changes = append(changes, diffSpecial("fallback", old.HasFallback(), new.HasFallback())...)
+	changes = append(changes, diffSpecial("receive", old.HasReceive(), new.HasReceive())...)
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return cmp.Or(
+			cmp.Compare(a.Item, b.Item),
+			cmp.Compare(a.Sig, b.Sig),
+			cmp.Compare(a.Kind, b.Kind),
+			cmp.Compare(a.Detail, b.Detail),
+		)
+	})
+	return changes
+}
+
+// diffMethod compares two methods with the same signature.
+func diffMethod(sig string, old, new Method) []Change {
+	var changes []Change
+	if have, want := argumentTypes(old.Outputs), argumentTypes(new.Outputs); have != want {
+		changes = append(changes, Change{
+			Kind: ChangeModified, Item: "method", Sig: sig, Breaking: true,
+			Detail: fmt.Sprintf("outputs changed from (%s) to (%s)", have, want),
+		})
+	} else if detail := diffNames(old.Outputs, new.Outputs); detail != "" {
+		changes = append(changes, Change{Kind: ChangeModified, Item: "method", Sig: sig, Detail: "output " + detail})
+	}
+	if detail := diffNames(old.Inputs, new.Inputs); detail != "" {
+		changes = append(changes, Change{Kind: ChangeModified, Item: "method", Sig: sig, Detail: "input " + detail})
+	}
+	if have, want := mutability(old), mutability(new); have != want {
+		changes = append(changes, Change{
+			Kind: ChangeModified, Item: "method", Sig: sig,
+			Breaking: (old.IsPayable() && !new.IsPayable()) || (old.IsConstant() && !new.IsConstant()),
+			Detail:   fmt.Sprintf("state mutability changed from %s to %s", have, want),
+		})
+	}
+	return changes
+}
+
+// diffEvent compares two events with the same signature.
+func diffEvent(sig string, old, new Event) []Change {
+	var changes []Change
+	if old.Anonymous != new.Anonymous {
+		changes = append(changes, Change{
+			Kind: ChangeModified, Item: "event", Sig: sig, Breaking: true,
+			Detail: fmt.Sprintf("anonymous changed from %v to %v", old.Anonymous, new.Anonymous),
+		})
+	}
+	if have, want := eventLayout(old), eventLayout(new); have != want {
+		changes = append(changes, Change{
+			Kind: ChangeModified, Item: "event", Sig: sig, Breaking: true,
+			Detail: fmt.Sprintf("indexed arguments changed from %s to %s", indexedNames(old), indexedNames(new)),
+		})
+	}
+	if detail := diffNames(old.Inputs, new.Inputs); detail != "" {
+		changes = append(changes, Change{Kind: ChangeModified, Item: "event", Sig: sig, Detail: "input " + detail})
+	}
+	return changes
+}
+
+// diffSpecial compares the presence of the fallback or receive function.
+func diffSpecial(item string, old, new bool) []Change {
+	switch {
+	case old && !new:
+		return []Change{{Kind: ChangeRemoved, Item: item, Sig: item + "()", Breaking: true}}
+	case !old && new:
+		return []Change{{Kind: ChangeAdded, Item: item, Sig: item + "()"}}
+	}
+	return nil
+}
+
+// diffNames describes the renames between two argument lists of the same types.
+func diffNames(old, new Arguments) string {
+	var renames []string
+	for i := range old {
+		if old[i].Name != new[i].Name {
+			renames = append(renames, fmt.Sprintf("%q renamed to %q", old[i].Name, new[i].Name))
+		}
+	}
+	return strings.Join(renames, ", ")
+}
+
+// argumentTypes returns the comma separated types of the arguments.
+func argumentTypes(args Arguments) string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+	}
+	return strings.Join(types, ",")
+}
+
+// indexedNames returns the list of indexed arguments of an event.
+func indexedNames(event Event) string {
+	var names []string
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			names = append(names, argName(input.Name, i))
+		}
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// mutability returns the state mutability of a method, deriving it from the
+// legacy constant and payable flags if it is not set.
+func mutability(method Method) string {
+	switch {
+	case method.StateMutability != "":
+		return method.StateMutability
+	case method.IsConstant():
+		return "view"
+	case method.IsPayable():
+		return "payable"
+	default:
+		return "nonpayable"
+	}
+}
+
+func methodsBySig(abi ABI) map[string]Method {
+	methods := make(map[string]Method, len(abi.Methods))
+	for _, method := range abi.Methods {
+		methods[method.Sig] = method
+	}
+	return methods
+}
+
+func eventsBySig(abi ABI) map[string]Event {
+	events := make(map[string]Event, len(abi.Events))
+	for _, event := range abi.Events {
+		events[event.Sig] = event
+	}
+	return events
+}
+
+func errorsBySig(abi ABI) map[string]Error {
+	errs := make(map[string]Error, len(abi.Errors))
+	for _, e := range abi.Errors {
+		errs[e.Sig] = e
+	}
+	return errs
+}
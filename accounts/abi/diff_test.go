@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	old, err := ParseHumanReadable([]string{
+		"function balanceOf(address owner) view returns (uint256)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function deposit() payable",
+		"function owner() view returns (address)",
+		"function burn(uint256 amount)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Approval(address indexed owner, address indexed spender, uint256 value)",
+		"error Unauthorized(address caller)",
+		"error Paused()",
+		"receive() external payable",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := ParseHumanReadable([]string{
+		"function balanceOf(address account) view returns (uint256)",
+		"function transfer(address to, uint256 amount)",
+		"function deposit()",
+		"function owner() returns (address)",
+		"function burn(uint256 amount) payable",
+		"function mint(address to, uint256 amount)",
+		"event Transfer(address indexed from, address to, uint256 value)",
+		"event Approval(address indexed owner, address indexed spender, uint256 value)",
+		"error Unauthorized(address account)",
+		"error Overflow(uint256 value)",
+		"fallback() external",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		`added error Overflow(uint256)`,
+		`removed error Paused()`,
+		`modified error Unauthorized(address): "caller" renamed to "account"`,
+		`modified event Transfer(address,address,uint256): indexed arguments changed from ['from', 'to'] to ['from'] (breaking)`,
+		`added fallback fallback()`,
+		`modified method balanceOf(address): input "owner" renamed to "account"`,
+		`modified method burn(uint256): state mutability changed from nonpayable to payable`,
+		`modified method deposit(): state mutability changed from payable to nonpayable (breaking)`,
+		`added method mint(address,uint256)`,
+		`modified method owner(): state mutability changed from view to nonpayable (breaking)`,
+		`modified method transfer(address,uint256): outputs changed from (bool) to () (breaking)`,
+		`removed receive receive() (breaking)`,
+	}
+	changes := Diff(old, new)
+	if len(changes) != len(want) {
+		for _, c := range changes {
+			t.Log(c)
+		}
+		t.Fatalf("change count mismatch: have %d, want %d", len(changes), len(want))
+	}
+	for i, c := range changes {
+		if c.String() != want[i] {
+			t.Errorf("change %d mismatch:\nhave %s\nwant %s", i, c, want[i])
+		}
+	}
+	if !changes.Breaking() {
+		t.Error("expected breaking changes")
+	}
+	if changes := Diff(old, old); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+	if additive := Diff(old, mustAppend(t, old, "function mint(address to, uint256 amount)")); additive.Breaking() {
+		t.Errorf("additions reported as breaking: %v", additive)
+	}
+}
+
+// mustAppend returns a copy of the ABI extended with the given declarations.
+func mustAppend(t *testing.T, abi ABI, decls ...string) ABI {
+	t.Helper()
+	extra, err := ParseHumanReadable(decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := ABI{Methods: make(map[string]Method), Events: abi.Events, Errors: abi.Errors, Receive: abi.Receive, Fallback: abi.Fallback}
+	for name, m := range abi.Methods {
+		merged.Methods[name] = m
+	}
+	for name, m := range extra.Methods {
+		merged.Methods[name] = m
+	}
+	return merged
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// TypedDataField is a member of an EIP-712 struct type.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedDataTypes maps EIP-712 struct type names to their members.
+type TypedDataTypes map[string][]TypedDataField
+
+// TypedDataDomain is the EIP-712 domain of a signed message. Only the fields
+// which are set are included in the domain separator.
+type TypedDataDomain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract *common.Address
+	Salt              *common.Hash
+}
+
+// HashTypedData computes the EIP-712 digest of a message, the value which is
+// signed by eth_signTypedData:
+//
+//	keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+//
+// The primary type of the message is the only type which is not referenced by
+// any other type. Message values are given as maps for structs, slices or
+// arrays for arrays, and the Go types used by the abi package for elementary
+// types (e.g. common.Address, *big.Int, [32]byte), with strings and []byte for
+// the dynamic types.
+//
+// The encoding itself is done by the signer's implementation in apitypes, the
+// values are only checked and converted into the representation it accepts.
+func HashTypedData(domain TypedDataDomain, types TypedDataTypes, message map[string]interface{}) (common.Hash, error) {
+	primary, err := types.primaryType()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	values, err := types.signerStruct(primary, message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	typedData := types.signerTypedData()
+	typedData.Types["EIP712Domain"], typedData.Domain = domain.signerDomain()
+	typedData.PrimaryType = primary
+	typedData.Message = values
+
+	digest, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(digest), nil
+}
+
+// Separator computes the EIP-712 domain separator, the struct hash of the
+// domain as an EIP712Domain type.
+func (domain TypedDataDomain) Separator() (common.Hash, error) {
+	var typedData apitypes.TypedData
+	fields, signerDomain := domain.signerDomain()
+	typedData.Types = apitypes.Types{"EIP712Domain": fields}
+	typedData.Domain = signerDomain
+
+	hash, err := typedData.HashStruct("EIP712Domain", signerDomain.Map())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// signerDomain returns the EIP712Domain type made up of the fields which are
+// set, along with the domain in the signer's representation.
+func (domain TypedDataDomain) signerDomain() ([]apitypes.Type, apitypes.TypedDataDomain) {
+	var (
+		fields []apitypes.Type
+		values = apitypes.TypedDataDomain{Name: domain.Name, Version: domain.Version}
+	)
+	if domain.Name != "" {
+		fields = append(fields, apitypes.Type{Name: "name", Type: "string"})
+	}
+	if domain.Version != "" {
+		fields = append(fields, apitypes.Type{Name: "version", Type: "string"})
+	}
+	if domain.ChainID != nil {
+		fields = append(fields, apitypes.Type{Name: "chainId", Type: "uint256"})
+		values.ChainId = (*math.HexOrDecimal256)(domain.ChainID)
+	}
+	if domain.VerifyingContract != nil {
+		fields = append(fields, apitypes.Type{Name: "verifyingContract", Type: "address"})
+		values.VerifyingContract = domain.VerifyingContract.Hex()
+	}
+	if domain.Salt != nil {
+		fields = append(fields, apitypes.Type{Name: "salt", Type: "bytes32"})
+		values.Salt = domain.Salt.Hex()
+	}
+	return fields, values
+}
+
+// HashStruct computes the EIP-712 hash of a value of the named struct type:
+//
+//	keccak256(typeHash ‖ encodeData(data))
+func (types TypedDataTypes) HashStruct(name string, data map[string]interface{}) (common.Hash, error) {
+	values, err := types.signerStruct(name, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	typedData := types.signerTypedData()
+	hash, err := typedData.HashStruct(name, values)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(hash), nil
+}
+
+// EncodeType returns the EIP-712 type encoding of the named struct type: the
+// type itself followed by all the struct types it references, sorted by name.
+func (types TypedDataTypes) EncodeType(name string) string {
+	typedData := types.signerTypedData()
+	return string(typedData.EncodeType(name))
+}
+
+// signerTypedData converts the types into the signer's representation.
+func (types TypedDataTypes) signerTypedData() apitypes.TypedData {
+	converted := make(apitypes.Types, len(types))
+	for name, fields := range types {
+		converted[name] = make([]apitypes.Type, len(fields))
+		for i, field := range fields {
+			converted[name][i] = apitypes.Type{Name: field.Name, Type: field.Type}
+		}
+	}
+	return apitypes.TypedData{Types: converted}
+}
+
+// primaryType returns the single struct type not referenced by any other.
+func (types TypedDataTypes) primaryType() (string, error) {
+	referenced := make(map[string]bool)
+	for _, fields := range types {
+		for _, field := range fields {
+			name, _, _ := strings.Cut(field.Type, "[")
+			referenced[name] = true
+		}
+	}
+	var candidates []string
+	for name := range types {
+		if name != "EIP712Domain" && !referenced[name] {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) != 1 {
+		slices.Sort(candidates)
+		return "", fmt.Errorf("abi: ambiguous typed data primary type, candidates %v", candidates)
+	}
+	return candidates[0], nil
+}
+
+// signerStruct checks a value of the named struct type and converts it into the
+// representation accepted by the signer.
+func (types TypedDataTypes) signerStruct(name string, data map[string]interface{}) (map[string]interface{}, error) {
+	fields, ok := types[name]
+	if !ok {
+		return nil, fmt.Errorf("abi: unknown typed data type %q", name)
+	}
+	if len(data) > len(fields) {
+		return nil, fmt.Errorf("abi: extra fields in %s value", name)
+	}
+	converted := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("abi: missing field %s.%s", name, field.Name)
+		}
+		v, err := types.signerValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("abi: field %s.%s: %v", name, field.Name, err)
+		}
+		converted[field.Name] = v
+	}
+	return converted, nil
+}
+
+// signerValue checks a single member value against its type and converts it
+// into the representation accepted by the signer: slices for arrays, hex
+// strings for addresses, *big.Int for integers and []byte for byte arrays.
+func (types TypedDataTypes) signerValue(typ string, value interface{}) (interface{}, error) {
+	if strings.HasSuffix(typ, "]") {
+		i := strings.LastIndexByte(typ, '[')
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, fmt.Errorf("expected array for type %s, got %T", typ, value)
+		}
+		if size := typ[i+1 : len(typ)-1]; size != "" {
+			if n, err := strconv.Atoi(size); err != nil || n != rv.Len() {
+				return nil, fmt.Errorf("array length %d mismatch for type %s", rv.Len(), typ)
+			}
+		}
+		converted := make([]interface{}, rv.Len())
+		for j := range converted {
+			v, err := types.signerValue(typ[:i], rv.Index(j).Interface())
+			if err != nil {
+				return nil, err
+			}
+			converted[j] = v
+		}
+		return converted, nil
+	}
+	if _, ok := types[typ]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected map for type %s, got %T", typ, value)
+		}
+		return types.signerStruct(typ, data)
+	}
+	t, err := NewType(typ, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(value)
+	if !rv.IsValid() {
+		return nil, errors.New("missing value")
+	}
+	switch t.T {
+	case StringTy:
+		if s, ok := value.(string); ok {
+			return s, nil
+		}
+	case BytesTy:
+		if b, ok := value.([]byte); ok {
+			return b, nil
+		}
+	case BoolTy:
+		if rv.Kind() == reflect.Bool {
+			return rv.Bool(), nil
+		}
+	case AddressTy:
+		if addr, ok := value.(common.Address); ok {
+			return addr.Hex(), nil
+		}
+	case IntTy, UintTy:
+		if v, ok := value.(*big.Int); ok && v != nil {
+			return v, nil
+		}
+		switch {
+		case rv.CanInt():
+			return big.NewInt(rv.Int()), nil
+		case rv.CanUint():
+			return new(big.Int).SetUint64(rv.Uint()), nil
+		}
+	case FixedBytesTy:
+		if (rv.Kind() == reflect.Array || rv.Kind() == reflect.Slice) && rv.Type().Elem().Kind() == reflect.Uint8 && rv.Len() == t.Size {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return b, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported typed data type %s", typ)
+	}
+	return nil, fmt.Errorf("invalid value %v (%T) for type %s", value, value, typ)
+}
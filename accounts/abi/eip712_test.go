@@ -0,0 +1,225 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Tests the example message of the EIP-712 specification.
+func TestHashTypedData(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = common.HexToAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+		domain   = TypedDataDomain{Name: "Ether Mail", Version: "1", ChainID: big.NewInt(1), VerifyingContract: &contract}
+		types    = TypedDataTypes{
+			"Person": {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+			"Mail":   {{Name: "from", Type: "Person"}, {Name: "to", Type: "Person"}, {Name: "contents", Type: "string"}},
+		}
+		message = map[string]interface{}{
+			"from":     map[string]interface{}{"name": "Cow", "wallet": common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB")},
+			"contents": "Hello, Bob!",
+		}
+	)
+	if have, want := types.EncodeType("Mail"), "Mail(Person from,Person to,string contents)Person(string name,address wallet)"; have != want {
+		t.Errorf("wrong type encoding: have %s, want %s", have, want)
+	}
+	separator, err := domain.Separator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"); separator != want {
+		t.Errorf("wrong domain separator: have %v, want %v", separator, want)
+	}
+	structHash, err := types.HashStruct("Mail", message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"); structHash != want {
+		t.Errorf("wrong struct hash: have %v, want %v", structHash, want)
+	}
+	digest, err := HashTypedData(domain, types, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"); digest != want {
+		t.Errorf("wrong digest: have %v, want %v", digest, want)
+	}
+}
+
+func TestHashTypedDataErrors(t *testing.T) {
+	t.Parallel()
+
+	types := TypedDataTypes{
+		"Order": {{Name: "amount", Type: "uint8"}, {Name: "ids", Type: "bytes4[2]"}},
+	}
+	tests := []map[string]interface{}{
+		{"amount": big.NewInt(256), "ids": [][4]byte{{}, {}}}, // overflow
+		{"amount": big.NewInt(1), "ids": [][4]byte{{}}},       // array length
+		{"amount": big.NewInt(1), "ids": [][]byte{{1}, {2}}},  // bytes length
+		{"amount": "1", "ids": [][4]byte{{}, {}}},             // wrong type
+		{"ids": [][4]byte{{}, {}}},                            // missing field
+		{"amount": 1, "ids": [][4]byte{{}, {}}, "extra": 1},   // extra field
+	}
+	for i, message := range tests {
+		if _, err := HashTypedData(TypedDataDomain{Name: "test"}, types, message); err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+	if _, err := HashTypedData(TypedDataDomain{Name: "test"}, types, map[string]interface{}{"amount": 1, "ids": [][4]byte{{}, {}}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// Multiple unreferenced types leave the primary type ambiguous
+	types["Other"] = []TypedDataField{{Name: "x", Type: "bool"}}
+	if _, err := HashTypedData(TypedDataDomain{Name: "test"}, types, map[string]interface{}{}); err == nil {
+		t.Error("expected ambiguous primary type error")
+	}
+}
+
+// Tests that typed data is hashed like the signer does.
+func TestHashTypedDataSigner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		salt     = common.HexToHash("0xf2d857f4a3edcb9b78b4d503bfe733db1e3f6cdc2b7971ee739626c97e86a558")
+		alice    = map[string]interface{}{
+			"wallet": common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"),
+			"age":    big.NewInt(42),
+			"active": true,
+			"data":   []byte{0xde, 0xad, 0xbe, 0xef},
+			"score":  big.NewInt(-7),
+		}
+		bob = map[string]interface{}{
+			"wallet": common.HexToAddress("0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"),
+			"age":    big.NewInt(0),
+			"active": false,
+			"data":   []byte{},
+			"score":  big.NewInt(1 << 30),
+		}
+		types = TypedDataTypes{
+			"Person": {
+				{Name: "wallet", Type: "address"},
+				{Name: "age", Type: "uint8"},
+				{Name: "active", Type: "bool"},
+				{Name: "data", Type: "bytes"},
+				{Name: "score", Type: "int32"},
+			},
+			"Group": {
+				{Name: "name", Type: "string"},
+				{Name: "members", Type: "Person[]"},
+				{Name: "matrix", Type: "uint16[2][]"},
+				{Name: "tags", Type: "bytes3[]"},
+				{Name: "id", Type: "bytes32"},
+			},
+		}
+		message = map[string]interface{}{
+			"name":    "Friends",
+			"members": []interface{}{alice, bob},
+			"matrix":  [][2]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3), big.NewInt(65535)}},
+			"tags":    [][3]byte{{1, 2, 3}, {4, 5, 6}},
+			"id":      common.HexToHash("0x0102"),
+		}
+	)
+	domains := []TypedDataDomain{
+		{Name: "Groups", Version: "2", ChainID: big.NewInt(5), VerifyingContract: &contract},
+		{Name: "Groups", ChainID: big.NewInt(1)},
+		{Version: "1", VerifyingContract: &contract},
+		{Name: "Groups", Salt: &salt},
+	}
+	for i, domain := range domains {
+		have, err := HashTypedData(domain, types, message)
+		if err != nil {
+			t.Fatalf("test %d: failed to hash typed data: %v", i, err)
+		}
+		typedData := apitypes.TypedData{
+			Types:       apitypes.Types{"EIP712Domain": nil},
+			PrimaryType: "Group",
+			Domain:      apitypes.TypedDataDomain{Name: domain.Name, Version: domain.Version},
+			Message:     signerValue(message).(map[string]interface{}),
+		}
+		for name, fields := range types {
+			for _, field := range fields {
+				typedData.Types[name] = append(typedData.Types[name], apitypes.Type{Name: field.Name, Type: field.Type})
+			}
+		}
+		if domain.Name != "" {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{Name: "name", Type: "string"})
+		}
+		if domain.Version != "" {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{Name: "version", Type: "string"})
+		}
+		if domain.ChainID != nil {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{Name: "chainId", Type: "uint256"})
+			typedData.Domain.ChainId = (*math.HexOrDecimal256)(domain.ChainID)
+		}
+		if domain.VerifyingContract != nil {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{Name: "verifyingContract", Type: "address"})
+			typedData.Domain.VerifyingContract = domain.VerifyingContract.Hex()
+		}
+		if domain.Salt != nil {
+			typedData.Types["EIP712Domain"] = append(typedData.Types["EIP712Domain"], apitypes.Type{Name: "salt", Type: "bytes32"})
+			typedData.Domain.Salt = domain.Salt.Hex()
+		}
+		want, _, err := apitypes.TypedDataAndHash(typedData)
+		if err != nil {
+			t.Fatalf("test %d: signer failed to hash typed data: %v", i, err)
+		}
+		if have != common.BytesToHash(want) {
+			t.Errorf("test %d: digest mismatch: have %v, want %x", i, have, want)
+		}
+		if have, want := types.EncodeType("Group"), string(typedData.EncodeType("Group")); have != want {
+			t.Errorf("test %d: type encoding mismatch: have %s, want %s", i, have, want)
+		}
+	}
+}
+
+// signerValue converts a typed data value into the representation accepted by
+// the signer, which takes addresses as hex strings and byte arrays as slices.
+func signerValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case *big.Int, string, bool, []byte:
+		return v
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			converted[key] = signerValue(field)
+		}
+		return converted
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return b
+	}
+	converted := make([]interface{}, rv.Len())
+	for i := range converted {
+		converted[i] = signerValue(rv.Index(i).Interface())
+	}
+	return converted
+}
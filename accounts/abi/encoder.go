@@ -0,0 +1,225 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Encoder packs method calls into a reusable buffer, avoiding most of the
+// intermediate allocations of ABI.Pack. Elementary values of their canonical Go
+// types (native integers, *big.Int, common.Address, bool, [32]byte, []byte and
+// string) are written directly into the output; other values are packed using
+// the regular encoder.
+//
+// An Encoder is not safe for concurrent use.
+type Encoder struct {
+	abi ABI
+	buf []byte
+}
+
+// NewEncoder creates an encoder for calls to the given contract.
+func NewEncoder(abi ABI) *Encoder {
+	return &Encoder{abi: abi}
+}
+
+// Pack packs the call like ABI.Pack, reusing the internal buffer of the encoder.
+// The returned slice is only valid until the next call to Pack.
+func (e *Encoder) Pack(name string, args ...interface{}) ([]byte, error) {
+	buf, err := e.PackInto(e.buf[:0], name, args...)
+	if err != nil {
+		return nil, err
+	}
+	e.buf = buf
+	return buf, nil
+}
+
+// PackInto appends the packed call to the named method (or the constructor if
+// the name is empty) to buf and returns the extended buffer.
+func (e *Encoder) PackInto(buf []byte, name string, args ...interface{}) ([]byte, error) {
+	inputs := e.abi.Constructor.Inputs
+	if name != "" {
+		method, exist := e.abi.Methods[name]
+		if !exist {
+			return nil, fmt.Errorf("method '%s' not found", name)
+		}
+		buf = append(buf, method.ID...)
+		inputs = method.Inputs
+	}
+	return inputs.packInto(buf, e.abi.Options, args...)
+}
+
+// packInto appends the packed arguments to buf.
+func (arguments Arguments) packInto(buf []byte, opts Options, args ...any) ([]byte, error) {
+	if (len(args) == 1 && len(arguments) > 1) || opts.Converters != nil {
+		// Struct expansion and custom conversions are rare enough to not
+		// warrant a fast path
+		packed, err := arguments.PackWithOptions(opts, args...)
+		if err != nil {
+			return nil, err
+		}
+		return append(buf, packed...), nil
+	}
+	if len(args) != len(arguments) {
+		return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(args), len(arguments))
+	}
+	// Reserve and zero the head, dynamic values are appended after it
+	start, headSize := len(buf), 0
+	for _, arg := range arguments {
+		headSize += getTypeSize(arg.Type)
+	}
+	buf = slices.Grow(buf, headSize)[:start+headSize]
+	clear(buf[start:])
+
+	head := start
+	for i, a := range args {
+		typ := arguments[i].Type
+		if isDynamicType(typ) {
+			binary.BigEndian.PutUint64(buf[head+24:head+32], uint64(len(buf)-start))
+			var err error
+			if buf, err = appendDynamic(buf, typ, a, opts); err != nil {
+				return nil, err
+			}
+			head += 32
+			continue
+		}
+		size := getTypeSize(typ)
+		if size != 32 || !putWord(buf[head:head+32], typ, a, opts) {
+			packed, err := typ.pack(reflect.ValueOf(a), opts)
+			if err != nil {
+				return nil, err
+			}
+			copy(buf[head:head+size], packed)
+		}
+		head += size
+	}
+	return buf, nil
+}
+
+// putWord writes an elementary value into a zeroed 32 byte word, returning false
+// if the value is not handled by the fast path.
+func putWord(word []byte, t Type, v any, opts Options) bool {
+	switch t.T {
+	case UintTy, IntTy:
+		if opts.StrictIntegers {
+			return false
+		}
+		switch v := v.(type) {
+		case *big.Int:
+			if v == nil || v.Sign() < 0 || v.BitLen() > 256 {
+				return false
+			}
+			v.FillBytes(word)
+		case uint64:
+			binary.BigEndian.PutUint64(word[24:], v)
+		case uint32:
+			binary.BigEndian.PutUint64(word[24:], uint64(v))
+		case uint16:
+			binary.BigEndian.PutUint64(word[24:], uint64(v))
+		case uint8:
+			binary.BigEndian.PutUint64(word[24:], uint64(v))
+		case uint:
+			binary.BigEndian.PutUint64(word[24:], uint64(v))
+		case int64:
+			putInt(word, v)
+		case int32:
+			putInt(word, int64(v))
+		case int16:
+			putInt(word, int64(v))
+		case int8:
+			putInt(word, int64(v))
+		case int:
+			putInt(word, int64(v))
+		default:
+			return false
+		}
+	case AddressTy:
+		addr, ok := v.(common.Address)
+		if !ok {
+			return false
+		}
+		copy(word[12:], addr[:])
+	case BoolTy:
+		b, ok := v.(bool)
+		if !ok {
+			return false
+		}
+		if b {
+			word[31] = 1
+		}
+	case FixedBytesTy:
+		switch v := v.(type) {
+		case [32]byte:
+			copy(word, v[:])
+		case common.Hash:
+			copy(word, v[:])
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// putInt writes the two's complement of a signed integer into a zeroed word.
+func putInt(word []byte, v int64) {
+	if v < 0 {
+		for i := 0; i < 24; i++ {
+			word[i] = 0xff
+		}
+	}
+	binary.BigEndian.PutUint64(word[24:], uint64(v))
+}
+
+// appendDynamic appends the encoding of a dynamic value to buf.
+func appendDynamic(buf []byte, t Type, v any, opts Options) ([]byte, error) {
+	switch v := v.(type) {
+	case string:
+		if t.T == StringTy {
+			return appendBytes(buf, v), nil
+		}
+	case []byte:
+		if t.T == BytesTy {
+			return appendBytes(buf, v), nil
+		}
+	}
+	packed, err := t.pack(reflect.ValueOf(v), opts)
+	if err != nil {
+		return nil, err
+	}
+	return append(buf, packed...), nil
+}
+
+// appendBytes appends the length prefixed, zero padded encoding of a string or
+// byte slice to buf.
+func appendBytes[T string | []byte](buf []byte, data T) []byte {
+	offset := len(buf)
+	buf = append(buf, make([]byte, 32)...)
+	binary.BigEndian.PutUint64(buf[offset+24:], uint64(len(data)))
+	buf = append(buf, data...)
+	if rem := len(data) % 32; rem != 0 {
+		buf = append(buf, make([]byte, 32-rem)...)
+	}
+	return buf
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var encoderTestABI = func() ABI {
+	abi, err := ParseHumanReadable([]string{
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function mixed(int8 a, uint32 b, bool c, bytes32 d, string e, bytes f, bytes4 g)",
+		"function complex((uint256 x, bytes y) t, uint64[] list, address[2] pair)",
+		"constructor(string name, int256 supply)",
+	})
+	if err != nil {
+		panic(err)
+	}
+	return abi
+}()
+
+// Tests that the encoder produces the same output as the regular packer.
+func TestEncoderPack(t *testing.T) {
+	t.Parallel()
+
+	type tuple struct {
+		X *big.Int
+		Y []byte
+	}
+	tests := []struct {
+		method string
+		args   []interface{}
+	}{
+		{"transfer", []interface{}{common.HexToAddress("0x1234"), big.NewInt(1000)}},
+		{"transfer", []interface{}{common.HexToAddress("0x1234"), uint64(1000)}},
+		{"transfer", []interface{}{common.HexToAddress("0x1234"), big.NewInt(-1)}},
+		{"mixed", []interface{}{int8(-5), uint32(7), true, common.HexToHash("0xff"), "hello world", bytes.Repeat([]byte{1}, 33), [4]byte{1, 2, 3, 4}}},
+		{"mixed", []interface{}{int8(5), uint32(0), false, [32]byte{}, "", []byte{}, [4]byte{}}},
+		{"complex", []interface{}{tuple{big.NewInt(1), []byte{2}}, []uint64{3, 4}, [2]common.Address{{5}, {6}}}},
+		{"", []interface{}{"token", big.NewInt(-100)}},
+	}
+	enc := NewEncoder(encoderTestABI)
+	for i, tt := range tests {
+		want, err := encoderTestABI.Pack(tt.method, tt.args...)
+		have, encErr := enc.Pack(tt.method, tt.args...)
+		if err != nil {
+			if encErr == nil {
+				t.Errorf("test %d: expected error %v", i, err)
+			}
+			continue
+		}
+		if encErr != nil {
+			t.Fatalf("test %d: encoder pack failed: %v", i, encErr)
+		}
+		if !bytes.Equal(have, want) {
+			t.Errorf("test %d: encoding mismatch:\nhave %x\nwant %x", i, have, want)
+		}
+		// Appending to an existing buffer must retain its contents
+		prefix := []byte{0xde, 0xad}
+		have, err = enc.PackInto(prefix, tt.method, tt.args...)
+		if err != nil {
+			t.Fatalf("test %d: encoder pack into failed: %v", i, err)
+		}
+		if !bytes.Equal(have, append([]byte{0xde, 0xad}, want...)) {
+			t.Errorf("test %d: appended encoding mismatch:\nhave %x\nwant %x", i, have, want)
+		}
+	}
+	if _, err := enc.Pack("transfer", common.Address{}); err == nil {
+		t.Error("expected argument count mismatch")
+	}
+	if _, err := enc.Pack("missing"); err == nil {
+		t.Error("expected missing method error")
+	}
+}
+
+func BenchmarkPackTransfer(b *testing.B) {
+	var (
+		to     = common.HexToAddress("0x1234")
+		amount = big.NewInt(1000)
+	)
+	b.Run("Pack", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			encoderTestABI.Pack("transfer", to, amount)
+		}
+	})
+	b.Run("Encoder", func(b *testing.B) {
+		enc := NewEncoder(encoderTestABI)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc.Pack("transfer", to, amount)
+		}
+	})
+}
+
+func BenchmarkPackDynamic(b *testing.B) {
+	var (
+		hash = common.HexToHash("0xff")
+		data = bytes.Repeat([]byte{1}, 100)
+	)
+	b.Run("Pack", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			encoderTestABI.Pack("mixed", int8(-5), uint32(7), true, hash, "hello world", data, [4]byte{1, 2, 3, 4})
+		}
+	})
+	b.Run("Encoder", func(b *testing.B) {
+		enc := NewEncoder(encoderTestABI)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			enc.Pack("mixed", int8(-5), uint32(7), true, hash, "hello world", data, [4]byte{1, 2, 3, 4})
+		}
+	})
+}
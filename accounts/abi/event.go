@@ -18,6 +18,7 @@ package abi
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -101,3 +102,173 @@ func NewEvent(name, rawName string, anonymous bool, inputs Arguments) Event {
 func (e Event) String() string {
 	return e.str
 }
+
+// TopicsFor builds the topic filter for logs of the event, suitable for use in
+// a FilterQuery. Values are keyed by the names of indexed arguments; arguments
+// without a value match any topic. A value given as []interface{} matches any
+// of the contained alternatives.
+//
+// Values are checked against the argument types and encoded by MakeTopics, so
+// integers must be given as sized Go integers or *big.Int. Dynamic values
+// (string and bytes) are hashed as done by the EVM, and values of array and
+// tuple types must be supplied as their precomputed topic hash. A common.Hash
+// is accepted as the raw topic for arguments of any type.
+func (e Event) TopicsFor(argValues map[string]interface{}) ([][]common.Hash, error) {
+	var topics [][]common.Hash
+	if !e.Anonymous {
+		topics = append(topics, []common.Hash{e.ID})
+	}
+	used := 0
+	for _, input := range e.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		value, ok := argValues[input.Name]
+		if !ok || value == nil {
+			topics = append(topics, nil)
+			continue
+		}
+		used++
+
+		alternatives, ok := value.([]interface{})
+		if !ok {
+			alternatives = []interface{}{value}
+		}
+		for _, alt := range alternatives {
+			if err := checkTopic(input.Type, alt); err != nil {
+				return nil, fmt.Errorf("abi: argument '%s': %v", input.Name, err)
+			}
+		}
+		rule, err := MakeTopics(alternatives)
+		if err != nil {
+			return nil, fmt.Errorf("abi: argument '%s': %v", input.Name, err)
+		}
+		topics = append(topics, rule[0])
+	}
+	if used != len(argValues) {
+		for name := range argValues {
+			if !e.hasIndexedInput(name) {
+				return nil, fmt.Errorf("abi: event %s has no indexed argument '%s'", e.Name, name)
+			}
+		}
+	}
+	return topics, nil
+}
+
+// hasIndexedInput reports whether the event has an indexed argument of the name.
+func (e Event) hasIndexedInput(name string) bool {
+	for _, input := range e.Inputs {
+		if input.Indexed && input.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTopic checks that a single indexed argument value can be converted into
+// the topic of type t by MakeTopics, which encodes it by its Go type.
+func checkTopic(t Type, value interface{}) error {
+	if _, ok := value.(common.Hash); ok {
+		return nil
+	}
+	switch t.T {
+	case StringTy:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("invalid value %v (%T) for type %v", value, value, t)
+		}
+		return nil
+	case BytesTy:
+		if _, ok := value.([]byte); !ok {
+			return fmt.Errorf("invalid value %v (%T) for type %v", value, value, t)
+		}
+		return nil
+	case SliceTy, ArrayTy, TupleTy:
+		return fmt.Errorf("values of type %v must be given as topic hash", t)
+	}
+	_, err := t.pack(reflect.ValueOf(value), Options{StrictIntegers: true})
+	return err
+}
+
+// DecodeLogInto fills the struct pointed to by out with the arguments of event
+// emitted in a log, reading the indexed arguments from the topics and the others
+// from the data. Struct fields are matched by argument name, honouring abi tags.
+//
+// Indexed strings, bytes, arrays and tuples are stored in the topics as their
+// keccak256 hash, which can't be reversed. Their fields must be of type
+// common.Hash (or [32]byte) and are set to the topic hash.
+func DecodeLogInto(out interface{}, event Event, topics []common.Hash, data []byte) error {
+	value := reflect.ValueOf(out)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("abi: cannot decode log into %T, want struct pointer", out)
+	}
+	value = value.Elem()
+
+	if !event.Anonymous {
+		if len(topics) == 0 || topics[0] != event.ID {
+			return fmt.Errorf("abi: log is not a %s event", event.Name)
+		}
+		topics = topics[1:]
+	}
+	names := make([]string, len(event.Inputs))
+	for i, input := range event.Inputs {
+		names[i] = input.Name
+	}
+	abi2struct, err := mapArgNamesToStructFields(names, value)
+	if err != nil {
+		return err
+	}
+	field := func(arg Argument) (reflect.Value, error) {
+		field := value.FieldByName(abi2struct[arg.Name])
+		if !field.IsValid() {
+			return field, fmt.Errorf("abi: field %s can't be found in the given value", arg.Name)
+		}
+		return field, nil
+	}
+	// Unpack the non-indexed arguments from the data
+	nonIndexed := event.Inputs.NonIndexed()
+	values, err := nonIndexed.Unpack(data)
+	if err != nil {
+		return err
+	}
+	for i, arg := range nonIndexed {
+		f, err := field(arg)
+		if err != nil {
+			return err
+		}
+		if err := set(f, reflect.ValueOf(values[i])); err != nil {
+			return fmt.Errorf("abi: field %s: %v", arg.Name, err)
+		}
+	}
+	// Reconstruct the indexed arguments from the topics
+	var indexed Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(indexed) != len(topics) {
+		return fmt.Errorf("abi: topic count mismatch: have %d, want %d", len(topics), len(indexed))
+	}
+	for i, arg := range indexed {
+		f, err := field(arg)
+		if err != nil {
+			return err
+		}
+		var v interface{}
+		switch arg.Type.T {
+		case StringTy, BytesTy, SliceTy, ArrayTy, TupleTy:
+			if f.Type() != reflect.TypeFor[common.Hash]() && f.Type() != reflect.TypeFor[[32]byte]() {
+				return fmt.Errorf("abi: indexed %v field %s is hashed and must be a common.Hash", arg.Type, arg.Name)
+			}
+			v = topics[i]
+		default:
+			if v, err = toGoType(0, arg.Type, topics[i].Bytes(), Options{}, nil); err != nil {
+				return fmt.Errorf("abi: field %s: %v", arg.Name, err)
+			}
+		}
+		if err := set(f, reflect.ValueOf(v)); err != nil {
+			return fmt.Errorf("abi: field %s: %v", arg.Name, err)
+		}
+	}
+	return nil
+}
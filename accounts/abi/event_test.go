@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -396,3 +397,130 @@ func TestEventIndexedWithArrayUnpack(t *testing.T) {
 	require.Equal(t, [2]uint8{0, 0}, rst.Value1)
 	require.Equal(t, stringOut, rst.Value2)
 }
+
+func TestEventTopicsFor(t *testing.T) {
+	t.Parallel()
+
+	abi, err := ParseHumanReadable([]string{
+		"event Registered(string indexed name, address indexed owner, uint64 indexed id, bytes data)",
+		"event Anon(bytes indexed blob, int8 indexed delta) anonymous",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		event = abi.Events["Registered"]
+		alice = common.HexToAddress("0xa11ce")
+		bob   = common.HexToAddress("0xb0b")
+	)
+	topics, err := event.TopicsFor(map[string]interface{}{
+		"name":  "vitalik",
+		"owner": []interface{}{alice, bob},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]common.Hash{
+		{event.ID},
+		{crypto.Keccak256Hash([]byte("vitalik"))},
+		{common.BytesToHash(alice.Bytes()), common.BytesToHash(bob.Bytes())},
+		nil,
+	}
+	if !reflect.DeepEqual(topics, want) {
+		t.Fatalf("wrong topics:\nhave %v\nwant %v", topics, want)
+	}
+	// Anonymous events have no signature topic, negative values are sign extended
+	topics, err = abi.Events["Anon"].TopicsFor(map[string]interface{}{"blob": []byte{1}, "delta": int8(-1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = [][]common.Hash{
+		{crypto.Keccak256Hash([]byte{1})},
+		{common.HexToHash("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")},
+	}
+	if !reflect.DeepEqual(topics, want) {
+		t.Fatalf("wrong anonymous topics:\nhave %v\nwant %v", topics, want)
+	}
+	// Invalid filters must be rejected
+	for i, args := range []map[string]interface{}{
+		{"data": []byte{1}},         // not indexed
+		{"missing": 1},              // unknown
+		{"name": []byte("vitalik")}, // wrong type
+		{"id": big.NewInt(-1)},      // out of range
+	} {
+		if _, err := event.TopicsFor(args); err == nil {
+			t.Errorf("test %d: expected error", i)
+		}
+	}
+}
+
+func TestDecodeLogInto(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Named(string indexed name, uint8 indexed kind, bytes payload)",
+		"event Raw(uint256 indexed id, uint256 value) anonymous",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	from, to := common.Address{0x01}, common.Address{0x02}
+
+	// Indexed and non-indexed arguments are merged into one struct
+	transfer := abi.Events["Transfer"]
+	data, _ := transfer.Inputs.NonIndexed().Pack(big.NewInt(100))
+	log := types.Log{
+		Topics: []common.Hash{transfer.ID, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+		Data:   data,
+	}
+	var tr struct {
+		From   common.Address
+		To     common.Address
+		Amount *big.Int `abi:"value"`
+	}
+	require.NoError(t, DecodeLogInto(&tr, transfer, log.Topics, log.Data))
+	assert.Equal(t, from, tr.From)
+	assert.Equal(t, to, tr.To)
+	assert.Equal(t, big.NewInt(100), tr.Amount)
+
+	// Hashed indexed arguments expose the topic hash
+	named := abi.Events["Named"]
+	data, _ = named.Inputs.NonIndexed().Pack([]byte{0xca, 0xfe})
+	nameHash := crypto.Keccak256Hash([]byte("alice"))
+	log = types.Log{
+		Topics: []common.Hash{named.ID, nameHash, common.BigToHash(big.NewInt(7))},
+		Data:   data,
+	}
+	var nm struct {
+		Name    common.Hash
+		Kind    uint8
+		Payload []byte
+	}
+	require.NoError(t, DecodeLogInto(&nm, named, log.Topics, log.Data))
+	assert.Equal(t, nameHash, nm.Name)
+	assert.Equal(t, uint8(7), nm.Kind)
+	assert.Equal(t, []byte{0xca, 0xfe}, nm.Payload)
+
+	var bad struct {
+		Name    string
+		Kind    uint8
+		Payload []byte
+	}
+	require.Error(t, DecodeLogInto(&bad, named, log.Topics, log.Data), "hashed topic into string field")
+
+	// Anonymous events consume all topics
+	raw := abi.Events["Raw"]
+	data, _ = raw.Inputs.NonIndexed().Pack(big.NewInt(2))
+	var rw struct {
+		Id    *big.Int
+		Value *big.Int
+	}
+	require.NoError(t, DecodeLogInto(&rw, raw, []common.Hash{common.BigToHash(big.NewInt(1))}, data))
+	assert.Equal(t, big.NewInt(1), rw.Id)
+	assert.Equal(t, big.NewInt(2), rw.Value)
+
+	// Mismatching logs are rejected
+	require.Error(t, DecodeLogInto(&tr, transfer, []common.Hash{named.ID}, nil), "wrong event")
+	require.Error(t, DecodeLogInto(&tr, transfer, []common.Hash{transfer.ID}, data), "missing topics")
+	require.Error(t, DecodeLogInto(tr, transfer, log.Topics, log.Data), "non-pointer")
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FixedPoint is the Go representation of the Solidity fixedMxN and ufixedMxN
+// types. The represented number is Value / 10^Decimals.
+type FixedPoint struct {
+	Value    *big.Int // Unscaled integer value, as stored in the encoding
+	Decimals int      // Number of decimal places (N of the type)
+}
+
+// NewFixedPoint converts a rational number into a fixed point value with the
+// given number of decimals. An error is returned if the number can't be
+// represented exactly.
+func NewFixedPoint(r *big.Rat, decimals int) (FixedPoint, error) {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+	if !scaled.IsInt() {
+		return FixedPoint{}, fmt.Errorf("abi: %v not representable with %d decimals", r.RatString(), decimals)
+	}
+	return FixedPoint{Value: new(big.Int).Set(scaled.Num()), Decimals: decimals}, nil
+}
+
+// Rat returns the fixed point value as a rational number.
+func (f FixedPoint) Rat() *big.Rat {
+	if f.Value == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).SetFrac(f.Value, pow10(f.Decimals))
+}
+
+// String returns the decimal representation of the value, with all its decimal
+// places (e.g. "-1.50" for a value of -150 with 2 decimals).
+func (f FixedPoint) String() string {
+	return f.Rat().FloatString(f.Decimals)
+}
+
+// pow10 returns 10^n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// isUnsignedFixed reports whether the fixed point type is an ufixed one.
+func (t Type) isUnsignedFixed() bool {
+	return strings.HasPrefix(t.stringKind, "ufixed")
+}
+
+// packFixedPoint packs a FixedPoint or *big.Rat value as the fixed point type t.
+func packFixedPoint(t Type, v reflect.Value) (*big.Int, error) {
+	var r *big.Rat
+	switch val := v.Interface().(type) {
+	case FixedPoint:
+		r = val.Rat()
+	case *big.Rat:
+		r = val
+	case big.Rat:
+		r = &val
+	default:
+		return nil, fmt.Errorf("abi: cannot use %v as type %v", v.Type(), t)
+	}
+	f, err := NewFixedPoint(r, t.Decimals)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkFixedPointRange(t, f.Value); err != nil {
+		return nil, err
+	}
+	return f.Value, nil
+}
+
+// readFixedPoint reads a fixed point value of type t from a 32 byte word.
+func readFixedPoint(t Type, word []byte) (FixedPoint, error) {
+	value := new(big.Int).SetBytes(word)
+	if !t.isUnsignedFixed() && value.Bit(255) == 1 {
+		value.Sub(value, new(big.Int).Lsh(common.Big1, 256))
+	}
+	if err := checkFixedPointRange(t, value); err != nil {
+		return FixedPoint{}, err
+	}
+	return FixedPoint{Value: value, Decimals: t.Decimals}, nil
+}
+
+// checkFixedPointRange verifies that the unscaled value fits into the bit size
+// of the fixed point type t.
+func checkFixedPointRange(t Type, value *big.Int) error {
+	kind := IntTy
+	if t.isUnsignedFixed() {
+		kind = UintTy
+	}
+	return checkIntegerRange(Type{T: kind, Size: t.Size, stringKind: t.stringKind}, value)
+}
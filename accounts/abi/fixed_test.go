@@ -0,0 +1,99 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFixedPointPackUnpack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ    string
+		input  interface{}
+		packed string
+		value  string
+	}{
+		{"ufixed128x18", big.NewRat(3, 2), "00000000000000000000000000000000000000000000000014d1120d7b160000", "1.500000000000000000"},
+		{"fixed128x18", big.NewRat(-3, 2), "ffffffffffffffffffffffffffffffffffffffffffffffffeb2eedf284ea0000", "-1.500000000000000000"},
+		{"fixed8x1", FixedPoint{Value: big.NewInt(-128), Decimals: 1}, strings.Repeat("ff", 31) + "80", "-12.8"},
+		{"ufixed16x2", FixedPoint{Value: big.NewInt(5), Decimals: 0}, "00000000000000000000000000000000000000000000000000000000000001f4", "5.00"},
+	}
+	for _, tt := range tests {
+		typ, err := NewType(tt.typ, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args := Arguments{{Type: typ}}
+		packed, err := args.Pack(tt.input)
+		if err != nil {
+			t.Fatalf("%s: pack failed: %v", tt.typ, err)
+		}
+		if have := common.Bytes2Hex(packed); have != tt.packed {
+			t.Errorf("%s: packed mismatch: have %s, want %s", tt.typ, have, tt.packed)
+		}
+		values, err := args.Unpack(packed)
+		if err != nil {
+			t.Fatalf("%s: unpack failed: %v", tt.typ, err)
+		}
+		if have := values[0].(FixedPoint).String(); have != tt.value {
+			t.Errorf("%s: value mismatch: have %s, want %s", tt.typ, have, tt.value)
+		}
+		// Unpacking into a rational should also work
+		var rat *big.Rat
+		if err := args.Copy(&rat, values); err != nil {
+			t.Fatalf("%s: copy failed: %v", tt.typ, err)
+		}
+		if rat.Cmp(values[0].(FixedPoint).Rat()) != 0 {
+			t.Errorf("%s: rational mismatch: have %v", tt.typ, rat)
+		}
+	}
+}
+
+func TestFixedPointPackErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ   string
+		input interface{}
+	}{
+		{"ufixed128x18", big.NewRat(-1, 1)},                             // negative unsigned
+		{"fixed8x1", big.NewRat(128, 10)},                               // overflow
+		{"ufixed16x2", big.NewRat(1, 3)},                                // inexact
+		{"fixed128x18", FixedPoint{Value: big.NewInt(1), Decimals: 19}}, // too precise
+		{"fixed128x18", big.NewInt(1)},                                  // wrong type
+	}
+	for i, tt := range tests {
+		typ, err := NewType(tt.typ, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := (Arguments{{Type: typ}}).Pack(tt.input); err == nil {
+			t.Errorf("test %d: expected error packing %v as %s", i, tt.input, tt.typ)
+		}
+	}
+	// Out of range values must be rejected when unpacking
+	typ, _ := NewType("ufixed8x1", "", nil)
+	if _, err := (Arguments{{Type: typ}}).Unpack(common.LeftPadBytes([]byte{1, 0}, 32)); err == nil {
+		t.Error("expected error unpacking out of range value")
+	}
+}
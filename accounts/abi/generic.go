@@ -0,0 +1,44 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+// Pack encodes a call to the named method of the ABI, or the constructor if the
+// name is empty. The arguments are supplied as a single value of type T: either
+// the value of the sole method input, or a struct whose fields are mapped onto
+// the inputs by name or `abi:""` tag.
+func Pack[T any](a ABI, name string, args T) ([]byte, error) {
+	inputs := a.Constructor.Inputs
+	if name != "" {
+		inputs = a.Methods[name].Inputs
+	}
+	if len(inputs) == 0 {
+		return a.Pack(name)
+	}
+	return a.Pack(name, args)
+}
+
+// Unpack decodes the output of the named method, or the data of the named event
+// or error, into a value of type T. T is either the type of a sole output, or a
+// struct whose fields are mapped onto the outputs by name or `abi:""` tag.
+func Unpack[T any](a ABI, name string, data []byte) (T, error) {
+	var out T
+	if err := a.UnpackIntoInterface(&out, name, data); err != nil {
+		var zero T
+		return zero, err
+	}
+	return out, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestGenericPackUnpack(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"function balanceOf(address owner) view returns (uint256)",
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"function reserves() view returns (uint112 reserve0, uint112 reserve1, uint32 timestamp)",
+		"function pause()",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Single argument and single output
+	owner := common.Address{0x01}
+	packed, err := Pack(abi, "balanceOf", owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := abi.Pack("balanceOf", owner)
+	if !bytes.Equal(packed, want) {
+		t.Fatalf("pack mismatch: have %x, want %x", packed, want)
+	}
+	balance, err := Unpack[*big.Int](abi, "balanceOf", common.LeftPadBytes([]byte{0x2a}, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Int64() != 42 {
+		t.Errorf("balance mismatch: have %v, want 42", balance)
+	}
+	// Struct arguments and outputs
+	type transferArgs struct {
+		To     common.Address
+		Amount *big.Int
+	}
+	packed, err = Pack(abi, "transfer", transferArgs{To: owner, Amount: big.NewInt(5)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ = abi.Pack("transfer", owner, big.NewInt(5))
+	if !bytes.Equal(packed, want) {
+		t.Fatalf("pack mismatch: have %x, want %x", packed, want)
+	}
+	type reserves struct {
+		Reserve0  *big.Int
+		Reserve1  *big.Int
+		Timestamp uint32
+	}
+	enc, err := abi.Methods["reserves"].Outputs.Pack(big.NewInt(1), big.NewInt(2), uint32(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := Unpack[reserves](abi, "reserves", enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Reserve0.Int64() != 1 || res.Reserve1.Int64() != 2 || res.Timestamp != 3 {
+		t.Errorf("unexpected reserves: %+v", res)
+	}
+	// Methods without arguments
+	if packed, err = Pack(abi, "pause", struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packed, abi.Methods["pause"].ID) {
+		t.Errorf("pack mismatch: have %x, want %x", packed, abi.Methods["pause"].ID)
+	}
+	// Errors are propagated
+	if _, err := Unpack[bool](abi, "transfer", []byte{1}); err == nil {
+		t.Error("expected error for malformed output")
+	}
+	if _, err := Pack(abi, "missing", 1); err == nil {
+		t.Error("expected error for unknown method")
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseHumanReadable creates an ABI from a list of human-readable Solidity-like
+// declarations, in the format popularized by ethers.js:
+//
+//	function transfer(address to, uint256 amount) returns (bool)
+//	function balanceOf(address owner) view returns (uint256)
+//	event Transfer(address indexed from, address indexed to, uint256 value)
+//	error InsufficientBalance(uint256 available, uint256 required)
+//	constructor(string name, (uint8 decimals, string symbol) meta) payable
+//	fallback() external payable
+//	receive() external payable
+//
+// Tuples can be written either as tuple(...) or as bare parentheses, and may be
+// nested or used in arrays. The shorthands uint, int and byte are expanded to
+// their canonical forms, so the resulting selectors and topics are identical to
+// the ones derived from the equivalent JSON description.
+func ParseHumanReadable(signatures []string) (ABI, error) {
+	fields := make([]abiField, 0, len(signatures))
+	for _, sig := range signatures {
+		if strings.TrimSpace(sig) == "" {
+			continue
+		}
+		f, err := parseHumanReadableField(sig)
+		if err != nil {
+			return ABI{}, fmt.Errorf("abi: failed to parse '%s': %v", sig, err)
+		}
+		fields = append(fields, f)
+	}
+	var abi ABI
+	if err := abi.setFields(fields); err != nil {
+		return ABI{}, err
+	}
+	return abi, nil
+}
+
+// hrLexer splits a human-readable declaration into identifier and symbol tokens.
+type hrLexer struct {
+	tokens []string
+	pos    int
+}
+
+func newHRLexer(input string) (*hrLexer, error) {
+	var tokens []string
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')' || c == ',' || c == '[' || c == ']':
+			tokens = append(tokens, string(c))
+			i++
+		case isAlpha(c) || isDigit(c) || isIdentifierSymbol(c):
+			start := i
+			for i < len(input) && (isAlpha(input[i]) || isDigit(input[i]) || isIdentifierSymbol(input[i])) {
+				i++
+			}
+			tokens = append(tokens, input[start:i])
+		default:
+			return nil, fmt.Errorf("unexpected character '%c'", c)
+		}
+	}
+	return &hrLexer{tokens: tokens}, nil
+}
+
+// peek returns the next token without consuming it.
+func (l *hrLexer) peek() string {
+	if l.pos < len(l.tokens) {
+		return l.tokens[l.pos]
+	}
+	return ""
+}
+
+// next consumes and returns the next token.
+func (l *hrLexer) next() string {
+	tok := l.peek()
+	if tok != "" {
+		l.pos++
+	}
+	return tok
+}
+
+// expect consumes the next token, failing if it isn't the wanted one.
+func (l *hrLexer) expect(want string) error {
+	if tok := l.next(); tok != want {
+		if tok == "" {
+			return fmt.Errorf("expected '%s', got end of input", want)
+		}
+		return fmt.Errorf("expected '%s', got '%s'", want, tok)
+	}
+	return nil
+}
+
+// parseHumanReadableField parses a single declaration into an ABI field.
+func parseHumanReadableField(sig string) (abiField, error) {
+	lex, err := newHRLexer(sig)
+	if err != nil {
+		return abiField{}, err
+	}
+	var f abiField
+	switch kind := lex.next(); kind {
+	case "function", "event", "error":
+		f.Type = kind
+		f.Name = lex.next()
+		if !isHRIdentifier(f.Name) {
+			return abiField{}, fmt.Errorf("invalid %s name '%s'", kind, f.Name)
+		}
+	case "constructor", "fallback", "receive":
+		f.Type = kind
+	default:
+		return abiField{}, fmt.Errorf("unknown declaration kind '%s'", kind)
+	}
+	inputs, err := parseHRParams(lex, f.Type == "event")
+	if err != nil {
+		return abiField{}, err
+	}
+	// Parse the trailing modifiers and the optional return list
+	mutability := "nonpayable"
+	for tok := lex.next(); tok != ""; tok = lex.next() {
+		switch tok {
+		case "external", "public", "virtual", "override":
+			// Visibility and inheritance specifiers don't affect the ABI
+		case "view", "pure", "payable", "nonpayable":
+			mutability = tok
+		case "constant":
+			mutability = "view"
+		case "anonymous":
+			if f.Type != "event" {
+				return abiField{}, errors.New("only events can be anonymous")
+			}
+			f.Anonymous = true
+		case "returns":
+			if f.Type != "function" {
+				return abiField{}, fmt.Errorf("%s cannot have return values", f.Type)
+			}
+			outputs, err := parseHRParams(lex, false)
+			if err != nil {
+				return abiField{}, err
+			}
+			f.Outputs, err = hrArguments(outputs)
+			if err != nil {
+				return abiField{}, err
+			}
+		default:
+			return abiField{}, fmt.Errorf("unexpected token '%s'", tok)
+		}
+	}
+	if f.Inputs, err = hrArguments(inputs); err != nil {
+		return abiField{}, err
+	}
+	if f.Type != "event" && f.Type != "error" {
+		f.StateMutability = mutability
+	}
+	if f.Type == "function" && f.Outputs == nil {
+		f.Outputs = []Argument{}
+	}
+	if (f.Type == "fallback" || f.Type == "receive") && len(f.Inputs) != 0 {
+		return abiField{}, fmt.Errorf("%s cannot have parameters", f.Type)
+	}
+	return f, nil
+}
+
+// parseHRParams parses a parenthesized, comma separated parameter list.
+func parseHRParams(lex *hrLexer, allowIndexed bool) ([]ArgumentMarshaling, error) {
+	if err := lex.expect("("); err != nil {
+		return nil, err
+	}
+	var params []ArgumentMarshaling
+	if lex.peek() == ")" {
+		lex.next()
+		return params, nil
+	}
+	for {
+		param, err := parseHRParam(lex, allowIndexed)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+
+		switch tok := lex.next(); tok {
+		case ",":
+		case ")":
+			return params, nil
+		case "":
+			return nil, errors.New("unexpected end of input in parameter list")
+		default:
+			return nil, fmt.Errorf("expected ',' or ')', got '%s'", tok)
+		}
+	}
+}
+
+// parseHRParam parses a single parameter: a type followed by an optional
+// indexed flag, data location and name.
+func parseHRParam(lex *hrLexer, allowIndexed bool) (ArgumentMarshaling, error) {
+	var param ArgumentMarshaling
+	if lex.peek() == "tuple" {
+		lex.next()
+	}
+	if lex.peek() == "(" {
+		components, err := parseHRParams(lex, false)
+		if err != nil {
+			return param, err
+		}
+		for i := range components {
+			// Tuple fields are converted to struct fields, so they need a name.
+			if components[i].Name == "" {
+				components[i].Name = fmt.Sprintf("arg%d", i)
+			}
+		}
+		param.Type, param.Components = "tuple", components
+	} else {
+		typ := lex.next()
+		if typ == "" {
+			return param, errors.New("unexpected end of input, expected type")
+		}
+		param.Type = normalizeHRType(typ)
+	}
+	suffix, err := parseHRArraySuffix(lex)
+	if err != nil {
+		return param, err
+	}
+	param.Type += suffix
+
+	for {
+		switch tok := lex.peek(); tok {
+		case "indexed":
+			if !allowIndexed {
+				return param, errors.New("only event parameters can be indexed")
+			}
+			lex.next()
+			param.Indexed = true
+		case "memory", "calldata", "storage", "payable":
+			lex.next()
+		default:
+			if isHRIdentifier(tok) {
+				param.Name = lex.next()
+			}
+			return param, nil
+		}
+	}
+}
+
+// parseHRArraySuffix consumes any number of [] or [N] array specifiers.
+func parseHRArraySuffix(lex *hrLexer) (string, error) {
+	var suffix string
+	for lex.peek() == "[" {
+		lex.next()
+		size := ""
+		if tok := lex.peek(); tok != "]" {
+			for _, c := range []byte(tok) {
+				if !isDigit(c) {
+					return "", fmt.Errorf("invalid array size '%s'", tok)
+				}
+			}
+			size = lex.next()
+		}
+		if err := lex.expect("]"); err != nil {
+			return "", err
+		}
+		suffix += "[" + size + "]"
+	}
+	return suffix, nil
+}
+
+// normalizeHRType expands the Solidity type aliases to their canonical form.
+func normalizeHRType(typ string) string {
+	switch typ {
+	case "uint":
+		return "uint256"
+	case "int":
+		return "int256"
+	case "byte":
+		return "bytes1"
+	case "fixed":
+		return "fixed128x18"
+	case "ufixed":
+		return "ufixed128x18"
+	}
+	return typ
+}
+
+// isHRIdentifier reports whether the token can be used as a name.
+func isHRIdentifier(tok string) bool {
+	if tok == "" || !(isAlpha(tok[0]) || isIdentifierSymbol(tok[0])) {
+		return false
+	}
+	switch tok {
+	case "returns", "indexed", "anonymous", "view", "pure", "payable", "nonpayable", "external", "public", "constant":
+		return false
+	}
+	return true
+}
+
+// hrArguments converts the parsed parameters to typed ABI arguments.
+func hrArguments(params []ArgumentMarshaling) ([]Argument, error) {
+	args := make([]Argument, 0, len(params))
+	for _, param := range params {
+		typ, err := NewType(param.Type, param.InternalType, param.Components)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, Argument{Name: param.Name, Type: typ, Indexed: param.Indexed})
+	}
+	return args, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseHumanReadable(t *testing.T) {
+	t.Parallel()
+	human := []string{
+		"constructor(string name, (uint8 decimals, string symbol) meta) payable",
+		"function transfer(address to, uint amount) returns (bool)",
+		"function balanceOf(address owner) external view returns (uint256 balance)",
+		"function transfer(address to, uint256 amount, bytes data)",
+		"function submit(tuple(address target, bytes[] data)[] calls, bytes32[2] salts) payable",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Raw(uint256 a, string indexed b) anonymous",
+		"error InsufficientBalance(uint256 available, uint256 required)",
+		"fallback() external",
+		"receive() external payable",
+	}
+	const equivalent = `[
+		{"type":"constructor","stateMutability":"payable","inputs":[{"name":"name","type":"string"},{"name":"meta","type":"tuple","components":[{"name":"decimals","type":"uint8"},{"name":"symbol","type":"string"}]}]},
+		{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+		{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"balance","type":"uint256"}]},
+		{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"},{"name":"data","type":"bytes"}],"outputs":[]},
+		{"type":"function","name":"submit","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"data","type":"bytes[]"}]},{"name":"salts","type":"bytes32[2]"}],"outputs":[]},
+		{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]},
+		{"type":"event","name":"Raw","anonymous":true,"inputs":[{"name":"a","type":"uint256"},{"name":"b","type":"string","indexed":true}]},
+		{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+		{"type":"fallback","stateMutability":"nonpayable"},
+		{"type":"receive","stateMutability":"payable"}
+	]`
+	have, err := ParseHumanReadable(human)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := JSON(strings.NewReader(equivalent))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, method := range want.Methods {
+		if !reflect.DeepEqual(have.Methods[name], method) {
+			t.Errorf("method %s mismatch:\nhave %+v\nwant %+v", name, have.Methods[name], method)
+		}
+	}
+	for name, event := range want.Events {
+		if !reflect.DeepEqual(have.Events[name], event) {
+			t.Errorf("event %s mismatch:\nhave %+v\nwant %+v", name, have.Events[name], event)
+		}
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("ABI mismatch:\nhave %+v\nwant %+v", have, want)
+	}
+	if sig := have.Methods["submit"].Sig; sig != "submit((address,bytes[])[],bytes32[2])" {
+		t.Errorf("unexpected signature: %s", sig)
+	}
+}
+
+func TestParseHumanReadableErrors(t *testing.T) {
+	t.Parallel()
+	for _, sig := range []string{
+		"transfer(address to)",
+		"function (address)",
+		"function foo(address",
+		"function foo(strin)",
+		"function foo(uint256[x])",
+		"function foo(address indexed a)",
+		"event Foo(uint256) returns (bool)",
+		"error Foo(uint256) anonymous",
+		"receive(uint256 a) external payable",
+		"function foo() external; function",
+	} {
+		if _, err := ParseHumanReadable([]string{sig}); err == nil {
+			t.Errorf("expected error parsing '%s'", sig)
+		}
+	}
+}
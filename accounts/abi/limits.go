@@ -0,0 +1,112 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDecodeLimit is returned (wrapped in a *LimitError) if decoding exceeds one
+// of the configured DecodeLimits.
+var ErrDecodeLimit = errors.New("abi: decode limit exceeded")
+
+// DecodeLimits bounds the resources spent on unpacking untrusted data. As the
+// offsets of dynamic values may point to the same location, a small input can
+// otherwise expand into a very large decoded value. A zero field means the
+// respective quantity is not limited.
+type DecodeLimits struct {
+	MaxElements int // Maximum total number of array elements across all arrays
+	MaxBytes    int // Maximum total length of all bytes and string values
+	MaxDepth    int // Maximum nesting depth of arrays and tuples
+}
+
+// LimitError reports which decode limit was exceeded.
+type LimitError struct {
+	Limit string // Name of the exceeded limit: "elements", "bytes" or "depth"
+	Max   int    // Configured maximum
+}
+
+// Error implements error.
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("abi: decode limit exceeded: more than %d %s", e.Max, e.Limit)
+}
+
+// Unwrap returns ErrDecodeLimit, so errors.Is can be used to detect any limit.
+func (e *LimitError) Unwrap() error {
+	return ErrDecodeLimit
+}
+
+// decodeBudget tracks the resources consumed while unpacking a single value.
+type decodeBudget struct {
+	limits   DecodeLimits
+	elements int
+	bytes    int
+	depth    int
+}
+
+// newDecodeBudget creates a budget for the limits, or nil if nothing is limited.
+func newDecodeBudget(limits DecodeLimits) *decodeBudget {
+	if limits == (DecodeLimits{}) {
+		return nil
+	}
+	return &decodeBudget{limits: limits}
+}
+
+// consumeElements accounts for an array of n elements about to be allocated.
+func (b *decodeBudget) consumeElements(n int) error {
+	if b == nil || b.limits.MaxElements == 0 {
+		return nil
+	}
+	if n > b.limits.MaxElements-b.elements {
+		return &LimitError{Limit: "elements", Max: b.limits.MaxElements}
+	}
+	b.elements += n
+	return nil
+}
+
+// consumeBytes accounts for a bytes or string value of length n.
+func (b *decodeBudget) consumeBytes(n int) error {
+	if b == nil || b.limits.MaxBytes == 0 {
+		return nil
+	}
+	if n > b.limits.MaxBytes-b.bytes {
+		return &LimitError{Limit: "bytes", Max: b.limits.MaxBytes}
+	}
+	b.bytes += n
+	return nil
+}
+
+// enter descends into a nested array or tuple, which must be followed by a
+// call to leave once the value is decoded.
+func (b *decodeBudget) enter() error {
+	if b == nil {
+		return nil
+	}
+	if b.limits.MaxDepth != 0 && b.depth >= b.limits.MaxDepth {
+		return &LimitError{Limit: "depth", Max: b.limits.MaxDepth}
+	}
+	b.depth++
+	return nil
+}
+
+// leave returns from a nested array or tuple.
+func (b *decodeBudget) leave() {
+	if b != nil {
+		b.depth--
+	}
+}
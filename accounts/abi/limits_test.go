@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// aliasedArrays encodes a uint256[][] of n inner arrays which all point to the
+// same array of m elements, expanding to n*m elements from only n+m+3 words.
+func aliasedArrays(n, m int) []byte {
+	words := []*big.Int{big.NewInt(32), big.NewInt(int64(n))}
+	for i := 0; i < n; i++ {
+		words = append(words, big.NewInt(int64(32*n))) // all offsets point to the same array
+	}
+	words = append(words, big.NewInt(int64(m)))
+	for i := 0; i < m; i++ {
+		words = append(words, big.NewInt(int64(i)))
+	}
+	var data []byte
+	for _, w := range words {
+		data = append(data, common.LeftPadBytes(w.Bytes(), 32)...)
+	}
+	return data
+}
+
+func TestDecodeLimits(t *testing.T) {
+	t.Parallel()
+	var (
+		nested = Arguments{{Type: mustTypes(t, "uint256[][]")[0]}}
+		strs   = Arguments{{Type: mustTypes(t, "string[]")[0]}}
+		deep   = Arguments{{Type: mustTypes(t, "uint8[][][][]")[0]}}
+	)
+	data := aliasedArrays(64, 64)
+	if _, err := nested.Unpack(data); err != nil {
+		t.Fatalf("unlimited unpack failed: %v", err)
+	}
+	strData, _ := strs.Pack([]string{"hello", "world", "!"})
+	deepData, _ := deep.Pack([][][][]uint8{{{{1}}}})
+
+	tests := []struct {
+		args   Arguments
+		data   []byte
+		limits DecodeLimits
+		limit  string
+	}{
+		{nested, data, DecodeLimits{MaxElements: 64*64 + 64}, ""},
+		{nested, data, DecodeLimits{MaxElements: 64*64 + 63}, "elements"},
+		{nested, data, DecodeLimits{MaxElements: 1000}, "elements"},
+		{strs, strData, DecodeLimits{MaxBytes: 11}, ""},
+		{strs, strData, DecodeLimits{MaxBytes: 10}, "bytes"},
+		{deep, deepData, DecodeLimits{MaxDepth: 4}, ""},
+		{deep, deepData, DecodeLimits{MaxDepth: 3}, "depth"},
+	}
+	for i, tt := range tests {
+		_, err := tt.args.UnpackWithOptions(Options{Limits: tt.limits}, tt.data)
+		if tt.limit == "" {
+			if err != nil {
+				t.Errorf("test %d: unexpected error: %v", i, err)
+			}
+			continue
+		}
+		var lerr *LimitError
+		if !errors.As(err, &lerr) || lerr.Limit != tt.limit {
+			t.Errorf("test %d: expected %s limit error, got %v", i, tt.limit, err)
+		}
+		if !errors.Is(err, ErrDecodeLimit) {
+			t.Errorf("test %d: error does not match ErrDecodeLimit", i)
+		}
+	}
+	// Limits apply per unpack operation, not cumulatively across calls
+	abi := ABI{Options: Options{Limits: DecodeLimits{MaxBytes: 11}}}
+	for i := 0; i < 3; i++ {
+		if _, err := strs.UnpackWithOptions(abi.Options, strData); err != nil {
+			t.Fatalf("repeated unpack %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestUnpackHugeArrayLength(t *testing.T) {
+	t.Parallel()
+	args := Arguments{{Type: mustTypes(t, "uint256[]")[0]}}
+	// A length overflowing the offset computation must not cause a panic
+	data := append(common.LeftPadBytes([]byte{32}, 32), common.LeftPadBytes(big.NewInt(1<<59).Bytes(), 32)...)
+	if _, err := args.Unpack(data); err == nil {
+		t.Fatal("expected error for oversized array length")
+	}
+}
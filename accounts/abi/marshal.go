@@ -0,0 +1,161 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// fieldJSON is the canonical JSON representation of an ABI entry. The fields
+// are declared in alphabetical order, matching the output of solc.
+type fieldJSON struct {
+	Anonymous       *bool           `json:"anonymous,omitempty"`
+	Inputs          *[]argumentJSON `json:"inputs,omitempty"`
+	Name            string          `json:"name,omitempty"`
+	Outputs         *[]argumentJSON `json:"outputs,omitempty"`
+	StateMutability string          `json:"stateMutability,omitempty"`
+	Type            string          `json:"type"`
+}
+
+// argumentJSON is the canonical JSON representation of an argument.
+type argumentJSON struct {
+	Components   []argumentJSON `json:"components,omitempty"`
+	Indexed      *bool          `json:"indexed,omitempty"`
+	InternalType string         `json:"internalType,omitempty"`
+	Name         string         `json:"name"`
+	Type         string         `json:"type"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding the ABI in a canonical form
+// suitable for fingerprinting: the constructor, fallback and receive functions
+// come first, followed by the functions, events and errors ordered by name and
+// signature. The state mutability is always set, with the legacy constant and
+// payable flags omitted.
+//
+// The internalType of arguments is only retained for structs and user-defined
+// value types. Unnamed event and error arguments are named by their position.
+// As overloaded functions are ordered by signature, parsing the output may
+// assign the disambiguated names (e.g. transfer0) differently.
+func (abi ABI) MarshalJSON() ([]byte, error) {
+	var fields []fieldJSON
+	if abi.Constructor.String() != "" {
+		fields = append(fields, fieldJSON{
+			Type:            "constructor",
+			Inputs:          argumentsJSON(abi.Constructor.Inputs, false),
+			StateMutability: mutability(abi.Constructor),
+		})
+	}
+	if abi.HasFallback() {
+		fields = append(fields, fieldJSON{Type: "fallback", StateMutability: mutability(abi.Fallback)})
+	}
+	if abi.HasReceive() {
+		fields = append(fields, fieldJSON{Type: "receive", StateMutability: mutability(abi.Receive)})
+	}
+	methods := make([]Method, 0, len(abi.Methods))
+	for _, method := range abi.Methods {
+		methods = append(methods, method)
+	}
+	slices.SortFunc(methods, func(a, b Method) int {
+		return cmp.Or(cmp.Compare(a.RawName, b.RawName), cmp.Compare(a.Sig, b.Sig))
+	})
+	for _, method := range methods {
+		fields = append(fields, fieldJSON{
+			Type:            "function",
+			Name:            method.RawName,
+			Inputs:          argumentsJSON(method.Inputs, false),
+			Outputs:         argumentsJSON(method.Outputs, false),
+			StateMutability: mutability(method),
+		})
+	}
+	events := make([]Event, 0, len(abi.Events))
+	for _, event := range abi.Events {
+		events = append(events, event)
+	}
+	slices.SortFunc(events, func(a, b Event) int {
+		return cmp.Or(cmp.Compare(a.RawName, b.RawName), cmp.Compare(a.Sig, b.Sig))
+	})
+	for _, event := range events {
+		fields = append(fields, fieldJSON{
+			Type:      "event",
+			Name:      event.RawName,
+			Inputs:    argumentsJSON(event.Inputs, true),
+			Anonymous: &event.Anonymous,
+		})
+	}
+	errs := make([]Error, 0, len(abi.Errors))
+	for _, e := range abi.Errors {
+		errs = append(errs, e)
+	}
+	slices.SortFunc(errs, func(a, b Error) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.Sig, b.Sig))
+	})
+	for _, e := range errs {
+		fields = append(fields, fieldJSON{
+			Type:   "error",
+			Name:   e.Name,
+			Inputs: argumentsJSON(e.Inputs, false),
+		})
+	}
+	if fields == nil {
+		fields = []fieldJSON{}
+	}
+	return json.Marshal(fields)
+}
+
+// argumentsJSON converts a list of arguments into their JSON representation,
+// including the indexed flag for event arguments.
+func argumentsJSON(args Arguments, event bool) *[]argumentJSON {
+	out := make([]argumentJSON, len(args))
+	for i, arg := range args {
+		out[i] = typeJSON(arg.Type)
+		out[i].Name = arg.Name
+		if event {
+			out[i].Indexed = &arg.Indexed
+		}
+	}
+	return &out
+}
+
+// typeJSON converts a type into its JSON representation, without a name.
+func typeJSON(t Type) argumentJSON {
+	switch t.T {
+	case SliceTy, ArrayTy:
+		suffix := "[]"
+		if t.T == ArrayTy {
+			suffix = fmt.Sprintf("[%d]", t.Size)
+		}
+		elem := typeJSON(*t.Elem)
+		elem.Type += suffix
+		if elem.InternalType != "" {
+			elem.InternalType += suffix
+		}
+		return elem
+	case TupleTy:
+		arg := argumentJSON{Type: "tuple", Components: make([]argumentJSON, len(t.TupleElems))}
+		for i, elem := range t.TupleElems {
+			arg.Components[i] = typeJSON(*elem)
+			arg.Components[i].Name = t.TupleRawNames[i]
+		}
+		arg.InternalType = t.TupleInternalType
+		return arg
+	default:
+		return argumentJSON{Type: t.String(), InternalType: t.UserDefinedName}
+	}
+}
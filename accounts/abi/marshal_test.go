@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+	const definition = `[
+		{"type":"error","name":"Unauthorized","inputs":[{"name":"caller","type":"address"}]},
+		{"type":"function","name":"transfer","constant":false,"payable":false,"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+		{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},
+		{"type":"function","name":"balanceOf","constant":true,"inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256","internalType":"Amount"}]},
+		{"type":"function","name":"transfer","stateMutability":"payable","inputs":[{"name":"to","type":"address"}],"outputs":[]},
+		{"type":"function","name":"submit","stateMutability":"nonpayable","inputs":[{"name":"orders","type":"tuple[2][]","internalType":"struct Lib.Order[2][]","components":[{"name":"id","type":"uint64"},{"name":"tags","type":"bytes32[]"}]}],"outputs":[]},
+		{"type":"receive","stateMutability":"payable"},
+		{"type":"constructor","stateMutability":"nonpayable","inputs":[{"name":"owner","type":"address"}]}
+	]`
+	const want = `[` +
+		`{"inputs":[{"name":"owner","type":"address"}],"stateMutability":"nonpayable","type":"constructor"},` +
+		`{"stateMutability":"payable","type":"receive"},` +
+		`{"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"Amount","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},` +
+		`{"inputs":[{"components":[{"name":"id","type":"uint64"},{"name":"tags","type":"bytes32[]"}],"internalType":"struct Lib.Order[2][]","name":"orders","type":"tuple[2][]"}],"name":"submit","outputs":[],"stateMutability":"nonpayable","type":"function"},` +
+		`{"inputs":[{"name":"to","type":"address"}],"name":"transfer","outputs":[],"stateMutability":"payable","type":"function"},` +
+		`{"inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},` +
+		`{"anonymous":false,"inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":false,"name":"value","type":"uint256"}],"name":"Transfer","type":"event"},` +
+		`{"inputs":[{"name":"caller","type":"address"}],"name":"Unauthorized","type":"error"}` +
+		`]`
+
+	abi, err := JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := json.Marshal(abi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(have) != want {
+		t.Fatalf("canonical JSON mismatch:\nhave %s\nwant %s", have, want)
+	}
+	// Re-parsing the output must yield the same ABI and encoding
+	reparsed, err := JSON(bytes.NewReader(have))
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := json.Marshal(reparsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, again) {
+		t.Errorf("re-serialization mismatch:\nhave %s\nwant %s", again, have)
+	}
+	for name, method := range abi.Methods {
+		if m, err := reparsed.MethodById(method.ID); err != nil || m.Sig != method.Sig {
+			t.Errorf("method %s: not found after re-parsing", name)
+		}
+	}
+	// An empty ABI serializes to an empty list
+	if empty, _ := json.Marshal(ABI{}); string(empty) != "[]" {
+		t.Errorf("empty ABI mismatch: have %s", empty)
+	}
+}
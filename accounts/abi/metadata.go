@@ -0,0 +1,176 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrNoBytecodeMetadata is returned if the bytecode doesn't end with compiler
+// metadata.
+var ErrNoBytecodeMetadata = errors.New("no metadata in bytecode")
+
+// BytecodeMetadata is the CBOR encoded metadata the Solidity compiler appends to
+// the runtime code of contracts, followed by its length as a 2 byte big endian
+// integer. See https://docs.soliditylang.org/en/latest/metadata.html.
+type BytecodeMetadata struct {
+	IPFS         []byte // Multihash of the metadata file on IPFS, if any
+	Bzzr         []byte // Swarm hash of the metadata file (bzzr0 or bzzr1), if any
+	Solc         string // Version of the compiler, if included
+	Experimental bool   // Whether experimental features of the compiler are used
+	Size         int    // Size of the metadata in the bytecode, including its length
+}
+
+// Hash returns the hash of the metadata file, preferring IPFS over Swarm.
+func (m *BytecodeMetadata) Hash() []byte {
+	if m.IPFS != nil {
+		return m.IPFS
+	}
+	return m.Bzzr
+}
+
+// ParseBytecodeMetadata decodes the compiler metadata at the end of the given
+// runtime code.
+func ParseBytecodeMetadata(code []byte) (*BytecodeMetadata, error) {
+	if len(code) < 2 {
+		return nil, ErrNoBytecodeMetadata
+	}
+	size := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if size == 0 || size+2 > len(code) {
+		return nil, ErrNoBytecodeMetadata
+	}
+	dec := &cborDecoder{data: code[len(code)-2-size : len(code)-2]}
+
+	// The metadata is a single map of string keys
+	major, entries, err := dec.head()
+	if err != nil || major != cborMap {
+		return nil, ErrNoBytecodeMetadata
+	}
+	meta := &BytecodeMetadata{Size: size + 2}
+	for range entries {
+		major, n, err := dec.head()
+		if err != nil || major != cborText {
+			return nil, ErrNoBytecodeMetadata
+		}
+		key, err := dec.bytes(n)
+		if err != nil {
+			return nil, ErrNoBytecodeMetadata
+		}
+		major, n, err = dec.head()
+		if err != nil {
+			return nil, ErrNoBytecodeMetadata
+		}
+		var value []byte
+		if major == cborBytes || major == cborText {
+			if value, err = dec.bytes(n); err != nil {
+				return nil, ErrNoBytecodeMetadata
+			}
+		}
+		switch string(key) {
+		case "ipfs":
+			meta.IPFS = value
+		case "bzzr0", "bzzr1":
+			meta.Bzzr = value
+		case "solc":
+			// Releases are encoded as the version numbers, prereleases as text
+			if major == cborBytes && len(value) == 3 {
+				meta.Solc = fmt.Sprintf("%d.%d.%d", value[0], value[1], value[2])
+			} else {
+				meta.Solc = string(value)
+			}
+		case "experimental":
+			meta.Experimental = major == cborSimple && n == cborTrue
+		}
+	}
+	if len(dec.data) != 0 {
+		return nil, ErrNoBytecodeMetadata
+	}
+	return meta, nil
+}
+
+// StripBytecodeMetadata returns the runtime code without the compiler metadata
+// at its end, or the code itself if it has none.
+func StripBytecodeMetadata(code []byte) []byte {
+	meta, err := ParseBytecodeMetadata(code)
+	if err != nil {
+		return code
+	}
+	return code[:len(code)-meta.Size]
+}
+
+// CBOR major types and simple values used by the compiler metadata.
+const (
+	cborBytes  = 2
+	cborText   = 3
+	cborMap    = 5
+	cborSimple = 7
+
+	cborTrue = 21
+)
+
+// cborDecoder is a minimal decoder for the subset of CBOR used by the compiler
+// metadata.
+type cborDecoder struct {
+	data []byte
+}
+
+// head decodes the header of the next data item, returning its major type and
+// its argument, which is the length for strings and maps.
+func (d *cborDecoder) head() (byte, uint64, error) {
+	if len(d.data) == 0 {
+		return 0, 0, errors.New("unexpected end of data")
+	}
+	major, info := d.data[0]>>5, d.data[0]&0x1f
+	d.data = d.data[1:]
+
+	var size int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, fmt.Errorf("unsupported additional info %d", info)
+	}
+	if len(d.data) < size {
+		return 0, 0, errors.New("unexpected end of data")
+	}
+	var arg uint64
+	for _, b := range d.data[:size] {
+		arg = arg<<8 | uint64(b)
+	}
+	d.data = d.data[size:]
+	return major, arg, nil
+}
+
+// bytes consumes the given number of bytes.
+func (d *cborDecoder) bytes(n uint64) ([]byte, error) {
+	if uint64(len(d.data)) < n {
+		return nil, errors.New("unexpected end of data")
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b, nil
+}
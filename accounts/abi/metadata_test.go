@@ -0,0 +1,75 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseBytecodeMetadata(t *testing.T) {
+	t.Parallel()
+
+	var (
+		code  = common.FromHex("0x6080604052600080fd")
+		hash  = common.FromHex("0x1220" + "5b1e6e5b0e5e5f9bcd2b8f3d3b0c1e5ee83e1ef0d4b2a3c4d5e6f708192a3b4c")
+		swarm = common.FromHex("0x5b1e6e5b0e5e5f9bcd2b8f3d3b0c1e5ee83e1ef0d4b2a3c4d5e6f708192a3b4c")
+	)
+	tests := []struct {
+		metadata string
+		want     *BytecodeMetadata
+	}{
+		// Solidity >= 0.6.2: {"ipfs": <hash>, "solc": <version>}
+		{
+			metadata: "a2646970667358221220" + "5b1e6e5b0e5e5f9bcd2b8f3d3b0c1e5ee83e1ef0d4b2a3c4d5e6f708192a3b4c" + "64736f6c634300081a0033",
+			want:     &BytecodeMetadata{IPFS: hash, Solc: "0.8.26", Size: 53},
+		},
+		// Solidity < 0.5.9: {"bzzr0": <hash>}
+		{
+			metadata: "a165627a7a72305820" + "5b1e6e5b0e5e5f9bcd2b8f3d3b0c1e5ee83e1ef0d4b2a3c4d5e6f708192a3b4c" + "0029",
+			want:     &BytecodeMetadata{Bzzr: swarm, Size: 43},
+		},
+		// Prerelease compiler with experimental features
+		{
+			metadata: "a264736f6c636d302e382e302d6e696768746c796c6578706572696d656e74616cf5" + "0022",
+			want:     &BytecodeMetadata{Solc: "0.8.0-nightly", Experimental: true, Size: 36},
+		},
+	}
+	for i, tt := range tests {
+		full := append(code, common.FromHex(tt.metadata)...)
+		meta, err := ParseBytecodeMetadata(full)
+		if err != nil {
+			t.Fatalf("test %d: failed to parse metadata: %v", i, err)
+		}
+		if !bytes.Equal(meta.IPFS, tt.want.IPFS) || !bytes.Equal(meta.Bzzr, tt.want.Bzzr) || meta.Solc != tt.want.Solc || meta.Experimental != tt.want.Experimental || meta.Size != tt.want.Size {
+			t.Fatalf("test %d: metadata mismatch: have %+v, want %+v", i, meta, tt.want)
+		}
+		if stripped := StripBytecodeMetadata(full); !bytes.Equal(stripped, code) {
+			t.Fatalf("test %d: stripped code mismatch: have %x, want %x", i, stripped, code)
+		}
+	}
+	// Code without metadata is left alone
+	if _, err := ParseBytecodeMetadata(code); !errors.Is(err, ErrNoBytecodeMetadata) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrNoBytecodeMetadata)
+	}
+	if stripped := StripBytecodeMetadata(code); !bytes.Equal(stripped, code) {
+		t.Fatalf("code without metadata modified: have %x, want %x", stripped, code)
+	}
+}
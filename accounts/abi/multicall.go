@@ -0,0 +1,101 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Multicall3Address is the address the Multicall3 contract is deployed at on
+// most EVM chains.
+var Multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// multicall3 is the ABI of the Multicall3 aggregate3 method.
+var multicall3 = func() ABI {
+	abi, err := ParseHumanReadable([]string{
+		"function aggregate3((address target, bool allowFailure, bytes callData)[] calls) payable returns ((bool success, bytes returnData)[] returnData)",
+	})
+	if err != nil {
+		panic(err)
+	}
+	return abi
+}()
+
+// MulticallCall is a single contract call to be batched via Multicall3.
+type MulticallCall struct {
+	Target       common.Address // Contract to call
+	AllowFailure bool           // Whether the batch may succeed if this call reverts
+	ABI          ABI            // ABI of the target contract
+	Method       string         // Method to call
+	Args         []interface{}  // Arguments of the method
+}
+
+// MulticallResult is the outcome of a single call within a Multicall3 batch.
+type MulticallResult struct {
+	Success    bool          // Whether the call succeeded
+	ReturnData []byte        // Raw return or revert data
+	Values     []interface{} // Unpacked return values, if the call succeeded
+	Err        error         // Revert or unpacking error, if any
+}
+
+// PackMulticall packs the calls into the input of Multicall3's aggregate3 method.
+func PackMulticall(calls []MulticallCall) ([]byte, error) {
+	type call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+	batch := make([]call3, len(calls))
+	for i, call := range calls {
+		data, err := call.ABI.Pack(call.Method, call.Args...)
+		if err != nil {
+			return nil, fmt.Errorf("abi: multicall %d (%s): %v", i, call.Method, err)
+		}
+		batch[i] = call3{Target: call.Target, AllowFailure: call.AllowFailure, CallData: data}
+	}
+	return multicall3.Pack("aggregate3", batch)
+}
+
+// UnpackMulticall unpacks the output of Multicall3's aggregate3 method into the
+// results of the individual calls, which must be the ones passed to PackMulticall.
+// The return values of successful calls are unpacked according to their ABIs,
+// while the reverts of failed calls are reported as *RevertError errors.
+func UnpackMulticall(calls []MulticallCall, data []byte) ([]MulticallResult, error) {
+	var batch []struct {
+		Success    bool
+		ReturnData []byte
+	}
+	if err := multicall3.UnpackIntoInterface(&batch, "aggregate3", data); err != nil {
+		return nil, err
+	}
+	if len(batch) != len(calls) {
+		return nil, fmt.Errorf("abi: multicall result count mismatch: got %d for %d calls", len(batch), len(calls))
+	}
+	results := make([]MulticallResult, len(calls))
+	for i, call := range calls {
+		res := MulticallResult{Success: batch[i].Success, ReturnData: batch[i].ReturnData}
+		if res.Success {
+			res.Values, res.Err = call.ABI.Unpack(call.Method, res.ReturnData)
+		} else {
+			res.Err = call.ABI.DecodeRevert(res.ReturnData)
+		}
+		results[i] = res
+	}
+	return results, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestMulticall(t *testing.T) {
+	t.Parallel()
+
+	token, err := ParseHumanReadable([]string{
+		"function balanceOf(address owner) view returns (uint256)",
+		"function symbol() view returns (string)",
+		"error Unauthorized(address caller)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		addr  = common.HexToAddress("0x1111")
+		owner = common.HexToAddress("0x2222")
+		calls = []MulticallCall{
+			{Target: addr, ABI: token, Method: "balanceOf", Args: []interface{}{owner}},
+			{Target: addr, ABI: token, Method: "symbol", AllowFailure: true},
+			{Target: addr, ABI: token, Method: "symbol", AllowFailure: true},
+		}
+	)
+	input, err := PackMulticall(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(input[:4], common.FromHex("0x82ad56cb")) {
+		t.Fatalf("wrong aggregate3 selector: %x", input[:4])
+	}
+	// Decode the input to check the individual call data
+	args, err := multicall3.Methods["aggregate3"].Inputs.Unpack(input[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	balanceOf, _ := token.Pack("balanceOf", owner)
+	if have := args[0].([]struct {
+		Target       common.Address `json:"target"`
+		AllowFailure bool           `json:"allowFailure"`
+		CallData     []byte         `json:"callData"`
+	}); len(have) != 3 || have[0].Target != addr || have[0].AllowFailure || !have[2].AllowFailure || !bytes.Equal(have[0].CallData, balanceOf) {
+		t.Fatalf("wrong packed calls: %+v", have)
+	}
+	// Assemble a response with a success, a revert and a custom error
+	balance, _ := token.Methods["balanceOf"].Outputs.Pack(big.NewInt(42))
+	reason := append(common.FromHex("0x08c379a0"), mustPackString(t, "paused")...)
+	custom := append(token.Errors["Unauthorized"].ID.Bytes()[:4], common.LeftPadBytes(owner.Bytes(), 32)...)
+
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+	output, err := multicall3.Methods["aggregate3"].Outputs.Pack([]result{{true, balance}, {false, reason}, {false, custom}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := UnpackMulticall(calls, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[0].Success || results[0].Err != nil || results[0].Values[0].(*big.Int).Int64() != 42 {
+		t.Errorf("wrong successful result: %+v", results[0])
+	}
+	if results[1].Success || results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "paused") {
+		t.Errorf("wrong reverted result: %+v", results[1])
+	}
+	if results[2].Success || results[2].Err == nil || !strings.Contains(results[2].Err.Error(), "Unauthorized") {
+		t.Errorf("wrong custom error result: %+v", results[2])
+	}
+	var rerr *RevertError
+	if !errors.As(results[2].Err, &rerr) || rerr.Name != "Unauthorized" || rerr.Args[0] != owner {
+		t.Errorf("wrong custom error: %v", results[2].Err)
+	}
+	// Mismatching call lists must be rejected
+	if _, err := UnpackMulticall(calls[:2], output); err == nil {
+		t.Error("expected result count mismatch")
+	}
+}
+
+func mustPackString(t *testing.T, s string) []byte {
+	t.Helper()
+	typ, _ := NewType("string", "", nil)
+	packed, err := (Arguments{{Type: typ}}).Pack(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return packed
+}
@@ -17,14 +17,18 @@
 package abi
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	gomath "math"
 	"math/big"
 	"reflect"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/holiman/uint256"
 )
 
 // packBytesSlice packs the given bytes as [L, V] as the canonical representation
@@ -39,19 +43,29 @@ func packBytesSlice(bytes []byte, l int) ([]byte, error) {
 
 // packElement packs the given reflect value according to the abi specification in
 // t.
-func packElement(t Type, reflectValue reflect.Value) ([]byte, error) {
+func packElement(t Type, reflectValue reflect.Value, opts Options) ([]byte, error) {
 	switch t.T {
-	case UintTy:
+	case UintTy, IntTy:
+		val, err := toBigIntValue(reflectValue)
+		if err != nil {
+			return nil, err
+		}
 		// make sure to not pack a negative value into a uint type.
-		if reflectValue.Kind() == reflect.Ptr {
-			val := new(big.Int).Set(reflectValue.Interface().(*big.Int))
-			if val.Sign() == -1 {
-				return nil, errInvalidSign
+		if t.T == UintTy && reflectValue.Kind() == reflect.Ptr && val.Sign() == -1 {
+			return nil, errInvalidSign
+		}
+		if opts.StrictIntegers {
+			if err := checkIntegerRange(t, val); err != nil {
+				return nil, err
 			}
 		}
-		return packNum(reflectValue)
-	case IntTy:
-		return packNum(reflectValue)
+		return math.U256Bytes(val), nil
+	case FixedPointTy:
+		val, err := packFixedPoint(t, reflectValue)
+		if err != nil {
+			return nil, err
+		}
+		return math.U256Bytes(val), nil
 	case StringTy:
 		v, ok := reflectValue.Interface().(string)
 		if !ok {
@@ -59,14 +73,12 @@ func packElement(t Type, reflectValue reflect.Value) ([]byte, error) {
 		}
 		return packBytesSlice([]byte(v), len(v))
 	case AddressTy:
-		addr, isStr := reflectValue.Interface().(string)
-		if isStr {
-			reflectValue = reflect.ValueOf(common.HexToAddress(addr))
-			if addr != "0x0000000000000000000000000000000000000000" {
-				if strings.ToLower(reflectValue.Interface().(common.Address).Hex()) != strings.ToLower(addr) {
-					return []byte{}, fmt.Errorf("Could not pack element, invalid address: %v", addr)
-				}
+		if str, isStr := reflectValue.Interface().(string); isStr {
+			addr, err := parseAddress(str, opts.Addresses)
+			if err != nil {
+				return []byte{}, err
 			}
+			reflectValue = reflect.ValueOf(addr)
 		}
 
 		if reflectValue.Kind() == reflect.Array {
@@ -97,11 +109,24 @@ func packElement(t Type, reflectValue reflect.Value) ([]byte, error) {
 			if !ok {
 				return []byte{}, errors.New("bytes type is neither slice nor array")
 			}
-			val := common.Hex2Bytes(str)
+			val, err := parseHexBytes(str)
+			if err != nil {
+				return []byte{}, err
+			}
 			return packBytesSlice(val, len(val))
 		}
 		return packBytesSlice(reflectValue.Bytes(), reflectValue.Len())
 	case FixedBytesTy, FunctionTy:
+		if str, ok := reflectValue.Interface().(string); ok {
+			val, err := parseHexBytes(str)
+			if err != nil {
+				return []byte{}, err
+			}
+			if len(val) != t.Size {
+				return []byte{}, fmt.Errorf("abi: invalid length %d for %v, want %d bytes", len(val), t, t.Size)
+			}
+			return common.RightPadBytes(val, 32), nil
+		}
 		if reflectValue.Kind() == reflect.Array {
 			reflectValue = mustArrayToByteSlice(reflectValue)
 		}
@@ -113,48 +138,144 @@ func packElement(t Type, reflectValue reflect.Value) ([]byte, error) {
 
 // packNum packs the given number (using the reflect value) and will cast it to appropriate number representation.
 func packNum(value reflect.Value) ([]byte, error) {
+	bn, err := toBigIntValue(value)
+	if err != nil {
+		return []byte{}, err
+	}
+	return math.U256Bytes(bn), nil
+}
+
+// toBigIntValue converts the given number (using the reflect value) into a newly
+// allocated big integer. The accepted Go types are:
+//
+//   - signed and unsigned integers of any size, including named integer types
+//   - *big.Int, big.Int, *uint256.Int and uint256.Int
+//   - float32 and float64, if they hold a finite integral value
+//   - strings in base 10 or 0x-prefixed hex, optionally negative
+//   - json.Number, additionally allowing exponents (e.g. 1e18) as long as the
+//     value is integral
+//
+// Values with a fractional part are rejected rather than truncated.
+func toBigIntValue(value reflect.Value) (*big.Int, error) {
+	switch v := value.Interface().(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, errors.New("Could not pack number in packNum, nil *big.Int")
+		}
+		return new(big.Int).Set(v), nil
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case *uint256.Int:
+		if v == nil {
+			return nil, errors.New("Could not pack number in packNum, nil *uint256.Int")
+		}
+		return v.ToBig(), nil
+	case uint256.Int:
+		return v.ToBig(), nil
+	case json.Number:
+		if bn, ok := parseBigInt(string(v)); ok {
+			return bn, nil
+		}
+		// Fall back to parsing decimals and exponents, requiring an integer
+		r, ok := new(big.Rat).SetString(string(v))
+		if !ok || !r.IsInt() {
+			return nil, fmt.Errorf("Could not pack number in packNum, invalid json.Number: %v", v)
+		}
+		return new(big.Int).Set(r.Num()), nil
+	}
 	switch kind := value.Kind(); kind {
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return math.U256Bytes(new(big.Int).SetUint64(value.Uint())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(value.Uint()), nil
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return math.U256Bytes(big.NewInt(value.Int())), nil
-	case reflect.Ptr:
-		return math.U256Bytes(new(big.Int).Set(value.Interface().(*big.Int))), nil
-	case reflect.Float64:
-		bigFloat := new(big.Float).SetFloat64(value.Float())
-		bigInt := new(big.Int)
-		bigFloat.Int(bigInt)
-		return math.U256Bytes(bigInt), nil
+		return big.NewInt(value.Int()), nil
+	case reflect.Float32, reflect.Float64:
+		f := value.Float()
+		if gomath.IsInf(f, 0) || gomath.IsNaN(f) || f != gomath.Trunc(f) {
+			return nil, fmt.Errorf("Could not pack number in packNum, inexact float: %v", f)
+		}
+		bn, _ := new(big.Float).SetFloat64(f).Int(nil)
+		return bn, nil
 	case reflect.String:
-		bn, ok := new(big.Int).SetString(value.Interface().(string), 10)
+		bn, ok := parseBigInt(value.String())
 		if !ok {
-			return []byte{}, fmt.Errorf("Could not pack number in packNum, invalid string: %v", value.Interface().(string))
+			return nil, fmt.Errorf("Could not pack number in packNum, invalid string: %v", value.String())
 		}
-		return math.U256Bytes(bn), nil
+		return bn, nil
 	default:
-		if v, ok := value.Interface().(*big.Int); ok {
-			return math.U256Bytes(v), nil
+		return nil, fmt.Errorf("Could not pack number in packNum, invalid type: %v", value.Type())
+	}
+}
+
+// checkIntegerRange verifies that the value is representable by the integer
+// type t, i.e. it fits into the declared bit size and is not negative if the
+// type is unsigned.
+func checkIntegerRange(t Type, value *big.Int) error {
+	if t.T == UintTy {
+		if value.Sign() < 0 {
+			return errInvalidSign
 		}
-		bn, err := toBigInt(value.Interface())
-		if err != nil {
-			return []byte{}, fmt.Errorf("Could not pack number in packNum, invalid type: %v, %v", kind, err)
+		if value.BitLen() > t.Size {
+			return fmt.Errorf("abi: value %v overflows %v", value, t)
 		}
-		return math.U256Bytes(bn), nil
+		return nil
+	}
+	// Signed values must lie within [-2^(size-1), 2^(size-1)-1]
+	limit := new(big.Int).Lsh(common.Big1, uint(t.Size-1))
+	if value.Cmp(limit) >= 0 || value.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return fmt.Errorf("abi: value %v overflows %v", value, t)
+	}
+	return nil
+}
+
+// parseAddress converts a hex string into an address, validating it according
+// to the given policy.
+func parseAddress(s string, policy AddressPolicy) (common.Address, error) {
+	if policy == AddressNoStrings {
+		return common.Address{}, fmt.Errorf("Could not pack element, string address not allowed: %v", s)
+	}
+	if len(s) != 2*common.AddressLength+2 || !common.IsHexAddress(s) {
+		return common.Address{}, fmt.Errorf("Could not pack element, invalid address: %v", s)
 	}
+	addr := common.HexToAddress(s)
+	if policy == AddressChecksum && s[2:] != addr.Hex()[2:] {
+		return common.Address{}, fmt.Errorf("Could not pack element, invalid address checksum: %v", s)
+	}
+	return addr, nil
+}
+
+// parseBigInt parses a base-10 or 0x-prefixed hexadecimal integer string, with
+// an optional leading minus sign.
+func parseBigInt(s string) (*big.Int, bool) {
+	digits, neg := strings.CutPrefix(s, "-")
+	base := 10
+	if hex, ok := strings.CutPrefix(digits, "0x"); ok {
+		digits, base = hex, 16
+	} else if hex, ok := strings.CutPrefix(digits, "0X"); ok {
+		digits, base = hex, 16
+	}
+	// SetString would accept further signs and underscores, reject them.
+	if digits == "" || strings.ContainsAny(digits, "+-_") {
+		return nil, false
+	}
+	bn, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, false
+	}
+	if neg {
+		bn.Neg(bn)
+	}
+	return bn, true
 }
 
-func toBigInt(value any) (*big.Int, error) {
-	switch v := value.(type) {
-	case int:
-		return big.NewInt(int64(v)), nil
-	case int64:
-		return big.NewInt(v), nil
-	case string:
-		bigIntValue := new(big.Int)
-		_, ok := bigIntValue.SetString(v, 10)
-		if ok {
-			return bigIntValue, nil
-		}
-	}
-	return nil, fmt.Errorf("Cannot convert to *big.Int")
+// parseHexBytes decodes a hex string with an optional 0x prefix.
+func parseHexBytes(s string) ([]byte, error) {
+	digits := s
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		digits = s[2:]
+	}
+	b, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("abi: invalid hex string %q: %v", s, err)
+	}
+	return b, nil
 }
@@ -0,0 +1,229 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PackFromJSON packs a call to the named method (or the constructor if the name
+// is empty) with the arguments given as JSON. The arguments may be supplied as a
+// JSON array in declaration order, or as a JSON object keyed by argument name.
+//
+// Argument values are converted according to their ABI types:
+//   - integers as JSON numbers, decimal strings or 0x-prefixed hex strings
+//   - addresses, bytes, bytesN and function values as 0x-prefixed hex strings
+//   - booleans as JSON booleans and strings as JSON strings
+//   - arrays as JSON arrays, and tuples as JSON objects or arrays
+func (abi ABI) PackFromJSON(name string, raw json.RawMessage) ([]byte, error) {
+	inputs := abi.Constructor.Inputs
+	if name != "" {
+		method, ok := abi.Methods[name]
+		if !ok {
+			return nil, fmt.Errorf("method '%s' not found", name)
+		}
+		inputs = method.Inputs
+	}
+	args, err := inputs.valuesFromJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack(name, args...)
+}
+
+// valuesFromJSON converts a JSON array or object into the Go values of the
+// arguments.
+func (arguments Arguments) valuesFromJSON(raw json.RawMessage) ([]any, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		raw = json.RawMessage("[]")
+	}
+	names := make([]string, len(arguments))
+	for i, arg := range arguments {
+		names[i] = arg.Name
+	}
+	fields, err := jsonFields(raw, names)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(arguments))
+	for i, field := range fields {
+		value, err := jsonToValue(arguments[i].Type, field)
+		if err != nil {
+			return nil, fmt.Errorf("abi: argument %s: %v", argName(names[i], i), err)
+		}
+		values[i] = value.Interface()
+	}
+	return values, nil
+}
+
+// jsonFields splits a JSON array or object into the raw values of the given
+// named fields, in declaration order.
+func jsonFields(raw json.RawMessage, names []string) ([]json.RawMessage, error) {
+	switch {
+	case len(raw) > 0 && raw[0] == '[':
+		var fields []json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, err
+		}
+		if len(fields) != len(names) {
+			return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(fields), len(names))
+		}
+		return fields, nil
+	case len(raw) > 0 && raw[0] == '{':
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, err
+		}
+		fields := make([]json.RawMessage, len(names))
+		for i, name := range names {
+			field, ok := values[name]
+			if !ok {
+				return nil, fmt.Errorf("missing value for %s", argName(name, i))
+			}
+			fields[i] = field
+			delete(values, name)
+		}
+		for name := range values {
+			return nil, fmt.Errorf("unknown argument %q", name)
+		}
+		return fields, nil
+	default:
+		return nil, errors.New("expected JSON array or object")
+	}
+}
+
+// argName returns a printable name for a (possibly unnamed) argument.
+func argName(name string, index int) string {
+	if name == "" {
+		return fmt.Sprintf("#%d", index)
+	}
+	return fmt.Sprintf("'%s'", name)
+}
+
+// jsonToValue converts a JSON value into the Go representation of the ABI type.
+func jsonToValue(t Type, raw json.RawMessage) (reflect.Value, error) {
+	value := reflect.New(t.GetType()).Elem()
+	switch t.T {
+	case IntTy, UintTy:
+		num, err := jsonToBigInt(raw)
+		if err != nil {
+			return value, err
+		}
+		if err := checkIntegerRange(t, num); err != nil {
+			return value, err
+		}
+		switch value.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value.SetUint(num.Uint64())
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value.SetInt(num.Int64())
+		default:
+			value.Set(reflect.ValueOf(num))
+		}
+	case BoolTy:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return value, err
+		}
+		value.SetBool(b)
+	case StringTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return value, err
+		}
+		value.SetString(s)
+	case AddressTy:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return value, err
+		}
+		if !common.IsHexAddress(s) {
+			return value, fmt.Errorf("invalid address %q", s)
+		}
+		value.Set(reflect.ValueOf(common.HexToAddress(s)))
+	case BytesTy, FixedBytesTy, FunctionTy:
+		var b hexutil.Bytes
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return value, err
+		}
+		if t.T == BytesTy {
+			value.SetBytes(b)
+			break
+		}
+		if len(b) != value.Len() {
+			return value, fmt.Errorf("invalid length %d for %v", len(b), t)
+		}
+		reflect.Copy(value, reflect.ValueOf([]byte(b)))
+	case SliceTy, ArrayTy:
+		var elems []json.RawMessage
+		if err := json.Unmarshal(raw, &elems); err != nil {
+			return value, err
+		}
+		if t.T == ArrayTy && len(elems) != t.Size {
+			return value, fmt.Errorf("array length mismatch: got %d for %d", len(elems), t.Size)
+		}
+		if t.T == SliceTy {
+			value.Set(reflect.MakeSlice(value.Type(), len(elems), len(elems)))
+		}
+		for i, elem := range elems {
+			v, err := jsonToValue(*t.Elem, elem)
+			if err != nil {
+				return value, fmt.Errorf("element %d: %v", i, err)
+			}
+			value.Index(i).Set(v)
+		}
+	case TupleTy:
+		fields, err := jsonFields(bytes.TrimSpace(raw), t.TupleRawNames)
+		if err != nil {
+			return value, err
+		}
+		for i, field := range fields {
+			v, err := jsonToValue(*t.TupleElems[i], field)
+			if err != nil {
+				return value, fmt.Errorf("field %s: %v", argName(t.TupleRawNames[i], i), err)
+			}
+			value.Field(i).Set(v)
+		}
+	default:
+		return value, fmt.Errorf("unsupported type %v", t)
+	}
+	return value, nil
+}
+
+// jsonToBigInt parses a JSON number, decimal string or hex string.
+func jsonToBigInt(raw json.RawMessage) (*big.Int, error) {
+	text := string(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return nil, err
+		}
+	}
+	num, ok := parseBigInt(text)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %s", raw)
+	}
+	return num, nil
+}
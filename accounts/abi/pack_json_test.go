@@ -0,0 +1,105 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestPackFromJSON(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"function transfer(address to, uint256 amount)",
+		"function small(int8 a, uint32 b, bool c)",
+		"function data(bytes payload, bytes4 sig, string note)",
+		"function order((address maker, uint256[] amounts, (bytes32 salt) extra) o)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+	tests := []struct {
+		method string
+		json   string
+		args   []any
+	}{
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", "1000"]`, []any{to, big.NewInt(1000)}},
+		{"transfer", `{"amount": "0x3e8", "to": "0x00000000000000000000000000000000deadbeef"}`, []any{to, big.NewInt(1000)}},
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", 1000]`, []any{to, big.NewInt(1000)}},
+		{"small", `[-5, "4294967295", true]`, []any{int8(-5), uint32(4294967295), true}},
+		{"data", `["0x0102", "0xcafebabe", "hi"]`, []any{[]byte{1, 2}, [4]byte{0xca, 0xfe, 0xba, 0xbe}, "hi"}},
+		{
+			"order",
+			`[{"maker": "0x00000000000000000000000000000000deadbeef", "amounts": ["1", 2], "extra": ["0x0000000000000000000000000000000000000000000000000000000000000001"]}]`,
+			[]any{struct {
+				Maker   common.Address
+				Amounts []*big.Int
+				Extra   struct{ Salt [32]byte }
+			}{to, []*big.Int{big.NewInt(1), big.NewInt(2)}, struct{ Salt [32]byte }{[32]byte{31: 1}}}},
+		},
+	}
+	for i, tt := range tests {
+		have, err := abi.PackFromJSON(tt.method, json.RawMessage(tt.json))
+		if err != nil {
+			t.Errorf("test %d: pack failed: %v", i, err)
+			continue
+		}
+		want, err := abi.Pack(tt.method, tt.args...)
+		if err != nil {
+			t.Fatalf("test %d: reference pack failed: %v", i, err)
+		}
+		if !bytes.Equal(have, want) {
+			t.Errorf("test %d: pack mismatch: have %x, want %x", i, have, want)
+		}
+	}
+}
+
+func TestPackFromJSONErrors(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"function transfer(address to, uint8 amount)",
+		"function data(bytes4 sig)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tt := range []struct {
+		method, json string
+	}{
+		{"transfer", `["0x00000000000000000000000000000000deadbeef"]`},
+		{"transfer", `{"to": "0x00000000000000000000000000000000deadbeef"}`},
+		{"transfer", `{"to": "0x00000000000000000000000000000000deadbeef", "amount": 1, "extra": 2}`},
+		{"transfer", `["0xdeadbeef", 1]`},
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", 256]`},
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", -1]`},
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", 1.5]`},
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", "+1"]`},
+		{"transfer", `["0x00000000000000000000000000000000deadbeef", "0x"]`},
+		{"data", `["0xcafe"]`},
+		{"data", `"0xcafebabe"`},
+		{"missing", `[]`},
+	} {
+		if _, err := abi.PackFromJSON(tt.method, json.RawMessage(tt.json)); err == nil {
+			t.Errorf("test %d: expected error packing %s", i, tt.json)
+		}
+	}
+}
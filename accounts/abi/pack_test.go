@@ -19,6 +19,7 @@ package abi
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
@@ -28,6 +29,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
 )
 
 // TestPack tests the general pack/unpack tests in packing_test.go
@@ -218,3 +220,307 @@ func TestPackNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestPackTaggedStructs(t *testing.T) {
+	t.Parallel()
+	const def = `[{"name":"f","type":"function",
+		"inputs":[
+			{"name":"s","type":"tuple","components":[
+				{"name":"amount","type":"uint256"},
+				{"name":"items","type":"tuple[]","components":[{"name":"label","type":"string"},{"name":"data","type":"uint8[]"}]}
+			]},
+			{"name":"owner","type":"address"}
+		],
+		"outputs":[
+			{"name":"s","type":"tuple","components":[
+				{"name":"amount","type":"uint256"},
+				{"name":"items","type":"tuple[]","components":[{"name":"label","type":"string"},{"name":"data","type":"uint8[]"}]}
+			]}
+		]}]`
+	abi, err := JSON(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Field order deliberately differs from the ABI, tags drive the mapping.
+	type item struct {
+		Values []uint8 `abi:"data"`
+		Name   string  `abi:"label"`
+	}
+	type tuple struct {
+		Entries []item   `abi:"items"`
+		Value   *big.Int `abi:"amount"`
+	}
+	type call struct {
+		Recipient common.Address `abi:"owner"`
+		Payload   tuple          `abi:"s"`
+	}
+	in := call{
+		Recipient: common.Address{0x01},
+		Payload: tuple{
+			Entries: []item{{Values: []uint8{1, 2}, Name: "a"}, {Values: nil, Name: "bc"}},
+			Value:   big.NewInt(42),
+		},
+	}
+	// Packing the struct must be identical to packing the arguments positionally.
+	packed, err := abi.Methods["f"].Inputs.Pack(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := abi.Methods["f"].Inputs.Pack(in.Payload, in.Recipient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(packed, want) {
+		t.Fatalf("struct packing mismatch: have %x, want %x", packed, want)
+	}
+	// Unpacking the single tuple output must restore the tagged struct.
+	enc, err := abi.Methods["f"].Outputs.Pack(in.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out tuple
+	if err := abi.UnpackIntoInterface(&out, "f", enc); err != nil {
+		t.Fatal(err)
+	}
+	if out.Value.Cmp(in.Payload.Value) != 0 {
+		t.Errorf("amount mismatch: have %v, want %v", out.Value, in.Payload.Value)
+	}
+	if len(out.Entries) != 2 || out.Entries[1].Name != "bc" || !bytes.Equal(out.Entries[0].Values, []uint8{1, 2}) {
+		t.Errorf("items mismatch: have %+v, want %+v", out.Entries, in.Payload.Entries)
+	}
+	// Missing fields are reported rather than silently packed as zero.
+	if _, err := abi.Methods["f"].Inputs.Pack(struct{ Owner common.Address }{}); err == nil {
+		t.Error("expected error packing struct with missing field")
+	}
+}
+
+func TestPackStrictIntegers(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"function u8(uint8 v)",
+		"function i16(int16 v)",
+		"function u256(uint256 v)",
+		"function list(uint32[] v)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		method string
+		value  any
+		ok     bool
+	}{
+		{"u8", 255, true},
+		{"u8", 256, false},
+		{"u8", big.NewInt(256), false},
+		{"u8", -1, false},
+		{"i16", math.MaxInt16, true},
+		{"i16", math.MinInt16, true},
+		{"i16", math.MaxInt16 + 1, false},
+		{"i16", big.NewInt(math.MinInt16 - 1), false},
+		{"u256", new(big.Int).Set(MaxUint256), true},
+		{"u256", new(big.Int).Add(MaxUint256, common.Big1), false},
+		{"u256", "-1", false},
+		{"list", []uint64{1, math.MaxUint32}, true},
+		{"list", []uint64{1, math.MaxUint32 + 1}, false},
+	}
+	for i, tt := range tests {
+		// The default mode silently truncates the values
+		if _, err := abi.Pack(tt.method, tt.value); err != nil && tt.ok {
+			t.Errorf("test %d: unexpected error in lenient mode: %v", i, err)
+		}
+		strict := abi
+		strict.Options.StrictIntegers = true
+		_, err := strict.Pack(tt.method, tt.value)
+		if tt.ok && err != nil {
+			t.Errorf("test %d: unexpected error: %v", i, err)
+		}
+		if !tt.ok && err == nil {
+			t.Errorf("test %d: expected error packing %v as %s", i, tt.value, tt.method)
+		}
+	}
+	// Strict mode is also available on a per-call basis
+	if _, err := abi.Methods["u8"].Inputs.PackWithOptions(Options{StrictIntegers: true}, 300); err == nil {
+		t.Error("expected error packing 300 into uint8")
+	}
+}
+
+func TestPackNamed(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{
+		"function transfer(address to, uint256 amount)",
+		"function unnamed(address, uint256)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := abi.Methods["transfer"].Inputs
+	to := common.Address{0x01}
+
+	have, err := inputs.PackNamed(map[string]any{"amount": big.NewInt(5), "to": to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := inputs.Pack(to, big.NewInt(5))
+	if !bytes.Equal(have, want) {
+		t.Errorf("pack mismatch: have %x, want %x", have, want)
+	}
+	if _, err := inputs.PackNamed(map[string]any{"to": to}); err == nil {
+		t.Error("expected error for missing argument")
+	}
+	if _, err := inputs.PackNamed(map[string]any{"to": to, "amount": big.NewInt(5), "memo": "hi"}); err == nil {
+		t.Error("expected error for unknown argument")
+	}
+	if _, err := abi.Methods["unnamed"].Inputs.PackNamed(map[string]any{}); err == nil {
+		t.Error("expected error for unnamed arguments")
+	}
+}
+
+func TestPackHexStrings(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		typ   string
+		input any
+		want  any
+	}{
+		{"uint256", "0x1234", big.NewInt(0x1234)},
+		{"uint256", "0X00ff", big.NewInt(0xff)},
+		{"int256", "-0x10", big.NewInt(-16)},
+		{"uint64", "4660", big.NewInt(4660)},
+		{"bytes", "0xdeadbeef", []byte{0xde, 0xad, 0xbe, 0xef}},
+		{"bytes", "cafe", []byte{0xca, 0xfe}},
+		{"bytes4", "0xdeadbeef", [4]byte{0xde, 0xad, 0xbe, 0xef}},
+		{"bytes2", "0xcafe", [2]byte{0xca, 0xfe}},
+	}
+	for _, tt := range tests {
+		args := Arguments{{Type: mustTypes(t, tt.typ)[0]}}
+		have, err := args.Pack(tt.input)
+		if err != nil {
+			t.Errorf("%s %q: pack failed: %v", tt.typ, tt.input, err)
+			continue
+		}
+		want, err := args.Pack(tt.want)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(have, want) {
+			t.Errorf("%s %q: have %x, want %x", tt.typ, tt.input, have, want)
+		}
+	}
+
+	invalid := []struct {
+		typ   string
+		input string
+	}{
+		{"uint256", "0x"},
+		{"uint256", "0xzz"},
+		{"uint256", "0x-1"},
+		{"uint256", "1_000"},
+		{"bytes", "0xabc"},
+		{"bytes", "0xgg"},
+		{"bytes4", "0xdead"},
+		{"bytes4", "0xdeadbeef00"},
+	}
+	for _, tt := range invalid {
+		args := Arguments{{Type: mustTypes(t, tt.typ)[0]}}
+		if _, err := args.Pack(tt.input); err == nil {
+			t.Errorf("%s %q: expected error", tt.typ, tt.input)
+		}
+	}
+}
+
+func TestPackAddressPolicy(t *testing.T) {
+	t.Parallel()
+	var (
+		checksummed = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+		lower       = strings.ToLower(checksummed)
+		badChecksum = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"
+		tooShort    = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA"
+		noPrefix    = checksummed[2:]
+	)
+	tests := []struct {
+		policy AddressPolicy
+		input  string
+		ok     bool
+	}{
+		{AddressCaseInsensitive, checksummed, true},
+		{AddressCaseInsensitive, lower, true},
+		{AddressCaseInsensitive, badChecksum, true},
+		{AddressCaseInsensitive, tooShort, false},
+		{AddressCaseInsensitive, noPrefix, false},
+		{AddressChecksum, checksummed, true},
+		{AddressChecksum, lower, false},
+		{AddressChecksum, badChecksum, false},
+		{AddressNoStrings, checksummed, false},
+	}
+	args := Arguments{{Type: mustTypes(t, "address")[0]}}
+	want, _ := args.Pack(common.HexToAddress(checksummed))
+	for _, tt := range tests {
+		have, err := args.PackWithOptions(Options{Addresses: tt.policy}, tt.input)
+		if (err == nil) != tt.ok {
+			t.Errorf("policy %d, address %s: unexpected error state: %v", tt.policy, tt.input, err)
+			continue
+		}
+		if tt.ok && !bytes.Equal(have, want) {
+			t.Errorf("policy %d, address %s: have %x, want %x", tt.policy, tt.input, have, want)
+		}
+	}
+	// Address values are accepted under every policy
+	if _, err := args.PackWithOptions(Options{Addresses: AddressNoStrings}, common.HexToAddress(checksummed)); err != nil {
+		t.Errorf("failed to pack address value: %v", err)
+	}
+}
+
+func TestPackNumericTypes(t *testing.T) {
+	t.Parallel()
+	type wei uint64
+
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+	tests := []struct {
+		input any
+		want  *big.Int
+	}{
+		{uint64(math.MaxUint64), maxUint64},
+		{uint(7), big.NewInt(7)},
+		{uintptr(7), big.NewInt(7)},
+		{int8(-3), big.NewInt(-3)},
+		{wei(1000), big.NewInt(1000)},
+		{float64(1 << 60), new(big.Int).Lsh(big.NewInt(1), 60)},
+		{float32(-42), big.NewInt(-42)},
+		{json.Number("12345678901234567890"), new(big.Int).SetUint64(12345678901234567890)},
+		{json.Number("0x10"), big.NewInt(16)},
+		{json.Number("1e18"), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)},
+		{json.Number("2.50e1"), big.NewInt(25)},
+		{*big.NewInt(9), big.NewInt(9)},
+		{uint256.MustFromDecimal("115792089237316195423570985008687907853269984665640564039457584007913129639935"), new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))},
+		{*uint256.NewInt(11), big.NewInt(11)},
+	}
+	args := Arguments{{Type: mustTypes(t, "int256")[0]}}
+	for _, tt := range tests {
+		have, err := args.Pack(tt.input)
+		if err != nil {
+			t.Errorf("%T %v: pack failed: %v", tt.input, tt.input, err)
+			continue
+		}
+		want, _ := args.Pack(tt.want)
+		if !bytes.Equal(have, want) {
+			t.Errorf("%T %v: have %x, want %x", tt.input, tt.input, have, want)
+		}
+	}
+	invalid := []any{
+		1.5,
+		math.Inf(1),
+		math.NaN(),
+		json.Number("1.5"),
+		json.Number("1e-3"),
+		json.Number("abc"),
+		(*big.Int)(nil),
+		(*uint256.Int)(nil),
+		struct{}{},
+	}
+	for _, input := range invalid {
+		if _, err := args.Pack(input); err == nil {
+			t.Errorf("%T %v: expected error", input, input)
+		}
+	}
+}
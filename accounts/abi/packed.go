@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// ErrAmbiguousPacking is returned by PackPacked if more than one dynamically
+// sized value is packed, in which case different inputs can produce the same
+// encoding (e.g. ("a", "bc") and ("ab", "c")).
+var ErrAmbiguousPacking = errors.New("abi: ambiguous packed encoding of multiple dynamic values")
+
+// PackPacked performs the non-standard packed encoding of the given values, the
+// equivalent of Solidity's abi.encodePacked. It is mostly useful for computing
+// hashes that are checked on-chain, e.g. signature digests and merkle leaves.
+//
+// The packed encoding has the following rules:
+//   - static types are encoded in-place using as few bytes as their type requires
+//     (e.g. uint16 takes 2 bytes, address 20 bytes, bool 1 byte)
+//   - strings and bytes are encoded in-place without padding or length prefix
+//   - array elements are padded to 32 bytes, without length prefix
+//
+// Tuples, nested arrays and arrays of dynamic types can not be packed. As the
+// encoding of dynamically sized values is not self-delimiting, at most one of
+// them (string, bytes or dynamic array) may be packed in a single call.
+func PackPacked(types []Type, values ...interface{}) ([]byte, error) {
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("argument count mismatch: got %d for %d", len(values), len(types))
+	}
+	var (
+		ret     []byte
+		dynamic int
+	)
+	for i, typ := range types {
+		if isDynamicType(typ) {
+			if dynamic++; dynamic > 1 {
+				return nil, ErrAmbiguousPacking
+			}
+		}
+		packed, err := packPackedValue(typ, reflect.ValueOf(values[i]))
+		if err != nil {
+			return nil, fmt.Errorf("abi: cannot pack argument %d: %v", i, err)
+		}
+		ret = append(ret, packed...)
+	}
+	return ret, nil
+}
+
+// packPackedValue packs a single top-level value in the packed encoding.
+func packPackedValue(t Type, v reflect.Value) ([]byte, error) {
+	switch t.T {
+	case SliceTy, ArrayTy:
+		switch t.Elem.T {
+		case SliceTy, ArrayTy, TupleTy, StringTy, BytesTy:
+			return nil, fmt.Errorf("unsupported array element type %v for packed encoding", t.Elem)
+		}
+		packed, err := t.pack(v, Options{})
+		if err != nil {
+			return nil, err
+		}
+		// Array elements keep their 32 byte padding, only strip the length
+		if t.T == SliceTy {
+			packed = packed[32:]
+		}
+		return packed, nil
+	case TupleTy:
+		return nil, errors.New("tuples are not supported in packed encoding")
+	default:
+		packed, err := t.pack(v, Options{})
+		if err != nil {
+			return nil, err
+		}
+		return packedElement(t, packed)
+	}
+}
+
+// packedElement converts the standard 32 byte encoding of an elementary value
+// into its packed form, ensuring the value fits into the declared size.
+func packedElement(t Type, word []byte) ([]byte, error) {
+	switch t.T {
+	case UintTy, IntTy:
+		size := t.Size / 8
+		head, tail := word[:32-size], word[32-size:]
+
+		// The truncated bytes need to be zero for unsigned values and the sign
+		// extension of the remaining value for signed ones.
+		var pad byte
+		if t.T == IntTy && tail[0]&0x80 != 0 {
+			pad = 0xff
+		}
+		for _, b := range head {
+			if b != pad {
+				return nil, fmt.Errorf("value %#x overflows %v", new(big.Int).SetBytes(word), t)
+			}
+		}
+		return tail, nil
+	case BoolTy:
+		return word[31:], nil
+	case AddressTy:
+		return word[12:], nil
+	case FixedBytesTy:
+		return word[:t.Size], nil
+	case FunctionTy:
+		return word[:24], nil
+	case StringTy, BytesTy:
+		length := new(big.Int).SetBytes(word[:32]).Uint64()
+		return word[32 : 32+length], nil
+	default:
+		return nil, fmt.Errorf("unsupported type %v for packed encoding", t)
+	}
+}
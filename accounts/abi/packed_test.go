@@ -0,0 +1,105 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func mustTypes(t *testing.T, names ...string) []Type {
+	t.Helper()
+	types := make([]Type, len(names))
+	for i, name := range names {
+		typ, err := NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		types[i] = typ
+	}
+	return types
+}
+
+func TestPackPacked(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		types  []string
+		values []interface{}
+		want   string
+	}{
+		// Example from the Solidity documentation
+		{
+			[]string{"int16", "bytes1", "uint16", "string"},
+			[]interface{}{int16(-1), [1]byte{0x42}, uint16(0x03), "Hello, world!"},
+			"ffff42000348656c6c6f2c20776f726c6421",
+		},
+		{
+			[]string{"address", "uint256", "bool"},
+			[]interface{}{common.HexToAddress("0x00000000000000000000000000000000deadbeef"), big.NewInt(1), true},
+			"00000000000000000000000000000000deadbeef" + "0000000000000000000000000000000000000000000000000000000000000001" + "01",
+		},
+		{
+			[]string{"uint8[]", "bytes4"},
+			[]interface{}{[]uint8{1, 2}, [4]byte{0xca, 0xfe, 0xba, 0xbe}},
+			"0000000000000000000000000000000000000000000000000000000000000001" + "0000000000000000000000000000000000000000000000000000000000000002" + "cafebabe",
+		},
+		{
+			[]string{"int24", "bytes"},
+			[]interface{}{big.NewInt(-2), []byte{0x01, 0x02, 0x03}},
+			"fffffe" + "010203",
+		},
+	}
+	for i, tt := range tests {
+		packed, err := PackPacked(mustTypes(t, tt.types...), tt.values...)
+		if err != nil {
+			t.Errorf("test %d: pack failed: %v", i, err)
+			continue
+		}
+		if want := common.FromHex(tt.want); !bytes.Equal(packed, want) {
+			t.Errorf("test %d: pack mismatch: have %x, want %x", i, packed, want)
+		}
+	}
+}
+
+func TestPackPackedErrors(t *testing.T) {
+	t.Parallel()
+	if _, err := PackPacked(mustTypes(t, "string", "string"), "a", "bc"); !errors.Is(err, ErrAmbiguousPacking) {
+		t.Errorf("expected ambiguity error, got %v", err)
+	}
+	if _, err := PackPacked(mustTypes(t, "bytes", "uint8", "uint256[]"), []byte{1}, uint8(1), []*big.Int{}); !errors.Is(err, ErrAmbiguousPacking) {
+		t.Errorf("expected ambiguity error, got %v", err)
+	}
+	if _, err := PackPacked(mustTypes(t, "uint8"), 256); err == nil {
+		t.Error("expected overflow error for uint8")
+	}
+	if _, err := PackPacked(mustTypes(t, "int8"), 128); err == nil {
+		t.Error("expected overflow error for int8")
+	}
+	if _, err := PackPacked(mustTypes(t, "uint16"), -1); err == nil {
+		t.Error("expected error for negative uint16")
+	}
+	if _, err := PackPacked(mustTypes(t, "uint8[][]"), [][]uint8{{1}}); err == nil {
+		t.Error("expected error for nested array")
+	}
+	if _, err := PackPacked(mustTypes(t, "uint8"), 1, 2); err == nil {
+		t.Error("expected error for argument count mismatch")
+	}
+}
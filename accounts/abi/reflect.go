@@ -39,21 +39,47 @@ import (
 //
 //	type TupleT struct { X *big.Int }
 func ConvertType(in interface{}, proto interface{}) interface{} {
+	out, err := TryConvertType(in, proto)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// TryConvertType is like ConvertType, but returns an error instead of panicking
+// if the value can't be converted, e.g. an integer not fitting into the type.
+func TryConvertType(in interface{}, proto interface{}) (interface{}, error) {
 	protoType := reflect.TypeOf(proto)
 	if reflect.TypeOf(in).ConvertibleTo(protoType) {
-		return reflect.ValueOf(in).Convert(protoType).Interface()
+		return reflect.ValueOf(in).Convert(protoType).Interface(), nil
+	}
+	// Integers are converted between sizes and representations if the value fits
+	if inType := reflect.TypeOf(in); isInteger(inType) && protoType.Kind() == reflect.Ptr {
+		switch {
+		case protoType == reflect.TypeFor[*big.Int]():
+			value := reflect.New(protoType).Elem()
+			if err := setInteger(value, reflect.ValueOf(in)); err != nil {
+				return nil, err
+			}
+			return proto.(*big.Int).Set(value.Interface().(*big.Int)), nil
+		case isInteger(protoType.Elem()) && protoType.Elem() != inType:
+			if err := setInteger(reflect.ValueOf(proto).Elem(), reflect.ValueOf(in)); err != nil {
+				return nil, err
+			}
+			return proto, nil
+		}
 	}
 	// Use set as a last ditch effort
 	if err := set(reflect.ValueOf(proto), reflect.ValueOf(in)); err != nil {
-		panic(err)
+		return nil, err
 	}
-	return proto
+	return proto, nil
 }
 
 // indirect recursively dereferences the value until it either gets the value
-// or finds a big.Int
+// or finds a big.Int. Nil pointers are returned as is.
 func indirect(v reflect.Value) reflect.Value {
-	if v.Kind() == reflect.Ptr && v.Elem().Type() != reflect.TypeFor[big.Int]() {
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Type() != reflect.TypeFor[big.Int]() {
 		return indirect(v.Elem())
 	}
 	return v
@@ -104,6 +130,8 @@ func set(dst, src reflect.Value) error {
 	switch {
 	case dstType.Kind() == reflect.Interface && dst.Elem().IsValid() && (dst.Elem().Type().Kind() == reflect.Ptr || dst.Elem().CanSet()):
 		return set(dst.Elem(), src)
+	case dstType == reflect.TypeFor[*big.Rat]() && srcType == reflect.TypeFor[FixedPoint]() && dst.CanSet():
+		dst.Set(reflect.ValueOf(src.Interface().(FixedPoint).Rat()))
 	case dstType.Kind() == reflect.Ptr && dstType.Elem() != reflect.TypeFor[big.Int]():
 		return set(dst.Elem(), src)
 	case srcType.AssignableTo(dstType) && dst.CanSet():
@@ -120,6 +148,49 @@ func set(dst, src reflect.Value) error {
 	return nil
 }
 
+// isInteger reports whether the type is a native Go integer or a *big.Int.
+func isInteger(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return typ == reflect.TypeFor[*big.Int]()
+}
+
+// setInteger assigns the integer src to the integer dst of a different type,
+// e.g. a *big.Int unpacked from a uint256 to a uint64. An error is returned if
+// the value doesn't fit into dst.
+func setInteger(dst, src reflect.Value) error {
+	var value *big.Int
+	switch {
+	case src.Type() == reflect.TypeFor[*big.Int]():
+		if src.IsNil() {
+			return fmt.Errorf("abi: cannot unmarshal nil %v in to %v", src.Type(), dst.Type())
+		}
+		value = src.Interface().(*big.Int)
+	case src.CanInt():
+		value = big.NewInt(src.Int())
+	default:
+		value = new(big.Int).SetUint64(src.Uint())
+	}
+	switch {
+	case dst.Type() == reflect.TypeFor[*big.Int]():
+		dst.Set(reflect.ValueOf(new(big.Int).Set(value)))
+	case dst.CanInt():
+		if !value.IsInt64() || dst.OverflowInt(value.Int64()) {
+			return fmt.Errorf("abi: cannot unmarshal %v in to %v: value %v out of range", src.Type(), dst.Type(), value)
+		}
+		dst.SetInt(value.Int64())
+	default:
+		if !value.IsUint64() || dst.OverflowUint(value.Uint64()) {
+			return fmt.Errorf("abi: cannot unmarshal %v in to %v: value %v out of range", src.Type(), dst.Type(), value)
+		}
+		dst.SetUint(value.Uint64())
+	}
+	return nil
+}
+
 // setSlice attempts to assign src to dst when slices are not assignable by default
 // e.g. src: [][]byte -> dst: [][15]byte
 // setSlice ignores if we cannot copy all of src' elements.
@@ -158,7 +229,18 @@ func setArray(dst, src reflect.Value) error {
 	return errors.New("cannot set array, destination not settable")
 }
 
+// setStruct assigns the fields of src to dst. If src is a tuple type created by
+// the abi package, its fields are matched to dst by argument name (honouring
+// `abi:""` tags), otherwise the fields are assigned positionally.
 func setStruct(dst, src reflect.Value) error {
+	if fields := tupleFieldMapping(dst, src); fields != nil {
+		for i, name := range fields {
+			if err := set(dst.FieldByName(name), src.Field(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
 	for i := 0; i < src.NumField(); i++ {
 		srcField := src.Field(i)
 		dstField := dst.Field(i)
@@ -172,6 +254,38 @@ func setStruct(dst, src reflect.Value) error {
 	return nil
 }
 
+// tupleFieldMapping returns the names of the dst struct fields that the fields
+// of the tuple value src should be assigned to, in src field order. Nil is
+// returned if src is not a tuple or not all of its fields can be resolved by
+// name, in which case the caller should fall back to positional assignment.
+func tupleFieldMapping(dst, src reflect.Value) []string {
+	if src.Kind() != reflect.Struct || dst.Kind() != reflect.Struct {
+		return nil
+	}
+	srcType := src.Type()
+	names := make([]string, srcType.NumField())
+	for i := range names {
+		name, ok := srcType.Field(i).Tag.Lookup("json")
+		if !ok || name == "" {
+			return nil
+		}
+		names[i] = name
+	}
+	abi2struct, err := mapArgNamesToStructFields(names, dst)
+	if err != nil {
+		return nil
+	}
+	fields := make([]string, len(names))
+	for i, name := range names {
+		field := abi2struct[name]
+		if field == "" || !dst.FieldByName(field).CanSet() {
+			return nil
+		}
+		fields[i] = field
+	}
+	return fields
+}
+
 // mapArgNamesToStructFields maps a slice of argument names to struct fields.
 //
 // first round: for each Exportable field that contains a `abi:""` tag and this field name
@@ -262,3 +262,45 @@ func TestConvertType(t *testing.T) {
 		t.Errorf("ConvertType failed, got %v want %v", out3[1].Y, big.NewInt(2))
 	}
 }
+
+func TestConvertTypeIntegers(t *testing.T) {
+	t.Parallel()
+
+	if out := *ConvertType(big.NewInt(1000), new(uint64)).(*uint64); out != 1000 {
+		t.Errorf("ConvertType failed, got %v want %v", out, 1000)
+	}
+	if out := *ConvertType(big.NewInt(-5), new(int8)).(*int8); out != -5 {
+		t.Errorf("ConvertType failed, got %v want %v", out, -5)
+	}
+	if out := *ConvertType(uint8(7), new(uint64)).(*uint64); out != 7 {
+		t.Errorf("ConvertType failed, got %v want %v", out, 7)
+	}
+	if out := *ConvertType(uint64(9), new(*big.Int)).(**big.Int); out.Cmp(big.NewInt(9)) != 0 {
+		t.Errorf("ConvertType failed, got %v want %v", out, 9)
+	}
+	if out := ConvertType(int32(-3), new(big.Int)).(*big.Int); out.Cmp(big.NewInt(-3)) != 0 {
+		t.Errorf("ConvertType failed, got %v want %v", out, -3)
+	}
+	// Values not fitting into the target type are rejected
+	for _, tt := range []struct {
+		in    interface{}
+		proto interface{}
+	}{
+		{big.NewInt(256), new(uint8)},
+		{big.NewInt(-1), new(uint64)},
+		{int16(-1), new(uint32)},
+		{new(big.Int).Lsh(big.NewInt(1), 64), new(int64)},
+	} {
+		if _, err := TryConvertType(tt.in, tt.proto); err == nil {
+			t.Errorf("TryConvertType(%v, %T) didn't fail", tt.in, tt.proto)
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("ConvertType(%v, %T) didn't fail", tt.in, tt.proto)
+				}
+			}()
+			ConvertType(tt.in, tt.proto)
+		}()
+	}
+}
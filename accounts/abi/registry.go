@@ -0,0 +1,170 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrUnknownSelector is returned by Registry.DecodeCall if no registered
+	// method matches the call data.
+	ErrUnknownSelector = errors.New("abi: no matching method")
+
+	// ErrUnknownTopic is returned by Registry.DecodeLog if no registered event
+	// matches the log.
+	ErrUnknownTopic = errors.New("abi: no matching event")
+)
+
+// Registry indexes the methods and events of many contract ABIs by selector and
+// topic, allowing arbitrary call data and logs to be decoded without knowing the
+// contract they belong to up front. It is safe for concurrent use.
+type Registry struct {
+	methods map[[4]byte][]registryMethod
+	events  map[common.Hash][]registryEvent
+	lock    sync.RWMutex
+}
+
+type registryMethod struct {
+	contract string
+	method   Method
+}
+
+type registryEvent struct {
+	contract string
+	event    Event
+}
+
+// DecodedCall is a method call decoded by a Registry.
+type DecodedCall struct {
+	Contract string        // Name the contract ABI was registered with
+	Method   Method        // Matched method
+	Values   []interface{} // Argument values, in declaration order
+}
+
+// DecodedLog is a log decoded by a Registry.
+type DecodedLog struct {
+	Contract string        // Name the contract ABI was registered with
+	Event    Event         // Matched event
+	Values   []interface{} // Argument values, in declaration order
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		methods: make(map[[4]byte][]registryMethod),
+		events:  make(map[common.Hash][]registryEvent),
+	}
+}
+
+// Register adds the methods and events of a contract ABI to the registry. The
+// name is only used to identify the contract in decoding results. Anonymous
+// events have no topic to match and are not indexed.
+func (r *Registry) Register(name string, abi ABI) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, method := range abi.Methods {
+		id := [4]byte(method.ID)
+		r.methods[id] = append(r.methods[id], registryMethod{contract: name, method: method})
+	}
+	for _, event := range abi.Events {
+		if event.Anonymous {
+			continue
+		}
+		r.events[event.ID] = append(r.events[event.ID], registryEvent{contract: name, event: event})
+	}
+}
+
+// DecodeCall finds the method matching the selector of the call data and decodes
+// its arguments. If multiple registered methods share the selector, the first
+// one (in registration order) able to decode the data is returned.
+func (r *Registry) DecodeCall(data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("abi: call data too short (%d bytes) for method selector", len(data))
+	}
+	r.lock.RLock()
+	candidates := r.methods[[4]byte(data[:4])]
+	r.lock.RUnlock()
+
+	for _, c := range candidates {
+		values, err := c.method.Inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		return &DecodedCall{Contract: c.contract, Method: c.method, Values: values}, nil
+	}
+	return nil, fmt.Errorf("%w for selector %#x", ErrUnknownSelector, data[:4])
+}
+
+// DecodeLog finds the event matching the first topic of a log and decodes its
+// indexed and non-indexed arguments from the topics and data. Events sharing a
+// topic but differing in their indexed arguments (e.g. ERC-20 and ERC-721
+// transfers) are told apart by the number of topics of the log.
+func (r *Registry) DecodeLog(topics []common.Hash, data []byte) (*DecodedLog, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("%w: log has no topics", ErrUnknownTopic)
+	}
+	r.lock.RLock()
+	candidates := r.events[topics[0]]
+	r.lock.RUnlock()
+
+	for _, c := range candidates {
+		values, err := decodeLogValues(c.event, topics[1:], data)
+		if err != nil {
+			continue
+		}
+		return &DecodedLog{Contract: c.contract, Event: c.event, Values: values}, nil
+	}
+	return nil, fmt.Errorf("%w for topic %v", ErrUnknownTopic, topics[0])
+}
+
+// decodeLogValues decodes the arguments of the event from the log topics (not
+// including the event signature) and data.
+func decodeLogValues(event Event, topics []common.Hash, data []byte) ([]interface{}, error) {
+	var indexed Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	var topicValues []interface{}
+	err := parseTopicWithSetter(indexed, topics, func(arg Argument, value interface{}) {
+		topicValues = append(topicValues, value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	dataValues, err := event.Inputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	// Merge the indexed and non-indexed values in declaration order
+	values := make([]interface{}, 0, len(event.Inputs))
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			values, topicValues = append(values, topicValues[0]), topicValues[1:]
+		} else {
+			values, dataValues = append(values, dataValues[0]), dataValues[1:]
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	erc20, err := ParseHumanReadable([]string{
+		"function transfer(address to, uint256 amount) returns (bool)",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	erc721, err := ParseHumanReadable([]string{
+		"function safeTransferFrom(address from, address to, uint256 id)",
+		"event Transfer(address indexed from, address indexed to, uint256 indexed id)",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := NewRegistry()
+	reg.Register("ERC20", erc20)
+	reg.Register("ERC721", erc721)
+
+	var (
+		from = common.HexToAddress("0x1111")
+		to   = common.HexToAddress("0x2222")
+	)
+	// Decode calls against both contracts
+	input, _ := erc721.Pack("safeTransferFrom", from, to, big.NewInt(7))
+	call, err := reg.DecodeCall(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if call.Contract != "ERC721" || call.Method.Name != "safeTransferFrom" || call.Values[2].(*big.Int).Int64() != 7 {
+		t.Errorf("wrong decoded call: %+v", call)
+	}
+	if _, err := reg.DecodeCall([]byte{1, 2, 3, 4}); !errors.Is(err, ErrUnknownSelector) {
+		t.Errorf("expected unknown selector, got %v", err)
+	}
+	// Decode logs with the same topic but different indexed arguments
+	var (
+		topic    = erc20.Events["Transfer"].ID
+		fromHash = common.BytesToHash(from.Bytes())
+		toHash   = common.BytesToHash(to.Bytes())
+	)
+	nftLog := &types.Log{Topics: []common.Hash{topic, fromHash, toHash, common.BigToHash(big.NewInt(9))}}
+	decoded, err := reg.DecodeLog(nftLog.Topics, nftLog.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Contract != "ERC721" || decoded.Values[0] != from || decoded.Values[2].(*big.Int).Int64() != 9 {
+		t.Errorf("wrong decoded nft log: %+v", decoded)
+	}
+	tokenLog := &types.Log{Topics: []common.Hash{topic, fromHash, toHash}, Data: common.BigToHash(big.NewInt(100)).Bytes()}
+	decoded, err = reg.DecodeLog(tokenLog.Topics, tokenLog.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Contract != "ERC20" || decoded.Values[1] != to || decoded.Values[2].(*big.Int).Int64() != 100 {
+		t.Errorf("wrong decoded token log: %+v", decoded)
+	}
+	if _, err := reg.DecodeLog([]common.Hash{{1}}, nil); !errors.Is(err, ErrUnknownTopic) {
+		t.Errorf("expected unknown topic, got %v", err)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// revertErrorCode is the JSON-RPC error code used by nodes to signal that the
+// execution of eth_call or eth_estimateGas was reverted.
+const revertErrorCode = 3
+
+// RevertError is a contract revert, decoded against the builtin Error(string)
+// and Panic(uint256) errors and the custom errors of an ABI. It can be extracted
+// from the errors returned by the RPC client using errors.As.
+type RevertError struct {
+	Selector [4]byte       // Selector of the error, zero if the revert data is too short
+	Name     string        // Name of the error, empty if it could not be decoded
+	Args     []interface{} // Decoded arguments of the error in declaration order
+	Data     []byte        // Raw revert data
+
+	cause error // Original error the revert was extracted from, if any
+}
+
+// Error implements error, describing the revert in a human-readable way.
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == "Error" && e.Selector == [4]byte(revertSelector):
+		return fmt.Sprintf("execution reverted: %v", e.Args[0])
+	case e.Name == "Panic" && e.Selector == [4]byte(panicSelector):
+		return "execution reverted: " + panicReason(e.Args[0].(*big.Int))
+	case e.Name != "":
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = fmt.Sprintf("%v", arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+	case len(e.Data) > 0:
+		return fmt.Sprintf("execution reverted: unknown error %#x", e.Data)
+	default:
+		return "execution reverted"
+	}
+}
+
+// Unwrap returns the error the revert was extracted from.
+func (e *RevertError) Unwrap() error {
+	return e.cause
+}
+
+// DecodeRevert decodes raw revert data into a RevertError. If the selector is
+// neither a builtin nor a custom error of the ABI, or the arguments fail to
+// decode, the returned error only carries the selector and raw data.
+func (abi ABI) DecodeRevert(data []byte) *RevertError {
+	rerr := &RevertError{Data: common.CopyBytes(data)}
+	if len(data) < 4 {
+		return rerr
+	}
+	rerr.Selector = [4]byte(data[:4])
+	if name, _, args, err := abi.unpackRevert(data); err == nil {
+		rerr.Name, rerr.Args = name, args
+	}
+	return rerr
+}
+
+// unpackRevert decodes revert data against the builtin Error(string) and
+// Panic(uint256) errors and the custom errors of the ABI, returning the name and
+// inputs of the matching error along with the decoded arguments.
+func (abi ABI) unpackRevert(data []byte) (string, Arguments, []interface{}, error) {
+	if len(data) < 4 {
+		return "", nil, nil, fmt.Errorf("revert data too short (%d bytes) for error lookup", len(data))
+	}
+	var (
+		name   string
+		inputs Arguments
+	)
+	switch {
+	case bytes.Equal(data[:4], revertSelector):
+		name, inputs = "Error", Arguments{{Name: "message", Type: Type{T: StringTy}}}
+	case bytes.Equal(data[:4], panicSelector):
+		name, inputs = "Panic", Arguments{{Name: "code", Type: Type{T: UintTy, Size: 256}}}
+	default:
+		e, err := abi.ErrorByID([4]byte(data[:4]))
+		if err != nil {
+			return "", nil, nil, err
+		}
+		name, inputs = e.Name, e.Inputs
+	}
+	args, err := inputs.UnpackWithOptions(abi.Options, data[4:])
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return name, inputs, args, nil
+}
+
+// WrapCallError converts an error returned by eth_call or eth_estimateGas into
+// a RevertError if it carries revert data, wrapping the original error. Other
+// errors are returned unchanged.
+func (abi ABI) WrapCallError(err error) error {
+	data, ok := RevertData(err)
+	if !ok {
+		return err
+	}
+	rerr := abi.DecodeRevert(data)
+	rerr.cause = err
+	return rerr
+}
+
+// RevertData extracts the raw revert data from an error returned by eth_call or
+// eth_estimateGas. The boolean is false if the error is not a revert reported
+// with its data.
+func RevertData(err error) ([]byte, bool) {
+	var (
+		ec interface{ ErrorCode() int }
+		ed interface{ ErrorData() interface{} }
+	)
+	if !errors.As(err, &ec) || !errors.As(err, &ed) || ec.ErrorCode() != revertErrorCode {
+		return nil, false
+	}
+	hex, ok := ed.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, err := hexutil.Decode(hex)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
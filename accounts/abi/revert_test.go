@@ -0,0 +1,110 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// testCallError mimics the errors returned by the RPC client for reverted calls.
+type testCallError struct {
+	code int
+	data interface{}
+}
+
+func (e *testCallError) Error() string          { return "execution reverted" }
+func (e *testCallError) ErrorCode() int         { return e.code }
+func (e *testCallError) ErrorData() interface{} { return e.data }
+
+func TestDecodeRevert(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{"error InsufficientBalance(address sender, uint256 balance)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, _ := abi.Errors["InsufficientBalance"].Inputs.Pack(common.Address{0x01}, big.NewInt(5))
+	custom = append(abi.Errors["InsufficientBalance"].ID.Bytes()[:4], custom...)
+
+	reason, _ := (Arguments{{Type: Type{T: StringTy}}}).Pack("not enough")
+	panicked, _ := (Arguments{{Type: Type{T: UintTy, Size: 256}}}).Pack(big.NewInt(0x11))
+
+	tests := []struct {
+		data []byte
+		name string
+		msg  string
+	}{
+		{append(common.CopyBytes(revertSelector), reason...), "Error", "execution reverted: not enough"},
+		{append(common.CopyBytes(panicSelector), panicked...), "Panic", "execution reverted: arithmetic underflow or overflow"},
+		{custom, "InsufficientBalance", "execution reverted: InsufficientBalance(0x0100000000000000000000000000000000000000, 5)"},
+		{[]byte{0xde, 0xad, 0xbe, 0xef}, "", "execution reverted: unknown error 0xdeadbeef"},
+		{custom[:20], "", fmt.Sprintf("execution reverted: unknown error %#x", custom[:20])},
+		{nil, "", "execution reverted"},
+	}
+	for i, tt := range tests {
+		rerr := abi.DecodeRevert(tt.data)
+		if rerr.Name != tt.name {
+			t.Errorf("test %d: name mismatch: have %q, want %q", i, rerr.Name, tt.name)
+		}
+		if rerr.Error() != tt.msg {
+			t.Errorf("test %d: message mismatch: have %q, want %q", i, rerr.Error(), tt.msg)
+		}
+	}
+}
+
+func TestWrapCallError(t *testing.T) {
+	t.Parallel()
+	abi, err := ParseHumanReadable([]string{"error Unauthorized(address caller)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	args, _ := abi.Errors["Unauthorized"].Inputs.Pack(common.Address{0xaa})
+	data := append(abi.Errors["Unauthorized"].ID.Bytes()[:4], args...)
+
+	callErr := &testCallError{code: 3, data: hexutil.Encode(data)}
+	wrapped := abi.WrapCallError(fmt.Errorf("call failed: %w", callErr))
+
+	var rerr *RevertError
+	if !errors.As(wrapped, &rerr) {
+		t.Fatalf("expected revert error, got %v", wrapped)
+	}
+	if rerr.Name != "Unauthorized" || rerr.Args[0] != (common.Address{0xaa}) {
+		t.Errorf("unexpected revert: %s %v", rerr.Name, rerr.Args)
+	}
+	if rerr.Selector != [4]byte(data[:4]) || !bytes.Equal(rerr.Data, data) {
+		t.Errorf("unexpected revert data: %x", rerr.Data)
+	}
+	if !errors.Is(wrapped, callErr) {
+		t.Error("original error not retained")
+	}
+	// Errors which are not reverts are passed through
+	for _, err := range []error{
+		errors.New("connection refused"),
+		&testCallError{code: -32000, data: hexutil.Encode(data)},
+		&testCallError{code: 3, data: 42},
+	} {
+		if have := abi.WrapCallError(err); have != err {
+			t.Errorf("error %v was wrapped: %v", err, have)
+		}
+	}
+}
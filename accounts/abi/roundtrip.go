@@ -0,0 +1,160 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"reflect"
+)
+
+// RoundTripCheck packs the value as type t, unpacks the result and verifies
+// that the unpacked value encodes to the same bytes again and, if it has the
+// same Go type as the input, equals it. It allows property testing other
+// encoders and decoders against this reference implementation.
+func RoundTripCheck(t Type, value interface{}) error {
+	args := Arguments{{Type: t}}
+	packed, err := args.Pack(value)
+	if err != nil {
+		return fmt.Errorf("abi: pack failed: %v", err)
+	}
+	unpacked, err := args.Unpack(packed)
+	if err != nil {
+		return fmt.Errorf("abi: unpack failed: %v", err)
+	}
+	repacked, err := args.Pack(unpacked[0])
+	if err != nil {
+		return fmt.Errorf("abi: repack failed: %v", err)
+	}
+	if !bytes.Equal(packed, repacked) {
+		return fmt.Errorf("abi: encoding mismatch after round trip: %x != %x", packed, repacked)
+	}
+	if reflect.TypeOf(value) == reflect.TypeOf(unpacked[0]) && !valuesEqual(reflect.ValueOf(value), reflect.ValueOf(unpacked[0])) {
+		return fmt.Errorf("abi: value mismatch after round trip: %v != %v", value, unpacked[0])
+	}
+	return nil
+}
+
+// valuesEqual is a deep equality check of two values of the same type, which
+// compares big integers numerically rather than by their internal layout.
+func valuesEqual(a, b reflect.Value) bool {
+	if a.Type() == reflect.TypeFor[*big.Int]() {
+		x, y := a.Interface().(*big.Int), b.Interface().(*big.Int)
+		return (x == nil) == (y == nil) && (x == nil || x.Cmp(y) == 0)
+	}
+	switch a.Kind() {
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !valuesEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !valuesEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+// RandomValue generates a random value of type t, using the Go types produced
+// by Unpack. Dynamic arrays, strings and bytes are kept short, so that values
+// of deeply nested types remain reasonably sized.
+func RandomValue(t Type, r *rand.Rand) interface{} {
+	return randomValue(t, r).Interface()
+}
+
+func randomValue(t Type, r *rand.Rand) reflect.Value {
+	value := reflect.New(t.GetType()).Elem()
+	switch t.T {
+	case IntTy, UintTy:
+		n := randomInt(t.Size, t.T == IntTy, r)
+		switch value.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			value.SetUint(n.Uint64())
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			value.SetInt(n.Int64())
+		default:
+			value.Set(reflect.ValueOf(n))
+		}
+	case FixedPointTy:
+		n := randomInt(t.Size, !t.isUnsignedFixed(), r)
+		value.Set(reflect.ValueOf(FixedPoint{Value: n, Decimals: t.Decimals}))
+	case BoolTy:
+		value.SetBool(r.Intn(2) == 1)
+	case StringTy:
+		value.SetString(string(randomBytes(r.Intn(70), r)))
+	case BytesTy:
+		value.SetBytes(randomBytes(r.Intn(70), r))
+	case AddressTy, FixedBytesTy, FunctionTy:
+		reflect.Copy(value, reflect.ValueOf(randomBytes(value.Len(), r)))
+	case SliceTy:
+		n := r.Intn(4)
+		value.Set(reflect.MakeSlice(value.Type(), n, n))
+		for i := 0; i < n; i++ {
+			value.Index(i).Set(randomValue(*t.Elem, r))
+		}
+	case ArrayTy:
+		for i := 0; i < t.Size; i++ {
+			value.Index(i).Set(randomValue(*t.Elem, r))
+		}
+	case TupleTy:
+		for i, elem := range t.TupleElems {
+			value.Field(i).Set(randomValue(*elem, r))
+		}
+	default:
+		panic(fmt.Sprintf("abi: cannot generate value of type %v", t))
+	}
+	return value
+}
+
+// randomInt generates a random integer representable in the given number of
+// bits, favouring the boundaries of the range.
+func randomInt(bits int, signed bool, r *rand.Rand) *big.Int {
+	var (
+		limit = new(big.Int).Lsh(big.NewInt(1), uint(bits))
+		n     *big.Int
+	)
+	switch r.Intn(4) {
+	case 0:
+		n = big.NewInt(0)
+	case 1:
+		n = new(big.Int).Sub(limit, big.NewInt(1))
+	default:
+		n = new(big.Int).Rand(r, limit)
+	}
+	if signed {
+		n.Sub(n, new(big.Int).Rsh(limit, 1))
+	}
+	return n
+}
+
+func randomBytes(n int, r *rand.Rand) []byte {
+	b := make([]byte, n)
+	r.Read(b)
+	return b
+}
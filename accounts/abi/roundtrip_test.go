@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestRoundTripRandomValues(t *testing.T) {
+	t.Parallel()
+
+	types := []string{
+		"uint8", "int16", "uint24", "int64", "uint256", "int256", "bool", "address",
+		"string", "bytes", "bytes1", "bytes32", "function", "fixed128x18", "ufixed8x1",
+		"uint256[]", "string[2]", "bytes[][]", "int8[3][2]",
+		"(uint256,string,(bool,address[])[])",
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, name := range types {
+		typ := mustTypes(t, name)[0]
+		for i := 0; i < 50; i++ {
+			value := RandomValue(typ, r)
+			if err := RoundTripCheck(typ, value); err != nil {
+				t.Fatalf("type %s, value %v: %v", name, value, err)
+			}
+		}
+	}
+}
+
+func TestRoundTripCheckFailures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		typ   string
+		value interface{}
+	}{
+		{"uint256", big.NewInt(-1)},                      // rejected by the packer
+		{"uint8", 300},                                   // packed, but fails to unpack
+		{"int256", new(big.Int).Lsh(big.NewInt(1), 256)}, // silently truncated
+	}
+	for _, tt := range tests {
+		if err := RoundTripCheck(mustTypes(t, tt.typ)[0], tt.value); err == nil {
+			t.Errorf("type %s, value %v: expected round trip failure", tt.typ, tt.value)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CanonicalSignature converts a function, event or error signature into the
+// canonical form used for deriving selectors and topics: argument names, data
+// locations and the declaration keyword are dropped, type aliases are expanded
+// and tuples are written as parenthesized component lists. For example
+//
+//	function submit(tuple(uint a, bytes b)[] calldata orders) returns (bool)
+//
+// becomes submit((uint256,bytes)[]).
+func CanonicalSignature(signature string) (string, error) {
+	decl := strings.TrimSpace(signature)
+	if kind, _, _ := strings.Cut(decl, " "); kind != "function" && kind != "event" && kind != "error" {
+		// Event parameters may be marked indexed, allow it for bare signatures
+		decl = "event " + decl
+	}
+	field, err := parseHumanReadableField(decl)
+	if err != nil {
+		return "", fmt.Errorf("abi: invalid signature '%s': %v", signature, err)
+	}
+	types := make([]string, len(field.Inputs))
+	for i, input := range field.Inputs {
+		types[i] = input.Type.String()
+	}
+	return fmt.Sprintf("%s(%s)", field.Name, strings.Join(types, ",")), nil
+}
+
+// Selector returns the 4-byte selector of a function or error signature, which
+// is canonicalized first, e.g. Selector("transfer(address to, uint amount)").
+func Selector(signature string) ([4]byte, error) {
+	sig, err := CanonicalSignature(signature)
+	if err != nil {
+		return [4]byte{}, err
+	}
+	return [4]byte(crypto.Keccak256([]byte(sig))), nil
+}
+
+// EventTopic returns the topic identifying the logs of an event signature,
+// which is canonicalized first.
+func EventTopic(signature string) (common.Hash, error) {
+	sig, err := CanonicalSignature(signature)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte(sig)), nil
+}
+
+// HumanReadable formats the method as a human-readable declaration, including
+// argument names and tuple components, which can be parsed again by
+// ParseHumanReadable.
+func (method Method) HumanReadable() string {
+	var decl string
+	switch method.Type {
+	case Function:
+		decl = fmt.Sprintf("function %s(%s)", method.RawName, formatHRParams(method.Inputs))
+	case Fallback:
+		decl = "fallback()"
+	case Receive:
+		decl = "receive()"
+	default:
+		decl = fmt.Sprintf("constructor(%s)", formatHRParams(method.Inputs))
+	}
+	if m := mutability(method); m != "nonpayable" {
+		decl += " " + m
+	}
+	if method.Type == Function && len(method.Outputs) > 0 {
+		decl += fmt.Sprintf(" returns (%s)", formatHRParams(method.Outputs))
+	}
+	return decl
+}
+
+// HumanReadable formats the event as a human-readable declaration, which can be
+// parsed again by ParseHumanReadable.
+func (e Event) HumanReadable() string {
+	decl := fmt.Sprintf("event %s(%s)", e.RawName, formatHRParams(e.Inputs))
+	if e.Anonymous {
+		decl += " anonymous"
+	}
+	return decl
+}
+
+// HumanReadable formats the error as a human-readable declaration, which can be
+// parsed again by ParseHumanReadable.
+func (e Error) HumanReadable() string {
+	return fmt.Sprintf("error %s(%s)", e.Name, formatHRParams(e.Inputs))
+}
+
+// formatHRParams formats a human-readable parameter list.
+func formatHRParams(args Arguments) string {
+	params := make([]string, len(args))
+	for i, arg := range args {
+		params[i] = formatHRType(arg.Type)
+		if arg.Indexed {
+			params[i] += " indexed"
+		}
+		if arg.Name != "" {
+			params[i] += " " + arg.Name
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// formatHRType formats a type, expanding tuples with their component names.
+func formatHRType(t Type) string {
+	switch t.T {
+	case SliceTy:
+		return formatHRType(*t.Elem) + "[]"
+	case ArrayTy:
+		return fmt.Sprintf("%s[%d]", formatHRType(*t.Elem), t.Size)
+	case TupleTy:
+		components := make([]string, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			components[i] = formatHRType(*elem) + " " + t.TupleRawNames[i]
+		}
+		return "(" + strings.Join(components, ", ") + ")"
+	default:
+		return t.String()
+	}
+}
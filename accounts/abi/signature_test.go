@@ -0,0 +1,118 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestCanonicalSignature(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"transfer(address,uint256)", "transfer(address,uint256)"},
+		{"transfer(address to, uint amount)", "transfer(address,uint256)"},
+		{"function transfer(address to, uint256 amount) external returns (bool)", "transfer(address,uint256)"},
+		{"submit(tuple(uint a, bytes b)[] calldata orders, byte flag)", "submit((uint256,bytes)[],bytes1)"},
+		{"nested(((address,uint8)[2],string) x)", "nested(((address,uint8)[2],string))"},
+		{"Transfer(address indexed from, address indexed to, uint256 value)", "Transfer(address,address,uint256)"},
+		{"event Transfer(address indexed, address indexed, uint256)", "Transfer(address,address,uint256)"},
+		{"error InsufficientBalance(uint available, uint required)", "InsufficientBalance(uint256,uint256)"},
+		{"empty()", "empty()"},
+	}
+	for _, tt := range tests {
+		have, err := CanonicalSignature(tt.input)
+		if err != nil {
+			t.Errorf("%q: %v", tt.input, err)
+			continue
+		}
+		if have != tt.want {
+			t.Errorf("%q: have %q, want %q", tt.input, have, tt.want)
+		}
+	}
+	for _, input := range []string{"", "transfer", "transfer(address", "transfer(addr)", "1transfer()"} {
+		if _, err := CanonicalSignature(input); err == nil {
+			t.Errorf("%q: expected error", input)
+		}
+	}
+}
+
+func TestSelectorAndEventTopic(t *testing.T) {
+	t.Parallel()
+	sel, err := Selector("transfer(address to, uint amount)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have := hexutil.Encode(sel[:]); have != "0xa9059cbb" {
+		t.Errorf("selector mismatch: have %s, want 0xa9059cbb", have)
+	}
+	topic, err := EventTopic("event Transfer(address indexed from, address indexed to, uint256 value)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"); topic != want {
+		t.Errorf("topic mismatch: have %x, want %x", topic, want)
+	}
+}
+
+func TestHumanReadableFormat(t *testing.T) {
+	t.Parallel()
+	decls := []string{
+		"function submit((uint256 id, (address to, bytes data)[] calls)[2] orders, bool strict) payable returns (bytes32 root)",
+		"function balanceOf(address owner) view returns (uint256)",
+		"function poke()",
+		"constructor(string name) payable",
+		"fallback()",
+		"receive() payable",
+		"event Transfer(address indexed from, address indexed to, uint256 value)",
+		"event Raw(bytes32 indexed id) anonymous",
+		"error Unauthorized(address caller)",
+	}
+	abi, err := ParseHumanReadable(decls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have := []string{
+		abi.Methods["submit"].HumanReadable(),
+		abi.Methods["balanceOf"].HumanReadable(),
+		abi.Methods["poke"].HumanReadable(),
+		abi.Constructor.HumanReadable(),
+		abi.Fallback.HumanReadable(),
+		abi.Receive.HumanReadable(),
+		abi.Events["Transfer"].HumanReadable(),
+		abi.Events["Raw"].HumanReadable(),
+		abi.Errors["Unauthorized"].HumanReadable(),
+	}
+	for i := range decls {
+		if have[i] != decls[i] {
+			t.Errorf("declaration %d mismatch:\nhave %s\nwant %s", i, have[i], decls[i])
+		}
+	}
+	// The formatted declarations must parse into the same ABI
+	reparsed, err := ParseHumanReadable(have)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if Diff(abi, reparsed) != nil {
+		t.Errorf("re-parsed ABI differs: %v", Diff(abi, reparsed))
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package storagelayout parses the storage layout emitted by solc and computes
+// the storage location of state variables, including mapping entries, array
+// elements and struct members, so they can be read using eth_getStorageAt.
+package storagelayout
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// Encodings of storage types, as reported by solc.
+const (
+	EncodingInplace      = "inplace"       // Value types, structs and static arrays
+	EncodingMapping      = "mapping"       // Mappings, entries at keccak256(key . slot)
+	EncodingDynamicArray = "dynamic_array" // Length at the slot, elements at keccak256(slot)
+	EncodingBytes        = "bytes"         // Strings and bytes, short values inline
+)
+
+// Variable is a state variable or struct member.
+type Variable struct {
+	ASTID    int    // Id of the declaration in the source AST
+	Contract string // Declaring contract, e.g. "contracts/Token.sol:Token"
+	Label    string // Name of the variable
+	Slot     *big.Int
+	Offset   int    // Byte offset within the slot, from the least significant end
+	Type     string // Type identifier, key into Layout.Types
+}
+
+// Type describes the storage encoding of a type.
+type Type struct {
+	Encoding      string
+	Label         string // Solidity type name, e.g. "mapping(address => uint256)"
+	NumberOfBytes uint64 // Number of bytes occupied by a value of the type
+	Key           string // Key type of mappings
+	Value         string // Value type of mappings
+	Base          string // Element type of arrays
+	Members       []Variable
+}
+
+// Layout is the storage layout of a contract.
+type Layout struct {
+	Storage []Variable
+	Types   map[string]Type
+}
+
+// jsonVariable and jsonType mirror the solc output, which encodes big numbers
+// as strings.
+type jsonVariable struct {
+	ASTID    int    `json:"astId"`
+	Contract string `json:"contract"`
+	Label    string `json:"label"`
+	Offset   int    `json:"offset"`
+	Slot     string `json:"slot"`
+	Type     string `json:"type"`
+}
+
+type jsonType struct {
+	Encoding      string         `json:"encoding"`
+	Label         string         `json:"label"`
+	NumberOfBytes string         `json:"numberOfBytes"`
+	Key           string         `json:"key"`
+	Value         string         `json:"value"`
+	Base          string         `json:"base"`
+	Members       []jsonVariable `json:"members"`
+}
+
+// Parse parses the storageLayout output of solc.
+func Parse(data []byte) (*Layout, error) {
+	var dec struct {
+		Storage []jsonVariable      `json:"storage"`
+		Types   map[string]jsonType `json:"types"`
+	}
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return nil, err
+	}
+	layout := &Layout{Types: make(map[string]Type, len(dec.Types))}
+	var err error
+	if layout.Storage, err = convertVariables(dec.Storage); err != nil {
+		return nil, err
+	}
+	for id, t := range dec.Types {
+		size, err := strconv.ParseUint(t.NumberOfBytes, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: invalid size %q", id, t.NumberOfBytes)
+		}
+		members, err := convertVariables(t.Members)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %v", id, err)
+		}
+		layout.Types[id] = Type{
+			Encoding:      t.Encoding,
+			Label:         t.Label,
+			NumberOfBytes: size,
+			Key:           t.Key,
+			Value:         t.Value,
+			Base:          t.Base,
+			Members:       members,
+		}
+	}
+	// Ensure all referenced types are defined, so lookups can't fail later
+	for _, v := range layout.Storage {
+		if _, ok := layout.Types[v.Type]; !ok {
+			return nil, fmt.Errorf("variable %s: unknown type %s", v.Label, v.Type)
+		}
+	}
+	for id, t := range layout.Types {
+		for _, ref := range append([]string{t.Key, t.Value, t.Base}, memberTypes(t)...) {
+			if _, ok := layout.Types[ref]; ref != "" && !ok {
+				return nil, fmt.Errorf("type %s: unknown type %s", id, ref)
+			}
+		}
+	}
+	return layout, nil
+}
+
+func convertVariables(vars []jsonVariable) ([]Variable, error) {
+	out := make([]Variable, len(vars))
+	for i, v := range vars {
+		slot, ok := new(big.Int).SetString(v.Slot, 10)
+		if !ok || slot.Sign() < 0 || slot.BitLen() > 256 {
+			return nil, fmt.Errorf("variable %s: invalid slot %q", v.Label, v.Slot)
+		}
+		out[i] = Variable{ASTID: v.ASTID, Contract: v.Contract, Label: v.Label, Slot: slot, Offset: v.Offset, Type: v.Type}
+	}
+	return out, nil
+}
+
+func memberTypes(t Type) []string {
+	types := make([]string, len(t.Members))
+	for i, m := range t.Members {
+		types[i] = m.Type
+	}
+	return types
+}
+
+// Location is the position of a value in contract storage.
+type Location struct {
+	Slot   common.Hash // Storage slot holding the value (or its first slot)
+	Offset int         // Byte offset within the slot, from the least significant end
+	Type   string      // Type identifier of the value
+	Size   uint64      // Number of bytes occupied by the value
+}
+
+// Extract returns the bytes of a value packed into a storage word read from
+// the location's slot. Values spanning whole slots are returned unchanged.
+func (loc *Location) Extract(word common.Hash) []byte {
+	if loc.Size >= 32 {
+		return word[:]
+	}
+	end := 32 - loc.Offset
+	return word[end-int(loc.Size) : end]
+}
+
+// Locate computes the storage location of a state variable or of a value
+// nested within it. The path starts with the name of the variable and is
+// followed by one element per level of nesting:
+//
+//   - the member name (a string) for structs
+//   - the index (any integer type or *big.Int) for arrays
+//   - the key for mappings, in any Go type accepted by the ABI encoder for the
+//     key type, e.g. common.Address, *big.Int or string
+//
+// For example Locate("balances", addr) or Locate("orders", id, "amount").
+func (l *Layout) Locate(variable string, path ...interface{}) (*Location, error) {
+	var root *Variable
+	for i := range l.Storage {
+		if l.Storage[i].Label == variable {
+			root = &l.Storage[i]
+			break
+		}
+	}
+	if root == nil {
+		return nil, fmt.Errorf("unknown variable %q", variable)
+	}
+	var (
+		slot   = uint256.MustFromBig(root.Slot)
+		offset = root.Offset
+		typeID = root.Type
+		name   = variable
+	)
+	for _, elem := range path {
+		t := l.Types[typeID]
+		switch {
+		case t.Encoding == EncodingMapping:
+			key, err := l.encodeKey(t.Key, elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			slot = hashSlot(append(key, slot.PaddedBytes(32)...))
+			offset, typeID = 0, t.Value
+			name += fmt.Sprintf("[%v]", elem)
+
+		case t.Encoding == EncodingDynamicArray || (t.Encoding == EncodingInplace && t.Base != ""):
+			index, err := toIndex(elem)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", name, err)
+			}
+			if t.Encoding == EncodingDynamicArray {
+				slot = hashSlot(slot.PaddedBytes(32))
+			} else if length, ok := staticLength(t.Label); ok && index >= length {
+				return nil, fmt.Errorf("%s: index %d out of bounds (length %d)", name, index, length)
+			}
+			var (
+				base = l.Types[t.Base]
+				skip uint64
+			)
+			offset, skip = elementPosition(base.NumberOfBytes, index)
+			slot = new(uint256.Int).Add(slot, uint256.NewInt(skip))
+			typeID = t.Base
+			name += fmt.Sprintf("[%d]", index)
+
+		case t.Encoding == EncodingInplace && len(t.Members) > 0:
+			member, ok := elem.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: struct member must be given by name, got %T", name, elem)
+			}
+			var found *Variable
+			for i := range t.Members {
+				if t.Members[i].Label == member {
+					found = &t.Members[i]
+					break
+				}
+			}
+			if found == nil {
+				return nil, fmt.Errorf("%s: %s has no member %q", name, t.Label, member)
+			}
+			slot = new(uint256.Int).Add(slot, uint256.MustFromBig(found.Slot))
+			offset, typeID = found.Offset, found.Type
+			name += "." + member
+
+		default:
+			return nil, fmt.Errorf("%s: cannot index into %s", name, t.Label)
+		}
+	}
+	return &Location{
+		Slot:   slot.Bytes32(),
+		Offset: offset,
+		Type:   typeID,
+		Size:   l.Types[typeID].NumberOfBytes,
+	}, nil
+}
+
+// encodeKey encodes a mapping key for hashing: strings and bytes are used as
+// is, value types are padded to 32 bytes as in memory.
+func (l *Layout) encodeKey(typeID string, key interface{}) ([]byte, error) {
+	t := l.Types[typeID]
+	if t.Encoding == EncodingBytes {
+		switch k := key.(type) {
+		case string:
+			return []byte(k), nil
+		case []byte:
+			return k, nil
+		default:
+			return nil, fmt.Errorf("invalid key type %T for %s", key, t.Label)
+		}
+	}
+	abiType, err := keyType(typeID, t)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Arguments{{Type: abiType}}.PackWithOptions(abi.Options{StrictIntegers: true}, key)
+}
+
+// keyType returns the ABI type used for encoding mapping keys of a value type.
+func keyType(typeID string, t Type) (abi.Type, error) {
+	switch {
+	case strings.HasPrefix(typeID, "t_contract("):
+		return abi.NewType("address", "", nil)
+	case strings.HasPrefix(typeID, "t_enum("):
+		return abi.NewType(fmt.Sprintf("uint%d", 8*t.NumberOfBytes), "", nil)
+	case strings.HasPrefix(typeID, "t_userDefinedValueType("):
+		return abi.Type{}, fmt.Errorf("unsupported user-defined key type %s", t.Label)
+	}
+	// Elementary types are labelled by their Solidity name
+	return abi.NewType(strings.TrimSuffix(t.Label, " payable"), "", nil)
+}
+
+// toIndex converts an array index given as any integer type.
+func toIndex(elem interface{}) (uint64, error) {
+	if b, ok := elem.(*big.Int); ok {
+		if b == nil || !b.IsUint64() {
+			return 0, fmt.Errorf("invalid array index %v", b)
+		}
+		return b.Uint64(), nil
+	}
+	v := reflect.ValueOf(elem)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			return 0, fmt.Errorf("negative array index %d", v.Int())
+		}
+		return uint64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	default:
+		return 0, fmt.Errorf("array index must be an integer, got %T", elem)
+	}
+}
+
+// elementPosition returns the byte offset and the number of slots from the start
+// of the array at which the element with the given index is stored. As many
+// elements as fit are packed into each slot, elements larger than a slot occupy
+// whole slots.
+func elementPosition(size uint64, index uint64) (int, uint64) {
+	if size == 0 {
+		return 0, 0
+	}
+	if size <= 32 {
+		perSlot := 32 / size
+		return int(index % perSlot * size), index / perSlot
+	}
+	return 0, index * ((size + 31) / 32)
+}
+
+var staticArrayRegex = regexp.MustCompile(`\[(\d+)\]$`)
+
+// staticLength parses the length of a static array from its type label.
+func staticLength(label string) (uint64, bool) {
+	m := staticArrayRegex.FindStringSubmatch(label)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(m[1], 10, 64)
+	return n, err == nil
+}
+
+// hashSlot computes the keccak256 hash of data as a slot number.
+func hashSlot(data []byte) *uint256.Int {
+	return new(uint256.Int).SetBytes32(crypto.Keccak256(data))
+}
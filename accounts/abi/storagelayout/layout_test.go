@@ -0,0 +1,175 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package storagelayout
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testLayout is the solc storage layout of the following contract:
+//
+//	contract Token {
+//	    struct Order { uint256 id; address maker; uint96 amount; }
+//	    uint128 a;
+//	    uint64 b;
+//	    address owner;
+//	    mapping(address => uint256) balances;
+//	    mapping(address => mapping(address => uint256)) allowances;
+//	    uint16[] small;
+//	    Order[] orders;
+//	    mapping(string => Order) named;
+//	    uint256[3] fixedArr;
+//	}
+const testLayout = `{
+  "storage": [
+    {"astId": 10, "contract": "Token.sol:Token", "label": "a", "offset": 0, "slot": "0", "type": "t_uint128"},
+    {"astId": 12, "contract": "Token.sol:Token", "label": "b", "offset": 16, "slot": "0", "type": "t_uint64"},
+    {"astId": 14, "contract": "Token.sol:Token", "label": "owner", "offset": 0, "slot": "1", "type": "t_address"},
+    {"astId": 18, "contract": "Token.sol:Token", "label": "balances", "offset": 0, "slot": "2", "type": "t_mapping(t_address,t_uint256)"},
+    {"astId": 24, "contract": "Token.sol:Token", "label": "allowances", "offset": 0, "slot": "3", "type": "t_mapping(t_address,t_mapping(t_address,t_uint256))"},
+    {"astId": 27, "contract": "Token.sol:Token", "label": "small", "offset": 0, "slot": "4", "type": "t_array(t_uint16)dyn_storage"},
+    {"astId": 31, "contract": "Token.sol:Token", "label": "orders", "offset": 0, "slot": "5", "type": "t_array(t_struct(Order)8_storage)dyn_storage"},
+    {"astId": 36, "contract": "Token.sol:Token", "label": "named", "offset": 0, "slot": "6", "type": "t_mapping(t_string_memory_ptr,t_struct(Order)8_storage)"},
+    {"astId": 40, "contract": "Token.sol:Token", "label": "fixedArr", "offset": 0, "slot": "7", "type": "t_array(t_uint256)3_storage"}
+  ],
+  "types": {
+    "t_address": {"encoding": "inplace", "label": "address", "numberOfBytes": "20"},
+    "t_uint16": {"encoding": "inplace", "label": "uint16", "numberOfBytes": "2"},
+    "t_uint64": {"encoding": "inplace", "label": "uint64", "numberOfBytes": "8"},
+    "t_uint96": {"encoding": "inplace", "label": "uint96", "numberOfBytes": "12"},
+    "t_uint128": {"encoding": "inplace", "label": "uint128", "numberOfBytes": "16"},
+    "t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"},
+    "t_string_memory_ptr": {"encoding": "bytes", "label": "string", "numberOfBytes": "32"},
+    "t_array(t_uint16)dyn_storage": {"base": "t_uint16", "encoding": "dynamic_array", "label": "uint16[]", "numberOfBytes": "32"},
+    "t_array(t_uint256)3_storage": {"base": "t_uint256", "encoding": "inplace", "label": "uint256[3]", "numberOfBytes": "96"},
+    "t_array(t_struct(Order)8_storage)dyn_storage": {"base": "t_struct(Order)8_storage", "encoding": "dynamic_array", "label": "struct Token.Order[]", "numberOfBytes": "32"},
+    "t_mapping(t_address,t_uint256)": {"encoding": "mapping", "key": "t_address", "label": "mapping(address => uint256)", "numberOfBytes": "32", "value": "t_uint256"},
+    "t_mapping(t_address,t_mapping(t_address,t_uint256))": {"encoding": "mapping", "key": "t_address", "label": "mapping(address => mapping(address => uint256))", "numberOfBytes": "32", "value": "t_mapping(t_address,t_uint256)"},
+    "t_mapping(t_string_memory_ptr,t_struct(Order)8_storage)": {"encoding": "mapping", "key": "t_string_memory_ptr", "label": "mapping(string => struct Token.Order)", "numberOfBytes": "32", "value": "t_struct(Order)8_storage"},
+    "t_struct(Order)8_storage": {"encoding": "inplace", "label": "struct Token.Order", "numberOfBytes": "64", "members": [
+      {"astId": 3, "contract": "Token.sol:Token", "label": "id", "offset": 0, "slot": "0", "type": "t_uint256"},
+      {"astId": 5, "contract": "Token.sol:Token", "label": "maker", "offset": 0, "slot": "1", "type": "t_address"},
+      {"astId": 7, "contract": "Token.sol:Token", "label": "amount", "offset": 20, "slot": "1", "type": "t_uint96"}
+    ]}
+  }
+}`
+
+func slotHash(n int64) common.Hash {
+	return common.BigToHash(big.NewInt(n))
+}
+
+func addSlot(h common.Hash, n int64) common.Hash {
+	return common.BigToHash(new(big.Int).Add(h.Big(), big.NewInt(n)))
+}
+
+func TestLocate(t *testing.T) {
+	t.Parallel()
+	layout, err := Parse([]byte(testLayout))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		alice = common.HexToAddress("0x00000000000000000000000000000000000a11ce")
+		bob   = common.HexToAddress("0x0000000000000000000000000000000000000b0b")
+
+		balanceSlot   = crypto.Keccak256Hash(common.LeftPadBytes(alice[:], 32), slotHash(2).Bytes())
+		allowanceBase = crypto.Keccak256Hash(common.LeftPadBytes(alice[:], 32), slotHash(3).Bytes())
+		allowanceSlot = crypto.Keccak256Hash(common.LeftPadBytes(bob[:], 32), allowanceBase.Bytes())
+		smallData     = crypto.Keccak256Hash(slotHash(4).Bytes())
+		ordersData    = crypto.Keccak256Hash(slotHash(5).Bytes())
+		namedSlot     = crypto.Keccak256Hash([]byte("limit"), slotHash(6).Bytes())
+	)
+	tests := []struct {
+		variable string
+		path     []interface{}
+		want     Location
+	}{
+		{"a", nil, Location{Slot: slotHash(0), Offset: 0, Type: "t_uint128", Size: 16}},
+		{"b", nil, Location{Slot: slotHash(0), Offset: 16, Type: "t_uint64", Size: 8}},
+		{"owner", nil, Location{Slot: slotHash(1), Type: "t_address", Size: 20}},
+		{"balances", []interface{}{alice}, Location{Slot: balanceSlot, Type: "t_uint256", Size: 32}},
+		{"balances", []interface{}{alice.Hex()}, Location{Slot: balanceSlot, Type: "t_uint256", Size: 32}},
+		{"allowances", []interface{}{alice, bob}, Location{Slot: allowanceSlot, Type: "t_uint256", Size: 32}},
+		{"small", []interface{}{0}, Location{Slot: smallData, Offset: 0, Type: "t_uint16", Size: 2}},
+		{"small", []interface{}{17}, Location{Slot: addSlot(smallData, 1), Offset: 2, Type: "t_uint16", Size: 2}},
+		{"orders", []interface{}{uint64(2), "id"}, Location{Slot: addSlot(ordersData, 4), Type: "t_uint256", Size: 32}},
+		{"orders", []interface{}{big.NewInt(2), "amount"}, Location{Slot: addSlot(ordersData, 5), Offset: 20, Type: "t_uint96", Size: 12}},
+		{"named", []interface{}{"limit", "maker"}, Location{Slot: addSlot(namedSlot, 1), Type: "t_address", Size: 20}},
+		{"fixedArr", []interface{}{2}, Location{Slot: slotHash(9), Type: "t_uint256", Size: 32}},
+	}
+	for _, tt := range tests {
+		loc, err := layout.Locate(tt.variable, tt.path...)
+		if err != nil {
+			t.Errorf("%s%v: %v", tt.variable, tt.path, err)
+			continue
+		}
+		if *loc != tt.want {
+			t.Errorf("%s%v: location mismatch:\nhave %+v\nwant %+v", tt.variable, tt.path, *loc, tt.want)
+		}
+	}
+	invalid := []struct {
+		variable string
+		path     []interface{}
+	}{
+		{"missing", nil},
+		{"fixedArr", []interface{}{3}},
+		{"small", []interface{}{-1}},
+		{"small", []interface{}{"x"}},
+		{"owner", []interface{}{0}},
+		{"orders", []interface{}{0, "price"}},
+		{"balances", []interface{}{"not an address"}},
+		{"named", []interface{}{42}},
+	}
+	for _, tt := range invalid {
+		if _, err := layout.Locate(tt.variable, tt.path...); err == nil {
+			t.Errorf("%s%v: expected error", tt.variable, tt.path)
+		}
+	}
+}
+
+func TestLocationExtract(t *testing.T) {
+	t.Parallel()
+	// Slot 0 holds a = 0x11..11 (16 bytes) and b = 0x2222 (8 bytes) above it
+	word := common.HexToHash("0x0000000000000000000000000000222211111111111111111111111111111111")
+	a := Location{Offset: 0, Size: 16}
+	b := Location{Offset: 16, Size: 8}
+	if have := new(big.Int).SetBytes(a.Extract(word)); have.Cmp(new(big.Int).SetBytes(common.FromHex("11111111111111111111111111111111"))) != 0 {
+		t.Errorf("a mismatch: %x", have)
+	}
+	if have := new(big.Int).SetBytes(b.Extract(word)); have.Uint64() != 0x2222 {
+		t.Errorf("b mismatch: %x", have)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+	for _, input := range []string{
+		`{"storage": [{"label": "x", "slot": "0", "type": "t_missing"}], "types": {}}`,
+		`{"storage": [{"label": "x", "slot": "-1", "type": "t_uint256"}], "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "32"}}}`,
+		`{"storage": [], "types": {"t_uint256": {"encoding": "inplace", "label": "uint256", "numberOfBytes": "x"}}}`,
+		`{"storage": [], "types": {"t_m": {"encoding": "mapping", "key": "t_a", "value": "t_b", "label": "m", "numberOfBytes": "32"}}}`,
+		`not json`,
+	} {
+		if _, err := Parse([]byte(input)); err == nil {
+			t.Errorf("expected error for %s", input)
+		}
+	}
+}
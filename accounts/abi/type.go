@@ -48,17 +48,24 @@ const (
 
 // Type is the reflection of the supported argument type.
 type Type struct {
-	Elem *Type
-	Size int
-	T    byte // Our own type checking
+	Elem     *Type
+	Size     int
+	Decimals int  // Number of decimal places of fixed point types
+	T        byte // Our own type checking
 
 	stringKind string // holds the unparsed string for deriving signatures
 
+	// UserDefinedName is the name of the Solidity user-defined value type (e.g.
+	// MyToken.Price) this elementary type underlies, as reported by the
+	// internalType field of the ABI JSON. It is empty for all other types.
+	UserDefinedName string
+
 	// Tuple relative fields
-	TupleRawName  string       // Raw struct name defined in source code, may be empty.
-	TupleElems    []*Type      // Type information of all tuple fields
-	TupleRawNames []string     // Raw field name of all tuple fields
-	TupleType     reflect.Type // Underlying struct of the tuple
+	TupleRawName      string       // Raw struct name defined in source code, may be empty.
+	TupleInternalType string       // Raw internalType of the struct (e.g. struct Lib.Order), may be empty.
+	TupleElems        []*Type      // Type information of all tuple fields
+	TupleRawNames     []string     // Raw field name of all tuple fields
+	TupleType         reflect.Type // Underlying struct of the tuple
 }
 
 var (
@@ -126,12 +133,12 @@ func NewType(t string, internalType string, components []ArgumentMarshaling) (ty
 	var varSize int
 	if len(parsedType[3]) > 0 {
 		var err error
-		varSize, err = strconv.Atoi(parsedType[2])
+		varSize, err = strconv.Atoi(parsedType[3])
 		if err != nil {
 			return Type{}, fmt.Errorf("abi: error parsing variable size: %v", err)
 		}
 	} else {
-		if parsedType[0] == "uint" || parsedType[0] == "int" {
+		if parsedType[0] == "uint" || parsedType[0] == "int" || parsedType[0] == "fixed" || parsedType[0] == "ufixed" {
 			// this should fail because it means that there's something wrong with
 			// the abi type (the compiler should always format it to the size...always)
 			return Type{}, fmt.Errorf("unsupported arg type: %s", t)
@@ -145,6 +152,17 @@ func NewType(t string, internalType string, components []ArgumentMarshaling) (ty
 	case "uint":
 		typ.Size = varSize
 		typ.T = UintTy
+	case "fixed", "ufixed":
+		decimals, err := strconv.Atoi(parsedType[5])
+		if err != nil {
+			return Type{}, fmt.Errorf("unsupported arg type: %s", t)
+		}
+		if varSize < 8 || varSize > 256 || varSize%8 != 0 || decimals < 1 || decimals > 80 {
+			return Type{}, fmt.Errorf("unsupported arg type: %s", t)
+		}
+		typ.Size = varSize
+		typ.Decimals = decimals
+		typ.T = FixedPointTy
 	case "bool":
 		typ.T = BoolTy
 	case "address":
@@ -213,6 +231,7 @@ func NewType(t string, internalType string, components []ArgumentMarshaling) (ty
 			// Foo.Bar type definition is not allowed in golang,
 			// convert the format to FooBar
 			typ.TupleRawName = strings.ReplaceAll(internalType[len(structPrefix):], ".", "")
+			typ.TupleInternalType = internalType
 		}
 
 	case "function":
@@ -226,6 +245,11 @@ func NewType(t string, internalType string, components []ArgumentMarshaling) (ty
 			return Type{}, fmt.Errorf("unsupported arg type: %s", t)
 		}
 	}
+	// Struct, enum and contract internal types carry a keyword prefix, value
+	// types wrapping an elementary type are referenced by their bare name.
+	if typ.T != TupleTy && internalType != "" && internalType != t && !strings.Contains(internalType, " ") {
+		typ.UserDefinedName = internalType
+	}
 
 	return
 }
@@ -253,8 +277,10 @@ func (t Type) GetType() reflect.Type {
 		return reflect.ArrayOf(t.Size, reflect.TypeFor[byte]())
 	case BytesTy:
 		return reflect.TypeFor[[]byte]()
-	case HashTy, FixedPointTy: // currently not used
+	case HashTy: // currently not used
 		return reflect.TypeFor[[32]byte]()
+	case FixedPointTy:
+		return reflect.TypeFor[FixedPoint]()
 	case FunctionTy:
 		return reflect.TypeFor[[24]byte]()
 	default:
@@ -267,9 +293,15 @@ func (t Type) String() (out string) {
 	return t.stringKind
 }
 
-func (t Type) pack(v reflect.Value) ([]byte, error) {
+func (t Type) pack(v reflect.Value, opts Options) ([]byte, error) {
 	// dereference pointer first if it's a pointer
 	v = indirect(v)
+
+	// apply any application specific conversion
+	v, err := opts.Converters.convert(t, v)
+	if err != nil {
+		return nil, err
+	}
 	// if err := typeCheck(t, v); err != nil {
 	// 	return nil, err
 	// }
@@ -295,7 +327,7 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 		}
 		var tail []byte
 		for i := 0; i < v.Len(); i++ {
-			val, err := t.Elem.pack(v.Index(i))
+			val, err := t.Elem.pack(v.Index(i), opts)
 			if err != nil {
 				return nil, err
 			}
@@ -346,7 +378,7 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 			if !field.IsValid() {
 				return nil, fmt.Errorf("field %s for tuple not found in the given struct", t.TupleRawNames[i])
 			}
-			val, err := elem.pack(field)
+			val, err := elem.pack(field, opts)
 			if err != nil {
 				return nil, err
 			}
@@ -365,7 +397,7 @@ func (t Type) pack(v reflect.Value) ([]byte, error) {
 		return append(ret, tail...), nil
 
 	default:
-		return packElement(t, v)
+		return packElement(t, v, opts)
 	}
 }
 
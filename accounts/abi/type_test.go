@@ -19,6 +19,7 @@ package abi
 import (
 	"math/big"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -89,13 +90,10 @@ func TestTypeRegexp(t *testing.T) {
 		{"address", nil, Type{Size: 20, T: AddressTy, stringKind: "address"}},
 		{"address[]", nil, Type{T: SliceTy, Elem: &Type{Size: 20, T: AddressTy, stringKind: "address"}, stringKind: "address[]"}},
 		{"address[2]", nil, Type{T: ArrayTy, Size: 2, Elem: &Type{Size: 20, T: AddressTy, stringKind: "address"}, stringKind: "address[2]"}},
-		// TODO when fixed types are implemented properly
-		// {"fixed", nil, Type{}},
-		// {"fixed128x128", nil, Type{}},
-		// {"fixed[]", nil, Type{}},
-		// {"fixed[2]", nil, Type{}},
-		// {"fixed128x128[]", nil, Type{}},
-		// {"fixed128x128[2]", nil, Type{}},
+		{"fixed128x18", nil, Type{Size: 128, Decimals: 18, T: FixedPointTy, stringKind: "fixed128x18"}},
+		{"ufixed8x1", nil, Type{Size: 8, Decimals: 1, T: FixedPointTy, stringKind: "ufixed8x1"}},
+		{"fixed128x18[]", nil, Type{T: SliceTy, Elem: &Type{Size: 128, Decimals: 18, T: FixedPointTy, stringKind: "fixed128x18"}, stringKind: "fixed128x18[]"}},
+		{"ufixed256x80[2]", nil, Type{T: ArrayTy, Size: 2, Elem: &Type{Size: 256, Decimals: 80, T: FixedPointTy, stringKind: "ufixed256x80"}, stringKind: "ufixed256x80[2]"}},
 		{"tuple", []ArgumentMarshaling{{Name: "a", Type: "int64"}}, Type{T: TupleTy, TupleType: reflect.TypeOf(struct {
 			A int64 `json:"a"`
 		}{}), stringKind: "(int64)",
@@ -318,10 +316,11 @@ func TestInternalType(t *testing.T) {
 		TupleType: reflect.TypeOf(struct {
 			A int64 `json:"a"`
 		}{}),
-		stringKind:    "(int64)",
-		TupleRawName:  "ab[]",
-		TupleElems:    []*Type{{T: IntTy, Size: 64, stringKind: "int64"}},
-		TupleRawNames: []string{"a"},
+		stringKind:        "(int64)",
+		TupleRawName:      "ab[]",
+		TupleInternalType: internalType,
+		TupleElems:        []*Type{{T: IntTy, Size: 64, stringKind: "int64"}},
+		TupleRawNames:     []string{"a"},
 	}
 
 	blob := "tuple"
@@ -378,3 +377,46 @@ func TestNewFixedBytesOver32(t *testing.T) {
 		t.Errorf("fixed bytes with size over 32 is not spec'd")
 	}
 }
+
+func TestNewFixedPointInvalid(t *testing.T) {
+	t.Parallel()
+	for _, typ := range []string{"fixed", "ufixed", "fixed128", "fixed7x18", "fixed264x18", "fixed128x0", "fixed128x81"} {
+		if _, err := NewType(typ, "", nil); err == nil {
+			t.Errorf("type %q: expected error", typ)
+		}
+	}
+}
+
+func TestUserDefinedValueType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		typ, internalType, want, wantElem string
+	}{
+		{"uint128", "MyToken.Price", "MyToken.Price", ""},
+		{"uint128[]", "MyToken.Price[]", "", "MyToken.Price"},
+		{"uint256", "uint256", "", ""},
+		{"address", "address payable", "", ""},
+		{"address", "contract IERC20", "", ""},
+		{"uint8", "enum Side", "", ""},
+	}
+	for _, tt := range tests {
+		typ, err := NewType(tt.typ, tt.internalType, nil)
+		if err != nil {
+			t.Fatalf("type %q: %v", tt.typ, err)
+		}
+		if typ.UserDefinedName != tt.want {
+			t.Errorf("type %q (%s): user defined name mismatch: have %q, want %q", tt.typ, tt.internalType, typ.UserDefinedName, tt.want)
+		}
+		if typ.Elem != nil && typ.Elem.UserDefinedName != tt.wantElem {
+			t.Errorf("type %q (%s): element user defined name mismatch: have %q, want %q", tt.typ, tt.internalType, typ.Elem.UserDefinedName, tt.wantElem)
+		}
+	}
+	// The alias must survive parsing the ABI JSON
+	abi, err := JSON(strings.NewReader(`[{"type":"function","name":"setPrice","inputs":[{"name":"p","type":"uint128","internalType":"MyToken.Price"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := abi.Methods["setPrice"].Inputs[0].Type.UserDefinedName; name != "MyToken.Price" {
+		t.Errorf("wrong user defined name from JSON: %q", name)
+	}
+}
@@ -17,6 +17,7 @@
 package abi
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -149,13 +150,16 @@ func ReadFixedBytes(t Type, word []byte) (interface{}, error) {
 }
 
 // forEachUnpack iteratively unpack elements.
-func forEachUnpack(t Type, output []byte, start, size int) (interface{}, error) {
+func forEachUnpack(t Type, output []byte, start, size int, opts Options, budget *decodeBudget) (interface{}, error) {
 	if size < 0 {
 		return nil, fmt.Errorf("cannot marshal input to array, size is negative (%d)", size)
 	}
-	if start+32*size > len(output) {
+	if size > (len(output)-start)/32 {
 		return nil, fmt.Errorf("abi: cannot marshal into go array: offset %d would go over slice boundary (len=%d)", len(output), start+32*size)
 	}
+	if err := budget.consumeElements(size); err != nil {
+		return nil, err
+	}
 
 	// this value will become our slice or our array, depending on the type
 	var refSlice reflect.Value
@@ -176,7 +180,7 @@ func forEachUnpack(t Type, output []byte, start, size int) (interface{}, error)
 	elemSize := getTypeSize(*t.Elem)
 
 	for i, j := start, 0; j < size; i, j = i+elemSize, j+1 {
-		inter, err := toGoType(i, *t.Elem, output)
+		inter, err := toGoType(i, *t.Elem, output, opts, budget)
 		if err != nil {
 			return nil, err
 		}
@@ -189,11 +193,11 @@ func forEachUnpack(t Type, output []byte, start, size int) (interface{}, error)
 	return refSlice.Interface(), nil
 }
 
-func forTupleUnpack(t Type, output []byte) (interface{}, error) {
+func forTupleUnpack(t Type, output []byte, opts Options, budget *decodeBudget) (interface{}, error) {
 	retval := reflect.New(t.GetType()).Elem()
 	virtualArgs := 0
 	for index, elem := range t.TupleElems {
-		marshalledValue, err := toGoType((index+virtualArgs)*32, *elem, output)
+		marshalledValue, err := toGoType((index+virtualArgs)*32, *elem, output, opts, budget)
 		if err != nil {
 			return nil, err
 		}
@@ -220,8 +224,9 @@ func forTupleUnpack(t Type, output []byte) (interface{}, error) {
 }
 
 // toGoType parses the output bytes and recursively assigns the value of these bytes
-// into a go type with accordance with the ABI spec.
-func toGoType(index int, t Type, output []byte) (interface{}, error) {
+// into a go type with accordance with the ABI spec. The resources consumed are
+// accounted for in the budget of the unpack operation, which may be nil.
+func toGoType(index int, t Type, output []byte, opts Options, budget *decodeBudget) (interface{}, error) {
 	if index+32 > len(output) {
 		return nil, fmt.Errorf("abi: cannot marshal in to go type: length insufficient %d require %d", len(output), index+32)
 	}
@@ -240,6 +245,21 @@ func toGoType(index int, t Type, output []byte) (interface{}, error) {
 		}
 	} else {
 		returnOutput = output[index : index+32]
+		if opts.Lenient {
+			returnOutput = cleanWord(t, returnOutput)
+		}
+	}
+
+	switch t.T {
+	case TupleTy, SliceTy, ArrayTy:
+		if err := budget.enter(); err != nil {
+			return nil, err
+		}
+		defer budget.leave()
+	case StringTy, BytesTy:
+		if err := budget.consumeBytes(length); err != nil {
+			return nil, err
+		}
 	}
 
 	switch t.T {
@@ -249,24 +269,26 @@ func toGoType(index int, t Type, output []byte) (interface{}, error) {
 			if err != nil {
 				return nil, err
 			}
-			return forTupleUnpack(t, output[begin:])
+			return forTupleUnpack(t, output[begin:], opts, budget)
 		}
-		return forTupleUnpack(t, output[index:])
+		return forTupleUnpack(t, output[index:], opts, budget)
 	case SliceTy:
-		return forEachUnpack(t, output[begin:], 0, length)
+		return forEachUnpack(t, output[begin:], 0, length, opts, budget)
 	case ArrayTy:
 		if isDynamicType(*t.Elem) {
 			offset := binary.BigEndian.Uint64(returnOutput[len(returnOutput)-8:])
 			if offset > uint64(len(output)) {
 				return nil, fmt.Errorf("abi: toGoType offset greater than output length: offset: %d, len(output): %d", offset, len(output))
 			}
-			return forEachUnpack(t, output[offset:], 0, t.Size)
+			return forEachUnpack(t, output[offset:], 0, t.Size, opts, budget)
 		}
-		return forEachUnpack(t, output[index:], 0, t.Size)
+		return forEachUnpack(t, output[index:], 0, t.Size, opts, budget)
 	case StringTy: // variable arrays are written at the end of the return bytes
 		return string(output[begin : begin+length]), nil
 	case IntTy, UintTy:
 		return ReadInteger(t, returnOutput)
+	case FixedPointTy:
+		return readFixedPoint(t, returnOutput)
 	case BoolTy:
 		return readBool(returnOutput)
 	case AddressTy:
@@ -284,6 +306,43 @@ func toGoType(index int, t Type, output []byte) (interface{}, error) {
 	}
 }
 
+// cleanWord returns a copy of a 32 byte word with the bits not belonging to an
+// elementary value of type t normalized, as expected by the canonical decoder.
+func cleanWord(t Type, word []byte) []byte {
+	var signed bool
+	switch t.T {
+	case IntTy:
+		signed = true
+	case UintTy:
+	case FixedPointTy:
+		signed = !t.isUnsignedFixed()
+	case BoolTy:
+		clean := make([]byte, 32)
+		if !bytes.Equal(word, clean) {
+			clean[31] = 1
+		}
+		return clean
+	case FunctionTy:
+		clean := make([]byte, 32)
+		copy(clean, word[:24])
+		return clean
+	default:
+		return word
+	}
+	if t.Size >= 256 {
+		return word
+	}
+	clean := make([]byte, 32)
+	start := 32 - t.Size/8
+	copy(clean[start:], word[start:])
+	if signed && word[start]&0x80 != 0 {
+		for i := 0; i < start; i++ {
+			clean[i] = 0xff
+		}
+	}
+	return clean
+}
+
 // lengthPrefixPointsTo interprets a 32 byte slice as an offset and then determines which indices to look to decode the type.
 func lengthPrefixPointsTo(index int, output []byte) (start int, length int, err error) {
 	bigOffsetEnd := new(big.Int).SetBytes(output[index : index+32])
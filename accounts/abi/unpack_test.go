@@ -23,6 +23,7 @@ import (
 	"math"
 	"math/big"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -1162,3 +1163,102 @@ func TestPackAndUnpackIncompatibleNumber(t *testing.T) {
 		}
 	}
 }
+
+func TestUnpackAt(t *testing.T) {
+	t.Parallel()
+
+	abi, err := JSON(strings.NewReader(`[{"type":"function","name":"f","outputs":[
+		{"name":"a","type":"uint256"},
+		{"name":"b","type":"uint8[3]"},
+		{"name":"c","type":"string"},
+		{"name":"d","type":"tuple","components":[{"name":"x","type":"uint64"},{"name":"y","type":"bool"}]},
+		{"name":"e","type":"bytes"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := abi.Methods["f"].Outputs
+	type tuple struct {
+		X uint64
+		Y bool
+	}
+	data, err := outputs.Pack(big.NewInt(7), [3]uint8{1, 2, 3}, "hello", tuple{9, true}, []byte{0xca, 0xfe})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := outputs.Unpack(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range outputs {
+		have, err := outputs.UnpackAt(data, i)
+		if err != nil {
+			t.Fatalf("output %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(have, want[i]) {
+			t.Errorf("output %d: have %v, want %v", i, have, want[i])
+		}
+	}
+	if _, err := outputs.UnpackAt(data, len(outputs)); err == nil {
+		t.Error("expected out of range error")
+	}
+	// Corrupting a later output must not affect earlier ones
+	corrupt := slices.Clone(data)
+	copy(corrupt[len(corrupt)-64:], bytes.Repeat([]byte{0xff}, 32))
+	if _, err := outputs.Unpack(corrupt); err == nil {
+		t.Fatal("expected full unpack to fail")
+	}
+	if have, err := outputs.UnpackAt(corrupt, 2); err != nil || have != "hello" {
+		t.Errorf("unexpected partial unpack result: %v, %v", have, err)
+	}
+}
+
+func TestUnpackLenient(t *testing.T) {
+	t.Parallel()
+
+	abi, err := JSON(strings.NewReader(`[{"type":"function","name":"f","outputs":[
+		{"name":"a","type":"uint8"},
+		{"name":"b","type":"int16"},
+		{"name":"c","type":"bool"},
+		{"name":"d","type":"bytes"}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Dirty high-order bits, and a bytes tail at a non-minimal offset, missing
+	// the padding of its last word
+	data := common.FromHex("" +
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff07" + // uint8 7
+		"000000000000000000000000000000000000000000000000000000000000fffe" + // int16 -2
+		"0000000000000000000000000000000000000000000000000000000000000002" + // bool true
+		"00000000000000000000000000000000000000000000000000000000000000a0" + // offset of d
+		"0000000000000000000000000000000000000000000000000000000000000000" + // garbage gap
+		"0000000000000000000000000000000000000000000000000000000000000002" + // length of d
+		"cafe")
+	if _, err := abi.Unpack("f", data); err == nil {
+		t.Fatal("expected strict unpack to fail")
+	}
+	abi.Options.Lenient = true
+	values, err := abi.Unpack("f", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []interface{}{uint8(7), int16(-2), true, []byte{0xca, 0xfe}}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("wrong lenient unpack result: have %v, want %v", values, want)
+	}
+	var out struct {
+		A uint8
+		B int16
+		C bool
+		D []byte
+	}
+	if err := abi.UnpackIntoInterface(&out, "f", data); err != nil {
+		t.Fatal(err)
+	}
+	if out.A != 7 || out.B != -2 || !out.C {
+		t.Errorf("wrong lenient unpack into struct: %+v", out)
+	}
+	m := make(map[string]interface{})
+	if err := abi.UnpackIntoMap(m, "f", data); err != nil || m["a"] != uint8(7) {
+		t.Errorf("wrong lenient unpack into map: %v, %v", m, err)
+	}
+}
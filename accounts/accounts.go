@@ -18,6 +18,7 @@
 package accounts
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -173,6 +174,33 @@ type Backend interface {
 	Subscribe(sink chan<- WalletEvent) event.Subscription
 }
 
+// ThresholdSigner is a coordinator of a threshold signature scheme such as GG20
+// or CMP, where the keys of the accounts are split into shares held by separate
+// parties, a quorum of which collaborates to sign without ever assembling a key.
+//
+// Signing sessions might take a long time to complete, e.g. if parties approve
+// requests manually, so they are asynchronous. The threshold package adapts
+// signers into wallets usable with the account manager.
+type ThresholdSigner interface {
+	// URL retrieves the canonical path under which the coordinator is reachable.
+	URL() URL
+
+	// Accounts retrieves the list of accounts whose keys are shared by the parties
+	// of the coordinator.
+	Accounts() []Account
+
+	// Sign starts a session signing the hash with the key of the account. If the
+	// session can't be started, an error is returned. Otherwise the callback is
+	// invoked exactly once when the session completes, possibly from another
+	// goroutine and before Sign returns. Cancelling the context aborts the session.
+	Sign(ctx context.Context, account Account, hash []byte, callback SignatureCallback) error
+}
+
+// SignatureCallback is invoked with the outcome of an asynchronous signing
+// session: either the signature in the [R || S || V] format, or the error the
+// session failed with.
+type SignatureCallback func(sig []byte, err error)
+
 // TextHash is a helper function that calculates a hash for the given message that can be
 // safely used to calculate a signature from.
 //
@@ -0,0 +1,233 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	var (
+		alice = common.HexToAddress("0x01")
+		bob   = common.HexToAddress("0x02")
+		start = time.Now()
+	)
+	for i, entry := range []Entry{
+		{Account: alice, Kind: KindTransaction, Origin: "ipc", Approved: true},
+		{Account: bob, Kind: KindText, Origin: "http 127.0.0.1:1234", Error: "authentication needed"},
+		{Account: alice, Kind: KindData, MimeType: accounts.MimetypeTypedData, Approved: true},
+	} {
+		entry, err := log.Append(entry)
+		if err != nil {
+			t.Fatalf("failed to append entry %d: %v", i, err)
+		}
+		if entry.Seq != uint64(i) || entry.Hash != log.Head() {
+			t.Fatalf("entry %d: chain mismatch: seq %d, hash %x, head %x", i, entry.Seq, entry.Hash, log.Head())
+		}
+	}
+	head := log.Head()
+	if err := log.Close(); err != nil {
+		t.Fatalf("failed to close log: %v", err)
+	}
+	// Reopening the log must restore the entries
+	if log, err = Open(path); err != nil {
+		t.Fatalf("failed to reopen log: %v", err)
+	}
+	defer log.Close()
+	if log.Head() != head {
+		t.Fatalf("head mismatch after reopening: have %x, want %x", log.Head(), head)
+	}
+	if err := log.Verify(); err != nil {
+		t.Fatalf("failed to verify log: %v", err)
+	}
+	// Queries must select the matching entries
+	tests := []struct {
+		filter Filter
+		want   []uint64
+	}{
+		{Filter{}, []uint64{0, 1, 2}},
+		{Filter{Account: &alice}, []uint64{0, 2}},
+		{Filter{Kind: KindText}, []uint64{1}},
+		{Filter{Origin: "ipc"}, []uint64{0}},
+		{Filter{Since: start.Add(-time.Minute)}, []uint64{0, 1, 2}},
+		{Filter{Until: start.Add(-time.Minute)}, nil},
+	}
+	for i, tt := range tests {
+		var have []uint64
+		for _, entry := range log.Query(tt.filter) {
+			have = append(have, entry.Seq)
+		}
+		if len(have) != len(tt.want) {
+			t.Errorf("test %d: entry mismatch: have %v, want %v", i, have, tt.want)
+			continue
+		}
+		for j := range have {
+			if have[j] != tt.want[j] {
+				t.Errorf("test %d: entry mismatch: have %v, want %v", i, have, tt.want)
+				break
+			}
+		}
+	}
+	// Modifying an entry must be detected
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := bytes.Replace(blob, []byte(`"approved":false`), []byte(`"approved":true`), 1)
+	if err := os.WriteFile(path, tampered, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Verify(); !errors.Is(err, ErrTampered) {
+		t.Errorf("verifying modified log: have %v, want %v", err, ErrTampered)
+	}
+	if _, err := Open(path); !errors.Is(err, ErrTampered) {
+		t.Errorf("opening modified log: have %v, want %v", err, ErrTampered)
+	}
+	// Removing an entry must be detected
+	lines := bytes.SplitAfter(blob, []byte("\n"))
+	if err := os.WriteFile(path, bytes.Join([][]byte{lines[0], lines[2]}, nil), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); !errors.Is(err, ErrTampered) {
+		t.Errorf("opening log with removed entry: have %v, want %v", err, ErrTampered)
+	}
+}
+
+func TestBackend(t *testing.T) {
+	log, err := Open(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer log.Close()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.NewAccount("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	am := accounts.NewManager(nil, NewBackend(ks, log, "node"))
+	defer am.Close()
+
+	// The wrapped keystore must still be retrievable by its type
+	if backends := am.Backends(keystore.KeyStoreType); len(backends) != 1 || backends[0] != ks {
+		t.Fatalf("keystore not indexed by type: %v", backends)
+	}
+	wallet, err := am.Find(account)
+	if err != nil {
+		t.Fatalf("failed to find wallet: %v", err)
+	}
+	// Signing with a locked account must be recorded as not approved
+	if _, err := wallet.SignText(account, []byte("hello")); err == nil {
+		t.Fatalf("signed with locked account")
+	}
+	if err := ks.Unlock(account, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	tx := types.NewTx(&types.LegacyTx{To: &account.Address, Gas: 21000, GasPrice: big.NewInt(1)})
+	if _, err := WithOrigin(wallet, "ipc").SignTx(account, tx, big.NewInt(1)); err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	entries := log.Query(Filter{Account: &account.Address})
+	if len(entries) != 2 {
+		t.Fatalf("entry count mismatch: have %d, want 2", len(entries))
+	}
+	if e := entries[0]; e.Kind != KindText || e.Approved || e.Error == "" || e.Origin != "node" {
+		t.Errorf("text entry mismatch: %+v", e)
+	}
+	want := types.LatestSignerForChainID(big.NewInt(1)).Hash(tx)
+	if e := entries[1]; e.Kind != KindTransaction || !e.Approved || e.Digest != want || e.Origin != "ipc" || e.Wallet != wallet.URL().String() {
+		t.Errorf("transaction entry mismatch: %+v", e)
+	}
+}
+
+// typedWallet is a wallet signing typed data by its members.
+type typedWallet struct {
+	accounts.Wallet
+	signed int
+}
+
+func (w *typedWallet) SignTypedData(account accounts.Account, typedData apitypes.TypedData) ([]byte, error) {
+	w.signed++
+	return make([]byte, 65), nil
+}
+
+func TestTypedDataWallet(t *testing.T) {
+	log, err := Open(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	defer log.Close()
+
+	ks := keystore.NewKeyStore(t.TempDir(), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.NewAccount("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Wallets without typed data signing must not pretend to support it
+	if _, ok := wrap(ks.Wallets()[0], log, "node").(typedDataSigner); ok {
+		t.Fatal("typed data signing exposed for keystore wallet")
+	}
+	inner := &typedWallet{Wallet: ks.Wallets()[0]}
+	signer, ok := WithOrigin(wrap(inner, log, "node"), "ipc").(typedDataSigner)
+	if !ok {
+		t.Fatal("typed data signing hidden by audit wallet")
+	}
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {{Name: "name", Type: "string"}},
+			"Mail":         {{Name: "contents", Type: "string"}},
+		},
+		PrimaryType: "Mail",
+		Domain:      apitypes.TypedDataDomain{Name: "test"},
+		Message:     apitypes.TypedDataMessage{"contents": "hello"},
+	}
+	if _, err := signer.SignTypedData(account, typedData); err != nil {
+		t.Fatalf("failed to sign typed data: %v", err)
+	}
+	if inner.signed != 1 {
+		t.Fatalf("typed data not forwarded to wallet")
+	}
+	sighash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := log.Query(Filter{Account: &account.Address})
+	if len(entries) != 1 {
+		t.Fatalf("entry count mismatch: have %d, want 1", len(entries))
+	}
+	if e := entries[0]; e.Kind != KindData || e.MimeType != accounts.MimetypeTypedData || e.Digest != common.BytesToHash(sighash) || e.Origin != "ipc" || !e.Approved {
+		t.Errorf("typed data entry mismatch: %+v", e)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package audit
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// Backend is an accounts.Backend wrapping the wallets of another backend, so that
+// all their signing operations are recorded in a log.
+type Backend struct {
+	backend accounts.Backend
+	log     *Log
+	origin  string
+}
+
+// NewBackend creates a backend recording the signing operations of the wallets
+// of the given backend into the log. Each backend can be given its own log, and
+// the origin is recorded for the requests not assigned one by WithOrigin.
+func NewBackend(backend accounts.Backend, log *Log, origin string) *Backend {
+	return &Backend{backend: backend, log: log, origin: origin}
+}
+
+// Unwrap returns the backend whose wallets are recorded, so that the account
+// manager can retrieve it by its type.
+func (b *Backend) Unwrap() accounts.Backend {
+	return b.backend
+}
+
+// Wallets implements accounts.Backend, returning the wrapped wallets of the
+// backend.
+func (b *Backend) Wallets() []accounts.Wallet {
+	inner := b.backend.Wallets()
+
+	wallets := make([]accounts.Wallet, len(inner))
+	for i, w := range inner {
+		wallets[i] = wrap(w, b.log, b.origin)
+	}
+	return wallets
+}
+
+// Subscribe implements accounts.Backend, forwarding the wallet events of the
+// backend with the wallets wrapped.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		events := make(chan accounts.WalletEvent)
+		sub := b.backend.Subscribe(events)
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				ev.Wallet = wrap(ev.Wallet, b.log, b.origin)
+				select {
+				case sink <- ev:
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
+
+// WithOrigin returns a view of the wallet recording the given request origin for
+// its signing operations, e.g. the address of the RPC client. Wallets not backed
+// by a log are returned as is.
+func WithOrigin(w accounts.Wallet, origin string) accounts.Wallet {
+	switch audited := w.(type) {
+	case *wallet:
+		return wrap(audited.Wallet, audited.log, origin)
+	case *typedDataWallet:
+		return wrap(audited.Wallet, audited.log, origin)
+	}
+	return w
+}
+
+// typedDataSigner is implemented by wallets able to sign EIP-712 typed data by
+// its members, such as hardware wallets displaying them to the user.
+type typedDataSigner interface {
+	SignTypedData(account accounts.Account, typedData apitypes.TypedData) ([]byte, error)
+}
+
+// wrap returns a wallet recording the signing operations of w into the log. The
+// signing of typed data is only exposed if w supports it, so that callers can
+// keep checking for it.
+func wrap(w accounts.Wallet, log *Log, origin string) accounts.Wallet {
+	audited := &wallet{Wallet: w, log: log, origin: origin}
+	if signer, ok := w.(typedDataSigner); ok {
+		return &typedDataWallet{wallet: audited, signer: signer}
+	}
+	return audited
+}
+
+// wallet is an accounts.Wallet recording its signing operations into a log.
+type wallet struct {
+	accounts.Wallet
+	log    *Log
+	origin string
+}
+
+// record appends a signing operation with its outcome to the log. If the entry
+// can't be recorded, the signing error is replaced, so no signature is handed
+// out without an entry.
+func (w *wallet) record(account accounts.Account, kind, mimeType string, digest common.Hash, err error) error {
+	entry := Entry{
+		Wallet:   w.URL().String(),
+		Account:  account.Address,
+		Kind:     kind,
+		MimeType: mimeType,
+		Digest:   digest,
+		Origin:   w.origin,
+		Approved: err == nil,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if _, logErr := w.log.Append(entry); logErr != nil {
+		return fmt.Errorf("failed to record signing operation: %w", logErr)
+	}
+	return err
+}
+
+// SignData implements accounts.Wallet, recording the operation.
+func (w *wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	sig, err := w.Wallet.SignData(account, mimeType, data)
+	if err = w.record(account, KindData, mimeType, crypto.Keccak256Hash(data), err); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, recording the operation.
+func (w *wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	sig, err := w.Wallet.SignDataWithPassphrase(account, passphrase, mimeType, data)
+	if err = w.record(account, KindData, mimeType, crypto.Keccak256Hash(data), err); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignText implements accounts.Wallet, recording the operation.
+func (w *wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	sig, err := w.Wallet.SignText(account, text)
+	if err = w.record(account, KindText, "", common.BytesToHash(accounts.TextHash(text)), err); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, recording the operation.
+func (w *wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	sig, err := w.Wallet.SignTextWithPassphrase(account, passphrase, text)
+	if err = w.record(account, KindText, "", common.BytesToHash(accounts.TextHash(text)), err); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignTx implements accounts.Wallet, recording the operation.
+func (w *wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := w.Wallet.SignTx(account, tx, chainID)
+	if err = w.record(account, KindTransaction, "", txDigest(tx, chainID), err); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, recording the operation.
+func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signed, err := w.Wallet.SignTxWithPassphrase(account, passphrase, tx, chainID)
+	if err = w.record(account, KindTransaction, "", txDigest(tx, chainID), err); err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// txDigest returns the hash of the transaction signed for the given chain.
+func txDigest(tx *types.Transaction, chainID *big.Int) common.Hash {
+	return types.LatestSignerForChainID(chainID).Hash(tx)
+}
+
+// typedDataWallet is a wallet recording its signing operations, which signs typed
+// data by its members like the wallet it wraps.
+type typedDataWallet struct {
+	*wallet
+	signer typedDataSigner
+}
+
+// SignTypedData signs EIP-712 typed data with the wrapped wallet, recording the
+// operation with the hash of the typed data as the digest.
+func (w *typedDataWallet) SignTypedData(account accounts.Account, typedData apitypes.TypedData) ([]byte, error) {
+	var digest common.Hash
+	if sighash, _, err := apitypes.TypedDataAndHash(typedData); err == nil {
+		digest = common.BytesToHash(sighash)
+	}
+	sig, err := w.signer.SignTypedData(account, typedData)
+	if err = w.record(account, KindData, accounts.MimetypeTypedData, digest, err); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
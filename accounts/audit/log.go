@@ -0,0 +1,243 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package audit implements an append-only log of signing operations, and an
+// accounts.Backend wrapper recording every signing request of its wallets.
+//
+// Every entry of the log commits to the hash of the previous one, so modifying,
+// reordering or removing entries breaks the chain. Removing entries from the end
+// can only be detected by comparing the head hash against a copy kept elsewhere.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Kinds of the digests signed by the operations in the log.
+const (
+	KindTransaction = "transaction" // Signing hash of a transaction
+	KindText        = "text"        // EIP-191 hash of a text message
+	KindData        = "data"        // Keccak256 hash of arbitrary data
+)
+
+// ErrTampered is returned if the entries of a log don't form an intact chain.
+var ErrTampered = errors.New("audit log tampered")
+
+// Entry is a single signing operation in the log.
+type Entry struct {
+	Seq      uint64         `json:"seq"`                // Position of the entry in the log, starting at 0
+	Time     time.Time      `json:"time"`               // Time the signing operation completed
+	Wallet   string         `json:"wallet"`             // URL of the wallet requested to sign
+	Account  common.Address `json:"account"`            // Account requested to sign
+	Kind     string         `json:"kind"`               // Kind of the signed digest
+	MimeType string         `json:"mimeType,omitempty"` // Content type of signed data, if requested with one
+	Digest   common.Hash    `json:"digest"`             // Digest requested to be signed
+	Origin   string         `json:"origin,omitempty"`   // Origin of the request, e.g. the address of an RPC client
+	Approved bool           `json:"approved"`           // Whether a signature was produced
+	Error    string         `json:"error,omitempty"`    // Reason of the failure if not approved
+
+	Prev common.Hash `json:"prev"` // Hash of the previous entry, zero for the first one
+	Hash common.Hash `json:"hash"` // Hash of this entry, including the previous hash
+}
+
+// hash computes the hash of the entry, covering all fields except the hash.
+func (e Entry) hash() common.Hash {
+	e.Hash = common.Hash{}
+	blob, _ := json.Marshal(e)
+	return crypto.Keccak256Hash(blob)
+}
+
+// Filter selects entries of the log. Zero fields match any entry.
+type Filter struct {
+	Account *common.Address // Account requested to sign
+	Kind    string          // Kind of the signed digest
+	Origin  string          // Origin of the request
+	Since   time.Time       // Earliest time of the operation (inclusive)
+	Until   time.Time       // Latest time of the operation (exclusive)
+}
+
+// matches returns whether the entry is selected by the filter.
+func (f *Filter) matches(e *Entry) bool {
+	switch {
+	case f.Account != nil && *f.Account != e.Account:
+		return false
+	case f.Kind != "" && f.Kind != e.Kind:
+		return false
+	case f.Origin != "" && f.Origin != e.Origin:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case !f.Until.IsZero() && !e.Time.Before(f.Until):
+		return false
+	}
+	return true
+}
+
+// Log is an append-only log of signing operations, stored in a file as one JSON
+// encoded entry per line.
+type Log struct {
+	path    string
+	file    *os.File
+	entries []Entry // All entries of the log, in order
+
+	lock sync.RWMutex
+}
+
+// Open opens the log at the given path, creating it if it doesn't exist. The
+// existing entries are verified, failing with ErrTampered if they don't form an
+// intact chain.
+func Open(path string) (*Log, error) {
+	entries, err := readEntries(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Log{path: path, file: file, entries: entries}, nil
+}
+
+// readEntries reads and verifies all entries of the log file.
+func readEntries(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		entries []Entry
+		scanner = bufio.NewScanner(file)
+		prev    common.Hash
+	)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("%w: entry %d: %v", ErrTampered, len(entries), err)
+		}
+		if entry.Seq != uint64(len(entries)) || entry.Prev != prev || entry.Hash != entry.hash() {
+			return nil, fmt.Errorf("%w: entry %d", ErrTampered, len(entries))
+		}
+		entries = append(entries, entry)
+		prev = entry.Hash
+	}
+	return entries, scanner.Err()
+}
+
+// Append adds an operation to the end of the log, filling in its position, its
+// time if unset, and the hashes chaining it to the previous entry. The entry is
+// synced to disk before returning.
+func (l *Log) Append(entry Entry) (Entry, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return Entry{}, os.ErrClosed
+	}
+	entry.Seq = uint64(len(l.entries))
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	entry.Time = entry.Time.UTC().Round(0)
+	if len(l.entries) > 0 {
+		entry.Prev = l.entries[len(l.entries)-1].Hash
+	} else {
+		entry.Prev = common.Hash{}
+	}
+	entry.Hash = entry.hash()
+
+	blob, err := json.Marshal(entry)
+	if err != nil {
+		return Entry{}, err
+	}
+	if _, err := l.file.Write(append(blob, '\n')); err != nil {
+		return Entry{}, err
+	}
+	if err := l.file.Sync(); err != nil {
+		return Entry{}, err
+	}
+	l.entries = append(l.entries, entry)
+	return entry, nil
+}
+
+// Query returns the entries of the log selected by the filter, in order.
+func (l *Log) Query(filter Filter) []Entry {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	var entries []Entry
+	for i := range l.entries {
+		if filter.matches(&l.entries[i]) {
+			entries = append(entries, l.entries[i])
+		}
+	}
+	return entries
+}
+
+// Head returns the hash of the last entry of the log, which commits to all the
+// entries. Keeping a copy of it elsewhere allows detecting a truncation.
+func (l *Log) Head() common.Hash {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if len(l.entries) == 0 {
+		return common.Hash{}
+	}
+	return l.entries[len(l.entries)-1].Hash
+}
+
+// Verify rereads the log file, checking that the entries still form an intact
+// chain and match the ones appended since it was opened.
+func (l *Log) Verify() error {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	entries, err := readEntries(l.path)
+	if err != nil {
+		return err
+	}
+	if len(entries) != len(l.entries) {
+		return fmt.Errorf("%w: have %d entries, want %d", ErrTampered, len(entries), len(l.entries))
+	}
+	if len(entries) > 0 && entries[len(entries)-1].Hash != l.entries[len(l.entries)-1].Hash {
+		return fmt.Errorf("%w: head mismatch", ErrTampered)
+	}
+	return nil
+}
+
+// Close closes the log file. Appending to a closed log fails.
+func (l *Log) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
@@ -0,0 +1,366 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bls implements storage of BLS12-381 keys in EIP-2335 keystores and
+// signing with them, as used by consensus layer validators.
+//
+// See https://eips.ethereum.org/EIPS/eip-2335 for the keystore format.
+package bls
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+	blsu "github.com/protolambda/bls12-381-util"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// version is the version of the EIP-2335 keystore format.
+	version = 4
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters recommended
+	// by EIP-2335, using 256MB memory.
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are scrypt parameters using 4MB memory, for
+	// environments not able to afford the standard ones.
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	scryptR     = 8
+	scryptDKLen = 32
+)
+
+var (
+	// ErrDecrypt is returned if a keystore can't be decrypted with a password.
+	ErrDecrypt = errors.New("could not decrypt key with given password")
+
+	// ErrInvalidKeystore is returned for keystores not conforming to EIP-2335.
+	ErrInvalidKeystore = errors.New("invalid EIP-2335 keystore")
+)
+
+// PublicKey is a compressed BLS12-381 public key.
+type PublicKey [48]byte
+
+// Hex returns the hex encoding of the public key, without 0x prefix as stored
+// in EIP-2335 keystores.
+func (pub PublicKey) Hex() string {
+	return hex.EncodeToString(pub[:])
+}
+
+// String implements fmt.Stringer.
+func (pub PublicKey) String() string {
+	return "0x" + pub.Hex()
+}
+
+// Signature is a compressed BLS12-381 signature.
+type Signature [96]byte
+
+// Key is a BLS12-381 secret key along with the metadata of its keystore.
+type Key struct {
+	ID          uuid.UUID
+	Path        string // EIP-2334 derivation path of the key, empty if not derived
+	Description string
+	PublicKey   PublicKey
+	SecretKey   *blsu.SecretKey
+}
+
+// NewKey generates a random secret key.
+func NewKey(rand io.Reader) (*Key, error) {
+	var (
+		sk  = new(blsu.SecretKey)
+		buf [32]byte
+	)
+	// Draw until a scalar of the field is found, which is unbiased. Around half
+	// of the 256 bit values are in the field.
+	for {
+		if _, err := io.ReadFull(rand, buf[:]); err != nil {
+			return nil, err
+		}
+		if err := sk.Deserialize(&buf); err == nil {
+			break
+		}
+	}
+	return newKeyFromSecret(sk)
+}
+
+// newKeyFromSecret creates a key with a new random ID from the secret key.
+func newKeyFromSecret(sk *blsu.SecretKey) (*Key, error) {
+	pub, err := blsu.SkToPk(sk)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	return &Key{ID: id, PublicKey: pub.Serialize(), SecretKey: sk}, nil
+}
+
+// Sign signs the message with the key, using the proof-of-possession scheme of
+// the consensus layer.
+func (k *Key) Sign(msg []byte) Signature {
+	return blsu.Sign(k.SecretKey, msg).Serialize()
+}
+
+// Verify checks that the signature of the message was made by the key of the
+// given public key.
+func Verify(pub PublicKey, msg []byte, sig Signature) bool {
+	var (
+		pk blsu.Pubkey
+		s  blsu.Signature
+	)
+	if pk.Deserialize((*[48]byte)(&pub)) != nil || s.Deserialize((*[96]byte)(&sig)) != nil {
+		return false
+	}
+	return blsu.Verify(&pk, msg, &s)
+}
+
+// keystoreJSON is the JSON encoding of an EIP-2335 keystore.
+type keystoreJSON struct {
+	Crypto      cryptoJSON `json:"crypto"`
+	Description string     `json:"description"`
+	Pubkey      string     `json:"pubkey"`
+	Path        string     `json:"path"`
+	UUID        string     `json:"uuid"`
+	Version     int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	KDF      moduleJSON `json:"kdf"`
+	Checksum moduleJSON `json:"checksum"`
+	Cipher   moduleJSON `json:"cipher"`
+}
+
+type moduleJSON struct {
+	Function string         `json:"function"`
+	Params   map[string]any `json:"params"`
+	Message  string         `json:"message"`
+}
+
+// EncryptKey encrypts a key into an EIP-2335 keystore, deriving the encryption
+// key from the password with scrypt using the given parameters.
+func EncryptKey(key *Key, password string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+	decryptionKey, err := scrypt.Key(normalizePassword(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	secret := key.SecretKey.Serialize()
+	ciphertext, err := aesCTRXOR(decryptionKey[:16], secret[:], iv)
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(append(decryptionKey[16:32:32], ciphertext...))
+
+	return json.MarshalIndent(keystoreJSON{
+		Crypto: cryptoJSON{
+			KDF: moduleJSON{
+				Function: "scrypt",
+				Params: map[string]any{
+					"dklen": scryptDKLen,
+					"n":     scryptN,
+					"p":     scryptP,
+					"r":     scryptR,
+					"salt":  hex.EncodeToString(salt),
+				},
+			},
+			Checksum: moduleJSON{Function: "sha256", Params: map[string]any{}, Message: hex.EncodeToString(checksum[:])},
+			Cipher:   moduleJSON{Function: "aes-128-ctr", Params: map[string]any{"iv": hex.EncodeToString(iv)}, Message: hex.EncodeToString(ciphertext)},
+		},
+		Description: key.Description,
+		Pubkey:      key.PublicKey.Hex(),
+		Path:        key.Path,
+		UUID:        key.ID.String(),
+		Version:     version,
+	}, "", "  ")
+}
+
+// DecryptKey decrypts an EIP-2335 keystore with the password. Keystores using
+// scrypt and PBKDF2 are supported.
+func DecryptKey(keyjson []byte, password string) (*Key, error) {
+	var ks keystoreJSON
+	if err := json.Unmarshal(keyjson, &ks); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeystore, err)
+	}
+	if ks.Version != version {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidKeystore, ks.Version)
+	}
+	decryptionKey, err := deriveKey(ks.Crypto.KDF, normalizePassword(password))
+	if err != nil {
+		return nil, err
+	}
+	if len(decryptionKey) != 32 {
+		return nil, fmt.Errorf("%w: unsupported derived key length %d", ErrInvalidKeystore, len(decryptionKey))
+	}
+	// Verify the password before decrypting
+	ciphertext, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cipher message: %v", ErrInvalidKeystore, err)
+	}
+	if ks.Crypto.Checksum.Function != "sha256" {
+		return nil, fmt.Errorf("%w: unsupported checksum function %q", ErrInvalidKeystore, ks.Crypto.Checksum.Function)
+	}
+	checksum := sha256.Sum256(append(decryptionKey[16:32:32], ciphertext...))
+	if hex.EncodeToString(checksum[:]) != strings.ToLower(ks.Crypto.Checksum.Message) {
+		return nil, ErrDecrypt
+	}
+	if ks.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, fmt.Errorf("%w: unsupported cipher function %q", ErrInvalidKeystore, ks.Crypto.Cipher.Function)
+	}
+	iv, err := hexParam(ks.Crypto.Cipher.Params, "iv")
+	if err != nil {
+		return nil, err
+	}
+	secret, err := aesCTRXOR(decryptionKey[:16], ciphertext, iv)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("%w: invalid secret length %d", ErrInvalidKeystore, len(secret))
+	}
+	sk := new(blsu.SecretKey)
+	if err := sk.Deserialize((*[32]byte)(secret)); err != nil {
+		return nil, fmt.Errorf("%w: invalid secret key: %v", ErrInvalidKeystore, err)
+	}
+	key, err := newKeyFromSecret(sk)
+	if err != nil {
+		return nil, err
+	}
+	// Ensure the keystore describes the key it contains
+	if ks.Pubkey != "" {
+		if pub, err := hex.DecodeString(ks.Pubkey); err != nil || !bytes.Equal(pub, key.PublicKey[:]) {
+			return nil, fmt.Errorf("%w: public key mismatch", ErrInvalidKeystore)
+		}
+	}
+	if id, err := uuid.Parse(ks.UUID); err == nil {
+		key.ID = id
+	}
+	key.Path, key.Description = ks.Path, ks.Description
+	return key, nil
+}
+
+// deriveKey derives the decryption key from the password with the KDF of the
+// keystore.
+func deriveKey(kdf moduleJSON, password []byte) ([]byte, error) {
+	salt, err := hexParam(kdf.Params, "salt")
+	if err != nil {
+		return nil, err
+	}
+	dklen, err := intParam(kdf.Params, "dklen")
+	if err != nil {
+		return nil, err
+	}
+	switch kdf.Function {
+	case "scrypt":
+		n, err := intParam(kdf.Params, "n")
+		if err != nil {
+			return nil, err
+		}
+		r, err := intParam(kdf.Params, "r")
+		if err != nil {
+			return nil, err
+		}
+		p, err := intParam(kdf.Params, "p")
+		if err != nil {
+			return nil, err
+		}
+		return scrypt.Key(password, salt, n, r, p, dklen)
+
+	case "pbkdf2":
+		if prf, _ := kdf.Params["prf"].(string); prf != "hmac-sha256" {
+			return nil, fmt.Errorf("%w: unsupported PBKDF2 PRF %q", ErrInvalidKeystore, prf)
+		}
+		c, err := intParam(kdf.Params, "c")
+		if err != nil {
+			return nil, err
+		}
+		return pbkdf2.Key(password, salt, c, dklen, sha256.New), nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported KDF %q", ErrInvalidKeystore, kdf.Function)
+	}
+}
+
+// hexParam retrieves a hex encoded parameter of a keystore module.
+func hexParam(params map[string]any, name string) ([]byte, error) {
+	s, ok := params[name].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing parameter %q", ErrInvalidKeystore, name)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid parameter %q: %v", ErrInvalidKeystore, name, err)
+	}
+	return b, nil
+}
+
+// intParam retrieves a positive integer parameter of a keystore module.
+func intParam(params map[string]any, name string) (int, error) {
+	f, ok := params[name].(float64)
+	if !ok || f <= 0 || f != float64(int(f)) {
+		return 0, fmt.Errorf("%w: missing or invalid parameter %q", ErrInvalidKeystore, name)
+	}
+	return int(f), nil
+}
+
+// normalizePassword processes the password as mandated by EIP-2335: it's NFKD
+// normalized and stripped of control codes.
+func normalizePassword(password string) []byte {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(password) {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return []byte(b.String())
+}
+
+func aesCTRXOR(key, inText, iv []byte) ([]byte, error) {
+	aesBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("%w: invalid IV length %d", ErrInvalidKeystore, len(iv))
+	}
+	stream := cipher.NewCTR(aesBlock, iv)
+	outText := make([]byte, len(inText))
+	stream.XORKeyStream(outText, inText)
+	return outText, nil
+}
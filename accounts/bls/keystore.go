@@ -0,0 +1,257 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// ErrLocked is returned when signing with a key which is not unlocked.
+	ErrLocked = errors.New("key is locked")
+
+	// ErrNoMatch is returned for public keys without a keystore in the directory.
+	ErrNoMatch = errors.New("no keystore for given public key")
+
+	// ErrKeyExists is returned when importing a key already in the directory.
+	ErrKeyExists = errors.New("key already exists")
+)
+
+// KeyStore manages a directory of EIP-2335 keystores, one per BLS key, and signs
+// with the keys unlocked in memory.
+type KeyStore struct {
+	keydir  string
+	scryptN int
+	scryptP int
+
+	unlocked map[PublicKey]*Key // Currently unlocked keys
+	mu       sync.RWMutex
+}
+
+// NewKeyStore creates a keystore for the given directory, which encrypts new
+// keys with the given scrypt parameters.
+func NewKeyStore(keydir string, scryptN, scryptP int) *KeyStore {
+	return &KeyStore{
+		keydir:   keydir,
+		scryptN:  scryptN,
+		scryptP:  scryptP,
+		unlocked: make(map[PublicKey]*Key),
+	}
+}
+
+// PublicKeys returns the public keys of all keystores in the directory.
+func (ks *KeyStore) PublicKeys() []PublicKey {
+	files := ks.scan()
+	keys := make([]PublicKey, 0, len(files))
+	for pub := range files {
+		keys = append(keys, pub)
+	}
+	slices.SortFunc(keys, func(a, b PublicKey) int {
+		return strings.Compare(a.Hex(), b.Hex())
+	})
+	return keys
+}
+
+// HasKey reports whether a keystore of the given public key is present.
+func (ks *KeyStore) HasKey(pub PublicKey) bool {
+	_, ok := ks.scan()[pub]
+	return ok
+}
+
+// scan reads the public keys of the keystores in the directory. Files which are
+// not keystores are skipped.
+func (ks *KeyStore) scan() map[PublicKey]string {
+	files := make(map[PublicKey]string)
+
+	entries, err := os.ReadDir(ks.keydir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debug("Failed to read BLS keystore directory", "dir", ks.keydir, "err", err)
+		}
+		return files
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(ks.keydir, entry.Name())
+		blob, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var keystore struct {
+			Pubkey  string `json:"pubkey"`
+			Version int    `json:"version"`
+		}
+		if err := json.Unmarshal(blob, &keystore); err != nil || keystore.Version != version {
+			continue
+		}
+		pub, err := hex.DecodeString(strings.TrimPrefix(keystore.Pubkey, "0x"))
+		if err != nil || len(pub) != len(PublicKey{}) {
+			log.Debug("Failed to decode BLS keystore public key", "path", path)
+			continue
+		}
+		files[PublicKey(pub)] = path
+	}
+	return files
+}
+
+// find returns the path of the keystore of the given public key.
+func (ks *KeyStore) find(pub PublicKey) (string, error) {
+	path, ok := ks.scan()[pub]
+	if !ok {
+		return "", ErrNoMatch
+	}
+	return path, nil
+}
+
+// getDecryptedKey decrypts the keystore of the given public key.
+func (ks *KeyStore) getDecryptedKey(pub PublicKey, password string) (string, *Key, error) {
+	path, err := ks.find(pub)
+	if err != nil {
+		return "", nil, err
+	}
+	keyjson, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	key, err := DecryptKey(keyjson, password)
+	return path, key, err
+}
+
+// NewKey generates a new random key and stores it into the directory, encrypted
+// with the password.
+func (ks *KeyStore) NewKey(password string) (PublicKey, error) {
+	key, err := NewKey(rand.Reader)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return key.PublicKey, ks.storeKey(key, password)
+}
+
+// Import stores the given EIP-2335 keystore into the directory, re-encrypted
+// with newPassword.
+func (ks *KeyStore) Import(keyjson []byte, password, newPassword string) (PublicKey, error) {
+	key, err := DecryptKey(keyjson, password)
+	if err != nil {
+		return PublicKey{}, err
+	}
+	return key.PublicKey, ks.ImportKey(key, newPassword)
+}
+
+// ImportKey stores the key into the directory, encrypted with the password.
+func (ks *KeyStore) ImportKey(key *Key, password string) error {
+	if ks.HasKey(key.PublicKey) {
+		return ErrKeyExists
+	}
+	return ks.storeKey(key, password)
+}
+
+// Export exports the key of the given public key as an EIP-2335 keystore,
+// encrypted with newPassword.
+func (ks *KeyStore) Export(pub PublicKey, password, newPassword string) ([]byte, error) {
+	_, key, err := ks.getDecryptedKey(pub, password)
+	if err != nil {
+		return nil, err
+	}
+	return EncryptKey(key, newPassword, ks.scryptN, ks.scryptP)
+}
+
+// Delete removes the keystore of the given public key, if the password is
+// correct.
+func (ks *KeyStore) Delete(pub PublicKey, password string) error {
+	path, _, err := ks.getDecryptedKey(pub, password)
+	if err != nil {
+		return err
+	}
+	ks.Lock(pub)
+	return os.Remove(path)
+}
+
+// storeKey encrypts the key and writes it atomically into the directory.
+func (ks *KeyStore) storeKey(key *Key, password string) error {
+	keyjson, err := EncryptKey(key, password, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(ks.keydir, 0700); err != nil {
+		return err
+	}
+	name := "keystore-" + key.PublicKey.Hex()[:16] + "-" + key.ID.String() + ".json"
+	f, err := os.CreateTemp(ks.keydir, "."+name+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(keyjson); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	f.Close()
+	return os.Rename(f.Name(), filepath.Join(ks.keydir, name))
+}
+
+// Unlock decrypts the key of the given public key, keeping it in memory for
+// signing until locked.
+func (ks *KeyStore) Unlock(pub PublicKey, password string) error {
+	_, key, err := ks.getDecryptedKey(pub, password)
+	if err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.unlocked[pub] = key
+	return nil
+}
+
+// Lock removes the key of the given public key from memory.
+func (ks *KeyStore) Lock(pub PublicKey) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	delete(ks.unlocked, pub)
+}
+
+// Sign signs the message with the unlocked key of the given public key.
+func (ks *KeyStore) Sign(pub PublicKey, msg []byte) (Signature, error) {
+	ks.mu.RLock()
+	key, ok := ks.unlocked[pub]
+	ks.mu.RUnlock()
+	if !ok {
+		return Signature{}, ErrLocked
+	}
+	return key.Sign(msg), nil
+}
+
+// SignWithPassphrase signs the message with the key of the given public key,
+// decrypting it with the password only for the duration of the signing.
+func (ks *KeyStore) SignWithPassphrase(pub PublicKey, password string, msg []byte) (Signature, error) {
+	_, key, err := ks.getDecryptedKey(pub, password)
+	if err != nil {
+		return Signature{}, err
+	}
+	return key.Sign(msg), nil
+}
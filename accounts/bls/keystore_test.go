@@ -0,0 +1,176 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bls
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// Test vectors of EIP-2335.
+const (
+	testPassword = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+	testSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	testPubkey   = "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+
+	testScryptKeystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "scrypt",
+            "params": {
+                "dklen": 32,
+                "n": 262144,
+                "p": 1,
+                "r": 8,
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+        }
+    },
+    "description": "This is a test keystore that uses scrypt to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/3141592653/589793238",
+    "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+    "version": 4
+}`
+	testPBKDF2Keystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+)
+
+func TestDecryptKey(t *testing.T) {
+	t.Parallel()
+
+	for _, keystore := range []string{testScryptKeystore, testPBKDF2Keystore} {
+		key, err := DecryptKey([]byte(keystore), testPassword)
+		if err != nil {
+			t.Fatalf("failed to decrypt keystore: %v", err)
+		}
+		if secret := key.SecretKey.Serialize(); hex.EncodeToString(secret[:]) != testSecret {
+			t.Errorf("secret mismatch: have %x, want %s", secret, testSecret)
+		}
+		if key.PublicKey.Hex() != testPubkey {
+			t.Errorf("public key mismatch: have %s, want %s", key.PublicKey.Hex(), testPubkey)
+		}
+		if _, err := DecryptKey([]byte(keystore), "wrong"); err != ErrDecrypt {
+			t.Errorf("decrypting with wrong password: have %v, want %v", err, ErrDecrypt)
+		}
+	}
+}
+
+func TestKeyStore(t *testing.T) {
+	t.Parallel()
+	ks := NewKeyStore(t.TempDir(), LightScryptN, LightScryptP)
+
+	// Imported and generated keys must be listed
+	imported, err := ks.Import([]byte(testPBKDF2Keystore), testPassword, "foo")
+	if err != nil {
+		t.Fatalf("failed to import keystore: %v", err)
+	}
+	if imported.Hex() != testPubkey {
+		t.Errorf("imported public key mismatch: have %s, want %s", imported.Hex(), testPubkey)
+	}
+	if _, err := ks.Import([]byte(testPBKDF2Keystore), testPassword, "foo"); err != ErrKeyExists {
+		t.Errorf("importing existing key: have %v, want %v", err, ErrKeyExists)
+	}
+	generated, err := ks.NewKey("bar")
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	if keys := ks.PublicKeys(); len(keys) != 2 || !ks.HasKey(imported) || !ks.HasKey(generated) {
+		t.Fatalf("key list mismatch: have %v", keys)
+	}
+	// Signing must require unlocking and produce valid signatures
+	msg := []byte("attestation")
+	if _, err := ks.Sign(generated, msg); err != ErrLocked {
+		t.Errorf("signing with locked key: have %v, want %v", err, ErrLocked)
+	}
+	if err := ks.Unlock(generated, "foo"); err != ErrDecrypt {
+		t.Errorf("unlocking with wrong password: have %v, want %v", err, ErrDecrypt)
+	}
+	if err := ks.Unlock(generated, "bar"); err != nil {
+		t.Fatalf("failed to unlock key: %v", err)
+	}
+	sig, err := ks.Sign(generated, msg)
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	if !Verify(generated, msg, sig) || Verify(imported, msg, sig) {
+		t.Error("signature verification mismatch")
+	}
+	if sig, err := ks.SignWithPassphrase(imported, "foo", msg); err != nil || !Verify(imported, msg, sig) {
+		t.Errorf("failed to sign with passphrase: %v", err)
+	}
+	// Exported keys must be decryptable, deleted ones gone
+	exported, err := ks.Export(generated, "bar", "baz")
+	if err != nil {
+		t.Fatalf("failed to export key: %v", err)
+	}
+	if key, err := DecryptKey(exported, "baz"); err != nil || key.PublicKey != generated {
+		t.Errorf("failed to decrypt exported key: %v", err)
+	}
+	if err := ks.Delete(generated, "bar"); err != nil {
+		t.Fatalf("failed to delete key: %v", err)
+	}
+	if _, err := ks.Sign(generated, msg); err != ErrLocked {
+		t.Errorf("signing with deleted key: have %v, want %v", err, ErrLocked)
+	}
+	if err := ks.Unlock(generated, "bar"); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("unlocking deleted key: have %v, want %v", err, ErrNoMatch)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultDiscoveryGap is the number of consecutive unused accounts after which
+// discovery stops exploring a derivation scheme.
+const DefaultDiscoveryGap = 5
+
+// DerivationScheme is a way of deriving consecutive accounts, as used by some
+// family of wallet software.
+type DerivationScheme struct {
+	Name     string
+	Base     DerivationPath
+	Iterator func(base DerivationPath) func() DerivationPath
+}
+
+// DerivationSchemes are the derivation schemes commonly used for Ethereum accounts.
+var DerivationSchemes = []DerivationScheme{
+	// BIP-44 accounts, m/44'/60'/0'/0/N, used by most software wallets and Trezor
+	{Name: "bip44", Base: DefaultBaseDerivationPath, Iterator: DefaultIterator},
+
+	// Ledger Live accounts, m/44'/60'/N'/0/0
+	{Name: "ledger-live", Base: DefaultBaseDerivationPath, Iterator: LedgerLiveIterator},
+
+	// Legacy accounts, m/44'/60'/0'/N, used by the old Ledger Chrome app and MEW
+	{Name: "legacy", Base: LegacyLedgerBaseDerivationPath, Iterator: DefaultIterator},
+}
+
+// DiscoveredAccount is an account found while discovering the accounts of a
+// wallet, along with its state on chain.
+type DiscoveredAccount struct {
+	Account Account
+	Scheme  string // Name of the derivation scheme the account was found with
+	Balance *big.Int
+	Nonce   uint64
+}
+
+// Used returns whether the account has ever been used on chain.
+func (a *DiscoveredAccount) Used() bool {
+	return a.Nonce > 0 || a.Balance.Sign() > 0
+}
+
+// DiscoverAccounts explores the accounts of the wallet over the given derivation
+// schemes, probing their state on chain. Each scheme is explored until gap
+// consecutive unused accounts are found, and the used accounts are returned
+// along with the first unused one of each scheme as candidates to pick from.
+//
+// Accounts are derived without pinning them, so discovery doesn't alter the
+// wallet. Callers can pin the picked accounts by deriving their paths again.
+func DiscoverAccounts(ctx context.Context, wallet Wallet, chain ethereum.ChainStateReader, schemes []DerivationScheme, gap int) ([]DiscoveredAccount, error) {
+	if gap <= 0 {
+		gap = DefaultDiscoveryGap
+	}
+	var (
+		found []DiscoveredAccount
+		seen  = make(map[common.Address]bool)
+	)
+	for _, scheme := range schemes {
+		next := scheme.Iterator(scheme.Base)
+		for unused, fresh := 0, false; unused < gap; {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			account, err := wallet.Derive(next(), false)
+			if err != nil {
+				return nil, err
+			}
+			balance, err := chain.BalanceAt(ctx, account.Address, nil)
+			if err != nil {
+				return nil, err
+			}
+			nonce, err := chain.NonceAt(ctx, account.Address, nil)
+			if err != nil {
+				return nil, err
+			}
+			candidate := DiscoveredAccount{Account: account, Scheme: scheme.Name, Balance: balance, Nonce: nonce}
+
+			// Report used accounts and the first unused one, once across schemes
+			report := candidate.Used() || !fresh
+			if candidate.Used() {
+				unused = 0
+			} else {
+				unused, fresh = unused+1, true
+			}
+			if !report || seen[account.Address] {
+				continue
+			}
+			seen[account.Address] = true
+			found = append(found, candidate)
+		}
+	}
+	return found, nil
+}
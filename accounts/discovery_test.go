@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// discoveryWallet is a wallet deriving addresses from the hash of the paths.
+type discoveryWallet struct {
+	Wallet
+	pinned int
+}
+
+func (w *discoveryWallet) Derive(path DerivationPath, pin bool) (Account, error) {
+	if pin {
+		w.pinned++
+	}
+	addr := common.BytesToAddress(crypto.Keccak256([]byte(path.String())))
+	return Account{Address: addr, URL: URL{Scheme: "test", Path: path.String()}}, nil
+}
+
+// discoveryChain is a chain state of accounts used at some paths.
+type discoveryChain struct {
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64
+}
+
+func (c *discoveryChain) use(path string, balance int64, nonce uint64) {
+	addr := common.BytesToAddress(crypto.Keccak256([]byte(path)))
+	c.balances[addr], c.nonces[addr] = big.NewInt(balance), nonce
+}
+
+func (c *discoveryChain) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if balance, ok := c.balances[account]; ok {
+		return balance, nil
+	}
+	return new(big.Int), nil
+}
+
+func (c *discoveryChain) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return c.nonces[account], nil
+}
+
+func (c *discoveryChain) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func (c *discoveryChain) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return nil, nil
+}
+
+func TestDiscoverAccounts(t *testing.T) {
+	t.Parallel()
+
+	chain := &discoveryChain{balances: make(map[common.Address]*big.Int), nonces: make(map[common.Address]uint64)}
+	chain.use("m/44'/60'/0'/0/0", 1, 0)
+	chain.use("m/44'/60'/0'/0/3", 0, 1) // Within the gap of the first account
+	chain.use("m/44'/60'/0'/0/9", 1, 1) // Beyond the gap, must not be found
+	chain.use("m/44'/60'/1'/0/0", 5, 2)
+	chain.use("m/44'/60'/0'/2", 7, 0)
+
+	wallet := new(discoveryWallet)
+	found, err := DiscoverAccounts(context.Background(), wallet, chain, DerivationSchemes, 3)
+	if err != nil {
+		t.Fatalf("failed to discover accounts: %v", err)
+	}
+	want := []struct {
+		scheme string
+		path   string
+		used   bool
+	}{
+		{"bip44", "m/44'/60'/0'/0/0", true},
+		{"bip44", "m/44'/60'/0'/0/1", false},
+		{"bip44", "m/44'/60'/0'/0/3", true},
+		{"ledger-live", "m/44'/60'/1'/0/0", true},
+		{"ledger-live", "m/44'/60'/2'/0/0", false},
+		{"legacy", "m/44'/60'/0'/0", false},
+		{"legacy", "m/44'/60'/0'/2", true},
+	}
+	if len(found) != len(want) {
+		t.Fatalf("account count mismatch: have %d, want %d: %v", len(found), len(want), found)
+	}
+	for i, w := range want {
+		if found[i].Scheme != w.scheme || found[i].Account.URL.Path != w.path || found[i].Used() != w.used {
+			t.Errorf("account %d mismatch: have %s %s %v, want %s %s %v", i, found[i].Scheme, found[i].Account.URL.Path, found[i].Used(), w.scheme, w.path, w.used)
+		}
+	}
+	if wallet.pinned != 0 {
+		t.Errorf("discovery pinned %d accounts", wallet.pinned)
+	}
+}
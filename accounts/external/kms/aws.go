@@ -0,0 +1,132 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// AWSKey is a secp256k1 key held by AWS KMS, accessed through its JSON API. The
+// key must be created with the ECC_SECG_P256K1 key spec and SIGN_VERIFY usage.
+type AWSKey struct {
+	KeyID    string       // Identifier or ARN of the key, or an alias of it
+	Config   aws.Config   // Credentials and region to access the key with
+	Endpoint string       // Endpoint of the service, defaulting to the one of the region
+	Client   *http.Client // Client to send the requests with, defaulting to http.DefaultClient
+}
+
+// NewAWSWallet creates a wallet signing with the given AWS KMS key.
+func NewAWSWallet(ctx context.Context, key *AWSKey) (*Wallet, error) {
+	return NewWallet(ctx, key, accounts.URL{Scheme: "kms", Path: "aws/" + key.KeyID})
+}
+
+// PublicKey implements Signer, retrieving the public key with GetPublicKey.
+func (k *AWSKey) PublicKey(ctx context.Context) ([]byte, error) {
+	var res struct {
+		PublicKey []byte
+	}
+	req := map[string]string{"KeyId": k.KeyID}
+	if err := k.call(ctx, "GetPublicKey", req, &res); err != nil {
+		return nil, err
+	}
+	return res.PublicKey, nil
+}
+
+// SignDigest implements Signer, signing the digest with Sign.
+func (k *AWSKey) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var res struct {
+		Signature []byte
+	}
+	req := map[string]any{
+		"KeyId":            k.KeyID,
+		"Message":          digest,
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}
+	if err := k.call(ctx, "Sign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call invokes the given action of the AWS KMS API, signing the request with the
+// credentials of the key configuration.
+func (k *AWSKey) call(ctx context.Context, action string, args any, result any) error {
+	body, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+	endpoint := k.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com/", k.Config.Region)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+
+	if k.Config.Credentials == nil {
+		return fmt.Errorf("no credentials to access AWS KMS key %s", k.KeyID)
+	}
+	creds, err := k.Config.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, creds, req, hex.EncodeToString(hash[:]), "kms", k.Config.Region, time.Now()); err != nil {
+		return err
+	}
+	client := k.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	blob, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		var failure struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(blob, &failure) == nil && failure.Type != "" {
+			return fmt.Errorf("AWS KMS %s failed: %s: %s", action, failure.Type, failure.Message)
+		}
+		return fmt.Errorf("AWS KMS %s failed: %s", action, res.Status)
+	}
+	return json.Unmarshal(blob, result)
+}
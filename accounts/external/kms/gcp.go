@@ -0,0 +1,143 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// gcpEndpoint is the default endpoint of the Google Cloud KMS REST API.
+const gcpEndpoint = "https://cloudkms.googleapis.com/v1/"
+
+// GCPKey is a secp256k1 key held by Google Cloud KMS, accessed through its REST
+// API. The key must be created with the EC_SIGN_SECP256K1_SHA256 algorithm.
+type GCPKey struct {
+	// Name is the resource name of the key version, i.e.
+	// projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+	Name string
+
+	// Token returns the OAuth2 access token to authorize the requests with.
+	Token func(ctx context.Context) (string, error)
+
+	Endpoint string       // Endpoint of the service, defaulting to the public one
+	Client   *http.Client // Client to send the requests with, defaulting to http.DefaultClient
+}
+
+// NewGCPWallet creates a wallet signing with the given Google Cloud KMS key.
+func NewGCPWallet(ctx context.Context, key *GCPKey) (*Wallet, error) {
+	return NewWallet(ctx, key, accounts.URL{Scheme: "kms", Path: "gcp/" + key.Name})
+}
+
+// PublicKey implements Signer, retrieving the PEM encoded public key of the key
+// version.
+func (k *GCPKey) PublicKey(ctx context.Context) ([]byte, error) {
+	var res struct {
+		Pem string `json:"pem"`
+	}
+	if err := k.call(ctx, http.MethodGet, "/publicKey", nil, &res); err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil {
+		return nil, errors.New("invalid PEM encoded public key")
+	}
+	return block.Bytes, nil
+}
+
+// SignDigest implements Signer, signing the digest with asymmetricSign.
+func (k *GCPKey) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	var res struct {
+		Signature []byte `json:"signature"`
+	}
+	req := map[string]any{
+		"digest": map[string][]byte{"sha256": digest},
+	}
+	if err := k.call(ctx, http.MethodPost, ":asymmetricSign", req, &res); err != nil {
+		return nil, err
+	}
+	return res.Signature, nil
+}
+
+// call invokes the given method of the key version, authorizing the request with
+// the access token of the key.
+func (k *GCPKey) call(ctx context.Context, method string, suffix string, args any, result any) error {
+	var body io.Reader
+	if args != nil {
+		blob, err := json.Marshal(args)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(blob)
+	}
+	endpoint := k.Endpoint
+	if endpoint == "" {
+		endpoint = gcpEndpoint
+	}
+	url := strings.TrimSuffix(endpoint, "/") + "/" + k.Name + suffix
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if args != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if k.Token == nil {
+		return fmt.Errorf("no access token for Google Cloud KMS key %s", k.Name)
+	}
+	token, err := k.Token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve access token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := k.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	blob, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(blob, &failure) == nil && failure.Error.Message != "" {
+			return fmt.Errorf("Google Cloud KMS request failed: %s", failure.Error.Message)
+		}
+		return fmt.Errorf("Google Cloud KMS request failed: %s", res.Status)
+	}
+	return json.Unmarshal(blob, result)
+}
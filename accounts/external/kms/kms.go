@@ -0,0 +1,271 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package kms implements wallets signing with secp256k1 keys held by a cloud key
+// management service, such as AWS KMS or Google Cloud KMS, so that the private
+// keys never leave the service.
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// defaultTimeout is the time allowed for a request to the key management
+// service, since the wallet interface doesn't carry a context.
+const defaultTimeout = 30 * time.Second
+
+var (
+	// oidPublicKeyECDSA is the algorithm identifier of elliptic curve keys.
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+	// oidNamedCurveSecp256k1 is the identifier of the secp256k1 curve.
+	oidNamedCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// Signer is a secp256k1 signing key held by a key management service.
+type Signer interface {
+	// PublicKey returns the DER encoded SubjectPublicKeyInfo of the key.
+	PublicKey(ctx context.Context) ([]byte, error)
+
+	// SignDigest signs the given 32 byte digest, returning the DER encoded ECDSA
+	// signature.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// Wallet is an accounts.Wallet of the single account of a key held by a key
+// management service.
+type Wallet struct {
+	signer  Signer
+	account accounts.Account
+	pubkey  []byte        // Uncompressed public key to recover the signature parity with
+	timeout time.Duration // Time allowed for the requests to the service
+}
+
+// NewWallet creates a wallet signing with the given key, identified by the URL.
+// The public key is retrieved from the service to derive the address.
+func NewWallet(ctx context.Context, signer Signer, url accounts.URL) (*Wallet, error) {
+	der, err := signer.PublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve public key: %w", err)
+	}
+	pubkey, err := parsePublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		signer: signer,
+		account: accounts.Account{
+			Address: crypto.PubkeyToAddress(*pubkey),
+			URL:     url,
+		},
+		pubkey:  crypto.FromECDSAPub(pubkey),
+		timeout: defaultTimeout,
+	}, nil
+}
+
+// SetTimeout sets the time allowed for each request to the key management service.
+func (w *Wallet) SetTimeout(timeout time.Duration) {
+	w.timeout = timeout
+}
+
+// URL implements accounts.Wallet, returning the URL identifying the key.
+func (w *Wallet) URL() accounts.URL {
+	return w.account.URL
+}
+
+// Status implements accounts.Wallet. The key is always available as far as the
+// wallet is concerned, failures are only detected when signing.
+func (w *Wallet) Status() (string, error) {
+	return "Online", nil
+}
+
+// Open implements accounts.Wallet, but is a noop since the service is accessed
+// on every signing request.
+func (w *Wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, but is a noop since there is no meaningful
+// open operation.
+func (w *Wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet, returning the account of the key.
+func (w *Wallet) Accounts() []accounts.Account {
+	return []accounts.Account{w.account}
+}
+
+// Address returns the address of the account of the key.
+func (w *Wallet) Address() common.Address {
+	return w.account.Address
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not the account of the key.
+func (w *Wallet) Contains(account accounts.Account) bool {
+	return account.Address == w.account.Address && (account.URL == (accounts.URL{}) || account.URL == w.account.URL)
+}
+
+// Derive implements accounts.Wallet, but is not supported by keys of key
+// management services.
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop since keys of key
+// management services can't be derived.
+func (w *Wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since access to the key is controlled by the service.
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.SignData(account, mimeType, data)
+}
+
+// SignText implements accounts.Wallet, attempting to sign the hash of the given
+// text with the key.
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.signHash(account, accounts.TextHash(text))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since access to the key is controlled by the service.
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return w.SignText(account, text)
+}
+
+// SignTx implements accounts.Wallet, signing the given transaction with the key
+// using the latest signer of the given chain, e.g. EIP-155 for legacy and the
+// typed signing scheme for EIP-1559 transactions.
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := w.signHash(account, signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since access to the key is controlled by the service.
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignTx(account, tx, chainID)
+}
+
+// signHash signs the given hash with the key, returning the signature in the
+// [R || S || V] format with V being 0 or 1.
+func (w *Wallet) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+	if !w.Contains(account) {
+		return nil, accounts.ErrUnknownAccount
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	der, err := w.signer.SignDigest(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return recoverableSignature(der, hash, w.pubkey)
+}
+
+// parsePublicKey decodes a DER encoded SubjectPublicKeyInfo of a secp256k1 key,
+// which the x509 package doesn't support.
+func parsePublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	if rest, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("invalid public key: trailing data")
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, fmt.Errorf("unsupported key algorithm %v", info.Algorithm.Algorithm)
+	}
+	var curve asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve); err != nil {
+		return nil, fmt.Errorf("invalid key curve: %v", err)
+	}
+	if !curve.Equal(oidNamedCurveSecp256k1) {
+		return nil, fmt.Errorf("unsupported key curve %v, want secp256k1", curve)
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.RightAlign())
+}
+
+// recoverableSignature converts a DER encoded ECDSA signature into the [R || S || V]
+// format.
+func recoverableSignature(der []byte, hash []byte, pubkey []byte) ([]byte, error) {
+	var parsed struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(der, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid signature: %v", err)
+	} else if len(rest) > 0 {
+		return nil, errors.New("invalid signature: trailing data")
+	}
+	return accounts.RecoverableSignature(parsed.R, parsed.S, hash, pubkey)
+}
+
+// Backend is an accounts.Backend of a fixed set of key management service wallets.
+type Backend struct {
+	wallets []accounts.Wallet
+}
+
+// NewBackend creates a backend of the given wallets.
+func NewBackend(wallets ...*Wallet) *Backend {
+	backend := new(Backend)
+	for _, wallet := range wallets {
+		backend.wallets = append(backend.wallets, wallet)
+	}
+	// The account manager expects the wallets sorted by URL
+	slices.SortFunc(backend.wallets, func(a, b accounts.Wallet) int {
+		return a.URL().Cmp(b.URL())
+	})
+	return backend
+}
+
+// Wallets implements accounts.Backend, returning the wallets of the backend.
+func (b *Backend) Wallets() []accounts.Wallet {
+	return b.wallets
+}
+
+// Subscribe implements accounts.Backend. The wallets of the backend are fixed,
+// so no events are ever sent.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package kms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testSigner is a Signer of a local key, returning signatures with high S values
+// like the key management services may.
+type testSigner struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *testSigner) PublicKey(ctx context.Context) ([]byte, error) {
+	return marshalPublicKey(&s.key.PublicKey), nil
+}
+
+func (s *testSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, s.key)
+	if err != nil {
+		return nil, err
+	}
+	r := new(big.Int).SetBytes(sig[:32])
+	highS := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(sig[32:64]))
+	return asn1.Marshal(struct{ R, S *big.Int }{r, highS})
+}
+
+func marshalPublicKey(pub *ecdsa.PublicKey) []byte {
+	curve, _ := asn1.Marshal(oidNamedCurveSecp256k1)
+	der, _ := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: curve}},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(pub), BitLength: 8 * 65},
+	})
+	return der
+}
+
+func TestWalletSign(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	wallet, err := NewWallet(context.Background(), &testSigner{key}, accounts.URL{Scheme: "kms", Path: "test"})
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	account := accounts.Account{Address: addr}
+	if !wallet.Contains(account) {
+		t.Fatalf("wallet doesn't contain the account of its key %v", addr)
+	}
+	// Signed data must be recoverable with low S values
+	sig, err := wallet.SignText(account, []byte("hello"))
+	if err != nil {
+		t.Fatalf("failed to sign text: %v", err)
+	}
+	if !crypto.ValidateSignatureValues(sig[64], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), true) {
+		t.Errorf("signature has high S value")
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig)
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if have := crypto.PubkeyToAddress(*pub); have != addr {
+		t.Errorf("recovered signer mismatch: have %v, want %v", have, addr)
+	}
+	// Transactions of all types must be signed for the chain
+	chainID := big.NewInt(1337)
+	to := common.HexToAddress("0x01")
+	txs := []types.TxData{
+		&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(1)},
+		&types.DynamicFeeTx{ChainID: chainID, Nonce: 2, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000, To: &to},
+	}
+	for i, data := range txs {
+		signed, err := wallet.SignTx(account, types.NewTx(data), chainID)
+		if err != nil {
+			t.Fatalf("tx %d: failed to sign: %v", i, err)
+		}
+		if !signed.Protected() {
+			t.Errorf("tx %d: not replay protected", i)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil {
+			t.Fatalf("tx %d: failed to recover sender: %v", i, err)
+		}
+		if sender != addr {
+			t.Errorf("tx %d: sender mismatch: have %v, want %v", i, sender, addr)
+		}
+	}
+	// Other accounts must be rejected
+	if _, err := wallet.SignText(accounts.Account{Address: to}, []byte("hello")); err != accounts.ErrUnknownAccount {
+		t.Errorf("signing for unknown account: have %v, want %v", err, accounts.ErrUnknownAccount)
+	}
+}
+
+func TestWalletInvalidKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+
+	// A prime256v1 key can't sign Ethereum transactions
+	curve, _ := asn1.Marshal(asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7})
+	der, _ := asn1.Marshal(struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: curve}},
+		PublicKey: asn1.BitString{Bytes: crypto.FromECDSAPub(&key.PublicKey), BitLength: 8 * 65},
+	})
+	if _, err := parsePublicKey(der); err == nil || !strings.Contains(err.Error(), "unsupported key curve") {
+		t.Fatalf("unexpected error for key of other curve: %v", err)
+	}
+}
+
+func TestAWSKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := &testSigner{key}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			http.Error(w, `{"__type":"AccessDeniedException","message":"unsigned"}`, http.StatusBadRequest)
+			return
+		}
+		var req struct {
+			KeyId   string
+			Message []byte
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.KeyId != "alias/test" {
+			http.Error(w, `{"__type":"NotFoundException","message":"unknown key"}`, http.StatusBadRequest)
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			pub, _ := signer.PublicKey(r.Context())
+			json.NewEncoder(w).Encode(map[string][]byte{"PublicKey": pub})
+		case "TrentService.Sign":
+			sig, _ := signer.SignDigest(r.Context(), req.Message)
+			json.NewEncoder(w).Encode(map[string][]byte{"Signature": sig})
+		}
+	}))
+	defer server.Close()
+
+	cfg := aws.Config{Region: "eu-west-1", Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", "")}
+	wallet, err := NewAWSWallet(context.Background(), &AWSKey{KeyID: "alias/test", Config: cfg, Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	testRemoteWallet(t, wallet, crypto.PubkeyToAddress(key.PublicKey))
+
+	_, err = NewAWSWallet(context.Background(), &AWSKey{KeyID: "alias/other", Config: cfg, Endpoint: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "NotFoundException") {
+		t.Errorf("unexpected error for unknown key: %v", err)
+	}
+}
+
+func TestGCPKey(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := &testSigner{key}
+
+	const name = "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, `{"error":{"message":"unauthenticated"}}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/" + name + "/publicKey":
+			pub, _ := signer.PublicKey(r.Context())
+			json.NewEncoder(w).Encode(map[string]string{"pem": string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))})
+		case "/" + name + ":asymmetricSign":
+			var req struct {
+				Digest struct {
+					Sha256 string `json:"sha256"`
+				} `json:"digest"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			digest, _ := base64.StdEncoding.DecodeString(req.Digest.Sha256)
+			sig, _ := signer.SignDigest(r.Context(), digest)
+			json.NewEncoder(w).Encode(map[string][]byte{"signature": sig})
+		default:
+			http.Error(w, `{"error":{"message":"not found"}}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	token := func(ctx context.Context) (string, error) { return "token", nil }
+	wallet, err := NewGCPWallet(context.Background(), &GCPKey{Name: name, Token: token, Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create wallet: %v", err)
+	}
+	testRemoteWallet(t, wallet, crypto.PubkeyToAddress(key.PublicKey))
+
+	badToken := func(ctx context.Context) (string, error) { return "expired", nil }
+	_, err = NewGCPWallet(context.Background(), &GCPKey{Name: name, Token: badToken, Endpoint: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "unauthenticated") {
+		t.Errorf("unexpected error for bad token: %v", err)
+	}
+}
+
+// testRemoteWallet checks that a wallet signs transactions from the given address.
+func testRemoteWallet(t *testing.T, wallet *Wallet, addr common.Address) {
+	t.Helper()
+
+	if wallet.Address() != addr {
+		t.Fatalf("address mismatch: have %v, want %v", wallet.Address(), addr)
+	}
+	chainID := big.NewInt(1)
+	tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1), Gas: 21000})
+	signed, err := wallet.SignTx(wallet.Accounts()[0], tx, chainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil {
+		t.Fatalf("failed to recover sender: %v", err)
+	}
+	if sender != addr {
+		t.Errorf("sender mismatch: have %v, want %v", sender, addr)
+	}
+}
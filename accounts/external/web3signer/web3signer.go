@@ -0,0 +1,419 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package web3signer implements a wallet of the secp256k1 keys held by a remote
+// signer speaking the REST API of Web3Signer, so that the keys can live on a
+// separate hardened host.
+//
+// The signer only signs the Keccak256 hash of the data it receives, so instead
+// of hashes, the wallet sends the signing preimages of messages and transactions.
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Scheme is the protocol scheme prefixing account and wallet URLs.
+const Scheme = "web3signer"
+
+// defaultTimeout is the time allowed for a request to the signer, since the
+// wallet interface doesn't carry a context.
+const defaultTimeout = 30 * time.Second
+
+// maxResponseSize is the maximum size of a response accepted from the signer.
+const maxResponseSize = 1024 * 1024
+
+// LoadTLSConfig creates the TLS configuration to connect to a signer requiring
+// client authentication, from the PEM encoded client certificate and its key,
+// and optionally the certificates of the authorities to verify the signer with,
+// instead of the system ones.
+func LoadTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+	}
+	return config, nil
+}
+
+// Backend is an accounts.Backend of the single wallet of a remote signer.
+type Backend struct {
+	wallets []accounts.Wallet
+}
+
+// NewBackend creates a backend of the signer at the given endpoint, connecting
+// with the optional TLS configuration.
+func NewBackend(endpoint string, config *tls.Config) (*Backend, error) {
+	wallet, err := NewWallet(endpoint, config)
+	if err != nil {
+		return nil, err
+	}
+	return &Backend{wallets: []accounts.Wallet{wallet}}, nil
+}
+
+// Wallets implements accounts.Backend, returning the wallet of the signer.
+func (b *Backend) Wallets() []accounts.Wallet {
+	return b.wallets
+}
+
+// Subscribe implements accounts.Backend, but no events are ever fired since the
+// wallet of the signer is always present.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// Wallet is an accounts.Wallet of the keys held by a remote signer. The keys are
+// listed on every call to Accounts, so that keys loaded into the signer appear.
+type Wallet struct {
+	endpoint string
+	client   *http.Client
+	url      accounts.URL
+	timeout  time.Duration
+
+	keys map[common.Address]string // Public keys of the accounts, identifying them to the signer
+	lock sync.RWMutex
+}
+
+// NewWallet creates a wallet of the signer at the given endpoint, connecting with
+// the optional TLS configuration, and checks that the signer is reachable.
+func NewWallet(endpoint string, config *tls.Config) (*Wallet, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported signer scheme %q", u.Scheme)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+
+	w := &Wallet{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   &http.Client{Transport: transport},
+		url:      accounts.URL{Scheme: Scheme, Path: u.Host},
+		timeout:  defaultTimeout,
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	if _, err := w.call(ctx, http.MethodGet, "/upcheck", nil); err != nil {
+		return nil, fmt.Errorf("signer unreachable: %w", err)
+	}
+	if err := w.refresh(ctx); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// SetTimeout sets the time allowed for each request to the signer.
+func (w *Wallet) SetTimeout(timeout time.Duration) {
+	w.timeout = timeout
+}
+
+// URL implements accounts.Wallet, returning the URL of the signer.
+func (w *Wallet) URL() accounts.URL {
+	return w.url
+}
+
+// Status implements accounts.Wallet, returning whether the signer is reachable.
+func (w *Wallet) Status() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	if _, err := w.call(ctx, http.MethodGet, "/upcheck", nil); err != nil {
+		return "Offline", err
+	}
+	return "Online", nil
+}
+
+// Open implements accounts.Wallet, but is a noop since the signer is accessed
+// on every request.
+func (w *Wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, but is a noop since there is no meaningful
+// open operation.
+func (w *Wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet, returning the accounts of the keys held
+// by the signer.
+func (w *Wallet) Accounts() []accounts.Account {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	if err := w.refresh(ctx); err != nil {
+		log.Error("Failed to list remote signer keys", "url", w.url, "err", err)
+	}
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	accs := make([]accounts.Account, 0, len(w.keys))
+	for addr := range w.keys {
+		accs = append(accs, accounts.Account{Address: addr, URL: w.url})
+	}
+	slices.SortFunc(accs, func(a, b accounts.Account) int {
+		return a.Address.Cmp(b.Address)
+	})
+	return accs
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not held by the signer, as of the last listing of the keys.
+func (w *Wallet) Contains(account accounts.Account) bool {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	_, ok := w.keys[account.Address]
+	return ok && (account.URL == (accounts.URL{}) || account.URL == w.url)
+}
+
+// Derive implements accounts.Wallet, but is not supported by remote signers.
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop since remote signers
+// don't support derivation.
+func (w *Wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.sign(account, data)
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since access to the keys is controlled by the signer.
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.SignData(account, mimeType, data)
+}
+
+// SignText implements accounts.Wallet, requesting the signer to sign the hash
+// of the given text.
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	_, msg := accounts.TextAndHash(text)
+	return w.sign(account, []byte(msg))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since access to the keys is controlled by the signer.
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return w.SignText(account, text)
+}
+
+// SignTx implements accounts.Wallet, requesting the signer to sign the given
+// transaction using the latest signer of the given chain.
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	preimage, err := signingPreimage(tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the signer signs what it's supposed to
+	if crypto.Keccak256Hash(preimage) != signer.Hash(tx) {
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	sig, err := w.sign(account, preimage)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since access to the keys is controlled by the signer.
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignTx(account, tx, chainID)
+}
+
+// sign requests the signer to sign the hash of the given data with the key of
+// the account, returning the signature in the [R || S || V] format with V being
+// 0 or 1.
+func (w *Wallet) sign(account accounts.Account, data []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+	defer cancel()
+
+	// Keys might have been loaded since the last listing
+	if !w.Contains(account) {
+		if err := w.refresh(ctx); err != nil {
+			return nil, err
+		}
+		if !w.Contains(account) {
+			return nil, accounts.ErrUnknownAccount
+		}
+	}
+	w.lock.RLock()
+	key := w.keys[account.Address]
+	w.lock.RUnlock()
+
+	res, err := w.call(ctx, http.MethodPost, "/api/v1/eth1/sign/"+key, map[string]string{"data": hexutil.Encode(data)})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hexutil.Decode(strings.Trim(strings.TrimSpace(string(res)), `"`))
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature %q", res)
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	// Make sure the signature was made by the requested key
+	pubkey, err := crypto.SigToPub(crypto.Keccak256(data), sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != account.Address {
+		return nil, fmt.Errorf("signer mismatch: expected %s, got %s", account.Address.Hex(), signer.Hex())
+	}
+	return sig, nil
+}
+
+// refresh lists the public keys held by the signer.
+func (w *Wallet) refresh(ctx context.Context) error {
+	res, err := w.call(ctx, http.MethodGet, "/api/v1/eth1/publicKeys", nil)
+	if err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+	var pubkeys []string
+	if err := json.Unmarshal(res, &pubkeys); err != nil {
+		return fmt.Errorf("failed to list keys: %w", err)
+	}
+	keys := make(map[common.Address]string, len(pubkeys))
+	for _, pubkey := range pubkeys {
+		blob, err := hexutil.Decode(pubkey)
+		if err != nil {
+			return fmt.Errorf("invalid public key %q: %w", pubkey, err)
+		}
+		// Public keys may or may not be prefixed with the uncompressed point marker
+		if len(blob) == 64 {
+			blob = append([]byte{0x04}, blob...)
+		}
+		key, err := crypto.UnmarshalPubkey(blob)
+		if err != nil {
+			return fmt.Errorf("invalid public key %q: %w", pubkey, err)
+		}
+		keys[crypto.PubkeyToAddress(*key)] = pubkey
+	}
+	w.lock.Lock()
+	w.keys = keys
+	w.lock.Unlock()
+	return nil
+}
+
+// call sends a request to the signer, returning the body of the response.
+func (w *Wallet) call(ctx context.Context, method string, path string, args any) ([]byte, error) {
+	var body io.Reader
+	if args != nil {
+		blob, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(blob)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, w.endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if args != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	res, err := w.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	blob, err := io.ReadAll(io.LimitReader(res.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", res.Status, bytes.TrimSpace(blob))
+	}
+	return blob, nil
+}
+
+// signingPreimage returns the data whose hash is signed by the latest signer of
+// the given chain, which the types package only exposes hashed.
+func signingPreimage(tx *types.Transaction, chainID *big.Int) ([]byte, error) {
+	var fields []any
+	switch tx.Type() {
+	case types.LegacyTxType:
+		fields = []any{tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data()}
+		if chainID != nil {
+			fields = append(fields, chainID, uint(0), uint(0))
+		}
+		return rlp.EncodeToBytes(fields)
+	case types.AccessListTxType:
+		fields = []any{chainID, tx.Nonce(), tx.GasPrice(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()}
+	case types.DynamicFeeTxType:
+		fields = []any{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList()}
+	case types.BlobTxType:
+		fields = []any{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList(), tx.BlobGasFeeCap(), tx.BlobHashes()}
+	case types.SetCodeTxType:
+		fields = []any{chainID, tx.Nonce(), tx.GasTipCap(), tx.GasFeeCap(), tx.Gas(), tx.To(), tx.Value(), tx.Data(), tx.AccessList(), tx.SetCodeAuthorizations()}
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", tx.Type())
+	}
+	if chainID == nil {
+		return nil, errors.New("typed transactions require a chain ID")
+	}
+	blob, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{tx.Type()}, blob...), nil
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package web3signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+)
+
+// newTestSigner creates a server implementing the signing API of Web3Signer for
+// the given key, returning signatures with V being 27 or 28 like it does.
+func newTestSigner(key *ecdsa.PrivateKey) *httptest.Server {
+	pubkey := hexutil.Encode(crypto.FromECDSAPub(&key.PublicKey)[1:])
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /upcheck", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+	mux.HandleFunc("GET /api/v1/eth1/publicKeys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]string{pubkey})
+	})
+	mux.HandleFunc("POST /api/v1/eth1/sign/{identifier}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("identifier") != pubkey {
+			http.Error(w, "Signer not found", http.StatusNotFound)
+			return
+		}
+		var req struct {
+			Data hexutil.Bytes `json:"data"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		sig, _ := crypto.Sign(crypto.Keccak256(req.Data), key)
+		sig[64] += 27
+		w.Write([]byte(hexutil.Encode(sig)))
+	})
+	return httptest.NewUnstartedServer(mux)
+}
+
+func TestWalletSign(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	server := newTestSigner(key)
+	server.Start()
+	defer server.Close()
+
+	backend, err := NewBackend(server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	wallet := backend.Wallets()[0]
+
+	accs := wallet.Accounts()
+	if len(accs) != 1 || accs[0].Address != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Fatalf("account mismatch: have %v, want %v", accs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	account := accs[0]
+
+	// Messages must be signed by their hash
+	sig, err := wallet.SignText(account, []byte("hello"))
+	if err != nil {
+		t.Fatalf("failed to sign text: %v", err)
+	}
+	if pub, err := crypto.SigToPub(accounts.TextHash([]byte("hello")), sig); err != nil || crypto.PubkeyToAddress(*pub) != account.Address {
+		t.Errorf("text signature not recoverable to account: %v", err)
+	}
+	// Transactions of all types must be signed by their hash
+	var (
+		chainID = big.NewInt(1337)
+		to      = common.HexToAddress("0x01")
+		txs     = []types.TxData{
+			&types.LegacyTx{Nonce: 1, GasPrice: big.NewInt(1), Gas: 21000, To: &to, Value: big.NewInt(1)},
+			&types.LegacyTx{Nonce: 2, GasPrice: big.NewInt(1), Gas: 100000, Data: []byte{0x60, 0x00}},
+			&types.AccessListTx{ChainID: chainID, Nonce: 3, GasPrice: big.NewInt(1), Gas: 21000, To: &to, AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{}}}}},
+			&types.DynamicFeeTx{ChainID: chainID, Nonce: 4, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21000, To: &to},
+			&types.BlobTx{ChainID: uint256.MustFromBig(chainID), Nonce: 5, GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2), Gas: 21000, To: to, BlobFeeCap: uint256.NewInt(3), BlobHashes: []common.Hash{{0x01}}},
+			&types.SetCodeTx{ChainID: uint256.MustFromBig(chainID), Nonce: 6, GasTipCap: uint256.NewInt(1), GasFeeCap: uint256.NewInt(2), Gas: 50000, To: to, AuthList: []types.SetCodeAuthorization{{ChainID: *uint256.MustFromBig(chainID), Address: to, Nonce: 7}}},
+		}
+	)
+	for i, data := range txs {
+		signed, err := wallet.SignTx(account, types.NewTx(data), chainID)
+		if err != nil {
+			t.Fatalf("tx %d: failed to sign transaction: %v", i, err)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+		if err != nil {
+			t.Fatalf("tx %d: failed to recover sender: %v", i, err)
+		}
+		if sender != account.Address {
+			t.Errorf("tx %d: sender mismatch: have %v, want %v", i, sender, account.Address)
+		}
+	}
+	// Unknown accounts must be rejected
+	if _, err := wallet.SignText(accounts.Account{Address: common.HexToAddress("0x02")}, []byte("hello")); err != accounts.ErrUnknownAccount {
+		t.Errorf("signing with unknown account: have %v, want %v", err, accounts.ErrUnknownAccount)
+	}
+}
+
+func TestWalletClientAuth(t *testing.T) {
+	dir := t.TempDir()
+
+	// Create a client certificate, trusted by the server
+	clientKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "geth"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &clientKey.PublicKey, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCert, _ := x509.ParseCertificate(der)
+	keyDER, _ := x509.MarshalECPrivateKey(clientKey)
+
+	os.WriteFile(filepath.Join(dir, "client.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	os.WriteFile(filepath.Join(dir, "client.key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+
+	// Start a server requiring the client certificate
+	key, _ := crypto.GenerateKey()
+	server := newTestSigner(key)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: x509.NewCertPool()}
+	server.TLS.ClientCAs.AddCert(clientCert)
+	server.StartTLS()
+	defer server.Close()
+
+	os.WriteFile(filepath.Join(dir, "ca.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)
+
+	// Connecting without the client certificate must fail
+	config, err := LoadTLSConfig("", "", filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		t.Fatalf("failed to load TLS config: %v", err)
+	}
+	if _, err := NewWallet(server.URL, config); err == nil {
+		t.Fatalf("connected without client certificate")
+	}
+	// Connecting with the client certificate must succeed
+	config, err = LoadTLSConfig(filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key"), filepath.Join(dir, "ca.crt"))
+	if err != nil {
+		t.Fatalf("failed to load TLS config: %v", err)
+	}
+	wallet, err := NewWallet(server.URL, config)
+	if err != nil {
+		t.Fatalf("failed to connect with client certificate: %v", err)
+	}
+	if url := wallet.URL().String(); !strings.HasPrefix(url, "web3signer://127.0.0.1:") {
+		t.Errorf("wallet URL mismatch: have %s", url)
+	}
+	if _, err := wallet.SignText(wallet.Accounts()[0], []byte("hello")); err != nil {
+		t.Errorf("failed to sign text: %v", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -61,6 +62,31 @@ func (err *AmbiguousAddrError) Error() string {
 	return fmt.Sprintf("multiple keys match address (%s)", files)
 }
 
+// KeyFileEventType represents the different changes of key files in the keystore
+// directory.
+type KeyFileEventType int
+
+const (
+	// KeyFileAdded is fired when a key file appears in the keystore directory.
+	KeyFileAdded KeyFileEventType = iota
+
+	// KeyFileRemoved is fired when a key file disappears from the keystore
+	// directory, or is modified such that it no longer contains a valid key.
+	KeyFileRemoved
+
+	// KeyFileModified is fired when the content of a key file changes, e.g. when
+	// the key is rotated or re-encrypted.
+	KeyFileModified
+)
+
+// KeyFileEvent is an event fired by the keystore when a change of its directory
+// is detected. Changes are reported regardless of whether they were made through
+// the keystore or externally, e.g. by configuration management tools.
+type KeyFileEvent struct {
+	Account accounts.Account // Account of the key file, as cached after the change
+	Kind    KeyFileEventType // Kind of change that happened to the file
+}
+
 // accountCache is a live index of all accounts in the keystore.
 type accountCache struct {
 	keydir   string
@@ -71,6 +97,7 @@ type accountCache struct {
 	throttle *time.Timer
 	notify   chan struct{}
 	fileC    fileCache
+	fileFeed event.Feed // Feed of key file changes detected while scanning
 }
 
 func newAccountCache(keydir string) (*accountCache, chan struct{}) {
@@ -128,8 +155,9 @@ func (ac *accountCache) delete(removed accounts.Account) {
 	}
 }
 
-// deleteByFile removes an account referenced by the given path.
-func (ac *accountCache) deleteByFile(path string) {
+// deleteByFile removes an account referenced by the given path, returning the
+// removed account if there was one.
+func (ac *accountCache) deleteByFile(path string) (accounts.Account, bool) {
 	ac.mu.Lock()
 	defer ac.mu.Unlock()
 	i := sort.Search(len(ac.all), func(i int) bool { return ac.all[i].URL.Path >= path })
@@ -142,7 +170,9 @@ func (ac *accountCache) deleteByFile(path string) {
 		} else {
 			ac.byAddr[removed.Address] = ba
 		}
+		return removed, true
 	}
+	return accounts.Account{}, false
 }
 
 // watcherStarted returns true if the watcher loop started running (even if it
@@ -280,20 +310,32 @@ func (ac *accountCache) scanAccounts() error {
 		return nil
 	}
 	// Process all the file diffs
-	start := time.Now()
-
+	var (
+		start  = time.Now()
+		events []KeyFileEvent
+	)
 	for _, path := range creates.ToSlice() {
 		if a := readAccount(path); a != nil {
 			ac.add(*a)
+			events = append(events, KeyFileEvent{Account: *a, Kind: KeyFileAdded})
 		}
 	}
 	for _, path := range deletes.ToSlice() {
-		ac.deleteByFile(path)
+		if a, ok := ac.deleteByFile(path); ok {
+			events = append(events, KeyFileEvent{Account: a, Kind: KeyFileRemoved})
+		}
 	}
 	for _, path := range updates.ToSlice() {
-		ac.deleteByFile(path)
-		if a := readAccount(path); a != nil {
+		old, existed := ac.deleteByFile(path)
+		switch a := readAccount(path); {
+		case a != nil && existed:
+			ac.add(*a)
+			events = append(events, KeyFileEvent{Account: *a, Kind: KeyFileModified})
+		case a != nil:
 			ac.add(*a)
+			events = append(events, KeyFileEvent{Account: *a, Kind: KeyFileAdded})
+		case existed:
+			events = append(events, KeyFileEvent{Account: old, Kind: KeyFileRemoved})
 		}
 	}
 	end := time.Now()
@@ -302,6 +344,9 @@ func (ac *accountCache) scanAccounts() error {
 	case ac.notify <- struct{}{}:
 	default:
 	}
+	for _, ev := range events {
+		ac.fileFeed.Send(ev)
+	}
 	log.Trace("Handled keystore changes", "time", end.Sub(start))
 	return nil
 }
@@ -397,6 +397,52 @@ func TestUpdatedKeyfileContents(t *testing.T) {
 	}
 }
 
+// TestKeyFileEvents tests that changes of key files made externally are reported
+// to the subscribers of the keystore.
+func TestKeyFileEvents(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ks := NewKeyStore(dir, LightScryptN, LightScryptP)
+
+	events := make(chan KeyFileEvent, 8)
+	sub := ks.SubscribeKeyFiles(events)
+	defer sub.Unsubscribe()
+
+	if !waitWatcherStart(ks) {
+		t.Fatal("keystore watcher didn't start in time")
+	}
+	file := filepath.Join(dir, "aaa")
+	expect := func(kind KeyFileEventType, want accounts.Account) {
+		t.Helper()
+		want.URL = accounts.URL{Scheme: KeyStoreScheme, Path: file}
+		select {
+		case ev := <-events:
+			if ev.Kind != kind || ev.Account != want {
+				t.Fatalf("event mismatch: have %v %v, want %v %v", ev.Kind, ev.Account, kind, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for event %v", kind)
+		}
+	}
+	// Adding, rotating and removing a key must all be reported
+	if err := cp.CopyFile(file, cachetestAccounts[0].URL.Path); err != nil {
+		t.Fatal(err)
+	}
+	expect(KeyFileAdded, cachetestAccounts[0])
+
+	os.Chtimes(file, time.Now().Add(-time.Second), time.Now().Add(-time.Second))
+	if err := forceCopyFile(file, cachetestAccounts[1].URL.Path); err != nil {
+		t.Fatal(err)
+	}
+	expect(KeyFileModified, cachetestAccounts[1])
+
+	if err := os.Remove(file); err != nil {
+		t.Fatal(err)
+	}
+	expect(KeyFileRemoved, cachetestAccounts[1])
+}
+
 // forceCopyFile is like cp.CopyFile, but doesn't complain if the destination exists.
 func forceCopyFile(dst, src string) error {
 	data, err := os.ReadFile(src)
@@ -0,0 +1,192 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// backupVersion is the version of the backup bundle format.
+const backupVersion = 1
+
+// ErrInvalidBackup is returned if a backup bundle is malformed or of an unknown
+// version.
+var ErrInvalidBackup = errors.New("invalid backup bundle")
+
+// ConflictPolicy defines how restoring a backup handles keys of accounts that
+// are already present in the keystore.
+type ConflictPolicy int
+
+const (
+	// ConflictFail aborts the restore without storing any key if any account of
+	// the backup is already present in the keystore.
+	ConflictFail ConflictPolicy = iota
+
+	// ConflictSkip keeps the existing key files, restoring only the accounts not
+	// yet present in the keystore.
+	ConflictSkip
+
+	// ConflictReplace overwrites the existing key files with the keys of the
+	// backup, e.g. to revert a passphrase change.
+	ConflictReplace
+)
+
+// backupJSON is the outer, unencrypted layer of a backup bundle.
+type backupJSON struct {
+	Version int        `json:"version"`
+	Crypto  CryptoJSON `json:"crypto"`
+}
+
+// backupContentJSON is the encrypted content of a backup bundle.
+type backupContentJSON struct {
+	Created time.Time       `json:"created"`
+	Keys    []backupKeyJSON `json:"keys"`
+}
+
+// backupKeyJSON is a single key file in a backup bundle, stored verbatim along
+// with the metadata of the account.
+type backupKeyJSON struct {
+	Address  common.Address  `json:"address"`
+	Filename string          `json:"filename"`
+	Modified time.Time       `json:"modified"`
+	Key      json.RawMessage `json:"key"`
+}
+
+// ExportAll creates a backup bundle of all the keys in the keystore, encrypted
+// and authenticated with the passphrase. The key files are stored verbatim, so
+// the keys themselves remain encrypted with their own passphrases.
+func (ks *KeyStore) ExportAll(passphrase string) ([]byte, error) {
+	content := backupContentJSON{Created: time.Now().UTC(), Keys: []backupKeyJSON{}}
+	for _, a := range ks.cache.accounts() {
+		info, err := os.Stat(a.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		keyJSON, err := os.ReadFile(a.URL.Path)
+		if err != nil {
+			return nil, err
+		}
+		if !json.Valid(keyJSON) {
+			return nil, fmt.Errorf("invalid key file %s", a.URL.Path)
+		}
+		content.Keys = append(content.Keys, backupKeyJSON{
+			Address:  a.Address,
+			Filename: info.Name(),
+			Modified: info.ModTime().UTC(),
+			Key:      keyJSON,
+		})
+	}
+	plain, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	crypto, err := ks.encryptData(plain, []byte(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(backupJSON{Version: backupVersion, Crypto: crypto})
+}
+
+// ImportAll restores the keys of a backup bundle created by ExportAll, which is
+// decrypted with the passphrase. Keys of accounts already present in the keystore
+// are handled according to the conflict policy. The restored accounts are
+// returned, excluding the skipped ones.
+func (ks *KeyStore) ImportAll(backup []byte, passphrase string, policy ConflictPolicy) ([]accounts.Account, error) {
+	var outer backupJSON
+	if err := json.Unmarshal(backup, &outer); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	if outer.Version != backupVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, outer.Version)
+	}
+	plain, err := DecryptDataV3(outer.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	var content backupContentJSON
+	if err := json.Unmarshal(plain, &content); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	// Ensure the key files are for the accounts they claim to be before touching
+	// the keystore, so that a bad bundle is rejected as a whole
+	for _, key := range content.Keys {
+		var header struct {
+			Address string `json:"address"`
+		}
+		if err := json.Unmarshal(key.Key, &header); err != nil {
+			return nil, fmt.Errorf("%w: key of %s: %v", ErrInvalidBackup, key.Address.Hex(), err)
+		}
+		if common.HexToAddress(header.Address) != key.Address {
+			return nil, fmt.Errorf("%w: key of %s is for %s", ErrInvalidBackup, key.Address.Hex(), header.Address)
+		}
+	}
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	if policy == ConflictFail {
+		for _, key := range content.Keys {
+			if ks.cache.hasAddress(key.Address) {
+				return nil, fmt.Errorf("%w: %s", ErrAccountAlreadyExists, key.Address.Hex())
+			}
+		}
+	}
+	var restored []accounts.Account
+	for _, key := range content.Keys {
+		// Overwrite the existing key file on conflict, or store a new one
+		a := accounts.Account{Address: key.Address}
+		if ks.cache.hasAddress(key.Address) {
+			if policy == ConflictSkip {
+				continue
+			}
+			existing, err := ks.cache.find(a)
+			if err != nil {
+				return restored, err
+			}
+			a = existing
+		} else {
+			a.URL = accounts.URL{Scheme: KeyStoreScheme, Path: ks.storage.JoinPath(keyFileName(key.Address))}
+		}
+		if err := writeKeyFile(a.URL.Path, key.Key); err != nil {
+			return restored, err
+		}
+		ks.cache.add(a)
+		restored = append(restored, a)
+	}
+	ks.refreshWallets()
+	return restored, nil
+}
+
+// encryptData encrypts the data with the passphrase, deriving the encryption key
+// with the KDF and parameters used by the keystore for key files.
+func (ks *KeyStore) encryptData(data, auth []byte) (CryptoJSON, error) {
+	store, ok := ks.storage.(*keyStorePassphrase)
+	switch {
+	case !ok:
+		return EncryptDataV3(data, auth, StandardScryptN, StandardScryptP)
+	case store.argon2T != 0:
+		return EncryptDataV3Argon2(data, auth, store.argon2T, store.argon2M, store.argon2P)
+	default:
+		return EncryptDataV3(data, auth, store.scryptN, store.scryptP)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+func TestExportImportAll(t *testing.T) {
+	t.Parallel()
+	_, ks := tmpKeyStore(t)
+
+	a1, err := ks.NewAccount("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a2, err := ks.NewAccount("bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup, err := ks.ExportAll("backup")
+	if err != nil {
+		t.Fatalf("failed to export keystore: %v", err)
+	}
+	// Restoring into an empty keystore must yield the same keys
+	_, restore := tmpKeyStore(t)
+	if _, err := restore.ImportAll(backup, "wrong", ConflictFail); err != ErrDecrypt {
+		t.Fatalf("restoring with wrong passphrase: have %v, want %v", err, ErrDecrypt)
+	}
+	restored, err := restore.ImportAll(backup, "backup", ConflictFail)
+	if err != nil {
+		t.Fatalf("failed to restore keystore: %v", err)
+	}
+	if len(restored) != 2 {
+		t.Fatalf("restored account count mismatch: have %d, want 2", len(restored))
+	}
+	for _, acc := range []struct {
+		account    accounts.Account
+		passphrase string
+	}{{a1, "foo"}, {a2, "bar"}} {
+		if err := restore.Unlock(accounts.Account{Address: acc.account.Address}, acc.passphrase); err != nil {
+			t.Errorf("failed to unlock restored account %x: %v", acc.account.Address, err)
+		}
+	}
+	// Restoring again must handle the conflicts according to the policy
+	if _, err := restore.ImportAll(backup, "backup", ConflictFail); !errors.Is(err, ErrAccountAlreadyExists) {
+		t.Errorf("restoring existing accounts: have %v, want %v", err, ErrAccountAlreadyExists)
+	}
+	if err := restore.Update(accounts.Account{Address: a1.Address}, "foo", "baz"); err != nil {
+		t.Fatal(err)
+	}
+	if restored, err := restore.ImportAll(backup, "backup", ConflictSkip); err != nil || len(restored) != 0 {
+		t.Errorf("restoring with skipped conflicts: have %d accounts, %v, want none", len(restored), err)
+	}
+	if err := restore.Unlock(accounts.Account{Address: a1.Address}, "baz"); err != nil {
+		t.Errorf("skipped key was overwritten: %v", err)
+	}
+	if restored, err := restore.ImportAll(backup, "backup", ConflictReplace); err != nil || len(restored) != 2 {
+		t.Errorf("restoring with replaced conflicts: have %d accounts, %v, want 2", len(restored), err)
+	}
+	if err := restore.Unlock(accounts.Account{Address: a1.Address}, "foo"); err != nil {
+		t.Errorf("replaced key was not overwritten: %v", err)
+	}
+	if accs := restore.Accounts(); len(accs) != 2 {
+		t.Errorf("account count mismatch: have %d, want 2", len(accs))
+	}
+	// Malformed bundles must be rejected
+	if _, err := restore.ImportAll([]byte(`{"version":2}`), "backup", ConflictSkip); !errors.Is(err, ErrInvalidBackup) {
+		t.Errorf("restoring unknown version: have %v, want %v", err, ErrInvalidBackup)
+	}
+}
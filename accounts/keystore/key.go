@@ -45,6 +45,8 @@ type Key struct {
 	// we only store privkey as pubkey/address can be derived from it
 	// privkey in this struct is always in plaintext
 	PrivateKey *ecdsa.PrivateKey
+	// path the key was derived at from a mnemonic, nil for random keys
+	DerivationPath accounts.DerivationPath
 }
 
 type keyStore interface {
@@ -57,17 +59,19 @@ type keyStore interface {
 }
 
 type plainKeyJSON struct {
-	Address    string `json:"address"`
-	PrivateKey string `json:"privatekey"`
-	Id         string `json:"id"`
-	Version    int    `json:"version"`
+	Address        string `json:"address"`
+	PrivateKey     string `json:"privatekey"`
+	Id             string `json:"id"`
+	Version        int    `json:"version"`
+	DerivationPath string `json:"derivationpath,omitempty"`
 }
 
 type encryptedKeyJSONV3 struct {
-	Address string     `json:"address"`
-	Crypto  CryptoJSON `json:"crypto"`
-	Id      string     `json:"id"`
-	Version int        `json:"version"`
+	Address        string     `json:"address"`
+	Crypto         CryptoJSON `json:"crypto"`
+	Id             string     `json:"id"`
+	Version        int        `json:"version"`
+	DerivationPath string     `json:"derivationpath,omitempty"`
 }
 
 type encryptedKeyJSONV1 struct {
@@ -96,6 +100,7 @@ func (k *Key) MarshalJSON() (j []byte, err error) {
 		hex.EncodeToString(crypto.FromECDSA(k.PrivateKey)),
 		k.Id.String(),
 		version,
+		derivationPathString(k.DerivationPath),
 	}
 	j, err = json.Marshal(jStruct)
 	return j, err
@@ -125,8 +130,26 @@ func (k *Key) UnmarshalJSON(j []byte) (err error) {
 
 	k.Address = common.BytesToAddress(addr)
 	k.PrivateKey = privkey
+	k.DerivationPath, err = parseDerivationPath(keyJSON.DerivationPath)
+
+	return err
+}
 
-	return nil
+// derivationPathString returns the textual form of a derivation path stored in
+// key files, which is empty for keys not derived from a mnemonic.
+func derivationPathString(path accounts.DerivationPath) string {
+	if path == nil {
+		return ""
+	}
+	return path.String()
+}
+
+// parseDerivationPath parses a derivation path stored in a key file.
+func parseDerivationPath(path string) (accounts.DerivationPath, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return accounts.ParseDerivationPath(path)
 }
 
 func newKeyFromECDSA(privateKeyECDSA *ecdsa.PrivateKey) *Key {
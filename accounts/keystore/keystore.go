@@ -60,10 +60,11 @@ const walletRefreshCycle = 3 * time.Second
 
 // KeyStore manages a key storage directory on disk.
 type KeyStore struct {
-	storage  keyStore                     // Storage backend, might be cleartext or encrypted
-	cache    *accountCache                // In-memory account cache over the filesystem storage
-	changes  chan struct{}                // Channel receiving change notifications from the cache
-	unlocked map[common.Address]*unlocked // Currently unlocked account (decrypted private keys)
+	storage  keyStore                         // Storage backend, might be cleartext or encrypted
+	cache    *accountCache                    // In-memory account cache over the filesystem storage
+	changes  chan struct{}                    // Channel receiving change notifications from the cache
+	unlocked map[common.Address]*unlocked     // Currently unlocked account (decrypted private keys)
+	policies map[common.Address]*UnlockPolicy // Policies restricting accounts while unlocked
 
 	wallets     []accounts.Wallet       // Wallet wrappers around the individual key files
 	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
@@ -77,12 +78,25 @@ type KeyStore struct {
 type unlocked struct {
 	*Key
 	abort chan struct{}
+
+	policy     *UnlockPolicy // Policy restricting the key, nil if unrestricted
+	signatures uint64        // Number of signatures produced since unlocking
 }
 
 // NewKeyStore creates a keystore for the given directory.
 func NewKeyStore(keydir string, scryptN, scryptP int) *KeyStore {
 	keydir, _ = filepath.Abs(keydir)
-	ks := &KeyStore{storage: &keyStorePassphrase{keydir, scryptN, scryptP, false}}
+	ks := &KeyStore{storage: &keyStorePassphrase{keysDirPath: keydir, scryptN: scryptN, scryptP: scryptP}}
+	ks.init(keydir)
+	return ks
+}
+
+// NewArgon2KeyStore creates a keystore for the given directory, which encrypts
+// new and updated keys with argon2id instead of scrypt, using the given number
+// of passes, memory in KiB and lanes. Existing scrypt keys remain accessible.
+func NewArgon2KeyStore(keydir string, argon2T, argon2M uint32, argon2P uint8) *KeyStore {
+	keydir, _ = filepath.Abs(keydir)
+	ks := &KeyStore{storage: &keyStorePassphrase{keysDirPath: keydir, argon2T: argon2T, argon2M: argon2M, argon2P: argon2P}}
 	ks.init(keydir)
 	return ks
 }
@@ -94,6 +108,7 @@ func (ks *KeyStore) init(keydir string) {
 
 	// Initialize the set of unlocked keys and the account cache
 	ks.unlocked = make(map[common.Address]*unlocked)
+	ks.policies = make(map[common.Address]*UnlockPolicy)
 	ks.cache, ks.changes = newAccountCache(keydir)
 
 	// TODO: In order for this finalizer to work, there must be no references
@@ -190,6 +205,23 @@ func (ks *KeyStore) Subscribe(sink chan<- accounts.WalletEvent) event.Subscripti
 	return sub
 }
 
+// SubscribeKeyFiles creates an async subscription to receive notifications when
+// key files are added to, removed from or modified in the keystore directory,
+// allowing long-running services to react to keys rotated externally.
+func (ks *KeyStore) SubscribeKeyFiles(sink chan<- KeyFileEvent) event.Subscription {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	// Track the subscriber along the wallet ones, as the directory is only
+	// rescanned while the notification loop is running on some platforms
+	sub := ks.updateScope.Track(ks.cache.fileFeed.Subscribe(sink))
+	if !ks.updating {
+		ks.updating = true
+		go ks.updater()
+	}
+	return sub
+}
+
 // updater is responsible for maintaining an up-to-date list of wallets stored in
 // the keystore, and for firing wallet addition/removal events. It listens for
 // account change events from the underlying account cache, and also periodically
@@ -256,28 +288,38 @@ func (ks *KeyStore) Delete(a accounts.Account, passphrase string) error {
 // SignHash calculates a ECDSA signature for the given hash. The produced
 // signature is in the [R || S || V] format where V is 0 or 1.
 func (ks *KeyStore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
-	// Look up the key to sign with and abort if it cannot be found
-	ks.mu.RLock()
-	defer ks.mu.RUnlock()
+	return ks.signHash(a, hash, true)
+}
+
+// signHash calculates a ECDSA signature for the given hash, which is the hash of
+// a text message unless raw, as far as unlock policies are concerned.
+func (ks *KeyStore) signHash(a accounts.Account, hash []byte, raw bool) ([]byte, error) {
+	// Look up the key to sign with and abort if it cannot be found or used
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
 
-	unlockedKey, found := ks.unlocked[a.Address]
-	if !found {
-		return nil, ErrLocked
+	unlockedKey, err := ks.authorize(a.Address, nil, raw)
+	if err != nil {
+		return nil, err
 	}
+	defer ks.consume(a.Address, unlockedKey)
+
 	// Sign the hash using plain ECDSA operations
 	return crypto.Sign(hash, unlockedKey.PrivateKey)
 }
 
 // SignTx signs the given transaction with the requested account.
 func (ks *KeyStore) SignTx(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
-	// Look up the key to sign with and abort if it cannot be found
-	ks.mu.RLock()
-	defer ks.mu.RUnlock()
+	// Look up the key to sign with and abort if it cannot be found or used
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
 
-	unlockedKey, found := ks.unlocked[a.Address]
-	if !found {
-		return nil, ErrLocked
+	unlockedKey, err := ks.authorize(a.Address, tx, false)
+	if err != nil {
+		return nil, err
 	}
+	defer ks.consume(a.Address, unlockedKey)
+
 	// Depending on the presence of the chain ID, sign with 2718 or homestead
 	signer := types.LatestSignerForChainID(chainID)
 	return types.SignTx(tx, signer, unlockedKey.PrivateKey)
@@ -331,6 +373,9 @@ func (ks *KeyStore) Lock(addr common.Address) error {
 // If the account address is already unlocked for a duration, TimedUnlock extends or
 // shortens the active unlock timeout. If the address was previously unlocked
 // indefinitely the timeout is not altered.
+//
+// If the account has an unlock policy, the timeout is capped by its maximum
+// duration, and the count of signatures allowed is restarted.
 func (ks *KeyStore) TimedUnlock(a accounts.Account, passphrase string, timeout time.Duration) error {
 	a, key, err := ks.getDecryptedKey(a, passphrase)
 	if err != nil {
@@ -339,6 +384,10 @@ func (ks *KeyStore) TimedUnlock(a accounts.Account, passphrase string, timeout t
 
 	ks.mu.Lock()
 	defer ks.mu.Unlock()
+	policy := ks.policies[a.Address]
+	if policy != nil && policy.MaxDuration > 0 && (timeout == 0 || timeout > policy.MaxDuration) {
+		timeout = policy.MaxDuration
+	}
 	u, found := ks.unlocked[a.Address]
 	if found {
 		if u.abort == nil {
@@ -351,10 +400,10 @@ func (ks *KeyStore) TimedUnlock(a accounts.Account, passphrase string, timeout t
 		close(u.abort)
 	}
 	if timeout > 0 {
-		u = &unlocked{Key: key, abort: make(chan struct{})}
+		u = &unlocked{Key: key, abort: make(chan struct{}), policy: policy}
 		go ks.expire(a.Address, u, timeout)
 	} else {
-		u = &unlocked{Key: key}
+		u = &unlocked{Key: key, policy: policy}
 	}
 	ks.unlocked[a.Address] = u
 	return nil
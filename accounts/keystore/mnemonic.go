@@ -0,0 +1,224 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrInvalidMnemonic is returned if a mnemonic isn't a valid BIP-39 sentence of
+// the English wordlist.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+// bip39English is the English wordlist of BIP-39, one word per line.
+//
+//go:embed bip39_english.txt
+var bip39English string
+
+// bip39Words is the sorted English wordlist of BIP-39.
+var bip39Words = strings.Fields(bip39English)
+
+// NewSeedFromMnemonic validates the given BIP-39 mnemonic and derives the seed of
+// the hierarchical deterministic wallet from it, protected by the optional BIP-39
+// passphrase (not to be confused with the passphrase encrypting key files).
+func NewSeedFromMnemonic(mnemonic string, passphrase string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if err := validateMnemonic(words); err != nil {
+		return nil, err
+	}
+	password := []byte(strings.Join(words, " "))
+	salt := []byte("mnemonic" + norm.NFKD.String(passphrase))
+	return pbkdf2.Key(password, salt, 2048, 64, sha512.New), nil
+}
+
+// validateMnemonic checks that the words are in the wordlist, and that their
+// checksum matches the entropy they encode.
+func validateMnemonic(words []string) error {
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return fmt.Errorf("%w: %d words", ErrInvalidMnemonic, len(words))
+	}
+	// Every word encodes 11 bits, of which a 32nd is checksum
+	var (
+		bits     = new(big.Int)
+		total    = 11 * len(words)
+		checksum = total / 33
+	)
+	for _, word := range words {
+		index, ok := slices.BinarySearch(bip39Words, word)
+		if !ok {
+			return fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, word)
+		}
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(int64(index)))
+	}
+	have := new(big.Int).And(bits, big.NewInt(1<<checksum-1)).Uint64()
+	entropy := math.PaddedBigBytes(bits.Rsh(bits, uint(checksum)), (total-checksum)/8)
+
+	hash := sha256.Sum256(entropy)
+	if want := uint64(hash[0] >> (8 - checksum)); have != want {
+		return fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	}
+	return nil
+}
+
+// DeriveKey derives the private key at the given BIP-32 path from the seed of a
+// hierarchical deterministic wallet.
+func DeriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	var (
+		curveN = crypto.S256().Params().N
+		mac    = hmac.New(sha512.New, []byte("Bitcoin seed"))
+	)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	key, chainCode := new(big.Int).SetBytes(sum[:32]), sum[32:]
+	if key.Sign() == 0 || key.Cmp(curveN) >= 0 {
+		return nil, errors.New("invalid seed")
+	}
+	for _, index := range path {
+		// Hardened children are derived from the private key, normal ones from
+		// the public key
+		var data []byte
+		if index >= 0x80000000 {
+			data = append([]byte{0}, math.PaddedBigBytes(key, 32)...)
+		} else {
+			priv, err := crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&priv.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac = hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum = mac.Sum(nil)
+
+		tweak := new(big.Int).SetBytes(sum[:32])
+		if tweak.Cmp(curveN) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key.Add(key, tweak).Mod(key, curveN)
+		if key.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+	return crypto.ToECDSA(math.PaddedBigBytes(key, 32))
+}
+
+// ImportMnemonic derives count accounts from the given BIP-39 mnemonic, starting
+// at the given path and incrementing its last component, like hardware wallets
+// do. The keys are stored encrypted with the passphrase, along with the path they
+// were derived at. A nil path defaults to the BIP-44 path of Ethereum.
+//
+// No account is imported if any of them already exists.
+func (ks *KeyStore) ImportMnemonic(mnemonic string, passphrase string, path accounts.DerivationPath, count int) ([]accounts.Account, error) {
+	seed, err := NewSeedFromMnemonic(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	return ks.ImportSeed(seed, passphrase, path, count)
+}
+
+// ImportSeed derives count accounts from the given seed of a hierarchical
+// deterministic wallet, the same way as ImportMnemonic. It's meant for seeds of
+// mnemonics protected by a BIP-39 passphrase, see NewSeedFromMnemonic.
+func (ks *KeyStore) ImportSeed(seed []byte, passphrase string, path accounts.DerivationPath, count int) ([]accounts.Account, error) {
+	if path == nil {
+		path = accounts.DefaultBaseDerivationPath
+	}
+	if len(path) == 0 {
+		return nil, errors.New("empty derivation path")
+	}
+	var (
+		next = accounts.DefaultIterator(path)
+		keys = make([]*Key, 0, count)
+	)
+	defer func() {
+		for _, key := range keys {
+			zeroKey(key.PrivateKey)
+		}
+	}()
+	for range count {
+		path := next()
+		priv, err := DeriveKey(seed, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key at %v: %w", path, err)
+		}
+		key := newKeyFromECDSA(priv)
+		key.DerivationPath = slices.Clone(path)
+		keys = append(keys, key)
+	}
+	ks.importMu.Lock()
+	defer ks.importMu.Unlock()
+
+	for _, key := range keys {
+		if ks.cache.hasAddress(key.Address) {
+			return nil, fmt.Errorf("%w: %v derived at %v", ErrAccountAlreadyExists, key.Address, key.DerivationPath)
+		}
+	}
+	accs := make([]accounts.Account, 0, len(keys))
+	for _, key := range keys {
+		a, err := ks.importKey(key, passphrase)
+		if err != nil {
+			return accs, err
+		}
+		accs = append(accs, a)
+	}
+	return accs, nil
+}
+
+// DerivationPath returns the path the key of the given account was derived at by
+// ImportMnemonic or ImportSeed, or nil if it's not a derived key. The path is
+// stored unencrypted in the key file, so it's available for locked accounts.
+func (ks *KeyStore) DerivationPath(a accounts.Account) (accounts.DerivationPath, error) {
+	a, err := ks.Find(a)
+	if err != nil {
+		return nil, err
+	}
+	keyjson, err := os.ReadFile(a.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	var key struct {
+		DerivationPath string `json:"derivationpath"`
+	}
+	if err := json.Unmarshal(keyjson, &key); err != nil {
+		return nil, err
+	}
+	return parseDerivationPath(key.DerivationPath)
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/hex"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testMnemonic is the mnemonic of the default accounts of common development
+// tools, whose addresses are well known.
+const testMnemonic = "test test test test test test test test test test test junk"
+
+func TestNewSeedFromMnemonic(t *testing.T) {
+	tests := []struct {
+		mnemonic   string
+		passphrase string
+		seed       string
+		err        error
+	}{
+		// Test vectors of the reference implementation
+		{
+			mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			passphrase: "TREZOR",
+			seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			mnemonic:   "  abandon abandon abandon abandon abandon abandon\nabandon abandon abandon abandon abandon about ",
+			passphrase: "TREZOR",
+			seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		// Invalid mnemonics
+		{mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", err: ErrInvalidMnemonic},
+		{mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", err: ErrInvalidMnemonic},
+		{mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon etherum", err: ErrInvalidMnemonic},
+	}
+	for i, tt := range tests {
+		seed, err := NewSeedFromMnemonic(tt.mnemonic, tt.passphrase)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+			continue
+		}
+		if have := hex.EncodeToString(seed); have != tt.seed {
+			t.Errorf("test %d: seed mismatch: have %s, want %s", i, have, tt.seed)
+		}
+	}
+}
+
+func TestImportMnemonic(t *testing.T) {
+	_, ks := tmpKeyStore(t)
+
+	accs, err := ks.ImportMnemonic(testMnemonic, "foo", nil, 3)
+	if err != nil {
+		t.Fatalf("failed to import mnemonic: %v", err)
+	}
+	want := []common.Address{
+		common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"),
+		common.HexToAddress("0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"),
+	}
+	if len(accs) != len(want) {
+		t.Fatalf("account count mismatch: have %d, want %d", len(accs), len(want))
+	}
+	next := accounts.DefaultIterator(accounts.DefaultBaseDerivationPath)
+	for i, acc := range accs {
+		if acc.Address != want[i] {
+			t.Errorf("account %d: address mismatch: have %v, want %v", i, acc.Address, want[i])
+		}
+		// The path must be available without decrypting the key
+		path, err := ks.DerivationPath(acc)
+		if err != nil {
+			t.Fatalf("account %d: failed to retrieve derivation path: %v", i, err)
+		}
+		if want := next(); path.String() != want.String() {
+			t.Errorf("account %d: derivation path mismatch: have %v, want %v", i, path, want)
+		}
+	}
+	// The path must survive changing the passphrase
+	if err := ks.Update(accs[1], "foo", "bar"); err != nil {
+		t.Fatalf("failed to update passphrase: %v", err)
+	}
+	keyjson, err := os.ReadFile(accs[1].URL.Path)
+	if err != nil {
+		t.Fatalf("failed to read key file: %v", err)
+	}
+	key, err := DecryptKey(keyjson, "bar")
+	if err != nil {
+		t.Fatalf("failed to decrypt key: %v", err)
+	}
+	if have := key.DerivationPath.String(); have != "m/44'/60'/0'/0/1" {
+		t.Errorf("derivation path mismatch after update: have %s, want m/44'/60'/0'/0/1", have)
+	}
+	// Importing an overlapping range must not import anything
+	path, _ := accounts.ParseDerivationPath("m/44'/60'/0'/0/2")
+	if _, err := ks.ImportMnemonic(testMnemonic, "foo", path, 2); !errors.Is(err, ErrAccountAlreadyExists) {
+		t.Fatalf("importing existing account: have %v, want %v", err, ErrAccountAlreadyExists)
+	}
+	if n := len(ks.Accounts()); n != 3 {
+		t.Errorf("account count mismatch after failed import: have %d, want 3", n)
+	}
+	// Random keys have no derivation path
+	priv, _ := crypto.GenerateKey()
+	acc, err := ks.ImportECDSA(priv, "foo")
+	if err != nil {
+		t.Fatalf("failed to import key: %v", err)
+	}
+	if path, err := ks.DerivationPath(acc); err != nil || path != nil {
+		t.Errorf("derivation path of random key: have %v (%v), want nil", path, err)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -42,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/google/uuid"
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/pbkdf2"
 	"golang.org/x/crypto/scrypt"
 )
@@ -69,10 +71,48 @@ const (
 	scryptDKLen = 32
 )
 
+const (
+	argon2KDF = "argon2id"
+
+	// StandardArgon2T is the number of passes of the argon2id encryption algorithm,
+	// using 256MB memory and taking approximately 1s CPU time on a modern processor.
+	StandardArgon2T = 3
+
+	// StandardArgon2M is the memory in KiB of the argon2id encryption algorithm,
+	// using 256MB memory and taking approximately 1s CPU time on a modern processor.
+	StandardArgon2M = 256 * 1024
+
+	// LightArgon2T is the number of passes of the argon2id encryption algorithm,
+	// using 4MB memory and taking approximately 10ms CPU time on a modern processor.
+	LightArgon2T = 1
+
+	// LightArgon2M is the memory in KiB of the argon2id encryption algorithm,
+	// using 4MB memory and taking approximately 10ms CPU time on a modern processor.
+	LightArgon2M = 4 * 1024
+
+	// Argon2P is the number of lanes of the argon2id encryption algorithm, which
+	// is the degree of parallelism that can be used for hashing.
+	Argon2P = 4
+
+	argon2DKLen = 32
+
+	// maxArgon2T, maxArgon2M and maxArgon2DKLen bound the parameters of argon2id
+	// accepted from key files, so a crafted key can't exhaust the memory or hang
+	// the node on unlock.
+	maxArgon2T     = 64
+	maxArgon2M     = 4 * 1024 * 1024
+	maxArgon2DKLen = 64
+)
+
 type keyStorePassphrase struct {
 	keysDirPath string
 	scryptN     int
 	scryptP     int
+	// argon2T, argon2M and argon2P are the parameters of argon2id, used instead
+	// of scrypt to encrypt keys if argon2T is non-zero.
+	argon2T uint32
+	argon2M uint32
+	argon2P uint8
 	// skipKeyFileVerification disables the security-feature which does
 	// reads and decrypts any newly created keyfiles. This should be 'false' in all
 	// cases except tests -- setting this to 'true' is not recommended.
@@ -98,12 +138,20 @@ func (ks keyStorePassphrase) GetKey(addr common.Address, filename, auth string)
 
 // StoreKey generates a key, encrypts with 'auth' and stores in the given directory
 func StoreKey(dir, auth string, scryptN, scryptP int) (accounts.Account, error) {
-	_, a, err := storeNewKey(&keyStorePassphrase{dir, scryptN, scryptP, false}, rand.Reader, auth)
+	_, a, err := storeNewKey(&keyStorePassphrase{keysDirPath: dir, scryptN: scryptN, scryptP: scryptP}, rand.Reader, auth)
 	return a, err
 }
 
 func (ks keyStorePassphrase) StoreKey(filename string, key *Key, auth string) error {
-	keyjson, err := EncryptKey(key, auth, ks.scryptN, ks.scryptP)
+	var (
+		keyjson []byte
+		err     error
+	)
+	if ks.argon2T != 0 {
+		keyjson, err = EncryptKeyArgon2(key, auth, ks.argon2T, ks.argon2M, ks.argon2P)
+	} else {
+		keyjson, err = EncryptKey(key, auth, ks.scryptN, ks.scryptP)
+	}
 	if err != nil {
 		return err
 	}
@@ -146,6 +194,46 @@ func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error)
 	if err != nil {
 		return CryptoJSON{}, err
 	}
+	scryptParamsJSON := make(map[string]interface{}, 5)
+	scryptParamsJSON["n"] = scryptN
+	scryptParamsJSON["r"] = scryptR
+	scryptParamsJSON["p"] = scryptP
+	scryptParamsJSON["dklen"] = scryptDKLen
+	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
+
+	return encryptDataV3(data, derivedKey, keyHeaderKDF, scryptParamsJSON)
+}
+
+// EncryptDataV3Argon2 encrypts the data given as 'data' with the password 'auth',
+// deriving the encryption key with argon2id instead of scrypt. The passes, the
+// memory in KiB and the lanes of argon2id are stored in the KDF parameters as t,
+// m and p, along with the version of the algorithm.
+//
+// Note, argon2id is an extension of the Web3 Secret Storage Definition, so other
+// tools might not be able to decrypt the data.
+func EncryptDataV3Argon2(data, auth []byte, argon2T, argon2M uint32, argon2P uint8) (CryptoJSON, error) {
+	if argon2T == 0 || argon2T > maxArgon2T || argon2M > maxArgon2M || argon2P == 0 {
+		return CryptoJSON{}, errors.New("invalid argon2id parameters")
+	}
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		panic("reading from crypto/rand failed: " + err.Error())
+	}
+	derivedKey := argon2.IDKey(auth, salt, argon2T, argon2M, argon2P, argon2DKLen)
+
+	argon2ParamsJSON := make(map[string]interface{}, 6)
+	argon2ParamsJSON["t"] = argon2T
+	argon2ParamsJSON["m"] = argon2M
+	argon2ParamsJSON["p"] = argon2P
+	argon2ParamsJSON["version"] = argon2.Version
+	argon2ParamsJSON["dklen"] = argon2DKLen
+	argon2ParamsJSON["salt"] = hex.EncodeToString(salt)
+
+	return encryptDataV3(data, derivedKey, argon2KDF, argon2ParamsJSON)
+}
+
+// encryptDataV3 encrypts the data with the key derived by the given KDF.
+func encryptDataV3(data, derivedKey []byte, kdf string, kdfParams map[string]interface{}) (CryptoJSON, error) {
 	encryptKey := derivedKey[:16]
 
 	iv := make([]byte, aes.BlockSize) // 16
@@ -158,12 +246,6 @@ func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error)
 	}
 	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
 
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
-	scryptParamsJSON["dklen"] = scryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
 	}
@@ -172,8 +254,8 @@ func EncryptDataV3(data, auth []byte, scryptN, scryptP int) (CryptoJSON, error)
 		Cipher:       "aes-128-ctr",
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          keyHeaderKDF,
-		KDFParams:    scryptParamsJSON,
+		KDF:          kdf,
+		KDFParams:    kdfParams,
 		MAC:          hex.EncodeToString(mac),
 	}
 	return cryptoStruct, nil
@@ -187,11 +269,27 @@ func EncryptKey(key *Key, auth string, scryptN, scryptP int) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	return marshalKeyV3(key, cryptoStruct)
+}
+
+// EncryptKeyArgon2 encrypts a key using the specified argon2id parameters into a
+// json blob that can be decrypted later on.
+func EncryptKeyArgon2(key *Key, auth string, argon2T, argon2M uint32, argon2P uint8) ([]byte, error) {
+	keyBytes := math.PaddedBigBytes(key.PrivateKey.D, 32)
+	cryptoStruct, err := EncryptDataV3Argon2(keyBytes, []byte(auth), argon2T, argon2M, argon2P)
+	if err != nil {
+		return nil, err
+	}
+	return marshalKeyV3(key, cryptoStruct)
+}
+
+func marshalKeyV3(key *Key, cryptoStruct CryptoJSON) ([]byte, error) {
 	encryptedKeyJSONV3 := encryptedKeyJSONV3{
 		hex.EncodeToString(key.Address[:]),
 		cryptoStruct,
 		key.Id.String(),
 		version,
+		derivationPathString(key.DerivationPath),
 	}
 	return json.Marshal(encryptedKeyJSONV3)
 }
@@ -206,6 +304,7 @@ func DecryptKey(keyjson []byte, auth string) (*Key, error) {
 	// Depending on the version try to parse one way or another
 	var (
 		keyBytes, keyId []byte
+		path            accounts.DerivationPath
 		err             error
 	)
 	if version, ok := m["version"].(string); ok && version == "1" {
@@ -220,6 +319,9 @@ func DecryptKey(keyjson []byte, auth string) (*Key, error) {
 			return nil, err
 		}
 		keyBytes, keyId, err = decryptKeyV3(k, auth)
+		if err == nil {
+			path, err = parseDerivationPath(k.DerivationPath)
+		}
 	}
 	// Handle any decryption errors and return the key
 	if err != nil {
@@ -234,9 +336,10 @@ func DecryptKey(keyjson []byte, auth string) (*Key, error) {
 		return nil, fmt.Errorf("invalid UUID: %w", err)
 	}
 	return &Key{
-		Id:         id,
-		Address:    crypto.PubkeyToAddress(key.PublicKey),
-		PrivateKey: key,
+		Id:             id,
+		Address:        crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey:     key,
+		DerivationPath: path,
 	}, nil
 }
 
@@ -351,6 +454,18 @@ func getKDFKey(cryptoJSON CryptoJSON, auth string) ([]byte, error) {
 		}
 		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
 		return key, nil
+	} else if cryptoJSON.KDF == argon2KDF {
+		// Keys without version were encrypted with the current one
+		if v, ok := cryptoJSON.KDFParams["version"]; ok && ensureInt(v) != argon2.Version {
+			return nil, fmt.Errorf("unsupported argon2id version: %v", v)
+		}
+		t := ensureInt(cryptoJSON.KDFParams["t"])
+		m := ensureInt(cryptoJSON.KDFParams["m"])
+		p := ensureInt(cryptoJSON.KDFParams["p"])
+		if t <= 0 || t > maxArgon2T || m < 0 || m > maxArgon2M || p <= 0 || p > 1<<8-1 || dkLen < 32 || dkLen > maxArgon2DKLen {
+			return nil, errors.New("invalid argon2id parameters")
+		}
+		return argon2.IDKey(authArray, salt, uint32(t), uint32(m), uint8(p), uint32(dkLen)), nil
 	}
 
 	return nil, fmt.Errorf("unsupported KDF: %s", cryptoJSON.KDF)
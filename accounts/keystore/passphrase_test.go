@@ -17,7 +17,9 @@
 package keystore
 
 import (
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -59,3 +61,67 @@ func TestKeyEncryptDecrypt(t *testing.T) {
 		}
 	}
 }
+
+// Tests that scrypt keys can be re-encrypted with argon2id, and that the version
+// of argon2id is checked on decryption.
+func TestKeyEncryptDecryptArgon2(t *testing.T) {
+	t.Parallel()
+	keyjson, err := os.ReadFile("testdata/very-light-scrypt.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := DecryptKey(keyjson, "")
+	if err != nil {
+		t.Fatalf("json key failed to decrypt: %v", err)
+	}
+	if keyjson, err = EncryptKeyArgon2(key, "foo", 1, 64, 1); err != nil {
+		t.Fatalf("failed to encrypt key: %v", err)
+	}
+	var k encryptedKeyJSONV3
+	if err := json.Unmarshal(keyjson, &k); err != nil {
+		t.Fatal(err)
+	}
+	if k.Crypto.KDF != "argon2id" {
+		t.Errorf("kdf mismatch: have %s, want argon2id", k.Crypto.KDF)
+	}
+	if _, err := DecryptKey(keyjson, "bar"); err != ErrDecrypt {
+		t.Errorf("decrypting with bad password: have %v, want %v", err, ErrDecrypt)
+	}
+	have, err := DecryptKey(keyjson, "foo")
+	if err != nil {
+		t.Fatalf("json key failed to decrypt: %v", err)
+	}
+	if have.Address != key.Address {
+		t.Errorf("key address mismatch: have %x, want %x", have.Address, key.Address)
+	}
+	// Keys of unknown argon2id versions must be rejected
+	k.Crypto.KDFParams["version"] = 0x10
+	if keyjson, err = json.Marshal(k); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecryptKey(keyjson, "foo"); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Errorf("decrypting unknown version: have %v, want version error", err)
+	}
+	// Keys demanding excessive resources must be rejected before deriving
+	delete(k.Crypto.KDFParams, "version")
+	for _, tt := range []struct {
+		param      string
+		bad, valid float64
+	}{
+		{"t", maxArgon2T + 1, 1},
+		{"m", maxArgon2M + 1, 64},
+		{"dklen", maxArgon2DKLen + 1, 32},
+	} {
+		k.Crypto.KDFParams[tt.param] = tt.bad
+		if keyjson, err = json.Marshal(k); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := DecryptKey(keyjson, "foo"); err == nil || !strings.Contains(err.Error(), "invalid argon2id parameters") {
+			t.Errorf("decrypting with excessive %s: have %v, want parameter error", tt.param, err)
+		}
+		k.Crypto.KDFParams[tt.param] = tt.valid
+	}
+	if _, err := EncryptKeyArgon2(key, "foo", maxArgon2T+1, 64, 1); err == nil {
+		t.Error("encrypted key with excessive passes")
+	}
+}
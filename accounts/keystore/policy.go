@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrPolicyViolation is returned if an unlocked account is requested to sign
+// something its unlock policy doesn't allow.
+var ErrPolicyViolation = errors.New("unlock policy violation")
+
+// UnlockPolicy restricts what an account may sign while unlocked. Zero fields
+// impose no restriction. Signing with the passphrase is not restricted, since
+// it is authorized explicitly.
+type UnlockPolicy struct {
+	// MaxDuration caps the time the account stays unlocked, also for unlocks
+	// requested indefinitely.
+	MaxDuration time.Duration
+
+	// MaxSignatures is the number of signatures allowed per unlock, after which
+	// the account is locked again.
+	MaxSignatures uint64
+
+	// MaxValue is the maximum value of the transactions allowed to be signed.
+	MaxValue *big.Int
+
+	// AllowedDestinations are the only recipients of the transactions allowed to
+	// be signed, if not nil. Contract creations are not allowed then.
+	AllowedDestinations []common.Address
+}
+
+// restrictsTx returns whether the policy restricts the transactions which may be
+// signed. Hashes can't be signed with such accounts, since they might be hashes
+// of disallowed transactions.
+func (p *UnlockPolicy) restrictsTx() bool {
+	return p.MaxValue != nil || p.AllowedDestinations != nil
+}
+
+// checkTx returns an error if the transaction is not allowed by the policy.
+func (p *UnlockPolicy) checkTx(tx *types.Transaction) error {
+	if p.MaxValue != nil && tx.Value().Cmp(p.MaxValue) > 0 {
+		return fmt.Errorf("%w: value %v exceeds %v", ErrPolicyViolation, tx.Value(), p.MaxValue)
+	}
+	if p.AllowedDestinations != nil {
+		if tx.To() == nil {
+			return fmt.Errorf("%w: contract creation not allowed", ErrPolicyViolation)
+		}
+		if !slices.Contains(p.AllowedDestinations, *tx.To()) {
+			return fmt.Errorf("%w: destination %v not allowed", ErrPolicyViolation, tx.To())
+		}
+	}
+	return nil
+}
+
+// SetUnlockPolicy sets the policy restricting the given account while unlocked,
+// or removes it if nil. The account is locked, so that the policy applies to all
+// subsequent unlocks.
+func (ks *KeyStore) SetUnlockPolicy(addr common.Address, policy *UnlockPolicy) {
+	ks.mu.Lock()
+	if policy == nil {
+		delete(ks.policies, addr)
+	} else {
+		p := *policy
+		p.AllowedDestinations = slices.Clone(policy.AllowedDestinations)
+		if policy.MaxValue != nil {
+			p.MaxValue = new(big.Int).Set(policy.MaxValue)
+		}
+		ks.policies[addr] = &p
+	}
+	ks.mu.Unlock()
+
+	ks.Lock(addr)
+}
+
+// authorize returns the unlocked key of the account if its policy allows signing
+// the transaction, or a hash if tx is nil. Hashes are never allowed if raw, while
+// the hashes of text messages are allowed by all policies.
+//
+// The caller must hold the write lock of the keystore, and consume the signature
+// if one is produced.
+func (ks *KeyStore) authorize(addr common.Address, tx *types.Transaction, raw bool) (*unlocked, error) {
+	u, found := ks.unlocked[addr]
+	if !found {
+		return nil, ErrLocked
+	}
+	if u.policy == nil {
+		return u, nil
+	}
+	if tx != nil {
+		if err := u.policy.checkTx(tx); err != nil {
+			return nil, err
+		}
+	} else if raw && u.policy.restrictsTx() {
+		return nil, fmt.Errorf("%w: hash signing not allowed", ErrPolicyViolation)
+	}
+	return u, nil
+}
+
+// consume counts a signature produced with the unlocked key, locking the account
+// if the policy allows no more signatures.
+//
+// The caller must hold the write lock of the keystore.
+func (ks *KeyStore) consume(addr common.Address, u *unlocked) {
+	u.signatures++
+	if u.policy == nil || u.policy.MaxSignatures == 0 || u.signatures < u.policy.MaxSignatures {
+		return
+	}
+	if u.abort != nil {
+		close(u.abort)
+	}
+	zeroKey(u.PrivateKey)
+	delete(ks.unlocked, addr)
+}
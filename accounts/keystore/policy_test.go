@@ -0,0 +1,96 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestUnlockPolicy(t *testing.T) {
+	t.Parallel()
+	_, ks := tmpKeyStore(t)
+
+	a, err := ks.NewAccount("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		allowed = common.HexToAddress("0x01")
+		denied  = common.HexToAddress("0x02")
+		chainID = big.NewInt(1)
+	)
+	newTx := func(to *common.Address, value int64) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{To: to, Value: big.NewInt(value), Gas: 21000, GasPrice: big.NewInt(1)})
+	}
+	ks.SetUnlockPolicy(a.Address, &UnlockPolicy{
+		MaxSignatures:       3,
+		MaxValue:            big.NewInt(100),
+		AllowedDestinations: []common.Address{allowed},
+	})
+	if err := ks.Unlock(a, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	// Disallowed transactions and hashes must be refused, without being counted
+	for i, tx := range []*types.Transaction{newTx(&allowed, 101), newTx(&denied, 1), newTx(nil, 0)} {
+		if _, err := ks.SignTx(a, tx, chainID); !errors.Is(err, ErrPolicyViolation) {
+			t.Errorf("tx %d: have %v, want %v", i, err, ErrPolicyViolation)
+		}
+	}
+	if _, err := ks.SignHash(a, testSigData); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("signing hash: have %v, want %v", err, ErrPolicyViolation)
+	}
+	// Allowed transactions and text messages must be signed until the maximum
+	// number of signatures is reached
+	wallet := ks.Wallets()[0]
+	if _, err := wallet.SignText(a, []byte("hello")); err != nil {
+		t.Errorf("failed to sign text: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := ks.SignTx(a, newTx(&allowed, 100), chainID); err != nil {
+			t.Fatalf("tx %d: failed to sign allowed transaction: %v", i, err)
+		}
+	}
+	if _, err := ks.SignTx(a, newTx(&allowed, 1), chainID); err != ErrLocked {
+		t.Errorf("signing after maximum signatures: have %v, want %v", err, ErrLocked)
+	}
+	// Unlocking must be capped by the maximum duration
+	ks.SetUnlockPolicy(a.Address, &UnlockPolicy{MaxDuration: 100 * time.Millisecond})
+	if err := ks.Unlock(a, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.SignHash(a, testSigData); err != nil {
+		t.Fatalf("failed to sign hash: %v", err)
+	}
+	time.Sleep(250 * time.Millisecond)
+	if _, err := ks.SignHash(a, testSigData); err != ErrLocked {
+		t.Errorf("signing after maximum duration: have %v, want %v", err, ErrLocked)
+	}
+	// Removing the policy must lift the restrictions
+	ks.SetUnlockPolicy(a.Address, nil)
+	if err := ks.Unlock(a, "foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.SignTx(a, newTx(nil, 1000), chainID); err != nil {
+		t.Errorf("failed to sign unrestricted transaction: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/accounts"
+)
+
+// KDFParams are the parameters of the key derivation function encrypting a key
+// file. Keys are encrypted with argon2id if Argon2T is non-zero, and with scrypt
+// otherwise.
+type KDFParams struct {
+	ScryptN int
+	ScryptP int
+
+	Argon2T uint32
+	Argon2M uint32
+	Argon2P uint8
+}
+
+// validate checks that the parameters can be used to encrypt keys.
+func (p KDFParams) validate() error {
+	if p.Argon2T != 0 {
+		if p.Argon2T > maxArgon2T || p.Argon2M == 0 || p.Argon2M > maxArgon2M || p.Argon2P == 0 {
+			return errors.New("invalid argon2id parameters")
+		}
+		return nil
+	}
+	if p.ScryptN <= 1 || p.ScryptN&(p.ScryptN-1) != 0 || p.ScryptP <= 0 {
+		return errors.New("invalid scrypt parameters")
+	}
+	return nil
+}
+
+// Rekey re-encrypts the key file of an existing account with newPassphrase,
+// deriving the encryption key with the given KDF parameters. This allows
+// migrating keys to stronger parameters than they were created with, without
+// exporting them. The key file is replaced atomically, only after the new one
+// was verified to decrypt.
+func (ks *KeyStore) Rekey(a accounts.Account, passphrase, newPassphrase string, params KDFParams) error {
+	store, ok := ks.storage.(*keyStorePassphrase)
+	if !ok {
+		return accounts.ErrNotSupported
+	}
+	if err := params.validate(); err != nil {
+		return err
+	}
+	a, key, err := ks.getDecryptedKey(a, passphrase)
+	if err != nil {
+		return err
+	}
+	defer zeroKey(key.PrivateKey)
+
+	rekeyed := &keyStorePassphrase{
+		keysDirPath:             store.keysDirPath,
+		scryptN:                 params.ScryptN,
+		scryptP:                 params.ScryptP,
+		argon2T:                 params.Argon2T,
+		argon2M:                 params.Argon2M,
+		argon2P:                 params.Argon2P,
+		skipKeyFileVerification: store.skipKeyFileVerification,
+	}
+	return rekeyed.StoreKey(a.URL.Path, key, newPassphrase)
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestRekey(t *testing.T) {
+	t.Parallel()
+	dir, ks := tmpKeyStore(t)
+
+	a, err := ks.NewAccount("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kdf := func() string {
+		keyjson, err := os.ReadFile(a.URL.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var k encryptedKeyJSONV3
+		if err := json.Unmarshal(keyjson, &k); err != nil {
+			t.Fatal(err)
+		}
+		return k.Crypto.KDF
+	}
+	// Invalid parameters and passphrases must leave the key file untouched
+	params := KDFParams{Argon2T: LightArgon2T, Argon2M: LightArgon2M, Argon2P: Argon2P}
+	if err := ks.Rekey(a, "foo", "bar", KDFParams{ScryptN: 3, ScryptP: 1}); err == nil {
+		t.Error("rekeyed with invalid scrypt parameters")
+	}
+	if err := ks.Rekey(a, "bar", "bar", params); err != ErrDecrypt {
+		t.Errorf("rekeying with wrong passphrase: have %v, want %v", err, ErrDecrypt)
+	}
+	if have := kdf(); have != keyHeaderKDF {
+		t.Errorf("kdf mismatch after failed rekeying: have %q, want %q", have, keyHeaderKDF)
+	}
+	// Rekeying must replace the key file in place
+	if err := ks.Rekey(a, "foo", "bar", params); err != nil {
+		t.Fatalf("failed to rekey: %v", err)
+	}
+	if have := kdf(); have != argon2KDF {
+		t.Errorf("kdf mismatch: have %q, want %q", have, argon2KDF)
+	}
+	if err := ks.Unlock(a, "foo"); err != ErrDecrypt {
+		t.Errorf("unlocking with old passphrase: have %v, want %v", err, ErrDecrypt)
+	}
+	if err := ks.Unlock(a, "bar"); err != nil {
+		t.Errorf("failed to unlock with new passphrase: %v", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("have %d files in keystore, want 1", len(files))
+	}
+}
@@ -84,18 +84,18 @@ func (w *keystoreWallet) SelfDerive(bases []accounts.DerivationPath, chain ether
 // the given account. If the wallet does not wrap this particular account, an
 // error is returned to avoid account leakage (even though in theory we may be
 // able to sign via our shared keystore backend).
-func (w *keystoreWallet) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+func (w *keystoreWallet) signHash(account accounts.Account, hash []byte, raw bool) ([]byte, error) {
 	// Make sure the requested account is contained within
 	if !w.Contains(account) {
 		return nil, accounts.ErrUnknownAccount
 	}
 	// Account seems valid, request the keystore to sign
-	return w.keystore.SignHash(account, hash)
+	return w.keystore.signHash(account, hash, raw)
 }
 
 // SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
 func (w *keystoreWallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
-	return w.signHash(account, crypto.Keccak256(data))
+	return w.signHash(account, crypto.Keccak256(data), true)
 }
 
 // SignDataWithPassphrase signs keccak256(data). The mimetype parameter describes the type of data being signed.
@@ -111,7 +111,7 @@ func (w *keystoreWallet) SignDataWithPassphrase(account accounts.Account, passph
 // SignText implements accounts.Wallet, attempting to sign the hash of
 // the given text with the given account.
 func (w *keystoreWallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
-	return w.signHash(account, accounts.TextHash(text))
+	return w.signHash(account, accounts.TextHash(text), false)
 }
 
 // SignTextWithPassphrase implements accounts.Wallet, attempting to sign the
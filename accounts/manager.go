@@ -83,8 +83,7 @@ func NewManager(config *Config, backends ...Backend) *Manager {
 		term:        make(chan struct{}),
 	}
 	for _, backend := range backends {
-		kind := reflect.TypeOf(backend)
-		am.backends[kind] = append(am.backends[kind], backend)
+		am.index(backend)
 	}
 	go am.update()
 
@@ -141,8 +140,7 @@ func (am *Manager) update() {
 			backend := event.backend
 			am.wallets = merge(am.wallets, backend.Wallets()...)
 			am.updaters = append(am.updaters, backend.Subscribe(am.updates))
-			kind := reflect.TypeOf(backend)
-			am.backends[kind] = append(am.backends[kind], backend)
+			am.index(backend)
 			am.lock.Unlock()
 			close(event.processed)
 		case errc := <-am.quit:
@@ -160,6 +158,22 @@ func (am *Manager) update() {
 	}
 }
 
+// index adds the backend to the index by type. Backends wrapping another one,
+// e.g. to record their signing operations, are also indexed by the types of the
+// wrapped backends.
+func (am *Manager) index(backend Backend) {
+	for backend != nil {
+		kind := reflect.TypeOf(backend)
+		am.backends[kind] = append(am.backends[kind], backend)
+
+		wrapper, ok := backend.(interface{ Unwrap() Backend })
+		if !ok {
+			break
+		}
+		backend = wrapper.Unwrap()
+	}
+}
+
 // Backends retrieves the backend(s) with the given type from the account manager.
 func (am *Manager) Backends(kind reflect.Type) []Backend {
 	am.lock.RLock()
@@ -0,0 +1,208 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package pkcs11 implements wallets of the secp256k1 keys stored on hardware
+// security modules, accessed through the PKCS#11 library of the vendor.
+//
+// Every token of the library is a wallet, and every secp256k1 key pair on it an
+// account of the wallet, identified by the CKA_ID shared by the public and the
+// private key. Wallets are opened by logging into the token with the PIN of the
+// user.
+package pkcs11
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Scheme is the protocol scheme prefixing account and wallet URLs.
+const Scheme = "pkcs11"
+
+// HubType is the reflect type of a PKCS#11 hub.
+var HubType = reflect.TypeFor[*Hub]()
+
+// refreshCycle is the maximum time between wallet refreshes, since PKCS#11 has
+// no portable notifications of inserted or removed tokens.
+const refreshCycle = 5 * time.Second
+
+// refreshThrottling is the minimum time between wallet refreshes.
+const refreshThrottling = time.Second
+
+// Hub is an accounts.Backend of the tokens accessible through a PKCS#11 library.
+type Hub struct {
+	module module // PKCS#11 library the tokens are accessed through
+
+	refreshed   time.Time               // Time instance when the list of wallets was last refreshed
+	wallets     []accounts.Wallet       // List of tokens currently tracking
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+	updating    bool                    // Whether the event notification loop is running
+
+	stateLock sync.RWMutex // Protects the internals of the hub from racey access
+}
+
+// NewHub loads the PKCS#11 library at the given path, and creates a hub of the
+// tokens accessible through it.
+func NewHub(path string) (*Hub, error) {
+	module, err := openModule(path)
+	if err != nil {
+		return nil, err
+	}
+	return newHub(module), nil
+}
+
+// newHub creates a hub of the tokens accessible through the given library.
+func newHub(module module) *Hub {
+	hub := &Hub{module: module}
+	hub.refreshWallets()
+	return hub
+}
+
+// Wallets implements accounts.Backend, returning all the currently tracked
+// tokens.
+func (hub *Hub) Wallets() []accounts.Wallet {
+	// Make sure the list of wallets is up to date
+	hub.refreshWallets()
+
+	hub.stateLock.RLock()
+	defer hub.stateLock.RUnlock()
+
+	cpy := make([]accounts.Wallet, len(hub.wallets))
+	copy(cpy, hub.wallets)
+	return cpy
+}
+
+// refreshWallets lists the tokens present in the slots of the library and
+// updates the list of wallets accordingly.
+func (hub *Hub) refreshWallets() {
+	// Don't query the library like crazy if the user fetches wallets in a loop
+	hub.stateLock.RLock()
+	elapsed := time.Since(hub.refreshed)
+	hub.stateLock.RUnlock()
+
+	if elapsed < refreshThrottling {
+		return
+	}
+	slots, err := hub.module.Slots()
+	if err != nil {
+		log.Error("Failed to list PKCS#11 slots", "err", err)
+		return
+	}
+	// Wallets are identified by the token serial, as slots get renumbered when
+	// tokens are inserted or removed
+	var found []*wallet
+	for _, slot := range slots {
+		info, err := hub.module.TokenInfo(slot)
+		if err != nil {
+			log.Warn("Failed to retrieve PKCS#11 token info", "slot", slot, "err", err)
+			continue
+		}
+		id := info.Serial
+		if id == "" {
+			id = info.Label
+		}
+		url := accounts.URL{Scheme: Scheme, Path: id}
+		found = append(found, &wallet{
+			hub:  hub,
+			slot: slot,
+			url:  url,
+			pool: newSessionPool(hub.module, slot),
+			info: info,
+			log:  log.New("url", url),
+		})
+	}
+	// Transform the current list of wallets into the new one
+	hub.stateLock.Lock()
+
+	var (
+		wallets = make([]accounts.Wallet, 0, len(found))
+		events  []accounts.WalletEvent
+		current = make(map[accounts.URL]*wallet)
+	)
+	for _, w := range hub.wallets {
+		current[w.URL()] = w.(*wallet)
+	}
+	for _, w := range found {
+		if old, ok := current[w.url]; ok && old.slot == w.slot {
+			wallets = append(wallets, old)
+			delete(current, w.url)
+			continue
+		}
+		events = append(events, accounts.WalletEvent{Wallet: w, Kind: accounts.WalletArrived})
+		wallets = append(wallets, w)
+	}
+	for _, w := range current {
+		w.lock.Lock()
+		w.close()
+		w.lock.Unlock()
+
+		events = append(events, accounts.WalletEvent{Wallet: w, Kind: accounts.WalletDropped})
+	}
+	sort.Sort(accounts.WalletsByURL(wallets))
+
+	hub.refreshed = time.Now()
+	hub.wallets = wallets
+	hub.stateLock.Unlock()
+
+	// Fire all wallet events and return
+	for _, event := range events {
+		hub.updateFeed.Send(event)
+	}
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition or removal of tokens.
+func (hub *Hub) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	// We need the mutex to reliably start/stop the update loop
+	hub.stateLock.Lock()
+	defer hub.stateLock.Unlock()
+
+	// Subscribe the caller and track the subscriber count
+	sub := hub.updateScope.Track(hub.updateFeed.Subscribe(sink))
+
+	// Subscribers require an active notification loop, start it
+	if !hub.updating {
+		hub.updating = true
+		go hub.updater()
+	}
+	return sub
+}
+
+// updater is responsible for maintaining an up-to-date list of wallets managed
+// by the hub, and for firing wallet addition/removal events.
+func (hub *Hub) updater() {
+	for {
+		time.Sleep(refreshCycle)
+
+		// Run the wallet refresher
+		hub.refreshWallets()
+
+		// If all our subscribers left, stop the updater
+		hub.stateLock.Lock()
+		if hub.updateScope.Count() == 0 {
+			hub.updating = false
+			hub.stateLock.Unlock()
+			return
+		}
+		hub.stateLock.Unlock()
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pkcs11
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// Object classes, key types, attributes and mechanisms of the PKCS#11 standard
+// used to find and use secp256k1 keys.
+const (
+	ckoPublicKey  = 0x02
+	ckoPrivateKey = 0x03
+
+	ckkEC = 0x03
+
+	ckaClass    = 0x000
+	ckaKeyType  = 0x100
+	ckaID       = 0x102
+	ckaECParams = 0x180
+	ckaECPoint  = 0x181
+
+	ckmECDSA = 0x1041
+)
+
+// Token flags of the PKCS#11 standard.
+const (
+	ckfLoginRequired     = 0x004
+	ckfProtectedAuthPath = 0x100
+	ckfUserPINLocked     = 0x40000
+)
+
+// Error is a return value of a PKCS#11 function other than CKR_OK.
+type Error uint
+
+// Return values of the PKCS#11 standard handled explicitly.
+const (
+	ckrAttributeTypeInvalid Error = 0x012
+	ckrDeviceRemoved        Error = 0x032
+	ckrPINIncorrect         Error = 0x0a0
+	ckrPINLocked            Error = 0x0a4
+	ckrSessionClosed        Error = 0x0b0
+	ckrSessionHandleInvalid Error = 0x0b3
+	ckrTokenNotPresent      Error = 0x0e0
+	ckrUserAlreadyLoggedIn  Error = 0x100
+	ckrUserNotLoggedIn      Error = 0x101
+)
+
+// Error implements error, naming the well known return values.
+func (e Error) Error() string {
+	switch e {
+	case ckrAttributeTypeInvalid:
+		return "pkcs11: attribute type invalid"
+	case ckrDeviceRemoved:
+		return "pkcs11: device removed"
+	case ckrPINIncorrect:
+		return "pkcs11: pin incorrect"
+	case ckrPINLocked:
+		return "pkcs11: pin locked"
+	case ckrSessionClosed:
+		return "pkcs11: session closed"
+	case ckrSessionHandleInvalid:
+		return "pkcs11: session handle invalid"
+	case ckrTokenNotPresent:
+		return "pkcs11: token not present"
+	case ckrUserAlreadyLoggedIn:
+		return "pkcs11: user already logged in"
+	case ckrUserNotLoggedIn:
+		return "pkcs11: user not logged in"
+	default:
+		return fmt.Sprintf("pkcs11: error 0x%x", uint(e))
+	}
+}
+
+// tokenInfo is the subset of the information of a token used by the wallets.
+type tokenInfo struct {
+	Label  string // Application defined label of the token
+	Model  string // Model of the device
+	Serial string // Serial number of the device
+	Flags  uint   // Capabilities and state of the token
+}
+
+// attribute is an attribute of an object, used as search template.
+type attribute struct {
+	Type  uint
+	Value []byte
+}
+
+// ulongAttribute creates an attribute with a CK_ULONG value, which is encoded
+// in the native byte order and size of an unsigned long. That is the size of a
+// uint on the supported platforms.
+func ulongAttribute(typ uint, value uint) attribute {
+	if bits.UintSize == 32 {
+		return attribute{Type: typ, Value: binary.NativeEndian.AppendUint32(nil, uint32(value))}
+	}
+	return attribute{Type: typ, Value: binary.NativeEndian.AppendUint64(nil, uint64(value))}
+}
+
+// module is the subset of the PKCS#11 interface of a library needed to find and
+// use the secp256k1 keys of its tokens. Handles of slots, sessions and objects
+// are the ones assigned by the library.
+type module interface {
+	// Slots returns the slots with a token present.
+	Slots() ([]uint, error)
+
+	// TokenInfo returns the information of the token in the given slot.
+	TokenInfo(slot uint) (tokenInfo, error)
+
+	// OpenSession opens a read only session with the token in the given slot.
+	OpenSession(slot uint) (uint, error)
+
+	// CloseSession closes the given session.
+	CloseSession(session uint) error
+
+	// Login logs the user into the token of the session, and with it all other
+	// sessions of the token. An empty PIN logs in via the protected
+	// authentication path of the token.
+	Login(session uint, pin string) error
+
+	// Logout logs the user out of the token of the session.
+	Logout(session uint) error
+
+	// FindObjects returns the objects matching all the attributes of the template.
+	FindObjects(session uint, template []attribute) ([]uint, error)
+
+	// Attributes returns the values of the given attributes of the object, nil
+	// for the attributes the object doesn't have.
+	Attributes(session uint, object uint, types []uint) ([][]byte, error)
+
+	// SignECDSA signs the digest with the given private key, returning the
+	// signature as the concatenation of R and S.
+	SignECDSA(session uint, key uint, digest []byte) ([]byte, error)
+}
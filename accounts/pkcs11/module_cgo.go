@@ -0,0 +1,370 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+//go:build cgo && !windows
+
+package pkcs11
+
+/*
+#cgo linux LDFLAGS: -ldl
+
+#include <dlfcn.h>
+#include <stdlib.h>
+
+// The subset of the PKCS#11 v2.40 types used, as defined by the standard for
+// Unix platforms, i.e. without structure packing.
+
+typedef unsigned long CK_ULONG;
+typedef unsigned char CK_BYTE;
+typedef CK_ULONG CK_RV;
+
+#define CKF_SERIAL_SESSION   0x4
+#define CKF_OS_LOCKING_OK    0x2
+#define CKU_USER             1
+#define CK_UNAVAILABLE_INFO  (~0UL)
+
+typedef struct { CK_BYTE major, minor; } CK_VERSION;
+
+typedef struct {
+	CK_ULONG type;
+	void    *pValue;
+	CK_ULONG ulValueLen;
+} CK_ATTRIBUTE;
+
+typedef struct {
+	CK_ULONG mechanism;
+	void    *pParameter;
+	CK_ULONG ulParameterLen;
+} CK_MECHANISM;
+
+typedef struct {
+	void    *CreateMutex, *DestroyMutex, *LockMutex, *UnlockMutex;
+	CK_ULONG flags;
+	void    *pReserved;
+} CK_C_INITIALIZE_ARGS;
+
+typedef struct {
+	CK_BYTE    label[32];
+	CK_BYTE    manufacturerID[32];
+	CK_BYTE    model[16];
+	CK_BYTE    serialNumber[16];
+	CK_ULONG   flags;
+	CK_ULONG   ulMaxSessionCount, ulSessionCount, ulMaxRwSessionCount, ulRwSessionCount;
+	CK_ULONG   ulMaxPinLen, ulMinPinLen;
+	CK_ULONG   ulTotalPublicMemory, ulFreePublicMemory, ulTotalPrivateMemory, ulFreePrivateMemory;
+	CK_VERSION hardwareVersion, firmwareVersion;
+	CK_BYTE    utcTime[16];
+} CK_TOKEN_INFO;
+
+// CK_FUNCTION_LIST is the function list of the library up to C_Sign, with the
+// functions not used kept as opaque pointers.
+typedef struct {
+	CK_VERSION version;
+	CK_RV (*C_Initialize)(void *);
+	CK_RV (*C_Finalize)(void *);
+	void *C_GetInfo, *C_GetFunctionList;
+	CK_RV (*C_GetSlotList)(CK_BYTE, CK_ULONG *, CK_ULONG *);
+	void *C_GetSlotInfo;
+	CK_RV (*C_GetTokenInfo)(CK_ULONG, CK_TOKEN_INFO *);
+	void *C_GetMechanismList, *C_GetMechanismInfo, *C_InitToken, *C_InitPIN, *C_SetPIN;
+	CK_RV (*C_OpenSession)(CK_ULONG, CK_ULONG, void *, void *, CK_ULONG *);
+	CK_RV (*C_CloseSession)(CK_ULONG);
+	void *C_CloseAllSessions, *C_GetSessionInfo, *C_GetOperationState, *C_SetOperationState;
+	CK_RV (*C_Login)(CK_ULONG, CK_ULONG, CK_BYTE *, CK_ULONG);
+	CK_RV (*C_Logout)(CK_ULONG);
+	void *C_CreateObject, *C_CopyObject, *C_DestroyObject, *C_GetObjectSize;
+	CK_RV (*C_GetAttributeValue)(CK_ULONG, CK_ULONG, CK_ATTRIBUTE *, CK_ULONG);
+	void *C_SetAttributeValue;
+	CK_RV (*C_FindObjectsInit)(CK_ULONG, CK_ATTRIBUTE *, CK_ULONG);
+	CK_RV (*C_FindObjects)(CK_ULONG, CK_ULONG *, CK_ULONG, CK_ULONG *);
+	CK_RV (*C_FindObjectsFinal)(CK_ULONG);
+	void *C_EncryptInit, *C_Encrypt, *C_EncryptUpdate, *C_EncryptFinal;
+	void *C_DecryptInit, *C_Decrypt, *C_DecryptUpdate, *C_DecryptFinal;
+	void *C_DigestInit, *C_Digest, *C_DigestUpdate, *C_DigestKey, *C_DigestFinal;
+	CK_RV (*C_SignInit)(CK_ULONG, CK_MECHANISM *, CK_ULONG);
+	CK_RV (*C_Sign)(CK_ULONG, CK_BYTE *, CK_ULONG, CK_BYTE *, CK_ULONG *);
+} CK_FUNCTION_LIST;
+
+typedef CK_RV (*CK_C_GetFunctionList)(CK_FUNCTION_LIST **);
+
+// Go can't call C function pointers, so every function is wrapped.
+
+static CK_RV p11_load(const char *path, void **handle, CK_FUNCTION_LIST **funcs) {
+	*handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	if (*handle == NULL) {
+		return ~0UL;
+	}
+	CK_C_GetFunctionList getFunctionList = (CK_C_GetFunctionList)dlsym(*handle, "C_GetFunctionList");
+	if (getFunctionList == NULL) {
+		dlclose(*handle);
+		return ~0UL;
+	}
+	CK_RV rv = getFunctionList(funcs);
+	if (rv != 0) {
+		dlclose(*handle);
+		return rv;
+	}
+	CK_C_INITIALIZE_ARGS args = {0};
+	args.flags = CKF_OS_LOCKING_OK;
+	rv = (*funcs)->C_Initialize(&args);
+	if (rv != 0) {
+		dlclose(*handle);
+	}
+	return rv;
+}
+
+static CK_RV p11_get_slot_list(CK_FUNCTION_LIST *f, CK_ULONG *slots, CK_ULONG *count) {
+	return f->C_GetSlotList(1, slots, count);
+}
+
+static CK_RV p11_get_token_info(CK_FUNCTION_LIST *f, CK_ULONG slot, CK_TOKEN_INFO *info) {
+	return f->C_GetTokenInfo(slot, info);
+}
+
+static CK_RV p11_open_session(CK_FUNCTION_LIST *f, CK_ULONG slot, CK_ULONG *session) {
+	return f->C_OpenSession(slot, CKF_SERIAL_SESSION, NULL, NULL, session);
+}
+
+static CK_RV p11_close_session(CK_FUNCTION_LIST *f, CK_ULONG session) {
+	return f->C_CloseSession(session);
+}
+
+static CK_RV p11_login(CK_FUNCTION_LIST *f, CK_ULONG session, CK_BYTE *pin, CK_ULONG len) {
+	return f->C_Login(session, CKU_USER, pin, len);
+}
+
+static CK_RV p11_logout(CK_FUNCTION_LIST *f, CK_ULONG session) {
+	return f->C_Logout(session);
+}
+
+static CK_RV p11_find_objects(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ATTRIBUTE *tmpl, CK_ULONG count, CK_ULONG *objects, CK_ULONG max, CK_ULONG *found) {
+	CK_RV rv = f->C_FindObjectsInit(session, tmpl, count);
+	if (rv != 0) {
+		return rv;
+	}
+	rv = f->C_FindObjects(session, objects, max, found);
+	CK_RV final = f->C_FindObjectsFinal(session);
+	return rv != 0 ? rv : final;
+}
+
+static CK_RV p11_get_attribute_value(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ULONG object, CK_ATTRIBUTE *attrs, CK_ULONG count) {
+	return f->C_GetAttributeValue(session, object, attrs, count);
+}
+
+static CK_RV p11_sign(CK_FUNCTION_LIST *f, CK_ULONG session, CK_ULONG key, CK_BYTE *digest, CK_ULONG len, CK_BYTE *sig, CK_ULONG *siglen) {
+	CK_MECHANISM mech = {0x1041, NULL, 0};
+	CK_RV rv = f->C_SignInit(session, &mech, key);
+	if (rv != 0) {
+		return rv;
+	}
+	return f->C_Sign(session, digest, len, sig, siglen);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// maxObjects is the maximum number of objects returned by a search.
+const maxObjects = 256
+
+// cgoModule is a PKCS#11 library loaded into the process.
+type cgoModule struct {
+	handle unsafe.Pointer
+	funcs  *C.CK_FUNCTION_LIST
+}
+
+// openModule loads and initializes the PKCS#11 library at the given path.
+func openModule(path string) (module, error) {
+	cpath := C.CString(path)
+	defer C.free(unsafe.Pointer(cpath))
+
+	m := new(cgoModule)
+	if rv := C.p11_load(cpath, &m.handle, &m.funcs); rv != 0 {
+		if rv == C.CK_UNAVAILABLE_INFO {
+			return nil, fmt.Errorf("failed to load PKCS#11 library %s", path)
+		}
+		return nil, fmt.Errorf("failed to initialize PKCS#11 library %s: %w", path, Error(rv))
+	}
+	return m, nil
+}
+
+// Slots implements module, returning the slots with a token present.
+func (m *cgoModule) Slots() ([]uint, error) {
+	var count C.CK_ULONG
+	if rv := C.p11_get_slot_list(m.funcs, nil, &count); rv != 0 {
+		return nil, Error(rv)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	slots := make([]C.CK_ULONG, count)
+	if rv := C.p11_get_slot_list(m.funcs, &slots[0], &count); rv != 0 {
+		return nil, Error(rv)
+	}
+	res := make([]uint, count)
+	for i := range res {
+		res[i] = uint(slots[i])
+	}
+	return res, nil
+}
+
+// TokenInfo implements module, returning the information of the token in the
+// given slot.
+func (m *cgoModule) TokenInfo(slot uint) (tokenInfo, error) {
+	var info C.CK_TOKEN_INFO
+	if rv := C.p11_get_token_info(m.funcs, C.CK_ULONG(slot), &info); rv != 0 {
+		return tokenInfo{}, Error(rv)
+	}
+	// Strings of the token information are blank padded, not zero terminated
+	text := func(b []C.CK_BYTE) string {
+		return strings.TrimRight(C.GoStringN((*C.char)(unsafe.Pointer(&b[0])), C.int(len(b))), " \x00")
+	}
+	return tokenInfo{
+		Label:  text(info.label[:]),
+		Model:  text(info.model[:]),
+		Serial: text(info.serialNumber[:]),
+		Flags:  uint(info.flags),
+	}, nil
+}
+
+// OpenSession implements module, opening a read only session with the token in
+// the given slot.
+func (m *cgoModule) OpenSession(slot uint) (uint, error) {
+	var session C.CK_ULONG
+	if rv := C.p11_open_session(m.funcs, C.CK_ULONG(slot), &session); rv != 0 {
+		return 0, Error(rv)
+	}
+	return uint(session), nil
+}
+
+// CloseSession implements module, closing the given session.
+func (m *cgoModule) CloseSession(session uint) error {
+	if rv := C.p11_close_session(m.funcs, C.CK_ULONG(session)); rv != 0 {
+		return Error(rv)
+	}
+	return nil
+}
+
+// Login implements module, logging the user into the token of the session.
+func (m *cgoModule) Login(session uint, pin string) error {
+	var cpin *C.CK_BYTE
+	if pin != "" {
+		cpin = (*C.CK_BYTE)(C.CBytes([]byte(pin)))
+		defer C.free(unsafe.Pointer(cpin))
+	}
+	if rv := C.p11_login(m.funcs, C.CK_ULONG(session), cpin, C.CK_ULONG(len(pin))); rv != 0 {
+		return Error(rv)
+	}
+	return nil
+}
+
+// Logout implements module, logging the user out of the token of the session.
+func (m *cgoModule) Logout(session uint) error {
+	if rv := C.p11_logout(m.funcs, C.CK_ULONG(session)); rv != 0 {
+		return Error(rv)
+	}
+	return nil
+}
+
+// FindObjects implements module, returning the objects matching the template.
+func (m *cgoModule) FindObjects(session uint, template []attribute) ([]uint, error) {
+	// The template contains pointers, so it must live in C memory
+	var attrs *C.CK_ATTRIBUTE
+	if len(template) > 0 {
+		attrs = (*C.CK_ATTRIBUTE)(C.calloc(C.size_t(len(template)), C.size_t(unsafe.Sizeof(C.CK_ATTRIBUTE{}))))
+		defer C.free(unsafe.Pointer(attrs))
+	}
+	list := unsafe.Slice(attrs, len(template))
+	for i, attr := range template {
+		list[i]._type = C.CK_ULONG(attr.Type)
+		list[i].pValue = C.CBytes(attr.Value)
+		list[i].ulValueLen = C.CK_ULONG(len(attr.Value))
+		defer C.free(list[i].pValue)
+	}
+	var (
+		objects = make([]C.CK_ULONG, maxObjects)
+		found   C.CK_ULONG
+	)
+	if rv := C.p11_find_objects(m.funcs, C.CK_ULONG(session), attrs, C.CK_ULONG(len(template)), &objects[0], maxObjects, &found); rv != 0 {
+		return nil, Error(rv)
+	}
+	res := make([]uint, found)
+	for i := range res {
+		res[i] = uint(objects[i])
+	}
+	return res, nil
+}
+
+// Attributes implements module, returning the values of the given attributes of
+// the object.
+func (m *cgoModule) Attributes(session uint, object uint, types []uint) ([][]byte, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+	attrs := (*C.CK_ATTRIBUTE)(C.calloc(C.size_t(len(types)), C.size_t(unsafe.Sizeof(C.CK_ATTRIBUTE{}))))
+	defer C.free(unsafe.Pointer(attrs))
+
+	list := unsafe.Slice(attrs, len(types))
+	for i, typ := range types {
+		list[i]._type = C.CK_ULONG(typ)
+	}
+	// Retrieve the sizes of the values first, then the values themselves. Missing
+	// attributes are reported with unavailable sizes, which isn't a failure.
+	rv := C.p11_get_attribute_value(m.funcs, C.CK_ULONG(session), C.CK_ULONG(object), attrs, C.CK_ULONG(len(types)))
+	if rv != 0 && Error(rv) != ckrAttributeTypeInvalid {
+		return nil, Error(rv)
+	}
+	for i := range list {
+		if list[i].ulValueLen != C.CK_UNAVAILABLE_INFO && list[i].ulValueLen > 0 {
+			list[i].pValue = C.malloc(C.size_t(list[i].ulValueLen))
+			defer C.free(list[i].pValue)
+		}
+	}
+	rv = C.p11_get_attribute_value(m.funcs, C.CK_ULONG(session), C.CK_ULONG(object), attrs, C.CK_ULONG(len(types)))
+	if rv != 0 && Error(rv) != ckrAttributeTypeInvalid {
+		return nil, Error(rv)
+	}
+	values := make([][]byte, len(types))
+	for i := range list {
+		if list[i].pValue != nil && list[i].ulValueLen != C.CK_UNAVAILABLE_INFO {
+			values[i] = C.GoBytes(list[i].pValue, C.int(list[i].ulValueLen))
+		}
+	}
+	return values, nil
+}
+
+// SignECDSA implements module, signing the digest with the given private key.
+func (m *cgoModule) SignECDSA(session uint, key uint, digest []byte) ([]byte, error) {
+	if len(digest) == 0 {
+		return nil, errors.New("empty digest")
+	}
+	var (
+		sig    = make([]byte, 64) // R and S of a secp256k1 signature
+		siglen = C.CK_ULONG(len(sig))
+	)
+	rv := C.p11_sign(m.funcs, C.CK_ULONG(session), C.CK_ULONG(key),
+		(*C.CK_BYTE)(unsafe.Pointer(&digest[0])), C.CK_ULONG(len(digest)),
+		(*C.CK_BYTE)(unsafe.Pointer(&sig[0])), &siglen)
+	if rv != 0 {
+		return nil, Error(rv)
+	}
+	return sig[:siglen], nil
+}
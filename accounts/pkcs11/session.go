@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pkcs11
+
+import "errors"
+
+// maxSessions is the maximum number of sessions opened concurrently with a token.
+const maxSessions = 8
+
+// sessionPool keeps the sessions opened with a token for reuse. Opening sessions
+// is slow on network attached modules, and a session can only run one operation
+// at a time, so concurrent signing requests need a session each.
+type sessionPool struct {
+	module module
+	slot   uint
+
+	idle chan uint     // Open sessions not in use
+	open chan struct{} // Semaphore limiting the number of open sessions
+}
+
+// newSessionPool creates a pool of sessions with the token in the given slot.
+func newSessionPool(module module, slot uint) *sessionPool {
+	return &sessionPool{
+		module: module,
+		slot:   slot,
+		idle:   make(chan uint, maxSessions),
+		open:   make(chan struct{}, maxSessions),
+	}
+}
+
+// do runs the given function with a session of the pool, waiting for one to
+// become available if all are in use.
+func (p *sessionPool) do(fn func(session uint) error) error {
+	session, err := p.get()
+	if err != nil {
+		return err
+	}
+	err = fn(session)
+	p.put(session, err)
+	return err
+}
+
+// get retrieves an idle session, or opens a new one if the limit allows.
+func (p *sessionPool) get() (uint, error) {
+	select {
+	case session := <-p.idle:
+		return session, nil
+	default:
+	}
+	select {
+	case session := <-p.idle:
+		return session, nil
+	case p.open <- struct{}{}:
+		session, err := p.module.OpenSession(p.slot)
+		if err != nil {
+			<-p.open
+			return 0, err
+		}
+		return session, nil
+	}
+}
+
+// put returns a session into the pool after use, closing it instead if the last
+// operation failed because the session is no longer usable.
+func (p *sessionPool) put(session uint, err error) {
+	var perr Error
+	if errors.As(err, &perr) {
+		switch perr {
+		case ckrSessionClosed, ckrSessionHandleInvalid, ckrDeviceRemoved, ckrTokenNotPresent:
+			p.module.CloseSession(session)
+			<-p.open
+			return
+		}
+	}
+	p.idle <- session
+}
+
+// close closes all idle sessions. Sessions in use are returned to the pool as
+// usual, and closed by a subsequent call.
+func (p *sessionPool) close() {
+	for {
+		select {
+		case session := <-p.idle:
+			p.module.CloseSession(session)
+			<-p.open
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,414 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrPINNeeded is returned if opening the token requires a PIN code. In this case,
+// the calling application should request user input to enter the PIN and send it
+// back.
+var ErrPINNeeded = errors.New("pkcs11: pin needed")
+
+// ErrPINLocked is returned if the PIN of the token has been locked after too many
+// failed attempts, which needs to be reset with the tools of the vendor.
+var ErrPINLocked = errors.New("pkcs11: pin locked")
+
+var (
+	// secp256k1Params is the DER encoded identifier of the secp256k1 curve, as
+	// stored in the CKA_EC_PARAMS attribute of keys.
+	secp256k1Params, _ = asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+)
+
+// key is a secp256k1 key pair stored on a token.
+type key struct {
+	account accounts.Account
+	id      []byte // CKA_ID linking the public and private key objects
+	pubkey  []byte // Uncompressed public key to recover the signature parity with
+}
+
+// wallet is an accounts.Wallet of the secp256k1 keys stored on a PKCS#11 token.
+type wallet struct {
+	hub  *Hub
+	slot uint
+	url  accounts.URL
+	pool *sessionPool
+
+	info      tokenInfo // Token information, refreshed on status requests and logins
+	keys      []key     // Keys found on the token, nil until first listed
+	loggedIn  bool      // Whether the user is logged into the token
+	temporary bool      // Whether the login only lasts for the requests signing with a PIN
+	pin       string    // PIN of the temporary login, required from the requests sharing it
+	users     int       // Number of requests signing with a PIN in progress
+
+	lock sync.Mutex // Lock protecting the fields above
+	log  log.Logger
+}
+
+// URL implements accounts.Wallet, returning the URL of the token.
+func (w *wallet) URL() accounts.URL {
+	return w.url
+}
+
+// Status implements accounts.Wallet, returning whether the token is locked, or
+// the failure if it's no longer reachable.
+func (w *wallet) Status() (string, error) {
+	info, err := w.hub.module.TokenInfo(w.slot)
+	if err != nil {
+		return "Failed", err
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.info = info
+	switch {
+	case info.Flags&ckfUserPINLocked != 0:
+		return "PIN locked", ErrPINLocked
+	case info.Flags&ckfLoginRequired == 0 || w.loggedIn:
+		return "Unlocked", nil
+	default:
+		return "Locked", nil
+	}
+}
+
+// Open implements accounts.Wallet, logging into the token with the given PIN.
+// An empty PIN logs in via the protected authentication path of the token, like
+// a PIN pad on the device, if it has one.
+func (w *wallet) Open(pin string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.open(pin)
+}
+
+// open logs into the token with the given PIN. It assumes the lock is held.
+func (w *wallet) open(pin string) error {
+	if w.loggedIn {
+		return accounts.ErrWalletAlreadyOpen
+	}
+	info, err := w.hub.module.TokenInfo(w.slot)
+	if err != nil {
+		return err
+	}
+	w.info = info
+
+	if w.info.Flags&ckfLoginRequired != 0 {
+		if pin == "" && w.info.Flags&ckfProtectedAuthPath == 0 {
+			return ErrPINNeeded
+		}
+		err := w.pool.do(func(session uint) error {
+			return w.hub.module.Login(session, pin)
+		})
+		switch {
+		case errors.Is(err, ckrUserAlreadyLoggedIn):
+			// Logged in by another application sharing the library, fine
+		case errors.Is(err, ckrPINIncorrect):
+			return accounts.ErrInvalidPassphrase
+		case errors.Is(err, ckrPINLocked):
+			return ErrPINLocked
+		case err != nil:
+			return err
+		}
+	}
+	w.loggedIn = true
+	w.log.Debug("Logged into PKCS#11 token", "label", w.info.Label)
+
+	// Notify anyone listening for wallet events that the token is accessible
+	go w.hub.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletOpened})
+	return nil
+}
+
+// Close implements accounts.Wallet, logging out of the token and closing the
+// idle sessions.
+func (w *wallet) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.close()
+}
+
+// close logs out of the token and closes the idle sessions. It assumes the lock
+// is held.
+func (w *wallet) close() error {
+	var err error
+	if w.loggedIn && w.info.Flags&ckfLoginRequired != 0 {
+		err = w.pool.do(func(session uint) error {
+			return w.hub.module.Logout(session)
+		})
+		if errors.Is(err, ckrUserNotLoggedIn) {
+			err = nil
+		}
+	}
+	w.loggedIn, w.temporary, w.pin = false, false, ""
+	w.pool.close()
+	return err
+}
+
+// Accounts implements accounts.Wallet, returning the accounts of the secp256k1
+// keys stored on the token. The public keys are listed without logging in, so
+// the accounts are available before the token is opened.
+func (w *wallet) Accounts() []accounts.Account {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.keys == nil {
+		keys, err := w.findKeys()
+		if err != nil {
+			w.log.Warn("Failed to list PKCS#11 keys", "err", err)
+			return nil
+		}
+		w.keys = keys
+	}
+	accs := make([]accounts.Account, len(w.keys))
+	for i, key := range w.keys {
+		accs[i] = key.account
+	}
+	return accs
+}
+
+// findKeys lists the secp256k1 public keys stored on the token.
+func (w *wallet) findKeys() ([]key, error) {
+	keys := []key{}
+	err := w.pool.do(func(session uint) error {
+		objects, err := w.hub.module.FindObjects(session, []attribute{
+			ulongAttribute(ckaClass, ckoPublicKey),
+			ulongAttribute(ckaKeyType, ckkEC),
+		})
+		if err != nil {
+			return err
+		}
+		for _, object := range objects {
+			attrs, err := w.hub.module.Attributes(session, object, []uint{ckaID, ckaECParams, ckaECPoint})
+			if err != nil {
+				return err
+			}
+			id, params, point := attrs[0], attrs[1], attrs[2]
+			if !bytes.Equal(params, secp256k1Params) {
+				continue // Key of another curve
+			}
+			pubkey, err := decodeECPoint(point)
+			if err != nil {
+				w.log.Warn("Skipping invalid PKCS#11 key", "id", hex.EncodeToString(id), "err", err)
+				continue
+			}
+			url := w.url
+			url.Path += "/" + hex.EncodeToString(id)
+
+			keys = append(keys, key{
+				account: accounts.Account{Address: crypto.PubkeyToAddress(*pubkey), URL: url},
+				id:      id,
+				pubkey:  crypto.FromECDSAPub(pubkey),
+			})
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// decodeECPoint decodes the CKA_EC_POINT attribute of a public key, which is the
+// uncompressed point wrapped in a DER octet string, but is left unwrapped by some
+// libraries.
+func decodeECPoint(point []byte) (*ecdsa.PublicKey, error) {
+	if len(point) != 65 {
+		var unwrapped []byte
+		if rest, err := asn1.Unmarshal(point, &unwrapped); err != nil || len(rest) > 0 {
+			return nil, errors.New("invalid EC point encoding")
+		}
+		point = unwrapped
+	}
+	return crypto.UnmarshalPubkey(point)
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not stored on the token.
+func (w *wallet) Contains(account accounts.Account) bool {
+	_, ok := w.key(account)
+	return ok
+}
+
+// key returns the key of the given account, if stored on the token.
+func (w *wallet) key(account accounts.Account) (key, bool) {
+	w.Accounts() // Make sure the keys are listed
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, key := range w.keys {
+		if key.account.Address == account.Address && (account.URL == (accounts.URL{}) || account.URL == key.account.URL) {
+			return key, true
+		}
+	}
+	return key{}, false
+}
+
+// Derive implements accounts.Wallet, but is not supported by PKCS#11 tokens.
+func (w *wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop since PKCS#11 tokens don't
+// support key derivation.
+func (w *wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+func (w *wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, logging into the token with
+// the given PIN if it isn't open yet.
+func (w *wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	var sig []byte
+	err := w.withPIN(passphrase, func() (err error) {
+		sig, err = w.SignData(account, mimeType, data)
+		return err
+	})
+	return sig, err
+}
+
+// SignText implements accounts.Wallet, attempting to sign the hash of the given
+// text with the key of the account.
+func (w *wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.signHash(account, accounts.TextHash(text))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, logging into the token with
+// the given PIN if it isn't open yet.
+func (w *wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	var sig []byte
+	err := w.withPIN(passphrase, func() (err error) {
+		sig, err = w.SignText(account, text)
+		return err
+	})
+	return sig, err
+}
+
+// SignTx implements accounts.Wallet, signing the given transaction with the key
+// of the account using the latest signer of the given chain.
+func (w *wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := w.signHash(account, signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, logging into the token with
+// the given PIN if it isn't open yet.
+func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	var signed *types.Transaction
+	err := w.withPIN(passphrase, func() (err error) {
+		signed, err = w.SignTx(account, tx, chainID)
+		return err
+	})
+	return signed, err
+}
+
+// withPIN runs the given function with the token opened, logging in with the PIN
+// if it isn't open yet. Concurrent requests share the login, which is ended once
+// the last of them is done.
+func (w *wallet) withPIN(pin string, fn func() error) error {
+	w.lock.Lock()
+	if !w.loggedIn {
+		if err := w.open(pin); err != nil {
+			w.lock.Unlock()
+			return err
+		}
+		w.temporary, w.pin = true, pin
+	}
+	if w.temporary {
+		if subtle.ConstantTimeCompare([]byte(pin), []byte(w.pin)) != 1 {
+			w.lock.Unlock()
+			return accounts.ErrInvalidPassphrase
+		}
+		w.users++
+		defer w.release()
+	}
+	w.lock.Unlock()
+
+	return fn()
+}
+
+// release ends a request signing with a PIN, logging out of the token after the
+// last one if the login was made for them.
+func (w *wallet) release() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.users--
+	if w.users == 0 && w.temporary {
+		if err := w.close(); err != nil {
+			w.log.Warn("Failed to log out of PKCS#11 token", "err", err)
+		}
+	}
+}
+
+// signHash signs the given hash with the private key of the account, returning
+// the signature in the [R || S || V] format with V being 0 or 1.
+func (w *wallet) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+	key, ok := w.key(account)
+	if !ok {
+		return nil, accounts.ErrUnknownAccount
+	}
+	w.lock.Lock()
+	locked := !w.loggedIn && w.info.Flags&ckfLoginRequired != 0
+	w.lock.Unlock()
+
+	if locked {
+		return nil, ErrPINNeeded
+	}
+	var rs []byte
+	err := w.pool.do(func(session uint) error {
+		objects, err := w.hub.module.FindObjects(session, []attribute{
+			ulongAttribute(ckaClass, ckoPrivateKey),
+			{Type: ckaID, Value: key.id},
+		})
+		if err != nil {
+			return err
+		}
+		if len(objects) != 1 {
+			return fmt.Errorf("found %d private keys for account %v", len(objects), key.account.Address)
+		}
+		rs, err = w.hub.module.SignECDSA(session, objects[0], hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) != 64 {
+		return nil, fmt.Errorf("invalid signature length %d", len(rs))
+	}
+	r, s := new(big.Int).SetBytes(rs[:32]), new(big.Int).SetBytes(rs[32:])
+	return accounts.RecoverableSignature(r, s, hash, key.pubkey)
+}
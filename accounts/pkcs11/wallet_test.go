@@ -0,0 +1,330 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pkcs11
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/asn1"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testModule is a PKCS#11 library with a single token of software keys, which
+// returns signatures with high S values like some devices do.
+type testModule struct {
+	pin   string
+	keys  []*ecdsa.PrivateKey // Object 2i+1 is the public and 2i+2 the private key
+	delay time.Duration       // Time taken by signing operations
+
+	lock     sync.Mutex
+	loggedIn bool
+	sessions map[uint]bool // Open sessions, mapped to whether they are in use
+	next     uint
+	opened   int // Number of sessions ever opened
+}
+
+func newTestModule(pin string, keys int) *testModule {
+	m := &testModule{pin: pin, sessions: make(map[uint]bool)}
+	for range keys {
+		key, _ := crypto.GenerateKey()
+		m.keys = append(m.keys, key)
+	}
+	return m
+}
+
+func (m *testModule) Slots() ([]uint, error) { return []uint{0}, nil }
+
+func (m *testModule) TokenInfo(slot uint) (tokenInfo, error) {
+	return tokenInfo{Label: "test", Serial: "0123456789", Flags: ckfLoginRequired}, nil
+}
+
+func (m *testModule) OpenSession(slot uint) (uint, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.next++
+	m.opened++
+	m.sessions[m.next] = false
+	return m.next, nil
+}
+
+func (m *testModule) CloseSession(session uint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	delete(m.sessions, session)
+	return nil
+}
+
+// use marks the session in use for the duration of an operation, failing if it
+// is used concurrently, which PKCS#11 doesn't allow.
+func (m *testModule) use(session uint) (func(), error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	busy, ok := m.sessions[session]
+	if !ok {
+		return nil, ckrSessionHandleInvalid
+	}
+	if busy {
+		return nil, Error(0x90) // CKR_OPERATION_ACTIVE
+	}
+	m.sessions[session] = true
+	return func() {
+		m.lock.Lock()
+		m.sessions[session] = false
+		m.lock.Unlock()
+	}, nil
+}
+
+func (m *testModule) Login(session uint, pin string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.loggedIn {
+		return ckrUserAlreadyLoggedIn
+	}
+	if pin != m.pin {
+		return ckrPINIncorrect
+	}
+	m.loggedIn = true
+	return nil
+}
+
+func (m *testModule) Logout(session uint) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if !m.loggedIn {
+		return ckrUserNotLoggedIn
+	}
+	m.loggedIn = false
+	return nil
+}
+
+func (m *testModule) FindObjects(session uint, template []attribute) ([]uint, error) {
+	var objects []uint
+	for i := range m.keys {
+		for _, class := range []uint{ckoPublicKey, ckoPrivateKey} {
+			object := uint(2*i) + class - 1
+			match := true
+			for _, attr := range template {
+				switch attr.Type {
+				case ckaClass:
+					match = match && bytes.Equal(attr.Value, ulongAttribute(ckaClass, class).Value)
+				case ckaKeyType:
+					match = match && bytes.Equal(attr.Value, ulongAttribute(ckaKeyType, ckkEC).Value)
+				case ckaID:
+					match = match && bytes.Equal(attr.Value, []byte{byte(i)})
+				}
+			}
+			if match {
+				objects = append(objects, object)
+			}
+		}
+	}
+	return objects, nil
+}
+
+func (m *testModule) Attributes(session uint, object uint, types []uint) ([][]byte, error) {
+	key := m.keys[(object-1)/2]
+	values := make([][]byte, len(types))
+	for i, typ := range types {
+		switch typ {
+		case ckaID:
+			values[i] = []byte{byte((object - 1) / 2)}
+		case ckaECParams:
+			values[i] = secp256k1Params
+		case ckaECPoint:
+			values[i], _ = asn1.Marshal(crypto.FromECDSAPub(&key.PublicKey))
+		}
+	}
+	return values, nil
+}
+
+func (m *testModule) SignECDSA(session uint, object uint, digest []byte) ([]byte, error) {
+	release, err := m.use(session)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	time.Sleep(m.delay)
+
+	m.lock.Lock()
+	loggedIn := m.loggedIn
+	m.lock.Unlock()
+
+	if !loggedIn {
+		return nil, ckrUserNotLoggedIn
+	}
+	sig, err := crypto.Sign(digest, m.keys[(object-1)/2])
+	if err != nil {
+		return nil, err
+	}
+	new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(sig[32:64])).FillBytes(sig[32:64])
+	return sig[:64], nil
+}
+
+func TestWalletPIN(t *testing.T) {
+	module := newTestModule("1234", 2)
+	hub := newHub(module)
+
+	wallets := hub.Wallets()
+	if len(wallets) != 1 {
+		t.Fatalf("wallet count mismatch: have %d, want 1", len(wallets))
+	}
+	wallet := wallets[0]
+	if url := wallet.URL().String(); url != "pkcs11://0123456789" {
+		t.Errorf("wallet URL mismatch: have %s, want pkcs11://0123456789", url)
+	}
+	// Accounts must be listed before the wallet is opened
+	accs := wallet.Accounts()
+	if len(accs) != 2 {
+		t.Fatalf("account count mismatch: have %d, want 2", len(accs))
+	}
+	for i, acc := range accs {
+		if want := crypto.PubkeyToAddress(module.keys[i].PublicKey); acc.Address != want {
+			t.Errorf("account %d: address mismatch: have %v, want %v", i, acc.Address, want)
+		}
+	}
+	// Signing must require the PIN
+	if _, err := wallet.SignText(accs[0], []byte("hello")); err != ErrPINNeeded {
+		t.Fatalf("signing with locked wallet: have %v, want %v", err, ErrPINNeeded)
+	}
+	if err := wallet.Open(""); err != ErrPINNeeded {
+		t.Fatalf("opening without PIN: have %v, want %v", err, ErrPINNeeded)
+	}
+	if err := wallet.Open("4321"); err != accounts.ErrInvalidPassphrase {
+		t.Fatalf("opening with wrong PIN: have %v, want %v", err, accounts.ErrInvalidPassphrase)
+	}
+	// Signing with the PIN must only log in for the duration of the request
+	if _, err := wallet.SignTextWithPassphrase(accs[0], "1234", []byte("hello")); err != nil {
+		t.Fatalf("failed to sign with PIN: %v", err)
+	}
+	if status, _ := wallet.Status(); status != "Locked" {
+		t.Errorf("wallet status mismatch after signing with PIN: have %s, want Locked", status)
+	}
+	// Concurrent requests must share the login made with the PIN
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 16)
+	)
+	module.delay = 10 * time.Millisecond
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := wallet.SignTextWithPassphrase(accs[i%2], "1234", []byte("hello"))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("failed to sign concurrently with PIN: %v", err)
+		}
+	}
+	if module.loggedIn {
+		t.Errorf("token still logged in after signing with PIN")
+	}
+	module.delay = 0
+	if err := wallet.Open("1234"); err != nil {
+		t.Fatalf("failed to open wallet: %v", err)
+	}
+	if status, _ := wallet.Status(); status != "Unlocked" {
+		t.Errorf("wallet status mismatch: have %s, want Unlocked", status)
+	}
+	if _, err := wallet.SignText(accs[1], []byte("hello")); err != nil {
+		t.Fatalf("failed to sign with open wallet: %v", err)
+	}
+	if err := wallet.Close(); err != nil {
+		t.Fatalf("failed to close wallet: %v", err)
+	}
+	if module.loggedIn {
+		t.Errorf("token still logged in after closing wallet")
+	}
+}
+
+func TestWalletSign(t *testing.T) {
+	module := newTestModule("1234", 1)
+	wallet := newHub(module).Wallets()[0]
+	if err := wallet.Open("1234"); err != nil {
+		t.Fatalf("failed to open wallet: %v", err)
+	}
+	account := accounts.Account{Address: crypto.PubkeyToAddress(module.keys[0].PublicKey)}
+
+	// Signatures must be recoverable with low S values
+	sig, err := wallet.SignData(account, accounts.MimetypeTypedData, []byte("hello"))
+	if err != nil {
+		t.Fatalf("failed to sign data: %v", err)
+	}
+	if !crypto.ValidateSignatureValues(sig[64], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64]), true) {
+		t.Errorf("signature has high S value")
+	}
+	pub, err := crypto.SigToPub(crypto.Keccak256([]byte("hello")), sig)
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if have := crypto.PubkeyToAddress(*pub); have != account.Address {
+		t.Errorf("recovered signer mismatch: have %v, want %v", have, account.Address)
+	}
+	// Transactions must be signed concurrently through separate sessions
+	var (
+		chainID = big.NewInt(1337)
+		to      = common.HexToAddress("0x01")
+		wg      sync.WaitGroup
+		errs    = make(chan error, 32)
+	)
+	for i := range 32 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: uint64(i), GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1), Gas: 21000, To: &to})
+			signed, err := wallet.SignTx(account, tx, chainID)
+			if err != nil {
+				errs <- err
+				return
+			}
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			if err == nil && sender != account.Address {
+				t.Errorf("tx %d: sender mismatch: have %v, want %v", i, sender, account.Address)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+	}
+	if module.opened > maxSessions {
+		t.Errorf("too many sessions opened: have %d, want at most %d", module.opened, maxSessions)
+	}
+}
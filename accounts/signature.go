@@ -0,0 +1,56 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// secp256k1N is the order of the secp256k1 curve, and secp256k1HalfN half of it.
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// RecoverableSignature converts the R and S values of an ECDSA signature of the
+// hash, made by an external signer such as a hardware security module, into the
+// [R || S || V] format with V being 0 or 1. S is normalized into the lower half of
+// the curve order as required by EIP-2, since signers may return either, and V is
+// determined by recovering the given uncompressed public key.
+func RecoverableSignature(r, s *big.Int, hash []byte, pubkey []byte) ([]byte, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(secp256k1N) >= 0 || s.Cmp(secp256k1N) >= 0 {
+		return nil, errors.New("invalid signature: values out of range")
+	}
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		if recovered, err := crypto.Ecrecover(hash, sig); err == nil && bytes.Equal(recovered, pubkey) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("signature not made by the account key")
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRecoverableSignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+
+	hash := crypto.Keccak256([]byte("hello"))
+	want, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := new(big.Int).SetBytes(want[:32])
+	s := new(big.Int).SetBytes(want[32:64])
+	highS := new(big.Int).Sub(secp256k1N, s)
+
+	// Both the low and the high S form must yield the canonical signature
+	for _, s := range []*big.Int{s, highS} {
+		sig, err := RecoverableSignature(r, s, hash, crypto.FromECDSAPub(&key.PublicKey))
+		if err != nil {
+			t.Fatalf("failed to convert signature: %v", err)
+		}
+		if !bytes.Equal(sig, want) {
+			t.Errorf("signature mismatch: have %x, want %x", sig, want)
+		}
+	}
+	if _, err := RecoverableSignature(r, s, hash, crypto.FromECDSAPub(&other.PublicKey)); err == nil {
+		t.Errorf("signature of another key accepted")
+	}
+	if _, err := RecoverableSignature(r, secp256k1N, hash, crypto.FromECDSAPub(&key.PublicKey)); err == nil {
+		t.Errorf("out of range signature accepted")
+	}
+}
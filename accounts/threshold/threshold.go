@@ -0,0 +1,261 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package threshold implements wallets signing with the coordinators of threshold
+// signature schemes, which implement accounts.ThresholdSigner.
+//
+// The wallets block on the asynchronous signing sessions of the coordinators to
+// implement accounts.Wallet, and additionally expose the sessions asynchronously
+// for callers not willing to wait for them.
+package threshold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DefaultTimeout is the time allowed for blocking signing sessions to complete,
+// long enough for the parties to approve requests manually.
+const DefaultTimeout = 5 * time.Minute
+
+// ErrTimeout is returned if a blocking signing session doesn't complete in time.
+var ErrTimeout = errors.New("threshold signing timed out")
+
+// Backend is an accounts.Backend of the wallets of a fixed set of coordinators.
+type Backend struct {
+	wallets []accounts.Wallet // Wallets of the coordinators, sorted by URL
+}
+
+// NewBackend creates a backend of the wallets of the given coordinators.
+func NewBackend(signers ...accounts.ThresholdSigner) *Backend {
+	wallets := make([]accounts.Wallet, len(signers))
+	for i, signer := range signers {
+		wallets[i] = NewWallet(signer)
+	}
+	slices.SortFunc(wallets, func(a, b accounts.Wallet) int {
+		return a.URL().Cmp(b.URL())
+	})
+	return &Backend{wallets: wallets}
+}
+
+// Wallets implements accounts.Backend, returning the wallets of the coordinators.
+func (b *Backend) Wallets() []accounts.Wallet {
+	return slices.Clone(b.wallets)
+}
+
+// Subscribe implements accounts.Backend, but no events are ever fired since the
+// set of coordinators is fixed.
+func (b *Backend) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+// Wallet is an accounts.Wallet of the accounts of a threshold signing coordinator.
+type Wallet struct {
+	signer  accounts.ThresholdSigner
+	timeout time.Duration
+	lock    sync.RWMutex
+}
+
+// NewWallet creates a wallet of the accounts of the coordinator.
+func NewWallet(signer accounts.ThresholdSigner) *Wallet {
+	return &Wallet{signer: signer, timeout: DefaultTimeout}
+}
+
+// SetTimeout sets the time allowed for blocking signing sessions to complete.
+func (w *Wallet) SetTimeout(timeout time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.timeout = timeout
+}
+
+// URL implements accounts.Wallet, returning the URL of the coordinator.
+func (w *Wallet) URL() accounts.URL {
+	return w.signer.URL()
+}
+
+// Status implements accounts.Wallet, always returning a static status since the
+// availability of the parties is only known while signing.
+func (w *Wallet) Status() (string, error) {
+	return "Threshold signer", nil
+}
+
+// Open implements accounts.Wallet, but is a noop since the coordinator manages
+// the connections to the parties.
+func (w *Wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, but is a noop since there is no meaningful
+// open operation.
+func (w *Wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet, returning the accounts of the coordinator.
+func (w *Wallet) Accounts() []accounts.Account {
+	return w.signer.Accounts()
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// managed by the coordinator.
+func (w *Wallet) Contains(account accounts.Account) bool {
+	return slices.ContainsFunc(w.signer.Accounts(), func(a accounts.Account) bool {
+		return a.Address == account.Address
+	})
+}
+
+// Derive implements accounts.Wallet, but is not supported by threshold signers.
+func (w *Wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop since threshold signers
+// don't support derivation.
+func (w *Wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// SignData signs keccak256(data). The mimetype parameter describes the type of data being signed.
+func (w *Wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since signing is authorized by the parties.
+func (w *Wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.SignData(account, mimeType, data)
+}
+
+// SignText implements accounts.Wallet, signing the hash of the given text.
+func (w *Wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return w.signHash(account, accounts.TextHash(text))
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since signing is authorized by the parties.
+func (w *Wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, text []byte) ([]byte, error) {
+	return w.SignText(account, text)
+}
+
+// SignTx implements accounts.Wallet, signing the given transaction using the
+// latest signer of the given chain.
+func (w *Wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	signer := types.LatestSignerForChainID(chainID)
+	sig, err := w.signHash(account, signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, ignoring the passphrase
+// since signing is authorized by the parties.
+func (w *Wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignTx(account, tx, chainID)
+}
+
+// SignTextAsync starts a session signing the hash of the given text, invoking
+// the callback with the signature once it completes. The callback is not invoked
+// if an error is returned.
+func (w *Wallet) SignTextAsync(ctx context.Context, account accounts.Account, text []byte, callback accounts.SignatureCallback) error {
+	return w.signHashAsync(ctx, account, accounts.TextHash(text), callback)
+}
+
+// SignTxAsync starts a session signing the given transaction using the latest
+// signer of the given chain, invoking the callback with the signed transaction
+// once it completes. The callback is not invoked if an error is returned.
+func (w *Wallet) SignTxAsync(ctx context.Context, account accounts.Account, tx *types.Transaction, chainID *big.Int, callback func(*types.Transaction, error)) error {
+	signer := types.LatestSignerForChainID(chainID)
+	return w.signHashAsync(ctx, account, signer.Hash(tx).Bytes(), func(sig []byte, err error) {
+		if err != nil {
+			callback(nil, err)
+			return
+		}
+		callback(tx.WithSignature(signer, sig))
+	})
+}
+
+// signHash signs the hash with the key of the account, blocking until the
+// session completes or times out.
+func (w *Wallet) signHash(account accounts.Account, hash []byte) ([]byte, error) {
+	w.lock.RLock()
+	timeout := w.timeout
+	w.lock.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		sig []byte
+		err error
+	}
+	done := make(chan result, 1)
+	if err := w.signHashAsync(ctx, account, hash, func(sig []byte, err error) {
+		done <- result{sig, err}
+	}); err != nil {
+		return nil, err
+	}
+	select {
+	case res := <-done:
+		return res.sig, res.err
+	case <-ctx.Done():
+		return nil, ErrTimeout
+	}
+}
+
+// signHashAsync starts a session signing the hash with the key of the account,
+// invoking the callback with the verified signature once it completes.
+func (w *Wallet) signHashAsync(ctx context.Context, account accounts.Account, hash []byte, callback accounts.SignatureCallback) error {
+	if !w.Contains(account) {
+		return accounts.ErrUnknownAccount
+	}
+	return w.signer.Sign(ctx, account, hash, func(sig []byte, err error) {
+		if err == nil {
+			sig, err = verifySignature(account.Address, hash, sig)
+		}
+		callback(sig, err)
+	})
+}
+
+// verifySignature checks that the signature of the hash was made by the key of
+// the given address, returning it with V normalized to 0 or 1.
+func verifySignature(addr common.Address, hash []byte, sig []byte) ([]byte, error) {
+	if len(sig) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	sig = common.CopyBytes(sig)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return nil, err
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != addr {
+		return nil, fmt.Errorf("signer mismatch: expected %s, got %s", addr.Hex(), signer.Hex())
+	}
+	return sig, nil
+}
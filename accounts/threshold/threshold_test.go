@@ -0,0 +1,126 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package threshold
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testSigner is a coordinator completing signing sessions in the background
+// with a key it holds whole.
+type testSigner struct {
+	key     *ecdsa.PrivateKey
+	delay   time.Duration // Time it takes the parties to sign
+	corrupt bool          // Whether to produce signatures of a wrong key
+}
+
+func (s *testSigner) URL() accounts.URL {
+	return accounts.URL{Scheme: "mpc", Path: "coordinator"}
+}
+
+func (s *testSigner) Accounts() []accounts.Account {
+	return []accounts.Account{{Address: crypto.PubkeyToAddress(s.key.PublicKey), URL: s.URL()}}
+}
+
+func (s *testSigner) Sign(ctx context.Context, account accounts.Account, hash []byte, callback accounts.SignatureCallback) error {
+	key, delay := s.key, s.delay
+	if s.corrupt {
+		key, _ = crypto.GenerateKey()
+	}
+	go func() {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			callback(nil, ctx.Err())
+			return
+		}
+		sig, err := crypto.Sign(hash, key)
+		if err == nil {
+			sig[crypto.RecoveryIDOffset] += 27
+		}
+		callback(sig, err)
+	}()
+	return nil
+}
+
+func TestWallet(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := &testSigner{key: key, delay: 10 * time.Millisecond}
+
+	am := accounts.NewManager(nil, NewBackend(signer))
+	defer am.Close()
+
+	account := signer.Accounts()[0]
+	wallet, err := am.Find(account)
+	if err != nil {
+		t.Fatalf("failed to find wallet: %v", err)
+	}
+	// Blocking signing must wait for the session to complete
+	var (
+		chainID = big.NewInt(1337)
+		to      = common.HexToAddress("0x01")
+		tx      = types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1), To: &to})
+	)
+	signed, err := wallet.SignTx(account, tx, chainID)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed); err != nil || sender != account.Address {
+		t.Errorf("sender mismatch: have %v (%v), want %v", sender, err, account.Address)
+	}
+	// Asynchronous signing must deliver the result to the callback
+	done := make(chan error, 1)
+	err = wallet.(*Wallet).SignTxAsync(context.Background(), account, tx, chainID, func(signed *types.Transaction, err error) {
+		if err == nil {
+			var sender common.Address
+			if sender, err = types.Sender(types.LatestSignerForChainID(chainID), signed); err == nil && sender != account.Address {
+				err = accounts.ErrUnknownAccount
+			}
+		}
+		done <- err
+	})
+	if err != nil {
+		t.Fatalf("failed to start signing session: %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("asynchronous signing failed: %v", err)
+	}
+	// Unknown accounts must be rejected without starting a session
+	if _, err := wallet.SignText(accounts.Account{Address: to}, []byte("hello")); err != accounts.ErrUnknownAccount {
+		t.Errorf("signing with unknown account: have %v, want %v", err, accounts.ErrUnknownAccount)
+	}
+	// Sessions not completing in time must fail
+	signer.delay = time.Hour
+	wallet.(*Wallet).SetTimeout(50 * time.Millisecond)
+	if _, err := wallet.SignText(account, []byte("hello")); err != ErrTimeout {
+		t.Errorf("signing with stalled session: have %v, want %v", err, ErrTimeout)
+	}
+	// Signatures of other keys must be rejected
+	signer.delay, signer.corrupt = 0, true
+	if _, err := wallet.SignText(account, []byte("hello")); err == nil {
+		t.Errorf("accepted signature of wrong key")
+	}
+}
@@ -70,6 +70,16 @@ type Hub struct {
 
 // NewLedgerHub creates a new hardware wallet manager for Ledger devices.
 func NewLedgerHub() (*Hub, error) {
+	return NewLedgerHubWithMetadata(nil)
+}
+
+// NewLedgerHubWithMetadata creates a new hardware wallet manager for Ledger
+// devices, which are sent the metadata of the contracts called by transactions
+// resolved by the provider, so that the calls are displayed decoded.
+func NewLedgerHubWithMetadata(provider LedgerMetadataProvider) (*Hub, error) {
+	makeDriver := func(logger log.Logger) driver {
+		return newLedgerDriver(logger, provider)
+	}
 	return newHub(LedgerScheme, 0x2c97, []uint16{
 
 		// Device definitions taken from
@@ -89,7 +99,7 @@ func NewLedgerHub() (*Hub, error) {
 		0x5000, /* WebUSB Ledger Nano S Plus */
 		0x6000, /* WebUSB Ledger Nano FTS */
 		0x7000, /* WebUSB Ledger Flex */
-	}, 0xffa0, 0, newLedgerDriver)
+	}, 0xffa0, 0, makeDriver)
 }
 
 // NewTrezorHubWithHID creates a new hardware wallet manager for Trezor devices.
@@ -27,6 +27,8 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"reflect"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -35,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 // ledgerOpcode is an enumeration encoding the supported Ledger opcodes.
@@ -52,13 +55,29 @@ const (
 	ledgerOpRetrieveAddress  ledgerOpcode = 0x02 // Returns the public key and Ethereum address for a given BIP 32 path
 	ledgerOpSignTransaction  ledgerOpcode = 0x04 // Signs an Ethereum transaction after having the user validate the parameters
 	ledgerOpGetConfiguration ledgerOpcode = 0x06 // Returns specific wallet application configuration
+	ledgerOpProvideERC20Info ledgerOpcode = 0x0a // Provides the signed description of an ERC-20 token to display amounts
 	ledgerOpSignTypedMessage ledgerOpcode = 0x0c // Signs an Ethereum message following the EIP 712 specification
+	ledgerOpTypedStructDef   ledgerOpcode = 0x1a // Sends the definition of a struct of EIP 712 typed data
+	ledgerOpTypedStructImpl  ledgerOpcode = 0x1c // Sends the values of a struct of EIP 712 typed data
+	ledgerOpSetPlugin        ledgerOpcode = 0x12 // Selects the signed plugin decoding the calls of a contract method
 
 	ledgerP1DirectlyFetchAddress    ledgerParam1 = 0x00 // Return address directly from the wallet
 	ledgerP1InitTypedMessageData    ledgerParam1 = 0x00 // First chunk of Typed Message data
 	ledgerP1InitTransactionData     ledgerParam1 = 0x00 // First transaction data block for signing
 	ledgerP1ContTransactionData     ledgerParam1 = 0x80 // Subsequent transaction data block for signing
+	ledgerP1CompleteTypedValue      ledgerParam1 = 0x00 // Last chunk of a typed data value
+	ledgerP1PartialTypedValue       ledgerParam1 = 0x01 // Chunk of a typed data value followed by more
 	ledgerP2DiscardAddressChainCode ledgerParam2 = 0x00 // Do not return the chain code along with the address
+	ledgerP2TypedStructName         ledgerParam2 = 0x00 // Name of the struct being defined or implemented
+	ledgerP2TypedArraySize          ledgerParam2 = 0x0f // Size of the array being implemented
+	ledgerP2TypedStructMember       ledgerParam2 = 0xff // Definition or value of the next struct member
+	ledgerP2HashedTypedMessage      ledgerParam2 = 0x00 // Sign the typed data by the hashes of the domain and message
+	ledgerP2FullTypedMessage        ledgerParam2 = 0x01 // Sign the typed data implemented beforehand
+
+	ledgerStatusOK          uint16 = 0x9000 // Status word of successfully executed commands
+	ledgerStatusInvalidData uint16 = 0x6a80 // Status word of rejected data, e.g. contract data if blind signing is disabled
+
+	ledgerFlagBlindSigning byte = 0x01 // Configuration flag of the user allowing to sign arbitrary (blind) data
 
 	ledgerEip155Size int = 3 // Size of the EIP-155 chain_id,r,s in unsigned transactions
 )
@@ -72,6 +91,11 @@ var errLedgerReplyInvalidHeader = errors.New("ledger: invalid reply header")
 // when a response does arrive, but it does not contain the expected data.
 var errLedgerInvalidVersionReply = errors.New("ledger: invalid version reply")
 
+// errLedgerBlindSigning is the error message returned if a transaction calling a
+// contract can't be displayed decoded by a Ledger wallet, which the user didn't
+// allow to sign blindly.
+var errLedgerBlindSigning = errors.New("ledger: contract data can't be displayed, enable blind signing in the Ethereum app settings")
+
 // ledgerDriver implements the communication with a Ledger hardware wallet.
 type ledgerDriver struct {
 	device  io.ReadWriter // USB device connection to communicate through
@@ -79,12 +103,16 @@ type ledgerDriver struct {
 	browser bool          // Flag whether the Ledger is in browser mode (reply channel mismatch)
 	failure error         // Any failure that would make the device unusable
 	log     log.Logger    // Contextual logger to tag the ledger with its id
+
+	metadata LedgerMetadataProvider // Resolver of the metadata of called contracts (optional)
 }
 
-// newLedgerDriver creates a new instance of a Ledger USB protocol driver.
-func newLedgerDriver(logger log.Logger) driver {
+// newLedgerDriver creates a new instance of a Ledger USB protocol driver, using
+// the optional provider to resolve the metadata of called contracts.
+func newLedgerDriver(logger log.Logger, metadata LedgerMetadataProvider) driver {
 	return &ledgerDriver{
-		log: logger,
+		log:      logger,
+		metadata: metadata,
 	}
 }
 
@@ -170,6 +198,11 @@ func (w *ledgerDriver) SignTx(path accounts.DerivationPath, tx *types.Transactio
 		//lint:ignore ST1005 brand name displayed on the console
 		return common.Address{}, nil, fmt.Errorf("Ledger v%d.%d.%d doesn't support signing this transaction, please update to v1.0.3 at least", w.version[0], w.version[1], w.version[2])
 	}
+	// Make sure the user gets to see what a contract call does, or that they
+	// allowed signing it blindly
+	if err := w.ledgerPrepareContractCall(tx, chainID); err != nil {
+		return common.Address{}, nil, err
+	}
 	// All infos gathered and metadata checks out, request signing
 	return w.ledgerSign(path, tx, chainID)
 }
@@ -192,6 +225,40 @@ func (w *ledgerDriver) SignTypedMessage(path accounts.DerivationPath, domainHash
 	return w.ledgerSignTypedMessage(path, domainHash, messageHash)
 }
 
+// SignTypedData implements usbwallet.driver, streaming the typed data to the
+// Ledger so that it can display its members, and waiting for the user to sign
+// or deny it. Firmwares predating 1.9.19 can only display and sign the hashes of
+// the domain and the message, which is done instead.
+//
+// Note, the filtering of the displayed members isn't used, since the filters
+// need to be signed by Ledger.
+func (w *ledgerDriver) SignTypedData(path accounts.DerivationPath, typedData apitypes.TypedData) ([]byte, error) {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return nil, accounts.ErrWalletClosed
+	}
+	// Fall back to signing the hashes on firmwares not supporting typed data
+	if w.version[0] < 1 || (w.version[0] == 1 && (w.version[1] < 9 || (w.version[1] == 9 && w.version[2] < 19))) {
+		domainHash, err := typedData.HashStruct(typedDataDomain, typedData.Domain.Map())
+		if err != nil {
+			return nil, err
+		}
+		messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := w.SignTypedMessage(path, domainHash, messageHash)
+		if err != nil {
+			return nil, err
+		}
+		if signature[crypto.RecoveryIDOffset] >= 27 {
+			signature[crypto.RecoveryIDOffset] -= 27
+		}
+		return signature, nil
+	}
+	return w.ledgerSignTypedData(path, &typedData)
+}
+
 // ledgerVersion retrieves the current version of the Ethereum wallet app running
 // on the Ledger wallet.
 //
@@ -210,18 +277,25 @@ func (w *ledgerDriver) SignTypedMessage(path accounts.DerivationPath, domainHash
 //	Application minor version                          | 1 byte
 //	Application patch version                          | 1 byte
 func (w *ledgerDriver) ledgerVersion() ([3]byte, error) {
+	_, version, err := w.ledgerConfiguration()
+	return version, err
+}
+
+// ledgerConfiguration retrieves the flags set by the user and the version of the
+// Ethereum wallet app running on the Ledger wallet, as defined by ledgerVersion.
+func (w *ledgerDriver) ledgerConfiguration() (byte, [3]byte, error) {
 	// Send the request and wait for the response
 	reply, err := w.ledgerExchange(ledgerOpGetConfiguration, 0, 0, nil)
 	if err != nil {
-		return [3]byte{}, err
+		return 0, [3]byte{}, err
 	}
 	if len(reply) != 4 {
-		return [3]byte{}, errLedgerInvalidVersionReply
+		return 0, [3]byte{}, errLedgerInvalidVersionReply
 	}
 	// Cache the version for future reference
 	var version [3]byte
 	copy(version[:], reply[1:])
-	return version, nil
+	return reply[0], version, nil
 }
 
 // ledgerDerive retrieves the currently active Ethereum address from a Ledger
@@ -360,8 +434,9 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction
 
 	// Send the request and wait for the response
 	var (
-		op    = ledgerP1InitTransactionData
-		reply []byte
+		op     = ledgerP1InitTransactionData
+		reply  []byte
+		status uint16
 	)
 
 	// Chunk size selection to mitigate an underlying RLP deserialization issue on the ledger app.
@@ -378,10 +453,15 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, tx *types.Transaction
 			chunk = len(payload)
 		}
 		// Send the chunk over, ensuring it's processed correctly
-		reply, err = w.ledgerExchange(ledgerOpSignTransaction, op, 0, payload[:chunk])
+		reply, status, err = w.ledgerExchangeStatus(ledgerOpSignTransaction, op, 0, payload[:chunk])
 		if err != nil {
 			return common.Address{}, nil, err
 		}
+		// Contract data is rejected if it can't be displayed and blind signing is
+		// disabled, which might have been toggled since the configuration check
+		if status == ledgerStatusInvalidData && len(tx.Data()) > 0 {
+			return common.Address{}, nil, errLedgerBlindSigning
+		}
 		// Shift the payload and ensure subsequent chunks are marked as such
 		payload = payload[chunk:]
 		op = ledgerP1ContTransactionData
@@ -460,7 +540,7 @@ func (w *ledgerDriver) ledgerSignTypedMessage(derivationPath []uint32, domainHas
 	)
 
 	// Send the message over, ensuring it's processed correctly
-	reply, err = w.ledgerExchange(ledgerOpSignTypedMessage, op, 0, payload)
+	reply, err = w.ledgerExchange(ledgerOpSignTypedMessage, op, ledgerP2HashedTypedMessage, payload)
 
 	if err != nil {
 		return nil, err
@@ -474,8 +554,258 @@ func (w *ledgerDriver) ledgerSignTypedMessage(derivationPath []uint32, domainHas
 	return signature, nil
 }
 
-// ledgerExchange performs a data exchange with the Ledger wallet, sending it a
-// message and retrieving the response.
+// ledgerSignTypedData sends the definitions of all the struct types and the
+// values of the domain and the message of the typed data to the Ledger wallet,
+// and waits for the user to sign or deny them.
+//
+// The struct definition protocol is defined as follows:
+//
+//	CLA | INS | P1 | P2                  | Lc       | Le
+//	----+-----+----+---------------------+----------+---
+//	 E0 | 1A  | 00 | 00: struct name     | variable | 00
+//	                 FF: struct member
+//
+// Where the input is the name of the struct, followed by the definitions of its
+// members, each of which is encoded as:
+//
+//	Description                                        | Length
+//	---------------------------------------------------+----------
+//	Type (80: array | 40: sized | 0-7: type id)        | 1 byte
+//	Struct name length, if a struct                    | 1 byte
+//	Struct name, if a struct                           | variable
+//	Type size in bytes, if sized                       | 1 byte
+//	Number of array dimensions, if an array            | 1 byte
+//	Dimension kind (00: dynamic, 01: fixed)            | 1 byte
+//	Dimension size, if fixed                           | 1 byte
+//	...                                                |
+//	Member name length                                 | 1 byte
+//	Member name                                        | variable
+//
+// The struct implementation protocol is defined as follows:
+//
+//	CLA | INS | P1                 | P2                  | Lc       | Le
+//	----+-----+--------------------+---------------------+----------+---
+//	 E0 | 1C  | 00: complete value | 00: struct name     | variable | 00
+//	            01: partial value  | 0F: array size
+//	                                 FF: member value
+//
+// Where the input is the name of the root struct (domain or message), the sizes
+// of the arrays, and the values of the atomic members in depth first order. The
+// values are prefixed by their 2 byte length, and split into chunks of at most
+// 255 bytes.
+//
+// Finally, the typed data is signed with the typed message signing opcode, with
+// P2 set to 01 and the input only consisting of the derivation path.
+func (w *ledgerDriver) ledgerSignTypedData(derivationPath []uint32, typedData *apitypes.TypedData) ([]byte, error) {
+	// Define all the struct types, the order doesn't matter
+	names := make([]string, 0, len(typedData.Types))
+	for name := range typedData.Types {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		if err := w.ledgerTypedExchange(ledgerOpTypedStructDef, 0, ledgerP2TypedStructName, []byte(name)); err != nil {
+			return nil, err
+		}
+		for _, member := range typedData.Types[name] {
+			def, err := ledgerTypedMemberDef(typedData, member)
+			if err != nil {
+				return nil, err
+			}
+			if err := w.ledgerTypedExchange(ledgerOpTypedStructDef, 0, ledgerP2TypedStructMember, def); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// Implement the domain and the message
+	for _, domain := range []bool{true, false} {
+		name, value := typedDataRoot(typedData, domain)
+		if err := w.ledgerTypedExchange(ledgerOpTypedStructImpl, 0, ledgerP2TypedStructName, []byte(name)); err != nil {
+			return nil, err
+		}
+		if err := w.ledgerTypedStruct(typedData, name, value); err != nil {
+			return nil, err
+		}
+	}
+	// Flatten the derivation path into the Ledger request and request signing
+	path := make([]byte, 1+4*len(derivationPath))
+	path[0] = byte(len(derivationPath))
+	for i, component := range derivationPath {
+		binary.BigEndian.PutUint32(path[1+4*i:], component)
+	}
+	reply, status, err := w.ledgerExchangeStatus(ledgerOpSignTypedMessage, ledgerP1InitTypedMessageData, ledgerP2FullTypedMessage, path)
+	if err != nil {
+		return nil, err
+	}
+	if status != ledgerStatusOK {
+		return nil, fmt.Errorf("ledger: typed data signing failed with status %#04x", status)
+	}
+	// Extract the Ethereum signature and do a sanity validation
+	if len(reply) != crypto.SignatureLength {
+		return nil, errors.New("reply lacks signature")
+	}
+	signature := append(reply[1:], reply[0])
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	return signature, nil
+}
+
+// ledgerTypedMemberDef encodes the definition of a struct member.
+func ledgerTypedMemberDef(typedData *apitypes.TypedData, member apitypes.Type) ([]byte, error) {
+	typ, err := parseTypedDataType(member.Type)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		kind byte
+		def  = []byte{0}
+	)
+	switch {
+	case typedData.Types[typ.base] != nil:
+		if len(typ.base) > 255 {
+			return nil, fmt.Errorf("struct name too long: %s", typ.base)
+		}
+		kind = 0
+		def = append(def, byte(len(typ.base)))
+		def = append(def, typ.base...)
+	case typ.base == "address":
+		kind = 3
+	case typ.base == "bool":
+		kind = 4
+	case typ.base == "string":
+		kind = 5
+	case typ.base == "bytes":
+		kind = 7
+	case typ.size() > 0:
+		switch typ.base[0] {
+		case 'i':
+			kind = 1
+		case 'u':
+			kind = 2
+		default:
+			kind = 6
+		}
+		kind |= 0x40
+		def = append(def, byte(typ.size()))
+	default:
+		return nil, fmt.Errorf("unsupported type %q", member.Type)
+	}
+	if len(typ.arrays) > 0 {
+		kind |= 0x80
+		def = append(def, byte(len(typ.arrays)))
+		for _, size := range typ.arrays {
+			switch {
+			case size == 0:
+				def = append(def, 0)
+			case size <= 255:
+				def = append(def, 1, byte(size))
+			default:
+				return nil, fmt.Errorf("array too large in type %q", member.Type)
+			}
+		}
+	}
+	if len(member.Name) > 255 {
+		return nil, fmt.Errorf("member name too long: %s", member.Name)
+	}
+	def[0] = kind
+	def = append(def, byte(len(member.Name)))
+	return append(def, member.Name...), nil
+}
+
+// ledgerTypedStruct sends the values of the members of a struct.
+func (w *ledgerDriver) ledgerTypedStruct(typedData *apitypes.TypedData, name string, value map[string]interface{}) error {
+	for _, member := range typedData.Types[name] {
+		typ, err := parseTypedDataType(member.Type)
+		if err != nil {
+			return err
+		}
+		if err := w.ledgerTypedValue(typedData, typ, value[member.Name]); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, member.Name, err)
+		}
+	}
+	return nil
+}
+
+// ledgerTypedValue sends the value of a struct member or array element.
+func (w *ledgerDriver) ledgerTypedValue(typedData *apitypes.TypedData, typ typedDataType, value interface{}) error {
+	// Arrays are sent by their size, followed by their elements
+	if dims := len(typ.arrays); dims > 0 {
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice {
+			return fmt.Errorf("provided data '%v' is not slice", value)
+		}
+		if size := typ.arrays[dims-1]; (size != 0 && items.Len() != size) || items.Len() > 255 {
+			return fmt.Errorf("invalid array length %d", items.Len())
+		}
+		if err := w.ledgerTypedExchange(ledgerOpTypedStructImpl, 0, ledgerP2TypedArraySize, []byte{byte(items.Len())}); err != nil {
+			return err
+		}
+		for i := 0; i < items.Len(); i++ {
+			if err := w.ledgerTypedValue(typedData, typ.elem(), items.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	// Structs are sent member by member
+	if typedData.Types[typ.base] != nil {
+		members, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("provided data '%v' doesn't match type '%s'", value, typ.base)
+		}
+		return w.ledgerTypedStruct(typedData, typ.base, members)
+	}
+	// Atomic values are sent length prefixed, chunked up if too long
+	enc, err := encodeTypedDataValue(typedData, typ, value)
+	if err != nil {
+		return err
+	}
+	if len(enc) > 0xffff {
+		return fmt.Errorf("value too long: %d bytes", len(enc))
+	}
+	data := binary.BigEndian.AppendUint16(make([]byte, 0, 2+len(enc)), uint16(len(enc)))
+	data = append(data, enc...)
+
+	for len(data) > 0 {
+		var (
+			op    = ledgerP1CompleteTypedValue
+			chunk = data
+		)
+		if len(chunk) > 255 {
+			op, chunk = ledgerP1PartialTypedValue, chunk[:255]
+		}
+		if err := w.ledgerTypedExchange(ledgerOpTypedStructImpl, op, ledgerP2TypedStructMember, chunk); err != nil {
+			return err
+		}
+		data = data[len(chunk):]
+	}
+	return nil
+}
+
+// ledgerTypedExchange sends a part of typed data to the Ledger wallet, failing
+// if the device rejects it.
+func (w *ledgerDriver) ledgerTypedExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 ledgerParam2, data []byte) error {
+	_, status, err := w.ledgerExchangeStatus(opcode, p1, p2, data)
+	if err != nil {
+		return err
+	}
+	if status != ledgerStatusOK {
+		return fmt.Errorf("ledger: typed data rejected with status %#04x", status)
+	}
+	return nil
+}
+
+// ledgerExchange performs a data exchange with the Ledger wallet like
+// ledgerExchangeStatus, discarding the status word of the reply.
+func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 ledgerParam2, data []byte) ([]byte, error) {
+	reply, _, err := w.ledgerExchangeStatus(opcode, p1, p2, data)
+	return reply, err
+}
+
+// ledgerExchangeStatus performs a data exchange with the Ledger wallet, sending it a
+// message and retrieving the response along with its status word.
 //
 // The common transport header is defined as follows:
 //
@@ -507,7 +837,7 @@ func (w *ledgerDriver) ledgerSignTypedMessage(derivationPath []uint32, domainHas
 //	APDU P2                  | 1 byte
 //	APDU length              | 1 byte
 //	Optional APDU data       | arbitrary
-func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 ledgerParam2, data []byte) ([]byte, error) {
+func (w *ledgerDriver) ledgerExchangeStatus(opcode ledgerOpcode, p1 ledgerParam1, p2 ledgerParam2, data []byte) ([]byte, uint16, error) {
 	// Construct the message payload, possibly split into multiple chunks
 	apdu := make([]byte, 2, 7+len(data))
 
@@ -535,7 +865,7 @@ func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 l
 		// Send over to the device
 		w.log.Trace("Data chunk sent to the Ledger", "chunk", hexutil.Bytes(chunk))
 		if _, err := w.device.Write(chunk); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 	}
 	// Stream the reply back from the wallet in 64 byte chunks
@@ -544,13 +874,13 @@ func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 l
 	for {
 		// Read the next chunk from the Ledger wallet
 		if _, err := io.ReadFull(w.device, chunk); err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		w.log.Trace("Data chunk received from the Ledger", "chunk", hexutil.Bytes(chunk))
 
 		// Make sure the transport header matches
 		if chunk[0] != 0x01 || chunk[1] != 0x01 || chunk[2] != 0x05 {
-			return nil, errLedgerReplyInvalidHeader
+			return nil, 0, errLedgerReplyInvalidHeader
 		}
 		// If it's the first chunk, retrieve the total message length
 		var payload []byte
@@ -569,5 +899,5 @@ func (w *ledgerDriver) ledgerExchange(opcode ledgerOpcode, p1 ledgerParam1, p2 l
 			break
 		}
 	}
-	return reply[:len(reply)-2], nil
+	return reply[:len(reply)-2], binary.BigEndian.Uint16(reply[len(reply)-2:]), nil
 }
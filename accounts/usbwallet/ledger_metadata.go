@@ -0,0 +1,175 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// This file contains the support for sending the metadata of called contracts to
+// Ledger wallets, so that the Ethereum app displays the calls decoded instead of
+// requiring the user to sign contract data blindly.
+
+package usbwallet
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// erc20TransferSelector and erc20ApproveSelector are the selectors of the
+	// ERC-20 methods the Ethereum app displays decoded with token information.
+	erc20TransferSelector = [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+	erc20ApproveSelector  = [4]byte{0x09, 0x5e, 0xa7, 0xb3}
+)
+
+// LedgerTokenInfo is the description of an ERC-20 token, signed by Ledger, for
+// the Ethereum app to display token amounts of transfers and approvals.
+type LedgerTokenInfo struct {
+	Ticker    string
+	Address   common.Address
+	Decimals  uint32
+	ChainID   uint32
+	Signature []byte // Signature of Ledger over the other fields
+}
+
+// payload encodes the token description as the Ethereum app expects it:
+//
+//	Description                 | Length
+//	----------------------------+----------
+//	Length of the ticker        | 1 byte
+//	Ticker                      | variable
+//	Token address               | 20 bytes
+//	Decimals (big endian)       | 4 bytes
+//	Chain ID (big endian)       | 4 bytes
+//	Signature                   | variable
+func (info *LedgerTokenInfo) payload() []byte {
+	payload := append([]byte{byte(len(info.Ticker))}, info.Ticker...)
+	payload = append(payload, info.Address[:]...)
+	payload = binary.BigEndian.AppendUint32(payload, info.Decimals)
+	payload = binary.BigEndian.AppendUint32(payload, info.ChainID)
+	return append(payload, info.Signature...)
+}
+
+// LedgerPluginInfo selects the plugin of the Ethereum app decoding the calls of
+// a contract method, signed by Ledger.
+type LedgerPluginInfo struct {
+	Name      string
+	Address   common.Address
+	Selector  [4]byte
+	Signature []byte // Signature of Ledger over the other fields
+}
+
+// payload encodes the plugin selection as the Ethereum app expects it:
+//
+//	Description                 | Length
+//	----------------------------+----------
+//	Length of the plugin name   | 1 byte
+//	Plugin name                 | variable
+//	Contract address            | 20 bytes
+//	Method selector             | 4 bytes
+//	Signature                   | variable
+func (info *LedgerPluginInfo) payload() []byte {
+	payload := append([]byte{byte(len(info.Name))}, info.Name...)
+	payload = append(payload, info.Address[:]...)
+	payload = append(payload, info.Selector[:]...)
+	return append(payload, info.Signature...)
+}
+
+// LedgerMetadataProvider resolves the metadata of contracts, typically from the
+// crypto asset list of Ledger, which holds the signatures the devices verify.
+// Nil is returned for unknown contracts.
+type LedgerMetadataProvider interface {
+	// LedgerToken returns the description of the token at the given address.
+	LedgerToken(chainID uint64, token common.Address) *LedgerTokenInfo
+
+	// LedgerPlugin returns the plugin decoding calls of the given contract method.
+	LedgerPlugin(chainID uint64, contract common.Address, selector [4]byte) *LedgerPluginInfo
+}
+
+// ledgerPrepareContractCall sends the metadata of the contract called by the
+// transaction to the Ledger, if known. If the call can't be displayed decoded
+// and the user didn't allow blind signing, an error is returned instead of
+// letting the device reject the transaction obscurely.
+func (w *ledgerDriver) ledgerPrepareContractCall(tx *types.Transaction, chainID *big.Int) error {
+	if len(tx.Data()) == 0 {
+		return nil
+	}
+	if w.ledgerProvideMetadata(tx, chainID) {
+		return nil
+	}
+	flags, _, err := w.ledgerConfiguration()
+	if err == errLedgerInvalidVersionReply {
+		return nil // Ancient app, let the device decide
+	}
+	if err != nil {
+		return err
+	}
+	if flags&ledgerFlagBlindSigning == 0 {
+		return errLedgerBlindSigning
+	}
+	w.log.Info("Ledger signing contract data blindly", "to", tx.To(), "size", len(tx.Data()))
+	return nil
+}
+
+// ledgerProvideMetadata resolves the metadata of the contract called by the
+// transaction and sends it to the Ledger, returning whether the device accepted
+// metadata allowing it to display the call decoded. Rejected metadata, e.g. by
+// apps not supporting it, is skipped.
+func (w *ledgerDriver) ledgerProvideMetadata(tx *types.Transaction, chainID *big.Int) bool {
+	if w.metadata == nil || tx.To() == nil || len(tx.Data()) < 4 || chainID == nil || !chainID.IsUint64() {
+		return false
+	}
+	var (
+		chain     = chainID.Uint64()
+		contract  = *tx.To()
+		selector  [4]byte
+		described bool
+	)
+	copy(selector[:], tx.Data())
+
+	// Plugins were introduced in v1.6.0 of the Ethereum app
+	if w.version[0] > 1 || (w.version[0] == 1 && w.version[1] >= 6) {
+		if info := w.metadata.LedgerPlugin(chain, contract, selector); info != nil {
+			described = w.ledgerMetadataExchange(ledgerOpSetPlugin, info.payload())
+		}
+	}
+	// Token descriptions only identify chains fitting 32 bits
+	if chain <= math.MaxUint32 {
+		if info := w.metadata.LedgerToken(chain, contract); info != nil {
+			accepted := w.ledgerMetadataExchange(ledgerOpProvideERC20Info, info.payload())
+			if accepted && (selector == erc20TransferSelector || selector == erc20ApproveSelector) {
+				described = true
+			}
+		}
+	}
+	return described
+}
+
+// ledgerMetadataExchange sends metadata to the Ledger, returning whether it was
+// accepted.
+func (w *ledgerDriver) ledgerMetadataExchange(opcode ledgerOpcode, payload []byte) bool {
+	_, status, err := w.ledgerExchangeStatus(opcode, 0, 0, payload)
+	if err != nil {
+		w.log.Debug("Failed to send contract metadata to Ledger", "err", err)
+		return false
+	}
+	if status != ledgerStatusOK {
+		w.log.Debug("Ledger rejected contract metadata", "opcode", opcode, "status", status)
+		return false
+	}
+	return true
+}
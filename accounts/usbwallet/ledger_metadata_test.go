@@ -0,0 +1,199 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package usbwallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// testLedger is a Ledger device speaking the HID transport protocol, answering
+// the commands it receives with a handler.
+type testLedger struct {
+	handle func(ins byte, data []byte) ([]byte, uint16)
+
+	request []byte   // APDU being received
+	pending int      // Length of the APDU being received
+	replies [][]byte // Chunks of replies to be read
+}
+
+func (d *testLedger) Write(chunk []byte) (int, error) {
+	if chunk[3] == 0 && chunk[4] == 0 {
+		d.pending = int(binary.BigEndian.Uint16(chunk[5:7]))
+		d.request = append([]byte{}, chunk[7:]...)
+	} else {
+		d.request = append(d.request, chunk[5:]...)
+	}
+	if len(d.request) >= d.pending {
+		apdu := d.request[:d.pending]
+		reply, status := d.handle(apdu[1], apdu[5:])
+		reply = binary.BigEndian.AppendUint16(reply, status)
+
+		// Split the reply into 64 byte chunks
+		payload := binary.BigEndian.AppendUint16(nil, uint16(len(reply)))
+		payload = append(payload, reply...)
+		for i := 0; len(payload) > 0; i++ {
+			chunk := make([]byte, 64)
+			copy(chunk, []byte{0x01, 0x01, 0x05})
+			binary.BigEndian.PutUint16(chunk[3:], uint16(i))
+			n := copy(chunk[5:], payload)
+			payload = payload[n:]
+			d.replies = append(d.replies, chunk)
+		}
+	}
+	return len(chunk), nil
+}
+
+func (d *testLedger) Read(buf []byte) (int, error) {
+	n := copy(buf, d.replies[0])
+	d.replies = d.replies[1:]
+	return n, nil
+}
+
+// testMetadata is a metadata provider of a single token and plugin.
+type testMetadata struct {
+	token  *LedgerTokenInfo
+	plugin *LedgerPluginInfo
+}
+
+func (m *testMetadata) LedgerToken(chainID uint64, token common.Address) *LedgerTokenInfo {
+	if m.token != nil && m.token.Address == token {
+		return m.token
+	}
+	return nil
+}
+
+func (m *testMetadata) LedgerPlugin(chainID uint64, contract common.Address, selector [4]byte) *LedgerPluginInfo {
+	if m.plugin != nil && m.plugin.Address == contract && m.plugin.Selector == selector {
+		return m.plugin
+	}
+	return nil
+}
+
+func TestLedgerContractMetadata(t *testing.T) {
+	var (
+		key, _   = crypto.GenerateKey()
+		chainID  = big.NewInt(1)
+		token    = common.HexToAddress("0xdac17f958d2ee523a2206206994597c13d831ec7")
+		router   = common.HexToAddress("0x1111111254eeb25477b68fb85ed929f73a960582")
+		transfer = append(erc20TransferSelector[:], make([]byte, 64)...)
+		swap     = []byte{0x12, 0xaa, 0x3c, 0xaf, 0x00}
+
+		tokenInfo  = &LedgerTokenInfo{Ticker: "USDT", Address: token, Decimals: 6, ChainID: 1, Signature: []byte{0x30, 0x01}}
+		pluginInfo = &LedgerPluginInfo{Name: "1inch", Address: router, Selector: [4]byte{0x12, 0xaa, 0x3c, 0xaf}, Signature: []byte{0x30, 0x02}}
+	)
+	tests := []struct {
+		name     string
+		to       common.Address
+		data     []byte
+		metadata *testMetadata
+		flags    byte   // Configuration flags of the app
+		rejected []byte // Commands rejected by the app
+		sent     []byte // Commands expected to be sent, in order
+		err      error
+	}{
+		{
+			name:     "token transfer",
+			to:       token,
+			data:     transfer,
+			metadata: &testMetadata{token: tokenInfo},
+			sent:     []byte{byte(ledgerOpProvideERC20Info), byte(ledgerOpSignTransaction)},
+		},
+		{
+			name:     "plugin call",
+			to:       router,
+			data:     swap,
+			metadata: &testMetadata{token: tokenInfo, plugin: pluginInfo},
+			sent:     []byte{byte(ledgerOpSetPlugin), byte(ledgerOpSignTransaction)},
+		},
+		{
+			name:     "blind signing disabled",
+			to:       router,
+			data:     swap,
+			metadata: &testMetadata{token: tokenInfo},
+			sent:     []byte{byte(ledgerOpGetConfiguration)},
+			err:      errLedgerBlindSigning,
+		},
+		{
+			name:     "plugin unsupported",
+			to:       router,
+			data:     swap,
+			metadata: &testMetadata{plugin: pluginInfo},
+			flags:    ledgerFlagBlindSigning,
+			rejected: []byte{byte(ledgerOpSetPlugin)},
+			sent:     []byte{byte(ledgerOpSetPlugin), byte(ledgerOpGetConfiguration), byte(ledgerOpSignTransaction)},
+		},
+		{
+			name:     "contract data rejected",
+			to:       router,
+			data:     swap,
+			flags:    ledgerFlagBlindSigning,
+			rejected: []byte{byte(ledgerOpSignTransaction)},
+			sent:     []byte{byte(ledgerOpGetConfiguration), byte(ledgerOpSignTransaction)},
+			err:      errLedgerBlindSigning,
+		},
+	}
+	for _, tt := range tests {
+		tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Gas: 100000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(1), To: &tt.to, Data: tt.data})
+
+		var sent []byte
+		device := &testLedger{handle: func(ins byte, data []byte) ([]byte, uint16) {
+			sent = append(sent, ins)
+			if bytes.IndexByte(tt.rejected, ins) >= 0 {
+				return nil, ledgerStatusInvalidData
+			}
+			switch ledgerOpcode(ins) {
+			case ledgerOpGetConfiguration:
+				return []byte{tt.flags, 1, 10, 0}, ledgerStatusOK
+			case ledgerOpProvideERC20Info:
+				if !bytes.Equal(data, tokenInfo.payload()) {
+					t.Errorf("%s: token info mismatch: %x", tt.name, data)
+				}
+			case ledgerOpSetPlugin:
+				if !bytes.Equal(data, pluginInfo.payload()) {
+					t.Errorf("%s: plugin info mismatch: %x", tt.name, data)
+				}
+			case ledgerOpSignTransaction:
+				sig, _ := crypto.Sign(types.LatestSignerForChainID(chainID).Hash(tx).Bytes(), key)
+				return append([]byte{sig[64]}, sig[:64]...), ledgerStatusOK
+			}
+			return nil, ledgerStatusOK
+		}}
+		driver := &ledgerDriver{device: device, version: [3]byte{1, 10, 0}, log: log.Root()}
+		if tt.metadata != nil {
+			driver.metadata = tt.metadata
+		}
+		sender, _, err := driver.SignTx(accounts.DefaultBaseDerivationPath, tx, chainID)
+		if err != tt.err {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+		}
+		if err == nil && sender != crypto.PubkeyToAddress(key.PublicKey) {
+			t.Errorf("%s: sender mismatch: have %v", tt.name, sender)
+		}
+		if !bytes.Equal(sent, tt.sent) {
+			t.Errorf("%s: commands mismatch: have %x, want %x", tt.name, sent, tt.sent)
+		}
+	}
+}
@@ -27,13 +27,16 @@ import (
 	"io"
 	"math"
 	"math/big"
+	"reflect"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/usbwallet/trezor"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -190,6 +193,16 @@ func (w *trezorDriver) SignTypedMessage(path accounts.DerivationPath, domainHash
 	return nil, accounts.ErrNotSupported
 }
 
+// SignTypedData implements usbwallet.driver, streaming the typed data to the
+// Trezor as it requests it to display its members, and waiting for the user to
+// sign or deny it.
+func (w *trezorDriver) SignTypedData(path accounts.DerivationPath, typedData apitypes.TypedData) ([]byte, error) {
+	if w.device == nil {
+		return nil, accounts.ErrWalletClosed
+	}
+	return w.trezorSignTypedData(path, &typedData)
+}
+
 // trezorDerive sends a derivation request to the Trezor device and returns the
 // Ethereum address located on that path.
 func (w *trezorDriver) trezorDerive(derivationPath []uint32) (common.Address, error) {
@@ -285,6 +298,174 @@ func (w *trezorDriver) trezorSign(derivationPath []uint32, tx *types.Transaction
 	return sender, signed, nil
 }
 
+// trezorSignTypedData sends the typed data signing request to the Trezor wallet,
+// answers its requests for the struct definitions and the member values, and
+// waits for the user to confirm or deny the signing.
+func (w *trezorDriver) trezorSignTypedData(derivationPath []uint32, typedData *apitypes.TypedData) ([]byte, error) {
+	var (
+		request proto.Message = &trezor.EthereumSignTypedData{
+			AddressN:         derivationPath,
+			PrimaryType:      &typedData.PrimaryType,
+			MetamaskV4Compat: proto.Bool(true),
+		}
+		structReq = new(trezor.EthereumTypedDataStructRequest)
+		valueReq  = new(trezor.EthereumTypedDataValueRequest)
+		response  = new(trezor.EthereumTypedDataSignature)
+	)
+	for {
+		res, err := w.trezorExchange(request, structReq, valueReq, response)
+		if err != nil {
+			return nil, err
+		}
+		switch res {
+		case 0:
+			request, err = trezorTypedStruct(typedData, structReq.GetName())
+		case 1:
+			var value []byte
+			value, err = trezorTypedValue(typedData, valueReq.GetMemberPath())
+			request = &trezor.EthereumTypedDataValueAck{Value: value}
+		default:
+			// Extract the Ethereum signature and do a sanity validation
+			signature := response.GetSignature()
+			if len(signature) != crypto.SignatureLength {
+				return nil, errors.New("reply lacks signature")
+			}
+			if signature[crypto.RecoveryIDOffset] >= 27 {
+				signature[crypto.RecoveryIDOffset] -= 27
+			}
+			return signature, nil
+		}
+		// The device is waiting for an answer, abort if there's none
+		if err != nil {
+			w.trezorExchange(&trezor.Cancel{})
+			return nil, err
+		}
+	}
+}
+
+// trezorTypedStruct assembles the definition of a struct type of typed data.
+func trezorTypedStruct(typedData *apitypes.TypedData, name string) (*trezor.EthereumTypedDataStructAck, error) {
+	members, ok := typedData.Types[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown struct type %q", name)
+	}
+	ack := new(trezor.EthereumTypedDataStructAck)
+	for _, member := range members {
+		typ, err := parseTypedDataType(member.Type)
+		if err != nil {
+			return nil, err
+		}
+		field, err := trezorTypedField(typedData, typ)
+		if err != nil {
+			return nil, err
+		}
+		ack.Members = append(ack.Members, &trezor.EthereumTypedDataStructAck_EthereumStructMember{
+			Type: field,
+			Name: proto.String(member.Name),
+		})
+	}
+	return ack, nil
+}
+
+// trezorTypedField assembles the definition of a type of typed data.
+func trezorTypedField(typedData *apitypes.TypedData, typ typedDataType) (*trezor.EthereumTypedDataStructAck_EthereumFieldType, error) {
+	if dims := len(typ.arrays); dims > 0 {
+		entry, err := trezorTypedField(typedData, typ.elem())
+		if err != nil {
+			return nil, err
+		}
+		field := &trezor.EthereumTypedDataStructAck_EthereumFieldType{
+			DataType:  trezor.EthereumTypedDataStructAck_ARRAY.Enum(),
+			EntryType: entry,
+		}
+		if size := typ.arrays[dims-1]; size > 0 {
+			field.Size = proto.Uint32(uint32(size))
+		}
+		return field, nil
+	}
+	if members, ok := typedData.Types[typ.base]; ok {
+		return &trezor.EthereumTypedDataStructAck_EthereumFieldType{
+			DataType:   trezor.EthereumTypedDataStructAck_STRUCT.Enum(),
+			Size:       proto.Uint32(uint32(len(members))),
+			StructName: proto.String(typ.base),
+		}, nil
+	}
+	var kind trezor.EthereumTypedDataStructAck_EthereumDataType
+	switch {
+	case typ.base == "address":
+		kind = trezor.EthereumTypedDataStructAck_ADDRESS
+	case typ.base == "bool":
+		kind = trezor.EthereumTypedDataStructAck_BOOL
+	case typ.base == "string":
+		kind = trezor.EthereumTypedDataStructAck_STRING
+	case typ.base == "bytes":
+		kind = trezor.EthereumTypedDataStructAck_BYTES
+	case typ.size() > 0:
+		switch typ.base[0] {
+		case 'i':
+			kind = trezor.EthereumTypedDataStructAck_INT
+		case 'u':
+			kind = trezor.EthereumTypedDataStructAck_UINT
+		default:
+			kind = trezor.EthereumTypedDataStructAck_BYTES
+		}
+		return &trezor.EthereumTypedDataStructAck_EthereumFieldType{
+			DataType: kind.Enum(),
+			Size:     proto.Uint32(uint32(typ.size())),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q", typ.base)
+	}
+	return &trezor.EthereumTypedDataStructAck_EthereumFieldType{DataType: kind.Enum()}, nil
+}
+
+// trezorTypedValue retrieves the value of the typed data member at the given
+// path, the first element of which selects the domain (0) or the message (1),
+// and the rest the members of structs and the elements of arrays. Arrays are
+// represented by their length.
+func trezorTypedValue(typedData *apitypes.TypedData, path []uint32) ([]byte, error) {
+	if len(path) == 0 || path[0] > 1 {
+		return nil, fmt.Errorf("invalid member path %v", path)
+	}
+	var (
+		name, root = typedDataRoot(typedData, path[0] == 0)
+		typ        = typedDataType{base: name}
+		value      interface{}
+	)
+	value = root
+	for _, index := range path[1:] {
+		if len(typ.arrays) > 0 {
+			items := reflect.ValueOf(value)
+			if items.Kind() != reflect.Slice || int(index) >= items.Len() {
+				return nil, fmt.Errorf("invalid member path %v", path)
+			}
+			typ, value = typ.elem(), items.Index(int(index)).Interface()
+			continue
+		}
+		members := typedData.Types[typ.base]
+		fields, ok := value.(map[string]interface{})
+		if !ok || int(index) >= len(members) {
+			return nil, fmt.Errorf("invalid member path %v", path)
+		}
+		var err error
+		if typ, err = parseTypedDataType(members[index].Type); err != nil {
+			return nil, err
+		}
+		value = fields[members[index].Name]
+	}
+	if len(typ.arrays) > 0 {
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice || items.Len() > math.MaxUint16 {
+			return nil, fmt.Errorf("provided data '%v' is not slice", value)
+		}
+		return binary.BigEndian.AppendUint16(nil, uint16(items.Len())), nil
+	}
+	if _, ok := typedData.Types[typ.base]; ok {
+		return nil, fmt.Errorf("invalid member path %v: struct values can't be requested", path)
+	}
+	return encodeTypedDataValue(typedData, typ, value)
+}
+
 // trezorExchange performs a data exchange with the Trezor wallet, sending it a
 // message and retrieving the response. If multiple responses are possible, the
 // method will also return the index of the destination object used.
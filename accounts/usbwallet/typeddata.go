@@ -0,0 +1,137 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// This file contains the helpers shared by the drivers to stream EIP-712 typed
+// data to hardware wallets member by member, so that the devices can display
+// the fields instead of opaque hashes.
+
+package usbwallet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// typedDataDomain is the name of the struct type of the EIP-712 domain.
+const typedDataDomain = "EIP712Domain"
+
+// typedDataType is a parsed type of a member of EIP-712 typed data.
+type typedDataType struct {
+	base   string // Name of the atomic type or struct, e.g. uint256 or Mail
+	arrays []int  // Sizes of the array dimensions in declaration order, 0 if dynamic
+}
+
+// parseTypedDataType splits the type of a member into its base type and array
+// dimensions, e.g. uint8[2][] into uint8 with a fixed and a dynamic dimension.
+func parseTypedDataType(typ string) (typedDataType, error) {
+	base, dims, _ := strings.Cut(typ, "[")
+	if base == "" {
+		return typedDataType{}, fmt.Errorf("invalid type %q", typ)
+	}
+	parsed := typedDataType{base: base}
+	if dims == "" {
+		return parsed, nil
+	}
+	for _, dim := range strings.Split(strings.TrimSuffix(dims, "]"), "][") {
+		if dim == "" {
+			parsed.arrays = append(parsed.arrays, 0)
+			continue
+		}
+		size, err := strconv.Atoi(dim)
+		if err != nil || size <= 0 {
+			return typedDataType{}, fmt.Errorf("invalid array size in type %q", typ)
+		}
+		parsed.arrays = append(parsed.arrays, size)
+	}
+	return parsed, nil
+}
+
+// elem returns the type of the elements of an array type.
+func (t typedDataType) elem() typedDataType {
+	return typedDataType{base: t.base, arrays: t.arrays[:len(t.arrays)-1]}
+}
+
+// size returns the size in bytes of integer and fixed size byte array types, or
+// zero for the other types.
+func (t typedDataType) size() int {
+	switch {
+	case t.base == "int" || t.base == "uint":
+		return 32
+	case strings.HasPrefix(t.base, "int"):
+		bits, _ := strconv.Atoi(strings.TrimPrefix(t.base, "int"))
+		return bits / 8
+	case strings.HasPrefix(t.base, "uint"):
+		bits, _ := strconv.Atoi(strings.TrimPrefix(t.base, "uint"))
+		return bits / 8
+	case strings.HasPrefix(t.base, "bytes") && t.base != "bytes":
+		size, _ := strconv.Atoi(strings.TrimPrefix(t.base, "bytes"))
+		return size
+	}
+	return 0
+}
+
+// typedDataRoot returns the struct type and the value of the domain or of the
+// message of the typed data.
+func typedDataRoot(typedData *apitypes.TypedData, domain bool) (string, map[string]interface{}) {
+	if domain {
+		return typedDataDomain, typedData.Domain.Map()
+	}
+	return typedData.PrimaryType, typedData.Message
+}
+
+// encodeTypedDataValue encodes the value of an atomic member the way hardware
+// wallets expect it: integers as big endian two's complement of their size,
+// addresses, booleans and fixed size byte arrays by their bytes, and dynamic
+// byte arrays and strings by their content.
+func encodeTypedDataValue(typedData *apitypes.TypedData, typ typedDataType, value interface{}) ([]byte, error) {
+	switch typ.base {
+	case "string":
+		if str, ok := value.(string); ok {
+			return []byte(str), nil
+		}
+		return nil, fmt.Errorf("provided data '%v' doesn't match type 'string'", value)
+
+	case "bytes":
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case hexutil.Bytes:
+			return v, nil
+		case string:
+			return hexutil.Decode(v)
+		}
+		return nil, fmt.Errorf("provided data '%v' doesn't match type 'bytes'", value)
+	}
+	// All other types are validated and encoded into a word by the ABI encoder
+	word, err := typedData.EncodePrimitiveValue(typ.base, value, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case typ.base == "address":
+		return word[12:], nil
+	case typ.base == "bool":
+		return word[31:], nil
+	case strings.HasPrefix(typ.base, "bytes"):
+		return word[:typ.size()], nil
+	default:
+		return word[32-typ.size():], nil
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package usbwallet
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallets", "type": "address[]"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person[]"},
+			{"name": "delta", "type": "int16"},
+			{"name": "tags", "type": "bytes4[2]"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"]},
+		"to": [{"name": "Bob", "wallets": []}],
+		"delta": -2,
+		"tags": ["0x01020304", "0x05060708"]
+	}
+}`
+
+func newTestTypedData(t *testing.T) *apitypes.TypedData {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal([]byte(testTypedData), &typedData); err != nil {
+		t.Fatal(err)
+	}
+	return &typedData
+}
+
+func TestParseTypedDataType(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want typedDataType
+		size int
+		err  bool
+	}{
+		{typ: "uint256", want: typedDataType{base: "uint256"}, size: 32},
+		{typ: "int8", want: typedDataType{base: "int8"}, size: 1},
+		{typ: "bytes20", want: typedDataType{base: "bytes20"}, size: 20},
+		{typ: "bytes", want: typedDataType{base: "bytes"}},
+		{typ: "Person[]", want: typedDataType{base: "Person", arrays: []int{0}}},
+		{typ: "uint8[2][]", want: typedDataType{base: "uint8", arrays: []int{2, 0}}, size: 1},
+		{typ: "uint8[0]", err: true},
+		{typ: "uint8[x]", err: true},
+		{typ: "[2]", err: true},
+	}
+	for _, tt := range tests {
+		have, err := parseTypedDataType(tt.typ)
+		if (err != nil) != tt.err {
+			t.Errorf("%s: error mismatch: have %v, want error %v", tt.typ, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%s: type mismatch: have %+v, want %+v", tt.typ, have, tt.want)
+		}
+		if size := have.size(); size != tt.size {
+			t.Errorf("%s: size mismatch: have %d, want %d", tt.typ, size, tt.size)
+		}
+	}
+}
+
+func TestTrezorTypedValue(t *testing.T) {
+	typedData := newTestTypedData(t)
+
+	tests := []struct {
+		path []uint32
+		want string
+	}{
+		{path: []uint32{0, 0}, want: hexutil.Encode([]byte("Ether Mail"))},
+		{path: []uint32{0, 1}, want: "0x0000000000000000000000000000000000000000000000000000000000000001"},
+		{path: []uint32{0, 2}, want: "0xcccccccccccccccccccccccccccccccccccccccc"},
+		{path: []uint32{1, 0, 1}, want: "0x0001"},
+		{path: []uint32{1, 0, 1, 0}, want: "0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826"},
+		{path: []uint32{1, 1}, want: "0x0001"},
+		{path: []uint32{1, 1, 0, 1}, want: "0x0000"},
+		{path: []uint32{1, 2}, want: "0xfffe"},
+		{path: []uint32{1, 3, 1}, want: "0x05060708"},
+	}
+	for _, tt := range tests {
+		have, err := trezorTypedValue(typedData, tt.path)
+		if err != nil {
+			t.Errorf("%v: failed to retrieve value: %v", tt.path, err)
+			continue
+		}
+		if hexutil.Encode(have) != tt.want {
+			t.Errorf("%v: value mismatch: have %x, want %s", tt.path, have, tt.want)
+		}
+	}
+	// Structs and nonexistent members must not be returned
+	for _, path := range [][]uint32{{2}, {1}, {1, 0}, {1, 4}, {1, 1, 1}} {
+		if _, err := trezorTypedValue(typedData, path); err == nil {
+			t.Errorf("%v: invalid path accepted", path)
+		}
+	}
+}
+
+func TestLedgerTypedMemberDef(t *testing.T) {
+	typedData := newTestTypedData(t)
+
+	tests := []struct {
+		member apitypes.Type
+		want   string
+	}{
+		{apitypes.Type{Name: "name", Type: "string"}, "0x05046e616d65"},
+		{apitypes.Type{Name: "chainId", Type: "uint256"}, "0x422007636861696e4964"},
+		{apitypes.Type{Name: "from", Type: "Person"}, "0x0006506572736f6e0466726f6d"},
+		{apitypes.Type{Name: "to", Type: "Person[]"}, "0x8006506572736f6e010002746f"},
+		{apitypes.Type{Name: "tags", Type: "bytes4[2][]"}, "0xc604020102000474616773"},
+	}
+	for _, tt := range tests {
+		have, err := ledgerTypedMemberDef(typedData, tt.member)
+		if err != nil {
+			t.Errorf("%s: failed to encode definition: %v", tt.member.Type, err)
+			continue
+		}
+		if hexutil.Encode(have) != tt.want {
+			t.Errorf("%s: definition mismatch: have %x, want %s", tt.member.Type, have, tt.want)
+		}
+	}
+}
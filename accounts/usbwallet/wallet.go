@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/karalabe/hid"
 )
 
@@ -69,6 +70,10 @@ type driver interface {
 	SignTx(path accounts.DerivationPath, tx *types.Transaction, chainID *big.Int) (common.Address, *types.Transaction, error)
 
 	SignTypedMessage(path accounts.DerivationPath, messageHash []byte, domainHash []byte) ([]byte, error)
+
+	// SignTypedData sends the EIP-712 typed data to the USB device for it to
+	// display the members, and waits for the user to confirm or deny signing.
+	SignTypedData(path accounts.DerivationPath, typedData apitypes.TypedData) ([]byte, error)
 }
 
 // wallet represents the common functionality shared by all USB hardware
@@ -571,6 +576,57 @@ func (w *wallet) SignData(account accounts.Account, mimeType string, data []byte
 	return signature, nil
 }
 
+// SignTypedData requests the hardware wallet to sign the given EIP-712 typed data
+// after displaying its members, instead of only the hashes of the domain and of
+// the message like SignData does. The signature is returned in the [R || S || V]
+// format with V being 0 or 1.
+func (w *wallet) SignTypedData(account accounts.Account, typedData apitypes.TypedData) ([]byte, error) {
+	// Validate the typed data before bothering the user with it
+	sighash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, err
+	}
+	w.stateLock.RLock() // Comms have own mutex, this is for the state fields
+	defer w.stateLock.RUnlock()
+
+	// If the wallet is closed, abort
+	if w.device == nil {
+		return nil, accounts.ErrWalletClosed
+	}
+	// Make sure the requested account is contained within
+	path, ok := w.paths[account.Address]
+	if !ok {
+		return nil, accounts.ErrUnknownAccount
+	}
+	// All infos gathered and metadata checks out, request signing
+	<-w.commsLock
+	defer func() { w.commsLock <- struct{}{} }()
+
+	// Ensure the device isn't screwed with while user confirmation is pending
+	w.hub.commsLock.Lock()
+	w.hub.commsPend++
+	w.hub.commsLock.Unlock()
+
+	defer func() {
+		w.hub.commsLock.Lock()
+		w.hub.commsPend--
+		w.hub.commsLock.Unlock()
+	}()
+	// Sign the typed data and verify the signer to avoid hardware fault surprises
+	signature, err := w.driver.SignTypedData(path, typedData)
+	if err != nil {
+		return nil, err
+	}
+	pubkey, err := crypto.SigToPub(sighash, signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != account.Address {
+		return nil, fmt.Errorf("signer mismatch: expected %s, got %s", account.Address.Hex(), signer.Hex())
+	}
+	return signature, nil
+}
+
 // SignDataWithPassphrase implements accounts.Wallet, attempting to sign the given
 // data with the given account using passphrase as extra authentication.
 // Since USB wallets don't rely on passphrases, these are silently ignored.
@@ -0,0 +1,213 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package watchonly implements an address book of accounts without private keys,
+// such as addresses held by hardware wallets or multisig contracts. The accounts
+// are listed like any other, so they can be used to query state and to assemble
+// unsigned transactions, but all signing requests are refused.
+package watchonly
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Scheme is the protocol scheme prefixing account and wallet URLs.
+const Scheme = "watch"
+
+// ErrWatchOnly is returned if a watch-only account is requested to sign, since
+// its private key isn't available.
+var ErrWatchOnly = errors.New("watch-only account cannot sign")
+
+// AddressBook is an accounts.Backend of watch-only accounts, every one of which
+// is a wallet of its own.
+type AddressBook struct {
+	wallets     []accounts.Wallet       // Wallets of the tracked addresses, sorted by URL
+	updateFeed  event.Feed              // Event feed to notify wallet additions/removals
+	updateScope event.SubscriptionScope // Subscription scope tracking current live listeners
+
+	lock sync.RWMutex // Protects the wallets from racey access
+}
+
+// NewAddressBook creates an address book tracking the given addresses.
+func NewAddressBook(addrs ...common.Address) *AddressBook {
+	book := new(AddressBook)
+	for _, addr := range addrs {
+		book.Add(addr)
+	}
+	return book
+}
+
+// Wallets implements accounts.Backend, returning the wallets of all the tracked
+// addresses.
+func (book *AddressBook) Wallets() []accounts.Wallet {
+	book.lock.RLock()
+	defer book.lock.RUnlock()
+
+	cpy := make([]accounts.Wallet, len(book.wallets))
+	copy(cpy, book.wallets)
+	return cpy
+}
+
+// Subscribe implements accounts.Backend, creating an async subscription to
+// receive notifications on the addition or removal of addresses.
+func (book *AddressBook) Subscribe(sink chan<- accounts.WalletEvent) event.Subscription {
+	return book.updateScope.Track(book.updateFeed.Subscribe(sink))
+}
+
+// Add starts tracking the given address, returning false if it's already tracked.
+func (book *AddressBook) Add(addr common.Address) bool {
+	book.lock.Lock()
+	if book.find(addr) >= 0 {
+		book.lock.Unlock()
+		return false
+	}
+	w := newWallet(addr)
+	book.wallets = append(book.wallets, w)
+	sort.Sort(accounts.WalletsByURL(book.wallets))
+	book.lock.Unlock()
+
+	book.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletArrived})
+	return true
+}
+
+// Remove stops tracking the given address, returning false if it isn't tracked.
+func (book *AddressBook) Remove(addr common.Address) bool {
+	book.lock.Lock()
+	i := book.find(addr)
+	if i < 0 {
+		book.lock.Unlock()
+		return false
+	}
+	w := book.wallets[i]
+	book.wallets = append(book.wallets[:i:i], book.wallets[i+1:]...)
+	book.lock.Unlock()
+
+	book.updateFeed.Send(accounts.WalletEvent{Wallet: w, Kind: accounts.WalletDropped})
+	return true
+}
+
+// find returns the index of the wallet of the given address, or -1 if it isn't
+// tracked. The lock must be held by the caller.
+func (book *AddressBook) find(addr common.Address) int {
+	for i, w := range book.wallets {
+		if w.(*wallet).account.Address == addr {
+			return i
+		}
+	}
+	return -1
+}
+
+// wallet is an accounts.Wallet of a single watch-only account.
+type wallet struct {
+	account accounts.Account
+}
+
+func newWallet(addr common.Address) *wallet {
+	return &wallet{
+		account: accounts.Account{
+			Address: addr,
+			URL:     accounts.URL{Scheme: Scheme, Path: strings.ToLower(addr.Hex())},
+		},
+	}
+}
+
+// URL implements accounts.Wallet, returning the URL of the account within.
+func (w *wallet) URL() accounts.URL {
+	return w.account.URL
+}
+
+// Status implements accounts.Wallet, returning a status marking the account as
+// unable to sign.
+func (w *wallet) Status() (string, error) {
+	return "Watch-only", nil
+}
+
+// Open implements accounts.Wallet, but is a noop since there is nothing to
+// unlock.
+func (w *wallet) Open(passphrase string) error { return nil }
+
+// Close implements accounts.Wallet, but is a noop since there is no meaningful
+// open operation.
+func (w *wallet) Close() error { return nil }
+
+// Accounts implements accounts.Wallet, returning the single watch-only account.
+func (w *wallet) Accounts() []accounts.Account {
+	return []accounts.Account{w.account}
+}
+
+// Contains implements accounts.Wallet, returning whether a particular account is
+// or is not the watch-only account.
+func (w *wallet) Contains(account accounts.Account) bool {
+	return account.Address == w.account.Address && (account.URL == (accounts.URL{}) || account.URL == w.account.URL)
+}
+
+// Derive implements accounts.Wallet, but is not supported by watch-only accounts.
+func (w *wallet) Derive(path accounts.DerivationPath, pin bool) (accounts.Account, error) {
+	return accounts.Account{}, accounts.ErrNotSupported
+}
+
+// SelfDerive implements accounts.Wallet, but is a noop since watch-only accounts
+// can't be derived.
+func (w *wallet) SelfDerive(bases []accounts.DerivationPath, chain ethereum.ChainStateReader) {
+}
+
+// SignData implements accounts.Wallet, always refusing to sign.
+func (w *wallet) SignData(account accounts.Account, mimeType string, data []byte) ([]byte, error) {
+	return nil, w.refuse(account)
+}
+
+// SignDataWithPassphrase implements accounts.Wallet, always refusing to sign.
+func (w *wallet) SignDataWithPassphrase(account accounts.Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return nil, w.refuse(account)
+}
+
+// SignText implements accounts.Wallet, always refusing to sign.
+func (w *wallet) SignText(account accounts.Account, text []byte) ([]byte, error) {
+	return nil, w.refuse(account)
+}
+
+// SignTextWithPassphrase implements accounts.Wallet, always refusing to sign.
+func (w *wallet) SignTextWithPassphrase(account accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	return nil, w.refuse(account)
+}
+
+// SignTx implements accounts.Wallet, always refusing to sign.
+func (w *wallet) SignTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, w.refuse(account)
+}
+
+// SignTxWithPassphrase implements accounts.Wallet, always refusing to sign.
+func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, w.refuse(account)
+}
+
+// refuse returns the error of a signing request for the given account.
+func (w *wallet) refuse(account accounts.Account) error {
+	if !w.Contains(account) {
+		return accounts.ErrUnknownAccount
+	}
+	return ErrWatchOnly
+}
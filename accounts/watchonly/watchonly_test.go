@@ -0,0 +1,86 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package watchonly
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestAddressBook(t *testing.T) {
+	var (
+		addr1 = common.HexToAddress("0x01")
+		addr2 = common.HexToAddress("0x02")
+		book  = NewAddressBook(addr2)
+		am    = accounts.NewManager(nil, book)
+	)
+	defer am.Close()
+
+	if book.Add(addr2) {
+		t.Errorf("tracked address added again")
+	}
+	if !book.Add(addr1) {
+		t.Errorf("failed to add address")
+	}
+	// The manager must pick up the added address
+	for i := 0; ; i++ {
+		if accs := am.Accounts(); len(accs) == 2 {
+			if accs[0] != addr1 || accs[1] != addr2 {
+				t.Fatalf("account mismatch: have %v, want [%v %v]", accs, addr1, addr2)
+			}
+			break
+		}
+		if i == 100 {
+			t.Fatalf("account count mismatch: have %d, want 2", len(am.Accounts()))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	wallet, err := am.Find(accounts.Account{Address: addr1})
+	if err != nil {
+		t.Fatalf("failed to find wallet: %v", err)
+	}
+	if url := wallet.URL().String(); url != "watch://0x0000000000000000000000000000000000000001" {
+		t.Errorf("wallet URL mismatch: have %s", url)
+	}
+	// Signing must be refused
+	account := accounts.Account{Address: addr1}
+	if _, err := wallet.SignText(account, []byte("hello")); err != ErrWatchOnly {
+		t.Errorf("signing text: have %v, want %v", err, ErrWatchOnly)
+	}
+	tx := types.NewTx(&types.LegacyTx{To: &addr2, Gas: 21000, GasPrice: big.NewInt(1)})
+	if _, err := wallet.SignTxWithPassphrase(account, "", tx, big.NewInt(1)); err != ErrWatchOnly {
+		t.Errorf("signing transaction: have %v, want %v", err, ErrWatchOnly)
+	}
+	if _, err := wallet.SignText(accounts.Account{Address: addr2}, []byte("hello")); err != accounts.ErrUnknownAccount {
+		t.Errorf("signing with other account: have %v, want %v", err, accounts.ErrUnknownAccount)
+	}
+	// Removed addresses must be dropped by the manager
+	if !book.Remove(addr1) || book.Remove(addr1) {
+		t.Fatalf("failed to remove address exactly once")
+	}
+	for i := 0; len(am.Accounts()) != 1; i++ {
+		if i == 100 {
+			t.Fatalf("account count mismatch: have %d, want 1", len(am.Accounts()))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// errNotArtifact is returned when a json file does not look like a contract
+// artifact, e.g. the debug and build-info files living next to the artifacts.
+var errNotArtifact = errors.New("not a contract artifact")
+
+// artifactJSON is the union of the Foundry and Hardhat contract artifact formats,
+// limited to the fields needed to generate bindings.
+type artifactJSON struct {
+	ABI               json.RawMessage   `json:"abi"`
+	Bytecode          json.RawMessage   `json:"bytecode"`          // Hardhat: hex string, Foundry: object
+	LinkReferences    linkReferences    `json:"linkReferences"`    // Hardhat only
+	ContractName      string            `json:"contractName"`      // Hardhat only
+	SourceName        string            `json:"sourceName"`        // Hardhat only
+	MethodIdentifiers map[string]string `json:"methodIdentifiers"` // Foundry only
+	Metadata          json.RawMessage   `json:"metadata"`          // Foundry only, object or string
+}
+
+// foundryBytecode is the bytecode section of a Foundry artifact.
+type foundryBytecode struct {
+	Object         string         `json:"object"`
+	LinkReferences linkReferences `json:"linkReferences"`
+}
+
+// linkReferences maps source files to the libraries defined in them and the
+// positions of their placeholders within the bytecode.
+type linkReferences map[string]map[string][]struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// artifact is a contract loaded from a build artifact.
+type artifact struct {
+	name  string            // Fully qualified name, <sourcePath>:<contract>
+	abi   string            // JSON ABI of the contract
+	bin   string            // Creation bytecode, possibly with library placeholders
+	sigs  map[string]string // Optional map of function signatures to selectors
+	links []string          // Fully qualified names of the libraries linked against
+}
+
+// typeName returns the name of the contract without the source path.
+func (a *artifact) typeName() string {
+	return a.name[strings.LastIndex(a.name, ":")+1:]
+}
+
+// loadArtifacts expands the given paths (which may be glob patterns) and loads
+// all contract artifacts among them. Files that are not contract artifacts are
+// skipped, along with a notice of the skip.
+func loadArtifacts(patterns []string) ([]*artifact, error) {
+	var (
+		artifacts []*artifact
+		seen      = make(map[string]bool)
+	)
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid artifact pattern %q: %v", pattern, err)
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no artifacts found at %q", pattern)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+			a, err := parseArtifact(data, path)
+			if errors.Is(err, errNotArtifact) {
+				fmt.Fprintf(os.Stderr, "skipping: %v (%v)\n", path, err)
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse artifact %s: %v", path, err)
+			}
+			artifacts = append(artifacts, a)
+		}
+	}
+	if len(artifacts) == 0 {
+		return nil, errors.New("no contract artifacts found")
+	}
+	return artifacts, nil
+}
+
+// parseArtifact parses a Foundry or Hardhat artifact. The path of the file is
+// used to name the contract if the artifact itself doesn't specify it.
+func parseArtifact(data []byte, path string) (*artifact, error) {
+	var raw artifactJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if abi := bytes.TrimSpace(raw.ABI); len(abi) == 0 || abi[0] != '[' {
+		return nil, errNotArtifact
+	}
+	a := &artifact{
+		abi:  string(raw.ABI),
+		sigs: raw.MethodIdentifiers,
+	}
+	// Hardhat stores the bytecode as a string, Foundry as an object containing
+	// the link references too.
+	links := raw.LinkReferences
+	if len(raw.Bytecode) > 0 && string(raw.Bytecode) != "null" {
+		if raw.Bytecode[0] == '"' {
+			if err := json.Unmarshal(raw.Bytecode, &a.bin); err != nil {
+				return nil, fmt.Errorf("invalid bytecode: %v", err)
+			}
+		} else {
+			var bytecode foundryBytecode
+			if err := json.Unmarshal(raw.Bytecode, &bytecode); err != nil {
+				return nil, fmt.Errorf("invalid bytecode: %v", err)
+			}
+			a.bin, links = bytecode.Object, bytecode.LinkReferences
+		}
+	}
+	if a.bin == "0x" {
+		a.bin = "" // Interfaces and abstract contracts
+	}
+	for source, libs := range links {
+		for lib := range libs {
+			a.links = append(a.links, source+":"+lib)
+		}
+	}
+	sort.Strings(a.links)
+
+	// Resolve the fully qualified name of the contract
+	switch {
+	case raw.SourceName != "" && raw.ContractName != "":
+		a.name = raw.SourceName + ":" + raw.ContractName
+	default:
+		if target := compilationTarget(raw.Metadata); target != "" {
+			a.name = target
+		} else {
+			// Foundry lays out the artifacts as <out>/<File.sol>/<Contract>.json
+			a.name = filepath.Base(filepath.Dir(path)) + ":" + strings.TrimSuffix(filepath.Base(path), ".json")
+		}
+	}
+	return a, nil
+}
+
+// compilationTarget extracts the fully qualified name of the compiled contract
+// from the solc metadata, which may be embedded as an object or a string.
+func compilationTarget(metadata json.RawMessage) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+	if metadata[0] == '"' {
+		var s string
+		if err := json.Unmarshal(metadata, &s); err != nil {
+			return ""
+		}
+		metadata = json.RawMessage(s)
+	}
+	var meta struct {
+		Settings struct {
+			CompilationTarget map[string]string `json:"compilationTarget"`
+		} `json:"settings"`
+	}
+	if err := json.Unmarshal(metadata, &meta); err != nil {
+		return ""
+	}
+	for source, name := range meta.Settings.CompilationTarget {
+		return source + ":" + name
+	}
+	return ""
+}
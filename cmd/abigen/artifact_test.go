@@ -0,0 +1,109 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	foundryArtifact = `{
+		"abi": [{"type":"function","name":"get","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}],
+		"bytecode": {
+			"object": "0x6080__$0123456789abcdef0123456789abcdef01$__00",
+			"sourceMap": "",
+			"linkReferences": {"src/Math.sol": {"Math": [{"start": 2, "length": 20}]}}
+		},
+		"methodIdentifiers": {"get()": "6d4ce63c"},
+		"metadata": {"settings": {"compilationTarget": {"src/Token.sol": "Token"}}}
+	}`
+	foundryInterface = `{
+		"abi": [],
+		"bytecode": {"object": "0x", "linkReferences": {}}
+	}`
+	hardhatArtifact = `{
+		"_format": "hh-sol-artifact-1",
+		"contractName": "Math",
+		"sourceName": "contracts/Math.sol",
+		"abi": [],
+		"bytecode": "0x6080",
+		"deployedBytecode": "0x6080",
+		"linkReferences": {},
+		"deployedLinkReferences": {}
+	}`
+	hardhatDebug = `{
+		"_format": "hh-sol-dbg-1",
+		"buildInfo": "../../build-info/0123.json"
+	}`
+)
+
+func TestParseArtifact(t *testing.T) {
+	t.Parallel()
+
+	a, err := parseArtifact([]byte(foundryArtifact), "out/Token.sol/Token.json")
+	require.NoError(t, err)
+	require.Equal(t, "src/Token.sol:Token", a.name)
+	require.Equal(t, "Token", a.typeName())
+	require.Equal(t, "0x6080__$0123456789abcdef0123456789abcdef01$__00", a.bin)
+	require.Equal(t, map[string]string{"get()": "6d4ce63c"}, a.sigs)
+	require.Equal(t, []string{"src/Math.sol:Math"}, a.links)
+
+	a, err = parseArtifact([]byte(foundryInterface), "out/IToken.sol/IToken.json")
+	require.NoError(t, err)
+	require.Equal(t, "IToken.sol:IToken", a.name)
+	require.Empty(t, a.bin)
+
+	a, err = parseArtifact([]byte(hardhatArtifact), "artifacts/contracts/Math.sol/Math.json")
+	require.NoError(t, err)
+	require.Equal(t, "contracts/Math.sol:Math", a.name)
+	require.Equal(t, "0x6080", a.bin)
+	require.Empty(t, a.links)
+
+	_, err = parseArtifact([]byte(hardhatDebug), "artifacts/contracts/Math.sol/Math.dbg.json")
+	require.ErrorIs(t, err, errNotArtifact)
+}
+
+func TestLoadArtifacts(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(path, content string) {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	write("Token.sol/Token.json", foundryArtifact)
+	write("IToken.sol/IToken.json", foundryInterface)
+	write("Math.sol/Math.json", hardhatArtifact)
+	write("Math.sol/Math.dbg.json", hardhatDebug)
+
+	artifacts, err := loadArtifacts([]string{filepath.Join(dir, "*", "*.json"), filepath.Join(dir, "Token.sol", "Token.json")})
+	require.NoError(t, err)
+
+	var names []string
+	for _, a := range artifacts {
+		names = append(names, a.name)
+	}
+	require.Equal(t, []string{"IToken.sol:IToken", "contracts/Math.sol:Math", "src/Token.sol:Token"}, names)
+
+	_, err = loadArtifacts([]string{filepath.Join(dir, "missing", "*.json")})
+	require.Error(t, err)
+}
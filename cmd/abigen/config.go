@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/naoina/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// config is the content of an abigen configuration file, in TOML or YAML format:
+//
+//	package = "bindings"
+//	out = "bindings.go"
+//
+//	[rename]
+//	totalSupply = "Supply"
+//
+//	[contracts.Token]
+//	package = "token"
+//	out = "token/token.go"
+//	rename = { transfer = "Send" }
+//	types = { "decimals.out0" = "uint64", "mint.amount" = "uint64" }
+type config struct {
+	Package   string                     `toml:"package" yaml:"package"`     // Package to generate the bindings into
+	Out       string                     `toml:"out" yaml:"out"`             // Output file of the bindings
+	Rename    map[string]string          `toml:"rename" yaml:"rename"`       // Renames of methods, events and errors of all contracts
+	Contracts map[string]*contractConfig `toml:"contracts" yaml:"contracts"` // Per contract settings, keyed by type name
+}
+
+// contractConfig is the configuration of the binding of a single contract.
+type contractConfig struct {
+	Package string            `toml:"package" yaml:"package"` // Package to generate the binding into, if not the global one
+	Out     string            `toml:"out" yaml:"out"`         // Output file of the binding, if not the global one
+	Rename  map[string]string `toml:"rename" yaml:"rename"`   // Renames of methods, events and errors
+	Types   map[string]string `toml:"types" yaml:"types"`     // Go type overrides, keyed by "<method>.<argument>"
+}
+
+// loadConfig reads the configuration file, picking the format by its extension.
+func loadConfig(path string) (*config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg := new(config)
+	switch ext := filepath.Ext(path); ext {
+	case ".toml":
+		err = toml.Unmarshal(data, cfg)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported config format %q, use .toml, .yaml or .yml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return cfg, nil
+}
+
+// aliases returns the renames of the configuration in the form accepted by the
+// binding generator, scoping the per contract ones to their contracts.
+func (cfg *config) aliases() map[string]string {
+	aliases := make(map[string]string)
+	for name, alias := range cfg.Rename {
+		aliases[name] = alias
+	}
+	for typ, contract := range cfg.Contracts {
+		for name, alias := range contract.Rename {
+			aliases[typ+"."+name] = alias
+		}
+	}
+	return aliases
+}
+
+// overrides returns the Go type overrides of the given contracts in the form
+// accepted by the binding generator.
+func (cfg *config) overrides(types []string) map[string]string {
+	overrides := make(map[string]string)
+	for _, typ := range types {
+		if contract := cfg.Contracts[typ]; contract != nil {
+			for arg, goType := range contract.Types {
+				overrides[typ+"."+arg] = goType
+			}
+		}
+	}
+	return overrides
+}
+
+// output returns the package and file the binding of the given contract should
+// be generated into.
+func (cfg *config) output(typ string) (pkg string, out string) {
+	pkg, out = cfg.Package, cfg.Out
+	if contract := cfg.Contracts[typ]; contract != nil {
+		if contract.Package != "" {
+			pkg = contract.Package
+		}
+		if contract.Out != "" {
+			out = contract.Out
+		}
+	}
+	return pkg, out
+}
+
+// check verifies that all configured contracts are among the bound ones.
+func (cfg *config) check(types []string) error {
+	var unknown []string
+	for typ := range cfg.Contracts {
+		if !slices.Contains(types, typ) {
+			unknown = append(unknown, typ)
+		}
+	}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		return fmt.Errorf("configured contracts not bound: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
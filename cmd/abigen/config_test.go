@@ -0,0 +1,91 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tomlConfig = `
+package = "bindings"
+out = "bindings.go"
+
+[rename]
+totalSupply = "Supply"
+
+[contracts.Token]
+package = "token"
+out = "token/token.go"
+rename = { transfer = "Send" }
+types = { "decimals.out0" = "uint64" }
+`
+	yamlConfig = `
+package: bindings
+out: bindings.go
+rename:
+  totalSupply: Supply
+contracts:
+  Token:
+    package: token
+    out: token/token.go
+    rename:
+      transfer: Send
+    types:
+      decimals.out0: uint64
+`
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{"abigen.toml": tomlConfig, "abigen.yaml": yamlConfig} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		cfg, err := loadConfig(path)
+		require.NoError(t, err, name)
+		require.Equal(t, map[string]string{"totalSupply": "Supply", "Token.transfer": "Send"}, cfg.aliases(), name)
+		require.Equal(t, map[string]string{"Token.decimals.out0": "uint64"}, cfg.overrides([]string{"Token", "Math"}), name)
+		require.Empty(t, cfg.overrides([]string{"Math"}), name)
+
+		pkg, out := cfg.output("Token")
+		require.Equal(t, "token", pkg, name)
+		require.Equal(t, "token/token.go", out, name)
+		pkg, out = cfg.output("Math")
+		require.Equal(t, "bindings", pkg, name)
+		require.Equal(t, "bindings.go", out, name)
+
+		require.NoError(t, cfg.check([]string{"Math", "Token"}), name)
+		require.Error(t, cfg.check([]string{"Math"}), name)
+	}
+	// Unknown settings and formats are rejected
+	path := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("pkg: bindings\n"), 0600))
+	_, err := loadConfig(path)
+	require.Error(t, err)
+
+	path = filepath.Join(dir, "abigen.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+	_, err = loadConfig(path)
+	require.Error(t, err)
+}
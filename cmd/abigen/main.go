@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/abigen"
@@ -51,6 +53,10 @@ var (
 		Name:  "combined-json",
 		Usage: "Path to the combined-json file generated by compiler, - for STDIN",
 	}
+	artifactFlag = &cli.StringSliceFlag{
+		Name:  "artifact",
+		Usage: "Path or glob pattern of Foundry or Hardhat contract artifacts to bind (may be repeated)",
+	}
 	excFlag = &cli.StringFlag{
 		Name:  "exc",
 		Usage: "Comma separated types to exclude from binding",
@@ -63,10 +69,18 @@ var (
 		Name:  "out",
 		Usage: "Output file for the generated binding (default = stdout)",
 	}
+	configFlag = &cli.StringFlag{
+		Name:  "config",
+		Usage: "TOML or YAML file configuring renames, Go type overrides and per contract output packages",
+	}
 	aliasFlag = &cli.StringFlag{
 		Name:  "alias",
 		Usage: "Comma separated aliases for function and event renaming.  If --v2 is set, errors are aliased as well. e.g. original1=alias1, original2=alias2",
 	}
+	templateFlag = &cli.StringFlag{
+		Name:  "template",
+		Usage: "Go template file replacing the builtin binding template",
+	}
 	v2Flag = &cli.BoolFlag{
 		Name:  "v2",
 		Usage: "Generates v2 bindings",
@@ -82,32 +96,62 @@ func init() {
 		binFlag,
 		typeFlag,
 		jsonFlag,
+		artifactFlag,
 		excFlag,
 		pkgFlag,
 		outFlag,
+		configFlag,
 		aliasFlag,
+		templateFlag,
 		v2Flag,
 	}
 	app.Action = generate
 }
 
 func generate(c *cli.Context) error {
-	flags.CheckExclusive(c, abiFlag, jsonFlag) // Only one source can be selected.
+	flags.CheckExclusive(c, abiFlag, jsonFlag, artifactFlag) // Only one source can be selected.
 
-	if c.String(pkgFlag.Name) == "" {
+	// Load the configuration file, with the flags taking precedence over it
+	cfg := new(config)
+	if c.IsSet(configFlag.Name) {
+		var err error
+		if cfg, err = loadConfig(c.String(configFlag.Name)); err != nil {
+			utils.Fatalf("Failed to load config: %v", err)
+		}
+	}
+	if c.IsSet(pkgFlag.Name) {
+		cfg.Package = c.String(pkgFlag.Name)
+	}
+	if c.IsSet(outFlag.Name) {
+		cfg.Out = c.String(outFlag.Name)
+	}
+	if cfg.Package == "" {
 		utils.Fatalf("No destination package specified (--pkg)")
 	}
-	if c.String(abiFlag.Name) == "" && c.String(jsonFlag.Name) == "" {
-		utils.Fatalf("Either contract ABI source (--abi) or combined-json (--combined-json) are required")
+	// Replace the builtin binding template if requested
+	if c.IsSet(templateFlag.Name) {
+		source, err := os.ReadFile(c.String(templateFlag.Name))
+		if err != nil {
+			utils.Fatalf("Failed to read template: %v", err)
+		}
+		gen := abigen.GeneratorV1
+		if c.IsSet(v2Flag.Name) {
+			gen = abigen.GeneratorV2
+		}
+		if err := abigen.RegisterPlugin(gen, abigen.Plugin{Name: "template", Source: string(source)}); err != nil {
+			utils.Fatalf("Failed to register template: %v", err)
+		}
+	}
+	if c.String(abiFlag.Name) == "" && c.String(jsonFlag.Name) == "" && len(c.StringSlice(artifactFlag.Name)) == 0 {
+		utils.Fatalf("Either contract ABI source (--abi), combined-json (--combined-json) or artifacts (--artifact) are required")
 	}
 	// If the entire solidity code was specified, build and bind based on that
 	var (
-		abis    []string
-		bins    []string
-		types   []string
-		sigs    []map[string]string
-		libs    = make(map[string]string)
-		aliases = make(map[string]string)
+		abis  []string
+		bins  []string
+		types []string
+		sigs  []map[string]string
+		libs  = make(map[string]string)
 	)
 	if c.String(abiFlag.Name) != "" {
 		// Load up the ABI, optional bytecode and type name from the parameters
@@ -131,15 +175,21 @@ func generate(c *cli.Context) error {
 			if bin, err = os.ReadFile(binFile); err != nil {
 				utils.Fatalf("Failed to read input bytecode: %v", err)
 			}
-			if strings.Contains(string(bin), "//") {
-				utils.Fatalf("Contract has additional library references, please use other mode(e.g. --combined-json) to catch library infos")
+			// Drop the library reference comments emitted by solc, the generated
+			// binding is linked against library addresses at deploy time.
+			var lines []string
+			for _, line := range strings.Split(string(bin), "\n") {
+				if !strings.HasPrefix(strings.TrimSpace(line), "//") {
+					lines = append(lines, line)
+				}
 			}
+			bin = []byte(strings.TrimSpace(strings.Join(lines, "\n")))
 		}
 		bins = append(bins, string(bin))
 
 		kind := c.String(typeFlag.Name)
 		if kind == "" {
-			kind = c.String(pkgFlag.Name)
+			kind = cfg.Package
 		}
 		types = append(types, kind)
 	} else {
@@ -172,6 +222,40 @@ func generate(c *cli.Context) error {
 				utils.Fatalf("Failed to read contract information from json output: %v", err)
 			}
 		}
+		if c.IsSet(artifactFlag.Name) {
+			artifacts, err := loadArtifacts(c.StringSlice(artifactFlag.Name))
+			if err != nil {
+				utils.Fatalf("Failed to load artifacts: %v", err)
+			}
+			// Gather all non-excluded artifacts for binding
+			bound := make(map[string]string)
+			for _, a := range artifacts {
+				if exclude != nil && exclude.Matches(a.name) {
+					fmt.Fprintf(os.Stderr, "excluding: %v\n", a.name)
+					continue
+				}
+				typeName := a.typeName()
+				if prev, ok := bound[typeName]; ok {
+					utils.Fatalf("Duplicate contract type %s (%s and %s), use --exc to exclude one", typeName, prev, a.name)
+				}
+				bound[typeName] = a.name
+
+				abis = append(abis, a.abi)
+				bins = append(bins, a.bin)
+				sigs = append(sigs, a.sigs)
+				types = append(types, typeName)
+				libs[libraryPattern(a.name)] = typeName
+			}
+			// The artifact of a library may not carry its source path, so also
+			// derive the placeholders from the references of the linking side.
+			for _, a := range artifacts {
+				for _, link := range a.links {
+					if name := link[strings.LastIndex(link, ":")+1:]; bound[name] != "" {
+						libs[libraryPattern(link)] = name
+					}
+				}
+			}
+		}
 		// Gather all non-excluded contract for binding
 		for name, contract := range contracts {
 			// fully qualified name is of the form <solFilePath>:<type>
@@ -190,15 +274,11 @@ func generate(c *cli.Context) error {
 			sigs = append(sigs, contract.Hashes)
 			types = append(types, typeName)
 
-			// Derive the library placeholder which is a 34 character prefix of the
-			// hex encoding of the keccak256 hash of the fully qualified library name.
-			// Note that the fully qualified library name is the path of its source
-			// file and the library name separated by ":".
-			libPattern := crypto.Keccak256Hash([]byte(name)).String()[2:36] // the first 2 chars are 0x
-			libs[libPattern] = typeName
+			libs[libraryPattern(name)] = typeName
 		}
 	}
-	// Extract all aliases from the flags
+	// Extract all aliases from the config and the flags
+	aliases := cfg.aliases()
 	if c.IsSet(aliasFlag.Name) {
 		// We support multi-versions for aliasing
 		// e.g.
@@ -210,30 +290,90 @@ func generate(c *cli.Context) error {
 			aliases[match[1]] = match[2]
 		}
 	}
-	// Generate the contract binding
-	var (
-		code string
-		err  error
-	)
-	if c.IsSet(v2Flag.Name) {
-		code, err = abigen.BindV2(types, abis, bins, c.String(pkgFlag.Name), libs, aliases)
-	} else {
-		code, err = abigen.Bind(types, abis, bins, sigs, c.String(pkgFlag.Name), libs, aliases)
+	if err := cfg.check(types); err != nil {
+		utils.Fatalf("Invalid config: %v", err)
 	}
-	if err != nil {
-		utils.Fatalf("Failed to generate ABI binding: %v", err)
+	// Group the contracts by the package and file their bindings are generated into
+	type output struct {
+		pkg string
+		out string
 	}
-	// Either flush it out to a file or display on the standard output
-	if !c.IsSet(outFlag.Name) {
-		fmt.Printf("%s\n", code)
-		return nil
+	var (
+		outputs []output
+		groups  = make(map[output][]int)
+		files   = make(map[string]string)
+	)
+	for i, typ := range types {
+		pkg, out := cfg.output(typ)
+		o := output{pkg, out}
+		if _, ok := groups[o]; !ok {
+			if prev, ok := files[out]; ok {
+				utils.Fatalf("Packages %s and %s can't be generated into the same output", prev, pkg)
+			}
+			files[out] = pkg
+			outputs = append(outputs, o)
+		}
+		groups[o] = append(groups[o], i)
 	}
-	if err := os.WriteFile(c.String(outFlag.Name), []byte(code), 0600); err != nil {
-		utils.Fatalf("Failed to write ABI binding: %v", err)
+	for _, o := range outputs {
+		var (
+			gtypes []string
+			gabis  []string
+			gbins  []string
+			gsigs  []map[string]string
+			glibs  = make(map[string]string)
+		)
+		for _, i := range groups[o] {
+			gtypes = append(gtypes, types[i])
+			gabis = append(gabis, abis[i])
+			gbins = append(gbins, bins[i])
+			if len(sigs) > i {
+				gsigs = append(gsigs, sigs[i])
+			}
+		}
+		// Libraries bound into other packages must be linked by address
+		for pattern, name := range libs {
+			if slices.Contains(gtypes, name) {
+				glibs[pattern] = name
+			}
+		}
+		// Generate the contract binding
+		var (
+			code string
+			err  error
+			opts = abigen.BindOptions{Overrides: cfg.overrides(gtypes)}
+		)
+		if c.IsSet(v2Flag.Name) {
+			code, err = abigen.BindV2WithOptions(gtypes, gabis, gbins, o.pkg, glibs, aliases, opts)
+		} else {
+			code, err = abigen.BindWithOptions(gtypes, gabis, gbins, gsigs, o.pkg, glibs, aliases, opts)
+		}
+		if err != nil {
+			utils.Fatalf("Failed to generate ABI binding: %v", err)
+		}
+		// Either flush it out to a file or display on the standard output
+		if o.out == "" {
+			fmt.Printf("%s\n", code)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(o.out), 0755); err != nil {
+			utils.Fatalf("Failed to create output directory: %v", err)
+		}
+		if err := os.WriteFile(o.out, []byte(code), 0600); err != nil {
+			utils.Fatalf("Failed to write ABI binding: %v", err)
+		}
 	}
 	return nil
 }
 
+// libraryPattern derives the library placeholder which is a 34 character prefix
+// of the hex encoding of the keccak256 hash of the fully qualified library name.
+// Note that the fully qualified library name is the path of its source file and
+// the library name separated by ":".
+func libraryPattern(name string) string {
+	return crypto.Keccak256Hash([]byte(name)).String()[2:36] // the first 2 chars are 0x
+}
+
 func main() {
 	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, true)))
 
@@ -28,6 +28,8 @@ import (
 	"io"
 	"math/big"
 	"net"
+	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -36,6 +38,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/audit"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
@@ -49,6 +52,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/admin"
 	"github.com/ethereum/go-ethereum/signer/core"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 	"github.com/ethereum/go-ethereum/signer/fourbyte"
@@ -117,6 +121,14 @@ var (
 		Usage: "File used for writing new 4byte-identifiers submitted via API",
 		Value: "./4byte-custom.json",
 	}
+	storageFlag = &cli.StringFlag{
+		Name:  "storage",
+		Usage: "Backend of the encrypted storage of credentials and rule state: vault+https://<host>/<mount>/<path> (token read from VAULT_TOKEN) or exec:<helper> (default: files in the config directory)",
+	}
+	abiDirFlag = &cli.StringFlag{
+		Name:  "abidir",
+		Usage: "Directory of contract ABIs used to decode call data in requests, named <address>.json for particular contracts",
+	}
 	auditLogFlag = &cli.StringFlag{
 		Name:  "auditlog",
 		Usage: "File used to emit audit logs. Set to \"\" to disable",
@@ -132,6 +144,31 @@ var (
 			"This means that an STDIN/STDOUT is used for RPC-communication with a e.g. a graphical user " +
 			"interface, and can be used when Clef is started by an external process.",
 	}
+	webhookFlag = &cli.StringFlag{
+		Name:  "webhook",
+		Usage: "URL of a webhook to POST requests to for approval, e.g. by a chat bot, instead of prompting the UI",
+	}
+	webhookSecretFlag = &cli.StringFlag{
+		Name:  "webhook.secret",
+		Usage: "File containing the secret shared with the webhook, authenticating requests and approvals",
+	}
+	webhookListenFlag = &cli.StringFlag{
+		Name:  "webhook.listen",
+		Usage: "Address to listen on for approvals posted back by the webhook",
+		Value: "localhost:8552",
+	}
+	adminListenFlag = &cli.StringFlag{
+		Name:  "admin.listen",
+		Usage: "Address to listen on for the REST admin API, managing the rules and whitelist (disabled if empty)",
+	}
+	adminTokenFlag = &cli.StringFlag{
+		Name:  "admin.token",
+		Usage: "File containing the bearer token authenticating requests to the admin API",
+	}
+	adminRuleSignerFlag = &cli.StringFlag{
+		Name:  "admin.rulesigner",
+		Usage: "Address of the account required to sign rule files rotated over the admin API",
+	}
 	testFlag = &cli.BoolFlag{
 		Name:  "stdio-ui-test",
 		Usage: "Mechanism to test interface between Clef and UI. Requires 'stdio-ui'.",
@@ -158,6 +195,7 @@ the rule-engine to work.`,
 			logLevelFlag,
 			configdirFlag,
 			signerSecretFlag,
+			storageFlag,
 		},
 		Description: `
 The attest command stores the sha256 of the rule.js-file that you want to use for automatic processing of
@@ -175,6 +213,7 @@ Clef that the file is 'safe' to execute.`,
 			logLevelFlag,
 			configdirFlag,
 			signerSecretFlag,
+			storageFlag,
 		},
 		Description: `
 The setpw command stores a password for a given address (keyfile).
@@ -188,6 +227,7 @@ The setpw command stores a password for a given address (keyfile).
 			logLevelFlag,
 			configdirFlag,
 			signerSecretFlag,
+			storageFlag,
 		},
 		Description: `
 The delpw command removes a password for a given address (keyfile).
@@ -271,6 +311,8 @@ func init() {
 		utils.LightKDFFlag,
 		utils.NoUSBFlag,
 		utils.SmartCardDaemonPathFlag,
+		utils.PKCS11LibraryFlag,
+		utils.SigningAuditLogFlag,
 		utils.HTTPListenAddrFlag,
 		utils.HTTPVirtualHostsFlag,
 		utils.IPCDisabledFlag,
@@ -279,9 +321,17 @@ func init() {
 		rpcPortFlag,
 		signerSecretFlag,
 		customDBFlag,
+		abiDirFlag,
+		storageFlag,
 		auditLogFlag,
 		ruleFlag,
 		stdiouiFlag,
+		webhookFlag,
+		webhookSecretFlag,
+		webhookListenFlag,
+		adminListenFlag,
+		adminTokenFlag,
+		adminRuleSignerFlag,
 		testFlag,
 		advancedMode,
 		acceptFlag,
@@ -391,13 +441,74 @@ func attestFile(ctx *cli.Context) error {
 	confKey := crypto.Keccak256([]byte("config"), stretchedKey)
 
 	// Initialize the encrypted storages
-	configStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "config.json"), confKey)
+	configStorage := newEncryptedStorage(ctx, vaultLocation, "config.json", confKey)
 	val := ctx.Args().First()
 	configStorage.Put("ruleset_sha256", val)
 	log.Info("Ruleset attestation updated", "sha256", val)
 	return nil
 }
 
+// newEncryptedStorage opens an encrypted storage of the vault in the configured
+// storage backend, defaulting to the vault directory on disk.
+func newEncryptedStorage(c *cli.Context, vaultLocation, name string, key []byte) *storage.AESEncryptedStorage {
+	backend := c.String(storageFlag.Name)
+	if backend == "" {
+		return storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, name), key)
+	}
+	// Backends may be shared between master seeds, keep their vaults apart
+	name = filepath.Base(vaultLocation) + "/" + name
+
+	switch {
+	case strings.HasPrefix(backend, "exec:"):
+		helper := strings.TrimPrefix(backend, "exec:")
+		return storage.NewAESEncryptedStorageWithBackend(storage.CommandBackend(helper), name, key)
+
+	case strings.HasPrefix(backend, "vault+"):
+		endpoint, err := url.Parse(strings.TrimPrefix(backend, "vault+"))
+		if err != nil {
+			utils.Fatalf("Invalid vault storage backend: %v", err)
+		}
+		mount, prefix, _ := strings.Cut(strings.Trim(endpoint.Path, "/"), "/")
+		if mount == "" {
+			utils.Fatalf("Vault storage backend requires the mount path of a KV secrets engine")
+		}
+		vault := storage.NewVaultBackend(endpoint.Scheme+"://"+endpoint.Host, mount, prefix, os.Getenv("VAULT_TOKEN"))
+		return storage.NewAESEncryptedStorageWithBackend(vault, name, key)
+
+	default:
+		utils.Fatalf("Unsupported storage backend %q", backend)
+		return nil
+	}
+}
+
+// startAdminAPI starts serving the REST admin API, managing the rules engine.
+func startAdminAPI(c *cli.Context, listen string, engine admin.RuleEngine, configStorage storage.Storage, auditLog *audit.Log) (*http.Server, net.Addr) {
+	if !c.IsSet(adminTokenFlag.Name) {
+		utils.Fatalf("The admin API requires a bearer token (--%s)", adminTokenFlag.Name)
+	}
+	token, err := os.ReadFile(c.String(adminTokenFlag.Name))
+	if err != nil {
+		utils.Fatalf("Could not read admin API token: %v", err)
+	}
+	config := admin.Config{
+		Token:    []byte(strings.TrimSpace(string(token))),
+		RuleFile: c.String(ruleFlag.Name),
+		AuditLog: auditLog,
+	}
+	if signer := c.String(adminRuleSignerFlag.Name); signer != "" {
+		if !common.IsHexAddress(signer) {
+			utils.Fatalf("Invalid rule signer address %q", signer)
+		}
+		addr := common.HexToAddress(signer)
+		config.RuleSigner = &addr
+	}
+	server, addr, err := node.StartHTTPEndpoint(listen, rpc.DefaultHTTPTimeouts, admin.New(config, engine, configStorage))
+	if err != nil {
+		utils.Fatalf("Could not start admin API: %v", err)
+	}
+	return server, addr
+}
+
 func initInternalApi(c *cli.Context) (*core.UIServerAPI, core.UIClientAPI, error) {
 	if err := initialize(c); err != nil {
 		return nil, nil, err
@@ -408,7 +519,7 @@ func initInternalApi(c *cli.Context) (*core.UIServerAPI, core.UIClientAPI, error
 		ksLoc                     = c.String(keystoreFlag.Name)
 		lightKdf                  = c.Bool(utils.LightKDFFlag.Name)
 	)
-	am := core.StartClefAccountManager(ksLoc, true, lightKdf, "")
+	am := core.StartClefAccountManager(ksLoc, true, lightKdf, "", "")
 	api := core.NewSignerAPI(am, 0, true, ui, nil, false, pwStorage)
 	internalApi := core.NewUIServerAPI(api)
 	return internalApi, ui, nil
@@ -437,7 +548,7 @@ func setCredential(ctx *cli.Context) error {
 	vaultLocation := filepath.Join(configDir, common.Bytes2Hex(crypto.Keccak256([]byte("vault"), stretchedKey)[:10]))
 	pwkey := crypto.Keccak256([]byte("credentials"), stretchedKey)
 
-	pwStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "credentials.json"), pwkey)
+	pwStorage := newEncryptedStorage(ctx, vaultLocation, "credentials.json", pwkey)
 	pwStorage.Put(address.Hex(), password)
 
 	log.Info("Credential store updated", "set", address)
@@ -465,7 +576,7 @@ func removeCredential(ctx *cli.Context) error {
 	vaultLocation := filepath.Join(configDir, common.Bytes2Hex(crypto.Keccak256([]byte("vault"), stretchedKey)[:10]))
 	pwkey := crypto.Keccak256([]byte("credentials"), stretchedKey)
 
-	pwStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "credentials.json"), pwkey)
+	pwStorage := newEncryptedStorage(ctx, vaultLocation, "credentials.json", pwkey)
 	pwStorage.Del(address.Hex())
 
 	log.Info("Credential store updated", "unset", address)
@@ -640,6 +751,29 @@ func signer(c *cli.Context) error {
 		log.Info("Using CLI as UI-channel")
 		ui = core.NewCommandlineUI()
 	}
+	if webhook := c.String(webhookFlag.Name); webhook != "" {
+		if !c.IsSet(webhookSecretFlag.Name) {
+			utils.Fatalf("Webhook approvals require a shared secret (--%s)", webhookSecretFlag.Name)
+		}
+		secret, err := os.ReadFile(c.String(webhookSecretFlag.Name))
+		if err != nil {
+			utils.Fatalf("Could not read webhook secret: %v", err)
+		}
+		listen := c.String(webhookListenFlag.Name)
+		webhookUI := core.NewWebhookUI(core.WebhookConfig{
+			URL:         webhook,
+			CallbackURL: fmt.Sprintf("http://%s/", listen),
+			Secret:      []byte(strings.TrimSpace(string(secret))),
+		}, ui)
+		callbackServer, addr, err := node.StartHTTPEndpoint(listen, rpc.DefaultHTTPTimeouts, webhookUI)
+		if err != nil {
+			utils.Fatalf("Could not start webhook callback endpoint: %v", err)
+		}
+		defer callbackServer.Shutdown(context.Background())
+
+		log.Info("Using webhook as UI-channel", "url", webhook, "callback", addr)
+		ui = webhookUI
+	}
 	// 4bytedb data
 	fourByteLocal := c.String(customDBFlag.Name)
 	db, err := fourbyte.NewWithFile(fourByteLocal)
@@ -649,6 +783,24 @@ func signer(c *cli.Context) error {
 	embeds, locals := db.Size()
 	log.Info("Loaded 4byte database", "embeds", embeds, "locals", locals, "local", fourByteLocal)
 
+	if dir := c.String(abiDirFlag.Name); dir != "" {
+		if err := db.LoadABIDir(dir); err != nil {
+			utils.Fatalf("Failed to load ABI directory: %v", err)
+		}
+		contracts, generic := db.ABIs()
+		log.Info("Loaded ABI directory", "dir", dir, "contracts", contracts, "generic", generic)
+	}
+
+	// Record the signing operations in a tamper-evident log, queryable over the
+	// admin API
+	var auditLog *audit.Log
+	if path := c.String(utils.SigningAuditLogFlag.Name); path != "" {
+		if auditLog, err = audit.Open(path); err != nil {
+			utils.Fatalf("Failed to open signing audit log: %v", err)
+		}
+		defer auditLog.Close()
+		log.Info("Recording signing operations", "path", path, "head", auditLog.Head())
+	}
 	var (
 		api       core.ExternalAPI
 		pwStorage storage.Storage = &storage.NoStorage{}
@@ -665,13 +817,17 @@ func signer(c *cli.Context) error {
 		confkey := crypto.Keccak256([]byte("config"), stretchedKey)
 
 		// Initialize the encrypted storages
-		pwStorage = storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "credentials.json"), pwkey)
-		jsStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "jsstorage.json"), jskey)
-		configStorage := storage.NewAESEncryptedStorage(filepath.Join(vaultLocation, "config.json"), confkey)
+		pwStorage = newEncryptedStorage(c, vaultLocation, "credentials.json", pwkey)
+		jsStorage := newEncryptedStorage(c, vaultLocation, "jsstorage.json", jskey)
+		configStorage := newEncryptedStorage(c, vaultLocation, "config.json", confkey)
 
 		// Do we have a rule-file?
+		var (
+			ruleJS   []byte
+			attested bool
+		)
 		if ruleFile := c.String(ruleFlag.Name); ruleFile != "" {
-			ruleJS, err := os.ReadFile(ruleFile)
+			ruleJS, err = os.ReadFile(ruleFile)
 			if err != nil {
 				log.Warn("Could not load rules, disabling", "file", ruleFile, "err", err)
 			} else {
@@ -681,17 +837,29 @@ func signer(c *cli.Context) error {
 				if storedShasum != foundShaSum {
 					log.Warn("Rule hash not attested, disabling", "hash", foundShaSum, "attested", storedShasum)
 				} else {
-					// Initialize rules
-					ruleEngine, err := rules.NewRuleEvaluator(ui, jsStorage)
-					if err != nil {
-						utils.Fatalf(err.Error())
-					}
-					ruleEngine.Init(string(ruleJS))
-					ui = ruleEngine
-					log.Info("Rule engine configured", "file", c.String(ruleFlag.Name))
+					attested = true
 				}
 			}
 		}
+		// Initialize rules, if attested or managed over the admin API
+		adminListen := c.String(adminListenFlag.Name)
+		if attested || adminListen != "" {
+			ruleEngine, err := rules.NewRuleEvaluator(ui, jsStorage)
+			if err != nil {
+				utils.Fatalf(err.Error())
+			}
+			if attested {
+				ruleEngine.Init(string(ruleJS))
+				log.Info("Rule engine configured", "file", c.String(ruleFlag.Name))
+			}
+			ui = ruleEngine
+
+			if adminListen != "" {
+				adminServer, addr := startAdminAPI(c, adminListen, ruleEngine, configStorage, auditLog)
+				defer adminServer.Shutdown(context.Background())
+				log.Info("Admin API opened", "url", fmt.Sprintf("http://%v/", addr))
+			}
+		}
 	}
 	var (
 		chainId  = c.Int64(chainIdFlag.Name)
@@ -700,10 +868,17 @@ func signer(c *cli.Context) error {
 		advanced = c.Bool(advancedMode.Name)
 		nousb    = c.Bool(utils.NoUSBFlag.Name)
 		scpath   = c.String(utils.SmartCardDaemonPathFlag.Name)
+		p11lib   = c.String(utils.PKCS11LibraryFlag.Name)
 	)
 	log.Info("Starting signer", "chainid", chainId, "keystore", ksLoc,
 		"light-kdf", lightKdf, "advanced", advanced)
-	am := core.StartClefAccountManager(ksLoc, nousb, lightKdf, scpath)
+	backends := core.ClefBackends(ksLoc, nousb, lightKdf, scpath, p11lib)
+	if auditLog != nil {
+		for i, backend := range backends {
+			backends[i] = audit.NewBackend(backend, auditLog, "")
+		}
+	}
+	am := accounts.NewManager(nil, backends...)
 	defer am.Close()
 	apiImpl := core.NewSignerAPI(am, chainId, nousb, ui, db, advanced, pwStorage)
 
@@ -1163,6 +1338,40 @@ func GenDoc(ctx *cli.Context) error {
 			"provide the transaction in return",
 			&core.SignTxResponse{})
 	}
+	{ // Sign transaction batch request
+		desc := "SignTxBatchRequest contains information about a pending request to sign a batch of transactions " +
+			"with a single approval. Each transaction is passed along with its own `call_info`, and the `summary` " +
+			"aggregates the total value, senders, distinct destinations and nonce range of the whole batch." +
+			"\n\n" +
+			"Unlike single transactions, the batch cannot be modified by the UI."
+
+		to := common.NewMixedcaseAddress(b)
+		tx := func(nonce uint64) *core.SignTxRequest {
+			return &core.SignTxRequest{
+				Meta: meta,
+				Transaction: apitypes.SendTxArgs{
+					Nonce:    hexutil.Uint64(nonce),
+					Value:    hexutil.Big(*big.NewInt(6)),
+					From:     common.NewMixedcaseAddress(a),
+					To:       &to,
+					GasPrice: (*hexutil.Big)(big.NewInt(5)),
+					Gas:      21000,
+				}}
+		}
+		add("SignTxBatchRequest", desc, &core.SignTxBatchRequest{
+			Meta:         meta,
+			Transactions: []*core.SignTxRequest{tx(1), tx(2)},
+			Summary: core.TxBatchSummary{
+				Count:        2,
+				TotalValue:   (*hexutil.Big)(big.NewInt(12)),
+				Senders:      []common.Address{a},
+				Destinations: []common.Address{b},
+				NonceMin:     1,
+				NonceMax:     2,
+			}})
+		add("SignTxBatchResponse - approve", "Response to SignTxBatchRequest",
+			&core.SignTxBatchResponse{Approved: true})
+	}
 	{ // WHen a signed tx is ready to go out
 		desc := "SignTransactionResult is used in the call `clef` -> `OnApprovedTx(result)`" +
 			"\n\n" +
@@ -28,10 +28,14 @@ import (
 	"unicode"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/audit"
 	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/external/web3signer"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/pkcs11"
 	"github.com/ethereum/go-ethereum/accounts/scwallet"
 	"github.com/ethereum/go-ethereum/accounts/usbwallet"
+	"github.com/ethereum/go-ethereum/accounts/watchonly"
 	"github.com/ethereum/go-ethereum/beacon/blsync"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
@@ -413,11 +417,27 @@ func setAccountManagerBackends(conf *node.Config, am *accounts.Manager, keydir s
 		scryptP = keystore.LightScryptP
 	}
 
-	// Assemble the supported backends
+	// Record the signing operations of all backends if requested
+	addBackend := am.AddBackend
+	if len(conf.SigningAuditLog) > 0 {
+		auditLog, err := audit.Open(conf.SigningAuditLog)
+		if err != nil {
+			return fmt.Errorf("error opening signing audit log: %v", err)
+		}
+		log.Info("Recording signing operations", "path", conf.SigningAuditLog, "head", auditLog.Head())
+		addBackend = func(backend accounts.Backend) {
+			am.AddBackend(audit.NewBackend(backend, auditLog, ""))
+		}
+	}
+	// Assemble the supported backends, watch-only accounts being available with
+	// any signer since they can't sign anyway
+	if len(conf.WatchAddresses) > 0 {
+		addBackend(watchonly.NewAddressBook(conf.WatchAddresses...))
+	}
 	if len(conf.ExternalSigner) > 0 {
 		log.Info("Using external signer", "url", conf.ExternalSigner)
 		if extBackend, err := external.NewExternalBackend(conf.ExternalSigner); err == nil {
-			am.AddBackend(extBackend)
+			addBackend(extBackend)
 			return nil
 		} else {
 			return fmt.Errorf("error connecting to external signer: %v", err)
@@ -428,25 +448,25 @@ func setAccountManagerBackends(conf *node.Config, am *accounts.Manager, keydir s
 	// If/when we implement some form of lockfile for USB and keystore wallets,
 	// we can have both, but it's very confusing for the user to see the same
 	// accounts in both externally and locally, plus very racey.
-	am.AddBackend(keystore.NewKeyStore(keydir, scryptN, scryptP))
+	addBackend(keystore.NewKeyStore(keydir, scryptN, scryptP))
 	if conf.USB {
 		// Start a USB hub for Ledger hardware wallets
 		if ledgerhub, err := usbwallet.NewLedgerHub(); err != nil {
 			log.Warn(fmt.Sprintf("Failed to start Ledger hub, disabling: %v", err))
 		} else {
-			am.AddBackend(ledgerhub)
+			addBackend(ledgerhub)
 		}
 		// Start a USB hub for Trezor hardware wallets (HID version)
 		if trezorhub, err := usbwallet.NewTrezorHubWithHID(); err != nil {
 			log.Warn(fmt.Sprintf("Failed to start HID Trezor hub, disabling: %v", err))
 		} else {
-			am.AddBackend(trezorhub)
+			addBackend(trezorhub)
 		}
 		// Start a USB hub for Trezor hardware wallets (WebUSB version)
 		if trezorhub, err := usbwallet.NewTrezorHubWithWebUSB(); err != nil {
 			log.Warn(fmt.Sprintf("Failed to start WebUSB Trezor hub, disabling: %v", err))
 		} else {
-			am.AddBackend(trezorhub)
+			addBackend(trezorhub)
 		}
 	}
 	if len(conf.SmartCardDaemonPath) > 0 {
@@ -454,8 +474,30 @@ func setAccountManagerBackends(conf *node.Config, am *accounts.Manager, keydir s
 		if schub, err := scwallet.NewHub(conf.SmartCardDaemonPath, scwallet.Scheme, keydir); err != nil {
 			log.Warn(fmt.Sprintf("Failed to start smart card hub, disabling: %v", err))
 		} else {
-			am.AddBackend(schub)
+			addBackend(schub)
+		}
+	}
+	if len(conf.PKCS11Library) > 0 {
+		// Start a hub for the tokens of a hardware security module
+		if p11hub, err := pkcs11.NewHub(conf.PKCS11Library); err != nil {
+			log.Warn(fmt.Sprintf("Failed to start PKCS#11 hub, disabling: %v", err))
+		} else {
+			addBackend(p11hub)
+		}
+	}
+	if len(conf.Web3Signer) > 0 {
+		// Connect to the remote signer, failing hard since the user explicitly
+		// requested its keys
+		log.Info("Using Web3Signer remote signer", "url", conf.Web3Signer)
+		config, err := web3signer.LoadTLSConfig(conf.Web3SignerClientCert, conf.Web3SignerClientKey, conf.Web3SignerCACert)
+		if err != nil {
+			return fmt.Errorf("error loading Web3Signer TLS configuration: %v", err)
+		}
+		backend, err := web3signer.NewBackend(conf.Web3Signer, config)
+		if err != nil {
+			return fmt.Errorf("error connecting to Web3Signer: %v", err)
 		}
+		addBackend(backend)
 	}
 
 	return nil
@@ -62,6 +62,13 @@ var (
 		utils.NoUSBFlag, // deprecated
 		utils.USBFlag,
 		utils.SmartCardDaemonPathFlag,
+		utils.PKCS11LibraryFlag,
+		utils.Web3SignerFlag,
+		utils.Web3SignerClientCertFlag,
+		utils.Web3SignerClientKeyFlag,
+		utils.Web3SignerCACertFlag,
+		utils.SigningAuditLogFlag,
+		utils.WatchAddressesFlag,
 		utils.OverrideOsaka,
 		utils.OverrideBPO1,
 		utils.OverrideBPO2,
@@ -182,6 +189,10 @@ var (
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
 		utils.WSPathPrefixFlag,
+		utils.GRPCEnabledFlag,
+		utils.GRPCListenAddrFlag,
+		utils.GRPCPortFlag,
+		utils.GRPCApiFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 		utils.InsecureUnlockAllowedFlag,
@@ -192,6 +203,10 @@ var (
 		utils.AllowUnprotectedTxs,
 		utils.BatchRequestLimit,
 		utils.BatchResponseMaxSize,
+		utils.RPCRateLimitFlag,
+		utils.RPCRateLimitPerConnFlag,
+		utils.RPCCallBudgetFlag,
+		utils.RPCSlowCallThresholdFlag,
 		utils.RPCTxSyncDefaultTimeoutFlag,
 		utils.RPCTxSyncMaxTimeoutFlag,
 		utils.RPCGlobalRangeLimitFlag,
@@ -135,6 +135,41 @@ var (
 		Value:    pcsclite.PCSCDSockName,
 		Category: flags.AccountCategory,
 	}
+	PKCS11LibraryFlag = &cli.StringFlag{
+		Name:     "pkcs11.lib",
+		Usage:    "Path to the PKCS#11 library of a hardware security module holding account keys",
+		Category: flags.AccountCategory,
+	}
+	Web3SignerFlag = &cli.StringFlag{
+		Name:     "web3signer",
+		Usage:    "URL of a Web3Signer remote signer holding account keys",
+		Category: flags.AccountCategory,
+	}
+	Web3SignerClientCertFlag = &cli.StringFlag{
+		Name:     "web3signer.tls.cert",
+		Usage:    "Path to the client certificate authenticating to the remote signer",
+		Category: flags.AccountCategory,
+	}
+	Web3SignerClientKeyFlag = &cli.StringFlag{
+		Name:     "web3signer.tls.key",
+		Usage:    "Path to the key of the client certificate authenticating to the remote signer",
+		Category: flags.AccountCategory,
+	}
+	Web3SignerCACertFlag = &cli.StringFlag{
+		Name:     "web3signer.tls.ca",
+		Usage:    "Path to the CA certificate verifying the remote signer (default = system roots)",
+		Category: flags.AccountCategory,
+	}
+	SigningAuditLogFlag = &cli.StringFlag{
+		Name:     "accounts.auditlog",
+		Usage:    "Path to an append-only log recording all signing operations",
+		Category: flags.AccountCategory,
+	}
+	WatchAddressesFlag = &cli.StringFlag{
+		Name:     "watch",
+		Usage:    "Comma separated addresses to list as watch-only accounts, which can't sign",
+		Category: flags.AccountCategory,
+	}
 	NetworkIdFlag = &cli.Uint64Flag{
 		Name:     "networkid",
 		Usage:    "Explicitly set network ID (integer)(For testnets: use --sepolia, --holesky, --hoodi instead)",
@@ -803,6 +838,29 @@ var (
 		Value:    "",
 		Category: flags.APICategory,
 	}
+	GRPCEnabledFlag = &cli.BoolFlag{
+		Name:     "grpc",
+		Usage:    "Enable the gRPC server",
+		Category: flags.APICategory,
+	}
+	GRPCListenAddrFlag = &cli.StringFlag{
+		Name:     "grpc.addr",
+		Usage:    "gRPC server listening interface",
+		Value:    node.DefaultGRPCHost,
+		Category: flags.APICategory,
+	}
+	GRPCPortFlag = &cli.IntFlag{
+		Name:     "grpc.port",
+		Usage:    "gRPC server listening port",
+		Value:    node.DefaultGRPCPort,
+		Category: flags.APICategory,
+	}
+	GRPCApiFlag = &cli.StringFlag{
+		Name:     "grpc.api",
+		Usage:    "API's offered over the gRPC interface",
+		Value:    "",
+		Category: flags.APICategory,
+	}
 	ExecFlag = &cli.StringFlag{
 		Name:     "exec",
 		Usage:    "Execute JavaScript statement",
@@ -830,6 +888,26 @@ var (
 		Value:    node.DefaultConfig.BatchResponseMaxSize,
 		Category: flags.APICategory,
 	}
+	RPCRateLimitFlag = &cli.StringFlag{
+		Name:     "rpc.ratelimit",
+		Usage:    "Comma separated rate limits of methods or namespaces served over HTTP and WebSocket, as name=rate[:burst[:concurrency]] (e.g. debug=5:10:2,eth_call=100)",
+		Category: flags.APICategory,
+	}
+	RPCRateLimitPerConnFlag = &cli.BoolFlag{
+		Name:     "rpc.ratelimit.perconn",
+		Usage:    "Apply the RPC rate limits to every connection separately, instead of to all calls served",
+		Category: flags.APICategory,
+	}
+	RPCCallBudgetFlag = &cli.DurationFlag{
+		Name:     "rpc.call-budget",
+		Usage:    "Maximum execution time of requests served over HTTP and WebSocket (0 = no limit)",
+		Category: flags.APICategory,
+	}
+	RPCSlowCallThresholdFlag = &cli.DurationFlag{
+		Name:     "rpc.slow-call-threshold",
+		Usage:    "Execution time above which calls served over HTTP and WebSocket are logged as slow (0 = disabled)",
+		Category: flags.APICategory,
+	}
 
 	// Network Settings
 	MaxPeersFlag = &cli.IntFlag{
@@ -1274,6 +1352,56 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	if ctx.IsSet(BatchResponseMaxSize.Name) {
 		cfg.BatchResponseMaxSize = ctx.Int(BatchResponseMaxSize.Name)
 	}
+
+	if ctx.IsSet(RPCRateLimitFlag.Name) {
+		limits, err := parseRateLimits(ctx.String(RPCRateLimitFlag.Name), ctx.Bool(RPCRateLimitPerConnFlag.Name))
+		if err != nil {
+			Fatalf("Option %q: %v", RPCRateLimitFlag.Name, err)
+		}
+		cfg.RPCRateLimits = limits
+	}
+
+	if ctx.IsSet(RPCCallBudgetFlag.Name) {
+		cfg.RPCCallBudget = ctx.Duration(RPCCallBudgetFlag.Name)
+	}
+
+	if ctx.IsSet(RPCSlowCallThresholdFlag.Name) {
+		cfg.RPCSlowCallThreshold = ctx.Duration(RPCSlowCallThresholdFlag.Name)
+	}
+}
+
+// parseRateLimits parses a comma separated list of RPC rate limits, each in the
+// format name=rate[:burst[:concurrency]].
+func parseRateLimits(spec string, perConn bool) (map[string]rpc.RateLimit, error) {
+	limits := make(map[string]rpc.RateLimit)
+	for _, entry := range SplitAndTrim(spec) {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+		parts := strings.Split(value, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid rate limit %q", entry)
+		}
+		limit := rpc.RateLimit{PerConnection: perConn}
+		rate, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate in %q", entry)
+		}
+		limit.Rate = rate
+		if len(parts) > 1 {
+			if limit.Burst, err = strconv.Atoi(parts[1]); err != nil || limit.Burst < 0 {
+				return nil, fmt.Errorf("invalid burst in %q", entry)
+			}
+		}
+		if len(parts) > 2 {
+			if limit.MaxConcurrent, err = strconv.Atoi(parts[2]); err != nil || limit.MaxConcurrent < 0 {
+				return nil, fmt.Errorf("invalid concurrency in %q", entry)
+			}
+		}
+		limits[name] = limit
+	}
+	return limits, nil
 }
 
 // setGraphQL creates the GraphQL listener interface string from the set
@@ -1315,6 +1443,25 @@ func setWS(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setGRPC creates the gRPC listener interface string from the set command line
+// flags, returning empty if the gRPC endpoint is disabled.
+func setGRPC(ctx *cli.Context, cfg *node.Config) {
+	if ctx.Bool(GRPCEnabledFlag.Name) {
+		if cfg.GRPCHost == "" {
+			cfg.GRPCHost = "127.0.0.1"
+		}
+		if ctx.IsSet(GRPCListenAddrFlag.Name) {
+			cfg.GRPCHost = ctx.String(GRPCListenAddrFlag.Name)
+		}
+	}
+	if ctx.IsSet(GRPCPortFlag.Name) {
+		cfg.GRPCPort = ctx.Int(GRPCPortFlag.Name)
+	}
+	if ctx.IsSet(GRPCApiFlag.Name) {
+		cfg.GRPCModules = SplitAndTrim(ctx.String(GRPCApiFlag.Name))
+	}
+}
+
 // setIPC creates an IPC path configuration from the set command line flags,
 // returning an empty string if IPC was explicitly disabled, or the set path.
 func setIPC(ctx *cli.Context, cfg *node.Config) {
@@ -1423,9 +1570,37 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setHTTP(ctx, cfg)
 	setGraphQL(ctx, cfg)
 	setWS(ctx, cfg)
+	setGRPC(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 	SetDataDir(ctx, cfg)
 	setSmartCard(ctx, cfg)
+	if ctx.IsSet(PKCS11LibraryFlag.Name) {
+		cfg.PKCS11Library = ctx.String(PKCS11LibraryFlag.Name)
+	}
+	if ctx.IsSet(Web3SignerFlag.Name) {
+		cfg.Web3Signer = ctx.String(Web3SignerFlag.Name)
+	}
+	if ctx.IsSet(Web3SignerClientCertFlag.Name) {
+		cfg.Web3SignerClientCert = ctx.String(Web3SignerClientCertFlag.Name)
+	}
+	if ctx.IsSet(Web3SignerClientKeyFlag.Name) {
+		cfg.Web3SignerClientKey = ctx.String(Web3SignerClientKeyFlag.Name)
+	}
+	if ctx.IsSet(Web3SignerCACertFlag.Name) {
+		cfg.Web3SignerCACert = ctx.String(Web3SignerCACertFlag.Name)
+	}
+	if ctx.IsSet(SigningAuditLogFlag.Name) {
+		cfg.SigningAuditLog = ctx.String(SigningAuditLogFlag.Name)
+	}
+	if ctx.IsSet(WatchAddressesFlag.Name) {
+		for _, account := range strings.Split(ctx.String(WatchAddressesFlag.Name), ",") {
+			if trimmed := strings.TrimSpace(account); !common.IsHexAddress(trimmed) {
+				Fatalf("Invalid account in --watch: %s", trimmed)
+			} else {
+				cfg.WatchAddresses = append(cfg.WatchAddresses, common.HexToAddress(trimmed))
+			}
+		}
+	}
 
 	if ctx.IsSet(JWTSecretFlag.Name) {
 		cfg.JWTSecret = ctx.String(JWTSecretFlag.Name)
@@ -20,6 +20,8 @@ package utils
 import (
 	"reflect"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 func Test_SplitTagsFlag(t *testing.T) {
@@ -64,3 +66,24 @@ func Test_SplitTagsFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRateLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := parseRateLimits("debug=5:10:2, eth_call=0.5", true)
+	if err != nil {
+		t.Fatalf("failed to parse rate limits: %v", err)
+	}
+	want := map[string]rpc.RateLimit{
+		"debug":    {Rate: 5, Burst: 10, MaxConcurrent: 2, PerConnection: true},
+		"eth_call": {Rate: 0.5, PerConnection: true},
+	}
+	if !reflect.DeepEqual(limits, want) {
+		t.Errorf("rate limits mismatch: have %v, want %v", limits, want)
+	}
+	for _, spec := range []string{"debug", "=5", "debug=x", "debug=-1", "debug=1:x", "debug=1:2:x", "debug=1:2:3:4"} {
+		if _, err := parseRateLimits(spec, false); err == nil {
+			t.Errorf("expected error for %q", spec)
+		}
+	}
+}
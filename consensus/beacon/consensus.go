@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
@@ -61,6 +62,10 @@ var (
 // engine implements the consensus interface (except the beacon itself).
 type Beacon struct {
 	ethone consensus.Engine // Original consensus engine used in eth1, e.g. ethash or clique
+
+	baseFeeLock     sync.Mutex
+	baseFeeNumber   uint64   // Number of the block whose base fee is overridden
+	baseFeeOverride *big.Int // Base fee of the block, nil if not overridden
 }
 
 // New creates a consensus engine with the given embedded eth1 engine.
@@ -71,6 +76,31 @@ func New(ethone consensus.Engine) *Beacon {
 	return &Beacon{ethone: ethone}
 }
 
+// OverrideBaseFee makes the block with the given number use the given base fee,
+// instead of the one derived from its parent by EIP-1559, both when preparing
+// and when verifying it. A nil base fee removes the override.
+//
+// Blocks with overridden base fees are rejected by every other node, so this is
+// only meant for simulated chains, e.g. to test the fee handling of contracts.
+func (beacon *Beacon) OverrideBaseFee(number uint64, baseFee *big.Int) {
+	beacon.baseFeeLock.Lock()
+	defer beacon.baseFeeLock.Unlock()
+
+	beacon.baseFeeNumber, beacon.baseFeeOverride = number, baseFee
+}
+
+// overriddenBaseFee returns the overridden base fee of a block, or nil if the
+// base fee is derived by EIP-1559.
+func (beacon *Beacon) overriddenBaseFee(number uint64) *big.Int {
+	beacon.baseFeeLock.Lock()
+	defer beacon.baseFeeLock.Unlock()
+
+	if beacon.baseFeeOverride == nil || beacon.baseFeeNumber != number {
+		return nil
+	}
+	return new(big.Int).Set(beacon.baseFeeOverride)
+}
+
 // Author implements consensus.Engine, returning the verified author of the block.
 func (beacon *Beacon) Author(header *types.Header) (common.Address, error) {
 	if !beacon.IsPoSHeader(header) {
@@ -242,6 +272,14 @@ func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, pa
 		return consensus.ErrInvalidNumber
 	}
 	// Verify the header's EIP-1559 attributes.
+	if baseFee := beacon.overriddenBaseFee(header.Number.Uint64()); baseFee != nil {
+		if header.BaseFee == nil || header.BaseFee.Cmp(baseFee) != 0 {
+			return fmt.Errorf("invalid baseFee: have %v, want %v (overridden)", header.BaseFee, baseFee)
+		}
+		// Verify the remaining attributes against the derived base fee
+		header = types.CopyHeader(header)
+		header.BaseFee = eip1559.CalcBaseFee(chain.Config(), parent)
+	}
 	if err := eip1559.VerifyEIP1559Header(chain.Config(), parent, header); err != nil {
 		return err
 	}
@@ -322,6 +360,9 @@ func (beacon *Beacon) Prepare(chain consensus.ChainHeaderReader, header *types.H
 		return beacon.ethone.Prepare(chain, header)
 	}
 	header.Difficulty = beaconDifficulty
+	if baseFee := beacon.overriddenBaseFee(header.Number.Uint64()); baseFee != nil && header.BaseFee != nil {
+		header.BaseFee = baseFee
+	}
 	return nil
 }
 
@@ -14,7 +14,7 @@
 // You should have received a copy of the GNU Lesser General Public License
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
-package types
+package types_test
 
 import (
 	"bytes"
@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 var (
@@ -81,12 +82,12 @@ func FuzzUnpackIntoDeposit(f *testing.F) {
 		if err != nil {
 			t.Fatalf("error packing deposit: %v", err)
 		}
-		got, err := DepositLogToRequest(out[4:])
+		got, err := types.DepositLogToRequest(out[4:])
 		if err != nil {
 			t.Errorf("error unpacking deposit: %v", err)
 		}
-		if len(got) != depositRequestSize {
-			t.Errorf("wrong output size: %d, want %d", len(got), depositRequestSize)
+		if len(got) != len(enc) {
+			t.Errorf("wrong output size: %d, want %d", len(got), len(enc))
 		}
 		if !bytes.Equal(enc, got) {
 			t.Errorf("roundtrip failed: want %x, got %x", enc, got)
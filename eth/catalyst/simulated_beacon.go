@@ -22,11 +22,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/beacon/engine"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
@@ -95,7 +97,11 @@ type SimulatedBeacon struct {
 
 	engineAPI          *ConsensusAPI
 	curForkchoiceState engine.ForkchoiceStateV1
-	lastBlockTime      uint64
+
+	timeLock      sync.Mutex // lock gates concurrent access to the block times and base fee
+	lastBlockTime uint64
+	nextBlockTime uint64   // Timestamp of the next block sealed on demand, 0 = current time
+	nextBaseFee   *big.Int // Base fee of the next block sealed on demand, nil = EIP-1559
 }
 
 func payloadVersion(config *params.ChainConfig, time uint64) engine.PayloadVersion {
@@ -167,9 +173,12 @@ func (c *SimulatedBeacon) Stop() error {
 // sealBlock initiates payload building for a new block and creates a new block
 // with the completed payload.
 func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp uint64) error {
+	c.timeLock.Lock()
 	if timestamp <= c.lastBlockTime {
 		timestamp = c.lastBlockTime + 1
 	}
+	c.timeLock.Unlock()
+
 	c.feeRecipientLock.Lock()
 	feeRecipient := c.feeRecipient
 	c.feeRecipientLock.Unlock()
@@ -266,7 +275,9 @@ func (c *SimulatedBeacon) sealBlock(withdrawals []*types.Withdrawal, timestamp u
 	if _, err = c.engineAPI.forkchoiceUpdated(c.curForkchoiceState, nil, version, false); err != nil {
 		return err
 	}
+	c.timeLock.Lock()
 	c.lastBlockTime = payload.Timestamp
+	c.timeLock.Unlock()
 	return nil
 }
 
@@ -314,12 +325,63 @@ func (c *SimulatedBeacon) setCurrentState(headHash, finalizedHash common.Hash) {
 // Commit seals a block on demand.
 func (c *SimulatedBeacon) Commit() common.Hash {
 	withdrawals := c.withdrawals.pop(10)
-	if err := c.sealBlock(withdrawals, uint64(time.Now().Unix())); err != nil {
+	timestamp := uint64(time.Now().Unix())
+
+	c.timeLock.Lock()
+	if c.nextBlockTime != 0 {
+		timestamp, c.nextBlockTime = c.nextBlockTime, 0
+	}
+	baseFee := c.nextBaseFee
+	c.nextBaseFee = nil
+	c.timeLock.Unlock()
+
+	if baseFee != nil {
+		engine := c.eth.Engine().(*beacon.Beacon)
+		number := c.eth.BlockChain().CurrentBlock().Number.Uint64() + 1
+
+		engine.OverrideBaseFee(number, baseFee)
+		defer engine.OverrideBaseFee(number, nil)
+	}
+	if err := c.sealBlock(withdrawals, timestamp); err != nil {
 		log.Warn("Error performing sealing work", "err", err)
 	}
 	return c.eth.BlockChain().CurrentBlock().Hash()
 }
 
+// Head returns the hash of the current head block.
+func (c *SimulatedBeacon) Head() common.Hash {
+	return c.eth.BlockChain().CurrentBlock().Hash()
+}
+
+// SetNextBlockTime sets the timestamp of the next block sealed by Commit.
+func (c *SimulatedBeacon) SetNextBlockTime(timestamp uint64) error {
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+
+	if timestamp <= c.lastBlockTime {
+		return fmt.Errorf("timestamp %d not after the head block (%d)", timestamp, c.lastBlockTime)
+	}
+	c.nextBlockTime = timestamp
+	return nil
+}
+
+// SetNextBaseFee sets the base fee of the next block sealed by Commit, instead of
+// the one derived from its parent by EIP-1559. Later blocks derive their base fee
+// from it.
+func (c *SimulatedBeacon) SetNextBaseFee(baseFee *big.Int) error {
+	if baseFee == nil || baseFee.Sign() < 0 {
+		return fmt.Errorf("invalid base fee %v", baseFee)
+	}
+	if _, ok := c.eth.Engine().(*beacon.Beacon); !ok {
+		return errors.New("base fee override requires the beacon consensus engine")
+	}
+	c.timeLock.Lock()
+	defer c.timeLock.Unlock()
+
+	c.nextBaseFee = new(big.Int).Set(baseFee)
+	return nil
+}
+
 // Rollback un-sends previously added transactions.
 func (c *SimulatedBeacon) Rollback() {
 	c.eth.TxPool().Clear()
@@ -341,6 +403,29 @@ func (c *SimulatedBeacon) Fork(parentHash common.Hash) error {
 	return err
 }
 
+// Revert sets the head to the provided hash, discarding the blocks after it.
+// Unlike Fork, all pending transactions are dropped, including the ones of the
+// discarded blocks, and block timestamps continue from the new head.
+func (c *SimulatedBeacon) Revert(hash common.Hash) error {
+	header := c.eth.BlockChain().GetHeaderByHash(hash)
+	if header == nil {
+		return errors.New("block not found")
+	}
+	c.Rollback()
+	if err := c.Fork(hash); err != nil {
+		return err
+	}
+	// Wait for the pool to reinject the transactions of the discarded blocks
+	// before dropping them too
+	c.eth.TxPool().Sync()
+	c.Rollback()
+
+	c.timeLock.Lock()
+	c.lastBlockTime, c.nextBlockTime, c.nextBaseFee = header.Time, 0, nil
+	c.timeLock.Unlock()
+	return nil
+}
+
 // AdjustTime creates a new block with an adjusted timestamp.
 func (c *SimulatedBeacon) AdjustTime(adjustment time.Duration) error {
 	if len(c.eth.TxPool().Pending(txpool.PendingFilter{})) != 0 {
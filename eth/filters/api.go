@@ -440,7 +440,8 @@ func (api *FilterAPI) NewFilter(crit FilterCriteria) (rpc.ID, error) {
 }
 
 // GetLogs returns logs matching the given argument that are stored within the state.
-func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*types.Log, error) {
+// The logs are streamed to the client, as the result of a big block range can be large.
+func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) (rpc.ResultStream, error) {
 	if len(crit.Topics) > maxTopics {
 		return nil, errExceedMaxTopics
 	}
@@ -484,12 +485,12 @@ func (api *FilterAPI) GetLogs(ctx context.Context, crit FilterCriteria) ([]*type
 		filter = api.sys.NewRangeFilter(begin, end, crit.Addresses, crit.Topics, api.rangeLimit)
 	}
 
-	// Run the filter and return all the logs
+	// Run the filter and stream all the logs
 	logs, err := filter.Logs(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return returnLogs(logs), err
+	return streamLogs(logs), nil
 }
 
 // UninstallFilter removes the filter with the given filter id.
@@ -612,6 +613,18 @@ func returnLogs(logs []*types.Log) []*types.Log {
 	return logs
 }
 
+// streamLogs returns a result stream sending the given logs.
+func streamLogs(logs []*types.Log) rpc.ResultStream {
+	return func(ctx context.Context, send func(interface{}) error) error {
+		for _, log := range logs {
+			if err := send(log); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // UnmarshalJSON sets *args fields with given data.
 func (args *FilterCriteria) UnmarshalJSON(data []byte) error {
 	type input struct {
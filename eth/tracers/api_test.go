@@ -653,7 +653,7 @@ func TestTraceBlock(t *testing.T) {
 			t.Errorf("test %d, want no error, have %v", i, err)
 			continue
 		}
-		have, _ := json.Marshal(result)
+		have, _ := json.Marshal(collectStream(t, result))
 		want := tc.want
 		if string(have) != want {
 			t.Errorf("test %d, result mismatch, have\n%v\n, want\n%v\n", i, string(have), want)
@@ -661,6 +661,19 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+// collectStream returns the items of a streamed result.
+func collectStream(t *testing.T, stream rpc.ResultStream) []interface{} {
+	items := make([]interface{}, 0)
+	err := stream(context.Background(), func(item interface{}) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+	return items
+}
+
 func TestTracingWithOverrides(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -1251,7 +1264,7 @@ func TestTraceBlockWithBasefee(t *testing.T) {
 			t.Errorf("test %d, want no error, have %v", i, err)
 			continue
 		}
-		have, _ := json.Marshal(result)
+		have, _ := json.Marshal(collectStream(t, result))
 		want := tc.want
 		if string(have) != want {
 			t.Errorf("test %d, result mismatch\nhave: %v\nwant: %v\n", i, string(have), want)
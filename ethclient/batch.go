@@ -0,0 +1,233 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// errBatchPending is returned by results of batches not yet executed.
+	errBatchPending = errors.New("batch not executed")
+
+	// errBatchExecuted is returned when executing a batch a second time.
+	errBatchExecuted = errors.New("batch already executed")
+)
+
+// Batch collects requests to send to the node in a single round trip. Requests
+// are added through the typed methods, each returning a handle to its result,
+// which becomes available once the batch is executed:
+//
+//	batch := client.Batch()
+//	balance := batch.BalanceAt(account, nil)
+//	head := batch.HeaderByNumber(nil)
+//	if err := batch.Execute(ctx); err != nil {
+//		return err // The batch failed as a whole
+//	}
+//	value, err := balance.Result() // Errors of the individual requests
+//
+// A Batch is not safe for concurrent use.
+type Batch struct {
+	client   *Client
+	elems    []rpc.BatchElem
+	results  []func(err error) // Decode the results, or fail them with the given error
+	executed bool
+}
+
+// BatchResult is the result of a request of a batch.
+type BatchResult[T any] struct {
+	value T
+	err   error
+}
+
+// Result returns the result of the request, or the error it failed with.
+func (r *BatchResult[T]) Result() (T, error) {
+	return r.value, r.err
+}
+
+// Batch creates a new batch of requests.
+func (ec *Client) Batch() *Batch {
+	return &Batch{client: ec}
+}
+
+// Len returns the number of requests in the batch.
+func (b *Batch) Len() int {
+	return len(b.elems)
+}
+
+// Execute sends all requests of the batch. The returned error is only set if
+// the batch failed as a whole, the errors of the individual requests are
+// reported by their results.
+func (b *Batch) Execute(ctx context.Context) error {
+	if b.executed {
+		return errBatchExecuted
+	}
+	b.executed = true
+
+	if len(b.elems) == 0 {
+		return nil
+	}
+	err := b.client.c.BatchCallContext(ctx, b.elems)
+	for i, result := range b.results {
+		if err != nil {
+			result(err)
+		} else {
+			result(b.elems[i].Error)
+		}
+	}
+	return err
+}
+
+// addBatchCall adds a request to the batch, decoding its result as R and then
+// converting it to T.
+func addBatchCall[R, T any](b *Batch, convert func(R) (T, error), method string, args ...interface{}) *BatchResult[T] {
+	var (
+		raw    = new(R)
+		result = &BatchResult[T]{err: errBatchPending}
+	)
+	b.elems = append(b.elems, rpc.BatchElem{Method: method, Args: args, Result: raw})
+	b.results = append(b.results, func(err error) {
+		if err != nil {
+			result.err = err
+			return
+		}
+		result.value, result.err = convert(*raw)
+	})
+	return result
+}
+
+// convertBig converts a decoded big integer.
+func convertBig(v hexutil.Big) (*big.Int, error) {
+	return (*big.Int)(&v), nil
+}
+
+// convertUint64 converts a decoded integer.
+func convertUint64(v hexutil.Uint64) (uint64, error) {
+	return uint64(v), nil
+}
+
+// convertBytes converts decoded binary data.
+func convertBytes(v hexutil.Bytes) ([]byte, error) {
+	return v, nil
+}
+
+// convertFound converts a result which is null if not found into NotFound.
+func convertFound[T any](v *T) (*T, error) {
+	if v == nil {
+		return nil, ethereum.NotFound
+	}
+	return v, nil
+}
+
+// ChainID adds a request retrieving the chain ID to the batch.
+func (b *Batch) ChainID() *BatchResult[*big.Int] {
+	return addBatchCall(b, convertBig, "eth_chainId")
+}
+
+// BlockNumber adds a request retrieving the most recent block number to the batch.
+func (b *Batch) BlockNumber() *BatchResult[uint64] {
+	return addBatchCall(b, convertUint64, "eth_blockNumber")
+}
+
+// HeaderByHash adds a request retrieving the block header with the given hash to
+// the batch.
+func (b *Batch) HeaderByHash(hash common.Hash) *BatchResult[*types.Header] {
+	return addBatchCall(b, convertFound[types.Header], "eth_getBlockByHash", hash, false)
+}
+
+// HeaderByNumber adds a request retrieving a block header from the current
+// canonical chain to the batch. If number is nil, the latest known header is
+// retrieved.
+func (b *Batch) HeaderByNumber(number *big.Int) *BatchResult[*types.Header] {
+	return addBatchCall(b, convertFound[types.Header], "eth_getBlockByNumber", toBlockNumArg(number), false)
+}
+
+// TransactionReceipt adds a request retrieving the receipt of a transaction to
+// the batch.
+func (b *Batch) TransactionReceipt(txHash common.Hash) *BatchResult[*types.Receipt] {
+	return addBatchCall(b, convertFound[types.Receipt], "eth_getTransactionReceipt", txHash)
+}
+
+// BalanceAt adds a request retrieving the wei balance of an account to the batch.
+// The block number can be nil, in which case the balance is taken from the
+// latest known block.
+func (b *Batch) BalanceAt(account common.Address, blockNumber *big.Int) *BatchResult[*big.Int] {
+	return addBatchCall(b, convertBig, "eth_getBalance", account, toBlockNumArg(blockNumber))
+}
+
+// StorageAt adds a request retrieving the value of a key in the contract storage
+// of an account to the batch. The block number can be nil, in which case the
+// value is taken from the latest known block.
+func (b *Batch) StorageAt(account common.Address, key common.Hash, blockNumber *big.Int) *BatchResult[[]byte] {
+	return addBatchCall(b, convertBytes, "eth_getStorageAt", account, key, toBlockNumArg(blockNumber))
+}
+
+// CodeAt adds a request retrieving the contract code of an account to the batch.
+// The block number can be nil, in which case the code is taken from the latest
+// known block.
+func (b *Batch) CodeAt(account common.Address, blockNumber *big.Int) *BatchResult[[]byte] {
+	return addBatchCall(b, convertBytes, "eth_getCode", account, toBlockNumArg(blockNumber))
+}
+
+// NonceAt adds a request retrieving the nonce of an account to the batch. The
+// block number can be nil, in which case the nonce is taken from the latest
+// known block.
+func (b *Batch) NonceAt(account common.Address, blockNumber *big.Int) *BatchResult[uint64] {
+	return addBatchCall(b, convertUint64, "eth_getTransactionCount", account, toBlockNumArg(blockNumber))
+}
+
+// CallContract adds a message call, executed without creating a transaction, to
+// the batch. The block number can be nil, in which case the call is executed on
+// the latest known block.
+func (b *Batch) CallContract(msg ethereum.CallMsg, blockNumber *big.Int) *BatchResult[[]byte] {
+	return addBatchCall(b, convertBytes, "eth_call", toCallArg(msg), toBlockNumArg(blockNumber))
+}
+
+// EstimateGas adds a request estimating the gas needed to execute a transaction
+// to the batch.
+func (b *Batch) EstimateGas(msg ethereum.CallMsg) *BatchResult[uint64] {
+	return addBatchCall(b, convertUint64, "eth_estimateGas", toCallArg(msg))
+}
+
+// SuggestGasPrice adds a request retrieving the currently suggested gas price to
+// the batch.
+func (b *Batch) SuggestGasPrice() *BatchResult[*big.Int] {
+	return addBatchCall(b, convertBig, "eth_gasPrice")
+}
+
+// SuggestGasTipCap adds a request retrieving the currently suggested gas tip cap
+// to the batch.
+func (b *Batch) SuggestGasTipCap() *BatchResult[*big.Int] {
+	return addBatchCall(b, convertBig, "eth_maxPriorityFeePerGas")
+}
+
+// FilterLogs adds a filter query to the batch.
+func (b *Batch) FilterLogs(q ethereum.FilterQuery) *BatchResult[[]types.Log] {
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return &BatchResult[[]types.Log]{err: err} // Invalid query, not sent
+	}
+	return addBatchCall(b, func(logs []types.Log) ([]types.Log, error) { return logs, nil }, "eth_getLogs", arg)
+}
@@ -0,0 +1,214 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	cacheHitMeter  = metrics.NewRegisteredMeter("ethclient/cache/hit", nil)
+	cacheMissMeter = metrics.NewRegisteredMeter("ethclient/cache/miss", nil)
+)
+
+// cacheBlockArgs is the position of the block argument of the cacheable methods
+// whose responses are immutable for blocks referenced by hash or finalized.
+var cacheBlockArgs = map[string]int{
+	"eth_getBlockByNumber":                    0,
+	"eth_getBlockByHash":                      0,
+	"eth_getBlockReceipts":                    0,
+	"eth_getBlockTransactionCountByNumber":    0,
+	"eth_getBlockTransactionCountByHash":      0,
+	"eth_getTransactionByBlockNumberAndIndex": 0,
+	"eth_getTransactionByBlockHashAndIndex":   0,
+	"eth_getBalance":                          1,
+	"eth_getCode":                             1,
+	"eth_getTransactionCount":                 1,
+	"eth_call":                                1,
+	"eth_getStorageAt":                        2,
+}
+
+// cacheTxMethods are the cacheable methods whose responses are immutable once
+// the block of the transaction is finalized.
+var cacheTxMethods = map[string]bool{
+	"eth_getTransactionByHash":  true,
+	"eth_getTransactionReceipt": true,
+}
+
+// CacheConfig configures the response cache.
+type CacheConfig struct {
+	MaxEntries int           // Maximum number of cached responses (0 = 4096)
+	TTL        time.Duration // Time after which cached responses expire (0 = never)
+
+	// FinalizedRefresh is the interval to look up the finalized block at, which
+	// decides whether responses about blocks referenced by number are immutable.
+	// 0 = 12 seconds.
+	FinalizedRefresh time.Duration
+}
+
+// CacheMiddleware returns a middleware caching the responses of requests about
+// immutable chain data: blocks referenced by hash, and blocks, receipts and
+// state of finalized blocks. Other requests, batches and subscriptions are
+// passed through.
+//
+//	client = client.WithMiddleware(ethclient.CacheMiddleware(ethclient.CacheConfig{}))
+//
+// Responses are cached for all clients using the returned middleware.
+func CacheMiddleware(config CacheConfig) Middleware {
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 4096
+	}
+	if config.FinalizedRefresh == 0 {
+		config.FinalizedRefresh = 12 * time.Second
+	}
+	c := &responseCache{
+		config:  config,
+		entries: lru.NewCache[string, cacheEntry](config.MaxEntries),
+	}
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, req *Request) error {
+			return c.invoke(ctx, req, next)
+		}
+	}
+}
+
+// cacheEntry is a cached response.
+type cacheEntry struct {
+	response json.RawMessage
+	expires  time.Time // Zero if it never expires
+}
+
+// responseCache caches the responses of immutable data.
+type responseCache struct {
+	config  CacheConfig
+	entries *lru.Cache[string, cacheEntry]
+
+	lock      sync.Mutex
+	finalized uint64    // Number of the finalized block
+	checked   time.Time // Time the finalized block was last looked up at
+}
+
+func (c *responseCache) invoke(ctx context.Context, req *Request, next Invoker) error {
+	blockArg, blockMethod := cacheBlockArgs[req.Method]
+	if req.Batch != nil || (!blockMethod && !cacheTxMethods[req.Method]) {
+		return next(ctx, req)
+	}
+	enc, err := json.Marshal(req.Args)
+	if err != nil {
+		return next(ctx, req)
+	}
+	key := req.Method + string(enc)
+
+	if entry, ok := c.entries.Get(key); ok {
+		if entry.expires.IsZero() || time.Now().Before(entry.expires) {
+			cacheHitMeter.Mark(1)
+			if req.Result == nil {
+				return nil
+			}
+			return json.Unmarshal(entry.response, req.Result)
+		}
+		c.entries.Remove(key)
+	}
+	cacheMissMeter.Mark(1)
+
+	// References to mutable blocks can be detected before sending the request
+	if blockMethod {
+		if blockArg >= len(req.Args) || !c.immutableBlock(ctx, req.Args[blockArg], next) {
+			return next(ctx, req)
+		}
+	}
+	var response json.RawMessage
+	if err := next(ctx, &Request{Method: req.Method, Args: req.Args, Result: &response}); err != nil {
+		return err
+	}
+	if req.Result != nil {
+		if err := json.Unmarshal(response, req.Result); err != nil {
+			return err
+		}
+	}
+	// Missing data might be available later, and transactions are immutable
+	// only once finalized
+	if len(response) == 0 || string(response) == "null" {
+		return nil
+	}
+	if !blockMethod {
+		var tx struct {
+			BlockNumber *hexutil.Uint64 `json:"blockNumber"`
+		}
+		if json.Unmarshal(response, &tx) != nil || tx.BlockNumber == nil || uint64(*tx.BlockNumber) > c.finalizedBlock(ctx, next) {
+			return nil
+		}
+	}
+	entry := cacheEntry{response: response}
+	if c.config.TTL > 0 {
+		entry.expires = time.Now().Add(c.config.TTL)
+	}
+	c.entries.Add(key, entry)
+	return nil
+}
+
+// immutableBlock reports whether a block argument references a block by hash,
+// or a finalized block by number.
+func (c *responseCache) immutableBlock(ctx context.Context, arg interface{}, next Invoker) bool {
+	enc, err := json.Marshal(arg)
+	if err != nil {
+		return false
+	}
+	var block rpc.BlockNumberOrHash
+	if err := json.Unmarshal(enc, &block); err != nil {
+		return false
+	}
+	if _, ok := block.Hash(); ok {
+		return !block.RequireCanonical // Canonical blocks might get reorged
+	}
+	number, ok := block.Number()
+	if !ok || number < 0 {
+		return false // Block tags
+	}
+	return uint64(number) <= c.finalizedBlock(ctx, next)
+}
+
+// finalizedBlock returns the number of the finalized block, looking it up if
+// the last lookup is outdated. Zero is returned if it can't be looked up.
+func (c *responseCache) finalizedBlock(ctx context.Context, next Invoker) uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if time.Since(c.checked) < c.config.FinalizedRefresh {
+		return c.finalized
+	}
+	var head *struct {
+		Number hexutil.Uint64 `json:"number"`
+	}
+	err := next(ctx, &Request{Method: "eth_getBlockByNumber", Args: []interface{}{"finalized", false}, Result: &head})
+	if err != nil {
+		return c.finalized // Retry on the next request
+	}
+	if head != nil {
+		c.finalized = uint64(head.Number)
+	}
+	c.checked = time.Now()
+	return c.finalized
+}
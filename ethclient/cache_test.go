@@ -0,0 +1,166 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// cacheService is an eth namespace counting the requests served, with blocks up
+// to 50 finalized.
+type cacheService struct {
+	lock  sync.Mutex
+	calls map[string]int
+}
+
+func (s *cacheService) count(method string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.calls[method]++
+}
+
+func (s *cacheService) served(method string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.calls[method]
+}
+
+func (s *cacheService) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	if number == rpc.FinalizedBlockNumber {
+		return &types.Header{Number: big.NewInt(50), Difficulty: new(big.Int)}
+	}
+	s.count("eth_getBlockByNumber")
+	if number > 100 {
+		return nil
+	}
+	return &types.Header{Number: big.NewInt(number.Int64()), Difficulty: new(big.Int)}
+}
+
+func (s *cacheService) GetBalance(account common.Address, block rpc.BlockNumberOrHash) *hexutil.Big {
+	s.count("eth_getBalance")
+	return (*hexutil.Big)(big.NewInt(42))
+}
+
+func (s *cacheService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	s.count("eth_getTransactionReceipt")
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		Logs:        []*types.Log{},
+		TxHash:      hash,
+		BlockNumber: new(big.Int).SetBytes(hash[:1]), // Block number is the first byte
+	}
+}
+
+func TestCacheMiddleware(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	service := &cacheService{calls: make(map[string]int)}
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	base := ethclient.NewClient(rpc.DialInProc(srv))
+	defer base.Close()
+	client := base.WithMiddleware(ethclient.CacheMiddleware(ethclient.CacheConfig{}))
+
+	ctx := context.Background()
+	for i, tt := range []struct {
+		method string
+		call   func() error
+		served int // Number of requests served out of three
+	}{
+		// Finalized data must be cached
+		{"eth_getBlockByNumber", func() error { _, err := client.HeaderByNumber(ctx, big.NewInt(10)); return err }, 1},
+		{"eth_getBalance", func() error { _, err := client.BalanceAt(ctx, common.Address{}, big.NewInt(10)); return err }, 1},
+		{"eth_getTransactionReceipt", func() error { _, err := client.TransactionReceipt(ctx, common.Hash{10}); return err }, 1},
+		// Data of blocks not yet finalized must not be cached
+		{"eth_getBlockByNumber", func() error { _, err := client.HeaderByNumber(ctx, big.NewInt(60)); return err }, 3},
+		{"eth_getBalance", func() error { _, err := client.BalanceAt(ctx, common.Address{}, nil); return err }, 3},
+		{"eth_getTransactionReceipt", func() error { _, err := client.TransactionReceipt(ctx, common.Hash{60}); return err }, 3},
+	} {
+		before := service.served(tt.method)
+		for j := 0; j < 3; j++ {
+			if err := tt.call(); err != nil {
+				t.Fatalf("test %d: request %d failed: %v", i, j, err)
+			}
+		}
+		if served := service.served(tt.method) - before; served != tt.served {
+			t.Errorf("test %d: %s: served requests mismatch: have %d, want %d", i, tt.method, served, tt.served)
+		}
+	}
+	// Cached responses must be decoded like fresh ones, missing data must not be cached
+	header, err := client.HeaderByNumber(ctx, big.NewInt(10))
+	if err != nil || header.Number.Uint64() != 10 {
+		t.Errorf("cached header mismatch: have %v (err %v), want number 10", header, err)
+	}
+	if _, err := client.HeaderByNumber(ctx, big.NewInt(200)); err == nil {
+		t.Error("expected error for missing header")
+	}
+	before := service.served("eth_getBlockByNumber")
+	client.HeaderByNumber(ctx, big.NewInt(200))
+	if service.served("eth_getBlockByNumber") == before {
+		t.Error("missing header was cached")
+	}
+}
+
+func TestCacheMiddlewareLimits(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	service := &cacheService{calls: make(map[string]int)}
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	base := ethclient.NewClient(rpc.DialInProc(srv))
+	defer base.Close()
+	client := base.WithMiddleware(ethclient.CacheMiddleware(ethclient.CacheConfig{MaxEntries: 2, TTL: 100 * time.Millisecond}))
+
+	ctx := context.Background()
+	header := func(n int64) {
+		t.Helper()
+		if _, err := client.HeaderByNumber(ctx, big.NewInt(n)); err != nil {
+			t.Fatalf("failed to retrieve header %d: %v", n, err)
+		}
+	}
+	// The least recently used entries must be evicted
+	header(1)
+	header(2)
+	header(3)
+	header(1)
+	if served := service.served("eth_getBlockByNumber"); served != 4 {
+		t.Errorf("served requests mismatch: have %d, want 4", served)
+	}
+	// Entries must expire
+	header(3)
+	time.Sleep(150 * time.Millisecond)
+	header(3)
+	if served := service.served("eth_getBlockByNumber"); served != 5 {
+		t.Errorf("served requests mismatch: have %d, want 5", served)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrTxDropped is returned by SendAndConfirm if the nonce of the transaction
+// was used by another transaction.
+var ErrTxDropped = errors.New("transaction dropped, nonce used by another transaction")
+
+// TxStatus is the status of a transaction tracked by SendAndConfirm.
+type TxStatus int
+
+const (
+	TxStatusSent        TxStatus = iota // The transaction was sent
+	TxStatusRebroadcast                 // The transaction was sent again
+	TxStatusReplaced                    // A replacement paying higher fees was sent
+	TxStatusIncluded                    // The transaction was included in a block
+	TxStatusReorged                     // The block including the transaction was reorged out
+	TxStatusConfirmed                   // The transaction reached the required confirmations
+	TxStatusDropped                     // The nonce of the transaction was used by another one
+)
+
+// String implements fmt.Stringer.
+func (s TxStatus) String() string {
+	switch s {
+	case TxStatusSent:
+		return "sent"
+	case TxStatusRebroadcast:
+		return "rebroadcast"
+	case TxStatusReplaced:
+		return "replaced"
+	case TxStatusIncluded:
+		return "included"
+	case TxStatusReorged:
+		return "reorged"
+	case TxStatusConfirmed:
+		return "confirmed"
+	case TxStatusDropped:
+		return "dropped"
+	default:
+		return fmt.Sprintf("TxStatus(%d)", int(s))
+	}
+}
+
+// TxEvent reports a change of the status of a transaction tracked by
+// SendAndConfirm.
+type TxEvent struct {
+	Status        TxStatus
+	Tx            *types.Transaction // The latest version of the transaction, or the included one
+	Receipt       *types.Receipt     // Receipt of the included transaction, if included
+	Confirmations uint64             // Number of blocks on top of the inclusion, counting it
+}
+
+// ConfirmOpts configures SendAndConfirm.
+type ConfirmOpts struct {
+	Confirmations uint64        // Number of blocks to wait for, counting the inclusion (0 = 1)
+	PollInterval  time.Duration // Interval to check the status of the transaction at (0 = 1 second)
+
+	// BumpAfter is the time to wait for the inclusion before rebroadcasting the
+	// transaction, or replacing it if Sign is set. 0 = never.
+	BumpAfter time.Duration
+
+	// Sign signs replacements of the transaction paying higher fees. If nil,
+	// the transaction is rebroadcast instead.
+	Sign func(*types.Transaction) (*types.Transaction, error)
+
+	BumpPercent uint64   // Fee increase of replacements in percent, at least 10 (0 = 12)
+	MaxFeeCap   *big.Int // Maximum fee cap or gas price of replacements (nil = unlimited)
+
+	// OnEvent is called when the status of the transaction changes. It may be nil.
+	OnEvent func(TxEvent)
+}
+
+// SendAndConfirm sends a signed transaction and waits until it is included with
+// the configured number of confirmations, returning its receipt.
+//
+// While waiting, the transaction is rebroadcast or replaced by a copy paying
+// higher fees whenever it isn't included in time. If the block including the
+// transaction is reorged out, the transaction is waited for again. The receipt
+// of whichever version of the transaction got included is returned, and
+// ErrTxDropped if another transaction with the same nonce got included instead.
+func (ec *Client) SendAndConfirm(ctx context.Context, tx *types.Transaction, opts ConfirmOpts) (*types.Receipt, error) {
+	if opts.Confirmations == 0 {
+		opts.Confirmations = 1
+	}
+	if opts.PollInterval == 0 {
+		opts.PollInterval = time.Second
+	}
+	if opts.BumpPercent == 0 {
+		opts.BumpPercent = 12
+	}
+	opts.BumpPercent = max(opts.BumpPercent, 10)
+
+	emit := func(ev TxEvent) {
+		if opts.OnEvent != nil {
+			opts.OnEvent(ev)
+		}
+	}
+	// Dropped transactions are detected by the nonce of their sender
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ec.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	emit(TxEvent{Status: TxStatusSent, Tx: tx})
+
+	var (
+		sent     = []*types.Transaction{tx}
+		lastSent = time.Now()
+		bumpable = opts.Sign != nil
+		included common.Hash // Hash of the block including the transaction
+		logger   = log.New("from", sender, "nonce", tx.Nonce())
+	)
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		// Check the nonce first, so inclusions seen by it are seen by the receipts
+		nonce, err := ec.NonceAt(ctx, sender, nil)
+		if err != nil {
+			logger.Debug("Failed to retrieve nonce", "err", err)
+			continue
+		}
+		var receipt *types.Receipt
+		for _, version := range sent {
+			if receipt, err = ec.TransactionReceipt(ctx, version.Hash()); !errors.Is(err, ethereum.NotFound) {
+				break
+			}
+		}
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			logger.Debug("Failed to retrieve receipt", "err", err)
+			continue
+		}
+		if receipt != nil {
+			for _, version := range sent {
+				if version.Hash() == receipt.TxHash {
+					tx = version
+				}
+			}
+			if receipt.BlockHash != included {
+				included = receipt.BlockHash
+				emit(TxEvent{Status: TxStatusIncluded, Tx: tx, Receipt: receipt, Confirmations: 1})
+			}
+			head, err := ec.BlockNumber(ctx)
+			if err != nil {
+				logger.Debug("Failed to retrieve head", "err", err)
+				continue
+			}
+			if number := receipt.BlockNumber.Uint64(); head >= number && head-number+1 >= opts.Confirmations {
+				emit(TxEvent{Status: TxStatusConfirmed, Tx: tx, Receipt: receipt, Confirmations: head - number + 1})
+				return receipt, nil
+			}
+			continue
+		}
+		if included != (common.Hash{}) {
+			// The inclusion was reorged out, make sure the node still has it
+			logger.Debug("Transaction reorged out", "hash", tx.Hash(), "block", included)
+			included = common.Hash{}
+			emit(TxEvent{Status: TxStatusReorged, Tx: tx})
+
+			if err := ec.SendTransaction(ctx, tx); err != nil {
+				logger.Debug("Failed to rebroadcast transaction", "hash", tx.Hash(), "err", err)
+			}
+			lastSent = time.Now()
+			continue
+		}
+		if nonce > tx.Nonce() {
+			emit(TxEvent{Status: TxStatusDropped, Tx: tx})
+			return nil, ErrTxDropped
+		}
+		if opts.BumpAfter == 0 || time.Since(lastSent) < opts.BumpAfter {
+			continue
+		}
+		lastSent = time.Now()
+
+		if bumpable {
+			replacement, err := bind.BumpFees(tx, opts.BumpPercent, opts.MaxFeeCap)
+			if err == nil {
+				replacement, err = opts.Sign(replacement)
+			}
+			if err == nil {
+				// The transaction might have been included meanwhile, so don't
+				// fail on the rejection of the replacement.
+				if err := ec.SendTransaction(ctx, replacement); err != nil {
+					logger.Debug("Replacement transaction rejected", "hash", replacement.Hash(), "err", err)
+					continue
+				}
+				logger.Debug("Replaced stalling transaction", "old", tx.Hash(), "new", replacement.Hash())
+				tx, sent = replacement, append(sent, replacement)
+				emit(TxEvent{Status: TxStatusReplaced, Tx: tx})
+				continue
+			}
+			if !errors.Is(err, bind.ErrBumpLimit) {
+				return nil, err
+			}
+			logger.Debug("Transaction fees reached the limit", "hash", tx.Hash())
+			bumpable = false
+		}
+		if err := ec.SendTransaction(ctx, tx); err != nil {
+			logger.Debug("Failed to rebroadcast transaction", "hash", tx.Hash(), "err", err)
+		}
+		emit(TxEvent{Status: TxStatusRebroadcast, Tx: tx})
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// poolService is an eth namespace emulating the inclusion of transactions. The
+// head advances whenever it is requested, and the test decides which of the
+// transactions sent get included.
+type poolService struct {
+	lock     sync.Mutex
+	head     uint64
+	nonce    uint64
+	sent     []*types.Transaction
+	included map[common.Hash]uint64 // Transactions included, and their blocks
+	reorg    bool                   // Reorg out the first inclusion once its receipt is served
+	onSend   func(s *poolService, tx *types.Transaction)
+}
+
+func (s *poolService) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sent = append(s.sent, tx)
+	if s.onSend != nil {
+		s.onSend(s, tx)
+	}
+	return tx.Hash(), nil
+}
+
+func (s *poolService) BlockNumber() hexutil.Uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.head++
+	return hexutil.Uint64(s.head)
+}
+
+func (s *poolService) GetTransactionCount(account common.Address, block rpc.BlockNumberOrHash) hexutil.Uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return hexutil.Uint64(s.nonce)
+}
+
+func (s *poolService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	number, ok := s.included[hash]
+	if !ok {
+		return nil
+	}
+	if s.reorg {
+		s.reorg = false
+		delete(s.included, hash)
+		s.nonce--
+	}
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		Logs:        []*types.Log{},
+		TxHash:      hash,
+		BlockNumber: new(big.Int).SetUint64(number),
+		BlockHash:   common.BigToHash(new(big.Int).SetUint64(number)),
+	}
+}
+
+// include includes a transaction in the next block.
+func (s *poolService) include(tx *types.Transaction) {
+	s.included[tx.Hash()] = s.head + 1
+	s.nonce = tx.Nonce() + 1
+}
+
+func testSendAndConfirm(t *testing.T, service *poolService, tx *types.Transaction, opts ethclient.ConfirmOpts) (*types.Receipt, []ethclient.TxStatus, error) {
+	t.Helper()
+
+	service.included = make(map[common.Hash]uint64)
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	client := ethclient.NewClient(rpc.DialInProc(srv))
+	defer client.Close()
+
+	var events []ethclient.TxStatus
+	opts.PollInterval = 5 * time.Millisecond
+	opts.OnEvent = func(ev ethclient.TxEvent) { events = append(events, ev.Status) }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := client.SendAndConfirm(ctx, tx, opts)
+	return receipt, events, err
+}
+
+func newConfirmTx(t *testing.T) (*types.Transaction, func(*types.Transaction) (*types.Transaction, error)) {
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	sign := func(tx *types.Transaction) (*types.Transaction, error) {
+		return types.SignTx(tx, signer, testKey)
+	}
+	tx, err := sign(types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     5,
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		Gas:       params.TxGas,
+		To:        &common.Address{0x01},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx, sign
+}
+
+func TestSendAndConfirm(t *testing.T) {
+	t.Parallel()
+
+	tx, _ := newConfirmTx(t)
+	service := &poolService{onSend: func(s *poolService, tx *types.Transaction) { s.include(tx) }}
+
+	receipt, events, err := testSendAndConfirm(t, service, tx, ethclient.ConfirmOpts{Confirmations: 3})
+	if err != nil {
+		t.Fatalf("failed to confirm: %v", err)
+	}
+	if receipt.TxHash != tx.Hash() {
+		t.Errorf("receipt mismatch: have tx %x, want %x", receipt.TxHash, tx.Hash())
+	}
+	if head, included := service.head, receipt.BlockNumber.Uint64(); head-included+1 < 3 {
+		t.Errorf("confirmed too early: head %d, included in %d", head, included)
+	}
+	want := []ethclient.TxStatus{ethclient.TxStatusSent, ethclient.TxStatusIncluded, ethclient.TxStatusConfirmed}
+	if !slices.Equal(events, want) {
+		t.Errorf("events mismatch: have %v, want %v", events, want)
+	}
+}
+
+func TestSendAndConfirmReplacement(t *testing.T) {
+	t.Parallel()
+
+	// Only include transactions paying a tip of at least 120
+	tx, sign := newConfirmTx(t)
+	service := &poolService{onSend: func(s *poolService, tx *types.Transaction) {
+		if tx.GasTipCap().Uint64() >= 120 {
+			s.include(tx)
+		}
+	}}
+	receipt, events, err := testSendAndConfirm(t, service, tx, ethclient.ConfirmOpts{BumpAfter: 10 * time.Millisecond, Sign: sign})
+	if err != nil {
+		t.Fatalf("failed to confirm: %v", err)
+	}
+	if len(service.sent) != 3 {
+		t.Fatalf("sent transactions mismatch: have %d, want 3", len(service.sent))
+	}
+	if replacement := service.sent[2]; receipt.TxHash != replacement.Hash() || replacement.Nonce() != tx.Nonce() {
+		t.Errorf("receipt mismatch: have tx %x, want replacement %x", receipt.TxHash, replacement.Hash())
+	}
+	if tip, feeCap := service.sent[1].GasTipCap(), service.sent[1].GasFeeCap(); tip.Uint64() != 112 || feeCap.Uint64() != 1120 {
+		t.Errorf("bumped fees mismatch: have tip %v and fee cap %v, want 112 and 1120", tip, feeCap)
+	}
+	want := []ethclient.TxStatus{ethclient.TxStatusSent, ethclient.TxStatusReplaced, ethclient.TxStatusReplaced, ethclient.TxStatusIncluded, ethclient.TxStatusConfirmed}
+	if !slices.Equal(events, want) {
+		t.Errorf("events mismatch: have %v, want %v", events, want)
+	}
+}
+
+func TestSendAndConfirmRebroadcast(t *testing.T) {
+	t.Parallel()
+
+	// Once the fee limit stops replacements, the last replacement must be
+	// rebroadcast instead
+	tx, sign := newConfirmTx(t)
+	service := &poolService{onSend: func(s *poolService, tx *types.Transaction) {
+		if len(s.sent) == 3 {
+			s.include(tx)
+		}
+	}}
+	receipt, events, err := testSendAndConfirm(t, service, tx, ethclient.ConfirmOpts{
+		BumpAfter: 10 * time.Millisecond,
+		Sign:      sign,
+		MaxFeeCap: big.NewInt(1200),
+	})
+	if err != nil {
+		t.Fatalf("failed to confirm: %v", err)
+	}
+	if receipt.TxHash != service.sent[1].Hash() {
+		t.Errorf("receipt mismatch: have tx %x, want replacement %x", receipt.TxHash, service.sent[1].Hash())
+	}
+	want := []ethclient.TxStatus{ethclient.TxStatusSent, ethclient.TxStatusReplaced, ethclient.TxStatusRebroadcast, ethclient.TxStatusIncluded, ethclient.TxStatusConfirmed}
+	if !slices.Equal(events, want) {
+		t.Errorf("events mismatch: have %v, want %v", events, want)
+	}
+}
+
+func TestSendAndConfirmReorg(t *testing.T) {
+	t.Parallel()
+
+	// Include the transaction, reorg it out right away, and include it again
+	// once rebroadcast
+	tx, _ := newConfirmTx(t)
+	service := &poolService{reorg: true, onSend: func(s *poolService, tx *types.Transaction) { s.include(tx) }}
+
+	_, events, err := testSendAndConfirm(t, service, tx, ethclient.ConfirmOpts{Confirmations: 3})
+	if err != nil {
+		t.Fatalf("failed to confirm: %v", err)
+	}
+	want := []ethclient.TxStatus{ethclient.TxStatusSent, ethclient.TxStatusIncluded, ethclient.TxStatusReorged, ethclient.TxStatusIncluded, ethclient.TxStatusConfirmed}
+	if !slices.Equal(events, want) {
+		t.Errorf("events mismatch: have %v, want %v", events, want)
+	}
+}
+
+func TestSendAndConfirmDropped(t *testing.T) {
+	t.Parallel()
+
+	// Use the nonce of the transaction by another one
+	tx, _ := newConfirmTx(t)
+	service := &poolService{onSend: func(s *poolService, tx *types.Transaction) { s.nonce = tx.Nonce() + 1 }}
+
+	_, events, err := testSendAndConfirm(t, service, tx, ethclient.ConfirmOpts{})
+	if !errors.Is(err, ethclient.ErrTxDropped) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ethclient.ErrTxDropped)
+	}
+	want := []ethclient.TxStatus{ethclient.TxStatusSent, ethclient.TxStatusDropped}
+	if !slices.Equal(events, want) {
+		t.Errorf("events mismatch: have %v, want %v", events, want)
+	}
+}
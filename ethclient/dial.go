@@ -0,0 +1,88 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/gorilla/websocket"
+)
+
+// DialConfig configures the connection made by DialOptions.
+type DialConfig struct {
+	// Header is added to HTTP requests, and to the handshake of websocket
+	// connections.
+	Header http.Header
+
+	// Proxy returns the proxy to use for a request, like http.Transport.Proxy.
+	// nil means the proxy set in the environment.
+	Proxy func(*http.Request) (*url.URL, error)
+
+	PingInterval     time.Duration // Idle time after which websocket connections are pinged (0 = 30 seconds)
+	PongTimeout      time.Duration // Time to wait for websocket pongs before dropping the connection (0 = 30 seconds)
+	MessageSizeLimit int64         // Maximum size of websocket messages received (0 = 32MB, negative = unlimited)
+
+	// Options are additional options of the RPC client, applied last.
+	Options []rpc.ClientOption
+}
+
+// ClientOptions returns the RPC client options implementing the configuration,
+// e.g. for FailoverOptions.DialOptions.
+func (c DialConfig) ClientOptions() []rpc.ClientOption {
+	var opts []rpc.ClientOption
+	if c.Header != nil {
+		opts = append(opts, rpc.WithHeaders(c.Header))
+	}
+	if c.Proxy != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = c.Proxy
+		opts = append(opts,
+			rpc.WithHTTPClient(&http.Client{Transport: transport}),
+			rpc.WithWebsocketDialer(websocket.Dialer{
+				ReadBufferSize:  1024,
+				WriteBufferSize: 1024,
+				Proxy:           c.Proxy,
+			}),
+		)
+	}
+	if c.PingInterval > 0 {
+		opts = append(opts, rpc.WithWebsocketPingInterval(c.PingInterval))
+	}
+	if c.PongTimeout > 0 {
+		opts = append(opts, rpc.WithWebsocketPongTimeout(c.PongTimeout))
+	}
+	switch {
+	case c.MessageSizeLimit > 0:
+		opts = append(opts, rpc.WithWebsocketMessageSizeLimit(c.MessageSizeLimit))
+	case c.MessageSizeLimit < 0:
+		opts = append(opts, rpc.WithWebsocketMessageSizeLimit(0))
+	}
+	return append(opts, c.Options...)
+}
+
+// DialOptions connects a client to the given URL with the given configuration.
+func DialOptions(ctx context.Context, rawurl string, config DialConfig) (*Client, error) {
+	c, err := rpc.DialOptions(ctx, rawurl, config.ClientOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
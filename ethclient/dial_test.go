@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func TestDialOptions(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	if err := srv.RegisterName("eth", new(chainService)); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		ws    = srv.WebsocketHandler([]string{"*"})
+		authz atomic.Int32 // Number of requests with the configured header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer secret" {
+			authz.Add(1)
+		}
+		if r.Header.Get("Upgrade") == "websocket" {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		srv.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	for _, rawurl := range []string{server.URL, "ws" + strings.TrimPrefix(server.URL, "http")} {
+		var proxied atomic.Int32
+		config := ethclient.DialConfig{
+			Header: http.Header{"Authorization": []string{"Bearer secret"}},
+			Proxy: func(*http.Request) (*url.URL, error) {
+				proxied.Add(1)
+				return nil, nil // Connect directly
+			},
+			PingInterval:     time.Second,
+			PongTimeout:      time.Second,
+			MessageSizeLimit: -1,
+		}
+		before := authz.Load()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		client, err := ethclient.DialOptions(ctx, rawurl, config)
+		if err != nil {
+			cancel()
+			t.Fatalf("%s: failed to dial: %v", rawurl, err)
+		}
+		if _, err := client.BlockNumber(ctx); err != nil {
+			t.Errorf("%s: request failed: %v", rawurl, err)
+		}
+		client.Close()
+		cancel()
+
+		if authz.Load() == before {
+			t.Errorf("%s: header not sent", rawurl)
+		}
+		if proxied.Load() == 0 {
+			t.Errorf("%s: proxy not used", rawurl)
+		}
+	}
+}
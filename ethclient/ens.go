@@ -0,0 +1,326 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/net/idna"
+)
+
+// ENSRegistryAddress is the address of the ENS registry on mainnet and the
+// public testnets.
+var ENSRegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+var (
+	// ErrENSNotFound is returned if an ENS name or the name of an address isn't
+	// registered, or has no resolver.
+	ErrENSNotFound = errors.New("ens name not found")
+
+	errENSDisabled = errors.New("ens resolution not enabled")
+)
+
+// ensProfile maps names as specified by UTS-46, without converting them to
+// punycode. The ASCII rules are checked by ENSNormalize, as names may contain
+// underscores.
+var ensProfile = idna.New(idna.MapForLookup(), idna.Transitional(false), idna.StrictDomainName(false))
+
+// Selectors of the ENS registry and resolver methods.
+var (
+	ensResolverSelector = crypto.Keccak256([]byte("resolver(bytes32)"))[:4]
+	ensAddrSelector     = crypto.Keccak256([]byte("addr(bytes32)"))[:4]
+	ensNameSelector     = crypto.Keccak256([]byte("name(bytes32)"))[:4]
+)
+
+// ensNameOutputs are the outputs of the name method of resolvers.
+var ensNameOutputs = abi.Arguments{{Type: abi.Type{T: abi.StringTy}}}
+
+// ENSConfig configures the resolution of ENS names.
+type ENSConfig struct {
+	Registry  common.Address // Address of the ENS registry (zero = ENSRegistryAddress)
+	CacheSize int            // Maximum number of cached resolutions (0 = 1024)
+	CacheTTL  time.Duration  // Time after which cached resolutions expire (0 = 5 minutes)
+}
+
+// ensResolver resolves ENS names, caching the results.
+type ensResolver struct {
+	config ENSConfig
+	cache  *lru.Cache[string, ensEntry]
+}
+
+// ensEntry is a cached resolution.
+type ensEntry struct {
+	addr    common.Address
+	name    string
+	expires time.Time
+}
+
+// WithENS returns a client sharing the connection of ec, with the resolution of
+// ENS names enabled. Names are resolved at the latest block through the
+// registry and resolver contracts. Wildcard resolution and offchain lookups
+// aren't supported.
+func (ec *Client) WithENS(config ENSConfig) *Client {
+	if config.Registry == (common.Address{}) {
+		config.Registry = ENSRegistryAddress
+	}
+	if config.CacheSize == 0 {
+		config.CacheSize = 1024
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = 5 * time.Minute
+	}
+	return &Client{c: ec.c, ens: &ensResolver{
+		config: config,
+		cache:  lru.NewCache[string, ensEntry](config.CacheSize),
+	}}
+}
+
+// ResolveName returns the address of an ENS name, e.g. "vitalik.eth". Hex
+// addresses are returned as is, so the result can be passed to all methods
+// accepting addresses:
+//
+//	account, err := client.ResolveName(ctx, nameOrAddress)
+//	if err != nil {
+//		return err
+//	}
+//	balance, err := client.BalanceAt(ctx, account, nil)
+//
+// ENS resolution must be enabled with WithENS. Names are normalized with
+// ENSNormalize before being resolved.
+func (ec *Client) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	if common.IsHexAddress(name) {
+		return common.HexToAddress(name), nil
+	}
+	if ec.ens == nil {
+		return common.Address{}, errENSDisabled
+	}
+	name, err := ENSNormalize(name)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if entry, ok := ec.ens.cached("name:" + name); ok {
+		return entry.addr, nil
+	}
+	node, err := ENSNameHash(name)
+	if err != nil {
+		return common.Address{}, err
+	}
+	resolver, err := ec.ensResolverOf(ctx, node)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("%w: %s", err, name)
+	}
+	out, err := ec.CallContract(ctx, ethereum.CallMsg{To: &resolver, Data: ensCallData(ensAddrSelector, node)}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(out) < 32 || common.BytesToAddress(out[:32]) == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%w: %s", ErrENSNotFound, name)
+	}
+	addr := common.BytesToAddress(out[:32])
+	ec.ens.add("name:"+name, ensEntry{addr: addr})
+	return addr, nil
+}
+
+// BalanceAtName returns the wei balance of an ENS name or hex address. The
+// block number can be nil, in which case the balance is taken from the latest
+// known block.
+func (ec *Client) BalanceAtName(ctx context.Context, name string, blockNumber *big.Int) (*big.Int, error) {
+	account, err := ec.ResolveName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return ec.BalanceAt(ctx, account, blockNumber)
+}
+
+// NewTransaction builds an unsigned EIP-1559 transaction sending value and data
+// from an account to an ENS name or hex address. The nonce is taken from the
+// pending state of the account, the gas limit is estimated and the fee cap
+// leaves room for the base fee to double on top of the suggested tip.
+func (ec *Client) NewTransaction(ctx context.Context, from common.Address, to string, value *big.Int, data []byte) (*types.Transaction, error) {
+	recipient, err := ec.ResolveName(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := ec.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := ec.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	head, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("no base fee in head, pre-London chain?")
+	}
+	tip, err := ec.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		value = new(big.Int)
+	}
+	gas, err := ec.EstimateGas(ctx, ethereum.CallMsg{From: from, To: &recipient, GasTipCap: tip, Value: value, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2))),
+		Gas:       gas,
+		To:        &recipient,
+		Value:     value,
+		Data:      data,
+	}), nil
+}
+
+// LookupAddress returns the primary ENS name of an address, set through its
+// reverse record. The name is only returned if it resolves back to the address.
+// ENS resolution must be enabled with WithENS.
+func (ec *Client) LookupAddress(ctx context.Context, addr common.Address) (string, error) {
+	if ec.ens == nil {
+		return "", errENSDisabled
+	}
+	key := "addr:" + addr.Hex()
+	if entry, ok := ec.ens.cached(key); ok {
+		return entry.name, nil
+	}
+	node, err := ENSNameHash(strings.ToLower(addr.Hex()[2:]) + ".addr.reverse")
+	if err != nil {
+		return "", err
+	}
+	resolver, err := ec.ensResolverOf(ctx, node)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, addr.Hex())
+	}
+	out, err := ec.CallContract(ctx, ethereum.CallMsg{To: &resolver, Data: ensCallData(ensNameSelector, node)}, nil)
+	if err != nil {
+		return "", err
+	}
+	values, err := ensNameOutputs.Unpack(out)
+	if err != nil {
+		return "", err
+	}
+	name := values[0].(string)
+	if name == "" {
+		return "", fmt.Errorf("%w: %s", ErrENSNotFound, addr.Hex())
+	}
+	// Anyone can claim any name in their reverse record, verify it
+	if resolved, err := ec.ResolveName(ctx, name); err != nil || resolved != addr {
+		return "", fmt.Errorf("%w: %s (name %q doesn't resolve to it)", ErrENSNotFound, addr.Hex(), name)
+	}
+	ec.ens.add(key, ensEntry{name: name})
+	return name, nil
+}
+
+// ensResolverOf returns the resolver of a node from the registry.
+func (ec *Client) ensResolverOf(ctx context.Context, node common.Hash) (common.Address, error) {
+	registry := ec.ens.config.Registry
+	out, err := ec.CallContract(ctx, ethereum.CallMsg{To: &registry, Data: ensCallData(ensResolverSelector, node)}, nil)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if len(out) < 32 || common.BytesToAddress(out[:32]) == (common.Address{}) {
+		return common.Address{}, ErrENSNotFound
+	}
+	return common.BytesToAddress(out[:32]), nil
+}
+
+// ensCallData encodes a call of a registry or resolver method taking a node.
+func ensCallData(selector []byte, node common.Hash) []byte {
+	return append(append(make([]byte, 0, 36), selector...), node[:]...)
+}
+
+// cached returns a resolution from the cache, unless it expired.
+func (r *ensResolver) cached(key string) (ensEntry, bool) {
+	entry, ok := r.cache.Get(key)
+	if !ok {
+		return ensEntry{}, false
+	}
+	if time.Now().After(entry.expires) {
+		r.cache.Remove(key)
+		return ensEntry{}, false
+	}
+	return entry, true
+}
+
+// add caches a resolution.
+func (r *ensResolver) add(key string, entry ensEntry) {
+	entry.expires = time.Now().Add(r.config.CacheTTL)
+	r.cache.Add(key, entry)
+}
+
+// ENSNormalize normalizes an ENS name: it is mapped as specified by UTS-46
+// (nontransitional, so e.g. "ß" is kept) and validated, with the ASCII rules of
+// ENSIP-15 allowing underscores at the start of a label and rejecting punycode
+// labels. The emoji and confusable checks of ENSIP-15 aren't applied.
+func ENSNormalize(name string) (string, error) {
+	for _, label := range strings.Split(name, ".") {
+		if len(label) >= 4 && label[2:4] == "--" {
+			return "", fmt.Errorf("invalid ens name %q: label %q has hyphens at positions 3 and 4", name, label)
+		}
+	}
+	normalized, err := ensProfile.ToUnicode(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid ens name %q: %v", name, err)
+	}
+	for _, label := range strings.Split(normalized, ".") {
+		if label == "" {
+			return "", fmt.Errorf("invalid ens name %q: empty label", name)
+		}
+		body := strings.TrimLeft(label, "_")
+		for _, c := range body {
+			if c < 0x80 && c != '-' && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+				return "", fmt.Errorf("invalid ens name %q: disallowed character %q", name, c)
+			}
+		}
+	}
+	return normalized, nil
+}
+
+// ENSNameHash returns the node of an ENS name, which identifies it in the
+// registry and resolver contracts. The name must already be normalized.
+func ENSNameHash(name string) (common.Hash, error) {
+	var node common.Hash
+	if name == "" {
+		return node, nil
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		if labels[i] == "" {
+			return common.Hash{}, fmt.Errorf("invalid ens name %q: empty label", name)
+		}
+		node = crypto.Keccak256Hash(node[:], crypto.Keccak256([]byte(labels[i])))
+	}
+	return node, nil
+}
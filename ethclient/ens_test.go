@@ -0,0 +1,240 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	ensResolver = common.Address{0xee}
+	ensAlice    = common.Address{0xa1}
+	ensBob      = common.Address{0xb0}
+)
+
+// ensService is an eth namespace emulating the ENS registry and a resolver
+// through eth_call.
+type ensService struct {
+	calls atomic.Int32
+	addrs map[common.Hash]common.Address // Forward records by node
+	names map[common.Hash]string         // Reverse records by node
+}
+
+func newENSService(t *testing.T) *ensService {
+	s := &ensService{addrs: make(map[common.Hash]common.Address), names: make(map[common.Hash]string)}
+	node := func(name string) common.Hash {
+		t.Helper()
+		node, err := ethclient.ENSNameHash(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return node
+	}
+	reverse := func(addr common.Address) string {
+		return strings.ToLower(addr.Hex()[2:]) + ".addr.reverse"
+	}
+	// Alice's reverse record is valid, Bob claims Alice's name
+	s.addrs[node("alice.eth")] = ensAlice
+	s.names[node(reverse(ensAlice))] = "alice.eth"
+	s.names[node(reverse(ensBob))] = "alice.eth"
+	return s
+}
+
+func (s *ensService) Call(args map[string]interface{}, block rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	s.calls.Add(1)
+	input, err := hexutil.Decode(args["input"].(string))
+	if err != nil || len(input) != 36 {
+		return nil, errors.New("invalid input")
+	}
+	var (
+		to       = common.HexToAddress(args["to"].(string))
+		selector = string(input[:4])
+		node     = common.BytesToHash(input[4:])
+	)
+	switch {
+	case to == ethclient.ENSRegistryAddress && selector == string(crypto.Keccak256([]byte("resolver(bytes32)"))[:4]):
+		if _, ok := s.addrs[node]; !ok && s.names[node] == "" {
+			return make([]byte, 32), nil
+		}
+		return common.LeftPadBytes(ensResolver[:], 32), nil
+	case to == ensResolver && selector == string(crypto.Keccak256([]byte("addr(bytes32)"))[:4]):
+		addr := s.addrs[node]
+		return common.LeftPadBytes(addr[:], 32), nil
+	case to == ensResolver && selector == string(crypto.Keccak256([]byte("name(bytes32)"))[:4]):
+		name := s.names[node]
+		out := append(common.LeftPadBytes(big.NewInt(32).Bytes(), 32), common.LeftPadBytes(big.NewInt(int64(len(name))).Bytes(), 32)...)
+		return append(out, common.RightPadBytes([]byte(name), (len(name)+31)/32*32)...), nil
+	}
+	return nil, errors.New("execution reverted")
+}
+
+func (s *ensService) GetBalance(addr common.Address, block rpc.BlockNumberOrHash) *hexutil.Big {
+	if addr == ensAlice {
+		return (*hexutil.Big)(big.NewInt(1e18))
+	}
+	return new(hexutil.Big)
+}
+
+func (s *ensService) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1337))
+}
+
+func (s *ensService) GetTransactionCount(addr common.Address, block rpc.BlockNumberOrHash) hexutil.Uint64 {
+	return 7
+}
+
+func (s *ensService) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (json.RawMessage, error) {
+	return json.Marshal(&types.Header{Number: big.NewInt(1), Difficulty: new(big.Int), BaseFee: big.NewInt(100)})
+}
+
+func (s *ensService) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(2))
+}
+
+func (s *ensService) EstimateGas(args map[string]interface{}, block *rpc.BlockNumberOrHash) hexutil.Uint64 {
+	return 21000
+}
+
+func TestENSNormalize(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name string
+		want string
+	}{
+		{"alice.eth", "alice.eth"},
+		{"Alice.ETH", "alice.eth"},
+		{"Ａｌｉｃｅ.eth", "alice.eth"},
+		{"faß.eth", "faß.eth"},
+		{"_alice.eth", "_alice.eth"},
+		{"al-ice.eth", "al-ice.eth"},
+	} {
+		have, err := ethclient.ENSNormalize(tt.name)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.name, err)
+		}
+		if have != tt.want {
+			t.Errorf("%q: normalization mismatch: have %q, want %q", tt.name, have, tt.want)
+		}
+	}
+	for _, name := range []string{"al ice.eth", "al_ice.eth", "alice..eth", "ab--c.eth", "xn--fa-hia.eth", "-alice.eth", "alice!.eth"} {
+		if have, err := ethclient.ENSNormalize(name); err == nil {
+			t.Errorf("%q: expected error, have %q", name, have)
+		}
+	}
+}
+
+func TestENSNameHash(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		name string
+		want common.Hash
+	}{
+		{"", common.Hash{}},
+		{"eth", common.HexToHash("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae")},
+		{"foo.eth", common.HexToHash("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f")},
+	} {
+		have, err := ethclient.ENSNameHash(tt.name)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.name, err)
+		}
+		if have != tt.want {
+			t.Errorf("%q: namehash mismatch: have %x, want %x", tt.name, have, tt.want)
+		}
+	}
+	if _, err := ethclient.ENSNameHash("foo..eth"); err == nil {
+		t.Error("expected error for empty label")
+	}
+}
+
+func TestENS(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	service := newENSService(t)
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	base := ethclient.NewClient(rpc.DialInProc(srv))
+	defer base.Close()
+	ctx := context.Background()
+
+	// Addresses must always be accepted, names only if enabled
+	if addr, err := base.ResolveName(ctx, ensAlice.Hex()); err != nil || addr != ensAlice {
+		t.Errorf("address resolution mismatch: have %v (err %v), want %v", addr, err, ensAlice)
+	}
+	if _, err := base.ResolveName(ctx, "alice.eth"); err == nil {
+		t.Error("expected error without ENS enabled")
+	}
+	client := base.WithENS(ethclient.ENSConfig{})
+
+	// Names must be resolved once, and cached
+	for i := 0; i < 3; i++ {
+		addr, err := client.ResolveName(ctx, "Alice.eth")
+		if err != nil || addr != ensAlice {
+			t.Fatalf("name resolution mismatch: have %v (err %v), want %v", addr, err, ensAlice)
+		}
+	}
+	if calls := service.calls.Load(); calls != 2 {
+		t.Errorf("calls mismatch: have %d, want 2", calls)
+	}
+	if _, err := client.ResolveName(ctx, "bob.eth"); !errors.Is(err, ethclient.ErrENSNotFound) {
+		t.Errorf("error mismatch: have %v, want %v", err, ethclient.ErrENSNotFound)
+	}
+	// Methods accepting names must resolve them
+	if balance, err := client.BalanceAtName(ctx, "ALICE.eth", nil); err != nil || balance.Cmp(big.NewInt(1e18)) != 0 {
+		t.Errorf("balance mismatch: have %v (err %v), want 1e18", balance, err)
+	}
+	tx, err := client.NewTransaction(ctx, ensBob, "alice.eth", big.NewInt(1), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if to := tx.To(); to == nil || *to != ensAlice {
+		t.Errorf("recipient mismatch: have %v, want %v", to, ensAlice)
+	}
+	if tx.ChainId().Uint64() != 1337 || tx.Nonce() != 7 || tx.Gas() != 21000 || tx.GasTipCap().Uint64() != 2 || tx.GasFeeCap().Uint64() != 202 {
+		t.Errorf("transaction fields mismatch: chain %v, nonce %d, gas %d, tip %v, fee cap %v", tx.ChainId(), tx.Nonce(), tx.Gas(), tx.GasTipCap(), tx.GasFeeCap())
+	}
+	if _, err := client.NewTransaction(ctx, ensAlice, "bob.eth", nil, nil); !errors.Is(err, ethclient.ErrENSNotFound) {
+		t.Errorf("error mismatch: have %v, want %v", err, ethclient.ErrENSNotFound)
+	}
+	// Reverse records must resolve back to the address
+	if name, err := client.LookupAddress(ctx, ensAlice); err != nil || name != "alice.eth" {
+		t.Errorf("reverse resolution mismatch: have %q (err %v), want alice.eth", name, err)
+	}
+	if _, err := client.LookupAddress(ctx, ensBob); !errors.Is(err, ethclient.ErrENSNotFound) {
+		t.Errorf("error mismatch: have %v, want %v", err, ethclient.ErrENSNotFound)
+	}
+	if _, err := client.LookupAddress(ctx, common.Address{0xcc}); !errors.Is(err, ethclient.ErrENSNotFound) {
+		t.Errorf("error mismatch: have %v, want %v", err, ethclient.ErrENSNotFound)
+	}
+}
@@ -23,9 +23,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -34,7 +37,19 @@ import (
 
 // Client defines typed wrappers for the Ethereum RPC API.
 type Client struct {
-	c *rpc.Client
+	c   rpcClient
+	ens *ensResolver // Resolver of ENS names, nil unless enabled
+
+	noBlockReceipts atomic.Bool // Set if the node doesn't serve eth_getBlockReceipts
+}
+
+// rpcClient is the RPC connection used by Client, either a plain *rpc.Client,
+// or a client spreading requests over multiple endpoints.
+type rpcClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+	EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error)
+	Close()
 }
 
 // Dial connects a client to the given URL.
@@ -53,7 +68,7 @@ func DialContext(ctx context.Context, rawurl string) (*Client, error) {
 
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) *Client {
-	return &Client{c}
+	return &Client{c: c}
 }
 
 // Close closes the underlying RPC connection.
@@ -61,9 +76,22 @@ func (ec *Client) Close() {
 	ec.c.Close()
 }
 
-// Client gets the underlying RPC client.
+// Client gets the underlying RPC client. For clients connected to multiple
+// endpoints, it returns the client of the currently preferred endpoint.
 func (ec *Client) Client() *rpc.Client {
-	return ec.c
+	return underlyingClient(ec.c)
+}
+
+// underlyingClient returns the RPC client below a connection, which may wrap
+// others.
+func underlyingClient(c rpcClient) *rpc.Client {
+	switch c := c.(type) {
+	case *rpc.Client:
+		return c
+	case interface{ rpcClient() *rpc.Client }:
+		return c.rpcClient()
+	}
+	return nil
 }
 
 // Blockchain Access
@@ -122,13 +150,75 @@ func (ec *Client) PeerCount(ctx context.Context) (uint64, error) {
 }
 
 // BlockReceipts returns the receipts of a given block number or hash.
+//
+// If the node doesn't support eth_getBlockReceipts, the receipts are fetched
+// one by one in a single batch instead.
 func (ec *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
-	var r []*types.Receipt
-	err := ec.c.CallContext(ctx, &r, "eth_getBlockReceipts", blockNrOrHash)
-	if err == nil && r == nil {
+	if !ec.noBlockReceipts.Load() {
+		var r []*types.Receipt
+		err := ec.c.CallContext(ctx, &r, "eth_getBlockReceipts", blockNrOrHash)
+		if !isMethodNotFound(err) {
+			if err == nil && r == nil {
+				return nil, ethereum.NotFound
+			}
+			return r, err
+		}
+		ec.noBlockReceipts.Store(true)
+	}
+	return ec.blockReceiptsByTx(ctx, blockNrOrHash)
+}
+
+// blockReceiptsByTx retrieves the receipts of a block by the hashes of its
+// transactions.
+func (ec *Client) blockReceiptsByTx(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	var block *struct {
+		Hash         common.Hash   `json:"hash"`
+		Transactions []common.Hash `json:"transactions"`
+	}
+	var err error
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		err = ec.c.CallContext(ctx, &block, "eth_getBlockByHash", hash, false)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		err = ec.c.CallContext(ctx, &block, "eth_getBlockByNumber", number, false)
+	} else {
+		return nil, errors.New("invalid block number or hash")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
 		return nil, ethereum.NotFound
 	}
-	return r, err
+	if len(block.Transactions) == 0 {
+		return []*types.Receipt{}, nil
+	}
+	var (
+		receipts = make([]*types.Receipt, len(block.Transactions))
+		reqs     = make([]rpc.BatchElem, len(block.Transactions))
+	)
+	for i, tx := range block.Transactions {
+		reqs[i] = rpc.BatchElem{Method: "eth_getTransactionReceipt", Args: []interface{}{tx}, Result: &receipts[i]}
+	}
+	if err := ec.c.BatchCallContext(ctx, reqs); err != nil {
+		return nil, err
+	}
+	for i := range reqs {
+		if reqs[i].Error != nil {
+			return nil, reqs[i].Error
+		}
+		// The block might have been reorged out meanwhile
+		if receipts[i] == nil || receipts[i].BlockHash != block.Hash {
+			return nil, ethereum.NotFound
+		}
+	}
+	return receipts, nil
+}
+
+// isMethodNotFound reports whether err is returned for methods the node doesn't
+// serve.
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601
 }
 
 type rpcBlock struct {
@@ -597,6 +687,36 @@ func (ec *Client) PendingCallContract(ctx context.Context, msg ethereum.CallMsg)
 	return hex, nil
 }
 
+// CreateAccessList creates an EIP-2930 access list for a message call executed on
+// the latest known block, and returns it along with the gas used by the call
+// with the access list included.
+//
+// If the execution of the call fails, the access list and the gas used up to
+// the failure are returned along with the error. The revert reason is decoded
+// for reverted calls, with the revert data available through rpc.DataError.
+func (ec *Client) CreateAccessList(ctx context.Context, msg ethereum.CallMsg) (types.AccessList, uint64, error) {
+	var result struct {
+		AccessList types.AccessList `json:"accessList"`
+		Error      string           `json:"error,omitempty"`
+		GasUsed    hexutil.Uint64   `json:"gasUsed"`
+	}
+	if err := ec.c.CallContext(ctx, &result, "eth_createAccessList", toCallArg(msg), "latest"); err != nil {
+		return nil, 0, err
+	}
+	if result.Error == "" {
+		return result.AccessList, uint64(result.GasUsed), nil
+	}
+	// The node doesn't report the revert data, which a plain call does
+	vmErr := errors.New(result.Error)
+	if strings.HasPrefix(result.Error, "execution reverted") {
+		msg.AccessList = result.AccessList
+		if _, err := ec.CallContract(ctx, msg, nil); err != nil {
+			vmErr = err
+		}
+	}
+	return result.AccessList, uint64(result.GasUsed), vmErr
+}
+
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (ec *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
@@ -916,13 +1036,41 @@ type simulateCallResultMarshaling struct {
 	Status      hexutil.Uint64
 }
 
-// CallError represents an error from a simulated call.
+// Err returns the error of the call, or nil if it succeeded.
+func (r *SimulateCallResult) Err() error {
+	if r.Error == nil {
+		return nil
+	}
+	return r.Error
+}
+
+// CallError represents an error from a simulated call. It implements
+// rpc.DataError, like the errors of reverted eth_call requests.
 type CallError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Data    string `json:"data,omitempty"`
 }
 
+// Error implements error.
+func (e *CallError) Error() string { return e.Message }
+
+// ErrorCode returns the JSON-RPC error code.
+func (e *CallError) ErrorCode() int { return e.Code }
+
+// ErrorData returns the hex encoded revert data, if any.
+func (e *CallError) ErrorData() interface{} { return e.Data }
+
+// RevertReason decodes the reason of a reverted call from its revert data,
+// either an Error(string) message or a Panic(uint256) code.
+func (e *CallError) RevertReason() (string, error) {
+	data, err := hexutil.Decode(e.Data)
+	if err != nil {
+		return "", fmt.Errorf("invalid revert data: %v", err)
+	}
+	return abi.UnpackRevert(data)
+}
+
 //go:generate go run github.com/fjl/gencodec -type SimulateBlockResult -field-override simulateBlockResultMarshaling -out gen_simulate_block_result.go
 
 // SimulateBlockResult represents the result of a simulated block.
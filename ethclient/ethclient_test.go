@@ -62,6 +62,8 @@ var (
 	testAddr           = crypto.PubkeyToAddress(testKey.PublicKey)
 	testBalance        = big.NewInt(2e15)
 	revertContractAddr = common.HexToAddress("290f1b36649a61e369c6276f6d29463335b4400c")
+	testSlot           = common.Hash{0x01}
+	testSlotValue      = common.Hash{31: 0x2a}
 	revertCode         = common.FromHex("7f08c379a0000000000000000000000000000000000000000000000000000000006000526020600452600a6024527f75736572206572726f7200000000000000000000000000000000000000000000604452604e6000fd")
 )
 
@@ -69,7 +71,7 @@ var genesis = &core.Genesis{
 	Config: params.AllDevChainProtocolChanges,
 	Alloc: types.GenesisAlloc{
 		testAddr:           {Balance: testBalance},
-		revertContractAddr: {Code: revertCode},
+		revertContractAddr: {Code: revertCode, Storage: map[common.Hash]common.Hash{testSlot: testSlotValue}},
 	},
 	ExtraData: []byte("test genesis"),
 	Timestamp: 9000,
@@ -189,6 +191,15 @@ func TestEthClient(t *testing.T) {
 		"TransactionSender": {
 			func(t *testing.T) { testTransactionSender(t, client) },
 		},
+		"Batch": {
+			func(t *testing.T) { testBatch(t, chain, client) },
+		},
+		"GetProof": {
+			func(t *testing.T) { testGetProof(t, client) },
+		},
+		"CreateAccessList": {
+			func(t *testing.T) { testCreateAccessList(t, client) },
+		},
 	}
 
 	t.Parallel()
@@ -687,6 +698,128 @@ func testTransactionSender(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func testBatch(t *testing.T, chain []*types.Block, client *rpc.Client) {
+	ec := ethclient.NewClient(client)
+	batch := ec.Batch()
+
+	var (
+		chainID  = batch.ChainID()
+		header   = batch.HeaderByNumber(big.NewInt(1))
+		byHash   = batch.HeaderByHash(chain[1].Hash())
+		balance  = batch.BalanceAt(testAddr, big.NewInt(0))
+		nonce    = batch.NonceAt(testAddr, big.NewInt(0))
+		receipt  = batch.TransactionReceipt(common.Hash{0x01})
+		reverted = batch.CallContract(ethereum.CallMsg{From: testAddr, To: &revertContractAddr}, nil)
+		filter   = batch.FilterLogs(ethereum.FilterQuery{BlockHash: &common.Hash{}, FromBlock: big.NewInt(1)})
+	)
+	if _, err := chainID.Result(); err == nil {
+		t.Fatal("expected error before execution")
+	}
+	if batch.Len() != 7 {
+		t.Fatalf("wrong batch length: have %d, want 7", batch.Len())
+	}
+	if err := batch.Execute(context.Background()); err != nil {
+		t.Fatalf("failed to execute batch: %v", err)
+	}
+	if err := batch.Execute(context.Background()); err == nil {
+		t.Fatal("expected error executing batch twice")
+	}
+	// Successful requests must return their typed results
+	if id, err := chainID.Result(); err != nil || id.Cmp(genesis.Config.ChainID) != 0 {
+		t.Errorf("ChainID: have %v (err %v), want %v", id, err, genesis.Config.ChainID)
+	}
+	if h, err := header.Result(); err != nil || h.Hash() != chain[1].Hash() {
+		t.Errorf("HeaderByNumber: have %v (err %v), want %v", h, err, chain[1].Header())
+	}
+	if h, err := byHash.Result(); err != nil || h.Number.Uint64() != 1 {
+		t.Errorf("HeaderByHash: have %v (err %v), want number 1", h, err)
+	}
+	if b, err := balance.Result(); err != nil || b.Cmp(testBalance) != 0 {
+		t.Errorf("BalanceAt: have %v (err %v), want %v", b, err, testBalance)
+	}
+	if n, err := nonce.Result(); err != nil || n != 0 {
+		t.Errorf("NonceAt: have %d (err %v), want 0", n, err)
+	}
+	// Failed requests must report their own errors
+	if _, err := receipt.Result(); err != ethereum.NotFound {
+		t.Errorf("TransactionReceipt: have error %v, want %v", err, ethereum.NotFound)
+	}
+	if _, err := reverted.Result(); err == nil || err.Error() != "execution reverted: user error" {
+		t.Errorf("CallContract: have error %v, want execution reverted", err)
+	}
+	if _, err := filter.Result(); err == nil {
+		t.Error("FilterLogs: expected error for invalid query")
+	}
+}
+
+func testGetProof(t *testing.T, client *rpc.Client) {
+	ec := ethclient.NewClient(client)
+	ctx := context.Background()
+
+	head, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Existing accounts and slots must be proven
+	proof, err := ec.GetProof(ctx, revertContractAddr, []common.Hash{testSlot, {0x02}}, head.Number)
+	if err != nil {
+		t.Fatalf("failed to retrieve proof: %v", err)
+	}
+	if proof.StorageProof[0].Value.Uint64() != 0x2a || proof.StorageProof[1].Value.Sign() != 0 {
+		t.Fatalf("slot value mismatch: have %v and %v, want 42 and 0", proof.StorageProof[0].Value, proof.StorageProof[1].Value)
+	}
+	if err := proof.VerifyProof(head.Root); err != nil {
+		t.Fatalf("failed to verify proof: %v", err)
+	}
+	// Missing accounts must be proven absent
+	missing, err := ec.GetProof(ctx, common.Address{0xff}, nil, head.Number)
+	if err != nil {
+		t.Fatalf("failed to retrieve proof: %v", err)
+	}
+	if err := missing.VerifyProof(head.Root); err != nil {
+		t.Fatalf("failed to verify absence proof: %v", err)
+	}
+	// Tampered states must be refused
+	proof.StorageProof[0].Value = big.NewInt(0x2b)
+	if err := proof.VerifyProof(head.Root); err == nil {
+		t.Error("expected error for tampered slot value")
+	}
+	proof.StorageProof[0].Value = big.NewInt(0x2a)
+	proof.Nonce++
+	if err := proof.VerifyProof(head.Root); err == nil {
+		t.Error("expected error for tampered nonce")
+	}
+	proof.Nonce--
+	if err := proof.VerifyProof(common.Hash{0x01}); err == nil {
+		t.Error("expected error for wrong state root")
+	}
+}
+
+func testCreateAccessList(t *testing.T, client *rpc.Client) {
+	ec := ethclient.NewClient(client)
+
+	// Plain transfers don't access any state worth listing
+	to := common.Address{0x01, 0x02}
+	list, gas, err := ec.CreateAccessList(context.Background(), ethereum.CallMsg{From: testAddr, To: &to, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("failed to create access list: %v", err)
+	}
+	if len(list) != 0 || gas != params.TxGas {
+		t.Errorf("transfer access list mismatch: have %v with gas %d, want empty with gas %d", list, gas, params.TxGas)
+	}
+	// Reverts must be reported with their reason, along with the access list
+	list, gas, err = ec.CreateAccessList(context.Background(), ethereum.CallMsg{From: testAddr, To: &revertContractAddr})
+	if err == nil || err.Error() != "execution reverted: user error" {
+		t.Fatalf("revert error mismatch: have %v, want execution reverted: user error", err)
+	}
+	if _, ok := err.(rpc.DataError); !ok {
+		t.Errorf("revert error without data: %T", err)
+	}
+	if list == nil || gas <= params.TxGas {
+		t.Errorf("reverted access list mismatch: have %v with gas %d", list, gas)
+	}
+}
+
 func TestBlockReceiptsPreservesCanonicalFlag(t *testing.T) {
 	srv := rpc.NewServer()
 	service := &blockReceiptsTestService{calls: make(chan rpc.BlockNumberOrHash, 1)}
@@ -730,6 +863,76 @@ func (s *blockReceiptsTestService) GetBlockReceipts(ctx context.Context, block r
 	return []*types.Receipt{}, nil
 }
 
+func TestBlockReceiptsFallback(t *testing.T) {
+	srv := rpc.NewServer()
+	service := &txReceiptsTestService{
+		block: common.Hash{0xbb},
+		txs:   []common.Hash{{0x01}, {0x02}, {0x03}},
+	}
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatalf("failed to register service: %v", err)
+	}
+	defer srv.Stop()
+
+	ec := ethclient.NewClient(rpc.DialInProc(srv))
+	defer ec.Close()
+
+	// Receipts must be fetched per transaction without eth_getBlockReceipts
+	for _, ref := range []rpc.BlockNumberOrHash{
+		rpc.BlockNumberOrHashWithNumber(1),
+		rpc.BlockNumberOrHashWithHash(service.block, false),
+	} {
+		receipts, err := ec.BlockReceipts(context.Background(), ref)
+		if err != nil {
+			t.Fatalf("BlockReceipts returned error: %v", err)
+		}
+		if len(receipts) != len(service.txs) {
+			t.Fatalf("receipt count mismatch: have %d, want %d", len(receipts), len(service.txs))
+		}
+		for i, receipt := range receipts {
+			if receipt.TxHash != service.txs[i] {
+				t.Errorf("receipt %d: tx hash mismatch: have %x, want %x", i, receipt.TxHash, service.txs[i])
+			}
+		}
+	}
+	// Missing blocks must be reported as such
+	if _, err := ec.BlockReceipts(context.Background(), rpc.BlockNumberOrHashWithNumber(2)); err != ethereum.NotFound {
+		t.Errorf("missing block: have error %v, want %v", err, ethereum.NotFound)
+	}
+}
+
+type txReceiptsTestService struct {
+	block common.Hash
+	txs   []common.Hash
+}
+
+func (s *txReceiptsTestService) result() map[string]interface{} {
+	return map[string]interface{}{"hash": s.block, "transactions": s.txs}
+}
+
+func (s *txReceiptsTestService) GetBlockByNumber(number rpc.BlockNumber, full bool) map[string]interface{} {
+	if number != 1 {
+		return nil
+	}
+	return s.result()
+}
+
+func (s *txReceiptsTestService) GetBlockByHash(hash common.Hash, full bool) map[string]interface{} {
+	if hash != s.block {
+		return nil
+	}
+	return s.result()
+}
+
+func (s *txReceiptsTestService) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	return &types.Receipt{
+		Status:    types.ReceiptStatusSuccessful,
+		Logs:      []*types.Log{},
+		TxHash:    hash,
+		BlockHash: s.block,
+	}
+}
+
 func newCanceledContext() context.Context {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -925,6 +1128,45 @@ func TestSimulateV1WithBlockOverrides(t *testing.T) {
 	}
 }
 
+func TestSimulateV1Revert(t *testing.T) {
+	backend, _, err := newTestBackend(nil)
+	if err != nil {
+		t.Fatalf("Failed to create test backend: %v", err)
+	}
+	defer backend.Close()
+
+	client := ethclient.NewClient(backend.Attach())
+	defer client.Close()
+
+	opts := ethclient.SimulateOptions{
+		BlockStateCalls: []ethclient.SimulateBlock{{
+			Calls: []ethereum.CallMsg{{From: testAddr, To: &revertContractAddr, Gas: 100000}},
+		}},
+	}
+	results, err := client.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatalf("SimulateV1 failed: %v", err)
+	}
+	if len(results) != 1 || len(results[0].Calls) != 1 {
+		t.Fatalf("unexpected results: %v", results)
+	}
+	call := results[0].Calls[0]
+	if call.Status != types.ReceiptStatusFailed {
+		t.Errorf("expected status 0 (failure), got %d", call.Status)
+	}
+	var dataErr rpc.DataError
+	if err := call.Err(); !errors.As(err, &dataErr) {
+		t.Fatalf("expected error with data, got %v", err)
+	}
+	reason, err := call.Error.RevertReason()
+	if err != nil {
+		t.Fatalf("failed to decode revert reason: %v", err)
+	}
+	if reason != "user error" {
+		t.Errorf("revert reason mismatch: have %q, want %q", reason, "user error")
+	}
+}
+
 func TestSimulateV1WithStateOverrides(t *testing.T) {
 	backend, _, err := newTestBackend(nil)
 	if err != nil {
@@ -0,0 +1,457 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval of the health probes
+	// of failover endpoints.
+	DefaultHealthCheckInterval = 15 * time.Second
+
+	// DefaultHealthCheckTimeout is the default time allowed for a health probe.
+	DefaultHealthCheckTimeout = 5 * time.Second
+)
+
+// errNoEndpoints is returned when creating a failover client without endpoints.
+var errNoEndpoints = errors.New("no endpoints")
+
+// FailoverOptions configures a failover client.
+type FailoverOptions struct {
+	// LoadBalance spreads requests over all healthy endpoints in turn. If not
+	// set, requests go to the first healthy endpoint in the order given, the
+	// others only serving as backups.
+	LoadBalance bool
+
+	// HealthCheckInterval is the interval of the health probes, which mark the
+	// endpoints healthy again after failures. DefaultHealthCheckInterval if zero.
+	HealthCheckInterval time.Duration
+
+	// HealthCheckTimeout is the time allowed for a health probe, including the
+	// redial of endpoints not connected. DefaultHealthCheckTimeout if zero.
+	HealthCheckTimeout time.Duration
+
+	// MaxBlockLag is the number of blocks an endpoint may lag behind the most
+	// advanced one before it's considered unhealthy. Zero disables the check.
+	MaxBlockLag uint64
+
+	// DialOptions are passed on to the RPC client of each endpoint.
+	DialOptions []rpc.ClientOption
+}
+
+// EndpointStatus reports the health and metrics of a failover endpoint.
+type EndpointStatus struct {
+	URL         string        // Address of the endpoint
+	Healthy     bool          // Whether the endpoint is eligible for requests
+	BlockNumber uint64        // Head block number seen by the last health probe
+	Requests    uint64        // Number of requests sent to the endpoint
+	Failures    uint64        // Number of requests failed over to other endpoints
+	Latency     time.Duration // Mean latency of the requests
+	LastError   error         // Error of the last failure, or nil
+}
+
+// endpoint is a single RPC endpoint of a failover client.
+type endpoint struct {
+	url   string
+	label string // Name of the endpoint in metrics, free of credentials
+
+	lock      sync.Mutex
+	client    *rpc.Client // Nil if not connected
+	healthy   bool
+	number    uint64
+	requests  uint64
+	failures  uint64
+	latency   time.Duration // Total latency of the requests
+	lastError error
+
+	requestMeter *metrics.Meter
+	failureMeter *metrics.Meter
+	latencyTimer *metrics.Timer
+}
+
+// newEndpoint creates an endpoint, registering its metrics.
+func newEndpoint(rawurl string) *endpoint {
+	label := "invalid"
+	if u, err := url.Parse(rawurl); err == nil {
+		label = u.Host
+		if label == "" {
+			label = u.Scheme // IPC endpoints have no host
+		}
+	}
+	prefix := "ethclient/failover/" + label + "/"
+	return &endpoint{
+		url:          rawurl,
+		label:        label,
+		requestMeter: metrics.GetOrRegisterMeter(prefix+"requests", nil),
+		failureMeter: metrics.GetOrRegisterMeter(prefix+"failures", nil),
+		latencyTimer: metrics.GetOrRegisterTimer(prefix+"latency", nil),
+	}
+}
+
+// conn returns the RPC client of the endpoint, nil if it's not connected.
+func (e *endpoint) conn() *rpc.Client {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.client
+}
+
+// isHealthy returns whether the endpoint is connected and healthy.
+func (e *endpoint) isHealthy() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.client != nil && e.healthy
+}
+
+// dial connects the endpoint if it's not connected.
+func (e *endpoint) dial(ctx context.Context, opts []rpc.ClientOption) (*rpc.Client, error) {
+	if c := e.conn(); c != nil {
+		return c, nil
+	}
+	c, err := rpc.DialOptions(ctx, e.url, opts...)
+	if err != nil {
+		return nil, err
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.client != nil { // Connected concurrently
+		c.Close()
+		return e.client, nil
+	}
+	e.client = c
+	return c, nil
+}
+
+// record accounts a request sent to the endpoint, marking the endpoint unhealthy
+// if it failed.
+func (e *endpoint) record(elapsed time.Duration, err error) {
+	e.requestMeter.Mark(1)
+	e.latencyTimer.Update(elapsed)
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.requests++
+	e.latency += elapsed
+	if err != nil {
+		e.failureMeter.Mark(1)
+		e.failures++
+		e.lastError = err
+		if e.healthy {
+			log.Warn("RPC endpoint failed, failing over", "endpoint", e.label, "err", err)
+		}
+		e.healthy = false
+	}
+}
+
+// status reports the health and metrics of the endpoint.
+func (e *endpoint) status() EndpointStatus {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	status := EndpointStatus{
+		URL:         e.url,
+		Healthy:     e.client != nil && e.healthy,
+		BlockNumber: e.number,
+		Requests:    e.requests,
+		Failures:    e.failures,
+		LastError:   e.lastError,
+	}
+	if e.requests > 0 {
+		status.Latency = e.latency / time.Duration(e.requests)
+	}
+	return status
+}
+
+// failover spreads the requests of a client over multiple endpoints, failing
+// over to the next endpoint if one is unreachable.
+type failover struct {
+	endpoints []*endpoint
+	opts      FailoverOptions
+	next      atomic.Uint64 // Counter rotating the endpoints if load balancing
+
+	closeOnce sync.Once
+	quit      chan struct{}
+	done      chan struct{}
+}
+
+// FailoverClient is a client spreading requests over multiple RPC endpoints. It
+// fails over to the next endpoint if one is unreachable, and keeps probing the
+// health of all endpoints in the background.
+//
+// Errors returned by the endpoints themselves, such as execution reverts, are
+// passed on to the caller as is, since other endpoints would answer the same.
+// Subscriptions are sticky: they remain with the endpoint they were created on,
+// and end with an error if that endpoint fails.
+type FailoverClient struct {
+	*Client
+	f *failover
+}
+
+// NewFailoverClient creates a client spreading requests over the endpoints at
+// the given URLs. Endpoints which can't be connected yet are retried by the
+// health probes, but at least one must be reachable.
+func NewFailoverClient(urls []string, opts FailoverOptions) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, errNoEndpoints
+	}
+	if opts.HealthCheckInterval == 0 {
+		opts.HealthCheckInterval = DefaultHealthCheckInterval
+	}
+	if opts.HealthCheckTimeout == 0 {
+		opts.HealthCheckTimeout = DefaultHealthCheckTimeout
+	}
+	f := &failover{
+		opts: opts,
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	for _, rawurl := range urls {
+		f.endpoints = append(f.endpoints, newEndpoint(rawurl))
+	}
+	// Connect and probe the endpoints, failing if none is reachable
+	f.probe()
+	if f.rpcClient() == nil {
+		return nil, f.endpoints[0].status().LastError
+	}
+	go f.loop()
+	return &FailoverClient{Client: &Client{c: f}, f: f}, nil
+}
+
+// Endpoints reports the health and metrics of the endpoints.
+func (fc *FailoverClient) Endpoints() []EndpointStatus {
+	statuses := make([]EndpointStatus, len(fc.f.endpoints))
+	for i, e := range fc.f.endpoints {
+		statuses[i] = e.status()
+	}
+	return statuses
+}
+
+// loop probes the health of the endpoints until the client is closed.
+func (f *failover) loop() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.opts.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.probe()
+		case <-f.quit:
+			return
+		}
+	}
+}
+
+// probe checks the health of all endpoints concurrently, connecting the ones
+// not connected yet. Endpoints are healthy if they report their head block, and
+// don't lag too far behind the others.
+func (f *failover) probe() {
+	var (
+		numbers = make([]uint64, len(f.endpoints))
+		errs    = make([]error, len(f.endpoints))
+		wg      sync.WaitGroup
+	)
+	for i, e := range f.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), f.opts.HealthCheckTimeout)
+			defer cancel()
+
+			c, err := e.dial(ctx, f.opts.DialOptions)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			var number hexutil.Uint64
+			if errs[i] = c.CallContext(ctx, &number, "eth_blockNumber"); errs[i] == nil {
+				numbers[i] = uint64(number)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var best uint64
+	for i := range f.endpoints {
+		if errs[i] == nil {
+			best = max(best, numbers[i])
+		}
+	}
+	for i, e := range f.endpoints {
+		e.lock.Lock()
+		wasHealthy := e.healthy
+		switch {
+		case errs[i] != nil:
+			e.healthy = false
+			e.lastError = errs[i]
+		case f.opts.MaxBlockLag > 0 && numbers[i]+f.opts.MaxBlockLag < best:
+			e.healthy = false
+			e.number = numbers[i]
+		default:
+			e.healthy = true
+			e.number = numbers[i]
+		}
+		healthy := e.healthy
+		e.lock.Unlock()
+
+		if healthy && !wasHealthy {
+			log.Debug("RPC endpoint healthy", "endpoint", e.label, "number", numbers[i])
+		} else if !healthy && wasHealthy {
+			log.Warn("RPC endpoint unhealthy", "endpoint", e.label, "number", numbers[i], "best", best, "err", errs[i])
+		}
+	}
+}
+
+// order returns the endpoints in the order they should be tried: the healthy
+// ones first, rotated if load balancing, then the unhealthy ones as a last
+// resort.
+func (f *failover) order() []*endpoint {
+	var healthy, unhealthy []*endpoint
+	for _, e := range f.endpoints {
+		if e.isHealthy() {
+			healthy = append(healthy, e)
+		} else if e.conn() != nil {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+	if f.opts.LoadBalance && len(healthy) > 1 {
+		start := int(f.next.Add(1) % uint64(len(healthy)))
+		healthy = append(healthy[start:], healthy[:start]...)
+	}
+	return append(healthy, unhealthy...)
+}
+
+// shouldFailover reports whether a request failing with the given error should
+// be retried on another endpoint.
+func shouldFailover(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	// Errors returned by the server are answers, not failures of the endpoint
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// do sends a request to the endpoints in turn, until one answers.
+func (f *failover) do(ctx context.Context, fn func(c *rpc.Client) error) error {
+	err := errNoEndpoints
+	for _, e := range f.order() {
+		c := e.conn()
+		if c == nil {
+			continue
+		}
+		start := time.Now()
+		if err = fn(c); shouldFailover(ctx, err) {
+			e.record(time.Since(start), err)
+			continue
+		}
+		e.record(time.Since(start), nil)
+		return err
+	}
+	return err
+}
+
+// CallContext implements rpcClient, failing over between the endpoints.
+func (f *failover) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return f.do(ctx, func(c *rpc.Client) error {
+		return c.CallContext(ctx, result, method, args...)
+	})
+}
+
+// BatchCallContext implements rpcClient, sending the batch to a single endpoint,
+// failing over between the endpoints as a whole.
+func (f *failover) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.do(ctx, func(c *rpc.Client) error {
+		return c.BatchCallContext(ctx, b)
+	})
+}
+
+// EthSubscribe implements rpcClient, creating the subscription on the first
+// endpoint supporting subscriptions, where it sticks.
+func (f *failover) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error) {
+	var (
+		sub *rpc.ClientSubscription
+		err = errNoEndpoints
+	)
+	for _, e := range f.order() {
+		c := e.conn()
+		if c == nil {
+			continue
+		}
+		start := time.Now()
+		sub, err = c.EthSubscribe(ctx, channel, args...)
+		if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+			continue // HTTP endpoints can't serve subscriptions, no failure
+		}
+		if shouldFailover(ctx, err) {
+			e.record(time.Since(start), err)
+			continue
+		}
+		e.record(time.Since(start), nil)
+		return sub, err
+	}
+	return nil, err
+}
+
+// rpcClient returns the client of the preferred endpoint.
+func (f *failover) rpcClient() *rpc.Client {
+	if order := f.order(); len(order) > 0 {
+		return order[0].conn()
+	}
+	return nil
+}
+
+// Close implements rpcClient, stopping the health probes and disconnecting all
+// endpoints.
+func (f *failover) Close() {
+	f.closeOnce.Do(func() {
+		close(f.quit)
+		<-f.done
+		f.closeClients()
+	})
+}
+
+// closeClients disconnects all endpoints.
+func (f *failover) closeClients() {
+	for _, e := range f.endpoints {
+		e.lock.Lock()
+		if e.client != nil {
+			e.client.Close()
+			e.client = nil
+		}
+		e.lock.Unlock()
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// failoverService is the eth namespace of a failover test endpoint.
+type failoverService struct {
+	chainID uint64
+	number  atomic.Uint64
+	calls   atomic.Uint64
+}
+
+func (s *failoverService) ChainId() hexutil.Uint64 {
+	s.calls.Add(1)
+	return hexutil.Uint64(s.chainID)
+}
+
+func (s *failoverService) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(s.number.Load())
+}
+
+func (s *failoverService) GasPrice() (*hexutil.Big, error) {
+	s.calls.Add(1)
+	return nil, errors.New("gas price unavailable")
+}
+
+func (s *failoverService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+	go notifier.Notify(sub.ID, &types.Header{Number: big.NewInt(int64(s.number.Load())), Difficulty: new(big.Int)})
+	return sub, nil
+}
+
+// failoverEndpoint is an RPC endpoint which can be taken down.
+type failoverEndpoint struct {
+	*httptest.Server
+	service *failoverService
+	down    atomic.Bool
+}
+
+func newFailoverEndpoint(t *testing.T, chainID uint64, websocket bool) *failoverEndpoint {
+	t.Helper()
+
+	e := &failoverEndpoint{service: &failoverService{chainID: chainID}}
+	e.service.number.Store(100)
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", e.service); err != nil {
+		t.Fatal(err)
+	}
+	var handler http.Handler = srv
+	if websocket {
+		handler = srv.WebsocketHandler([]string{"*"})
+	}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if e.down.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(func() {
+		e.Close()
+		srv.Stop()
+	})
+	return e
+}
+
+func TestFailoverClient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		primary = newFailoverEndpoint(t, 1, false)
+		backup  = newFailoverEndpoint(t, 2, false)
+	)
+	client, err := ethclient.NewFailoverClient([]string{primary.URL, backup.URL}, ethclient.FailoverOptions{
+		HealthCheckInterval: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	chainID := func(want uint64) {
+		t.Helper()
+		id, err := client.ChainID(context.Background())
+		if err != nil {
+			t.Fatalf("failed to retrieve chain id: %v", err)
+		}
+		if id.Uint64() != want {
+			t.Fatalf("chain id mismatch, request served by the wrong endpoint: have %d, want %d", id, want)
+		}
+	}
+	// Requests must go to the primary while it's healthy
+	chainID(1)
+
+	// Requests must fail over to the backup if the primary goes down
+	primary.down.Store(true)
+	chainID(2)
+	chainID(2)
+
+	status := client.Endpoints()
+	if status[0].Healthy || status[0].Failures != 1 || status[0].LastError == nil {
+		t.Errorf("primary status mismatch: %+v", status[0])
+	}
+	if !status[1].Healthy || status[1].Requests != 2 || status[1].Failures != 0 {
+		t.Errorf("backup status mismatch: %+v", status[1])
+	}
+	// Requests must return to the primary once it's probed healthy again
+	primary.down.Store(false)
+	waitHealthy(t, client, 0, true)
+	chainID(1)
+
+	// Errors of the endpoints must be returned, not failed over
+	if _, err := client.SuggestGasPrice(context.Background()); err == nil {
+		t.Fatal("expected error from endpoint")
+	}
+	if calls := backup.service.calls.Load(); calls != 2 {
+		t.Errorf("endpoint error failed over, backup calls: have %d, want 2", calls)
+	}
+	if status := client.Endpoints(); !status[0].Healthy {
+		t.Errorf("endpoint error marked endpoint unhealthy")
+	}
+	// Requests must be attempted on unhealthy endpoints as a last resort
+	backup.down.Store(true)
+	primary.down.Store(true)
+	if _, err := client.ChainID(context.Background()); err == nil {
+		t.Fatal("expected error with all endpoints down")
+	}
+}
+
+func TestFailoverLoadBalance(t *testing.T) {
+	t.Parallel()
+
+	endpoints := []*failoverEndpoint{
+		newFailoverEndpoint(t, 1, false),
+		newFailoverEndpoint(t, 1, false),
+		newFailoverEndpoint(t, 1, false),
+	}
+	// The third endpoint lags behind, and must not be used while healthy ones remain
+	endpoints[2].service.number.Store(10)
+
+	urls := []string{endpoints[0].URL, endpoints[1].URL, endpoints[2].URL}
+	client, err := ethclient.NewFailoverClient(urls, ethclient.FailoverOptions{LoadBalance: true, MaxBlockLag: 5})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	for i := 0; i < 10; i++ {
+		if _, err := client.ChainID(context.Background()); err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	if a, b := endpoints[0].service.calls.Load(), endpoints[1].service.calls.Load(); a != 5 || b != 5 {
+		t.Errorf("requests not balanced: have %d and %d, want 5 each", a, b)
+	}
+	if calls := endpoints[2].service.calls.Load(); calls != 0 {
+		t.Errorf("lagging endpoint received %d requests", calls)
+	}
+	if status := client.Endpoints()[2]; status.Healthy || status.BlockNumber != 10 {
+		t.Errorf("lagging endpoint status mismatch: %+v", status)
+	}
+}
+
+func TestFailoverSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		httpEndpoint = newFailoverEndpoint(t, 1, false)
+		wsEndpoint   = newFailoverEndpoint(t, 2, true)
+	)
+	wsURL := "ws" + strings.TrimPrefix(wsEndpoint.URL, "http")
+
+	client, err := ethclient.NewFailoverClient([]string{httpEndpoint.URL, wsURL}, ethclient.FailoverOptions{})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Subscriptions must stick to the endpoint supporting them, without marking
+	// the others unhealthy
+	heads := make(chan *types.Header, 1)
+	sub, err := client.SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case head := <-heads:
+		if head.Number.Uint64() != 100 {
+			t.Errorf("head number mismatch: have %d, want 100", head.Number)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head received")
+	}
+	if status := client.Endpoints()[0]; !status.Healthy || status.Failures != 0 {
+		t.Errorf("http endpoint status mismatch: %+v", status)
+	}
+}
+
+func TestFailoverSubscriptionUnsupported(t *testing.T) {
+	t.Parallel()
+
+	var (
+		primary = newFailoverEndpoint(t, 1, false)
+		backup  = newFailoverEndpoint(t, 1, false)
+	)
+	client, err := ethclient.NewFailoverClient([]string{primary.URL, backup.URL}, ethclient.FailoverOptions{})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Close()
+
+	// Without any endpoint supporting subscriptions, the error of the endpoints
+	// must be returned
+	_, err = client.SubscribeNewHead(context.Background(), make(chan *types.Header))
+	if !errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		t.Fatalf("wrong error: have %v, want %v", err, rpc.ErrNotificationsUnsupported)
+	}
+}
+
+func TestFailoverUnreachable(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ethclient.NewFailoverClient(nil, ethclient.FailoverOptions{}); err == nil {
+		t.Error("expected error without endpoints")
+	}
+	_, err := ethclient.NewFailoverClient([]string{"ws://127.0.0.1:1"}, ethclient.FailoverOptions{HealthCheckTimeout: time.Second})
+	if err == nil {
+		t.Error("expected error with unreachable endpoints")
+	}
+}
+
+// waitHealthy waits until the health of an endpoint matches.
+func waitHealthy(t *testing.T, client *ethclient.FailoverClient, index int, healthy bool) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if client.Endpoints()[index].Healthy == healthy {
+			return
+		}
+	}
+	t.Fatalf("endpoint %d health not %v", index, healthy)
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+)
+
+// Predefined fee strategies, trading cost for the speed of inclusion. They
+// suggest the priority fee from the fees paid within the last 20 blocks.
+var (
+	FeeStrategySlow     = bind.PercentileFees{Blocks: 20, Percentile: 25}
+	FeeStrategyStandard = bind.PercentileFees{Blocks: 20, Percentile: 50}
+	FeeStrategyFast     = bind.PercentileFees{Blocks: 20, Percentile: 90}
+)
+
+// FeeEstimate is a suggestion of EIP-1559 transaction fees.
+type FeeEstimate struct {
+	BaseFee   *big.Int // Base fee of the current head
+	GasTipCap *big.Int // Suggested maxPriorityFeePerGas
+	GasFeeCap *big.Int // Suggested maxFeePerGas
+}
+
+// EstimateFees suggests the fees of an EIP-1559 transaction to be included on
+// top of the current head, using the given strategy. A nil strategy defaults to
+// FeeStrategyStandard.
+func (ec *Client) EstimateFees(ctx context.Context, strategy bind.FeeStrategy) (*FeeEstimate, error) {
+	if strategy == nil {
+		strategy = FeeStrategyStandard
+	}
+	head, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, errors.New("no base fee in head, pre-London chain?")
+	}
+	tip, feeCap, err := strategy.SuggestFees(ctx, ec, head)
+	if err != nil {
+		return nil, err
+	}
+	return &FeeEstimate{
+		BaseFee:   new(big.Int).Set(head.BaseFee),
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+	}, nil
+}
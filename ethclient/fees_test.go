@@ -0,0 +1,162 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// feeHistoryService serves a fixed fee history.
+type feeHistoryService struct {
+	rewards     []int64   // Rewards of the requested percentile per block
+	ratios      []float64 // Gas used ratios per block
+	baseFee     int64     // Base fee of the head, pre-London if zero
+	tip         int64     // Tip suggested by the node
+	percentiles []float64 // Percentiles of the last request
+}
+
+type feeHistory struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+func (s *feeHistoryService) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	head := &types.Header{Number: big.NewInt(int64(len(s.rewards))), Difficulty: new(big.Int)}
+	if s.baseFee != 0 {
+		head.BaseFee = big.NewInt(s.baseFee)
+	}
+	return head
+}
+
+func (s *feeHistoryService) FeeHistory(blocks hexutil.Uint64, last rpc.BlockNumber, percentiles []float64) *feeHistory {
+	s.percentiles = percentiles
+
+	history := &feeHistory{OldestBlock: (*hexutil.Big)(big.NewInt(1)), GasUsedRatio: s.ratios}
+	for _, reward := range s.rewards {
+		history.Reward = append(history.Reward, []*hexutil.Big{(*hexutil.Big)(big.NewInt(reward))})
+		history.BaseFee = append(history.BaseFee, (*hexutil.Big)(big.NewInt(s.baseFee)))
+	}
+	history.BaseFee = append(history.BaseFee, (*hexutil.Big)(big.NewInt(s.baseFee)))
+	return history
+}
+
+func (s *feeHistoryService) MaxPriorityFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(s.tip))
+}
+
+func TestEstimateFees(t *testing.T) {
+	t.Parallel()
+
+	for i, tt := range []struct {
+		service    feeHistoryService
+		strategy   bind.FeeStrategy
+		percentile float64
+		tip        int64
+		feeCap     int64
+	}{
+		// Median of the tips, fee cap doubling the base fee
+		{
+			service:  feeHistoryService{rewards: []int64{5, 1, 3}, ratios: []float64{0.5, 0.5, 0.5}, baseFee: 100},
+			strategy: bind.PercentileFees{Percentile: 60, Blocks: 20}, percentile: 60,
+			tip: 3, feeCap: 203,
+		},
+		// Empty blocks must be ignored
+		{
+			service:  feeHistoryService{rewards: []int64{0, 0, 7, 0}, ratios: []float64{0, 0, 0.5, 0}, baseFee: 100},
+			strategy: ethclient.FeeStrategyStandard, percentile: 50,
+			tip: 7, feeCap: 207,
+		},
+		// The tip suggested by the node must be used without recent transactions
+		{
+			service:  feeHistoryService{rewards: []int64{0, 0}, ratios: []float64{0, 0}, baseFee: 100, tip: 2},
+			strategy: ethclient.FeeStrategyFast, percentile: 90,
+			tip: 2, feeCap: 202,
+		},
+		// Minimum tip
+		{
+			service:  feeHistoryService{rewards: []int64{1}, ratios: []float64{0.5}, baseFee: 100},
+			strategy: bind.PercentileFees{Percentile: 50, MinTip: big.NewInt(10)},
+			tip:      10, feeCap: 210,
+		},
+		// Missing strategies default to the standard one
+		{
+			service:    feeHistoryService{rewards: []int64{4, 6}, ratios: []float64{0.5, 0.5}, baseFee: 100},
+			percentile: 50,
+			tip:        6, feeCap: 206,
+		},
+	} {
+		srv := rpc.NewServer()
+		if err := srv.RegisterName("eth", &tt.service); err != nil {
+			t.Fatal(err)
+		}
+		client := ethclient.NewClient(rpc.DialInProc(srv))
+
+		fees, err := client.EstimateFees(context.Background(), tt.strategy)
+		if err != nil {
+			t.Fatalf("test %d: failed to estimate fees: %v", i, err)
+		}
+		if fees.GasTipCap.Int64() != tt.tip {
+			t.Errorf("test %d: tip mismatch: have %v, want %d", i, fees.GasTipCap, tt.tip)
+		}
+		if fees.GasFeeCap.Int64() != tt.feeCap {
+			t.Errorf("test %d: fee cap mismatch: have %v, want %d", i, fees.GasFeeCap, tt.feeCap)
+		}
+		if fees.BaseFee.Int64() != tt.service.baseFee {
+			t.Errorf("test %d: base fee mismatch: have %v, want %d", i, fees.BaseFee, tt.service.baseFee)
+		}
+		if want := tt.percentile; want != 0 && (len(tt.service.percentiles) != 1 || tt.service.percentiles[0] != want) {
+			t.Errorf("test %d: percentiles mismatch: have %v, want [%v]", i, tt.service.percentiles, want)
+		}
+		client.Close()
+		srv.Stop()
+	}
+}
+
+func TestEstimateFeesInvalid(t *testing.T) {
+	t.Parallel()
+
+	for i, tt := range []struct {
+		service  feeHistoryService
+		strategy bind.FeeStrategy
+	}{
+		{service: feeHistoryService{baseFee: 100}, strategy: bind.PercentileFees{Percentile: 101}},
+		{service: feeHistoryService{baseFee: 100}, strategy: bind.PercentileFees{Percentile: -1}},
+		// Pre-London chains have no base fee
+		{service: feeHistoryService{}, strategy: ethclient.FeeStrategyStandard},
+	} {
+		srv := rpc.NewServer()
+		if err := srv.RegisterName("eth", &tt.service); err != nil {
+			t.Fatal(err)
+		}
+		client := ethclient.NewClient(rpc.DialInProc(srv))
+		if _, err := client.EstimateFees(context.Background(), tt.strategy); err == nil {
+			t.Errorf("test %d: expected error for strategy %+v", i, tt.strategy)
+		}
+		client.Close()
+		srv.Stop()
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
@@ -155,6 +156,9 @@ func TestGethClient(t *testing.T) {
 		}, {
 			"TestSubscribePendingTxHashes",
 			func(t *testing.T) { testSubscribePendingTransactions(t, client) },
+		}, {
+			"TestTxPool",
+			func(t *testing.T) { testTxPool(t, client) },
 		}, {
 			"TestCallContract",
 			func(t *testing.T) { testCallContract(t, client) },
@@ -174,6 +178,10 @@ func TestGethClient(t *testing.T) {
 			"TestTraceTransaction",
 			func(t *testing.T) { testTraceTransactions(t, client, txHashes) },
 		},
+		{
+			"TestTypedTraces",
+			func(t *testing.T) { testTypedTraces(t, client, txHashes[0]) },
+		},
 		{
 			"TestSetHead",
 			func(t *testing.T) { testSetHead(t, client) },
@@ -399,6 +407,41 @@ func testGetNodeInfo(t *testing.T, client *rpc.Client) {
 	}
 }
 
+func testTxPool(t *testing.T, client *rpc.Client) {
+	ec := New(client)
+
+	// The transaction sent by the pending transaction tests must be pending
+	status, err := ec.TxPoolStatus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Pending != 1 || status.Queued != 0 {
+		t.Fatalf("unexpected status: have %d pending and %d queued, want 1 and 0", status.Pending, status.Queued)
+	}
+	content, err := ec.TxPoolContent(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(content.Pending[testAddr]) != 1 || len(content.Queued) != 0 {
+		t.Fatalf("unexpected content: %v", content)
+	}
+	from, err := ec.TxPoolContentFrom(context.Background(), testAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for nonce, tx := range content.Pending[testAddr] {
+		if tx.Nonce() != nonce {
+			t.Errorf("nonce mismatch: have %d, want %d", tx.Nonce(), nonce)
+		}
+		if other := from.Pending[nonce]; other == nil || other.Hash() != tx.Hash() {
+			t.Errorf("account content mismatch: have %v, want %x", other, tx.Hash())
+		}
+	}
+	if len(from.Queued) != 0 {
+		t.Errorf("unexpected queued transactions: %v", from.Queued)
+	}
+}
+
 func testSetHead(t *testing.T, client *rpc.Client) {
 	ec := New(client)
 	err := ec.SetHead(context.Background(), big.NewInt(0))
@@ -499,6 +542,60 @@ func testTraceTransactions(t *testing.T, client *rpc.Client, txHashes []common.H
 	}
 }
 
+func testTypedTraces(t *testing.T, client *rpc.Client, txHash common.Hash) {
+	ec := New(client)
+	to := common.BytesToAddress([]byte{0x01})
+
+	// Call traces
+	frame, err := ec.TraceTransactionCalls(context.Background(), txHash, &CallTracerConfig{WithLog: true})
+	if err != nil {
+		t.Fatalf("failed to trace calls: %v", err)
+	}
+	if frame.Type != "CALL" || frame.From != testAddr || frame.To == nil || *frame.To != to {
+		t.Errorf("call frame mismatch: have %s %v -> %v", frame.Type, frame.From, frame.To)
+	}
+	if frame.Value == nil || frame.Value.Uint64() != 1 || frame.GasUsed != params.TxGas {
+		t.Errorf("call frame mismatch: have value %v, gas used %d", frame.Value, frame.GasUsed)
+	}
+	msg := ethereum.CallMsg{From: testAddr, To: &testContract, Value: big.NewInt(2)}
+	frame, err = ec.TraceCallCalls(context.Background(), msg, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+	if frame.Type != "CALL" || frame.To == nil || *frame.To != testContract || frame.Value.Uint64() != 2 {
+		t.Errorf("call frame mismatch: have %s to %v value %v", frame.Type, frame.To, frame.Value)
+	}
+	// State traces
+	prestate, err := ec.TraceTransactionPrestate(context.Background(), txHash, nil)
+	if err != nil {
+		t.Fatalf("failed to trace prestate: %v", err)
+	}
+	if sender := prestate.Pre[testAddr]; sender == nil || sender.Balance.Cmp(testBalance) != 0 || sender.Storage != nil {
+		t.Errorf("sender prestate mismatch: have %+v", sender)
+	}
+	if prestate.Post != nil {
+		t.Errorf("unexpected poststate without diff mode")
+	}
+	diff, err := ec.TraceTransactionPrestate(context.Background(), txHash, &PrestateTracerConfig{DiffMode: true})
+	if err != nil {
+		t.Fatalf("failed to trace state diff: %v", err)
+	}
+	if pre, post := diff.Pre[testAddr], diff.Post[testAddr]; pre == nil || post == nil || post.Balance.Cmp(pre.Balance) >= 0 || post.Nonce != 1 {
+		t.Errorf("sender state diff mismatch: have %+v -> %+v", pre, post)
+	}
+	prestate, err = ec.TraceCallPrestate(context.Background(), msg, nil, &PrestateTracerConfig{DisableCode: true})
+	if err != nil {
+		t.Fatalf("failed to trace call prestate: %v", err)
+	}
+	if contract := prestate.Pre[testContract]; contract == nil || contract.Nonce != 1 || contract.Code != nil {
+		t.Errorf("contract prestate mismatch: have %+v", contract)
+	}
+	// Untyped call traces
+	if _, err := ec.TraceCall(context.Background(), msg, nil, nil); err != nil {
+		t.Fatalf("failed to trace call: %v", err)
+	}
+}
+
 func TestOverrideAccountMarshal(t *testing.T) {
 	om := map[common.Address]OverrideAccount{
 		{0x11}: {
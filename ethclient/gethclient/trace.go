@@ -0,0 +1,205 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gethclient
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+)
+
+//go:generate go run github.com/fjl/gencodec -type CallFrame -field-override callFrameMarshaling -out gen_callframe_json.go
+//go:generate go run github.com/fjl/gencodec -type CallLog -field-override callLogMarshaling -out gen_calllog_json.go
+//go:generate go run github.com/fjl/gencodec -type PrestateAccount -field-override prestateAccountMarshaling -out gen_prestateaccount_json.go
+
+// CallTracerConfig configures the callTracer.
+type CallTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // Only trace the top call, without subcalls
+	WithLog     bool `json:"withLog"`     // Collect the event logs emitted by the calls
+}
+
+// CallFrame is a call made during the execution of a transaction, as traced by
+// the callTracer.
+type CallFrame struct {
+	Type         string          `json:"type"` // Opcode of the call, e.g. CALL or CREATE2
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *big.Int        `json:"value,omitempty"`
+	Gas          uint64          `json:"gas"`
+	GasUsed      uint64          `json:"gasUsed"`
+	Input        []byte          `json:"input"`
+	Output       []byte          `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+	Logs         []CallLog       `json:"logs,omitempty"`
+}
+
+type callFrameMarshaling struct {
+	Value   *hexutil.Big
+	Gas     hexutil.Uint64
+	GasUsed hexutil.Uint64
+	Input   hexutil.Bytes
+	Output  hexutil.Bytes
+}
+
+// CallLog is an event log emitted by a call, as traced by the callTracer.
+type CallLog struct {
+	Address  common.Address `json:"address"`
+	Topics   []common.Hash  `json:"topics"`
+	Data     []byte         `json:"data"`
+	Position uint64         `json:"position"` // Number of subcalls made before the log was emitted
+}
+
+type callLogMarshaling struct {
+	Data     hexutil.Bytes
+	Position hexutil.Uint64
+}
+
+// PrestateTracerConfig configures the prestateTracer.
+type PrestateTracerConfig struct {
+	DiffMode       bool `json:"diffMode"`       // Trace the changes made instead of the touched state
+	DisableCode    bool `json:"disableCode"`    // Omit the contract code
+	DisableStorage bool `json:"disableStorage"` // Omit the contract storage
+}
+
+// PrestateAccount is the state of an account as traced by the prestateTracer.
+// Fields not touched or not changed are unset.
+type PrestateAccount struct {
+	Balance  *big.Int                    `json:"balance,omitempty"`
+	Code     []byte                      `json:"code,omitempty"`
+	CodeHash *common.Hash                `json:"codeHash,omitempty"`
+	Nonce    uint64                      `json:"nonce,omitempty"`
+	Storage  map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+type prestateAccountMarshaling struct {
+	Balance *hexutil.Big
+	Code    hexutil.Bytes
+}
+
+// PrestateResult is the result of the prestateTracer. Pre holds the state
+// touched by the transaction before its execution. In diff mode, Pre only holds
+// the state changed by the transaction, and Post the state after the changes.
+type PrestateResult struct {
+	Pre  map[common.Address]*PrestateAccount `json:"pre"`
+	Post map[common.Address]*PrestateAccount `json:"post,omitempty"`
+}
+
+// TraceCall returns the result of tracing a message call executed on top of the
+// given block, without creating a transaction. The block number can be nil, in
+// which case the call is traced on the latest known block.
+func (ec *Client) TraceCall(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, config *tracers.TraceCallConfig) (any, error) {
+	var result any
+	err := ec.c.CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), config)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// TraceTransactionCalls traces the calls made by a transaction with the callTracer.
+// The config can be nil.
+func (ec *Client) TraceTransactionCalls(ctx context.Context, hash common.Hash, config *CallTracerConfig) (*CallFrame, error) {
+	traceConfig, err := tracerConfig("callTracer", config)
+	if err != nil {
+		return nil, err
+	}
+	var result CallFrame
+	if err := ec.c.CallContext(ctx, &result, "debug_traceTransaction", hash, traceConfig); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TraceCallCalls traces the calls made by a message call with the callTracer,
+// like TraceCall. The config can be nil.
+func (ec *Client) TraceCallCalls(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, config *CallTracerConfig) (*CallFrame, error) {
+	traceConfig, err := tracerConfig("callTracer", config)
+	if err != nil {
+		return nil, err
+	}
+	var result CallFrame
+	err = ec.c.CallContext(ctx, &result, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), &tracers.TraceCallConfig{TraceConfig: *traceConfig})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// TraceTransactionPrestate traces the state touched by a transaction with the
+// prestateTracer. The config can be nil.
+func (ec *Client) TraceTransactionPrestate(ctx context.Context, hash common.Hash, config *PrestateTracerConfig) (*PrestateResult, error) {
+	traceConfig, err := tracerConfig("prestateTracer", config)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := ec.c.CallContext(ctx, &raw, "debug_traceTransaction", hash, traceConfig); err != nil {
+		return nil, err
+	}
+	return decodePrestate(raw, config)
+}
+
+// TraceCallPrestate traces the state touched by a message call with the
+// prestateTracer, like TraceCall. The config can be nil.
+func (ec *Client) TraceCallPrestate(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int, config *PrestateTracerConfig) (*PrestateResult, error) {
+	traceConfig, err := tracerConfig("prestateTracer", config)
+	if err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	err = ec.c.CallContext(ctx, &raw, "debug_traceCall", toCallArg(msg), toBlockNumArg(blockNumber), &tracers.TraceCallConfig{TraceConfig: *traceConfig})
+	if err != nil {
+		return nil, err
+	}
+	return decodePrestate(raw, config)
+}
+
+// tracerConfig assembles the trace config selecting a native tracer.
+func tracerConfig(tracer string, config any) (*tracers.TraceConfig, error) {
+	traceConfig := &tracers.TraceConfig{Tracer: &tracer}
+	if config != nil {
+		enc, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		traceConfig.TracerConfig = enc
+	}
+	return traceConfig, nil
+}
+
+// decodePrestate decodes the result of the prestateTracer, which is shaped
+// differently in diff mode.
+func decodePrestate(raw json.RawMessage, config *PrestateTracerConfig) (*PrestateResult, error) {
+	result := new(PrestateResult)
+	if config != nil && config.DiffMode {
+		if err := json.Unmarshal(raw, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	if err := json.Unmarshal(raw, &result.Pre); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
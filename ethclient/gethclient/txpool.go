@@ -0,0 +1,119 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gethclient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxPoolContent is the content of the transaction pool, grouped by sender and
+// nonce. Pending transactions are executable, queued ones are waiting for
+// transactions with lower nonces.
+type TxPoolContent struct {
+	Pending map[common.Address]map[uint64]*types.Transaction
+	Queued  map[common.Address]map[uint64]*types.Transaction
+}
+
+// TxPoolAccountContent is the content of the transaction pool for a single
+// sender, grouped by nonce.
+type TxPoolAccountContent struct {
+	Pending map[uint64]*types.Transaction
+	Queued  map[uint64]*types.Transaction
+}
+
+// TxPoolStatus is the number of transactions in the transaction pool.
+type TxPoolStatus struct {
+	Pending uint64
+	Queued  uint64
+}
+
+// TxPoolContent returns the transactions contained in the transaction pool.
+func (ec *Client) TxPoolContent(ctx context.Context) (*TxPoolContent, error) {
+	var result map[string]map[common.Address]map[string]*types.Transaction
+	if err := ec.c.CallContext(ctx, &result, "txpool_content"); err != nil {
+		return nil, err
+	}
+	content := &TxPoolContent{
+		Pending: make(map[common.Address]map[uint64]*types.Transaction, len(result["pending"])),
+		Queued:  make(map[common.Address]map[uint64]*types.Transaction, len(result["queued"])),
+	}
+	for account, txs := range result["pending"] {
+		byNonce, err := txsByNonce(txs)
+		if err != nil {
+			return nil, err
+		}
+		content.Pending[account] = byNonce
+	}
+	for account, txs := range result["queued"] {
+		byNonce, err := txsByNonce(txs)
+		if err != nil {
+			return nil, err
+		}
+		content.Queued[account] = byNonce
+	}
+	return content, nil
+}
+
+// TxPoolContentFrom returns the transactions of an account contained in the
+// transaction pool.
+func (ec *Client) TxPoolContentFrom(ctx context.Context, account common.Address) (*TxPoolAccountContent, error) {
+	var result map[string]map[string]*types.Transaction
+	if err := ec.c.CallContext(ctx, &result, "txpool_contentFrom", account); err != nil {
+		return nil, err
+	}
+	pending, err := txsByNonce(result["pending"])
+	if err != nil {
+		return nil, err
+	}
+	queued, err := txsByNonce(result["queued"])
+	if err != nil {
+		return nil, err
+	}
+	return &TxPoolAccountContent{Pending: pending, Queued: queued}, nil
+}
+
+// TxPoolStatus returns the number of pending and queued transactions in the
+// transaction pool.
+func (ec *Client) TxPoolStatus(ctx context.Context) (*TxPoolStatus, error) {
+	var result struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := ec.c.CallContext(ctx, &result, "txpool_status"); err != nil {
+		return nil, err
+	}
+	return &TxPoolStatus{Pending: uint64(result.Pending), Queued: uint64(result.Queued)}, nil
+}
+
+// txsByNonce converts transactions keyed by decimal nonces.
+func txsByNonce(txs map[string]*types.Transaction) (map[uint64]*types.Transaction, error) {
+	byNonce := make(map[uint64]*types.Transaction, len(txs))
+	for key, tx := range txs {
+		nonce, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction pool nonce %q: %v", key, err)
+		}
+		byNonce[nonce] = tx
+	}
+	return byNonce, nil
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Request is an outbound RPC request passing through the middlewares.
+type Request struct {
+	// Method is the name of the method called. It is eth_subscribe for
+	// subscriptions, and empty for batches.
+	Method string
+	Args   []interface{}
+
+	// Result is the value the response is decoded into. For subscriptions, it
+	// is the channel receiving the notifications.
+	Result interface{}
+
+	// Batch holds the requests of a batch, their responses and errors.
+	Batch []rpc.BatchElem
+
+	// Subscription is set once a subscription request succeeded.
+	Subscription *rpc.ClientSubscription
+}
+
+// Invoker sends an RPC request, decoding the response into its result.
+type Invoker func(ctx context.Context, req *Request) error
+
+// Middleware intercepts the requests of a client, e.g. for logging, metrics or
+// retries. It returns an invoker which is usually wrapping the next one, in the
+// fashion of http.RoundTripper composition:
+//
+//	func logging(next ethclient.Invoker) ethclient.Invoker {
+//		return func(ctx context.Context, req *ethclient.Request) error {
+//			start := time.Now()
+//			err := next(ctx, req)
+//			log.Info("RPC request", "method", req.Method, "elapsed", time.Since(start), "err", err)
+//			return err
+//		}
+//	}
+type Middleware func(next Invoker) Invoker
+
+// WithMiddleware returns a client sharing the connection of ec, passing every
+// request through the given middlewares. The first middleware is the outermost
+// one, seeing the requests first and the responses last.
+func (ec *Client) WithMiddleware(middlewares ...Middleware) *Client {
+	c := &middlewareClient{next: ec.c}
+	c.invoke = c.send
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		c.invoke = middlewares[i](c.invoke)
+	}
+	return &Client{c: c, ens: ec.ens}
+}
+
+// HeaderMiddleware returns a middleware adding the given HTTP headers to all
+// requests, e.g. for authentication. Headers only apply to HTTP connections, and
+// the handshake of websocket connections.
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, req *Request) error {
+			return next(rpc.NewContextWithHeaders(ctx, header), req)
+		}
+	}
+}
+
+// middlewareClient is an RPC connection passing requests through middlewares.
+type middlewareClient struct {
+	next   rpcClient
+	invoke Invoker
+}
+
+// send is the innermost invoker, sending requests over the connection.
+func (c *middlewareClient) send(ctx context.Context, req *Request) (err error) {
+	switch {
+	case req.Batch != nil:
+		return c.next.BatchCallContext(ctx, req.Batch)
+	case req.Method == "eth_subscribe":
+		req.Subscription, err = c.next.EthSubscribe(ctx, req.Result, req.Args...)
+		return err
+	default:
+		return c.next.CallContext(ctx, req.Result, req.Method, req.Args...)
+	}
+}
+
+func (c *middlewareClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.invoke(ctx, &Request{Method: method, Args: args, Result: result})
+}
+
+func (c *middlewareClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	if b == nil {
+		b = []rpc.BatchElem{}
+	}
+	return c.invoke(ctx, &Request{Batch: b})
+}
+
+func (c *middlewareClient) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (*rpc.ClientSubscription, error) {
+	req := &Request{Method: "eth_subscribe", Args: args, Result: channel}
+	if err := c.invoke(ctx, req); err != nil {
+		return nil, err
+	}
+	return req.Subscription, nil
+}
+
+func (c *middlewareClient) Close() {
+	c.next.Close()
+}
+
+func (c *middlewareClient) rpcClient() *rpc.Client {
+	return underlyingClient(c.next)
+}
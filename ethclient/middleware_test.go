@@ -0,0 +1,189 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// flakyService is an eth namespace failing every other gas price request.
+type flakyService struct {
+	chainService
+	calls int
+}
+
+func (s *flakyService) GasPrice() (*hexutil.Big, error) {
+	s.calls++
+	if s.calls%2 == 1 {
+		return nil, errors.New("flaky")
+	}
+	return (*hexutil.Big)(hexutil.MustDecodeBig("0x2a")), nil
+}
+
+// recorder returns a middleware recording the requests passing through it.
+func recorder(name string, events *[]string) ethclient.Middleware {
+	return func(next ethclient.Invoker) ethclient.Invoker {
+		return func(ctx context.Context, req *ethclient.Request) error {
+			method := req.Method
+			if req.Batch != nil {
+				method = "batch"
+			}
+			*events = append(*events, name+" "+method)
+			err := next(ctx, req)
+			*events = append(*events, name+" done")
+			return err
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	service := &flakyService{chainService: chainService{number: 100}}
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	base := ethclient.NewClient(rpc.DialInProc(srv))
+	defer base.Close()
+
+	var events []string
+	client := base.WithMiddleware(recorder("outer", &events), recorder("inner", &events))
+	if client.Client() == nil {
+		t.Fatal("no underlying client")
+	}
+	// Calls must pass through the middlewares in order
+	if _, err := client.BlockNumber(context.Background()); err != nil {
+		t.Fatalf("failed to call: %v", err)
+	}
+	want := []string{"outer eth_blockNumber", "inner eth_blockNumber", "inner done", "outer done"}
+	if !slices.Equal(events, want) {
+		t.Errorf("call events mismatch: have %v, want %v", events, want)
+	}
+	// Batches must be passed as a whole
+	events = events[:0]
+	batch := client.Batch()
+	number := batch.BlockNumber()
+	if err := batch.Execute(context.Background()); err != nil {
+		t.Fatalf("failed to execute batch: %v", err)
+	}
+	if n, err := number.Result(); err != nil || n != 100 {
+		t.Errorf("batch result mismatch: have %d (err %v), want 100", n, err)
+	}
+	want = []string{"outer batch", "inner batch", "inner done", "outer done"}
+	if !slices.Equal(events, want) {
+		t.Errorf("batch events mismatch: have %v, want %v", events, want)
+	}
+	// Subscriptions must pass through the middlewares
+	events = events[:0]
+	heads := make(chan *types.Header, 1)
+	sub, err := client.SubscribeNewHead(context.Background(), heads)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+	want = []string{"outer eth_subscribe", "inner eth_subscribe", "inner done", "outer done"}
+	if !slices.Equal(events, want) {
+		t.Errorf("subscription events mismatch: have %v, want %v", events, want)
+	}
+	service.advance()
+	select {
+	case head := <-heads:
+		if head.Number.Uint64() != 101 {
+			t.Errorf("head number mismatch: have %d, want 101", head.Number)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head received")
+	}
+}
+
+func TestMiddlewareRetry(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	if err := srv.RegisterName("eth", new(flakyService)); err != nil {
+		t.Fatal(err)
+	}
+	base := ethclient.NewClient(rpc.DialInProc(srv))
+	defer base.Close()
+
+	retry := func(next ethclient.Invoker) ethclient.Invoker {
+		return func(ctx context.Context, req *ethclient.Request) error {
+			err := next(ctx, req)
+			if err != nil {
+				err = next(ctx, req)
+			}
+			return err
+		}
+	}
+	if _, err := base.SuggestGasPrice(context.Background()); err == nil {
+		t.Fatal("expected error without retries")
+	}
+	// The flaky service succeeds every other request
+	base.SuggestGasPrice(context.Background())
+
+	price, err := base.WithMiddleware(retry).SuggestGasPrice(context.Background())
+	if err != nil {
+		t.Fatalf("failed to retry: %v", err)
+	}
+	if price.Uint64() != 42 {
+		t.Errorf("gas price mismatch: have %v, want 42", price)
+	}
+}
+
+func TestHeaderMiddleware(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	if err := srv.RegisterName("eth", &chainService{number: 100}); err != nil {
+		t.Fatal(err)
+	}
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		srv.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	base, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer base.Close()
+
+	client := base.WithMiddleware(ethclient.HeaderMiddleware(http.Header{"Authorization": {"Bearer token"}}))
+	if _, err := client.BlockNumber(context.Background()); err != nil {
+		t.Fatalf("failed to call: %v", err)
+	}
+	if auth != "Bearer token" {
+		t.Errorf("header mismatch: have %q, want %q", auth, "Bearer token")
+	}
+}
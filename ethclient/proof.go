@@ -0,0 +1,193 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+)
+
+// AccountProof is the state of an account and some of its storage slots, along
+// with the Merkle proofs of them.
+type AccountProof struct {
+	Address      common.Address
+	Balance      *big.Int
+	Nonce        uint64
+	CodeHash     common.Hash
+	StorageHash  common.Hash
+	AccountProof [][]byte // Trie nodes from the state root to the account
+	StorageProof []StorageProof
+}
+
+// StorageProof is the value of a storage slot along with its Merkle proof.
+type StorageProof struct {
+	Key   common.Hash
+	Value *big.Int
+	Proof [][]byte // Trie nodes from the storage root to the slot
+}
+
+// GetProof returns the state of an account and the values of the given storage
+// slots, including their Merkle proofs. The block number can be nil, in which
+// case the state is taken from the latest known block.
+//
+// The proofs are not checked, use VerifyProof to do so against the state root
+// of a trusted header.
+func (ec *Client) GetProof(ctx context.Context, account common.Address, keys []common.Hash, blockNumber *big.Int) (*AccountProof, error) {
+	type storageResult struct {
+		Value *hexutil.Big    `json:"value"`
+		Proof []hexutil.Bytes `json:"proof"`
+	}
+	type accountResult struct {
+		Address      common.Address  `json:"address"`
+		AccountProof []hexutil.Bytes `json:"accountProof"`
+		Balance      *hexutil.Big    `json:"balance"`
+		CodeHash     common.Hash     `json:"codeHash"`
+		Nonce        hexutil.Uint64  `json:"nonce"`
+		StorageHash  common.Hash     `json:"storageHash"`
+		StorageProof []storageResult `json:"storageProof"`
+	}
+	if keys == nil {
+		keys = []common.Hash{} // The node requires the list of keys
+	}
+	var res accountResult
+	if err := ec.c.CallContext(ctx, &res, "eth_getProof", account, keys, toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	if len(res.StorageProof) != len(keys) {
+		return nil, fmt.Errorf("storage proof count mismatch: have %d, want %d", len(res.StorageProof), len(keys))
+	}
+	proof := &AccountProof{
+		Address:      res.Address,
+		Balance:      (*big.Int)(res.Balance),
+		Nonce:        uint64(res.Nonce),
+		CodeHash:     res.CodeHash,
+		StorageHash:  res.StorageHash,
+		AccountProof: toByteSlices(res.AccountProof),
+	}
+	if proof.Balance == nil {
+		proof.Balance = new(big.Int)
+	}
+	for i, st := range res.StorageProof {
+		value := (*big.Int)(st.Value)
+		if value == nil {
+			value = new(big.Int)
+		}
+		proof.StorageProof = append(proof.StorageProof, StorageProof{
+			Key:   keys[i],
+			Value: value,
+			Proof: toByteSlices(st.Proof),
+		})
+	}
+	return proof, nil
+}
+
+// VerifyProof checks that the state of the account and its storage slots are
+// proven by the Merkle proofs against the given state root.
+func (p *AccountProof) VerifyProof(stateRoot common.Hash) error {
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(p.Address[:]), proofDB(p.AccountProof))
+	if err != nil {
+		return fmt.Errorf("invalid account proof: %v", err)
+	}
+	// A missing account is proven by its absence from the state trie, nodes
+	// report it with either zero or empty hashes
+	account := &types.StateAccount{
+		Balance:  new(uint256.Int),
+		Root:     types.EmptyRootHash,
+		CodeHash: types.EmptyCodeHash[:],
+	}
+	codeHash, storageHash := p.CodeHash, p.StorageHash
+	if value != nil {
+		if err := rlp.DecodeBytes(value, account); err != nil {
+			return fmt.Errorf("invalid account: %v", err)
+		}
+	} else {
+		if codeHash == (common.Hash{}) {
+			codeHash = types.EmptyCodeHash
+		}
+		if storageHash == (common.Hash{}) {
+			storageHash = types.EmptyRootHash
+		}
+	}
+	if account.Nonce != p.Nonce {
+		return fmt.Errorf("nonce mismatch: proven %d, claimed %d", account.Nonce, p.Nonce)
+	}
+	if account.Balance.ToBig().Cmp(p.Balance) != 0 {
+		return fmt.Errorf("balance mismatch: proven %v, claimed %v", account.Balance, p.Balance)
+	}
+	if common.BytesToHash(account.CodeHash) != codeHash {
+		return fmt.Errorf("code hash mismatch: proven %x, claimed %x", account.CodeHash, p.CodeHash)
+	}
+	if account.Root != storageHash {
+		return fmt.Errorf("storage hash mismatch: proven %x, claimed %x", account.Root, p.StorageHash)
+	}
+	for _, st := range p.StorageProof {
+		if err := st.VerifyProof(account.Root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyProof checks that the value of the storage slot is proven by the Merkle
+// proof against the given storage root.
+func (p *StorageProof) VerifyProof(storageRoot common.Hash) error {
+	value, err := trie.VerifyProof(storageRoot, crypto.Keccak256(p.Key[:]), proofDB(p.Proof))
+	if err != nil {
+		return fmt.Errorf("invalid storage proof of slot %x: %v", p.Key, err)
+	}
+	// Slots are stored RLP encoded without leading zeroes, and empty slots are
+	// proven by their absence
+	var proven []byte
+	if value != nil {
+		if _, proven, _, err = rlp.Split(value); err != nil {
+			return fmt.Errorf("invalid value of slot %x: %v", p.Key, err)
+		}
+	}
+	if !bytes.Equal(proven, p.Value.Bytes()) {
+		return fmt.Errorf("value mismatch of slot %x: proven %x, claimed %x", p.Key, proven, p.Value.Bytes())
+	}
+	return nil
+}
+
+// proofDB collects the nodes of a Merkle proof, keyed by their hashes.
+func proofDB(proof [][]byte) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
+
+// toByteSlices converts decoded binary data.
+func toByteSlices(data []hexutil.Bytes) [][]byte {
+	slices := make([][]byte, len(data))
+	for i, d := range data {
+		slices[i] = d
+	}
+	return slices
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// DefaultReorgDepth is the default number of recent canonical blocks tracked to
+// detect reorgs.
+const DefaultReorgDepth = 64
+
+// Reorg is a reorganization of the canonical chain. Both chains are ordered by
+// number, starting after their common ancestor.
+type Reorg struct {
+	OldChain []*types.Header // Blocks removed from the canonical chain
+	NewChain []*types.Header // Blocks made canonical, up to the new head
+}
+
+// SubscribeReorgs subscribes to reorganizations of the canonical chain. The
+// heads received are compared against a window of the most recent canonical
+// blocks, and whenever a head doesn't extend it, the blocks replaced are
+// reported along with the new chain, whose missing blocks are retrieved from the
+// node. Heads simply extending the chain aren't reported.
+//
+// The depth is the number of blocks tracked, 0 means DefaultReorgDepth. The
+// subscription fails if a reorg deeper than that is detected.
+func (ec *Client) SubscribeReorgs(ctx context.Context, ch chan<- *Reorg, depth uint64) (ethereum.Subscription, error) {
+	if depth == 0 {
+		depth = DefaultReorgDepth
+	}
+	head, err := ec.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	heads := make(chan *types.Header)
+	sub, err := ec.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+	tracker := &reorgTracker{client: ec, depth: depth, window: []*types.Header{head}}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		for {
+			select {
+			case head := <-heads:
+				reorg, err := tracker.add(ctx, head)
+				if err != nil {
+					return err
+				}
+				if reorg == nil {
+					continue
+				}
+				select {
+				case ch <- reorg:
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				if err == nil {
+					err = errSubscriptionClosed
+				}
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// reorgTracker tracks the most recent canonical blocks to detect reorgs.
+type reorgTracker struct {
+	client *Client
+	depth  uint64
+	window []*types.Header // Canonical blocks, ordered by number
+}
+
+// add makes a head canonical, returning the reorg it caused, if any.
+func (t *reorgTracker) add(ctx context.Context, head *types.Header) (*Reorg, error) {
+	// Walk back the new chain until it joins the tracked one, retrieving the
+	// blocks missed, e.g. the ones of a reorg made of multiple blocks
+	var (
+		first   = t.window[0].Number.Uint64()
+		newest  = t.window[len(t.window)-1].Number.Uint64()
+		chain   = []*types.Header{head}
+		current = head
+	)
+	if number := head.Number.Uint64(); number >= first && number <= newest && t.window[number-first].Hash() == head.Hash() {
+		return nil, nil // Head already known
+	}
+	for {
+		number := current.Number.Uint64()
+		if number <= first {
+			return nil, fmt.Errorf("reorg deeper than %d blocks at block %d", t.depth, head.Number)
+		}
+		if number-1 <= newest && t.window[number-1-first].Hash() == current.ParentHash {
+			break
+		}
+		parent, err := t.client.HeaderByHash(ctx, current.ParentHash)
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]*types.Header{parent}, chain...)
+		current = parent
+	}
+	// Replace the blocks after the common ancestor
+	var (
+		ancestor = current.Number.Uint64() - 1 - first
+		reorg    *Reorg
+	)
+	if removed := t.window[ancestor+1:]; len(removed) > 0 {
+		reorg = &Reorg{OldChain: removed, NewChain: chain}
+	}
+	t.window = append(t.window[:ancestor+1:ancestor+1], chain...)
+	if uint64(len(t.window)) > t.depth {
+		t.window = t.window[uint64(len(t.window))-t.depth:]
+	}
+	return reorg, nil
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// forkService is the eth namespace of a node whose head is set by tests,
+// serving all blocks of all forks by hash.
+type forkService struct {
+	lock   sync.Mutex
+	head   *types.Header
+	blocks map[common.Hash]*types.Header
+	heads  []func(*types.Header)
+}
+
+// extend creates a chain of blocks on top of the parent, tagged to make forks
+// distinct.
+func (s *forkService) extend(parent *types.Header, n int, tag byte) []*types.Header {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var chain []*types.Header
+	for i := 0; i < n; i++ {
+		header := &types.Header{
+			ParentHash: parent.Hash(),
+			Number:     new(big.Int).Add(parent.Number, common.Big1),
+			Difficulty: new(big.Int),
+			Extra:      []byte{tag},
+		}
+		s.blocks[header.Hash()] = header
+		chain = append(chain, header)
+		parent = header
+	}
+	return chain
+}
+
+// setHead makes a block the head, notifying the subscriptions.
+func (s *forkService) setHead(head *types.Header) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.head = head
+	for _, notify := range s.heads {
+		notify(head)
+	}
+}
+
+func (s *forkService) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.head
+}
+
+func (s *forkService) GetBlockByHash(hash common.Hash, full bool) *types.Header {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.blocks[hash]
+}
+
+func (s *forkService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	s.lock.Lock()
+	s.heads = append(s.heads, func(h *types.Header) { notifier.Notify(sub.ID, h) })
+	s.lock.Unlock()
+	return sub, nil
+}
+
+func TestSubscribeReorgs(t *testing.T) {
+	t.Parallel()
+
+	genesis := &types.Header{Number: big.NewInt(100), Difficulty: new(big.Int)}
+	service := &forkService{head: genesis, blocks: map[common.Hash]*types.Header{genesis.Hash(): genesis}}
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	client := ethclient.NewClient(rpc.DialInProc(srv))
+	defer client.Close()
+
+	reorgs := make(chan *ethclient.Reorg)
+	sub, err := client.SubscribeReorgs(context.Background(), reorgs, 4)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	receive := func(old, new []*types.Header) {
+		t.Helper()
+		select {
+		case reorg := <-reorgs:
+			if len(reorg.OldChain) != len(old) || len(reorg.NewChain) != len(new) {
+				t.Fatalf("reorg length mismatch: have %d -> %d blocks, want %d -> %d", len(reorg.OldChain), len(reorg.NewChain), len(old), len(new))
+			}
+			for i := range old {
+				if reorg.OldChain[i].Hash() != old[i].Hash() {
+					t.Errorf("old block %d mismatch: have %x, want %x", i, reorg.OldChain[i].Hash(), old[i].Hash())
+				}
+			}
+			for i := range new {
+				if reorg.NewChain[i].Hash() != new[i].Hash() {
+					t.Errorf("new block %d mismatch: have %x, want %x", i, reorg.NewChain[i].Hash(), new[i].Hash())
+				}
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("reorg not received")
+		}
+	}
+	// Extending the chain, even with gaps, must not report reorgs
+	chain := service.extend(genesis, 5, 0)
+	service.setHead(chain[0])
+	service.setHead(chain[2])
+	service.setHead(chain[2])
+	service.setHead(chain[4])
+
+	// Replacing the head must report a reorg of one block
+	fork := service.extend(chain[3], 1, 1)
+	service.setHead(fork[0])
+	receive(chain[4:], fork)
+
+	// Reorgs made of multiple blocks must be reported with the missing blocks
+	// retrieved, also if the new chain is shorter
+	fork2 := service.extend(chain[1], 2, 2)
+	service.setHead(fork2[1])
+	receive(append(chain[2:4:4], fork...), fork2)
+
+	// Reorgs deeper than the window must fail the subscription
+	fork3 := service.extend(chain[0], 4, 3)
+	service.setHead(fork3[3])
+	select {
+	case reorg := <-reorgs:
+		t.Fatalf("unexpected reorg: %v", reorg)
+	case err := <-sub.Err():
+		if err == nil {
+			t.Fatal("subscription ended without error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription didn't fail")
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// errSubscriptionClosed is returned when the node ends a subscription.
+var errSubscriptionClosed = errors.New("subscription closed")
+
+// ResilientOptions configures subscriptions which survive connection drops.
+type ResilientOptions struct {
+	// MaxRetryInterval is the maximum delay between resubscription attempts,
+	// which start at a tenth of it. 0 = 30 seconds.
+	MaxRetryInterval time.Duration
+
+	// MaxBackfill is the maximum number of missed blocks replayed after
+	// resubscribing, the most recent ones are replayed. 0 = 128.
+	MaxBackfill uint64
+
+	// OnGap is called with the range of blocks missed while the subscription
+	// was down, before they are replayed. It may be nil.
+	OnGap func(from, to uint64)
+}
+
+// withDefaults returns the options with unset fields set to their defaults.
+func (opts ResilientOptions) withDefaults() ResilientOptions {
+	if opts.MaxRetryInterval == 0 {
+		opts.MaxRetryInterval = 30 * time.Second
+	}
+	if opts.MaxBackfill == 0 {
+		opts.MaxBackfill = 128
+	}
+	return opts
+}
+
+// gap reports the missed blocks to the hook, and returns the first block to
+// replay, which is limited by the maximum backfill.
+func (opts ResilientOptions) gap(from, to uint64) uint64 {
+	if opts.OnGap != nil {
+		opts.OnGap(from, to)
+	}
+	if to-from+1 > opts.MaxBackfill {
+		from = to - opts.MaxBackfill + 1
+	}
+	return from
+}
+
+// SubscribeNewHeadResilient subscribes to notifications about the current
+// blockchain head like SubscribeNewHead, but resubscribes whenever the
+// subscription fails, e.g. because the websocket connection dropped. The heads
+// missed in the meantime are replayed before the subscription continues.
+//
+// Only establishing the initial subscription can fail, e.g. if the client
+// doesn't support subscriptions.
+func (ec *Client) SubscribeNewHeadResilient(ctx context.Context, ch chan<- *types.Header, opts ResilientOptions) (ethereum.Subscription, error) {
+	head, err := ec.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	w := &headWatcher{client: ec, sink: ch, opts: opts.withDefaults(), last: head}
+	first, err := w.subscribe(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	return event.ResubscribeErr(w.opts.MaxRetryInterval, func(ctx context.Context, lastErr error) (event.Subscription, error) {
+		if first != nil {
+			sub := first
+			first = nil
+			return sub, nil
+		}
+		return w.subscribe(ctx, true)
+	}), nil
+}
+
+// headWatcher maintains a head subscription, tracking the delivered heads so
+// the missed ones can be replayed after a failure.
+type headWatcher struct {
+	client *Client
+	sink   chan<- *types.Header
+	opts   ResilientOptions
+	last   uint64 // Number of the last head delivered
+}
+
+// subscribe establishes a head subscription forwarding the heads to the sink.
+// If resumed is set, the heads missed since the previous subscription are
+// replayed before the first one received.
+func (w *headWatcher) subscribe(ctx context.Context, resumed bool) (event.Subscription, error) {
+	heads := make(chan *types.Header)
+	sub, err := w.client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		for {
+			select {
+			case head := <-heads:
+				// Replay the heads missed before the first one of a new subscription
+				if number := head.Number.Uint64(); resumed && number > w.last+1 {
+					for n := w.opts.gap(w.last+1, number-1); n < number; n++ {
+						missed, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+						if err != nil {
+							return err
+						}
+						if !w.deliver(missed, quit) {
+							return nil
+						}
+					}
+				}
+				resumed = false
+				if !w.deliver(head, quit) {
+					return nil
+				}
+			case err := <-sub.Err():
+				if err == nil {
+					err = errSubscriptionClosed
+				}
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// deliver sends a head to the subscriber, returning false if quit was closed.
+func (w *headWatcher) deliver(head *types.Header, quit <-chan struct{}) bool {
+	select {
+	case w.sink <- head:
+		w.last = head.Number.Uint64()
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+// SubscribeFilterLogsResilient subscribes to the results of a streaming filter
+// query like SubscribeFilterLogs, but resubscribes whenever the subscription
+// fails, e.g. because the websocket connection dropped. The logs missed in the
+// meantime are replayed from the last delivered one before the subscription
+// continues, see bind.WatchFilterLogsResilient.
+//
+// Only establishing the initial subscription can fail, e.g. if the client
+// doesn't support subscriptions.
+func (ec *Client) SubscribeFilterLogsResilient(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log, opts ResilientOptions) (ethereum.Subscription, error) {
+	opts = opts.withDefaults()
+	head, err := ec.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// All logs up to the current head are considered delivered
+	start := head + 1
+	return bind.WatchFilterLogsResilient(ec, q, &bind.ResilientWatchOpts{
+		WatchOpts:     bind.WatchOpts{Start: &start},
+		MaxRetryDelay: opts.MaxRetryInterval,
+		MaxBackfill:   opts.MaxBackfill,
+		OnGap:         opts.OnGap,
+	}, ch)
+}
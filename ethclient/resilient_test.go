@@ -0,0 +1,315 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// chainService is the eth namespace of a node whose chain is advanced by tests,
+// producing one log per block.
+type chainService struct {
+	lock   sync.Mutex
+	number uint64
+	heads  []func(*types.Header) // Notifiers of the head subscriptions
+	logs   []func(*types.Log)    // Notifiers of the log subscriptions
+	subs   atomic.Int32          // Number of subscriptions created
+}
+
+func (s *chainService) header(number uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Difficulty: new(big.Int)}
+}
+
+func (s *chainService) log(number uint64) *types.Log {
+	return &types.Log{BlockNumber: number, Topics: []common.Hash{}, Data: []byte{}}
+}
+
+func (s *chainService) BlockNumber() hexutil.Uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return hexutil.Uint64(s.number)
+}
+
+func (s *chainService) GetBlockByNumber(number rpc.BlockNumber, full bool) *types.Header {
+	return s.header(uint64(number))
+}
+
+func (s *chainService) GetLogs(crit map[string]interface{}) []*types.Log {
+	from, _ := hexutil.DecodeUint64(crit["fromBlock"].(string))
+	to, _ := hexutil.DecodeUint64(crit["toBlock"].(string))
+
+	var logs []*types.Log
+	for n := from; n <= to; n++ {
+		logs = append(logs, s.log(n))
+	}
+	return logs
+}
+
+func (s *chainService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	s.lock.Lock()
+	s.heads = append(s.heads, func(h *types.Header) { notifier.Notify(sub.ID, h) })
+	s.lock.Unlock()
+	s.subs.Add(1)
+	return sub, nil
+}
+
+func (s *chainService) Logs(ctx context.Context, crit map[string]interface{}) (*rpc.Subscription, error) {
+	notifier, _ := rpc.NotifierFromContext(ctx)
+	sub := notifier.CreateSubscription()
+
+	s.lock.Lock()
+	s.logs = append(s.logs, func(l *types.Log) { notifier.Notify(sub.ID, l) })
+	s.lock.Unlock()
+	s.subs.Add(1)
+	return sub, nil
+}
+
+// advance produces the next block, notifying the subscriptions made so far.
+func (s *chainService) advance() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.number++
+	for _, notify := range s.heads {
+		notify(s.header(s.number))
+	}
+	for _, notify := range s.logs {
+		notify(s.log(s.number))
+	}
+}
+
+// newChainEndpoint starts a websocket endpoint serving the chain, which can be
+// taken down by dropping all connections and refusing new ones.
+func newChainEndpoint(t *testing.T) (*chainService, string, func(bool)) {
+	t.Helper()
+
+	service := &chainService{number: 100}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	var (
+		handler = srv.WebsocketHandler([]string{"*"})
+		down    atomic.Bool
+		lock    sync.Mutex
+		conns   []net.Conn
+	)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if down.Load() {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	// Websocket connections are hijacked, track them to be able to drop them
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			lock.Lock()
+			conns = append(conns, conn)
+			lock.Unlock()
+		}
+	}
+	server.Start()
+	t.Cleanup(func() {
+		server.Close()
+		srv.Stop()
+	})
+	setDown := func(d bool) {
+		down.Store(d)
+		if d {
+			service.lock.Lock()
+			service.heads, service.logs = nil, nil
+			service.lock.Unlock()
+
+			lock.Lock()
+			for _, conn := range conns {
+				conn.Close()
+			}
+			conns = nil
+			lock.Unlock()
+		}
+	}
+	return service, "ws" + strings.TrimPrefix(server.URL, "http"), setDown
+}
+
+// waitSubscriptions waits until the service created the given number of
+// subscriptions.
+func waitSubscriptions(t *testing.T, service *chainService, n int32) {
+	t.Helper()
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if service.subs.Load() >= n {
+			return
+		}
+	}
+	t.Fatalf("no resubscription, have %d subscriptions, want %d", service.subs.Load(), n)
+}
+
+// receiveHead waits for the next head of a subscription.
+func receiveHead(t *testing.T, sub ethereum.Subscription, heads <-chan *types.Header, want uint64) {
+	t.Helper()
+	select {
+	case head := <-heads:
+		if head.Number.Uint64() != want {
+			t.Fatalf("head number mismatch: have %d, want %d", head.Number, want)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("head %d not received", want)
+	}
+}
+
+// receiveLog waits for the next log of a subscription.
+func receiveLog(t *testing.T, sub ethereum.Subscription, logs <-chan types.Log, want uint64) {
+	t.Helper()
+	select {
+	case log := <-logs:
+		if log.BlockNumber != want {
+			t.Fatalf("log block mismatch: have %d, want %d", log.BlockNumber, want)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("log of block %d not received", want)
+	}
+}
+
+func TestSubscribeNewHeadResilient(t *testing.T) {
+	t.Parallel()
+
+	service, url, setDown := newChainEndpoint(t)
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	var gaps [][2]uint64
+	heads := make(chan *types.Header)
+	sub, err := client.SubscribeNewHeadResilient(context.Background(), heads, ethclient.ResilientOptions{
+		MaxRetryInterval: 100 * time.Millisecond,
+		OnGap:            func(from, to uint64) { gaps = append(gaps, [2]uint64{from, to}) },
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	// Blocks produced while the connection is down must be replayed before the
+	// subscription continues
+	service.advance()
+	receiveHead(t, sub, heads, 101)
+	setDown(true)
+	service.advance()
+	service.advance()
+	setDown(false)
+	waitSubscriptions(t, service, 2)
+	service.advance()
+
+	for want := uint64(102); want <= 104; want++ {
+		receiveHead(t, sub, heads, want)
+	}
+	if len(gaps) != 1 || gaps[0] != [2]uint64{102, 103} {
+		t.Errorf("gaps mismatch: have %v, want [[102 103]]", gaps)
+	}
+}
+
+func TestSubscribeFilterLogsResilient(t *testing.T) {
+	t.Parallel()
+
+	service, url, setDown := newChainEndpoint(t)
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	logs := make(chan types.Log)
+	sub, err := client.SubscribeFilterLogsResilient(context.Background(), ethereum.FilterQuery{}, logs, ethclient.ResilientOptions{
+		MaxRetryInterval: 100 * time.Millisecond,
+		MaxBackfill:      2,
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	// Only the most recent logs missed must be replayed, without duplicates
+	service.advance()
+	receiveLog(t, sub, logs, 101)
+	setDown(true)
+	service.advance()
+	service.advance()
+	service.advance()
+	setDown(false)
+	waitSubscriptions(t, service, 2)
+	service.advance()
+
+	for _, want := range []uint64{103, 104, 105} {
+		receiveLog(t, sub, logs, want)
+	}
+	select {
+	case log := <-logs:
+		t.Fatalf("unexpected log of block %d", log.BlockNumber)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeResilientUnsupported(t *testing.T) {
+	t.Parallel()
+
+	// Subscriptions failing for good must not be retried
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("eth", &chainService{number: 100}); err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(srv)
+	defer server.Close()
+	defer srv.Stop()
+
+	client, err := ethclient.Dial(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.SubscribeNewHeadResilient(context.Background(), make(chan *types.Header), ethclient.ResilientOptions{}); err == nil {
+		t.Error("expected error subscribing to heads over HTTP")
+	}
+	if _, err := client.SubscribeFilterLogsResilient(context.Background(), ethereum.FilterQuery{}, make(chan types.Log), ethclient.ResilientOptions{}); err == nil {
+		t.Error("expected error subscribing to logs over HTTP")
+	}
+}
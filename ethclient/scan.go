@@ -0,0 +1,129 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	scanMaxRange      = 10000                  // Maximum number of blocks requested at once
+	scanRetries       = 5                      // Number of retries of failed requests
+	scanRetryInterval = 250 * time.Millisecond // Initial delay between retries, doubled on each one
+)
+
+// ScanHandler processes the logs of a block range scanned by ScanLogs. The
+// ranges are processed in order, so the end of the range reports the progress
+// of the scan. Returning an error aborts the scan.
+type ScanHandler func(from, to uint64, logs []types.Log) error
+
+// ScanLogs retrieves the logs matching the filter query between the from and to
+// blocks, inclusive, passing them to the handler range by range. The block
+// range of the query is ignored.
+//
+// The range is split into requests of at most 10000 blocks, which are split
+// further whenever the node rejects them for returning too many results or
+// spanning too many blocks, and grown again once they succeed. Other failures
+// are retried with backoff before the scan fails.
+func (ec *Client) ScanLogs(ctx context.Context, q ethereum.FilterQuery, from, to uint64, handler ScanHandler) error {
+	q.BlockHash = nil
+	size := uint64(scanMaxRange)
+
+	for from <= to {
+		end := to
+		if to-from >= size {
+			end = from + size - 1
+		}
+		q.FromBlock = new(big.Int).SetUint64(from)
+		q.ToBlock = new(big.Int).SetUint64(end)
+
+		logs, err := ec.scanRange(ctx, q)
+		if err != nil {
+			if !isLimitError(err) || from == end {
+				return err
+			}
+			size = (end - from + 1) / 2
+			log.Debug("Splitting log scan range", "from", from, "to", end, "size", size, "err", err)
+			continue
+		}
+		if err := handler(from, end, logs); err != nil {
+			return err
+		}
+		if end == to {
+			break
+		}
+		from, size = end+1, min(2*size, scanMaxRange)
+	}
+	return nil
+}
+
+// scanRange retrieves the logs of a block range, retrying failures which aren't
+// caused by the request itself.
+func (ec *Client) scanRange(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	wait := scanRetryInterval
+	for retries := 0; ; retries++ {
+		logs, err := ec.FilterLogs(ctx, q)
+		if err == nil || retries == scanRetries || isLimitError(err) || errors.Is(err, rpc.ErrClientQuit) || ctx.Err() != nil {
+			return logs, err
+		}
+		log.Debug("Retrying log scan", "from", q.FromBlock, "to", q.ToBlock, "err", err, "wait", wait)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// limitErrors are fragments of the errors returned by nodes and providers
+// when a log query returns too many results or spans too many blocks.
+var limitErrors = []string{
+	"too large",
+	"too big",
+	"too many",
+	"more than",
+	"limit exceeded",
+	"exceeds",
+	"block range",
+	"response size",
+}
+
+// isLimitError reports whether a log query failed for exceeding the limits of
+// the node, in which case it should be retried with a smaller block range.
+func isLimitError(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32005 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range limitErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// scanService is an eth namespace producing one log per block, rejecting log
+// queries spanning more blocks than its limit and failing the first query
+// for the given block.
+type scanService struct {
+	limit    uint64
+	failAt   uint64
+	requests int
+}
+
+func (s *scanService) GetLogs(crit map[string]interface{}) ([]*types.Log, error) {
+	s.requests++
+	from, _ := hexutil.DecodeUint64(crit["fromBlock"].(string))
+	to, _ := hexutil.DecodeUint64(crit["toBlock"].(string))
+
+	if from == s.failAt {
+		s.failAt = 0
+		return nil, errors.New("backend unavailable")
+	}
+	if to-from+1 > s.limit {
+		return nil, fmt.Errorf("query returned more than %d results", s.limit)
+	}
+	var logs []*types.Log
+	for n := from; n <= to; n++ {
+		logs = append(logs, &types.Log{BlockNumber: n, Topics: []common.Hash{}, Data: []byte{}})
+	}
+	return logs, nil
+}
+
+func TestScanLogs(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	service := &scanService{limit: 3000, failAt: 20001}
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	client := ethclient.NewClient(rpc.DialInProc(srv))
+	defer client.Close()
+
+	// All blocks must be scanned in order, in ranges within the limit
+	var (
+		next   = uint64(1)
+		ranges int
+	)
+	err := client.ScanLogs(context.Background(), ethereum.FilterQuery{}, 1, 25000, func(from, to uint64, logs []types.Log) error {
+		if from != next || to < from || to-from+1 > service.limit {
+			t.Fatalf("invalid range %d-%d, want it to start at %d", from, to, next)
+		}
+		for i, log := range logs {
+			if log.BlockNumber != from+uint64(i) {
+				t.Fatalf("log %d block mismatch: have %d, want %d", i, log.BlockNumber, from+uint64(i))
+			}
+		}
+		next, ranges = to+1, ranges+1
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if next != 25001 {
+		t.Errorf("scan ended at block %d, want 25000", next-1)
+	}
+	if service.failAt != 0 {
+		t.Error("failed request not retried")
+	}
+	if service.requests > 2*ranges+4 {
+		t.Errorf("too many requests: %d requests for %d ranges", service.requests, ranges)
+	}
+	// Handler errors must abort the scan
+	errAbort := errors.New("abort")
+	err = client.ScanLogs(context.Background(), ethereum.FilterQuery{}, 1, 10000, func(from, to uint64, logs []types.Log) error {
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("error mismatch: have %v, want %v", err, errAbort)
+	}
+}
@@ -18,6 +18,9 @@ package simulated
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -65,6 +68,10 @@ type Backend struct {
 	node   *node.Node
 	beacon *catalyst.SimulatedBeacon
 	client simClient
+
+	snapshots map[int]common.Hash // Chain heads recorded by Snapshot
+	nextSnap  int                 // Identifier of the next snapshot
+	snapLock  sync.Mutex          // Protects the snapshots
 }
 
 // NewBackend creates a new simulated blockchain that can be used as a backend for
@@ -129,9 +136,10 @@ func newWithNode(stack *node.Node, conf *eth.Config, blockPeriod uint64) (*Backe
 		return nil, err
 	}
 	return &Backend{
-		node:   stack,
-		beacon: beacon,
-		client: simClient{ethclient.NewClient(stack.Attach())},
+		node:      stack,
+		beacon:    beacon,
+		client:    simClient{ethclient.NewClient(stack.Attach())},
+		snapshots: make(map[int]common.Hash),
 	}, nil
 }
 
@@ -186,6 +194,56 @@ func (n *Backend) AdjustTime(adjustment time.Duration) error {
 	return n.beacon.AdjustTime(adjustment)
 }
 
+// SetNextBlockTime sets the timestamp of the next block created by Commit, which
+// must be after the timestamp of the current head block.
+func (n *Backend) SetNextBlockTime(timestamp time.Time) error {
+	if timestamp.Unix() < 0 {
+		return fmt.Errorf("invalid block timestamp %v", timestamp)
+	}
+	return n.beacon.SetNextBlockTime(uint64(timestamp.Unix()))
+}
+
+// SetNextBlockBaseFee sets the base fee of the next block created by Commit,
+// instead of the one derived from the parent block. The base fees of later blocks
+// are derived from it again.
+func (n *Backend) SetNextBlockBaseFee(baseFee *big.Int) error {
+	return n.beacon.SetNextBaseFee(baseFee)
+}
+
+// Snapshot records the current state of the chain and returns an identifier to
+// restore it with Revert. Pending transactions are not part of the snapshot.
+func (n *Backend) Snapshot() int {
+	n.snapLock.Lock()
+	defer n.snapLock.Unlock()
+
+	id := n.nextSnap
+	n.nextSnap++
+	n.snapshots[id] = n.beacon.Head()
+	return id
+}
+
+// Revert restores the state of the chain recorded by the given snapshot. The
+// blocks committed since are discarded along with all pending transactions.
+// The snapshot is consumed, along with all snapshots taken after it.
+func (n *Backend) Revert(id int) error {
+	n.snapLock.Lock()
+	defer n.snapLock.Unlock()
+
+	head, ok := n.snapshots[id]
+	if !ok {
+		return fmt.Errorf("unknown snapshot %d", id)
+	}
+	if err := n.beacon.Revert(head); err != nil {
+		return err
+	}
+	for snap := range n.snapshots {
+		if snap >= id {
+			delete(n.snapshots, snap)
+		}
+	}
+	return nil
+}
+
 // Client returns a client that accesses the simulated chain.
 func (n *Backend) Client() Client {
 	return n.client
@@ -22,6 +22,7 @@ import (
 	"crypto/sha256"
 	"math/big"
 	"math/rand"
+	"sync"
 	"testing"
 	"time"
 
@@ -340,6 +341,172 @@ func TestAdjustTimeAfterFork(t *testing.T) {
 	}
 }
 
+func TestSnapshotRevert(t *testing.T) {
+	t.Parallel()
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+
+	sim.Commit()
+	base, _ := client.HeaderByNumber(ctx, nil)
+	balance, _ := client.BalanceAt(ctx, testAddr, nil)
+	snap := sim.Snapshot()
+
+	// Include a transaction and take a nested snapshot
+	tx, err := newTx(sim, testKey, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	nested := sim.Snapshot()
+	sim.Commit()
+
+	// Reverting restores the chain and drops the included transaction
+	if err := sim.Revert(snap); err != nil {
+		t.Fatalf("failed to revert: %v", err)
+	}
+	head, _ := client.HeaderByNumber(ctx, nil)
+	if head.Hash() != base.Hash() {
+		t.Fatalf("wrong head after revert: have %d, want %d", head.Number, base.Number)
+	}
+	if have, _ := client.BalanceAt(ctx, testAddr, nil); have.Cmp(balance) != 0 {
+		t.Fatalf("wrong balance after revert: have %v, want %v", have, balance)
+	}
+	sim.Commit()
+	if _, err := client.TransactionReceipt(ctx, tx.Hash()); err == nil {
+		t.Fatal("reverted transaction included again")
+	}
+	// The snapshot and the ones taken after it are consumed
+	if err := sim.Revert(snap); err == nil {
+		t.Fatal("reverted to consumed snapshot")
+	}
+	if err := sim.Revert(nested); err == nil {
+		t.Fatal("reverted to snapshot taken after the reverted one")
+	}
+}
+
+func TestSnapshotConcurrent(t *testing.T) {
+	t.Parallel()
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	var (
+		wg  sync.WaitGroup
+		ids = make(chan int, 16)
+	)
+	for i := 0; i < cap(ids); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids <- sim.Snapshot()
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate snapshot id %d", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestSetNextBlockTime(t *testing.T) {
+	t.Parallel()
+	sim := NewBackend(types.GenesisAlloc{})
+	defer sim.Close()
+
+	client := sim.Client()
+	head, _ := client.HeaderByNumber(context.Background(), nil)
+
+	next := time.Unix(int64(head.Time), 0).Add(time.Hour)
+	if err := sim.SetNextBlockTime(next); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	head, _ = client.HeaderByNumber(context.Background(), nil)
+	if head.Time != uint64(next.Unix()) {
+		t.Fatalf("wrong block time: have %d, want %d", head.Time, next.Unix())
+	}
+	// Time can't go backwards
+	if err := sim.SetNextBlockTime(next); err == nil {
+		t.Fatal("block time not after the head accepted")
+	}
+}
+
+func TestSetNextBlockBaseFee(t *testing.T) {
+	t.Parallel()
+	sim := simTestBackend(testAddr)
+	defer sim.Close()
+
+	client := sim.Client()
+	ctx := context.Background()
+
+	parent, _ := client.HeaderByNumber(ctx, nil)
+	baseFee := new(big.Int).Div(parent.BaseFee, big.NewInt(2))
+	if err := sim.SetNextBlockBaseFee(baseFee); err != nil {
+		t.Fatal(err)
+	}
+	tx, err := newTx(sim, testKey, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+
+	head, _ := client.HeaderByNumber(ctx, nil)
+	if head.Number.Uint64() != parent.Number.Uint64()+1 || head.BaseFee.Cmp(baseFee) != 0 {
+		t.Fatalf("wrong base fee of block %d: have %v, want %v", head.Number, head.BaseFee, baseFee)
+	}
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatalf("transaction not included: %v", err)
+	}
+	if want := new(big.Int).Add(baseFee, tx.GasTipCap()); receipt.EffectiveGasPrice.Cmp(want) != 0 {
+		t.Fatalf("wrong effective gas price: have %v, want %v", receipt.EffectiveGasPrice, want)
+	}
+	// The override only applies to a single block, later ones derive their base fee
+	sim.Commit()
+	if next, _ := client.HeaderByNumber(ctx, nil); next.Number.Uint64() != head.Number.Uint64()+1 {
+		t.Fatalf("failed to commit block after overridden base fee")
+	}
+	if err := sim.SetNextBlockBaseFee(big.NewInt(-1)); err == nil {
+		t.Fatal("negative base fee accepted")
+	}
+}
+
+func TestSetNextBlockTimeConcurrent(t *testing.T) {
+	t.Parallel()
+	sim := NewBackend(types.GenesisAlloc{})
+	defer sim.Close()
+
+	head, _ := sim.Client().HeaderByNumber(context.Background(), nil)
+	next := time.Unix(int64(head.Time), 0)
+
+	// Blocks are committed while the time of the next one is being set
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 8; i++ {
+			sim.Commit()
+		}
+	}()
+	for i := 1; i <= 8; i++ {
+		sim.SetNextBlockTime(next.Add(time.Duration(i) * time.Hour))
+	}
+	wg.Wait()
+}
+
 func createAndCloseSimBackend() {
 	genesisData := types.GenesisAlloc{}
 	simulatedBackend := NewBackend(genesisData)
@@ -0,0 +1,78 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// timeoutMeter counts the requests which exceeded their deadline, whether set by
+// TimeoutMiddleware or by the caller. Exceedances are also counted per method,
+// under ethclient/timeout/<method>.
+var timeoutMeter = metrics.NewRegisteredMeter("ethclient/timeout", nil)
+
+// TimeoutConfig configures the timeouts of requests.
+type TimeoutConfig struct {
+	Default time.Duration            // Timeout of requests (0 = none)
+	Methods map[string]time.Duration // Timeouts of specific methods, overriding the default
+
+	// Batch is the timeout of batch requests (0 = the default). Subscription
+	// requests use the timeout of eth_subscribe, which only limits the time to
+	// set up the subscription.
+	Batch time.Duration
+}
+
+// TimeoutMiddleware returns a middleware limiting the time requests may take,
+// so call sites don't need to set deadlines themselves. Deadlines set by the
+// caller are kept if they are earlier.
+//
+//	client = client.WithMiddleware(ethclient.TimeoutMiddleware(ethclient.TimeoutConfig{
+//		Default: 10 * time.Second,
+//		Methods: map[string]time.Duration{"eth_getLogs": time.Minute},
+//	}))
+//
+// Requests exceeding their deadline are counted by the ethclient/timeout meters.
+func TimeoutMiddleware(config TimeoutConfig) Middleware {
+	return func(next Invoker) Invoker {
+		return func(ctx context.Context, req *Request) error {
+			method, timeout := req.Method, config.Default
+			if req.Batch != nil {
+				method = "batch"
+				if config.Batch > 0 {
+					timeout = config.Batch
+				}
+			} else if t, ok := config.Methods[req.Method]; ok {
+				timeout = t
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			err := next(ctx, req)
+			if errors.Is(err, context.DeadlineExceeded) {
+				timeoutMeter.Mark(1)
+				metrics.GetOrRegisterMeter("ethclient/timeout/"+method, nil).Mark(1)
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// slowService is an eth namespace responding after a delay set by the test.
+type slowService struct {
+	delay atomic.Int64
+}
+
+func (s *slowService) sleep(ctx context.Context) error {
+	select {
+	case <-time.After(time.Duration(s.delay.Load())):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *slowService) BlockNumber(ctx context.Context) (hexutil.Uint64, error) {
+	return 1, s.sleep(ctx)
+}
+
+func (s *slowService) ChainId(ctx context.Context) (*hexutil.Big, error) {
+	return (*hexutil.Big)(common.Big1), s.sleep(ctx)
+}
+
+func TestTimeoutMiddleware(t *testing.T) {
+	t.Parallel()
+
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	service := new(slowService)
+	if err := srv.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	base := ethclient.NewClient(rpc.DialInProc(srv))
+	defer base.Close()
+	client := base.WithMiddleware(ethclient.TimeoutMiddleware(ethclient.TimeoutConfig{
+		Default: 50 * time.Millisecond,
+		Methods: map[string]time.Duration{"eth_chainId": time.Second},
+	}))
+
+	exceeded := metrics.GetOrRegisterMeter("ethclient/timeout/eth_blockNumber", nil)
+	before := exceeded.Snapshot().Count()
+	ctx := context.Background()
+
+	// The default timeout must apply, and be counted once exceeded
+	service.delay.Store(int64(10 * time.Millisecond))
+	if _, err := client.BlockNumber(ctx); err != nil {
+		t.Errorf("fast request failed: %v", err)
+	}
+	service.delay.Store(int64(500 * time.Millisecond))
+	if _, err := client.BlockNumber(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error mismatch: have %v, want %v", err, context.DeadlineExceeded)
+	}
+	if count := exceeded.Snapshot().Count() - before; count != 1 {
+		t.Errorf("exceeded deadlines mismatch: have %d, want 1", count)
+	}
+	// Method timeouts must override the default
+	service.delay.Store(int64(200 * time.Millisecond))
+	if _, err := client.ChainID(ctx); err != nil {
+		t.Errorf("request with method timeout failed: %v", err)
+	}
+	// Earlier deadlines of the caller must be kept
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.ChainID(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error mismatch: have %v, want %v", err, context.DeadlineExceeded)
+	}
+}
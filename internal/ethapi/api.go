@@ -28,6 +28,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/audit"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
@@ -1528,12 +1529,25 @@ func MarshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber u
 	return fields
 }
 
+// findWallet looks up the wallet containing the requested signer, attributing
+// its signing operations to the RPC client in the audit log, if one is kept.
+func findWallet(ctx context.Context, am *accounts.Manager, account accounts.Account) (accounts.Wallet, error) {
+	wallet, err := am.Find(account)
+	if err != nil {
+		return nil, err
+	}
+	if info := rpc.PeerInfoFromContext(ctx); info.Transport != "" {
+		wallet = audit.WithOrigin(wallet, info.Transport+" "+info.RemoteAddr)
+	}
+	return wallet, nil
+}
+
 // sign is a helper function that signs a transaction with the private key of the given address.
-func (api *TransactionAPI) sign(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+func (api *TransactionAPI) sign(ctx context.Context, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
 	// Look up the wallet containing the requested signer
 	account := accounts.Account{Address: addr}
 
-	wallet, err := api.b.AccountManager().Find(account)
+	wallet, err := findWallet(ctx, api.b.AccountManager(), account)
 	if err != nil {
 		return nil, err
 	}
@@ -1580,7 +1594,7 @@ func (api *TransactionAPI) SendTransaction(ctx context.Context, args Transaction
 	// Look up the wallet containing the requested signer
 	account := accounts.Account{Address: args.from()}
 
-	wallet, err := api.b.AccountManager().Find(account)
+	wallet, err := findWallet(ctx, api.b.AccountManager(), account)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -1767,11 +1781,11 @@ func (api *TransactionAPI) SendRawTransactionSync(ctx context.Context, input hex
 // The account associated with addr must be unlocked.
 //
 // https://ethereum.org/en/developers/docs/apis/json-rpc/#eth_sign
-func (api *TransactionAPI) Sign(addr common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+func (api *TransactionAPI) Sign(ctx context.Context, addr common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
 	// Look up the wallet containing the requested signer
 	account := accounts.Account{Address: addr}
 
-	wallet, err := api.b.AccountManager().Find(account)
+	wallet, err := findWallet(ctx, api.b.AccountManager(), account)
 	if err != nil {
 		return nil, err
 	}
@@ -1822,7 +1836,7 @@ func (api *TransactionAPI) SignTransaction(ctx context.Context, args Transaction
 	if err := checkTxFee(tx.GasPrice(), tx.Gas(), api.b.RPCTxFeeCap()); err != nil {
 		return nil, err
 	}
-	signed, err := api.sign(args.from(), tx)
+	signed, err := api.sign(ctx, args.from(), tx)
 	if err != nil {
 		return nil, err
 	}
@@ -1904,7 +1918,7 @@ func (api *TransactionAPI) Resend(ctx context.Context, sendArgs TransactionArgs,
 			if gasLimit != nil && *gasLimit != 0 {
 				sendArgs.Gas = gasLimit
 			}
-			signedTx, err := api.sign(sendArgs.from(), sendArgs.ToTransaction(types.DynamicFeeTxType))
+			signedTx, err := api.sign(ctx, sendArgs.from(), sendArgs.ToTransaction(types.DynamicFeeTxType))
 			if err != nil {
 				return common.Hash{}, err
 			}
@@ -181,6 +181,9 @@ func (api *adminAPI) StartHTTP(host *string, port *int, cors *string, apis *stri
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimits:             api.node.config.RPCRateLimits,
+			callBudget:             api.node.config.RPCCallBudget,
+			slowCallThreshold:      api.node.config.RPCSlowCallThreshold,
 		},
 	}
 	if cors != nil {
@@ -259,6 +262,9 @@ func (api *adminAPI) StartWS(host *string, port *int, allowedOrigins *string, ap
 		rpcEndpointConfig: rpcEndpointConfig{
 			batchItemLimit:         api.node.config.BatchRequestLimit,
 			batchResponseSizeLimit: api.node.config.BatchResponseMaxSize,
+			rateLimits:             api.node.config.RPCRateLimits,
+			callBudget:             api.node.config.RPCCallBudget,
+			slowCallThreshold:      api.node.config.RPCSlowCallThreshold,
 		},
 	}
 	if apis != nil {
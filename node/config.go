@@ -24,6 +24,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -96,6 +97,29 @@ type Config struct {
 	// SmartCardDaemonPath is the path to the smartcard daemon's socket.
 	SmartCardDaemonPath string `toml:",omitempty"`
 
+	// PKCS11Library is the path to the PKCS#11 library of a hardware security
+	// module, whose secp256k1 keys are made available as accounts.
+	PKCS11Library string `toml:",omitempty"`
+
+	// Web3Signer is the URL of a Web3Signer remote signer, whose keys are made
+	// available as accounts next to the local ones.
+	Web3Signer string `toml:",omitempty"`
+
+	// Web3SignerClientCert and Web3SignerClientKey are the paths to the client
+	// certificate and key authenticating to the remote signer over TLS, and
+	// Web3SignerCACert the path to the CA certificate verifying the signer.
+	Web3SignerClientCert string `toml:",omitempty"`
+	Web3SignerClientKey  string `toml:",omitempty"`
+	Web3SignerCACert     string `toml:",omitempty"`
+
+	// SigningAuditLog is the path to an append-only log recording the signing
+	// operations of all the accounts. An empty path disables the log.
+	SigningAuditLog string `toml:",omitempty"`
+
+	// WatchAddresses are addresses without private keys, which are listed as
+	// watch-only accounts that refuse to sign.
+	WatchAddresses []common.Address `toml:",omitempty"`
+
 	// IPCPath is the requested location to place the IPC endpoint. If the path is
 	// a simple file name, it is placed inside the data directory (or on the root
 	// pipe path on Windows), whereas if it's a resolvable path name (absolute or
@@ -176,6 +200,19 @@ type Config struct {
 	// private APIs to untrusted users is a major security risk.
 	WSExposeAll bool `toml:",omitempty"`
 
+	// GRPCHost is the host interface on which to start the gRPC server. If this
+	// field is empty, no gRPC endpoint will be started.
+	GRPCHost string `toml:",omitempty"`
+
+	// GRPCPort is the TCP port number on which to start the gRPC server. The
+	// default zero value is valid and will pick a port number randomly.
+	GRPCPort int `toml:",omitempty"`
+
+	// GRPCModules is a list of API modules to expose via the gRPC interface. If
+	// the module list is empty, all RPC API endpoints designated public will be
+	// exposed.
+	GRPCModules []string `toml:",omitempty"`
+
 	// GraphQLCors is the Cross-Origin Resource Sharing header to send to requesting
 	// clients. Please be aware that CORS is a browser enforced security, it's fully
 	// useless for custom HTTP clients.
@@ -204,6 +241,18 @@ type Config struct {
 	// BatchResponseMaxSize is the maximum number of bytes returned from a batched rpc call.
 	BatchResponseMaxSize int `toml:",omitempty"`
 
+	// RPCRateLimits are the rate limits of the calls served over HTTP and WebSocket,
+	// keyed by method name, e.g. "debug_traceCall", or by namespace, e.g. "debug".
+	RPCRateLimits map[string]rpc.RateLimit `toml:",omitempty"`
+
+	// RPCCallBudget is the maximum execution time of the requests served over HTTP
+	// and WebSocket. Zero leaves requests limited by the transport timeouts only.
+	RPCCallBudget time.Duration `toml:",omitempty"`
+
+	// RPCSlowCallThreshold is the execution time above which calls served over HTTP
+	// and WebSocket are logged as slow. Zero disables slow call logging.
+	RPCSlowCallThreshold time.Duration `toml:",omitempty"`
+
 	// JWTSecret is the path to the hex-encoded jwt secret.
 	JWTSecret string `toml:",omitempty"`
 
@@ -288,10 +337,19 @@ func DefaultWSEndpoint() string {
 	return config.WSEndpoint()
 }
 
+// GRPCEndpoint resolves a gRPC endpoint based on the configured host interface
+// and port parameters.
+func (c *Config) GRPCEndpoint() string {
+	if c.GRPCHost == "" {
+		return ""
+	}
+	return net.JoinHostPort(c.GRPCHost, fmt.Sprintf("%d", c.GRPCPort))
+}
+
 // ExtRPCEnabled returns the indicator whether node enables the external
-// RPC(http, ws or graphql).
+// RPC(http, ws, grpc or graphql).
 func (c *Config) ExtRPCEnabled() bool {
-	return c.HTTPHost != "" || c.WSHost != ""
+	return c.HTTPHost != "" || c.WSHost != "" || c.GRPCHost != ""
 }
 
 // NodeName returns the devp2p node identifier.
@@ -19,6 +19,7 @@ package node
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/rpc"
@@ -31,10 +32,25 @@ import (
 // See https://github.com/ethereum/execution-apis/blob/main/src/engine/authentication.md
 // for more details about this authentication scheme.
 func NewJWTAuth(jwtsecret [32]byte) rpc.HTTPAuth {
+	return newJWTAuth(jwtsecret, nil)
+}
+
+// NewScopedJWTAuth creates an rpc client authentication provider that uses JWT,
+// like NewJWTAuth, with tokens restricting the calls of the client to the given
+// namespaces, e.g. "eth", and methods, e.g. "debug_traceCall".
+func NewScopedJWTAuth(jwtsecret [32]byte, scope []string) rpc.HTTPAuth {
+	return newJWTAuth(jwtsecret, &scope)
+}
+
+func newJWTAuth(jwtsecret [32]byte, scope *[]string) rpc.HTTPAuth {
 	return func(h http.Header) error {
-		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claims := jwt.MapClaims{
 			"iat": &jwt.NumericDate{Time: time.Now()},
-		})
+		}
+		if scope != nil {
+			claims["scope"] = strings.Join(*scope, " ")
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 		s, err := token.SignedString(jwtsecret[:])
 		if err != nil {
 			return fmt.Errorf("failed to create JWT token: %w", err)
@@ -21,11 +21,21 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 const jwtExpiryTimeout = 60 * time.Second
 
+// jwtClaims are the claims of the tokens accepted by the JWT handler.
+type jwtClaims struct {
+	jwt.RegisteredClaims
+
+	// Scope optionally restricts the calls of the request to a space separated list
+	// of namespaces and methods, e.g. "eth net debug_traceCall".
+	Scope *string `json:"scope,omitempty"`
+}
+
 type jwtHandler struct {
 	keyFunc func(token *jwt.Token) (interface{}, error)
 	next    http.Handler
@@ -45,7 +55,7 @@ func newJWTHandler(secret []byte, next http.Handler) http.Handler {
 func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 	var (
 		strToken string
-		claims   jwt.RegisteredClaims
+		claims   jwtClaims
 	)
 	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
 		strToken = strings.TrimPrefix(auth, "Bearer ")
@@ -74,6 +84,9 @@ func (handler *jwtHandler) ServeHTTP(out http.ResponseWriter, r *http.Request) {
 		http.Error(out, "stale token", http.StatusUnauthorized)
 	case time.Until(claims.IssuedAt.Time) > jwtExpiryTimeout:
 		http.Error(out, "future token", http.StatusUnauthorized)
+	case claims.Scope != nil:
+		ctx := rpc.WithScope(r.Context(), strings.Fields(*claims.Scope))
+		handler.next.ServeHTTP(out, r.WithContext(ctx))
 	default:
 		handler.next.ServeHTTP(out, r)
 	}
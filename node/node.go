@@ -63,6 +63,7 @@ type Node struct {
 	ws            *httpServer //
 	httpAuth      *httpServer //
 	wsAuth        *httpServer //
+	grpc          *httpServer //
 	ipc           *ipcServer  // Stores information about the ipc http server
 	inprocHandler *rpc.Server // In-process RPC request handler to process the API requests
 
@@ -154,6 +155,7 @@ func New(conf *Config) (*Node, error) {
 	node.httpAuth = newHTTPServer(node.log, conf.HTTPTimeouts)
 	node.ws = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.wsAuth = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
+	node.grpc = newHTTPServer(node.log, rpc.DefaultHTTPTimeouts)
 	node.ipc = newIPCServer(node.log, conf.IPCEndpoint())
 
 	return node, nil
@@ -393,6 +395,9 @@ func (n *Node) startRPC() error {
 	rpcConfig := rpcEndpointConfig{
 		batchItemLimit:         n.config.BatchRequestLimit,
 		batchResponseSizeLimit: n.config.BatchResponseMaxSize,
+		rateLimits:             n.config.RPCRateLimits,
+		callBudget:             n.config.RPCCallBudget,
+		slowCallThreshold:      n.config.RPCSlowCallThreshold,
 	}
 
 	initHttp := func(server *httpServer, port int) error {
@@ -484,6 +489,19 @@ func (n *Node) startRPC() error {
 			return err
 		}
 	}
+	// Configure gRPC.
+	if n.config.GRPCHost != "" {
+		if err := n.grpc.setListenAddr(n.config.GRPCHost, n.config.GRPCPort); err != nil {
+			return err
+		}
+		if err := n.grpc.enableGRPC(openAPIs, grpcConfig{
+			Modules:           n.config.GRPCModules,
+			rpcEndpointConfig: rpcConfig,
+		}); err != nil {
+			return err
+		}
+		servers = append(servers, n.grpc)
+	}
 	// Configure authenticated API
 	if len(openAPIs) != len(allAPIs) {
 		jwtSecret, err := n.obtainJWTSecret(n.config.JWTSecret)
@@ -519,6 +537,7 @@ func (n *Node) stopRPC() {
 	n.ws.stop()
 	n.httpAuth.stop()
 	n.wsAuth.stop()
+	n.grpc.stop()
 	n.ipc.stop()
 	n.stopInProc()
 }
@@ -677,6 +696,11 @@ func (n *Node) WSEndpoint() string {
 	return "ws://" + n.ws.listenAddr() + n.ws.wsConfig.prefix
 }
 
+// GRPCEndpoint returns the address of the gRPC server.
+func (n *Node) GRPCEndpoint() string {
+	return n.grpc.listenAddr()
+}
+
 // HTTPAuthEndpoint returns the URL of the authenticated HTTP server.
 func (n *Node) HTTPAuthEndpoint() string {
 	return "http://" + n.httpAuth.listenAddr()
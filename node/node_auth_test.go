@@ -197,6 +197,58 @@ func TestAuthEndpoints(t *testing.T) {
 	}
 }
 
+func TestScopedAuth(t *testing.T) {
+	var secret [32]byte
+	if _, err := crand.Read(secret[:]); err != nil {
+		t.Fatalf("failed to create jwt secret: %v", err)
+	}
+	jwtPath := filepath.Join(t.TempDir(), "jwt_secret")
+	if err := os.WriteFile(jwtPath, []byte(hexutil.Encode(secret[:])), 0600); err != nil {
+		t.Fatalf("failed to prepare jwt secret file: %v", err)
+	}
+	node, err := New(&Config{AuthAddr: "127.0.0.1", AuthPort: 0, JWTSecret: jwtPath})
+	if err != nil {
+		t.Fatalf("could not create a new node: %v", err)
+	}
+	node.RegisterAPIs([]rpc.API{
+		{Namespace: "engine", Service: helloRPC("hello engine"), Authenticated: true},
+		{Namespace: "eth", Service: helloRPC("hello eth"), Authenticated: true},
+	})
+	if err := node.Start(); err != nil {
+		t.Fatalf("failed to start test node: %v", err)
+	}
+	defer node.Close()
+
+	tests := []struct {
+		auth    rpc.HTTPAuth
+		allowed map[string]bool
+	}{
+		{NewJWTAuth(secret), map[string]bool{"engine_helloWorld": true, "eth_helloWorld": true}},
+		{NewScopedJWTAuth(secret, []string{"eth"}), map[string]bool{"eth_helloWorld": true}},
+		{NewScopedJWTAuth(secret, []string{"eth_chainId", "engine_helloWorld"}), map[string]bool{"engine_helloWorld": true}},
+		{NewScopedJWTAuth(secret, nil), map[string]bool{}},
+	}
+	for i, tt := range tests {
+		for _, endpoint := range []string{node.HTTPAuthEndpoint(), node.WSAuthEndpoint()} {
+			client, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPAuth(tt.auth))
+			if err != nil {
+				t.Fatalf("test %d, %s: failed to dial: %v", i, endpoint, err)
+			}
+			for _, method := range []string{"engine_helloWorld", "eth_helloWorld"} {
+				var result string
+				err := client.Call(&result, method)
+				if tt.allowed[method] && err != nil {
+					t.Errorf("test %d, %s: %s failed: %v", i, endpoint, method, err)
+				}
+				if !tt.allowed[method] && (err == nil || err.Error() != "method "+method+" not allowed") {
+					t.Errorf("test %d, %s: %s error mismatch: %v", i, endpoint, method, err)
+				}
+			}
+			client.Close()
+		}
+	}
+}
+
 func noneAuth(secret [32]byte) rpc.HTTPAuth {
 	return func(header http.Header) error {
 		token := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
@@ -53,11 +53,35 @@ type wsConfig struct {
 	rpcEndpointConfig
 }
 
+// grpcConfig is the gRPC configuration.
+type grpcConfig struct {
+	Modules []string
+	rpcEndpointConfig
+}
+
 type rpcEndpointConfig struct {
 	jwtSecret              []byte // optional JWT secret
 	batchItemLimit         int
 	batchResponseSizeLimit int
 	httpBodyLimit          int
+	rateLimits             map[string]rpc.RateLimit
+	callBudget             time.Duration
+	slowCallThreshold      time.Duration
+}
+
+// newServer creates an RPC server with the configured limits.
+func (config rpcEndpointConfig) newServer() *rpc.Server {
+	srv := rpc.NewServer()
+	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
+	if config.httpBodyLimit > 0 {
+		srv.SetHTTPBodyLimit(config.httpBodyLimit)
+	}
+	if len(config.rateLimits) > 0 {
+		srv.SetRateLimits(config.rateLimits)
+	}
+	srv.SetCallBudget(config.callBudget)
+	srv.SetSlowCallThreshold(config.slowCallThreshold)
+	return srv
 }
 
 type rpcHandler struct {
@@ -84,6 +108,10 @@ type httpServer struct {
 	wsConfig  wsConfig
 	wsHandler atomic.Pointer[rpcHandler]
 
+	// gRPC handler things.
+	grpcConfig  grpcConfig
+	grpcHandler atomic.Pointer[rpcHandler]
+
 	// These are set by setListenAddr.
 	endpoint string
 	host     string
@@ -145,6 +173,14 @@ func (h *httpServer) start() error {
 		h.server.WriteTimeout = h.timeouts.WriteTimeout
 		h.server.IdleTimeout = h.timeouts.IdleTimeout
 	}
+	if h.grpcAllowed() {
+		// gRPC runs over unencrypted HTTP/2, and streams subscriptions which must
+		// not be cut off by the write timeout. Calls are bounded by their deadlines.
+		h.server.Protocols = new(http.Protocols)
+		h.server.Protocols.SetHTTP1(true)
+		h.server.Protocols.SetUnencryptedHTTP2(true)
+		h.server.WriteTimeout = 0
+	}
 
 	// Start the server.
 	listener, err := net.Listen("tcp", h.endpoint)
@@ -153,6 +189,7 @@ func (h *httpServer) start() error {
 		// configuration so they can be configured another time.
 		h.disableRPC()
 		h.disableWS()
+		h.disableGRPC()
 		return err
 	}
 	h.listener = listener
@@ -165,6 +202,9 @@ func (h *httpServer) start() error {
 		}
 		h.log.Info("WebSocket enabled", "url", url)
 	}
+	if h.grpcAllowed() {
+		h.log.Info("gRPC server started", "endpoint", listener.Addr())
+	}
 	// if server is websocket only, return after logging
 	if !h.rpcAllowed() {
 		return nil
@@ -204,6 +244,12 @@ func (h *httpServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// check if gRPC request and serve if gRPC enabled
+	if grpc := h.grpcHandler.Load(); grpc != nil && isGRPC(r) {
+		grpc.ServeHTTP(w, r)
+		return
+	}
+
 	// if http-rpc is enabled, try to serve request
 	rpc := h.httpHandler.Load()
 	if rpc != nil {
@@ -275,6 +321,7 @@ func (h *httpServer) doStop() {
 		h.wsHandler.Store(nil)
 		wsHandler.server.Stop()
 	}
+	h.disableGRPC()
 
 	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
 	defer cancel()
@@ -302,11 +349,7 @@ func (h *httpServer) enableRPC(apis []rpc.API, config httpConfig) error {
 	}
 
 	// Create RPC server and handler.
-	srv := rpc.NewServer()
-	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
-	if config.httpBodyLimit > 0 {
-		srv.SetHTTPBodyLimit(config.httpBodyLimit)
-	}
+	srv := config.newServer()
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
@@ -338,11 +381,7 @@ func (h *httpServer) enableWS(apis []rpc.API, config wsConfig) error {
 		return errors.New("JSON-RPC over WebSocket is already enabled")
 	}
 	// Create RPC server and handler.
-	srv := rpc.NewServer()
-	srv.SetBatchLimits(config.batchItemLimit, config.batchResponseSizeLimit)
-	if config.httpBodyLimit > 0 {
-		srv.SetHTTPBodyLimit(config.httpBodyLimit)
-	}
+	srv := config.newServer()
 	if err := RegisterApis(apis, config.Modules, srv); err != nil {
 		return err
 	}
@@ -377,6 +416,42 @@ func (h *httpServer) disableWS() bool {
 	return ws != nil
 }
 
+// enableGRPC turns on gRPC on the server.
+func (h *httpServer) enableGRPC(apis []rpc.API, config grpcConfig) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.grpcAllowed() {
+		return errors.New("gRPC is already enabled")
+	}
+	// Create RPC server and handler.
+	srv := config.newServer()
+	if err := RegisterApis(apis, config.Modules, srv); err != nil {
+		return err
+	}
+	h.grpcConfig = config
+	h.grpcHandler.Store(&rpcHandler{
+		Handler: srv.GRPCHandler(),
+		server:  srv,
+	})
+	return nil
+}
+
+// disableGRPC disables the gRPC handler. This is internal, the caller must hold h.mu.
+func (h *httpServer) disableGRPC() bool {
+	grpc := h.grpcHandler.Load()
+	if grpc != nil {
+		h.grpcHandler.Store(nil)
+		grpc.server.Stop()
+	}
+	return grpc != nil
+}
+
+// grpcAllowed returns true when gRPC is enabled.
+func (h *httpServer) grpcAllowed() bool {
+	return h.grpcHandler.Load() != nil
+}
+
 // rpcAllowed returns true when JSON-RPC over HTTP is enabled.
 func (h *httpServer) rpcAllowed() bool {
 	return h.httpHandler.Load() != nil
@@ -387,6 +462,11 @@ func (h *httpServer) wsAllowed() bool {
 	return h.wsHandler.Load() != nil
 }
 
+// isGRPC checks the header of an http request for a gRPC call.
+func isGRPC(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
 // isWebsocket checks the header of an http request for a websocket upgrade request.
 func isWebsocket(r *http.Request) bool {
 	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
@@ -561,6 +641,11 @@ func (w *gzipResponseWriter) Flush() {
 	}
 }
 
+// Unwrap returns the wrapped response, so that http.ResponseController can reach it.
+func (w *gzipResponseWriter) Unwrap() http.ResponseWriter {
+	return w.resp
+}
+
 func (w *gzipResponseWriter) close() {
 	if w.gz == nil {
 		return
@@ -18,6 +18,7 @@ package node
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,9 +32,11 @@ import (
 	"github.com/ethereum/go-ethereum/internal/testlog"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/rpc/grpcpb"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 const testMethod = "rpc_modules"
@@ -593,6 +596,100 @@ func TestHTTPWriteTimeout(t *testing.T) {
 	})
 }
 
+func TestHTTPRateLimits(t *testing.T) {
+	t.Parallel()
+
+	conf := &httpConfig{
+		Modules: []string{"test"},
+		rpcEndpointConfig: rpcEndpointConfig{
+			rateLimits: map[string]rpc.RateLimit{"test_greet": {Rate: 0.001, Burst: 1}},
+		},
+	}
+	srv := createAndStartServer(t, conf, false, &wsConfig{}, nil)
+	url := fmt.Sprintf("http://%v", srv.listenAddr())
+
+	for i, want := range []string{`"result":"Hello"`, `"code":-32005`} {
+		body, err := io.ReadAll(rpcRequest(t, url, "test_greet").Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), want) {
+			t.Errorf("request %d: wrong response: have %s, want %s", i, body, want)
+		}
+	}
+}
+
+func TestHTTPCallBudget(t *testing.T) {
+	t.Parallel()
+
+	conf := &httpConfig{
+		Modules: []string{"test"},
+		rpcEndpointConfig: rpcEndpointConfig{
+			callBudget: 100 * time.Millisecond,
+		},
+	}
+	srv := createAndStartServer(t, conf, false, &wsConfig{}, nil)
+	url := fmt.Sprintf("http://%v", srv.listenAddr())
+
+	start := time.Now()
+	body, err := io.ReadAll(rpcRequest(t, url, "test_sleep").Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), `"code":-32002`) {
+		t.Errorf("wrong response: have %s, want timeout error", body)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("call not canceled by budget, took %v", elapsed)
+	}
+}
+
+// TestGRPC makes sure the gRPC endpoint of the node is started from the config
+// and serves the configured modules.
+func TestGRPC(t *testing.T) {
+	t.Parallel()
+
+	node, err := New(&Config{GRPCHost: "127.0.0.1", GRPCModules: []string{"test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer node.Close()
+	node.RegisterAPIs(apis())
+	if err := node.Start(); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := proto.Marshal(&grpcpb.CallRequest{Method: "test_greet"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := binary.BigEndian.AppendUint32([]byte{0}, uint32(len(enc)))
+	req, _ := http.NewRequest(http.MethodPost, "http://"+node.GRPCEndpoint()+"/ethereum.rpc.v1.JSONRPC/Call", bytes.NewReader(append(frame, enc...)))
+	req.Header.Set("content-type", "application/grpc")
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := resp.Trailer.Get("grpc-status"); status != "0" || len(body) < 5 {
+		t.Fatalf("call failed: status %q, body %x", status, body)
+	}
+	result := new(grpcpb.CallResponse)
+	if err := proto.Unmarshal(body[5:], result); err != nil {
+		t.Fatal(err)
+	}
+	if string(result.Result) != `"Hello"` {
+		t.Errorf("wrong result: have %s, want \"Hello\"", result.Result)
+	}
+}
+
 func apis() []rpc.API {
 	return []rpc.API{
 		{
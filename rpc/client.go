@@ -88,6 +88,9 @@ type Client struct {
 	// config fields
 	batchItemLimit       int
 	batchResponseMaxSize int
+	rateLimiter          *rateLimiter
+	callBudget           time.Duration
+	slowCallThreshold    time.Duration
 
 	// writeConn is used for writing to the connection on the caller's goroutine. It should
 	// only be accessed outside of dispatch, with the write lock held. The write lock is
@@ -119,7 +122,14 @@ func (c *Client) newClientConn(conn ServerCodec) *clientConn {
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, clientContextKey{}, c)
 	ctx = context.WithValue(ctx, peerInfoContextKey{}, conn.peerInfo())
+	if sc, ok := conn.(scopedConn); ok {
+		if scope, restricted := sc.scope(); restricted {
+			ctx = WithScope(ctx, scope)
+		}
+	}
 	handler := newHandler(ctx, conn, c.idgen, c.services, c.batchItemLimit, c.batchResponseMaxSize, nil)
+	handler.limits = newConnLimits(c.rateLimiter)
+	handler.callBudget, handler.slowCallThreshold = c.callBudget, c.slowCallThreshold
 	return &clientConn{conn, handler}
 }
 
@@ -138,9 +148,10 @@ type readOp struct {
 type requestOp struct {
 	ids         []json.RawMessage
 	err         error
-	resp        chan []*jsonrpcMessage // the response goes here
-	sub         *ClientSubscription    // set for Subscribe requests.
-	hadResponse bool                   // true when the request was responded to
+	resp        chan []*jsonrpcMessage   // the response goes here
+	sub         *ClientSubscription      // set for Subscribe requests.
+	hadResponse bool                     // true when the request was responded to
+	streams     map[string]*streamResult // chunks of streamed results, by request id
 }
 
 func (op *requestOp) wait(ctx context.Context, c *Client) ([]*jsonrpcMessage, error) {
@@ -247,6 +258,9 @@ func initClient(conn ServerCodec, services *serviceRegistry, cfg *clientConfig)
 		idgen:                cfg.idgen,
 		batchItemLimit:       cfg.batchItemLimit,
 		batchResponseMaxSize: cfg.batchResponseLimit,
+		rateLimiter:          cfg.rateLimiter,
+		callBudget:           cfg.callBudget,
+		slowCallThreshold:    cfg.slowCallThreshold,
 		writeConn:            conn,
 		close:                make(chan struct{}),
 		closing:              make(chan struct{}),
@@ -18,6 +18,7 @@ package rpc
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
@@ -35,12 +36,17 @@ type clientConfig struct {
 
 	// WebSocket options
 	wsDialer           *websocket.Dialer
-	wsMessageSizeLimit *int64 // wsMessageSizeLimit nil = default, 0 = no limit
+	wsMessageSizeLimit *int64        // wsMessageSizeLimit nil = default, 0 = no limit
+	wsPingInterval     time.Duration // 0 = default
+	wsPongTimeout      time.Duration // 0 = default
 
 	// RPC handler options
 	idgen              func() ID
 	batchItemLimit     int
 	batchResponseLimit int
+	rateLimiter        *rateLimiter
+	callBudget         time.Duration
+	slowCallThreshold  time.Duration
 }
 
 func (cfg *clientConfig) initHeaders() {
@@ -75,6 +81,22 @@ func WithWebsocketMessageSizeLimit(messageSizeLimit int64) ClientOption {
 	})
 }
 
+// WithWebsocketPingInterval configures the idle time after which the RPC client
+// pings the server over websocket connections, to keep them alive.
+func WithWebsocketPingInterval(interval time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.wsPingInterval = interval
+	})
+}
+
+// WithWebsocketPongTimeout configures the time the RPC client waits for the server to
+// answer a ping over websocket connections, before dropping the connection.
+func WithWebsocketPongTimeout(timeout time.Duration) ClientOption {
+	return optionFunc(func(cfg *clientConfig) {
+		cfg.wsPongTimeout = timeout
+	})
+}
+
 // WithHeader configures HTTP headers set by the RPC client. Headers set using this option
 // will be used for both HTTP and WebSocket connections.
 func WithHeader(key, value string) ClientOption {
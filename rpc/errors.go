@@ -61,6 +61,8 @@ const (
 	errcodeDefault          = -32000
 	errcodeTimeout          = -32002
 	errcodeResponseTooLarge = -32003
+	errcodeLimitExceeded    = -32005
+	errcodeUnauthorized     = -32006
 	errcodePanic            = -32603
 	errcodeMarshalError     = -32603
 
@@ -0,0 +1,621 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc/grpcpb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcPackage is the path prefix of the services defined in grpcpb/jsonrpc.proto.
+const grpcPackage = "/ethereum.rpc.v1."
+
+// grpcSubscribePath is the path of the streaming Subscribe method.
+const grpcSubscribePath = grpcPackage + "JSONRPC/Subscribe"
+
+// gRPC status codes.
+const (
+	grpcStatusOK               = 0
+	grpcStatusUnknown          = 2
+	grpcStatusInvalidArgument  = 3
+	grpcStatusDeadlineExceeded = 4
+	grpcStatusUnimplemented    = 12
+	grpcStatusInternal         = 13
+	grpcStatusUnavailable      = 14
+)
+
+var errGRPCStreamClosed = errors.New("gRPC stream closed")
+
+// grpcError is an error ending a gRPC call with a non-OK status.
+type grpcError struct {
+	status  int
+	message string
+}
+
+func (e *grpcError) Error() string { return e.message }
+
+// newGRPCError converts a JSON-RPC error into a gRPC status.
+func newGRPCError(err *jsonError) *grpcError {
+	status := grpcStatusUnknown
+	switch err.Code {
+	case -32601:
+		status = grpcStatusUnimplemented
+	case -32602:
+		status = grpcStatusInvalidArgument
+	case errcodeTimeout:
+		status = grpcStatusDeadlineExceeded
+	}
+	return &grpcError{status: status, message: err.Message}
+}
+
+// grpcMethod maps a unary gRPC method to a JSON-RPC call.
+type grpcMethod struct {
+	newRequest func() proto.Message
+	call       func(req proto.Message) (*jsonrpcMessage, error)  // JSON-RPC call of the request
+	response   func(resp *jsonrpcMessage) (proto.Message, error) // gRPC response of the JSON-RPC response
+}
+
+// grpcMethods are the unary methods of the services, keyed by path.
+var grpcMethods = map[string]grpcMethod{
+	grpcPackage + "JSONRPC/Call": {
+		newRequest: func() proto.Message { return new(grpcpb.CallRequest) },
+		call: func(req proto.Message) (*jsonrpcMessage, error) {
+			call := req.(*grpcpb.CallRequest)
+			if call.Method == "" {
+				return nil, &grpcError{status: grpcStatusInvalidArgument, message: "missing method"}
+			}
+			return &jsonrpcMessage{Method: call.Method, Params: call.Params}, nil
+		},
+		response: func(resp *jsonrpcMessage) (proto.Message, error) {
+			// Errors of generic calls are returned in the response
+			if resp.Error == nil {
+				return &grpcpb.CallResponse{Result: resp.Result}, nil
+			}
+			rpcErr := &grpcpb.Error{Code: int64(resp.Error.Code), Message: resp.Error.Message}
+			if resp.Error.Data != nil {
+				rpcErr.Data, _ = json.Marshal(resp.Error.Data)
+			}
+			return &grpcpb.CallResponse{Error: rpcErr}, nil
+		},
+	},
+	grpcPackage + "Eth/BlockNumber": grpcEthMethod("eth_blockNumber", func(*grpcpb.BlockNumberRequest) ([]any, error) {
+		return nil, nil
+	}, grpcUint64Result),
+	grpcPackage + "Eth/ChainId": grpcEthMethod("eth_chainId", func(*grpcpb.ChainIdRequest) ([]any, error) {
+		return nil, nil
+	}, grpcUint64Result),
+	grpcPackage + "Eth/GetBalance":          grpcEthMethod("eth_getBalance", grpcAccountParams, grpcBigIntResult),
+	grpcPackage + "Eth/GetTransactionCount": grpcEthMethod("eth_getTransactionCount", grpcAccountParams, grpcUint64Result),
+	grpcPackage + "Eth/GetCode":             grpcEthMethod("eth_getCode", grpcAccountParams, grpcBytesResult),
+	grpcPackage + "Eth/Call":                grpcEthMethod("eth_call", grpcCallParams, grpcBytesResult),
+	grpcPackage + "Eth/EstimateGas":         grpcEthMethod("eth_estimateGas", grpcCallParams, grpcUint64Result),
+	grpcPackage + "Eth/SendRawTransaction": grpcEthMethod("eth_sendRawTransaction", func(req *grpcpb.BytesValue) ([]any, error) {
+		return []any{hexutil.Bytes(req.Value)}, nil
+	}, func(result json.RawMessage) (proto.Message, error) {
+		var hash common.Hash
+		if err := json.Unmarshal(result, &hash); err != nil {
+			return nil, err
+		}
+		return &grpcpb.Hash{Value: hash[:]}, nil
+	}),
+}
+
+// grpcEthMethod creates a typed method of the Eth service, converting between
+// the messages and the JSON parameters and result of the JSON-RPC method. Failed
+// calls end with a non-OK gRPC status.
+func grpcEthMethod[T any, R interface {
+	*T
+	proto.Message
+}](method string, params func(R) ([]any, error), result func(json.RawMessage) (proto.Message, error)) grpcMethod {
+	return grpcMethod{
+		newRequest: func() proto.Message { return R(new(T)) },
+		call: func(req proto.Message) (*jsonrpcMessage, error) {
+			args, err := params(req.(R))
+			if err != nil {
+				return nil, &grpcError{status: grpcStatusInvalidArgument, message: err.Error()}
+			}
+			msg := &jsonrpcMessage{Method: method}
+			if len(args) > 0 {
+				if msg.Params, err = json.Marshal(args); err != nil {
+					return nil, err
+				}
+			}
+			return msg, nil
+		},
+		response: func(resp *jsonrpcMessage) (proto.Message, error) {
+			if resp.Error != nil {
+				return nil, newGRPCError(resp.Error)
+			}
+			return result(resp.Result)
+		},
+	}
+}
+
+// grpcAccountParams converts an account request into JSON-RPC parameters.
+func grpcAccountParams(req *grpcpb.AccountRequest) ([]any, error) {
+	addr, err := grpcAddress(req.Address)
+	if err != nil || addr == nil {
+		return nil, errors.New("invalid address")
+	}
+	block, err := grpcBlock(req.Block)
+	if err != nil {
+		return nil, err
+	}
+	return []any{addr, block}, nil
+}
+
+// grpcCallParams converts a transaction call into JSON-RPC parameters.
+func grpcCallParams(req *grpcpb.TransactionCall) ([]any, error) {
+	from, err := grpcAddress(req.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from: %v", err)
+	}
+	to, err := grpcAddress(req.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid to: %v", err)
+	}
+	block, err := grpcBlock(req.Block)
+	if err != nil {
+		return nil, err
+	}
+	call := map[string]any{"input": hexutil.Bytes(req.Data)}
+	if from != nil {
+		call["from"] = from
+	}
+	if to != nil {
+		call["to"] = to
+	}
+	if req.Gas != 0 {
+		call["gas"] = hexutil.Uint64(req.Gas)
+	}
+	if req.Value != nil {
+		call["value"] = (*hexutil.Big)(new(big.Int).SetBytes(req.Value.Value))
+	}
+	return []any{call, block}, nil
+}
+
+// grpcAddress converts an optional address message.
+func grpcAddress(addr *grpcpb.Address) (*common.Address, error) {
+	if addr == nil {
+		return nil, nil
+	}
+	if len(addr.Value) != common.AddressLength {
+		return nil, fmt.Errorf("address of %d bytes", len(addr.Value))
+	}
+	a := common.BytesToAddress(addr.Value)
+	return &a, nil
+}
+
+// grpcBlock converts a block reference into a JSON-RPC block parameter,
+// defaulting to the latest block.
+func grpcBlock(ref *grpcpb.BlockReference) (any, error) {
+	switch block := ref.GetBlock().(type) {
+	case nil:
+		return LatestBlockNumber, nil
+	case *grpcpb.BlockReference_Number:
+		if block.Number > uint64(1<<63-1) {
+			return nil, errors.New("block number too large")
+		}
+		return BlockNumber(block.Number), nil
+	case *grpcpb.BlockReference_Hash:
+		if block.Hash == nil || len(block.Hash.Value) != common.HashLength {
+			return nil, errors.New("invalid block hash")
+		}
+		return map[string]any{"blockHash": common.BytesToHash(block.Hash.Value)}, nil
+	case *grpcpb.BlockReference_Tag:
+		switch block.Tag {
+		case grpcpb.BlockTag_BLOCK_TAG_LATEST:
+			return LatestBlockNumber, nil
+		case grpcpb.BlockTag_BLOCK_TAG_PENDING:
+			return PendingBlockNumber, nil
+		case grpcpb.BlockTag_BLOCK_TAG_SAFE:
+			return SafeBlockNumber, nil
+		case grpcpb.BlockTag_BLOCK_TAG_FINALIZED:
+			return FinalizedBlockNumber, nil
+		case grpcpb.BlockTag_BLOCK_TAG_EARLIEST:
+			return EarliestBlockNumber, nil
+		}
+	}
+	return nil, errors.New("invalid block reference")
+}
+
+func grpcUint64Result(result json.RawMessage) (proto.Message, error) {
+	var v hexutil.Uint64
+	if err := json.Unmarshal(result, &v); err != nil {
+		return nil, err
+	}
+	return &grpcpb.Uint64Value{Value: uint64(v)}, nil
+}
+
+func grpcBigIntResult(result json.RawMessage) (proto.Message, error) {
+	var v hexutil.Big
+	if err := json.Unmarshal(result, &v); err != nil {
+		return nil, err
+	}
+	if v.ToInt().Sign() < 0 {
+		return nil, errors.New("negative integer result")
+	}
+	return &grpcpb.BigInt{Value: v.ToInt().Bytes()}, nil
+}
+
+func grpcBytesResult(result json.RawMessage) (proto.Message, error) {
+	var v hexutil.Bytes
+	if err := json.Unmarshal(result, &v); err != nil {
+		return nil, err
+	}
+	return &grpcpb.BytesValue{Value: v}, nil
+}
+
+// GRPCHandler returns a handler serving the registered API namespaces as the gRPC
+// services defined in grpcpb/jsonrpc.proto: the JSONRPC service calls any method
+// with JSON encoded parameters and results, exactly like over HTTP, and streams
+// subscriptions, while the Eth service provides typed messages for common methods
+// of the eth namespace. gRPC clients get HTTP/2 multiplexing and deadlines through
+// the grpc-timeout header.
+//
+// gRPC requires HTTP/2, so the handler must be served over TLS, or by a server with
+// unencrypted HTTP/2 enabled through http.Server.Protocols. Compressed messages
+// aren't supported.
+func (s *Server) GRPCHandler() http.Handler {
+	return http.HandlerFunc(s.serveGRPC)
+}
+
+func (s *Server) serveGRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasPrefix(r.Header.Get("content-type"), "application/grpc") {
+		http.Error(w, "invalid gRPC request", http.StatusUnsupportedMediaType)
+		return
+	}
+	w.Header().Set("content-type", "application/grpc")
+	w.Header().Set("trailer", "grpc-status, grpc-message")
+
+	method, unary := grpcMethods[r.URL.Path]
+	if !unary && r.URL.Path != grpcSubscribePath {
+		writeGRPCStatus(w, grpcStatusUnimplemented, "unknown method "+r.URL.Path)
+		return
+	}
+	ctx := r.Context()
+	if timeout := r.Header.Get("grpc-timeout"); timeout != "" {
+		d, err := parseGRPCTimeout(timeout)
+		if err != nil {
+			writeGRPCStatus(w, grpcStatusInvalidArgument, err.Error())
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	connInfo := PeerInfo{Transport: "grpc", RemoteAddr: r.RemoteAddr}
+	connInfo.HTTP.Version = r.Proto
+	connInfo.HTTP.Host = r.Host
+	connInfo.HTTP.UserAgent = r.Header.Get("User-Agent")
+	ctx = context.WithValue(ctx, peerInfoContextKey{}, connInfo)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+
+	if !unary {
+		s.serveGRPCSubscription(ctx, w, r, connInfo)
+		return
+	}
+	req := method.newRequest()
+	if err := s.readGRPCMessage(r, req); err != nil {
+		writeGRPCStatus(w, grpcStatusInvalidArgument, err.Error())
+		return
+	}
+	msg, err := method.call(req)
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	msg.Version, msg.ID = vsn, json.RawMessage("1")
+
+	codec := &grpcConn{req: msg, remote: r.RemoteAddr, closeCh: make(chan interface{})}
+	defer codec.close()
+	s.serveSingleRequest(ctx, codec)
+
+	resp := codec.response()
+	if resp == nil {
+		writeGRPCStatus(w, grpcStatusUnavailable, "server stopped")
+		return
+	}
+	out, err := method.response(resp)
+	if err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	if err := writeGRPCMessage(w, out); err != nil {
+		return
+	}
+	writeGRPCStatus(w, grpcStatusOK, "")
+}
+
+// serveGRPCSubscription serves a call of the Subscribe method, streaming the
+// notifications of the subscription until the call ends.
+func (s *Server) serveGRPCSubscription(ctx context.Context, w http.ResponseWriter, r *http.Request, connInfo PeerInfo) {
+	req := new(grpcpb.SubscribeRequest)
+	if err := s.readGRPCMessage(r, req); err != nil {
+		writeGRPCStatus(w, grpcStatusInvalidArgument, err.Error())
+		return
+	}
+	if req.Namespace == "" {
+		writeGRPCStatus(w, grpcStatusInvalidArgument, "missing namespace")
+		return
+	}
+	codec := &grpcStreamConn{
+		req:      &jsonrpcMessage{Version: vsn, ID: json.RawMessage("1"), Method: req.Namespace + subscribeMethodSuffix, Params: req.Params},
+		w:        w,
+		connInfo: connInfo,
+		closeCh:  make(chan interface{}),
+	}
+	go s.ServeCodec(codec, 0)
+
+	select {
+	case <-ctx.Done():
+	case <-codec.closed():
+	}
+	// Stop the subscription and end the stream
+	codec.close()
+	if err := codec.finish(); err != nil {
+		writeGRPCError(w, err)
+		return
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		writeGRPCStatus(w, grpcStatusDeadlineExceeded, "deadline exceeded")
+	case ctx.Err() == nil:
+		writeGRPCStatus(w, grpcStatusUnavailable, "server stopped")
+	default:
+		writeGRPCStatus(w, grpcStatusOK, "")
+	}
+}
+
+// readGRPCMessage reads the request message of a gRPC call.
+func (s *Server) readGRPCMessage(r *http.Request, msg proto.Message) error {
+	body := io.LimitReader(r.Body, int64(s.httpBodyLimit)+5)
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return fmt.Errorf("invalid message: %v", err)
+	}
+	if prefix[0] != 0 {
+		return errors.New("compressed messages not supported")
+	}
+	size := binary.BigEndian.Uint32(prefix[1:])
+	if size > uint32(s.httpBodyLimit) {
+		return fmt.Errorf("message too large (%d>%d)", size, s.httpBodyLimit)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(body, data); err != nil {
+		return fmt.Errorf("invalid message: %v", err)
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return fmt.Errorf("invalid message: %v", err)
+	}
+	return nil
+}
+
+// writeGRPCMessage writes a length-prefixed message to a gRPC response.
+func writeGRPCMessage(w io.Writer, msg proto.Message) error {
+	enc, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, 5, 5+len(enc))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(enc)))
+	_, err = w.Write(append(frame, enc...))
+	return err
+}
+
+// writeGRPCError ends a gRPC call with the status of an error.
+func writeGRPCError(w http.ResponseWriter, err error) {
+	if grpcErr, ok := err.(*grpcError); ok {
+		writeGRPCStatus(w, grpcErr.status, grpcErr.message)
+		return
+	}
+	writeGRPCStatus(w, grpcStatusInternal, err.Error())
+}
+
+// writeGRPCStatus sets the status trailers of a gRPC response.
+func writeGRPCStatus(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("grpc-status", strconv.Itoa(status))
+	if message != "" {
+		w.Header().Set("grpc-message", message)
+	}
+}
+
+// parseGRPCTimeout parses the value of a grpc-timeout header.
+func parseGRPCTimeout(s string) (time.Duration, error) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", s)
+	}
+	value, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", s)
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[s[len(s)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid grpc-timeout unit %q", s)
+	}
+	return time.Duration(value) * unit, nil
+}
+
+// grpcConn is the codec of a single gRPC call, holding the request and
+// collecting the response.
+type grpcConn struct {
+	req    *jsonrpcMessage
+	remote string
+
+	mu        sync.Mutex
+	resp      *jsonrpcMessage
+	closeOnce sync.Once
+	closeCh   chan interface{}
+}
+
+func (c *grpcConn) readBatch() ([]*jsonrpcMessage, bool, error) {
+	return []*jsonrpcMessage{c.req}, false, nil
+}
+
+func (c *grpcConn) writeJSON(ctx context.Context, v interface{}, isError bool) error {
+	// Responses are re-decoded, as they might not be a *jsonrpcMessage
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	resp := new(jsonrpcMessage)
+	if err := json.Unmarshal(enc, resp); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resp = resp
+	return nil
+}
+
+func (c *grpcConn) response() *jsonrpcMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resp
+}
+
+func (c *grpcConn) peerInfo() PeerInfo {
+	return PeerInfo{Transport: "grpc", RemoteAddr: c.remote}
+}
+
+func (c *grpcConn) remoteAddr() string {
+	return c.remote
+}
+
+func (c *grpcConn) closed() <-chan interface{} {
+	return c.closeCh
+}
+
+func (c *grpcConn) close() {
+	c.closeOnce.Do(func() { close(c.closeCh) })
+}
+
+// grpcStreamConn is the codec of a Subscribe call, holding the subscription
+// request and streaming its notifications.
+type grpcStreamConn struct {
+	req      *jsonrpcMessage
+	connInfo PeerInfo
+
+	mu        sync.Mutex
+	w         http.ResponseWriter
+	err       error // Error returned by the subscription request
+	done      bool  // Set when the call ended, the writer is unusable afterwards
+	closeOnce sync.Once
+	closeCh   chan interface{}
+}
+
+func (c *grpcStreamConn) readBatch() ([]*jsonrpcMessage, bool, error) {
+	if req := c.takeRequest(); req != nil {
+		return []*jsonrpcMessage{req}, false, nil
+	}
+	<-c.closeCh
+	return nil, false, io.EOF
+}
+
+func (c *grpcStreamConn) takeRequest() *jsonrpcMessage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	req := c.req
+	c.req = nil
+	return req
+}
+
+func (c *grpcStreamConn) writeJSON(ctx context.Context, v interface{}, isError bool) error {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	msg := new(jsonrpcMessage)
+	if err := json.Unmarshal(enc, msg); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.done {
+		return errGRPCStreamClosed
+	}
+	switch {
+	case msg.isResponse() && msg.Error != nil:
+		// The subscription could not be created
+		c.err = newGRPCError(msg.Error)
+		c.closeOnce.Do(func() { close(c.closeCh) })
+		return nil
+	case msg.isResponse():
+		// The subscription was created, send the headers to start the stream
+		return http.NewResponseController(c.w).Flush()
+	case msg.isNotification():
+		var result subscriptionResult
+		if err := json.Unmarshal(msg.Params, &result); err != nil {
+			return err
+		}
+		if err := writeGRPCMessage(c.w, &grpcpb.Notification{Result: result.Result}); err != nil {
+			return err
+		}
+		return http.NewResponseController(c.w).Flush()
+	}
+	return nil
+}
+
+// finish ends the call, returning the error of the subscription request.
+func (c *grpcStreamConn) finish() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done = true
+	return c.err
+}
+
+func (c *grpcStreamConn) peerInfo() PeerInfo {
+	return c.connInfo
+}
+
+func (c *grpcStreamConn) remoteAddr() string {
+	return c.connInfo.RemoteAddr
+}
+
+func (c *grpcStreamConn) closed() <-chan interface{} {
+	return c.closeCh
+}
+
+func (c *grpcStreamConn) close() {
+	c.closeOnce.Do(func() { close(c.closeCh) })
+}
@@ -0,0 +1,308 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc/grpcpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcRequest sends a gRPC call to the server, returning the response.
+func grpcRequest(t *testing.T, ctx context.Context, srv *httptest.Server, path string, msg proto.Message, header http.Header) *http.Response {
+	t.Helper()
+
+	enc, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame := make([]byte, 5, 5+len(enc))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(enc)))
+	frame = append(frame, enc...)
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+path, bytes.NewReader(frame))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("content-type", "application/grpc")
+	req.Header.Set("te", "trailers")
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	client := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Fatalf("request not served over HTTP/2: %s", resp.Proto)
+	}
+	return resp
+}
+
+// readGRPCFrame reads a single message of a gRPC response, returning false at
+// the end of the response.
+func readGRPCFrame(t *testing.T, body io.Reader, msg proto.Message) bool {
+	t.Helper()
+
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err == io.EOF {
+		return false
+	} else if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	data := make([]byte, binary.BigEndian.Uint32(prefix[1:]))
+	if _, err := io.ReadFull(body, data); err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+	if err := proto.Unmarshal(data, msg); err != nil {
+		t.Fatalf("invalid response message: %v", err)
+	}
+	return true
+}
+
+// grpcCall performs a unary gRPC call against the server, decoding the response
+// message and returning the grpc-status trailer.
+func grpcCall(t *testing.T, srv *httptest.Server, path string, req, resp proto.Message, header http.Header) string {
+	t.Helper()
+
+	res := grpcRequest(t, context.Background(), srv, path, req, header)
+	defer res.Body.Close()
+	if readGRPCFrame(t, res.Body, resp) {
+		if readGRPCFrame(t, res.Body, resp) {
+			t.Fatal("unexpected second response message")
+		}
+	}
+	return res.Trailer.Get("grpc-status")
+}
+
+func newGRPCTestServer(server *Server) *httptest.Server {
+	srv := httptest.NewUnstartedServer(server.GRPCHandler())
+	srv.Config.Protocols = new(http.Protocols)
+	srv.Config.Protocols.SetUnencryptedHTTP2(true)
+	srv.Start()
+	return srv
+}
+
+func TestGRPCCall(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	srv := newGRPCTestServer(server)
+	defer srv.Close()
+
+	const path = grpcPackage + "JSONRPC/Call"
+
+	// Successful calls must return the JSON result
+	resp := new(grpcpb.CallResponse)
+	if status := grpcCall(t, srv, path, &grpcpb.CallRequest{Method: "test_echo", Params: []byte(`["x",1]`)}, resp, nil); status != "0" {
+		t.Fatalf("status mismatch: have %q, want 0", status)
+	}
+	if want := `{"String":"x","Int":1,"Args":null}`; string(resp.Result) != want {
+		t.Errorf("result mismatch: have %s, want %s", resp.Result, want)
+	}
+	// Method errors must be returned in the response
+	resp = new(grpcpb.CallResponse)
+	if status := grpcCall(t, srv, path, &grpcpb.CallRequest{Method: "test_returnError"}, resp, nil); status != "0" {
+		t.Fatalf("status mismatch: have %q, want 0", status)
+	}
+	if code := resp.GetError().GetCode(); code != 444 {
+		t.Errorf("error code mismatch: have %v, want 444", code)
+	}
+	if data := resp.GetError().GetData(); string(data) != `"testError data"` {
+		t.Errorf("error data mismatch: have %s", data)
+	}
+	// Unknown methods must be reported as JSON-RPC errors too
+	resp = new(grpcpb.CallResponse)
+	grpcCall(t, srv, path, &grpcpb.CallRequest{Method: "test_unknown"}, resp, nil)
+	if code := resp.GetError().GetCode(); code != -32601 {
+		t.Errorf("error code mismatch: have %v, want -32601", code)
+	}
+	// Exceeded deadlines must time out the call
+	resp = new(grpcpb.CallResponse)
+	header := http.Header{"Grpc-Timeout": {"50m"}}
+	grpcCall(t, srv, path, &grpcpb.CallRequest{Method: "test_sleep", Params: []byte(`[200000000]`)}, resp, header)
+	if code := resp.GetError().GetCode(); code != errcodeTimeout {
+		t.Errorf("error code mismatch: have %v, want %d", code, errcodeTimeout)
+	}
+	// Unknown gRPC methods must be rejected
+	if status := grpcCall(t, srv, grpcPackage+"JSONRPC/Stream", &grpcpb.CallRequest{Method: "test_echo"}, resp, nil); status != "12" {
+		t.Errorf("status mismatch: have %q, want 12", status)
+	}
+}
+
+// grpcEthService is an eth namespace serving the methods of the Eth service.
+type grpcEthService struct{}
+
+func (s *grpcEthService) BlockNumber() hexutil.Uint64 {
+	return 42
+}
+
+func (s *grpcEthService) GetBalance(addr common.Address, block BlockNumberOrHash) (*hexutil.Big, error) {
+	if hash, ok := block.Hash(); ok {
+		return (*hexutil.Big)(new(big.Int).SetBytes(hash[:1])), nil
+	}
+	number, _ := block.Number()
+	if number == PendingBlockNumber {
+		return (*hexutil.Big)(big.NewInt(2)), nil
+	}
+	return (*hexutil.Big)(new(big.Int).SetBytes(addr[:])), nil
+}
+
+func (s *grpcEthService) Call(args map[string]interface{}, block BlockNumberOrHash) (hexutil.Bytes, error) {
+	return []byte(fmt.Sprintf("%v %v %v %v %v %v", args["from"], args["to"], args["gas"], args["value"], args["input"], block.String())), nil
+}
+
+func TestGRPCEth(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", new(grpcEthService)); err != nil {
+		t.Fatal(err)
+	}
+	srv := newGRPCTestServer(server)
+	defer srv.Close()
+
+	number := new(grpcpb.Uint64Value)
+	if status := grpcCall(t, srv, grpcPackage+"Eth/BlockNumber", new(grpcpb.BlockNumberRequest), number, nil); status != "0" || number.Value != 42 {
+		t.Errorf("block number mismatch: have %d (status %s), want 42", number.Value, status)
+	}
+	// Block references must select the block
+	addr := &grpcpb.Address{Value: common.HexToAddress("0x0102").Bytes()}
+	for _, tt := range []struct {
+		block *grpcpb.BlockReference
+		want  int64
+	}{
+		{nil, 0x0102},
+		{&grpcpb.BlockReference{Block: &grpcpb.BlockReference_Tag{Tag: grpcpb.BlockTag_BLOCK_TAG_PENDING}}, 2},
+		{&grpcpb.BlockReference{Block: &grpcpb.BlockReference_Number{Number: 7}}, 0x0102},
+		{&grpcpb.BlockReference{Block: &grpcpb.BlockReference_Hash{Hash: &grpcpb.Hash{Value: common.Hash{9}.Bytes()}}}, 9},
+	} {
+		balance := new(grpcpb.BigInt)
+		if status := grpcCall(t, srv, grpcPackage+"Eth/GetBalance", &grpcpb.AccountRequest{Address: addr, Block: tt.block}, balance, nil); status != "0" {
+			t.Fatalf("block %v: status mismatch: have %q, want 0", tt.block, status)
+		}
+		if have := new(big.Int).SetBytes(balance.Value); have.Int64() != tt.want {
+			t.Errorf("block %v: balance mismatch: have %v, want %d", tt.block, have, tt.want)
+		}
+	}
+	// Calls must be converted to the JSON-RPC call object
+	call := &grpcpb.TransactionCall{
+		To:    addr,
+		Gas:   21000,
+		Value: &grpcpb.BigInt{Value: []byte{1, 0}},
+		Data:  []byte{0xca, 0xfe},
+		Block: &grpcpb.BlockReference{Block: &grpcpb.BlockReference_Number{Number: 7}},
+	}
+	result := new(grpcpb.BytesValue)
+	if status := grpcCall(t, srv, grpcPackage+"Eth/Call", call, result, nil); status != "0" {
+		t.Fatalf("status mismatch: have %q, want 0", status)
+	}
+	if want := "<nil> 0x0000000000000000000000000000000000000102 0x5208 0x100 0xcafe 0x7"; string(result.Value) != want {
+		t.Errorf("call mismatch: have %s, want %s", result.Value, want)
+	}
+	// Invalid arguments and unknown methods must end with a gRPC status
+	if status := grpcCall(t, srv, grpcPackage+"Eth/GetBalance", &grpcpb.AccountRequest{Address: &grpcpb.Address{Value: []byte{1}}}, new(grpcpb.BigInt), nil); status != "3" {
+		t.Errorf("status mismatch: have %q, want 3", status)
+	}
+	if status := grpcCall(t, srv, grpcPackage+"Eth/ChainId", new(grpcpb.ChainIdRequest), new(grpcpb.Uint64Value), nil); status != "12" {
+		t.Errorf("status mismatch: have %q, want 12", status)
+	}
+}
+
+func TestGRPCSubscribe(t *testing.T) {
+	t.Parallel()
+
+	server := NewServer()
+	defer server.Stop()
+	service := &notificationTestService{unsubscribed: make(chan string, 1)}
+	if err := server.RegisterName("nftest", service); err != nil {
+		t.Fatal(err)
+	}
+	srv := newGRPCTestServer(server)
+	defer srv.Close()
+
+	// Notifications must be streamed until the call is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := &grpcpb.SubscribeRequest{Namespace: "nftest", Params: []byte(`["someSubscription",3,10]`)}
+	resp := grpcRequest(t, ctx, srv, grpcSubscribePath, req, nil)
+	defer resp.Body.Close()
+	for i := 0; i < 3; i++ {
+		notification := new(grpcpb.Notification)
+		if !readGRPCFrame(t, resp.Body, notification) {
+			t.Fatalf("stream ended after %d notifications", i)
+		}
+		if have, want := string(notification.Result), fmt.Sprint(10+i); have != want {
+			t.Errorf("notification %d mismatch: have %s, want %s", i, have, want)
+		}
+	}
+	cancel()
+	select {
+	case <-service.unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not ended after cancelling the call")
+	}
+	// Failed subscriptions must end with a gRPC status
+	req = &grpcpb.SubscribeRequest{Namespace: "nftest", Params: []byte(`["unknownSubscription"]`)}
+	if status := grpcCall(t, srv, grpcSubscribePath, req, new(grpcpb.Notification), nil); status == "0" || status == "" {
+		t.Errorf("status mismatch: have %q, want error", status)
+	}
+}
+
+func TestParseGRPCTimeout(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int64
+		fail  bool
+	}{
+		{input: "1S", want: 1e9},
+		{input: "250m", want: 250e6},
+		{input: "2H", want: 2 * 3600e9},
+		{input: "10n", want: 10},
+		{input: "S", fail: true},
+		{input: "10x", fail: true},
+		{input: "-1S", fail: true},
+		{input: "123456789S", fail: true},
+	}
+	for _, tt := range tests {
+		d, err := parseGRPCTimeout(tt.input)
+		if tt.fail {
+			if err == nil {
+				t.Errorf("%q: expected error", tt.input)
+			}
+			continue
+		}
+		if err != nil || int64(d) != tt.want {
+			t.Errorf("%q: have %v, %v, want %d", tt.input, d, err, tt.want)
+		}
+	}
+}
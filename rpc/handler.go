@@ -69,6 +69,9 @@ type handler struct {
 	batchRequestLimit    int
 	batchResponseMaxSize int
 	tracerProvider       trace.TracerProvider
+	limits               *connLimits   // Rate limits of the calls served, nil if unlimited
+	callBudget           time.Duration // Maximum execution time of requests, 0 if unlimited
+	slowCallThreshold    time.Duration // Execution time of calls logged as slow, 0 if disabled
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -216,7 +219,7 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		// Cancel the request context after timeout and send an error response. Since the
 		// currently-running method might not return immediately on timeout, we must wait
 		// for the timeout concurrently with processing the request.
-		if timeout, ok := ContextRequestTimeout(cp.ctx); ok {
+		if timeout, ok := h.requestTimeout(cp.ctx); ok {
 			timer = time.AfterFunc(timeout, func() {
 				cancel()
 				err := &internalServerError{errcodeTimeout, errMsgTimeout}
@@ -235,6 +238,11 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 				break
 			}
 			resp := h.handleCallMsg(cp, msg)
+			if resp != nil && resp.stream != nil {
+				buffered := resp.buffer(cp.ctx)
+				resp.finishStream(nil)
+				resp = buffered
+			}
 			callBuffer.pushResponse(resp)
 			if resp != nil && h.batchResponseMaxSize != 0 {
 				responseBytes += len(resp.Result)
@@ -293,7 +301,7 @@ func (h *handler) handleNonBatchCall(cp *callProc, msg *jsonrpcMessage) {
 	// Cancel the request context after timeout and send an error response. Since the
 	// running method might not return immediately on timeout, we must wait for the
 	// timeout concurrently with processing the request.
-	if timeout, ok := ContextRequestTimeout(cp.ctx); ok {
+	if timeout, ok := h.requestTimeout(cp.ctx); ok {
 		timer = time.AfterFunc(timeout, func() {
 			cancel()
 			responded.Do(func() {
@@ -304,6 +312,15 @@ func (h *handler) handleNonBatchCall(cp *callProc, msg *jsonrpcMessage) {
 	}
 
 	answer := h.handleCallMsg(cp, msg)
+	if answer != nil && answer.stream != nil {
+		// Streamed results are produced while writing, so the timeout keeps
+		// running until the response is written.
+		err := context.DeadlineExceeded
+		responded.Do(func() {
+			err = h.writeStream(cp.ctx, answer)
+		})
+		answer.finishStream(err)
+	}
 	if timer != nil {
 		timer.Stop()
 	}
@@ -387,6 +404,25 @@ func (h *handler) cancelServerSubscriptions(err error) {
 	}
 }
 
+// writeStream writes a streamed response, collecting the result first if the
+// connection can't stream it.
+func (h *handler) writeStream(ctx context.Context, msg *jsonrpcMessage) error {
+	if w, ok := h.conn.(streamWriter); ok {
+		return w.writeStream(ctx, msg)
+	}
+	return h.conn.writeJSON(ctx, msg.buffer(ctx), false)
+}
+
+// requestTimeout returns the time a request may run for, limited by both the call
+// budget and the request context.
+func (h *handler) requestTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ContextRequestTimeout(ctx)
+	if h.callBudget > 0 && (!ok || h.callBudget < timeout) {
+		return h.callBudget, true
+	}
+	return timeout, ok
+}
+
 // startCallProc runs fn in a new goroutine and starts tracking it in the h.calls wait group.
 func (h *handler) startCallProc(fn func(*callProc)) {
 	h.callWG.Add(1)
@@ -409,6 +445,11 @@ func (h *handler) handleResponses(batch []*jsonrpcMessage, handleCall func(*json
 		}
 		resolvedops = append(resolvedops, op)
 		delete(h.respWait, string(msg.ID))
+		if stream := op.streams[string(msg.ID)]; stream != nil {
+			if err := stream.finish(msg); err != nil {
+				op.err = err
+			}
+		}
 
 		// For subscription responses, start the subscription if the server
 		// indicates success. EthSubscribe gets unblocked in either case through
@@ -443,6 +484,10 @@ func (h *handler) handleResponses(batch []*jsonrpcMessage, handleCall func(*json
 				h.handleSubscriptionResult(msg)
 				continue
 			}
+			if msg.Method == streamChunkMethod {
+				h.handleStreamChunk(msg)
+				continue
+			}
 			handleCall(msg)
 
 		default:
@@ -467,12 +512,39 @@ func (h *handler) handleSubscriptionResult(msg *jsonrpcMessage) {
 	}
 }
 
+// handleStreamChunk collects the chunks of streamed results.
+func (h *handler) handleStreamChunk(msg *jsonrpcMessage) {
+	var chunk streamChunk
+	if err := json.Unmarshal(msg.Params, &chunk); err != nil {
+		h.log.Debug("Dropping invalid stream chunk")
+		return
+	}
+	op := h.respWait[string(chunk.ID)]
+	if op == nil {
+		h.log.Debug("Unsolicited stream chunk", "reqid", idForLog{chunk.ID})
+		return
+	}
+	if op.streams == nil {
+		op.streams = make(map[string]*streamResult)
+	}
+	stream := op.streams[string(chunk.ID)]
+	if stream == nil {
+		stream = new(streamResult)
+		op.streams[string(chunk.ID)] = stream
+	}
+	if err := stream.add(chunk.Result); err != nil {
+		op.err = err
+	}
+}
+
 // handleCallMsg executes a call message and returns the answer.
 func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
 	start := time.Now()
 	switch {
 	case msg.isNotification():
-		h.handleCall(ctx, msg)
+		if resp := h.handleCall(ctx, msg); resp.stream != nil {
+			resp.finishStream(nil) // notifications have no response to stream
+		}
 		h.log.Debug("Served "+msg.Method, "duration", time.Since(start))
 		return nil
 
@@ -501,6 +573,22 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if !scopeAllows(cp.ctx, msg.Method) {
+		return msg.errorResponse(&unauthorizedError{method: msg.Method})
+	}
+	release, limitErr := h.limits.acquire(msg.Method)
+	if limitErr != nil {
+		return msg.errorResponse(limitErr)
+	}
+	// Streamed results are produced after the method returned, so the call of a
+	// stream holds its slot and span until the stream is done.
+	var streaming bool
+	defer func() {
+		if !streaming {
+			release()
+		}
+	}()
+
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -531,11 +619,18 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 		Method:    method,
 		RequestID: string(msg.ID),
 	}
+	peer := PeerInfoFromContext(cp.ctx)
 	attrib := []telemetry.Attribute{
 		telemetry.BoolAttribute("rpc.batch", cp.isBatch),
+		telemetry.Int64Attribute("rpc.params.size", int64(len(msg.Params))),
+		telemetry.StringAttribute("rpc.transport", peer.Transport),
 	}
 	ctx, spanEnd := telemetry.StartServerSpan(cp.ctx, h.tracer(), rpcInfo, attrib...)
-	defer spanEnd(err)
+	defer func() {
+		if !streaming {
+			spanEnd(err)
+		}
+	}()
 
 	// Start tracing span before parsing arguments.
 	_, _, pSpanEnd := telemetry.StartSpanWithTracer(ctx, h.tracer(), "rpc.parsePositionalArguments")
@@ -554,16 +649,50 @@ func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage
 	}
 	rSpanEnd(err)
 
+	if answer.stream != nil {
+		var (
+			stream    = answer.stream
+			streamErr error
+		)
+		streaming = true
+		answer.stream = func(ctx context.Context, send func(item interface{}) error) error {
+			streamErr = stream(ctx, send)
+			return streamErr
+		}
+		answer.streamDone = func(err error) {
+			if streamErr != nil {
+				err = streamErr
+			}
+			h.finishCall(ctx, msg, peer, start, err)
+			spanEnd(err)
+			release()
+		}
+		return answer
+	}
+	h.finishCall(ctx, msg, peer, start, err)
+	return answer
+}
+
+// finishCall reports slow calls and collects the statistics of a served call.
+func (h *handler) finishCall(ctx context.Context, msg *jsonrpcMessage, peer PeerInfo, start time.Time, err error) {
+	// Report calls exceeding the slow call threshold.
+	elapsed := time.Since(start)
+	if h.slowCallThreshold > 0 && elapsed >= h.slowCallThreshold {
+		rpcSlowCallMeter.Mark(1)
+		trace.SpanFromContext(ctx).SetAttributes(telemetry.BoolAttribute("rpc.slow", true))
+		h.log.Warn("Slow RPC call", "method", msg.Method, "reqid", idForLog{msg.ID}, "params", len(msg.Params),
+			"duration", elapsed, "transport", peer.Transport, "peer", peer.RemoteAddr, "agent", peer.HTTP.UserAgent)
+	}
+
 	// Collect the statistics for RPC calls if metrics is enabled.
 	rpcRequestGauge.Inc(1)
-	if answer.Error != nil {
+	if err != nil {
 		failedRequestGauge.Inc(1)
 	} else {
 		successfulRequestGauge.Inc(1)
 	}
-	rpcServingTimer.UpdateSince(start)
-	updateServeTimeHistogram(msg.Method, answer.Error == nil, time.Since(start))
-	return answer
+	rpcServingTimer.Update(elapsed)
+	updateServeTimeHistogram(msg.Method, err == nil, elapsed)
 }
 
 // handleSubscribe processes *_subscribe method calls.
@@ -620,6 +749,9 @@ func (h *handler) runMethod(ctx context.Context, msg *jsonrpcMessage, callb *cal
 	if err != nil {
 		return msg.errorResponse(err)
 	}
+	if stream, ok := result.(ResultStream); ok {
+		return &jsonrpcMessage{Version: vsn, ID: msg.ID, stream: stream}
+	}
 	_, _, spanEnd := telemetry.StartSpanWithTracer(ctx, h.tracer(), "rpc.encodeJSONResponse", attributes...)
 	response := msg.response(result)
 	if response.Error != nil {
@@ -146,9 +146,10 @@ func DialHTTPWithClient(endpoint string, client *http.Client) (*Client, error) {
 }
 
 func newClientTransportHTTP(endpoint string, cfg *clientConfig) reconnectFunc {
-	headers := make(http.Header, 2+len(cfg.httpHeaders))
+	headers := make(http.Header, 3+len(cfg.httpHeaders))
 	headers.Set("accept", contentType)
 	headers.Set("content-type", contentType)
+	headers.Set(streamHeader, streamChunked)
 	for key, values := range cfg.httpHeaders {
 		headers[key] = values
 	}
@@ -187,13 +188,33 @@ func (c *Client) sendHTTP(ctx context.Context, op *requestOp, msg interface{}) e
 	}
 	defer cleanlyCloseBody(respBody)
 
-	var resp jsonrpcMessage
-	batch := [1]*jsonrpcMessage{&resp}
-	if err := json.NewDecoder(respBody).Decode(&resp); err != nil {
-		return err
+	// Streamed results are received as chunk notifications before the response.
+	var (
+		dec    = json.NewDecoder(respBody)
+		stream streamResult
+	)
+	for {
+		var resp jsonrpcMessage
+		if err := dec.Decode(&resp); err != nil {
+			return err
+		}
+		if resp.isNotification() && resp.Method == streamChunkMethod {
+			var chunk streamChunk
+			if err := json.Unmarshal(resp.Params, &chunk); err != nil {
+				return err
+			}
+			if err := stream.add(chunk.Result); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := stream.finish(&resp); err != nil {
+			return err
+		}
+		batch := [1]*jsonrpcMessage{&resp}
+		op.resp <- batch[:]
+		return nil
 	}
-	op.resp <- batch[:]
-	return nil
 }
 
 func (c *Client) sendBatchHTTP(ctx context.Context, op *requestOp, msgs []*jsonrpcMessage) error {
@@ -262,11 +283,12 @@ type httpServerConn struct {
 	io.Reader
 	io.Writer
 	r *http.Request
+	w http.ResponseWriter
 }
 
 func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) ServerCodec {
 	body := io.LimitReader(r.Body, int64(s.httpBodyLimit))
-	conn := &httpServerConn{Reader: body, Writer: w, r: r}
+	conn := &httpServerConn{Reader: body, Writer: w, r: r, w: w}
 
 	encoder := func(v any, isErrorResponse bool) error {
 		if !isErrorResponse {
@@ -302,7 +324,28 @@ func (s *Server) newHTTPServerConn(r *http.Request, w http.ResponseWriter) Serve
 	dec := json.NewDecoder(conn)
 	dec.UseNumber()
 
-	return NewFuncCodec(conn, encoder, dec.Decode)
+	codec := NewFuncCodec(conn, encoder, dec.Decode).(*jsonCodec)
+	if r.Header.Get(streamHeader) == streamChunked {
+		codec.stream = func() (io.WriteCloser, error) {
+			return flushWriteCloser{w}, nil
+		}
+	}
+	return codec
+}
+
+// flushWriteCloser turns a HTTP response into the writer of stream messages, which
+// are flushed when closed.
+type flushWriteCloser struct {
+	w http.ResponseWriter
+}
+
+func (w flushWriteCloser) Write(p []byte) (int, error) { return w.w.Write(p) }
+
+func (w flushWriteCloser) Close() error {
+	if f, ok := w.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
 }
 
 // Close does nothing and always returns nil.
@@ -313,8 +356,10 @@ func (t *httpServerConn) RemoteAddr() string {
 	return t.r.RemoteAddr
 }
 
-// SetWriteDeadline does nothing and always returns nil.
-func (t *httpServerConn) SetWriteDeadline(time.Time) error { return nil }
+// SetWriteDeadline sets the write deadline of the response.
+func (t *httpServerConn) SetWriteDeadline(deadline time.Time) error {
+	return http.NewResponseController(t.w).SetWriteDeadline(deadline)
+}
 
 // ServeHTTP serves JSON-RPC requests over HTTP.
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -67,6 +67,9 @@ type jsonrpcMessage struct {
 	Params  json.RawMessage `json:"params,omitempty"`
 	Error   *jsonError      `json:"error,omitempty"`
 	Result  json.RawMessage `json:"result,omitempty"`
+
+	stream     ResultStream    // Result written by the connection, if set
+	streamDone func(err error) // Finishes the call once the stream was written or dropped
 }
 
 func (msg *jsonrpcMessage) isNotification() bool {
@@ -185,6 +188,7 @@ type jsonCodec struct {
 	decode  decodeFunc       // decoder to allow multiple transports
 	encMu   sync.Mutex       // guards the encoder
 	encode  encodeFunc       // encoder to allow multiple transports
+	stream  streamFunc       // opens the writer of streamed responses, nil if unsupported
 	conn    deadlineCloser
 }
 
@@ -192,6 +196,8 @@ type encodeFunc = func(v interface{}, isErrorResponse bool) error
 
 type decodeFunc = func(v interface{}) error
 
+type streamFunc = func() (io.WriteCloser, error)
+
 // NewFuncCodec creates a codec which uses the given functions to read and write. If conn
 // implements ConnRemoteAddr, log messages will use it to include the remote address of
 // the connection.
@@ -32,6 +32,9 @@ var (
 	serveTimeHistName = "rpc/duration"
 
 	rpcServingTimer = metrics.NewRegisteredTimer("rpc/duration/all", nil)
+
+	// rpcSlowCallMeter counts the calls exceeding the slow call threshold.
+	rpcSlowCallMeter = metrics.NewRegisteredMeter("rpc/slow", nil)
 )
 
 // updateServeTimeHistogram tracks the serving time of a remote RPC call.
@@ -0,0 +1,180 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"golang.org/x/time/rate"
+)
+
+var rpcLimitedMeter = metrics.NewRegisteredMeter("rpc/limited", nil)
+
+// RateLimit limits the calls of a method, or of all methods of a namespace.
+type RateLimit struct {
+	Rate          float64 // Calls allowed per second (0 = unlimited)
+	Burst         int     // Calls allowed at once on top of the rate (0 = the rate, at least 1)
+	MaxConcurrent int     // Calls processed at the same time (0 = unlimited)
+
+	// PerConnection applies the limits to every connection separately, instead
+	// of to all calls served. Every HTTP request is a connection on its own.
+	PerConnection bool
+}
+
+// SetRateLimits configures the rate limits of the server, keyed by method name, e.g.
+// "debug_traceCall", or by namespace, e.g. "debug". Calls must satisfy both the limits
+// of their method and namespace. Calls exceeding the limits are rejected with error
+// code -32005, instead of waiting.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetRateLimits(limits map[string]RateLimit) {
+	l := &rateLimiter{
+		limits: make(map[string]RateLimit, len(limits)),
+		shared: make(map[string]*limitState),
+	}
+	for key, limit := range limits {
+		l.limits[key] = limit
+		if !limit.PerConnection {
+			l.shared[key] = newLimitState(limit)
+		}
+	}
+	s.rateLimiter = l
+}
+
+// rateLimiter holds the rate limits of a server.
+type rateLimiter struct {
+	limits map[string]RateLimit
+	shared map[string]*limitState // States of the limits applying to all connections
+}
+
+// limitState tracks the calls subject to a limit.
+type limitState struct {
+	bucket *rate.Limiter // Token bucket of the rate, nil if unlimited
+	slots  chan struct{} // Semaphore of the concurrent calls, nil if unlimited
+}
+
+func newLimitState(limit RateLimit) *limitState {
+	state := new(limitState)
+	if limit.Rate > 0 {
+		burst := limit.Burst
+		if burst == 0 {
+			burst = max(1, int(math.Ceil(limit.Rate)))
+		}
+		state.bucket = rate.NewLimiter(rate.Limit(limit.Rate), burst)
+	}
+	if limit.MaxConcurrent > 0 {
+		state.slots = make(chan struct{}, limit.MaxConcurrent)
+	}
+	return state
+}
+
+// connLimits applies the rate limits to the calls of a connection.
+type connLimits struct {
+	limiter *rateLimiter
+
+	lock   sync.Mutex
+	states map[string]*limitState // States of the per-connection limits
+}
+
+func newConnLimits(limiter *rateLimiter) *connLimits {
+	if limiter == nil {
+		return nil
+	}
+	return &connLimits{limiter: limiter, states: make(map[string]*limitState)}
+}
+
+// state returns the state of a limit, nil if there is no limit for the key.
+func (c *connLimits) state(key string) *limitState {
+	limit, ok := c.limiter.limits[key]
+	if !ok {
+		return nil
+	}
+	if !limit.PerConnection {
+		return c.limiter.shared[key]
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state := c.states[key]
+	if state == nil {
+		state = newLimitState(limit)
+		c.states[key] = state
+	}
+	return state
+}
+
+// acquire admits a call of the method, returning the function to call once the
+// call is done, or an error if the call exceeds the limits.
+func (c *connLimits) acquire(method string) (func(), error) {
+	if c == nil {
+		return func() {}, nil
+	}
+	keys := []string{method}
+	if namespace, _, found := strings.Cut(method, serviceMethodSeparator); found {
+		keys = append(keys, namespace)
+	}
+	var acquired []*limitState
+	release := func() {
+		for _, state := range acquired {
+			<-state.slots
+		}
+	}
+	for _, key := range keys {
+		state := c.state(key)
+		if state == nil {
+			continue
+		}
+		if state.bucket != nil && !state.bucket.Allow() {
+			release()
+			rpcLimitedMeter.Mark(1)
+			return nil, &limitExceededError{method: method, limit: key, reason: "rate"}
+		}
+		if state.slots != nil {
+			select {
+			case state.slots <- struct{}{}:
+				acquired = append(acquired, state)
+			default:
+				release()
+				rpcLimitedMeter.Mark(1)
+				return nil, &limitExceededError{method: method, limit: key, reason: "concurrency"}
+			}
+		}
+	}
+	return release, nil
+}
+
+// limitExceededError is returned for calls exceeding the rate limits.
+type limitExceededError struct {
+	method string
+	limit  string // Method or namespace whose limit was exceeded
+	reason string // Kind of limit exceeded, rate or concurrency
+}
+
+func (e *limitExceededError) ErrorCode() int { return errcodeLimitExceeded }
+
+func (e *limitExceededError) Error() string {
+	return fmt.Sprintf("%s limit of %s exceeded", e.reason, e.limit)
+}
+
+func (e *limitExceededError) ErrorData() interface{} {
+	return map[string]string{"method": e.method, "limit": e.limit, "reason": e.reason}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// isLimitExceeded reports whether a call failed for exceeding the limit of key.
+func isLimitExceeded(err error, key string) bool {
+	var dataErr DataError
+	if !errors.As(err, &dataErr) || dataErr.(Error).ErrorCode() != errcodeLimitExceeded {
+		return false
+	}
+	data, _ := dataErr.ErrorData().(map[string]interface{})
+	return data["limit"] == key
+}
+
+func TestServerRateLimits(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	server.SetRateLimits(map[string]RateLimit{
+		"test_echo": {Rate: 0.001, Burst: 2},
+		"test":      {MaxConcurrent: 1},
+	})
+	defer server.Stop()
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	// Calls above the rate of a method must be rejected
+	var res echoResult
+	for i := 0; i < 2; i++ {
+		if err := client.Call(&res, "test_echo", "x", 1); err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+	if err := client.Call(&res, "test_echo", "x", 1); !isLimitExceeded(err, "test_echo") {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+	// Other methods of the namespace must not be affected by the rate, but by
+	// the concurrency limit of the namespace
+	blocked := make(chan error, 1)
+	go func() { blocked <- client.Call(nil, "test_sleep", 200*time.Millisecond) }()
+
+	var (
+		err    error
+		repeat string
+	)
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if err = client.Call(&repeat, "test_repeat", "x", 1); err != nil {
+			break
+		}
+	}
+	if !isLimitExceeded(err, "test") {
+		t.Fatalf("expected concurrency limit error, got %v", err)
+	}
+	if err := <-blocked; err != nil {
+		t.Fatalf("blocking call failed: %v", err)
+	}
+	if err := client.Call(&repeat, "test_repeat", "x", 1); err != nil {
+		t.Fatalf("call failed after blocking call ended: %v", err)
+	}
+}
+
+func TestServerRateLimitsPerConnection(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	server.SetRateLimits(map[string]RateLimit{
+		"test_echo": {Rate: 0.001, Burst: 1, PerConnection: true},
+	})
+	defer server.Stop()
+
+	// Every connection must get its own allowance
+	for i := 0; i < 2; i++ {
+		client := DialInProc(server)
+		var res echoResult
+		if err := client.Call(&res, "test_echo", "x", 1); err != nil {
+			t.Fatalf("connection %d: call failed: %v", i, err)
+		}
+		err := client.Call(&res, "test_echo", "x", 1)
+		if !isLimitExceeded(err, "test_echo") {
+			t.Fatalf("connection %d: expected rate limit error, got %v", i, err)
+		}
+		want := map[string]interface{}{"method": "test_echo", "limit": "test_echo", "reason": "rate"}
+		if data := err.(DataError).ErrorData(); !reflect.DeepEqual(data, want) {
+			t.Errorf("connection %d: error data mismatch: have %v, want %v", i, data, want)
+		}
+		client.Close()
+	}
+}
+
+func TestServerRateLimitsSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	server.SetRateLimits(map[string]RateLimit{
+		"nftest_subscribe": {Rate: 0.001, Burst: 1},
+	})
+	defer server.Stop()
+
+	client := DialInProc(server)
+	defer client.Close()
+
+	// Subscriptions must be subject to the limits like any other call
+	sub, err := client.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 1)
+	if err != nil {
+		t.Fatalf("subscription failed: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	_, err = client.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 1)
+	if !isLimitExceeded(err, "nftest_subscribe") {
+		t.Fatalf("expected rate limit error, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+type scopeContextKey struct{}
+
+// WithScope returns a copy of ctx restricting the calls of the request to a scope of
+// namespaces, e.g. "eth", and methods, e.g. "debug_traceCall". An empty scope allows
+// no calls at all.
+//
+// HTTP handlers wrapping the server, like the JWT authentication of package node, use
+// this to authorize requests. For WebSocket connections, the scope of the upgrade
+// request applies to all calls of the connection.
+func WithScope(ctx context.Context, scope []string) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, slices.Clone(scope))
+}
+
+// ScopeFromContext returns the scope of namespaces and methods the request may call,
+// and whether calls are restricted at all.
+func ScopeFromContext(ctx context.Context) ([]string, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).([]string)
+	return scope, ok
+}
+
+// scopeAllows reports whether the method is within the scope of the context.
+func scopeAllows(ctx context.Context, method string) bool {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return true
+	}
+	namespace, _, _ := strings.Cut(method, serviceMethodSeparator)
+	return slices.Contains(scope, method) || slices.Contains(scope, namespace)
+}
+
+// scopedConn is implemented by connections restricted to the scope of the request
+// which opened them.
+type scopedConn interface {
+	scope() ([]string, bool)
+}
+
+// unauthorizedError is returned for calls outside the scope of the request.
+type unauthorizedError struct{ method string }
+
+func (e *unauthorizedError) ErrorCode() int { return errcodeUnauthorized }
+
+func (e *unauthorizedError) Error() string {
+	return "method " + e.method + " not allowed"
+}
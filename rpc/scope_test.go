@@ -0,0 +1,77 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// scopeHandler restricts the requests served by next to a scope.
+func scopeHandler(scope []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(WithScope(r.Context(), scope)))
+	})
+}
+
+func TestServerScope(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	defer server.Stop()
+	scope := []string{"test_echo", "nftest"}
+	httpsrv := httptest.NewServer(scopeHandler(scope, server))
+	defer httpsrv.Close()
+	wssrv := httptest.NewServer(scopeHandler(scope, server.WebsocketHandler([]string{"*"})))
+	defer wssrv.Close()
+
+	httpClient, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer httpClient.Close()
+	wsClient, err := DialWebsocket(context.Background(), "ws"+strings.TrimPrefix(wssrv.URL, "http"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wsClient.Close()
+
+	for _, client := range []*Client{httpClient, wsClient} {
+		var echo echoResult
+		if err := client.Call(&echo, "test_echo", "x", 1); err != nil {
+			t.Errorf("call within scope failed: %v", err)
+		}
+		var repeat string
+		err := client.Call(&repeat, "test_repeat", "x", 1)
+		if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != errcodeUnauthorized {
+			t.Errorf("expected unauthorized error, got %v", err)
+		}
+	}
+	// Subscriptions must be authorized by their namespace
+	sub, err := wsClient.Subscribe(context.Background(), "nftest", make(chan int), "someSubscription", 1, 1)
+	if err != nil {
+		t.Fatalf("subscription within scope failed: %v", err)
+	}
+	sub.Unsubscribe()
+	_, err = wsClient.Subscribe(context.Background(), "test", make(chan int), "someSubscription", 1, 1)
+	if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != errcodeUnauthorized {
+		t.Errorf("expected unauthorized error, got %v", err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"go.opentelemetry.io/otel/trace"
@@ -56,6 +57,9 @@ type Server struct {
 	batchResponseLimit int
 	httpBodyLimit      int
 	wsReadLimit        int64
+	rateLimiter        *rateLimiter
+	callBudget         time.Duration
+	slowCallThreshold  time.Duration
 	tracerProvider     trace.TracerProvider
 }
 
@@ -101,6 +105,26 @@ func (s *Server) SetWebsocketReadLimit(limit int64) {
 	s.wsReadLimit = limit
 }
 
+// SetCallBudget sets the maximum execution time of a request. Requests running longer
+// are canceled and answered with a timeout error. Batches must complete within the
+// budget as a whole. A zero budget leaves requests limited by transport timeouts only.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetCallBudget(budget time.Duration) {
+	s.callBudget = budget
+}
+
+// SetSlowCallThreshold sets the execution time above which calls are logged as slow,
+// along with the size of their parameters and the peer that sent them. Slow calls are
+// also marked on their tracing span. A zero threshold disables slow call logging.
+//
+// This method should be called before processing any requests via ServeCodec, ServeHTTP,
+// ServeListener etc.
+func (s *Server) SetSlowCallThreshold(threshold time.Duration) {
+	s.slowCallThreshold = threshold
+}
+
 // RegisterName creates a service for the given receiver type under the given name. When no
 // methods on the given receiver match the criteria to be either an RPC method or a
 // subscription an error is returned. Otherwise a new service is created and added to the
@@ -126,6 +150,9 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 		idgen:              s.idgen,
 		batchItemLimit:     s.batchItemLimit,
 		batchResponseLimit: s.batchResponseLimit,
+		rateLimiter:        s.rateLimiter,
+		callBudget:         s.callBudget,
+		slowCallThreshold:  s.slowCallThreshold,
 	}
 	c := initClient(codec, &s.services, cfg)
 	<-codec.closed()
@@ -169,6 +196,8 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 	}
 
 	h := newHandler(ctx, codec, s.idgen, &s.services, s.batchItemLimit, s.batchResponseLimit, s.tracerProvider)
+	h.limits = newConnLimits(s.rateLimiter)
+	h.callBudget, h.slowCallThreshold = s.callBudget, s.slowCallThreshold
 	h.allowSubscribe = false
 	defer h.close(io.EOF, nil)
 
@@ -208,6 +208,36 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 	}
 }
 
+func TestServerCallBudget(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	server.SetCallBudget(50 * time.Millisecond)
+	defer server.Stop()
+	client := DialInProc(server)
+	defer client.Close()
+
+	// Requests within the budget must succeed
+	if err := client.Call(nil, "test_sleep", 10*time.Millisecond); err != nil {
+		t.Fatalf("call within budget failed: %v", err)
+	}
+	// Requests exceeding it must time out, for batches too
+	err := client.Call(nil, "test_sleep", time.Second)
+	if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != errcodeTimeout {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	batch := []BatchElem{
+		{Method: "test_sleep", Args: []interface{}{30 * time.Millisecond}},
+		{Method: "test_sleep", Args: []interface{}{30 * time.Millisecond}},
+	}
+	if err := client.BatchCall(batch); err != nil {
+		t.Fatalf("batch failed: %v", err)
+	}
+	if rpcErr, ok := batch[1].Error.(Error); !ok || rpcErr.ErrorCode() != errcodeTimeout {
+		t.Fatalf("expected timeout error, got %v", batch[1].Error)
+	}
+}
+
 func TestServerWebsocketReadLimit(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,232 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	// streamHeader is the HTTP header by which clients accept chunked results on the
+	// HTTP and WebSocket transports.
+	streamHeader  = "Rpc-Stream"
+	streamChunked = "chunked"
+
+	// streamChunkMethod is the method of the notifications holding the chunks of a
+	// streamed result.
+	streamChunkMethod = "rpc_streamChunk"
+
+	// streamChunkSize is the amount of encoded items collected before they are
+	// written in a chunk.
+	streamChunkSize = 64 * 1024
+)
+
+var errInvalidStreamChunk = errors.New("invalid stream chunk")
+
+// ResultStream is a method result that is produced while the response is written.
+// Methods returning large array results, e.g. logs of a big block range or traces of
+// a block, can return a ResultStream instead of the array to avoid holding the whole
+// encoded result in memory.
+//
+// The function is called with the context of the call, and must pass the items of the
+// result to send in order, stopping when send returns an error.
+//
+// Clients accept streamed results by sending the "Rpc-Stream: chunked" header with the
+// HTTP request or WebSocket handshake. For these clients, the items are encoded as they
+// are sent, and written in notifications holding the id of the call once they exceed
+// the chunk size:
+//
+//	{"jsonrpc":"2.0","method":"rpc_streamChunk","params":{"id":1,"result":[...]}}
+//
+// The call is then answered by a regular response holding the remaining items, or by
+// an error response if the stream fails, and the result of the call is the
+// concatenation of all chunks. The Client of this package accepts chunked results. For
+// other clients, in batches and on other transports, the items are collected before
+// the response is written.
+type ResultStream func(ctx context.Context, send func(item interface{}) error) error
+
+// streamChunk is the parameter object of a stream chunk notification.
+type streamChunk struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+}
+
+type jsonrpcStreamChunk struct {
+	Version string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  streamChunk `json:"params"`
+}
+
+// streamWriter is implemented by connections able to write streamed responses.
+type streamWriter interface {
+	writeStream(ctx context.Context, msg *jsonrpcMessage) error
+}
+
+// finishStream ends the call of a streamed response once the stream was written,
+// or dropped with the given error.
+func (msg *jsonrpcMessage) finishStream(err error) {
+	if msg.streamDone != nil {
+		msg.streamDone(err)
+	}
+}
+
+// streamErrorResponse returns the error response of a stream failing with err.
+func (msg *jsonrpcMessage) streamErrorResponse(ctx context.Context, err error) *jsonrpcMessage {
+	if ctx.Err() != nil {
+		// The call was canceled by the request timeout.
+		err = &internalServerError{errcodeTimeout, errMsgTimeout}
+	}
+	return msg.errorResponse(err)
+}
+
+// buffer runs the stream of a response, returning the response with the collected
+// result.
+func (msg *jsonrpcMessage) buffer(ctx context.Context) *jsonrpcMessage {
+	items := make([]json.RawMessage, 0)
+	err := msg.stream(ctx, func(item interface{}) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		enc, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		items = append(items, enc)
+		return nil
+	})
+	if err != nil {
+		return msg.streamErrorResponse(ctx, err)
+	}
+	return msg.response(items)
+}
+
+// writeStream writes a streamed response to the connection. Results fitting into a
+// single chunk are written as a regular response.
+func (c *jsonCodec) writeStream(ctx context.Context, msg *jsonrpcMessage) error {
+	if c.stream == nil {
+		return c.writeJSON(ctx, msg.buffer(ctx), false)
+	}
+	var (
+		buf      = bytes.NewBufferString("[")
+		chunks   int
+		writeErr error
+	)
+	err := msg.stream(ctx, func(item interface{}) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		enc, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		buf.Write(enc)
+		if buf.Len() < streamChunkSize {
+			return nil
+		}
+		buf.WriteByte(']')
+		chunk := &jsonrpcStreamChunk{
+			Version: vsn,
+			Method:  streamChunkMethod,
+			Params:  streamChunk{ID: msg.ID, Result: buf.Bytes()},
+		}
+		if writeErr = c.writeStreamMessage(chunk); writeErr != nil {
+			return writeErr
+		}
+		chunks++
+		buf.Reset()
+		buf.WriteByte('[')
+		return nil
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	var resp *jsonrpcMessage
+	if err != nil {
+		resp = msg.streamErrorResponse(ctx, err)
+	} else {
+		buf.WriteByte(']')
+		resp = &jsonrpcMessage{Version: vsn, ID: msg.ID, Result: buf.Bytes()}
+	}
+	if chunks == 0 {
+		return c.writeJSON(ctx, resp, resp.Error != nil)
+	}
+	return c.writeStreamMessage(resp)
+}
+
+// writeStreamMessage writes a message of a chunked response. The encoder is only held
+// while the message is written, so other responses and notifications can be sent
+// between the chunks.
+func (c *jsonCodec) writeStreamMessage(v interface{}) error {
+	c.encMu.Lock()
+	defer c.encMu.Unlock()
+
+	// Writing all chunks can take longer than the write timeout, so every message
+	// extends the deadline.
+	c.conn.SetWriteDeadline(time.Now().Add(defaultWriteTimeout))
+	w, err := c.stream()
+	if err != nil {
+		return err
+	}
+	err = json.NewEncoder(w).Encode(v)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// streamResult joins the chunks of a streamed result received by the client.
+type streamResult struct {
+	items   []byte
+	chunked bool
+}
+
+// add appends the items of a chunk.
+func (s *streamResult) add(result json.RawMessage) error {
+	result = bytes.TrimSpace(result)
+	if len(result) < 2 || result[0] != '[' || result[len(result)-1] != ']' {
+		return errInvalidStreamChunk
+	}
+	s.chunked = true
+	items := bytes.TrimSpace(result[1 : len(result)-1])
+	if len(items) == 0 {
+		return nil
+	}
+	if len(s.items) > 0 {
+		s.items = append(s.items, ',')
+	}
+	s.items = append(s.items, items...)
+	return nil
+}
+
+// finish sets the result of the final response of a stream to the joined chunks.
+func (s *streamResult) finish(msg *jsonrpcMessage) error {
+	if !s.chunked || msg.Error != nil {
+		return nil
+	}
+	if err := s.add(msg.Result); err != nil {
+		return err
+	}
+	msg.Result = append(append([]byte{'['}, s.items...), ']')
+	return nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type streamService struct {
+	unblock chan struct{}
+}
+
+// Numbers streams the numbers below n, failing at failAt if it's not negative.
+func (s *streamService) Numbers(n, failAt int) ResultStream {
+	return func(ctx context.Context, send func(interface{}) error) error {
+		for i := 0; i < n; i++ {
+			if i == failAt {
+				return errors.New("stream failed")
+			}
+			if err := send(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Blocked streams a single item once the service is unblocked.
+func (s *streamService) Blocked() ResultStream {
+	return func(ctx context.Context, send func(interface{}) error) error {
+		select {
+		case <-s.unblock:
+			return send(1)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Chunked streams items exceeding a chunk, and then blocks until the service is
+// unblocked.
+func (s *streamService) Chunked() ResultStream {
+	return func(ctx context.Context, send func(interface{}) error) error {
+		for i := 0; i < streamChunkSize; i++ {
+			if err := send(i); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-s.unblock:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func newStreamTestServer(t *testing.T) *Server {
+	server := newTestServer()
+	if err := server.RegisterName("stream", &streamService{unblock: make(chan struct{})}); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	return server
+}
+
+func TestStreamResult(t *testing.T) {
+	t.Parallel()
+
+	server := newStreamTestServer(t)
+	httpsrv := httptest.NewServer(server)
+	t.Cleanup(httpsrv.Close)
+	wssrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	t.Cleanup(wssrv.Close)
+
+	dial := map[string]func() (*Client, error){
+		"inproc": func() (*Client, error) { return DialInProc(server), nil },
+		"http":   func() (*Client, error) { return DialHTTP(httpsrv.URL) },
+		"ws": func() (*Client, error) {
+			return DialWebsocket(context.Background(), "ws"+strings.TrimPrefix(wssrv.URL, "http"), "")
+		},
+	}
+	for name, dial := range dial {
+		client, err := dial()
+		if err != nil {
+			t.Fatalf("%s: failed to dial: %v", name, err)
+		}
+		defer client.Close()
+
+		// Streamed results must be received as regular arrays
+		var result []int
+		if err := client.Call(&result, "stream_numbers", 100000, -1); err != nil {
+			t.Fatalf("%s: call failed: %v", name, err)
+		}
+		if len(result) != 100000 || result[0] != 0 || result[99999] != 99999 {
+			t.Fatalf("%s: result mismatch: have %d items", name, len(result))
+		}
+		if err := client.Call(&result, "stream_numbers", 0, -1); err != nil || len(result) != 0 {
+			t.Fatalf("%s: empty stream mismatch: %v, %v", name, result, err)
+		}
+		// Errors of the stream must fail the call, also after chunks were sent
+		err = client.Call(&result, "stream_numbers", 10, 5)
+		if err == nil || err.Error() != "stream failed" {
+			t.Fatalf("%s: error mismatch: have %v, want stream failed", name, err)
+		}
+		err = client.Call(&result, "stream_numbers", 100000, 50000)
+		if err == nil || err.Error() != "stream failed" {
+			t.Fatalf("%s: chunked error mismatch: have %v, want stream failed", name, err)
+		}
+		// The connection must stay usable
+		var echo echoResult
+		if err := client.Call(&echo, "test_echo", "x", 1); err != nil {
+			t.Fatalf("%s: call after stream failed: %v", name, err)
+		}
+		// Streams must be collected in batches
+		var items []int
+		batch := []BatchElem{
+			{Method: "stream_numbers", Args: []interface{}{3, -1}, Result: &items},
+			{Method: "stream_numbers", Args: []interface{}{3, 1}, Result: new([]int)},
+		}
+		if err := client.BatchCall(batch); err != nil {
+			t.Fatalf("%s: batch failed: %v", name, err)
+		}
+		if batch[0].Error != nil || len(items) != 3 {
+			t.Errorf("%s: batch result mismatch: %v, %v", name, items, batch[0].Error)
+		}
+		if batch[1].Error == nil {
+			t.Errorf("%s: expected batch stream error", name)
+		}
+	}
+}
+
+func TestStreamResultHTTPEncoding(t *testing.T) {
+	t.Parallel()
+
+	server := newStreamTestServer(t)
+	httpsrv := httptest.NewServer(server)
+	t.Cleanup(httpsrv.Close)
+
+	post := func(body string, chunked bool) []string {
+		req, _ := http.NewRequest(http.MethodPost, httpsrv.URL, strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		if chunked {
+			req.Header.Set(streamHeader, streamChunked)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(resp.Body)
+		return strings.Split(string(bytes.TrimSpace(data)), "\n")
+	}
+	tests := []struct {
+		body    string
+		chunked bool
+		want    string
+	}{
+		{
+			body: `{"jsonrpc":"2.0","id":1,"method":"stream_numbers","params":[3,-1]}`,
+			want: `{"jsonrpc":"2.0","id":1,"result":[0,1,2]}`,
+		},
+		{
+			body:    `{"jsonrpc":"2.0","id":1,"method":"stream_numbers","params":[3,-1]}`,
+			chunked: true,
+			want:    `{"jsonrpc":"2.0","id":1,"result":[0,1,2]}`,
+		},
+		{
+			body:    `{"jsonrpc":"2.0","id":"a","method":"stream_numbers","params":[3,2]}`,
+			chunked: true,
+			want:    `{"jsonrpc":"2.0","id":"a","error":{"code":-32000,"message":"stream failed"}}`,
+		},
+	}
+	for _, tt := range tests {
+		have := post(tt.body, tt.chunked)
+		if len(have) != 1 || have[0] != tt.want {
+			t.Errorf("response mismatch:\nhave %s\nwant %s", have, tt.want)
+		}
+	}
+
+	// Large results must only be chunked if the client accepts it
+	body := `{"jsonrpc":"2.0","id":"b","method":"stream_numbers","params":[100000,%d]}`
+	if have := post(fmt.Sprintf(body, -1), false); len(have) != 1 {
+		t.Fatalf("expected a single response, got %d messages", len(have))
+	}
+	for _, failAt := range []int{-1, 50000} {
+		lines := post(fmt.Sprintf(body, failAt), true)
+		if len(lines) < 2 {
+			t.Fatalf("expected chunks, got %d messages", len(lines))
+		}
+		var items []int
+		for i, line := range lines {
+			var msg jsonrpcMessage
+			if err := json.Unmarshal([]byte(line), &msg); err != nil {
+				t.Fatalf("invalid message %d: %v", i, err)
+			}
+			if i < len(lines)-1 {
+				var chunk streamChunk
+				if !msg.isNotification() || msg.Method != streamChunkMethod || json.Unmarshal(msg.Params, &chunk) != nil {
+					t.Fatalf("message %d is not a chunk: %s", i, line)
+				}
+				var chunkItems []int
+				if string(chunk.ID) != `"b"` || json.Unmarshal(chunk.Result, &chunkItems) != nil {
+					t.Fatalf("chunk %d mismatch: %s", i, line)
+				}
+				items = append(items, chunkItems...)
+				continue
+			}
+			// The response must hold either the result or the error
+			if !msg.isResponse() || (msg.Result != nil) == (msg.Error != nil) {
+				t.Fatalf("invalid final response: %s", line)
+			}
+			if failAt >= 0 {
+				if msg.Error == nil || msg.Error.Message != "stream failed" {
+					t.Fatalf("error mismatch: %s", line)
+				}
+				continue
+			}
+			var rest []int
+			if err := json.Unmarshal(msg.Result, &rest); err != nil {
+				t.Fatal(err)
+			}
+			items = append(items, rest...)
+			if len(items) != 100000 || items[99999] != 99999 {
+				t.Fatalf("result mismatch: have %d items", len(items))
+			}
+		}
+	}
+}
+
+func TestStreamResultInterleaved(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	service := &streamService{unblock: make(chan struct{})}
+	if err := server.RegisterName("stream", service); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	wssrv := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	defer wssrv.Close()
+
+	client, err := DialWebsocket(context.Background(), "ws"+strings.TrimPrefix(wssrv.URL, "http"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Other responses must be written while the stream is blocked between chunks
+	var result []int
+	done := make(chan error, 1)
+	go func() { done <- client.Call(&result, "stream_chunked") }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var echo echoResult
+	if err := client.CallContext(ctx, &echo, "test_echo", "x", 1); err != nil {
+		t.Fatalf("call during stream failed: %v", err)
+	}
+	close(service.unblock)
+	if err := <-done; err != nil {
+		t.Fatalf("streaming call failed: %v", err)
+	}
+	if len(result) != streamChunkSize || result[streamChunkSize-1] != streamChunkSize-1 {
+		t.Fatalf("result mismatch: have %d items", len(result))
+	}
+}
+
+func TestStreamResultHoldsLimit(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer()
+	server.SetRateLimits(map[string]RateLimit{"stream": {MaxConcurrent: 1}})
+	service := &streamService{unblock: make(chan struct{})}
+	if err := server.RegisterName("stream", service); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+
+	// Use another connection to probe the slot held by the streamer
+	streamer := DialInProc(server)
+	defer streamer.Close()
+	client := DialInProc(server)
+	defer client.Close()
+
+	// The slot of a streaming call must be held until the stream is done
+	blocked := make(chan error, 1)
+	stream := func() { blocked <- streamer.Call(new([]int), "stream_blocked") }
+	go stream()
+
+	var err error
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		select {
+		case err := <-blocked:
+			// The probe took the slot before the streamer, retry.
+			if !isLimitExceeded(err, "stream") {
+				t.Fatalf("streaming call failed: %v", err)
+			}
+			go stream()
+		default:
+		}
+		if err = client.Call(new([]int), "stream_numbers", 1, -1); err != nil {
+			break
+		}
+	}
+	if !isLimitExceeded(err, "stream") {
+		t.Fatalf("expected concurrency limit error, got %v", err)
+	}
+	close(service.unblock)
+	if err := <-blocked; err != nil {
+		t.Fatalf("streaming call failed: %v", err)
+	}
+	// The slot is released right after the response was written
+	for start := time.Now(); time.Since(start) < 5*time.Second; time.Sleep(10 * time.Millisecond) {
+		if err = client.Call(new([]int), "stream_numbers", 1, -1); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("call failed after stream ended: %v", err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -222,3 +223,50 @@ func TestTracingSubscribeUnsubscribe(t *testing.T) {
 		t.Errorf("expected no spans for subscribe/unsubscribe, got %d", len(spans))
 	}
 }
+
+// TestTracingSlowCall verifies that calls exceeding the slow call threshold are
+// marked on their span.
+func TestTracingSlowCall(t *testing.T) {
+	t.Parallel()
+	server, tracer, exporter := newTracingServer(t)
+	server.SetSlowCallThreshold(50 * time.Millisecond)
+	httpsrv := httptest.NewServer(server)
+	t.Cleanup(httpsrv.Close)
+	client, err := DialHTTP(httpsrv.URL)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	before := rpcSlowCallMeter.Snapshot().Count()
+	if err := client.Call(nil, "test_sleep", 100*time.Millisecond); err != nil {
+		t.Fatalf("RPC call failed: %v", err)
+	}
+	var result echoResult
+	if err := client.Call(&result, "test_echo", "hello", 42); err != nil {
+		t.Fatalf("RPC call failed: %v", err)
+	}
+	if err := tracer.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+	spans := make(map[string]map[string]string)
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = attributeMap(span.Attributes)
+	}
+	sleep, echo := spans["jsonrpc.test/sleep"], spans["jsonrpc.test/echo"]
+	if sleep == nil || echo == nil {
+		t.Fatalf("call spans not found")
+	}
+	if sleep["rpc.slow"] != "true" {
+		t.Errorf("expected slow call to be marked, got %v", sleep["rpc.slow"])
+	}
+	if _, ok := echo["rpc.slow"]; ok {
+		t.Errorf("expected fast call not to be marked")
+	}
+	if echo["rpc.transport"] != "http" || echo["rpc.params.size"] != "12" {
+		t.Errorf("unexpected call attributes: %v", echo)
+	}
+	if count := rpcSlowCallMeter.Snapshot().Count() - before; count < 1 {
+		t.Errorf("slow calls not counted")
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -60,7 +61,8 @@ func (s *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 			log.Debug("WebSocket upgrade failed", "err", err)
 			return
 		}
-		codec := newWebsocketCodec(conn, r.Host, r.Header, s.wsReadLimit)
+		codec := newWebsocketCodec(conn, r.Host, r.Header, s.wsReadLimit, wsPingInterval, wsPongTimeout).(*websocketCodec)
+		codec.callScope, codec.scoped = ScopeFromContext(r.Context())
 		s.ServeCodec(codec, 0)
 	})
 }
@@ -236,6 +238,7 @@ func newClientTransportWS(endpoint string, cfg *clientConfig) (reconnectFunc, er
 	if err != nil {
 		return nil, err
 	}
+	header.Set(streamHeader, streamChunked)
 	for key, values := range cfg.httpHeaders {
 		header[key] = values
 	}
@@ -259,7 +262,14 @@ func newClientTransportWS(endpoint string, cfg *clientConfig) (reconnectFunc, er
 		if cfg.wsMessageSizeLimit != nil && *cfg.wsMessageSizeLimit >= 0 {
 			messageSizeLimit = *cfg.wsMessageSizeLimit
 		}
-		return newWebsocketCodec(conn, dialURL, header, messageSizeLimit), nil
+		pingInterval, pongTimeout := wsPingInterval, wsPongTimeout
+		if cfg.wsPingInterval > 0 {
+			pingInterval = cfg.wsPingInterval
+		}
+		if cfg.wsPongTimeout > 0 {
+			pongTimeout = cfg.wsPongTimeout
+		}
+		return newWebsocketCodec(conn, dialURL, header, messageSizeLimit, pingInterval, pongTimeout), nil
 	}
 	return connect, nil
 }
@@ -289,9 +299,13 @@ type websocketCodec struct {
 	wg           sync.WaitGroup
 	pingReset    chan struct{}
 	pongReceived chan struct{}
+	pingInterval time.Duration // Idle time after which a ping is sent
+	pongTimeout  time.Duration // Time to wait for the pong before dropping the connection
+	callScope    []string      // Namespaces and methods the connection may call
+	scoped       bool          // Whether calls are restricted to callScope
 }
 
-func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit int64) ServerCodec {
+func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readLimit int64, pingInterval, pongTimeout time.Duration) ServerCodec {
 	conn.SetReadLimit(readLimit)
 	encode := func(v interface{}, isErrorResponse bool) error {
 		return conn.WriteJSON(v)
@@ -301,11 +315,18 @@ func newWebsocketCodec(conn *websocket.Conn, host string, req http.Header, readL
 		conn:         conn,
 		pingReset:    make(chan struct{}, 1),
 		pongReceived: make(chan struct{}),
+		pingInterval: pingInterval,
+		pongTimeout:  pongTimeout,
 		info: PeerInfo{
 			Transport:  "ws",
 			RemoteAddr: conn.RemoteAddr().String(),
 		},
 	}
+	if req.Get(streamHeader) == streamChunked {
+		wc.jsonCodec.stream = func() (io.WriteCloser, error) {
+			return conn.NextWriter(websocket.TextMessage)
+		}
+	}
 	// Fill in connection details.
 	wc.info.HTTP.Host = host
 	wc.info.HTTP.Origin = req.Get("Origin")
@@ -328,6 +349,10 @@ func (wc *websocketCodec) close() {
 	wc.wg.Wait()
 }
 
+func (wc *websocketCodec) scope() ([]string, bool) {
+	return wc.callScope, wc.scoped
+}
+
 func (wc *websocketCodec) peerInfo() PeerInfo {
 	return wc.info
 }
@@ -346,7 +371,7 @@ func (wc *websocketCodec) writeJSON(ctx context.Context, v interface{}, isError
 
 // pingLoop sends periodic ping frames when the connection is idle.
 func (wc *websocketCodec) pingLoop() {
-	var pingTimer = time.NewTimer(wsPingInterval)
+	var pingTimer = time.NewTimer(wc.pingInterval)
 	defer wc.wg.Done()
 	defer pingTimer.Stop()
 
@@ -359,15 +384,15 @@ func (wc *websocketCodec) pingLoop() {
 			if !pingTimer.Stop() {
 				<-pingTimer.C
 			}
-			pingTimer.Reset(wsPingInterval)
+			pingTimer.Reset(wc.pingInterval)
 
 		case <-pingTimer.C:
 			wc.jsonCodec.encMu.Lock()
 			wc.conn.SetWriteDeadline(time.Now().Add(wsPingWriteTimeout))
 			wc.conn.WriteMessage(websocket.PingMessage, nil)
-			wc.conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+			wc.conn.SetReadDeadline(time.Now().Add(wc.pongTimeout))
 			wc.jsonCodec.encMu.Unlock()
-			pingTimer.Reset(wsPingInterval)
+			pingTimer.Reset(wc.pingInterval)
 
 		case <-wc.pongReceived:
 			wc.conn.SetReadDeadline(time.Time{})
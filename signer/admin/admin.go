@@ -0,0 +1,335 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package admin implements a REST API for managing the rules of clef remotely,
+// allowing operators to administer many instances programmatically.
+package admin
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/audit"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+const (
+	// RulesetHashKey is the key of the attested hash of the rule file in the
+	// config storage.
+	RulesetHashKey = "ruleset_sha256"
+
+	// maxRulesSize is the largest rule file accepted.
+	maxRulesSize = 1024 * 1024
+
+	// defaultAuditLimit and maxAuditLimit are the default and largest number of
+	// audit log entries returned by a query.
+	defaultAuditLimit = 100
+	maxAuditLimit     = 10000
+)
+
+// RuleEngine is the rule engine managed through the API.
+type RuleEngine interface {
+	// Init replaces the rules being evaluated.
+	Init(rules string) error
+
+	// Whitelist returns the whitelisted addresses.
+	Whitelist() []common.Address
+
+	// AddToWhitelist adds an address to the whitelist.
+	AddToWhitelist(addr common.Address) error
+
+	// RemoveFromWhitelist removes an address from the whitelist.
+	RemoveFromWhitelist(addr common.Address) error
+}
+
+// Config configures the admin API.
+type Config struct {
+	Token      []byte          // Bearer token authenticating the requests
+	RuleSigner *common.Address // Account required to sign rule files, rotation disabled if nil
+	RuleFile   string          // File the rotated rules are written to, rotation disabled if empty
+	AuditLog   *audit.Log      // Signing audit log to query, queries disabled if nil
+}
+
+// API serves the admin API over HTTP:
+//
+//	GET    /whitelist             lists the whitelisted addresses
+//	PUT    /whitelist/{address}   adds an address to the whitelist
+//	DELETE /whitelist/{address}   removes an address from the whitelist
+//	GET    /rules                 returns the hash of the attested rule file
+//	PUT    /rules                 rotates the rule file, see rotateRequest
+//	GET    /audit                 returns the last entries of the audit log, see queryAudit
+//
+// All requests must carry the configured token in an Authorization header, as
+// "Bearer <token>".
+type API struct {
+	config Config
+	rules  RuleEngine
+	attest storage.Storage // Config storage holding the attested hash of the rules
+	mux    *http.ServeMux
+}
+
+// New creates the admin API, managing the given rule engine. The hash of rotated
+// rule files is attested in the config storage.
+func New(config Config, rules RuleEngine, attest storage.Storage) *API {
+	api := &API{config: config, rules: rules, attest: attest, mux: http.NewServeMux()}
+	api.mux.HandleFunc("GET /whitelist", api.listWhitelist)
+	api.mux.HandleFunc("PUT /whitelist/{address}", api.addWhitelist)
+	api.mux.HandleFunc("DELETE /whitelist/{address}", api.removeWhitelist)
+	api.mux.HandleFunc("GET /rules", api.getRules)
+	api.mux.HandleFunc("PUT /rules", api.rotateRules)
+	api.mux.HandleFunc("GET /audit", api.queryAudit)
+	return api
+}
+
+// ServeHTTP implements http.Handler, authenticating the requests.
+func (api *API) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || len(api.config.Token) == 0 || subtle.ConstantTimeCompare([]byte(token), api.config.Token) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+		return
+	}
+	api.mux.ServeHTTP(w, r)
+}
+
+// writeJSON responds with a JSON encoded value.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with an error.
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+// pathAddress parses the address in the path of a request.
+func pathAddress(r *http.Request) (common.Address, error) {
+	addr := r.PathValue("address")
+	if !common.IsHexAddress(addr) {
+		return common.Address{}, fmt.Errorf("invalid address %q", addr)
+	}
+	return common.HexToAddress(addr), nil
+}
+
+func (api *API) listWhitelist(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, api.rules.Whitelist())
+}
+
+func (api *API) addWhitelist(w http.ResponseWriter, r *http.Request) {
+	addr, err := pathAddress(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := api.rules.AddToWhitelist(addr); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.Info("Address whitelisted", "address", addr, "remote", r.RemoteAddr)
+	writeJSON(w, api.rules.Whitelist())
+}
+
+func (api *API) removeWhitelist(w http.ResponseWriter, r *http.Request) {
+	addr, err := pathAddress(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := api.rules.RemoveFromWhitelist(addr); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.Info("Address removed from whitelist", "address", addr, "remote", r.RemoteAddr)
+	writeJSON(w, api.rules.Whitelist())
+}
+
+// rulesResponse describes the attested rule file.
+type rulesResponse struct {
+	SHA256 string `json:"sha256"`
+}
+
+func (api *API) getRules(w http.ResponseWriter, r *http.Request) {
+	hash, _ := api.attest.Get(RulesetHashKey)
+	writeJSON(w, rulesResponse{SHA256: hash})
+}
+
+// rotateRequest is the payload of rule rotations. The signature is made by the
+// rule signer over the rules, as an EIP-191 personal message.
+type rotateRequest struct {
+	Rules     string        `json:"rules"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// verifyRules checks that the rules are signed by the configured rule signer.
+func (api *API) verifyRules(rules string, sig []byte) error {
+	if len(sig) != crypto.SignatureLength {
+		return errors.New("invalid signature length")
+	}
+	sig = common.CopyBytes(sig)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pubkey, err := crypto.SigToPub(accounts.TextHash([]byte(rules)), sig)
+	if err != nil {
+		return err
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != *api.config.RuleSigner {
+		return fmt.Errorf("rules signed by %v, not by the rule signer", signer)
+	}
+	return nil
+}
+
+// writeRules replaces the rule file, leaving it read-only.
+func (api *API) writeRules(rules string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(api.config.RuleFile), ".rules-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(rules); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0400); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), api.config.RuleFile)
+}
+
+func (api *API) rotateRules(w http.ResponseWriter, r *http.Request) {
+	if api.config.RuleSigner == nil || api.config.RuleFile == "" {
+		writeError(w, http.StatusForbidden, errors.New("rule rotation not enabled"))
+		return
+	}
+	var req rotateRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRulesSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := api.verifyRules(req.Rules, req.Signature); err != nil {
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+	// Refuse rules failing to compile before replacing the working ones
+	if _, err := goja.Compile("rules.js", req.Rules, false); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid rules: %v", err))
+		return
+	}
+	// Write and attest the rules before installing them, so they are kept on
+	// restart.
+	if err := api.writeRules(req.Rules); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	sum := sha256.Sum256([]byte(req.Rules))
+	hash := hex.EncodeToString(sum[:])
+
+	// The storage doesn't report failed writes, so read the attestation back
+	api.attest.Put(RulesetHashKey, hash)
+	if attested, err := api.attest.Get(RulesetHashKey); err != nil || attested != hash {
+		writeError(w, http.StatusInternalServerError, errors.New("failed to attest rules"))
+		return
+	}
+	if err := api.rules.Init(req.Rules); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	log.Info("Rules rotated", "hash", hash, "remote", r.RemoteAddr)
+	writeJSON(w, rulesResponse{SHA256: hash})
+}
+
+// auditResponse holds the entries matching an audit log query.
+type auditResponse struct {
+	Head    common.Hash   `json:"head"`    // Hash of the last entry of the log
+	Entries []audit.Entry `json:"entries"` // Last matching entries, in order
+}
+
+// queryAudit returns the last entries of the signing audit log, filtered by the
+// optional query parameters:
+//
+//	account=0x...    account requested to sign
+//	kind=...         kind of the signed digest, e.g. "transaction"
+//	origin=...       origin of the request
+//	since=<RFC3339>  earliest time of the operation
+//	until=<RFC3339>  latest time of the operation (exclusive)
+//	limit=N          number of entries to return, 100 by default
+func (api *API) queryAudit(w http.ResponseWriter, r *http.Request) {
+	if api.config.AuditLog == nil {
+		writeError(w, http.StatusNotFound, errors.New("audit log not enabled"))
+		return
+	}
+	var (
+		query  = r.URL.Query()
+		filter = audit.Filter{Kind: query.Get("kind"), Origin: query.Get("origin")}
+		limit  = defaultAuditLimit
+	)
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 || n > maxAuditLimit {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit %q", s))
+			return
+		}
+		limit = n
+	}
+	if s := query.Get("account"); s != "" {
+		if !common.IsHexAddress(s) {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid account %q", s))
+			return
+		}
+		addr := common.HexToAddress(s)
+		filter.Account = &addr
+	}
+	for key, t := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+		if s := query.Get(key); s != "" {
+			var err error
+			if *t, err = time.Parse(time.RFC3339, s); err != nil {
+				writeError(w, http.StatusBadRequest, fmt.Errorf("invalid %s %q", key, s))
+				return
+			}
+		}
+	}
+	entries := api.config.AuditLog.Query(filter)
+	if entries == nil {
+		entries = []audit.Entry{}
+	}
+	if len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	writeJSON(w, auditResponse{Head: api.config.AuditLog.Head(), Entries: entries})
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/audit"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+// testEngine is a rule engine recording the changes made through the API.
+type testEngine struct {
+	rules     string
+	whitelist []common.Address
+}
+
+func (e *testEngine) Init(rules string) error {
+	e.rules = rules
+	return nil
+}
+
+func (e *testEngine) Whitelist() []common.Address {
+	return append([]common.Address{}, e.whitelist...)
+}
+
+func (e *testEngine) AddToWhitelist(addr common.Address) error {
+	if !slices.Contains(e.whitelist, addr) {
+		e.whitelist = append(e.whitelist, addr)
+	}
+	return nil
+}
+
+func (e *testEngine) RemoveFromWhitelist(addr common.Address) error {
+	e.whitelist = slices.DeleteFunc(e.whitelist, func(a common.Address) bool { return a == addr })
+	return nil
+}
+
+// testRequest sends an authenticated request to the API, decoding the response
+// into res if given.
+func testRequest(t *testing.T, api *API, method, path string, body interface{}, res interface{}) int {
+	t.Helper()
+
+	var payload []byte
+	if body != nil {
+		payload, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	api.ServeHTTP(rec, req)
+
+	if res != nil && rec.Code == http.StatusOK {
+		if err := json.Unmarshal(rec.Body.Bytes(), res); err != nil {
+			t.Fatalf("%s %s: invalid response: %v", method, path, err)
+		}
+	}
+	return rec.Code
+}
+
+func TestAuthentication(t *testing.T) {
+	t.Parallel()
+
+	api := New(Config{Token: []byte("token")}, new(testEngine), storage.NewEphemeralStorage())
+	for _, header := range []string{"", "Bearer", "Bearer other", "token", "Basic token"} {
+		req := httptest.NewRequest(http.MethodGet, "/whitelist", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("header %q: status mismatch: have %d, want %d", header, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestWhitelist(t *testing.T) {
+	t.Parallel()
+
+	var (
+		engine = new(testEngine)
+		api    = New(Config{Token: []byte("token")}, engine, storage.NewEphemeralStorage())
+		a, b   = common.Address{0x0a}, common.Address{0x0b}
+		list   []common.Address
+	)
+	for _, addr := range []common.Address{a, b, a} {
+		if status := testRequest(t, api, http.MethodPut, "/whitelist/"+addr.Hex(), nil, &list); status != http.StatusOK {
+			t.Fatalf("failed to whitelist %v: status %d", addr, status)
+		}
+	}
+	if status := testRequest(t, api, http.MethodDelete, "/whitelist/"+a.Hex(), nil, &list); status != http.StatusOK {
+		t.Fatalf("failed to remove %v: status %d", a, status)
+	}
+	if status := testRequest(t, api, http.MethodGet, "/whitelist", nil, &list); status != http.StatusOK {
+		t.Fatalf("failed to list whitelist: status %d", status)
+	}
+	if !slices.Equal(list, []common.Address{b}) {
+		t.Errorf("whitelist mismatch: have %v, want %v", list, []common.Address{b})
+	}
+	if status := testRequest(t, api, http.MethodPut, "/whitelist/0xinvalid", nil, nil); status != http.StatusBadRequest {
+		t.Errorf("invalid address status mismatch: have %d, want %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestRotateRules(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _   = crypto.GenerateKey()
+		other, _ = crypto.GenerateKey()
+		signer   = crypto.PubkeyToAddress(key.PublicKey)
+		file     = filepath.Join(t.TempDir(), "rules.js")
+		engine   = new(testEngine)
+		attest   = storage.NewEphemeralStorage()
+		rules    = `function ApproveListing() { return "Approve" }`
+	)
+	signRules := func(rules string, signer bool) []byte {
+		k := other
+		if signer {
+			k = key
+		}
+		sig, err := crypto.Sign(accounts.TextHash([]byte(rules)), k)
+		if err != nil {
+			t.Fatalf("failed to sign rules: %v", err)
+		}
+		sig[crypto.RecoveryIDOffset] += 27
+		return sig
+	}
+	// Rotation must be refused unless enabled
+	api := New(Config{Token: []byte("token")}, engine, attest)
+	req := rotateRequest{Rules: rules, Signature: signRules(rules, true)}
+	if status := testRequest(t, api, http.MethodPut, "/rules", req, nil); status != http.StatusForbidden {
+		t.Fatalf("disabled rotation status mismatch: have %d, want %d", status, http.StatusForbidden)
+	}
+	api = New(Config{Token: []byte("token"), RuleSigner: &signer, RuleFile: file}, engine, attest)
+
+	// Rules signed by others or tampered with must be refused
+	for i, req := range []rotateRequest{
+		{Rules: rules, Signature: signRules(rules, false)},
+		{Rules: rules + " ", Signature: signRules(rules, true)},
+		{Rules: rules, Signature: []byte{0x01}},
+	} {
+		if status := testRequest(t, api, http.MethodPut, "/rules", req, nil); status != http.StatusForbidden {
+			t.Errorf("test %d: status mismatch: have %d, want %d", i, status, http.StatusForbidden)
+		}
+	}
+	if engine.rules != "" {
+		t.Fatalf("unauthorized rules installed")
+	}
+	// Signed rules failing to compile must be refused
+	broken := `function ApproveListing() { return "Approve"`
+	if status := testRequest(t, api, http.MethodPut, "/rules", rotateRequest{Rules: broken, Signature: signRules(broken, true)}, nil); status != http.StatusBadRequest {
+		t.Fatalf("broken rules status mismatch: have %d, want %d", status, http.StatusBadRequest)
+	}
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Fatalf("broken rules written")
+	}
+	// Signed rules must be written, attested and installed
+	var res rulesResponse
+	if status := testRequest(t, api, http.MethodPut, "/rules", req, &res); status != http.StatusOK {
+		t.Fatalf("rotation status mismatch: have %d, want %d", status, http.StatusOK)
+	}
+	hash := sha256.Sum256([]byte(rules))
+	if want := hex.EncodeToString(hash[:]); res.SHA256 != want {
+		t.Errorf("hash mismatch: have %s, want %s", res.SHA256, want)
+	}
+	if attested, _ := attest.Get(RulesetHashKey); attested != res.SHA256 {
+		t.Errorf("attested hash mismatch: have %s, want %s", attested, res.SHA256)
+	}
+	if stored, err := os.ReadFile(file); err != nil || string(stored) != rules {
+		t.Errorf("rule file mismatch: have %q (err %v), want %q", stored, err, rules)
+	}
+	if engine.rules != rules {
+		t.Errorf("installed rules mismatch: have %q, want %q", engine.rules, rules)
+	}
+	// The read-only rule file must be replaceable by later rotations
+	rules = `function ApproveListing() { return "Reject" }`
+	req = rotateRequest{Rules: rules, Signature: signRules(rules, true)}
+	if status := testRequest(t, api, http.MethodPut, "/rules", req, nil); status != http.StatusOK {
+		t.Fatalf("second rotation status mismatch: have %d, want %d", status, http.StatusOK)
+	}
+	if engine.rules != rules {
+		t.Errorf("installed rules mismatch: have %q, want %q", engine.rules, rules)
+	}
+}
+
+func TestQueryAudit(t *testing.T) {
+	t.Parallel()
+
+	auditLog, err := audit.Open(filepath.Join(t.TempDir(), "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer auditLog.Close()
+
+	var (
+		alice = common.HexToAddress("0xa11ce")
+		bob   = common.HexToAddress("0xb0b")
+		start = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+	for i := 0; i < 5; i++ {
+		entry := audit.Entry{Time: start.Add(time.Duration(i) * time.Hour), Account: alice, Kind: audit.KindTransaction, Approved: true}
+		if i%2 == 1 {
+			entry.Account, entry.Kind = bob, audit.KindText
+		}
+		if _, err := auditLog.Append(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	api := New(Config{Token: []byte("token"), AuditLog: auditLog}, new(testEngine), storage.NewEphemeralStorage())
+
+	for _, tt := range []struct {
+		query string
+		want  []uint64
+	}{
+		{"", []uint64{0, 1, 2, 3, 4}},
+		{"?limit=2", []uint64{3, 4}},
+		{"?limit=10", []uint64{0, 1, 2, 3, 4}},
+		{"?account=" + bob.Hex(), []uint64{1, 3}},
+		{"?kind=transaction&limit=2", []uint64{2, 4}},
+		{"?since=2025-01-01T01:00:00Z&until=2025-01-01T03:00:00Z", []uint64{1, 2}},
+		{"?origin=nobody", []uint64{}},
+	} {
+		var res auditResponse
+		if status := testRequest(t, api, http.MethodGet, "/audit"+tt.query, nil, &res); status != http.StatusOK {
+			t.Fatalf("query %q: status %d", tt.query, status)
+		}
+		seqs := make([]uint64, 0, len(res.Entries))
+		for _, entry := range res.Entries {
+			seqs = append(seqs, entry.Seq)
+		}
+		if !slices.Equal(seqs, tt.want) {
+			t.Errorf("query %q: entries mismatch: have %v, want %v", tt.query, seqs, tt.want)
+		}
+		if res.Head != auditLog.Head() {
+			t.Errorf("query %q: head mismatch: have %v, want %v", tt.query, res.Head, auditLog.Head())
+		}
+	}
+	for _, query := range []string{"?limit=0", "?account=0x1", "?since=yesterday"} {
+		if status := testRequest(t, api, http.MethodGet, "/audit"+query, nil, nil); status != http.StatusBadRequest {
+			t.Errorf("query %q: status mismatch: have %d, want %d", query, status, http.StatusBadRequest)
+		}
+	}
+}
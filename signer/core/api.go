@@ -28,6 +28,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/accounts/pkcs11"
 	"github.com/ethereum/go-ethereum/accounts/scwallet"
 	"github.com/ethereum/go-ethereum/accounts/usbwallet"
 	"github.com/ethereum/go-ethereum/common"
@@ -44,9 +45,9 @@ const (
 	// numberOfAccountsToDerive For hardware wallets, the number of accounts to derive
 	numberOfAccountsToDerive = 10
 	// ExternalAPIVersion -- see extapi_changelog.md
-	ExternalAPIVersion = "6.1.0"
+	ExternalAPIVersion = "6.3.0"
 	// InternalAPIVersion -- see intapi_changelog.md
-	InternalAPIVersion = "7.0.1"
+	InternalAPIVersion = "7.3.0"
 )
 
 // ExternalAPI defines the external API through which signing requests are made.
@@ -57,6 +58,8 @@ type ExternalAPI interface {
 	New(ctx context.Context) (common.Address, error)
 	// SignTransaction request to sign the specified transaction
 	SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*ethapi.SignTransactionResult, error)
+	// SignTransactions request to sign the specified batch of transactions with a single approval
+	SignTransactions(ctx context.Context, args []apitypes.SendTxArgs) ([]*ethapi.SignTransactionResult, error)
 	// SignData - request to sign the given data (plus prefix)
 	SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data interface{}) (hexutil.Bytes, error)
 	// SignTypedData - request to sign the given structured data (plus prefix)
@@ -67,6 +70,8 @@ type ExternalAPI interface {
 	Version(ctx context.Context) (string, error)
 	// SignGnosisSafeTx signs/confirms a gnosis-safe multisig transaction
 	SignGnosisSafeTx(ctx context.Context, signerAddress common.MixedcaseAddress, gnosisTx GnosisSafeTx, methodSelector *string) (*GnosisSafeTx, error)
+	// SignUserOperation signs an ERC-4337 user operation as the owner of the sending account
+	SignUserOperation(ctx context.Context, signerAddress common.MixedcaseAddress, op UserOperationArgs, entryPoint common.Address, callABI *string) (*UserOperationArgs, error)
 }
 
 // UIClientAPI specifies what method a UI needs to implement to be able to be used as a
@@ -74,6 +79,8 @@ type ExternalAPI interface {
 type UIClientAPI interface {
 	// ApproveTx prompt the user for confirmation to request to sign Transaction
 	ApproveTx(request *SignTxRequest) (SignTxResponse, error)
+	// ApproveTxBatch prompt the user for a single confirmation to sign a batch of Transactions
+	ApproveTxBatch(request *SignTxBatchRequest) (SignTxBatchResponse, error)
 	// ApproveSignData prompt the user for confirmation to request to sign data
 	ApproveSignData(request *SignDataRequest) (SignDataResponse, error)
 	// ApproveListing prompt the user for confirmation to list accounts
@@ -111,6 +118,15 @@ type Validator interface {
 	ValidateTransaction(selector *string, tx *apitypes.SendTxArgs) (*apitypes.ValidationMessages, error)
 }
 
+// CallDecoder is implemented by validators able to decode contract call data with
+// user supplied ABIs, such as fourbyte.Database. It is used to render the calls
+// embedded in typed data, rather than showing them as raw hex.
+type CallDecoder interface {
+	// DecodeCallData renders call data sent to the given contract, if known, with
+	// the names of the method and its arguments.
+	DecodeCallData(to *common.Address, data []byte) (string, error)
+}
+
 // SignerAPI defines the actual implementation of ExternalAPI
 type SignerAPI struct {
 	chainID     *big.Int
@@ -130,7 +146,13 @@ type Metadata struct {
 	Origin    string `json:"Origin"`
 }
 
-func StartClefAccountManager(ksLocation string, nousb, lightKDF bool, scpath string) *accounts.Manager {
+func StartClefAccountManager(ksLocation string, nousb, lightKDF bool, scpath string, p11lib string) *accounts.Manager {
+	return accounts.NewManager(nil, ClefBackends(ksLocation, nousb, lightKDF, scpath, p11lib)...)
+}
+
+// ClefBackends starts the account backends used by clef, for the callers wrapping
+// them before assembling the account manager.
+func ClefBackends(ksLocation string, nousb, lightKDF bool, scpath string, p11lib string) []accounts.Backend {
 	var (
 		backends []accounts.Backend
 		n, p     = keystore.StandardScryptN, keystore.StandardScryptP
@@ -184,7 +206,15 @@ func StartClefAccountManager(ksLocation string, nousb, lightKDF bool, scpath str
 			}
 		}
 	}
-	return accounts.NewManager(nil, backends...)
+	// Start a hub for the tokens of a hardware security module
+	if len(p11lib) > 0 {
+		if p11hub, err := pkcs11.NewHub(p11lib); err != nil {
+			log.Warn(fmt.Sprintf("Failed to start PKCS#11 hub, disabling: %v", err))
+		} else {
+			backends = append(backends, p11hub)
+		}
+	}
+	return backends
 }
 
 // MetadataFromContext extracts Metadata from a given context.Context
@@ -233,6 +263,28 @@ type (
 		Transaction apitypes.SendTxArgs `json:"transaction"`
 		Approved    bool                `json:"approved"`
 	}
+	// SignTxBatchRequest contains info about a batch of Transactions to sign with
+	// a single approval, along with a summary of the whole batch
+	SignTxBatchRequest struct {
+		Transactions []*SignTxRequest `json:"transactions"`
+		Summary      TxBatchSummary   `json:"summary"`
+		Meta         Metadata         `json:"meta"`
+	}
+	// TxBatchSummary aggregates the Transactions of a batch for review
+	TxBatchSummary struct {
+		Count        int              `json:"count"`
+		TotalValue   *hexutil.Big     `json:"total_value"`
+		Senders      []common.Address `json:"senders"`
+		Destinations []common.Address `json:"destinations"`
+		Creations    int              `json:"creations"`
+		NonceMin     hexutil.Uint64   `json:"nonce_min"`
+		NonceMax     hexutil.Uint64   `json:"nonce_max"`
+	}
+	// SignTxBatchResponse result from SignTxBatchRequest. Unlike single Transactions,
+	// a batch cannot be modified by the UI
+	SignTxBatchResponse struct {
+		Approved bool `json:"approved"`
+	}
 	SignDataRequest struct {
 		ContentType string                    `json:"content_type"`
 		Address     common.MixedcaseAddress   `json:"address"`
@@ -241,6 +293,10 @@ type (
 		Callinfo    []apitypes.ValidationInfo `json:"call_info"`
 		Hash        hexutil.Bytes             `json:"hash"`
 		Meta        Metadata                  `json:"meta"`
+
+		UserOperation *UserOperationInfo `json:"user_operation,omitempty"` // User operation behind the hash, if any
+
+		typedData *apitypes.TypedData // Typed data behind the raw data, if any
 	}
 	SignDataResponse struct {
 		Approved bool `json:"approved"`
@@ -286,8 +342,10 @@ func NewSignerAPI(am *accounts.Manager, chainID int64, noUSB bool, ui UIClientAP
 		log.Info("Clef is in advanced mode: will warn instead of reject")
 	}
 	signer := &SignerAPI{big.NewInt(chainID), am, ui, validator, !advancedMode, credentials}
-	if !noUSB {
-		signer.startUSBListener()
+	// Security modules are configured separately from USB devices, so they need
+	// the listener even if USB support is disabled
+	if !noUSB || len(am.Backends(pkcs11.HubType)) > 0 {
+		signer.startWalletListener()
 	}
 	return signer
 }
@@ -321,8 +379,29 @@ func (api *SignerAPI) openTrezor(url accounts.URL) {
 	}
 }
 
-// startUSBListener starts a listener for USB events, for hardware wallet interaction
-func (api *SignerAPI) startUSBListener() {
+func (api *SignerAPI) openPKCS11(url accounts.URL) {
+	resp, err := api.UI.OnInputRequired(UserInputRequest{
+		Prompt:     fmt.Sprintf("PIN required to open PKCS#11 token %v", url),
+		IsPassword: true,
+		Title:      "PKCS#11 login",
+	})
+	if err != nil {
+		log.Warn("failed getting PKCS#11 pin", "err", err)
+		return
+	}
+	w, err := api.am.Wallet(url.String())
+	if err != nil {
+		log.Warn("wallet unavailable", "url", url)
+		return
+	}
+	if err = w.Open(resp.Text); err != nil {
+		log.Warn("failed to open wallet", "wallet", url, "err", err)
+	}
+}
+
+// startWalletListener starts a listener for wallet events, for hardware wallet and
+// security module interaction
+func (api *SignerAPI) startWalletListener() {
 	eventCh := make(chan accounts.WalletEvent, 16)
 	am := api.am
 	am.Subscribe(eventCh)
@@ -333,6 +412,9 @@ func (api *SignerAPI) startUSBListener() {
 			if err == usbwallet.ErrTrezorPINNeeded {
 				go api.openTrezor(wallet.URL())
 			}
+			if err == pkcs11.ErrPINNeeded {
+				go api.openPKCS11(wallet.URL())
+			}
 		}
 	}
 	go api.derivationLoop(eventCh)
@@ -349,10 +431,17 @@ func (api *SignerAPI) derivationLoop(events chan accounts.WalletEvent) {
 				if err == usbwallet.ErrTrezorPINNeeded {
 					go api.openTrezor(event.Wallet.URL())
 				}
+				if err == pkcs11.ErrPINNeeded {
+					go api.openPKCS11(event.Wallet.URL())
+				}
 			}
 		case accounts.WalletOpened:
 			status, _ := event.Wallet.Status()
 			log.Info("New wallet appeared", "url", event.Wallet.URL(), "status", status)
+			if event.Wallet.URL().Scheme == pkcs11.Scheme {
+				// Keys of security modules are stored, not derived
+				continue
+			}
 			var derive = func(limit int, next func() accounts.DerivationPath) {
 				// Derive first N accounts, hardcoded for now
 				for i := 0; i < limit; i++ {
@@ -538,12 +627,8 @@ func (api *SignerAPI) lookupOrQueryPassword(address common.Address, title, promp
 	return pwResp.Text, nil
 }
 
-// SignTransaction signs the given Transaction and returns it both as json and rlp-encoded form
-func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*ethapi.SignTransactionResult, error) {
-	var (
-		err    error
-		result SignTxResponse
-	)
+// newSignTxRequest validates a Transaction and assembles the request to approve it.
+func (api *SignerAPI) newSignTxRequest(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*SignTxRequest, error) {
 	msgs, err := api.validator.ValidateTransaction(methodSelector, &args)
 	if err != nil {
 		return nil, err
@@ -563,58 +648,156 @@ func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxA
 				requestedChainId)
 		}
 	}
-	req := SignTxRequest{
+	return &SignTxRequest{
 		Transaction: args,
 		Meta:        MetadataFromContext(ctx),
 		Callinfo:    msgs.Messages,
+	}, nil
+}
+
+// signTx signs an approved Transaction with the given password, and notifies the
+// UI about it.
+func (api *SignerAPI) signTx(args apitypes.SendTxArgs, pw string) (*ethapi.SignTransactionResult, error) {
+	response, err := api.createSignedTx(args, pw)
+	if err != nil {
+		return nil, err
 	}
-	// Process approval
-	result, err = api.UI.ApproveTx(&req)
+	// Finally, send the signed tx to the UI
+	api.UI.OnApprovedTx(*response)
+	return response, nil
+}
+
+// createSignedTx signs an approved Transaction with the given password, without
+// notifying the UI about it.
+func (api *SignerAPI) createSignedTx(args apitypes.SendTxArgs, pw string) (*ethapi.SignTransactionResult, error) {
+	acc := accounts.Account{Address: args.From.Address()}
+	wallet, err := api.am.Find(acc)
 	if err != nil {
 		return nil, err
 	}
-	if !result.Approved {
-		return nil, ErrRequestDenied
+	// Convert fields into a real transaction
+	unsignedTx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
 	}
-	// Log changes made by the UI to the signing-request
-	logDiff(&req, &result)
-	var (
-		acc    accounts.Account
-		wallet accounts.Wallet
-	)
-	acc = accounts.Account{Address: result.Transaction.From.Address()}
-	wallet, err = api.am.Find(acc)
+	signedTx, err := wallet.SignTxWithPassphrase(acc, pw, unsignedTx, api.chainID)
 	if err != nil {
+		api.UI.ShowError(err.Error())
 		return nil, err
 	}
-	// Convert fields into a real transaction
-	unsignedTx, err := result.Transaction.ToTransaction()
+	data, err := signedTx.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
-	// Get the password for the transaction
-	pw, err := api.lookupOrQueryPassword(acc.Address, "Account password",
-		fmt.Sprintf("Please enter the password for account %s", acc.Address.String()))
+	return &ethapi.SignTransactionResult{Raw: data, Tx: signedTx}, nil
+}
+
+// SignTransaction signs the given Transaction and returns it both as json and rlp-encoded form
+func (api *SignerAPI) SignTransaction(ctx context.Context, args apitypes.SendTxArgs, methodSelector *string) (*ethapi.SignTransactionResult, error) {
+	req, err := api.newSignTxRequest(ctx, args, methodSelector)
 	if err != nil {
 		return nil, err
 	}
-	// The one to sign is the one that was returned from the UI
-	signedTx, err := wallet.SignTxWithPassphrase(acc, pw, unsignedTx, api.chainID)
+	// Process approval
+	result, err := api.UI.ApproveTx(req)
 	if err != nil {
-		api.UI.ShowError(err.Error())
 		return nil, err
 	}
+	if !result.Approved {
+		return nil, ErrRequestDenied
+	}
+	// Log changes made by the UI to the signing-request
+	logDiff(req, &result)
 
-	data, err := signedTx.MarshalBinary()
+	// Get the password for the transaction
+	from := result.Transaction.From.Address()
+	pw, err := api.lookupOrQueryPassword(from, "Account password",
+		fmt.Sprintf("Please enter the password for account %s", from.String()))
 	if err != nil {
 		return nil, err
 	}
-	response := ethapi.SignTransactionResult{Raw: data, Tx: signedTx}
+	// The one to sign is the one that was returned from the UI,
+	// and it is passed on to the external caller
+	return api.signTx(result.Transaction, pw)
+}
 
-	// Finally, send the signed tx to the UI
-	api.UI.OnApprovedTx(response)
-	// ...and to the external caller
-	return &response, nil
+// SignTransactions signs the given batch of Transactions after a single approval
+// of the whole batch, and returns them in the same order. The batch is either
+// signed completely, or not at all: the UI is only notified of the signed
+// Transactions once all of them are signed.
+func (api *SignerAPI) SignTransactions(ctx context.Context, args []apitypes.SendTxArgs) ([]*ethapi.SignTransactionResult, error) {
+	if len(args) == 0 {
+		return nil, errors.New("empty transaction batch")
+	}
+	req := &SignTxBatchRequest{
+		Transactions: make([]*SignTxRequest, len(args)),
+		Meta:         MetadataFromContext(ctx),
+	}
+	for i := range args {
+		txreq, err := api.newSignTxRequest(ctx, args[i], nil)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		req.Transactions[i] = txreq
+	}
+	req.Summary = newTxBatchSummary(args)
+
+	// Process approval
+	result, err := api.UI.ApproveTxBatch(req)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Approved {
+		return nil, ErrRequestDenied
+	}
+	// Get the passwords of all senders before signing anything
+	passwords := make(map[common.Address]string)
+	for _, from := range req.Summary.Senders {
+		pw, err := api.lookupOrQueryPassword(from, "Account password",
+			fmt.Sprintf("Please enter the password for account %s", from.String()))
+		if err != nil {
+			return nil, err
+		}
+		passwords[from] = pw
+	}
+	signed := make([]*ethapi.SignTransactionResult, len(args))
+	for i := range args {
+		if signed[i], err = api.createSignedTx(args[i], passwords[args[i].From.Address()]); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+	}
+	for _, response := range signed {
+		api.UI.OnApprovedTx(*response)
+	}
+	return signed, nil
+}
+
+// newTxBatchSummary aggregates a batch of Transactions, listing the senders and
+// destinations in the order of their first appearance.
+func newTxBatchSummary(txs []apitypes.SendTxArgs) TxBatchSummary {
+	var (
+		total   = new(big.Int)
+		summary = TxBatchSummary{Count: len(txs), NonceMin: txs[0].Nonce, NonceMax: txs[0].Nonce}
+		seen    = make(map[common.Address]bool)
+		sent    = make(map[common.Address]bool)
+	)
+	for _, tx := range txs {
+		total.Add(total, tx.Value.ToInt())
+		if from := tx.From.Address(); !sent[from] {
+			sent[from] = true
+			summary.Senders = append(summary.Senders, from)
+		}
+		if tx.To == nil {
+			summary.Creations++
+		} else if to := tx.To.Address(); !seen[to] {
+			seen[to] = true
+			summary.Destinations = append(summary.Destinations, to)
+		}
+		summary.NonceMin = min(summary.NonceMin, tx.Nonce)
+		summary.NonceMax = max(summary.NonceMax, tx.Nonce)
+	}
+	summary.TotalValue = (*hexutil.Big)(total)
+	return summary
 }
 
 func (api *SignerAPI) SignGnosisSafeTx(ctx context.Context, signerAddress common.MixedcaseAddress, gnosisTx GnosisSafeTx, methodSelector *string) (*GnosisSafeTx, error) {
@@ -664,6 +847,35 @@ func (api *SignerAPI) SignGnosisSafeTx(ctx context.Context, signerAddress common
 	return &gnosisTx, nil
 }
 
+// SignUserOperation signs the hash of an ERC-4337 user operation for the given
+// entry point and the configured chain as an EIP-191 text message, as validated
+// by the common smart contract accounts owned by an externally owned account.
+// If the ABI of the account is supplied, the call data of the operation is
+// decoded for review. The operation is returned carrying the signature.
+func (api *SignerAPI) SignUserOperation(ctx context.Context, signerAddress common.MixedcaseAddress, op UserOperationArgs, entryPoint common.Address, callABI *string) (*UserOperationArgs, error) {
+	info, err := newUserOperationInfo(op, entryPoint, api.chainID, callABI)
+	if err != nil {
+		return nil, err
+	}
+	sighash, msg := accounts.TextAndHash(info.Hash[:])
+	req := &SignDataRequest{
+		ContentType:   accounts.MimetypeTextPlain,
+		Address:       signerAddress,
+		Rawdata:       []byte(msg),
+		Messages:      info.messages(),
+		Hash:          sighash,
+		Meta:          MetadataFromContext(ctx),
+		UserOperation: info,
+	}
+	signature, err := api.sign(req, true)
+	if err != nil {
+		api.UI.ShowError(err.Error())
+		return nil, err
+	}
+	op.Signature = signature
+	return &op, nil
+}
+
 // Version returns the external api version. This method does not require user acceptance. Available methods are
 // available via enumeration anyway, and this info does not contain user-specific data
 func (api *SignerAPI) Version(ctx context.Context) (string, error) {
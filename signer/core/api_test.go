@@ -42,6 +42,10 @@ import (
 type headlessUi struct {
 	approveCh chan string // to send approve/deny
 	inputCh   chan string // to send password
+
+	batch    *core.SignTxBatchRequest       // last batch request received
+	data     *core.SignDataRequest          // last data signing request received
+	approved []ethapi.SignTransactionResult // signed transactions notified to the UI
 }
 
 func (ui *headlessUi) OnInputRequired(info core.UserInputRequest) (core.UserInputResponse, error) {
@@ -49,9 +53,11 @@ func (ui *headlessUi) OnInputRequired(info core.UserInputRequest) (core.UserInpu
 	return core.UserInputResponse{Text: input}, nil
 }
 
-func (ui *headlessUi) OnSignerStartup(info core.StartupInfo)        {}
-func (ui *headlessUi) RegisterUIServer(api *core.UIServerAPI)       {}
-func (ui *headlessUi) OnApprovedTx(tx ethapi.SignTransactionResult) {}
+func (ui *headlessUi) OnSignerStartup(info core.StartupInfo)  {}
+func (ui *headlessUi) RegisterUIServer(api *core.UIServerAPI) {}
+func (ui *headlessUi) OnApprovedTx(tx ethapi.SignTransactionResult) {
+	ui.approved = append(ui.approved, tx)
+}
 
 func (ui *headlessUi) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, error) {
 	switch <-ui.approveCh {
@@ -68,7 +74,14 @@ func (ui *headlessUi) ApproveTx(request *core.SignTxRequest) (core.SignTxRespons
 	}
 }
 
+func (ui *headlessUi) ApproveTxBatch(request *core.SignTxBatchRequest) (core.SignTxBatchResponse, error) {
+	ui.batch = request
+	approved := (<-ui.approveCh == "Y")
+	return core.SignTxBatchResponse{Approved: approved}, nil
+}
+
 func (ui *headlessUi) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
+	ui.data = request
 	approved := (<-ui.approveCh == "Y")
 	return core.SignDataResponse{approved}, nil
 }
@@ -119,8 +132,8 @@ func setup(t *testing.T) (*core.SignerAPI, *headlessUi) {
 	if err != nil {
 		t.Fatal(err.Error())
 	}
-	ui := &headlessUi{make(chan string, 20), make(chan string, 20)}
-	am := core.StartClefAccountManager(tmpDirName(t), true, true, "")
+	ui := &headlessUi{approveCh: make(chan string, 20), inputCh: make(chan string, 20)}
+	am := core.StartClefAccountManager(tmpDirName(t), true, true, "", "")
 	api := core.NewSignerAPI(am, 1337, true, ui, db, true, &storage.NoStorage{})
 	return api, ui
 }
@@ -318,3 +331,92 @@ func TestSignTx(t *testing.T) {
 		t.Error("Expected tx to be modified by UI")
 	}
 }
+
+func TestSignTxBatch(t *testing.T) {
+	t.Parallel()
+
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := common.NewMixedcaseAddress(list[0])
+
+	txs := make([]apitypes.SendTxArgs, 3)
+	for i := range txs {
+		txs[i] = mkTestTx(a)
+		txs[i].Nonce = hexutil.Uint64(5 + i)
+	}
+	txs[2].To = nil
+
+	// A denied batch must not be signed
+	control.approveCh <- "N"
+	if res, err := api.SignTransactions(t.Context(), txs); res != nil || err != core.ErrRequestDenied {
+		t.Fatalf("Expected ErrRequestDenied, got %v, %v", res, err)
+	}
+	summary := control.batch.Summary
+	if summary.Count != 3 || summary.NonceMin != 5 || summary.NonceMax != 7 || summary.Creations != 1 {
+		t.Errorf("Unexpected summary %+v", summary)
+	}
+	if summary.TotalValue.ToInt().Cmp(big.NewInt(3e18)) != 0 {
+		t.Errorf("Unexpected total value %v", summary.TotalValue)
+	}
+	if len(summary.Senders) != 1 || summary.Senders[0] != a.Address() {
+		t.Errorf("Unexpected senders %v", summary.Senders)
+	}
+	if len(summary.Destinations) != 1 || summary.Destinations[0] != common.HexToAddress("0x1337") {
+		t.Errorf("Unexpected destinations %v", summary.Destinations)
+	}
+	// An approved batch must be signed completely with a single password prompt
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	res, err := api.SignTransactions(t.Context(), txs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != len(txs) {
+		t.Fatalf("Expected %d signed transactions, got %d", len(txs), len(res))
+	}
+	if len(control.approved) != len(txs) {
+		t.Errorf("Expected %d approved transactions, got %d", len(txs), len(control.approved))
+	}
+	for i, signed := range res {
+		if signed.Tx.Nonce() != uint64(txs[i].Nonce) {
+			t.Errorf("Transaction %d: expected nonce %d, got %d", i, txs[i].Nonce, signed.Tx.Nonce())
+		}
+	}
+	// Empty batches must be rejected without prompting
+	if _, err := api.SignTransactions(t.Context(), nil); err == nil {
+		t.Error("Expected error on empty batch")
+	}
+}
+
+func TestSignTxBatchPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	api, control := setup(t)
+	createAccount(control, api, t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := []apitypes.SendTxArgs{
+		mkTestTx(common.NewMixedcaseAddress(list[0])),
+		mkTestTx(common.NewMixedcaseAddress(list[1])),
+	}
+	// Signing the second transaction fails on a wrong password, which must not
+	// leave the UI believing that the first one was signed
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	control.inputCh <- "wrong_password"
+	if res, err := api.SignTransactions(t.Context(), txs); res != nil || err == nil {
+		t.Fatalf("Expected error, got %v, %v", res, err)
+	}
+	if len(control.approved) != 0 {
+		t.Errorf("Expected no approved transactions, got %d", len(control.approved))
+	}
+}
@@ -356,6 +356,7 @@ type TypedDataDomain struct {
 	Version           string                `json:"version"`
 	ChainId           *math.HexOrDecimal256 `json:"chainId"`
 	VerifyingContract string                `json:"verifyingContract"`
+	Salt              string                `json:"salt"`
 }
 
 // TypedDataAndHash is a helper function that calculates a hash for typed data conforming to EIP-712.
@@ -447,7 +448,13 @@ func (typedData *TypedData) TypeHash(primaryType string) hexutil.Bytes {
 //
 // each encoded member is 32-byte long
 func (typedData *TypedData) EncodeData(primaryType string, data map[string]interface{}, depth int) (hexutil.Bytes, error) {
-	if err := typedData.validate(); err != nil {
+	// The domain is only checked when it is the value being encoded, so that
+	// message structs can be hashed on their own.
+	validate := typedData.Types.validate
+	if primaryType == "EIP712Domain" {
+		validate = typedData.validate
+	}
+	if err := validate(); err != nil {
 		return nil, err
 	}
 
@@ -906,7 +913,7 @@ func isPrimitiveTypeValid(primitiveType string) bool {
 // validate checks if the given domain is valid, i.e. contains at least
 // the minimum viable keys and values
 func (domain *TypedDataDomain) validate() error {
-	if domain.ChainId == nil && len(domain.Name) == 0 && len(domain.Version) == 0 && len(domain.VerifyingContract) == 0 {
+	if domain.ChainId == nil && len(domain.Name) == 0 && len(domain.Version) == 0 && len(domain.VerifyingContract) == 0 && len(domain.Salt) == 0 {
 		return errors.New("domain is undefined")
 	}
 
@@ -933,6 +940,10 @@ func (domain *TypedDataDomain) Map() map[string]interface{} {
 		dataMap["verifyingContract"] = domain.VerifyingContract
 	}
 
+	if len(domain.Salt) > 0 {
+		dataMap["salt"] = domain.Salt
+	}
+
 	return dataMap
 }
 
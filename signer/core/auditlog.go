@@ -64,6 +64,23 @@ func (l *AuditLogger) SignTransaction(ctx context.Context, args apitypes.SendTxA
 	return res, e
 }
 
+func (l *AuditLogger) SignTransactions(ctx context.Context, args []apitypes.SendTxArgs) ([]*ethapi.SignTransactionResult, error) {
+	txs := make([]string, len(args))
+	for i := range args {
+		txs[i] = args[i].String()
+	}
+	l.log.Info("SignTransactions", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"txs", txs)
+
+	res, e := l.api.SignTransactions(ctx, args)
+	raws := make([]string, len(res))
+	for i := range res {
+		raws[i] = common.Bytes2Hex(res[i].Raw)
+	}
+	l.log.Info("SignTransactions", "type", "response", "data", raws, "error", e)
+	return res, e
+}
+
 func (l *AuditLogger) SignData(ctx context.Context, contentType string, addr common.MixedcaseAddress, data interface{}) (hexutil.Bytes, error) {
 	marshalledData, _ := json.Marshal(data) // can ignore error, marshalling what we just unmarshalled
 	l.log.Info("SignData", "type", "request", "metadata", MetadataFromContext(ctx).String(),
@@ -107,6 +124,19 @@ func (l *AuditLogger) EcRecover(ctx context.Context, data hexutil.Bytes, sig hex
 	return b, e
 }
 
+func (l *AuditLogger) SignUserOperation(ctx context.Context, addr common.MixedcaseAddress, op UserOperationArgs, entryPoint common.Address, callABI *string) (*UserOperationArgs, error) {
+	data, _ := json.Marshal(op) // can ignore error, marshalling what we just unmarshalled
+	l.log.Info("SignUserOperation", "type", "request", "metadata", MetadataFromContext(ctx).String(),
+		"addr", addr.String(), "data", string(data), "entryPoint", entryPoint)
+	res, e := l.api.SignUserOperation(ctx, addr, op, entryPoint, callABI)
+	if res != nil {
+		l.log.Info("SignUserOperation", "type", "response", "data", common.Bytes2Hex(res.Signature), "error", e)
+	} else {
+		l.log.Info("SignUserOperation", "type", "response", "data", res, "error", e)
+	}
+	return res, e
+}
+
 func (l *AuditLogger) Version(ctx context.Context) (string, error) {
 	l.log.Info("Version", "type", "request", "metadata", MetadataFromContext(ctx).String())
 	data, err := l.api.Version(ctx)
@@ -164,6 +164,45 @@ func (ui *CommandlineUI) ApproveTx(request *SignTxRequest) (SignTxResponse, erro
 	return SignTxResponse{request.Transaction, true}, nil
 }
 
+// ApproveTxBatch prompt the user for a single confirmation to sign a batch of
+// Transactions, showing the summary of the batch followed by the Transactions
+func (ui *CommandlineUI) ApproveTxBatch(request *SignTxBatchRequest) (SignTxBatchResponse, error) {
+	ui.mu.Lock()
+	defer ui.mu.Unlock()
+
+	summary := request.Summary
+	fmt.Printf("--------- Transaction batch request-------\n")
+	fmt.Printf("transactions:       %d\n", summary.Count)
+	fmt.Printf("total value:        %v wei\n", summary.TotalValue.ToInt())
+	fmt.Printf("nonces:             %d - %d\n", uint64(summary.NonceMin), uint64(summary.NonceMax))
+	fmt.Printf("senders:\n")
+	for _, from := range summary.Senders {
+		fmt.Printf("  %v\n", from)
+	}
+	fmt.Printf("destinations (%d):\n", len(summary.Destinations))
+	for _, to := range summary.Destinations {
+		fmt.Printf("  %v\n", to)
+	}
+	if summary.Creations > 0 {
+		fmt.Printf("contract creations: %d\n", summary.Creations)
+	}
+	fmt.Printf("\nTransactions:\n")
+	for i, req := range request.Transactions {
+		to := "<contract creation>"
+		if req.Transaction.To != nil {
+			to = req.Transaction.To.String()
+		}
+		fmt.Printf(" %d. nonce %d: %v -> %s, %v wei\n", i, uint64(req.Transaction.Nonce), req.Transaction.From.String(), to, req.Transaction.Value.ToInt())
+		for _, m := range req.Callinfo {
+			fmt.Printf("    * %s : %s\n", m.Typ, m.Message)
+		}
+	}
+	fmt.Printf("\n")
+	showMetadata(request.Meta)
+	fmt.Printf("-------------------------------------------\n")
+	return SignTxBatchResponse{Approved: ui.confirm()}, nil
+}
+
 // ApproveSignData prompt the user for confirmation to request to sign data
 func (ui *CommandlineUI) ApproveSignData(request *SignDataRequest) (SignDataResponse, error) {
 	ui.mu.Lock()
@@ -33,6 +33,12 @@ import (
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
+// typedDataSigner is implemented by wallets able to sign EIP-712 typed data by
+// its members, such as hardware wallets displaying them to the user.
+type typedDataSigner interface {
+	SignTypedData(account accounts.Account, typedData apitypes.TypedData) ([]byte, error)
+}
+
 // sign receives a request and produces a signature
 //
 // Note, the produced signature conforms to the secp256k1 curve R, S and V values,
@@ -59,8 +65,14 @@ func (api *SignerAPI) sign(req *SignDataRequest, legacyV bool) (hexutil.Bytes, e
 	if err != nil {
 		return nil, err
 	}
-	// Sign the data with the wallet
-	signature, err := wallet.SignDataWithPassphrase(account, pw, req.ContentType, req.Rawdata)
+	// Sign the data with the wallet, letting hardware wallets display typed data
+	// instead of its hash
+	var signature []byte
+	if signer, ok := wallet.(typedDataSigner); ok && req.typedData != nil {
+		signature, err = signer.SignTypedData(account, *req.typedData)
+	} else {
+		signature, err = wallet.SignDataWithPassphrase(account, pw, req.ContentType, req.Rawdata)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -239,6 +251,14 @@ func (api *SignerAPI) signTypedData(ctx context.Context, addr common.MixedcaseAd
 	}
 	req.Address = addr
 	req.Meta = MetadataFromContext(ctx)
+	if decoder, ok := api.validator.(CallDecoder); ok {
+		var contract *common.Address
+		if common.IsHexAddress(typedData.Domain.VerifyingContract) {
+			addr := common.HexToAddress(typedData.Domain.VerifyingContract)
+			contract = &addr
+		}
+		decodeTypedDataCalls(decoder, req.Messages, contract)
+	}
 	if validationMessages != nil {
 		req.Callinfo = validationMessages.Messages
 	}
@@ -286,7 +306,41 @@ func typedDataRequest(data any) (*SignDataRequest, error) {
 		ContentType: apitypes.DataTyped.Mime,
 		Rawdata:     []byte(rawData),
 		Messages:    messages,
-		Hash:        sighash}, nil
+		Hash:        sighash,
+		typedData:   &typedData}, nil
+}
+
+// decodeTypedDataCalls renders the call data embedded in formatted typed data,
+// such as the calls of multisig transactions. The data of a struct is assumed to
+// be sent to the address in its "to" field, if any, or the verifying contract.
+func decodeTypedDataCalls(decoder CallDecoder, fields []*apitypes.NameValueType, contract *common.Address) {
+	to := contract
+	for _, field := range fields {
+		if field.Name == "to" && field.Typ == "address" {
+			if value, ok := field.Value.(string); ok && common.IsHexAddress(value) {
+				addr := common.HexToAddress(value)
+				to = &addr
+			}
+		}
+	}
+	for _, field := range fields {
+		switch value := field.Value.(type) {
+		case []*apitypes.NameValueType:
+			decodeTypedDataCalls(decoder, value, contract)
+		case string:
+			if field.Typ != "bytes" {
+				continue
+			}
+			data, err := hexutil.Decode(value)
+			if err != nil {
+				continue
+			}
+			if call, err := decoder.DecodeCallData(to, data); err == nil {
+				field.Value = call
+				field.Typ = "call"
+			}
+		}
+	}
 }
 
 // EcRecover recovers the address associated with the given sig.
@@ -66,6 +66,12 @@ func (ui *StdIOUI) ApproveTx(request *SignTxRequest) (SignTxResponse, error) {
 	return result, err
 }
 
+func (ui *StdIOUI) ApproveTxBatch(request *SignTxBatchRequest) (SignTxBatchResponse, error) {
+	var result SignTxBatchResponse
+	err := ui.dispatch("ui_approveTxBatch", request, &result)
+	return result, err
+}
+
 func (ui *StdIOUI) ApproveSignData(request *SignDataRequest) (SignDataResponse, error) {
 	var result SignDataResponse
 	err := ui.dispatch("ui_approveSignData", request, &result)
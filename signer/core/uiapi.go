@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
@@ -209,6 +210,49 @@ func (api *UIServerAPI) New(ctx context.Context) (common.Address, error) {
 	return api.extApi.newAccount()
 }
 
+// DelayedRequest is a request approved after a delay, pending until the delay
+// elapses unless cancelled by the user.
+type DelayedRequest struct {
+	ID      uint64          `json:"id"`
+	Method  string          `json:"method"`
+	Request json.RawMessage `json:"request"`
+	Due     time.Time       `json:"due"`
+}
+
+// RequestScheduler is implemented by UIs which can approve requests after a
+// delay, such as the rule engine, giving the user time to cancel them.
+type RequestScheduler interface {
+	// DelayedRequests returns the requests pending until their delay elapses.
+	DelayedRequests() []*DelayedRequest
+
+	// CancelDelayedRequest rejects a pending request.
+	CancelDelayedRequest(id uint64) error
+}
+
+// ListDelayedRequests lists the requests approved after a delay, which are still
+// pending and can be cancelled.
+// Example call
+// {"jsonrpc":"2.0","method":"clef_listDelayedRequests","params":[], "id":5}
+func (api *UIServerAPI) ListDelayedRequests() []*DelayedRequest {
+	scheduler, ok := api.extApi.UI.(RequestScheduler)
+	if !ok {
+		return []*DelayedRequest{}
+	}
+	return scheduler.DelayedRequests()
+}
+
+// CancelDelayedRequest rejects a request approved after a delay, before the
+// delay elapses.
+// Example call
+// {"jsonrpc":"2.0","method":"clef_cancelDelayedRequest","params":[1], "id":5}
+func (api *UIServerAPI) CancelDelayedRequest(id uint64) error {
+	scheduler, ok := api.extApi.UI.(RequestScheduler)
+	if !ok {
+		return errors.New("delayed approvals not supported")
+	}
+	return scheduler.CancelDelayedRequest(id)
+}
+
 // Other methods to be added, not yet implemented are:
 // - Ruleset interaction: add rules, attest rulefiles
 // - Store metadata about accounts, e.g. naming of accounts
@@ -0,0 +1,212 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// knownEntryPoints are the ERC-4337 EntryPoint deployments clef signs user
+// operations for. Operations for other entry points are refused, as the version
+// determining the hash of the operation can't be known.
+var knownEntryPoints = map[common.Address]bind.EntryPoint{
+	bind.EntryPoint06.Address: bind.EntryPoint06,
+	bind.EntryPoint07.Address: bind.EntryPoint07,
+}
+
+// UserOperationArgs is an ERC-4337 user operation, in the unpacked format of the
+// v0.7 bundler API. It is used both on input and output, the latter carrying the
+// signature of the operation.
+type UserOperationArgs struct {
+	Sender                        common.MixedcaseAddress `json:"sender"`
+	Nonce                         hexutil.Big             `json:"nonce"`
+	Factory                       *common.Address         `json:"factory,omitempty"`
+	FactoryData                   hexutil.Bytes           `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes           `json:"callData"`
+	CallGasLimit                  hexutil.Uint64          `json:"callGasLimit"`
+	VerificationGasLimit          hexutil.Uint64          `json:"verificationGasLimit"`
+	PreVerificationGas            hexutil.Uint64          `json:"preVerificationGas"`
+	MaxFeePerGas                  hexutil.Big             `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          hexutil.Big             `json:"maxPriorityFeePerGas"`
+	Paymaster                     *common.Address         `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit hexutil.Uint64          `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       hexutil.Uint64          `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes           `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes           `json:"signature"`
+}
+
+// ToUserOperation converts the arguments into a user operation.
+func (args *UserOperationArgs) ToUserOperation() *bind.UserOperation {
+	return &bind.UserOperation{
+		Sender:                        args.Sender.Address(),
+		Nonce:                         args.Nonce.ToInt(),
+		Factory:                       args.Factory,
+		FactoryData:                   args.FactoryData,
+		CallData:                      args.CallData,
+		CallGasLimit:                  uint64(args.CallGasLimit),
+		VerificationGasLimit:          uint64(args.VerificationGasLimit),
+		PreVerificationGas:            uint64(args.PreVerificationGas),
+		MaxFeePerGas:                  args.MaxFeePerGas.ToInt(),
+		MaxPriorityFeePerGas:          args.MaxPriorityFeePerGas.ToInt(),
+		Paymaster:                     args.Paymaster,
+		PaymasterVerificationGasLimit: uint64(args.PaymasterVerificationGasLimit),
+		PaymasterPostOpGasLimit:       uint64(args.PaymasterPostOpGasLimit),
+		PaymasterData:                 args.PaymasterData,
+		Signature:                     args.Signature,
+	}
+}
+
+// UserOperationInfo describes a user operation pending to be signed, passed to
+// the UI and rules along with the data signing request.
+type UserOperationInfo struct {
+	EntryPoint common.Address     `json:"entry_point"`
+	ChainID    *hexutil.Big       `json:"chain_id"`
+	Hash       common.Hash        `json:"hash"`
+	Operation  UserOperationArgs  `json:"operation"`
+	Call       *UserOperationCall `json:"call,omitempty"`
+}
+
+// UserOperationCall is the call data of a user operation, decoded with the ABI
+// of the account supplied along with the request.
+type UserOperationCall struct {
+	Method string                    `json:"method"`
+	Args   []*apitypes.NameValueType `json:"args"`
+}
+
+// decodeUserOperationCall decodes the call data of an operation with the given
+// JSON ABI of the account.
+func decodeUserOperationCall(callABI string, data []byte) (*UserOperationCall, error) {
+	parsed, err := abi.JSON(strings.NewReader(callABI))
+	if err != nil {
+		return nil, fmt.Errorf("invalid call ABI: %w", err)
+	}
+	if len(data) < 4 {
+		return nil, errors.New("call data too short")
+	}
+	method, err := parsed.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid call data: %w", err)
+	}
+	call := &UserOperationCall{Method: method.Sig}
+	for i, input := range method.Inputs {
+		call.Args = append(call.Args, &apitypes.NameValueType{
+			Name:  input.Name,
+			Typ:   input.Type.String(),
+			Value: formatCallValue(values[i]),
+		})
+	}
+	return call, nil
+}
+
+// formatCallValue formats a decoded ABI value for display, retaining the full
+// precision of numbers.
+func formatCallValue(v interface{}) string {
+	switch v := v.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// newUserOperationInfo validates a user operation and describes it for review.
+func newUserOperationInfo(op UserOperationArgs, entryPoint common.Address, chainID *big.Int, callABI *string) (*UserOperationInfo, error) {
+	ep, ok := knownEntryPoints[entryPoint]
+	if !ok {
+		return nil, fmt.Errorf("unknown entry point %v", entryPoint)
+	}
+	info := &UserOperationInfo{
+		EntryPoint: entryPoint,
+		ChainID:    (*hexutil.Big)(chainID),
+		Hash:       op.ToUserOperation().Hash(ep, chainID),
+		Operation:  op,
+	}
+	if callABI != nil {
+		call, err := decodeUserOperationCall(*callABI, op.CallData)
+		if err != nil {
+			return nil, err
+		}
+		info.Call = call
+	}
+	return info, nil
+}
+
+// messages describes the user operation for display.
+func (info *UserOperationInfo) messages() []*apitypes.NameValueType {
+	var (
+		op      = info.Operation
+		version = "v0.6"
+	)
+	if knownEntryPoints[info.EntryPoint].Version == bind.EntryPointV07 {
+		version = "v0.7"
+	}
+	messages := []*apitypes.NameValueType{
+		{Name: "This is a request to sign an ERC-4337 user operation", Typ: "description", Value: ""},
+		{Name: "Entry point", Typ: "address", Value: fmt.Sprintf("%v (%s)", info.EntryPoint, version)},
+		{Name: "Chain ID", Typ: "uint256", Value: info.ChainID.ToInt().String()},
+		{Name: "Sender", Typ: "address", Value: op.Sender.String()},
+		{Name: "Nonce", Typ: "uint256", Value: op.Nonce.ToInt().String()},
+	}
+	if op.Factory != nil {
+		messages = append(messages, &apitypes.NameValueType{
+			Name: "Account deployed by factory", Typ: "address", Value: op.Factory.Hex(),
+		})
+	}
+	if info.Call != nil {
+		args := make([]string, len(info.Call.Args))
+		for i, arg := range info.Call.Args {
+			args[i] = fmt.Sprintf("%s: %v", arg.Name, arg.Value)
+		}
+		messages = append(messages, &apitypes.NameValueType{
+			Name: "Call", Typ: "call", Value: fmt.Sprintf("%s(%s)", info.Call.Method, strings.Join(args, ", ")),
+		})
+	} else {
+		messages = append(messages, &apitypes.NameValueType{
+			Name: "Call data", Typ: "hexdata", Value: op.CallData.String(),
+		})
+	}
+	paymaster := "none, paid by the sender"
+	if op.Paymaster != nil {
+		paymaster = op.Paymaster.Hex()
+	}
+	messages = append(messages,
+		&apitypes.NameValueType{Name: "Paymaster", Typ: "address", Value: paymaster},
+		&apitypes.NameValueType{Name: "Max fee per gas", Typ: "uint256", Value: op.MaxFeePerGas.ToInt().String()},
+		&apitypes.NameValueType{Name: "Max priority fee per gas", Typ: "uint256", Value: op.MaxPriorityFeePerGas.ToInt().String()},
+		&apitypes.NameValueType{Name: "Gas limits (call, verification, pre-verification)", Typ: "uint64",
+			Value: fmt.Sprintf("%d, %d, %d", op.CallGasLimit, op.VerificationGasLimit, op.PreVerificationGas)},
+		&apitypes.NameValueType{Name: "User operation hash", Typ: "bytes32", Value: info.Hash.Hex()},
+	)
+	return messages
+}
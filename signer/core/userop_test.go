@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+const testAccountABI = `[{"type":"function","name":"execute","inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"outputs":[]}]`
+
+func TestSignUserOperation(t *testing.T) {
+	t.Parallel()
+
+	api, control := setup(t)
+	createAccount(control, api, t)
+	control.approveCh <- "A"
+	list, err := api.List(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	owner := common.NewMixedcaseAddress(list[0])
+
+	// execute(0x1337, 1 ether, 0xdeadbeef)
+	calldata := common.FromHex("0xb61d27f6" +
+		"0000000000000000000000000000000000000000000000000000000000001337" +
+		"0000000000000000000000000000000000000000000000000de0b6b3a7640000" +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"0000000000000000000000000000000000000000000000000000000000000004" +
+		"deadbeef00000000000000000000000000000000000000000000000000000000")
+	paymaster := common.HexToAddress("0xba")
+	op := core.UserOperationArgs{
+		Sender:               common.NewMixedcaseAddress(common.HexToAddress("0xaa")),
+		Nonce:                hexutil.Big(*big.NewInt(1)),
+		CallData:             calldata,
+		CallGasLimit:         100000,
+		VerificationGasLimit: 200000,
+		PreVerificationGas:   50000,
+		MaxFeePerGas:         hexutil.Big(*big.NewInt(3e9)),
+		MaxPriorityFeePerGas: hexutil.Big(*big.NewInt(1e9)),
+		Paymaster:            &paymaster,
+	}
+	// Operations for unknown entry points must be refused without prompting
+	if _, err := api.SignUserOperation(t.Context(), owner, op, common.HexToAddress("0x01"), nil); err == nil {
+		t.Fatal("Expected error on unknown entry point")
+	}
+	abiJSON := testAccountABI
+	control.approveCh <- "Y"
+	control.inputCh <- "a_long_password"
+	signed, err := api.SignUserOperation(t.Context(), owner, op, bind.EntryPoint07.Address, &abiJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The operation must be presented decoded, for the configured chain
+	info := control.data.UserOperation
+	if info == nil {
+		t.Fatal("Expected user operation in signing request")
+	}
+	hash := op.ToUserOperation().Hash(bind.EntryPoint07, big.NewInt(1337))
+	if info.Hash != hash {
+		t.Errorf("Unexpected hash %v, want %v", info.Hash, hash)
+	}
+	if info.Call == nil || info.Call.Method != "execute(address,uint256,bytes)" {
+		t.Fatalf("Unexpected call %+v", info.Call)
+	}
+	if v := info.Call.Args[1].Value; v != "1000000000000000000" {
+		t.Errorf("Unexpected value argument %v", v)
+	}
+	if v := info.Call.Args[2].Value; v != "0xdeadbeef" {
+		t.Errorf("Unexpected func argument %v", v)
+	}
+	// The signature must be made by the owner over the operation hash
+	sig := common.CopyBytes(signed.Signature)
+	if len(sig) != crypto.SignatureLength || sig[crypto.RecoveryIDOffset] < 27 {
+		t.Fatalf("Invalid signature %x", sig)
+	}
+	sig[crypto.RecoveryIDOffset] -= 27
+	pubkey, err := crypto.SigToPub(accounts.TextHash(hash[:]), sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubkey); signer != owner.Address() {
+		t.Errorf("Unexpected signer %v, want %v", signer, owner.Address())
+	}
+	// Call data not matching the supplied ABI must be refused
+	badABI := `[{"type":"function","name":"executeBatch","inputs":[{"name":"dest","type":"address[]"}],"outputs":[]}]`
+	if _, err := api.SignUserOperation(t.Context(), owner, op, bind.EntryPoint07.Address, &badABI); err == nil {
+		t.Error("Expected error on undecodable call data")
+	}
+}
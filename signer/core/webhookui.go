@@ -0,0 +1,286 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// WebhookSignatureHeader is the header carrying the HMAC-SHA256 of the body
+	// of webhook requests and approval callbacks, keyed with the shared secret.
+	WebhookSignatureHeader = "X-Clef-Signature"
+
+	// DefaultWebhookTimeout is the time allowed for approving a request before
+	// it is rejected.
+	DefaultWebhookTimeout = 5 * time.Minute
+
+	// maxWebhookCallbackSize is the largest approval callback body accepted.
+	maxWebhookCallbackSize = 64 * 1024
+)
+
+// WebhookConfig configures the webhook approval channel.
+type WebhookConfig struct {
+	URL         string        // Endpoint the requests are POSTed to
+	CallbackURL string        // Endpoint the approvals are POSTed back to, advertised to the webhook
+	Secret      []byte        // Shared secret authenticating both directions
+	Timeout     time.Duration // Time allowed for approving requests, DefaultWebhookTimeout if zero
+	Client      *http.Client  // Client used to call the webhook, http.DefaultClient if nil
+}
+
+// webhookRequest is the payload POSTed to the webhook. Requests awaiting approval
+// carry an ID and the callback to POST the decision to, notifications don't.
+type webhookRequest struct {
+	ID       string      `json:"id,omitempty"`
+	Method   string      `json:"method"`
+	Params   interface{} `json:"params"`
+	Callback string      `json:"callback,omitempty"`
+}
+
+// webhookDecision is the payload of approval callbacks.
+type webhookDecision struct {
+	ID       string `json:"id"`
+	Approved bool   `json:"approved"`
+}
+
+// WebhookUI is a UI which POSTs signing requests to a webhook, e.g. a chat bot
+// or a custom approval service, and waits for them to be approved or rejected
+// through an authenticated callback. The UI serves the callbacks over HTTP.
+//
+// Requests which can't be answered over the webhook, such as password input,
+// are forwarded to the next UI.
+type WebhookUI struct {
+	next   UIClientAPI
+	config WebhookConfig
+
+	pending map[string]chan bool // Decision channels of the requests awaiting approval
+	lock    sync.Mutex
+}
+
+// NewWebhookUI creates a webhook approval channel, forwarding requests which
+// require user input to the next UI.
+func NewWebhookUI(config WebhookConfig, next UIClientAPI) *WebhookUI {
+	if config.Timeout == 0 {
+		config.Timeout = DefaultWebhookTimeout
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	return &WebhookUI{next: next, config: config, pending: make(map[string]chan bool)}
+}
+
+// sign computes the authentication code of a payload.
+func (ui *WebhookUI) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, ui.config.Secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// post sends a signed payload to the webhook.
+func (ui *WebhookUI) post(ctx context.Context, req *webhookRequest) error {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, ui.config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set(WebhookSignatureHeader, hex.EncodeToString(ui.sign(payload)))
+
+	res, err := ui.config.Client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", res.Status)
+	}
+	return nil
+}
+
+// approve posts a request to the webhook and waits for its decision, returning
+// false if it is rejected or not decided in time.
+func (ui *WebhookUI) approve(method string, params interface{}) (bool, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return false, err
+	}
+	req := &webhookRequest{ID: hex.EncodeToString(id[:]), Method: method, Params: params, Callback: ui.config.CallbackURL}
+
+	decision := make(chan bool, 1)
+	ui.lock.Lock()
+	ui.pending[req.ID] = decision
+	ui.lock.Unlock()
+
+	defer func() {
+		ui.lock.Lock()
+		delete(ui.pending, req.ID)
+		ui.lock.Unlock()
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), ui.config.Timeout)
+	defer cancel()
+
+	if err := ui.post(ctx, req); err != nil {
+		return false, err
+	}
+	select {
+	case approved := <-decision:
+		return approved, nil
+	case <-ctx.Done():
+		log.Warn("Webhook approval timed out", "method", method, "id", req.ID)
+		return false, nil
+	}
+}
+
+// notify posts a notification to the webhook, without waiting for any decision.
+func (ui *WebhookUI) notify(method string, params interface{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), ui.config.Timeout)
+	defer cancel()
+
+	if err := ui.post(ctx, &webhookRequest{Method: method, Params: params}); err != nil {
+		log.Info("Error notifying webhook", "method", method, "err", err)
+	}
+}
+
+// ServeHTTP implements http.Handler, accepting approval callbacks. Callbacks are
+// only accepted if authenticated with the shared secret, and each request can
+// only be decided once.
+func (ui *WebhookUI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookCallbackSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	signature, err := hex.DecodeString(r.Header.Get(WebhookSignatureHeader))
+	if err != nil || !hmac.Equal(signature, ui.sign(payload)) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	var decision webhookDecision
+	if err := json.Unmarshal(payload, &decision); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	ui.lock.Lock()
+	pending, ok := ui.pending[decision.ID]
+	delete(ui.pending, decision.ID)
+	ui.lock.Unlock()
+
+	if !ok {
+		http.Error(w, "unknown or decided request", http.StatusNotFound)
+		return
+	}
+	pending <- decision.Approved
+	w.WriteHeader(http.StatusOK)
+}
+
+// RegisterUIServer implements UIClientAPI, passing the server to the next UI.
+func (ui *WebhookUI) RegisterUIServer(api *UIServerAPI) {
+	ui.next.RegisterUIServer(api)
+}
+
+// ApproveTx implements UIClientAPI, asking the webhook to approve the transaction.
+func (ui *WebhookUI) ApproveTx(request *SignTxRequest) (SignTxResponse, error) {
+	approved, err := ui.approve("ui_approveTx", request)
+	if err != nil || !approved {
+		return SignTxResponse{Approved: false}, err
+	}
+	return SignTxResponse{Transaction: request.Transaction, Approved: true}, nil
+}
+
+// ApproveTxBatch implements UIClientAPI, asking the webhook to approve the batch
+// of transactions.
+func (ui *WebhookUI) ApproveTxBatch(request *SignTxBatchRequest) (SignTxBatchResponse, error) {
+	approved, err := ui.approve("ui_approveTxBatch", request)
+	return SignTxBatchResponse{Approved: approved && err == nil}, err
+}
+
+// ApproveSignData implements UIClientAPI, asking the webhook to approve the data.
+func (ui *WebhookUI) ApproveSignData(request *SignDataRequest) (SignDataResponse, error) {
+	approved, err := ui.approve("ui_approveSignData", request)
+	return SignDataResponse{Approved: approved && err == nil}, err
+}
+
+// ApproveListing implements UIClientAPI, asking the webhook to approve revealing
+// all the accounts.
+func (ui *WebhookUI) ApproveListing(request *ListRequest) (ListResponse, error) {
+	approved, err := ui.approve("ui_approveListing", request)
+	if err != nil || !approved {
+		return ListResponse{}, err
+	}
+	return ListResponse{Accounts: request.Accounts}, nil
+}
+
+// ApproveNewAccount implements UIClientAPI, forwarding the request to the next
+// UI since a password needs to be set.
+func (ui *WebhookUI) ApproveNewAccount(request *NewAccountRequest) (NewAccountResponse, error) {
+	return ui.next.ApproveNewAccount(request)
+}
+
+// ShowError implements UIClientAPI, notifying both the webhook and the next UI.
+func (ui *WebhookUI) ShowError(message string) {
+	ui.next.ShowError(message)
+	ui.notify("ui_showError", &Message{message})
+}
+
+// ShowInfo implements UIClientAPI, notifying both the webhook and the next UI.
+func (ui *WebhookUI) ShowInfo(message string) {
+	ui.next.ShowInfo(message)
+	ui.notify("ui_showInfo", &Message{message})
+}
+
+// OnApprovedTx implements UIClientAPI, notifying the webhook of the signed
+// transaction.
+func (ui *WebhookUI) OnApprovedTx(tx ethapi.SignTransactionResult) {
+	ui.next.OnApprovedTx(tx)
+	ui.notify("ui_onApprovedTx", tx)
+}
+
+// OnSignerStartup implements UIClientAPI, notifying both the webhook and the
+// next UI.
+func (ui *WebhookUI) OnSignerStartup(info StartupInfo) {
+	ui.next.OnSignerStartup(info)
+	ui.notify("ui_onSignerStartup", info)
+}
+
+// OnInputRequired implements UIClientAPI, forwarding the request to the next UI
+// since secrets must not be sent over the webhook.
+func (ui *WebhookUI) OnInputRequired(info UserInputRequest) (UserInputResponse, error) {
+	return ui.next.OnInputRequired(info)
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestWebhookUI(t *testing.T) {
+	t.Parallel()
+
+	var (
+		secret   = []byte("secret")
+		decide   = make(chan bool, 1) // Decision the webhook takes on the next request
+		callback = func(url string, body []byte, key []byte) int {
+			mac := hmac.New(sha256.New, key)
+			mac.Write(body)
+			req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+			req.Header.Set(WebhookSignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+			res, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("callback failed: %v", err)
+				return 0
+			}
+			res.Body.Close()
+			return res.StatusCode
+		}
+	)
+	// Create a webhook answering the requests asynchronously, with the decision
+	// of the test, after verifying they are authentic
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(body)
+		if r.Header.Get(WebhookSignatureHeader) != hex.EncodeToString(mac.Sum(nil)) {
+			t.Errorf("webhook request not authenticated")
+		}
+		var req webhookRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("invalid webhook request: %v", err)
+		}
+		if req.Method != "ui_approveTx" {
+			t.Errorf("method mismatch: have %s, want ui_approveTx", req.Method)
+		}
+		select {
+		case approved := <-decide:
+			go func() {
+				decision, _ := json.Marshal(webhookDecision{ID: req.ID, Approved: approved})
+				if status := callback(req.Callback, []byte("{}"), secret); status != http.StatusNotFound {
+					t.Errorf("unknown request status mismatch: have %d, want %d", status, http.StatusNotFound)
+				}
+				if status := callback(req.Callback, decision, []byte("forged")); status != http.StatusUnauthorized {
+					t.Errorf("forged callback status mismatch: have %d, want %d", status, http.StatusUnauthorized)
+				}
+				if status := callback(req.Callback, decision, secret); status != http.StatusOK {
+					t.Errorf("callback status mismatch: have %d, want %d", status, http.StatusOK)
+				}
+				if status := callback(req.Callback, decision, secret); status != http.StatusNotFound {
+					t.Errorf("replayed callback status mismatch: have %d, want %d", status, http.StatusNotFound)
+				}
+			}()
+		default:
+			// Leave the request undecided
+		}
+	}))
+	defer webhook.Close()
+
+	ui := NewWebhookUI(WebhookConfig{URL: webhook.URL, Secret: secret, Timeout: 500 * time.Millisecond}, nil)
+	server := httptest.NewServer(ui)
+	defer server.Close()
+	ui.config.CallbackURL = server.URL
+
+	to := common.NewMixedcaseAddress(common.Address{0x01})
+	request := &SignTxRequest{Transaction: apitypes.SendTxArgs{To: &to}}
+
+	// Approved and rejected requests must be reflected in the responses
+	for _, approved := range []bool{true, false} {
+		decide <- approved
+		res, err := ui.ApproveTx(request)
+		if err != nil {
+			t.Fatalf("failed to approve: %v", err)
+		}
+		if res.Approved != approved {
+			t.Errorf("approval mismatch: have %v, want %v", res.Approved, approved)
+		}
+	}
+	// Undecided requests must be rejected after the timeout
+	res, err := ui.ApproveTx(request)
+	if err != nil {
+		t.Fatalf("failed to approve: %v", err)
+	}
+	if res.Approved {
+		t.Error("undecided request approved")
+	}
+}
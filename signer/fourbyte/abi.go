@@ -39,6 +39,7 @@ type decodedCallData struct {
 type decodedArgument struct {
 	soltype abi.Argument
 	value   interface{}
+	named   bool // Whether the name of the argument is known from a full ABI
 }
 
 // String implements stringer interface, tries to use the underlying value-type
@@ -50,6 +51,9 @@ func (arg decodedArgument) String() string {
 	default:
 		value = fmt.Sprintf("%v", val)
 	}
+	if arg.named && arg.soltype.Name != "" {
+		return fmt.Sprintf("%v %v: %v", arg.soltype.Type.String(), arg.soltype.Name, value)
+	}
 	return fmt.Sprintf("%v: %v", arg.soltype.Type.String(), value)
 }
 
@@ -88,6 +92,17 @@ func parseSelector(unescapedSelector string) ([]byte, error) {
 // parseCallData matches the provided call data against the ABI definition and
 // returns a struct containing the actual go-typed values.
 func parseCallData(calldata []byte, unescapedAbidata string) (*decodedCallData, error) {
+	abispec, err := abi.JSON(strings.NewReader(unescapedAbidata))
+	if err != nil {
+		return nil, fmt.Errorf("invalid method signature (%q): %v", unescapedAbidata, err)
+	}
+	return decodeCallData(calldata, abispec, false)
+}
+
+// decodeCallData matches the provided call data against a parsed ABI and returns
+// a struct containing the actual go-typed values. Arguments are named only if
+// the ABI is a full contract ABI, rather than one derived from a selector.
+func decodeCallData(calldata []byte, abispec abi.ABI, named bool) (*decodedCallData, error) {
 	// Validate the call data that it has the 4byte prefix and the rest divisible by 32 bytes
 	if len(calldata) < 4 {
 		return nil, fmt.Errorf("invalid call data, incomplete method signature (%d bytes < 4)", len(calldata))
@@ -99,10 +114,6 @@ func parseCallData(calldata []byte, unescapedAbidata string) (*decodedCallData,
 		return nil, fmt.Errorf("invalid call data; length should be a multiple of 32 bytes (was %d)", len(argdata))
 	}
 	// Validate the called method and unpack the call data accordingly
-	abispec, err := abi.JSON(strings.NewReader(unescapedAbidata))
-	if err != nil {
-		return nil, fmt.Errorf("invalid method signature (%q): %v", unescapedAbidata, err)
-	}
 	method, err := abispec.MethodById(sigdata)
 	if err != nil {
 		return nil, err
@@ -117,6 +128,7 @@ func parseCallData(calldata []byte, unescapedAbidata string) (*decodedCallData,
 		decoded.inputs = append(decoded.inputs, decodedArgument{
 			soltype: method.Inputs[i],
 			value:   values[i],
+			named:   named,
 		})
 	}
 	// We're finished decoding the data. At this point, we encode the decoded data
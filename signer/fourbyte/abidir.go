@@ -0,0 +1,113 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fourbyte
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// errNoABI is returned if no supplied ABI knows the method being called.
+var errNoABI = errors.New("no ABI for method")
+
+// LoadABIDir loads the contract ABIs of a directory, used to decode call data
+// with named methods and arguments. Files named after a contract address, such
+// as <address>.json, contain the ABI of that contract. Any other JSON files are
+// consulted for calls to contracts without an ABI of their own. Files may hold
+// either a plain ABI, or a compiler artifact with the ABI in its "abi" field.
+func (db *Database) LoadABIDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		parsed, err := loadABI(file)
+		if err != nil {
+			return fmt.Errorf("%s: %v", file, err)
+		}
+		name := strings.TrimSuffix(filepath.Base(file), ".json")
+		if common.IsHexAddress(name) {
+			db.contracts[common.HexToAddress(name)] = parsed
+		} else {
+			db.abis = append(db.abis, parsed)
+		}
+	}
+	return nil
+}
+
+// ABIs returns the number of contract specific and generic ABIs loaded.
+func (db *Database) ABIs() (int, int) {
+	return len(db.contracts), len(db.abis)
+}
+
+// loadABI parses an ABI file, accepting compiler artifacts too.
+func loadABI(file string) (abi.ABI, error) {
+	blob, err := os.ReadFile(file)
+	if err != nil {
+		return abi.ABI{}, err
+	}
+	if blob = bytes.TrimSpace(blob); len(blob) > 0 && blob[0] == '{' {
+		var artifact struct {
+			ABI json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(blob, &artifact); err != nil {
+			return abi.ABI{}, err
+		}
+		if len(artifact.ABI) == 0 {
+			return abi.ABI{}, errors.New("no ABI in artifact")
+		}
+		blob = artifact.ABI
+	}
+	return abi.JSON(bytes.NewReader(blob))
+}
+
+// DecodeCallData decodes call data with the supplied ABIs, preferring the one of
+// the called contract. The call is rendered with the names of the method and its
+// arguments.
+func (db *Database) DecodeCallData(to *common.Address, data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", errNoABI
+	}
+	if to != nil {
+		if parsed, ok := db.contracts[*to]; ok {
+			decoded, err := decodeCallData(data, parsed, true)
+			if err != nil {
+				return "", err
+			}
+			return decoded.String(), nil
+		}
+	}
+	for _, parsed := range db.abis {
+		if _, err := parsed.MethodById(data[:4]); err != nil {
+			continue
+		}
+		decoded, err := decodeCallData(data, parsed, true)
+		if err != nil {
+			return "", err
+		}
+		return decoded.String(), nil
+	}
+	return "", errNoABI
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package fourbyte
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+func TestABIDir(t *testing.T) {
+	var (
+		dir   = t.TempDir()
+		token = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		other = common.HexToAddress("0x0000000000000000000000000000000000001337")
+	)
+	tokenABI := `[{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}]`
+	artifact := `{"contractName":"Vault","abi":[{"type":"function","name":"deposit","inputs":[{"name":"assets","type":"uint256"}],"outputs":[]}]}`
+	if err := os.WriteFile(filepath.Join(dir, token.Hex()+".json"), []byte(tokenABI), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "vault.json"), []byte(artifact), 0600); err != nil {
+		t.Fatal(err)
+	}
+	db := newEmpty()
+	if err := db.LoadABIDir(dir); err != nil {
+		t.Fatalf("failed to load ABI directory: %v", err)
+	}
+	if contracts, generic := db.ABIs(); contracts != 1 || generic != 1 {
+		t.Fatalf("loaded ABIs mismatch: have %d/%d, want 1/1", contracts, generic)
+	}
+	transfer := common.FromHex("0xa9059cbb" +
+		"0000000000000000000000000000000000000000000000000000000000001337" +
+		"0000000000000000000000000000000000000000000000000000000000000005")
+	deposit := common.FromHex("0xb6b55f25" +
+		"0000000000000000000000000000000000000000000000000000000000000007")
+
+	tests := []struct {
+		to   common.Address
+		data []byte
+		info string
+		warn bool
+	}{
+		// Calls to contracts with their own ABI are decoded with it
+		{to: token, data: transfer, info: `Transaction invokes the following method: "transfer(address to: 0x0000000000000000000000000000000000001337,uint256 amount: 5)"`},
+		// Calls to other contracts are decoded with the generic ABIs
+		{to: other, data: deposit, info: `Transaction invokes the following method: "deposit(uint256 assets: 7)"`},
+		// Calls not matching the ABI of the contract are warned about
+		{to: token, data: deposit, warn: true},
+		// Calls unknown to all ABIs fall back to the 4byte database
+		{to: other, data: transfer, warn: true},
+	}
+	for i, tt := range tests {
+		to := common.NewMixedcaseAddress(tt.to)
+		data := hexutil.Bytes(tt.data)
+		gasPrice := hexutil.Big(*common.Big1)
+		msgs, err := db.ValidateTransaction(nil, &apitypes.SendTxArgs{To: &to, Data: &data, Gas: 21000, GasPrice: &gasPrice})
+		if err != nil {
+			t.Fatalf("test %d: validation failed: %v", i, err)
+		}
+		if tt.warn {
+			if msgs.GetWarnings() == nil {
+				t.Errorf("test %d: expected warning, have %v", i, msgs.Messages)
+			}
+			continue
+		}
+		if len(msgs.Messages) != 1 || msgs.Messages[0].Message != tt.info {
+			t.Errorf("test %d: messages mismatch: have %v, want %q", i, msgs.Messages, tt.info)
+		}
+	}
+}
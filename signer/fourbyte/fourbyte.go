@@ -23,6 +23,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 //go:embed 4byte.json
@@ -34,13 +37,17 @@ type Database struct {
 	embedded   map[string]string
 	custom     map[string]string
 	customPath string
+
+	contracts map[common.Address]abi.ABI // ABIs of particular contracts, loaded from an ABI directory
+	abis      []abi.ABI                  // ABIs of any contracts, loaded from an ABI directory
 }
 
 // newEmpty exists for testing purposes.
 func newEmpty() *Database {
 	return &Database{
-		embedded: make(map[string]string),
-		custom:   make(map[string]string),
+		embedded:  make(map[string]string),
+		custom:    make(map[string]string),
+		contracts: make(map[common.Address]abi.ABI),
 	}
 }
 
@@ -73,8 +80,12 @@ func NewFromFile(path string) (*Database, error) {
 // file) as well as a custom database. The latter will be used to write new
 // values into if they are submitted via the API.
 func NewWithFile(path string) (*Database, error) {
-	db := &Database{make(map[string]string), make(map[string]string), path}
-	db.customPath = path
+	db := &Database{
+		embedded:   make(map[string]string),
+		custom:     make(map[string]string),
+		customPath: path,
+		contracts:  make(map[common.Address]abi.ABI),
+	}
 
 	if err := json.Unmarshal(embeddedJSON, &db.embedded); err != nil {
 		return nil, err
@@ -87,7 +87,20 @@ func (db *Database) ValidateTransaction(selector *string, tx *apitypes.SendTxArg
 	case tx.GasPrice != nil && tx.MaxPriorityFeePerGas != nil:
 		messages.Crit("Both 'gasPrice' and 'maxPriorityFeePerGas' specified.")
 	}
-	// Semantic fields validated, try to make heads or tails of the call data
+	// Semantic fields validated, try to make heads or tails of the call data,
+	// preferring the supplied ABIs over the 4byte database
+	if selector == nil && len(data) > 0 {
+		to := tx.To.Address()
+		call, err := db.DecodeCallData(&to, data)
+		switch {
+		case err == nil:
+			messages.Info(fmt.Sprintf("Transaction invokes the following method: %q", call))
+			return messages, nil
+		case !errors.Is(err, errNoABI):
+			messages.Warn(fmt.Sprintf("Transaction contains data, but it does not match the supplied ABI: %v", err))
+			return messages, nil
+		}
+	}
 	db.ValidateCallData(selector, data, messages)
 	return messages, nil
 }
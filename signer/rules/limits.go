@@ -0,0 +1,120 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+// limitRetention is the longest period spending can be counted over. Older
+// records are pruned from the storage.
+const limitRetention = 366 * 24 * time.Hour
+
+// limitPrefix is prepended to the names of the counters to form storage keys.
+const limitPrefix = "limits/"
+
+// limitRecord is an amount spent at some time, as stored for the counters.
+type limitRecord struct {
+	Time   int64  `json:"time"`   // Unix time of the spending
+	Amount string `json:"amount"` // Decimal amount spent
+}
+
+// loadLimit retrieves the records of a counter which are within the retention
+// period.
+func (r *rulesetUI) loadLimit(name string, now time.Time) []limitRecord {
+	stored, err := r.storage.Get(limitPrefix + name)
+	if err != nil || stored == "" {
+		return nil
+	}
+	var records []limitRecord
+	if err := json.Unmarshal([]byte(stored), &records); err != nil {
+		return nil
+	}
+	cutoff := now.Add(-limitRetention).Unix()
+	for len(records) > 0 && records[0].Time < cutoff {
+		records = records[1:]
+	}
+	return records
+}
+
+// limitsObject creates the JS object exposing the spending counters, which are
+// kept in the storage of the rules across invocations:
+//
+//	limits.record(name, amount)   records the amount as spent now under the counter
+//	limits.spent(name, seconds)   returns the decimal sum spent in the last seconds
+//	limits.count(name, seconds)   returns the number of spendings in the last seconds
+//
+// Amounts are accepted as decimal or hex strings and numbers, as found in the
+// transactions passed to the rules.
+func (r *rulesetUI) limitsObject(vm *goja.Runtime) *goja.Object {
+	// window collects the records of the counter within the requested period
+	window := func(call goja.FunctionCall) []limitRecord {
+		var (
+			name    = call.Argument(0).String()
+			seconds = call.Argument(1).ToInteger()
+			now     = r.now()
+		)
+		if seconds <= 0 {
+			panic(vm.NewTypeError("invalid limit period %d", seconds))
+		}
+		start := now.Add(-time.Duration(seconds) * time.Second).Unix()
+		var records []limitRecord
+		for _, record := range r.loadLimit(name, now) {
+			if record.Time > start {
+				records = append(records, record)
+			}
+		}
+		return records
+	}
+	obj := vm.NewObject()
+	obj.Set("record", func(call goja.FunctionCall) goja.Value {
+		name := call.Argument(0).String()
+		amount, ok := math.ParseBig256(call.Argument(1).String())
+		if !ok {
+			panic(vm.NewTypeError("invalid amount %v", call.Argument(1)))
+		}
+		now := r.now()
+		records := append(r.loadLimit(name, now), limitRecord{Time: now.Unix(), Amount: amount.String()})
+		blob, err := json.Marshal(records)
+		if err != nil {
+			panic(vm.NewGoError(err))
+		}
+		r.storage.Put(limitPrefix+name, string(blob))
+		return goja.Undefined()
+	})
+	obj.Set("spent", func(call goja.FunctionCall) goja.Value {
+		sum := new(big.Int)
+		for _, record := range window(call) {
+			amount, ok := new(big.Int).SetString(record.Amount, 10)
+			if !ok {
+				panic(vm.NewGoError(fmt.Errorf("corrupt limit record %q", record.Amount)))
+			}
+			sum.Add(sum, amount)
+		}
+		return vm.ToValue(sum.String())
+	})
+	obj.Set("count", func(call goja.FunctionCall) goja.Value {
+		return vm.ToValue(len(window(call)))
+	})
+	return obj
+}
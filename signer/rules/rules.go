@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/dop251/goja"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -47,7 +49,12 @@ func consoleOutput(call goja.FunctionCall) goja.Value {
 type rulesetUI struct {
 	next    core.UIClientAPI // The next handler, for manual processing
 	storage storage.Storage
-	jsRules string // The rules to use
+	jsRules string           // The rules to use
+	now     func() time.Time // Clock of the spending limits and schedules, overridable in tests
+	delayed delayQueue       // Requests pending until the delay of their approval elapses
+
+	rulesLock     sync.RWMutex // Protects the rules, which can be replaced while running
+	whitelistLock sync.Mutex   // Serializes updates of the whitelist
 }
 
 func NewRuleEvaluator(next core.UIClientAPI, jsbackend storage.Storage) (*rulesetUI, error) {
@@ -55,6 +62,7 @@ func NewRuleEvaluator(next core.UIClientAPI, jsbackend storage.Storage) (*rulese
 		next:    next,
 		storage: jsbackend,
 		jsRules: "",
+		now:     time.Now,
 	}
 
 	return c, nil
@@ -65,6 +73,9 @@ func (r *rulesetUI) RegisterUIServer(api *core.UIServerAPI) {
 }
 
 func (r *rulesetUI) Init(javascriptRules string) error {
+	r.rulesLock.Lock()
+	defer r.rulesLock.Unlock()
+
 	r.jsRules = javascriptRules
 	return nil
 }
@@ -94,6 +105,9 @@ func (r *rulesetUI) execute(jsfunc string, jsarg interface{}) (goja.Value, error
 		return jsval
 	})
 	vm.Set("storage", storageObj)
+	vm.Set("limits", r.limitsObject(vm))
+	vm.Set("schedule", r.scheduleObject(vm))
+	vm.Set("whitelist", r.whitelistObject(vm))
 
 	// Load bootstrap libraries
 	script, err := goja.Compile("bignumber.js", deps.BigNumberJS, true)
@@ -104,7 +118,11 @@ func (r *rulesetUI) execute(jsfunc string, jsarg interface{}) (goja.Value, error
 	vm.RunProgram(script)
 
 	// Run the actual rule implementation
-	_, err = vm.RunString(r.jsRules)
+	r.rulesLock.RLock()
+	jsRules := r.jsRules
+	r.rulesLock.RUnlock()
+
+	_, err = vm.RunString(jsRules)
 	if err != nil {
 		log.Warn("Execution failed", "err", err)
 		return goja.Undefined(), err
@@ -140,6 +158,13 @@ func (r *rulesetUI) checkApproval(jsfunc string, jsarg []byte, err error) (bool,
 		return false, err
 	}
 	result := v.ToString().String()
+	if delay, ok := parseDelay(result); ok {
+		approved := r.delayed.wait(jsfunc, jsarg, delay, r.now().Add(delay))
+		if approved {
+			log.Info("Op approved after delay")
+		}
+		return approved, nil
+	}
 	if result == "Approve" {
 		log.Info("Op approved")
 		return true, nil
@@ -167,6 +192,16 @@ func (r *rulesetUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse,
 	return core.SignTxResponse{Approved: false}, err
 }
 
+func (r *rulesetUI) ApproveTxBatch(request *core.SignTxBatchRequest) (core.SignTxBatchResponse, error) {
+	jsonreq, err := json.Marshal(request)
+	approved, err := r.checkApproval("ApproveTxBatch", jsonreq, err)
+	if err != nil {
+		log.Info("Rule-based approval error, going to manual", "error", err)
+		return r.next.ApproveTxBatch(request)
+	}
+	return core.SignTxBatchResponse{Approved: approved}, nil
+}
+
 func (r *rulesetUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	jsonreq, err := json.Marshal(request)
 	approved, err := r.checkApproval("ApproveSignData", jsonreq, err)
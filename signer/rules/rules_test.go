@@ -21,6 +21,7 @@ import (
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
@@ -88,6 +89,10 @@ func (alwaysDenyUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse,
 	return core.SignTxResponse{Transaction: request.Transaction, Approved: false}, nil
 }
 
+func (alwaysDenyUI) ApproveTxBatch(request *core.SignTxBatchRequest) (core.SignTxBatchResponse, error) {
+	return core.SignTxBatchResponse{Approved: false}, nil
+}
+
 func (alwaysDenyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	return core.SignDataResponse{Approved: false}, nil
 }
@@ -214,6 +219,11 @@ func (d *dummyUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, e
 	return core.SignTxResponse{}, core.ErrRequestDenied
 }
 
+func (d *dummyUI) ApproveTxBatch(request *core.SignTxBatchRequest) (core.SignTxBatchResponse, error) {
+	d.calls = append(d.calls, "ApproveTxBatch")
+	return core.SignTxBatchResponse{}, core.ErrRequestDenied
+}
+
 func (d *dummyUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	d.calls = append(d.calls, "ApproveSignData")
 	return core.SignDataResponse{}, core.ErrRequestDenied
@@ -494,6 +504,218 @@ func TestLimitWindow(t *testing.T) {
 	}
 }
 
+const ExampleBuiltinLimits = `
+	var whitelist = ["0x000000000000000000000000000000000000beef"];
+
+	function ApproveTx(r) {
+		var to = r.transaction.to.toLowerCase();
+		if (whitelist.indexOf(to) >= 0) {
+			return "Approve";
+		}
+		// Max 1 ether per 24h to non-whitelisted addresses
+		var spent = new BigNumber(limits.spent("other", 24*3600));
+		var value = new BigNumber(r.transaction.value.slice(2), 16);
+		if (spent.plus(value).lte(new BigNumber("1e18"))) {
+			return "Approve";
+		}
+		return "Reject";
+	}
+
+	function OnApprovedTx(resp) {
+		if (whitelist.indexOf(resp.tx.to.toLowerCase()) < 0) {
+			limits.record("other", resp.tx.value);
+		}
+	}
+`
+
+func TestBuiltinLimits(t *testing.T) {
+	t.Parallel()
+	r, err := initRuleEngine(ExampleBuiltinLimits)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+
+	// 0.4 ether, of which two fit into the daily limit
+	v := new(big.Int).Mul(big.NewInt(4), big.NewInt(1e17))
+	approve := func(want bool) {
+		t.Helper()
+		resp, err := r.ApproveTx(dummyTx(hexutil.Big(*v)))
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if resp.Approved != want {
+			t.Fatalf("Approval mismatch: have %v, want %v", resp.Approved, want)
+		}
+		if resp.Approved {
+			r.OnApprovedTx(ethapi.SignTransactionResult{Tx: dummySigned(v), Raw: common.Hex2Bytes("deadbeef")})
+		}
+	}
+	approve(true)
+	now = now.Add(time.Hour)
+	approve(true)
+	approve(false)
+
+	// The first spending leaves the window after a day
+	now = now.Add(23*time.Hour + time.Second)
+	approve(true)
+	approve(false)
+
+	// Counters must reject invalid periods
+	if _, err := r.execute("limits.count", "other"); err == nil {
+		t.Errorf("Expected error counting without period")
+	}
+}
+
+const ExampleSchedule = `
+	function ApproveTx(r) {
+		// Office hours on weekdays, delayed approvals otherwise
+		var day = schedule.weekday();
+		if (day > 0 && day < 6 && schedule.within("09:00", "17:00")) {
+			return "Approve"
+		}
+		if (schedule.within("22:00", "06:00")) {
+			return "Reject"
+		}
+		return schedule.delay(0.2)
+	}
+`
+
+func TestScheduleWindow(t *testing.T) {
+	t.Parallel()
+	r, err := initRuleEngine(ExampleSchedule)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	var now time.Time
+	r.now = func() time.Time { return now }
+
+	for i, tt := range []struct {
+		time  string
+		delay bool
+		want  bool
+	}{
+		{"2025-06-02T09:00:00Z", false, true},  // Monday, opening
+		{"2025-06-02T16:59:59Z", false, true},  // Monday, closing
+		{"2025-06-02T23:30:00Z", false, false}, // Monday night
+		{"2025-06-03T05:59:00Z", false, false}, // Tuesday early morning
+		{"2025-06-02T17:00:00Z", true, true},   // Monday evening
+		{"2025-06-07T12:00:00Z", true, true},   // Saturday
+	} {
+		now, _ = time.Parse(time.RFC3339, tt.time)
+		start := time.Now()
+		resp, err := r.ApproveTx(dummyTxWithV(0))
+		if err != nil {
+			t.Fatalf("test %d: unexpected error %v", i, err)
+		}
+		if resp.Approved != tt.want {
+			t.Errorf("test %d: approval mismatch: have %v, want %v", i, resp.Approved, tt.want)
+		}
+		if delayed := time.Since(start) >= 200*time.Millisecond; delayed != tt.delay {
+			t.Errorf("test %d: delay mismatch: have %v, want %v", i, delayed, tt.delay)
+		}
+	}
+	// Windows must reject malformed times
+	if _, err := r.execute("schedule.within", "9am"); err == nil {
+		t.Errorf("Expected error for malformed time of day")
+	}
+}
+
+func TestDelayedApproval(t *testing.T) {
+	t.Parallel()
+	r, err := initRuleEngine(`function ApproveTx(r) { return schedule.delay(3600) }`)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	r.now = func() time.Time { return now }
+
+	// Queue two requests, waiting for them to be listed
+	results := make(chan bool, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			resp, err := r.ApproveTx(dummyTxWithV(uint64(i)))
+			if err != nil {
+				t.Errorf("Unexpected error %v", err)
+			}
+			results <- resp.Approved
+		}()
+	}
+	var pending []*core.DelayedRequest
+	for start := time.Now(); len(pending) < 2; pending = r.DelayedRequests() {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("Requests not queued, have %d", len(pending))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	for _, req := range pending {
+		if req.Method != "ApproveTx" {
+			t.Errorf("Method mismatch: have %s, want ApproveTx", req.Method)
+		}
+		if want := now.Add(time.Hour); !req.Due.Equal(want) {
+			t.Errorf("Due time mismatch: have %v, want %v", req.Due, want)
+		}
+	}
+	// Cancelled requests must be rejected and unlisted
+	for _, req := range pending {
+		if err := r.CancelDelayedRequest(req.ID); err != nil {
+			t.Fatalf("Failed to cancel request %d: %v", req.ID, err)
+		}
+		if approved := <-results; approved {
+			t.Errorf("Cancelled request %d approved", req.ID)
+		}
+	}
+	if pending := r.DelayedRequests(); len(pending) != 0 {
+		t.Errorf("Cancelled requests still pending: %d", len(pending))
+	}
+	if err := r.CancelDelayedRequest(pending[0].ID); err == nil {
+		t.Errorf("Expected error cancelling unknown request")
+	}
+}
+
+func TestWhitelist(t *testing.T) {
+	t.Parallel()
+	r, err := initRuleEngine(`
+	function ApproveTx(r) {
+		if (whitelist.contains(r.transaction.to)) {
+			return "Approve"
+		}
+		return "Reject"
+	}`)
+	if err != nil {
+		t.Fatalf("Couldn't create evaluator %v", err)
+	}
+	approve := func(want bool) {
+		t.Helper()
+		resp, err := r.ApproveTx(dummyTxWithV(0))
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if resp.Approved != want {
+			t.Fatalf("Approval mismatch: have %v, want %v", resp.Approved, want)
+		}
+	}
+	dead := common.HexToAddress("0x000000000000000000000000000000000000dead")
+	approve(false)
+
+	// Whitelisted destinations must be approved until removed again
+	for i := 0; i < 2; i++ {
+		if err := r.AddToWhitelist(dead); err != nil {
+			t.Fatalf("Failed to whitelist: %v", err)
+		}
+	}
+	if list := r.Whitelist(); len(list) != 1 || list[0] != dead {
+		t.Fatalf("Whitelist mismatch: have %v, want [%v]", list, dead)
+	}
+	approve(true)
+
+	if err := r.RemoveFromWhitelist(dead); err != nil {
+		t.Fatalf("Failed to remove from whitelist: %v", err)
+	}
+	approve(false)
+}
+
 // dontCallMe is used as a next-handler that does not want to be called - it invokes test failure
 type dontCallMe struct {
 	t *testing.T
@@ -515,6 +737,11 @@ func (d *dontCallMe) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse
 	return core.SignTxResponse{}, core.ErrRequestDenied
 }
 
+func (d *dontCallMe) ApproveTxBatch(request *core.SignTxBatchRequest) (core.SignTxBatchResponse, error) {
+	d.t.Fatalf("Did not expect next-handler to be called")
+	return core.SignTxBatchResponse{}, core.ErrRequestDenied
+}
+
 func (d *dontCallMe) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
 	d.t.Fatalf("Did not expect next-handler to be called")
 	return core.SignDataResponse{}, core.ErrRequestDenied
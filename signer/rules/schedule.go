@@ -0,0 +1,188 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// delayPrefix starts the response of rules approving a request after a delay,
+// followed by the delay in seconds, e.g. "Delay:3600".
+const delayPrefix = "Delay:"
+
+// parseDelay parses the delay of a time-locked approval from a rule response.
+func parseDelay(result string) (time.Duration, bool) {
+	if !strings.HasPrefix(result, delayPrefix) {
+		return 0, false
+	}
+	seconds, err := strconv.ParseFloat(result[len(delayPrefix):], 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// delayedRequest is a request pending until its delay elapses.
+type delayedRequest struct {
+	info   *core.DelayedRequest
+	cancel chan struct{} // Closed if the request is cancelled
+}
+
+// delayQueue tracks the requests of time-locked approvals.
+type delayQueue struct {
+	pending map[uint64]*delayedRequest
+	nextID  uint64
+	lock    sync.Mutex
+}
+
+// wait holds a request back until its delay elapses, returning whether it can
+// proceed, or false if it was cancelled in the meantime.
+func (q *delayQueue) wait(method string, request []byte, delay time.Duration, due time.Time) bool {
+	q.lock.Lock()
+	if q.pending == nil {
+		q.pending = make(map[uint64]*delayedRequest)
+	}
+	q.nextID++
+	req := &delayedRequest{
+		info:   &core.DelayedRequest{ID: q.nextID, Method: method, Request: request, Due: due},
+		cancel: make(chan struct{}),
+	}
+	q.pending[req.info.ID] = req
+	q.lock.Unlock()
+
+	defer func() {
+		q.lock.Lock()
+		delete(q.pending, req.info.ID)
+		q.lock.Unlock()
+	}()
+	log.Info("Request approval delayed", "id", req.info.ID, "method", method, "due", due)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-req.cancel:
+		log.Info("Delayed request cancelled", "id", req.info.ID, "method", method)
+		return false
+	}
+}
+
+// list returns the pending requests, ordered by their due time.
+func (q *delayQueue) list() []*core.DelayedRequest {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	requests := make([]*core.DelayedRequest, 0, len(q.pending))
+	for _, req := range q.pending {
+		requests = append(requests, req.info)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].Due.Equal(requests[j].Due) {
+			return requests[i].ID < requests[j].ID
+		}
+		return requests[i].Due.Before(requests[j].Due)
+	})
+	return requests
+}
+
+// cancel rejects a pending request.
+func (q *delayQueue) cancel(id uint64) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	req, ok := q.pending[id]
+	if !ok {
+		return fmt.Errorf("no delayed request with id %d", id)
+	}
+	close(req.cancel)
+	delete(q.pending, id)
+	return nil
+}
+
+// DelayedRequests implements core.RequestScheduler, listing the requests
+// approved after a delay which are still pending.
+func (r *rulesetUI) DelayedRequests() []*core.DelayedRequest {
+	return r.delayed.list()
+}
+
+// CancelDelayedRequest implements core.RequestScheduler, rejecting a pending
+// request approved after a delay.
+func (r *rulesetUI) CancelDelayedRequest(id uint64) error {
+	return r.delayed.cancel(id)
+}
+
+// parseTimeOfDay parses a time of day in the form HH:MM, returning the offset
+// since midnight.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, want HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// scheduleObject creates the JS object for scheduling approvals in time:
+//
+//	schedule.within(from, to)    returns whether the time of day is within [from, to), UTC
+//	schedule.weekday()           returns the day of the week, UTC, 0 being Sunday
+//	schedule.delay(seconds)      returns the response approving the request after a delay
+//
+// Times of day are given as HH:MM. Windows wrap around midnight if from is later
+// than to, e.g. within("22:00", "06:00") covers the night.
+func (r *rulesetUI) scheduleObject(vm *goja.Runtime) *goja.Object {
+	obj := vm.NewObject()
+	obj.Set("within", func(call goja.FunctionCall) goja.Value {
+		from, err := parseTimeOfDay(call.Argument(0).String())
+		if err != nil {
+			panic(vm.NewTypeError("%v", err))
+		}
+		to, err := parseTimeOfDay(call.Argument(1).String())
+		if err != nil {
+			panic(vm.NewTypeError("%v", err))
+		}
+		var (
+			now = r.now().UTC()
+			day = now.Sub(now.Truncate(24 * time.Hour))
+		)
+		if from <= to {
+			return vm.ToValue(day >= from && day < to)
+		}
+		return vm.ToValue(day >= from || day < to)
+	})
+	obj.Set("weekday", func(call goja.FunctionCall) goja.Value {
+		return vm.ToValue(int(r.now().UTC().Weekday()))
+	})
+	obj.Set("delay", func(call goja.FunctionCall) goja.Value {
+		seconds := call.Argument(0).ToFloat()
+		if seconds < 0 {
+			panic(vm.NewTypeError("invalid delay %v", call.Argument(0)))
+		}
+		return vm.ToValue(delayPrefix + strconv.FormatFloat(seconds, 'f', -1, 64))
+	})
+	return obj
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rules
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// whitelistKey is the storage key of the whitelisted addresses.
+const whitelistKey = "whitelist"
+
+// Whitelist returns the whitelisted addresses, which the rules can consult
+// through the whitelist object.
+func (r *rulesetUI) Whitelist() []common.Address {
+	r.whitelistLock.Lock()
+	defer r.whitelistLock.Unlock()
+
+	return r.loadWhitelist()
+}
+
+// AddToWhitelist adds an address to the whitelist.
+func (r *rulesetUI) AddToWhitelist(addr common.Address) error {
+	r.whitelistLock.Lock()
+	defer r.whitelistLock.Unlock()
+
+	whitelist := r.loadWhitelist()
+	if slices.Contains(whitelist, addr) {
+		return nil
+	}
+	return r.storeWhitelist(append(whitelist, addr))
+}
+
+// RemoveFromWhitelist removes an address from the whitelist.
+func (r *rulesetUI) RemoveFromWhitelist(addr common.Address) error {
+	r.whitelistLock.Lock()
+	defer r.whitelistLock.Unlock()
+
+	whitelist := r.loadWhitelist()
+	if i := slices.Index(whitelist, addr); i >= 0 {
+		return r.storeWhitelist(slices.Delete(whitelist, i, i+1))
+	}
+	return nil
+}
+
+// loadWhitelist retrieves the whitelist from the storage.
+func (r *rulesetUI) loadWhitelist() []common.Address {
+	stored, err := r.storage.Get(whitelistKey)
+	if err != nil || stored == "" {
+		return []common.Address{}
+	}
+	var whitelist []common.Address
+	if err := json.Unmarshal([]byte(stored), &whitelist); err != nil {
+		return []common.Address{}
+	}
+	return whitelist
+}
+
+// storeWhitelist persists the whitelist in the storage.
+func (r *rulesetUI) storeWhitelist(whitelist []common.Address) error {
+	blob, err := json.Marshal(whitelist)
+	if err != nil {
+		return err
+	}
+	r.storage.Put(whitelistKey, string(blob))
+	return nil
+}
+
+// whitelistObject creates the JS object exposing the whitelisted addresses:
+//
+//	whitelist.contains(address)   returns whether the address is whitelisted
+//	whitelist.list()              returns the whitelisted addresses
+func (r *rulesetUI) whitelistObject(vm *goja.Runtime) *goja.Object {
+	obj := vm.NewObject()
+	obj.Set("contains", func(call goja.FunctionCall) goja.Value {
+		addr := call.Argument(0).String()
+		if !common.IsHexAddress(addr) {
+			return vm.ToValue(false)
+		}
+		return vm.ToValue(slices.Contains(r.Whitelist(), common.HexToAddress(addr)))
+	})
+	obj.Set("list", func(call goja.FunctionCall) goja.Value {
+		whitelist := r.Whitelist()
+		addrs := make([]interface{}, len(whitelist))
+		for i, addr := range whitelist {
+			addrs[i] = addr.Hex()
+		}
+		return vm.NewArray(addrs...)
+	})
+	return obj
+}
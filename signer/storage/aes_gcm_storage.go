@@ -22,7 +22,6 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"io"
-	"os"
 
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -36,9 +35,13 @@ type storedCredential struct {
 
 // AESEncryptedStorage is a storage type which is backed by a json-file. The json-file contains
 // key-value mappings, where the keys are _not_ encrypted, only the values are.
+//
+// The json-file is stored on disk by default, or in any other Backend.
 type AESEncryptedStorage struct {
 	// File to read/write credentials
 	filename string
+	// Backend storing the file, the local disk if nil
+	backend Backend
 	// Key stored in base64
 	key []byte
 }
@@ -51,6 +54,24 @@ func NewAESEncryptedStorage(filename string, key []byte) *AESEncryptedStorage {
 	}
 }
 
+// NewAESEncryptedStorageWithBackend creates a new encrypted storage backed by the
+// given file/key, with the file stored in a backend.
+func NewAESEncryptedStorageWithBackend(backend Backend, filename string, key []byte) *AESEncryptedStorage {
+	return &AESEncryptedStorage{
+		filename: filename,
+		backend:  backend,
+		key:      key,
+	}
+}
+
+// storage returns the backend storing the file.
+func (s *AESEncryptedStorage) storage() Backend {
+	if s.backend == nil {
+		return FileBackend("")
+	}
+	return s.backend
+}
+
 // Put stores a value by key. 0-length keys results in noop.
 func (s *AESEncryptedStorage) Put(key, value string) {
 	if len(key) == 0 {
@@ -113,14 +134,14 @@ func (s *AESEncryptedStorage) Del(key string) {
 // readEncryptedStorage reads the file with encrypted creds
 func (s *AESEncryptedStorage) readEncryptedStorage() (map[string]storedCredential, error) {
 	creds := make(map[string]storedCredential)
-	raw, err := os.ReadFile(s.filename)
-
+	raw, err := s.storage().Load(s.filename)
 	if err != nil {
-		if os.IsNotExist(err) {
-			// Doesn't exist yet
-			return creds, nil
-		}
 		log.Warn("Failed to read encrypted storage", "err", err, "file", s.filename)
+		return nil, err
+	}
+	if raw == nil {
+		// Doesn't exist yet
+		return creds, nil
 	}
 	if err = json.Unmarshal(raw, &creds); err != nil {
 		log.Warn("Failed to unmarshal encrypted storage", "err", err, "file", s.filename)
@@ -135,10 +156,7 @@ func (s *AESEncryptedStorage) writeEncryptedStorage(creds map[string]storedCrede
 	if err != nil {
 		return err
 	}
-	if err = os.WriteFile(s.filename, raw, 0600); err != nil {
-		return err
-	}
-	return nil
+	return s.storage().Store(s.filename, raw)
 }
 
 // encrypt encrypts plaintext with the given key, with additional data
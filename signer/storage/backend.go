@@ -0,0 +1,201 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Backend persists the files of encrypted storages. The values are encrypted
+// before reaching the backend, which is thus not trusted with their secrecy,
+// only with keeping them available.
+type Backend interface {
+	// Load retrieves the contents of a file, or nil if it doesn't exist.
+	Load(name string) ([]byte, error)
+
+	// Store replaces the contents of a file.
+	Store(name string, data []byte) error
+}
+
+// FileBackend stores files on the local disk, relative to a directory.
+type FileBackend string
+
+// Load implements Backend, reading the file from disk.
+func (b FileBackend) Load(name string) ([]byte, error) {
+	data, err := os.ReadFile(filepath.Join(string(b), name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Store implements Backend, writing the file to disk.
+func (b FileBackend) Store(name string, data []byte) error {
+	path := filepath.Join(string(b), name)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// vaultTimeout is the time allowed for requests to HashiCorp Vault.
+const vaultTimeout = 10 * time.Second
+
+// VaultBackend stores files as secrets of a HashiCorp Vault KV version 2 secrets
+// engine, under a common path prefix.
+type VaultBackend struct {
+	endpoint string // Address of the Vault server, e.g. https://vault:8200
+	mount    string // Mount path of the KV secrets engine
+	prefix   string // Path prefix of the secrets
+	token    string // Token authenticating to Vault
+	client   *http.Client
+}
+
+// NewVaultBackend creates a backend storing files in the KV version 2 secrets
+// engine mounted at the given path of a Vault server.
+func NewVaultBackend(endpoint, mount, prefix, token string) *VaultBackend {
+	return &VaultBackend{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		mount:    strings.Trim(mount, "/"),
+		prefix:   strings.Trim(prefix, "/"),
+		token:    token,
+		client:   http.DefaultClient,
+	}
+}
+
+// vaultSecret is the data of a secret holding a file.
+type vaultSecret struct {
+	Data struct {
+		Value string `json:"value"`
+	} `json:"data"`
+}
+
+// url returns the API endpoint of the secret holding a file.
+func (b *VaultBackend) url(name string) string {
+	path := name
+	if b.prefix != "" {
+		path = b.prefix + "/" + name
+	}
+	return fmt.Sprintf("%s/v1/%s/data/%s", b.endpoint, b.mount, path)
+}
+
+// do sends a request to Vault, returning the response if successful.
+func (b *VaultBackend) do(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", b.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return b.client.Do(req)
+}
+
+// Load implements Backend, reading the latest version of the secret.
+func (b *VaultBackend) Load(name string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultTimeout)
+	defer cancel()
+
+	res, err := b.do(ctx, http.MethodGet, b.url(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("vault responded with %s", res.Status)
+	}
+	var result struct {
+		Data vaultSecret `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(result.Data.Data.Value)
+}
+
+// Store implements Backend, writing a new version of the secret.
+func (b *VaultBackend) Store(name string, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultTimeout)
+	defer cancel()
+
+	var secret vaultSecret
+	secret.Data.Value = base64.StdEncoding.EncodeToString(data)
+	body, err := json.Marshal(secret)
+	if err != nil {
+		return err
+	}
+	res, err := b.do(ctx, http.MethodPost, b.url(name), body)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("vault responded with %s", res.Status)
+	}
+	return nil
+}
+
+// CommandBackend stores files through an external helper program, such as one
+// accessing the keychain of the operating system. The helper is invoked as
+// "<helper> load <name>", printing the contents of the file or nothing if it
+// doesn't exist, and as "<helper> store <name>", reading the contents from its
+// standard input.
+type CommandBackend string
+
+// Load implements Backend, reading the file through the helper.
+func (b CommandBackend) Load(name string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(string(b), "load", name)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("storage helper failed: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	if stdout.Len() == 0 {
+		return nil, nil
+	}
+	return stdout.Bytes(), nil
+}
+
+// Store implements Backend, writing the file through the helper.
+func (b CommandBackend) Store(name string, data []byte) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(string(b), "store", name)
+	cmd.Stdin, cmd.Stderr = bytes.NewReader(data), &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("storage helper failed: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testBackend runs an encrypted storage on top of a backend, checking that the
+// values are persisted without being exposed to it.
+func testBackend(t *testing.T, backend Backend, raw func(name string) []byte) {
+	key := []byte("AES256Key-32Characters1234567890")
+
+	s1 := NewAESEncryptedStorageWithBackend(backend, "vault/credentials.json", key)
+	s1.Put("bazonk", "foobar")
+	s1.Put("other", "value")
+	s1.Del("other")
+
+	s2 := NewAESEncryptedStorageWithBackend(backend, "vault/credentials.json", key)
+	if v, err := s2.Get("bazonk"); v != "foobar" || err != nil {
+		t.Errorf("Expected bazonk->foobar (nil error), got '%v' (%v error)", v, err)
+	}
+	if _, err := s2.Get("other"); err != ErrNotFound {
+		t.Errorf("Expected deleted key to be missing, got %v", err)
+	}
+	if data := raw("vault/credentials.json"); len(data) == 0 || bytes.Contains(data, []byte("foobar")) {
+		t.Errorf("Expected encrypted data in backend, got %q", data)
+	}
+	// Storages missing from the backend must be empty
+	s3 := NewAESEncryptedStorageWithBackend(backend, "vault/missing.json", key)
+	if _, err := s3.Get("bazonk"); err != ErrNotFound {
+		t.Errorf("Expected missing key, got %v", err)
+	}
+}
+
+func TestFileBackend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	testBackend(t, FileBackend(dir), func(name string) []byte {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		return data
+	})
+}
+
+func TestVaultBackend(t *testing.T) {
+	t.Parallel()
+
+	// Emulate the KV version 2 secrets engine of a Vault server
+	var (
+		lock    sync.Mutex
+		secrets = make(map[string]json.RawMessage)
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+		path, ok := strings.CutPrefix(r.URL.Path, "/v1/secret/data/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch r.Method {
+		case http.MethodGet:
+			secret, ok := secrets[path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"data": secret})
+		case http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			secrets[path] = body
+		}
+	}))
+	defer server.Close()
+
+	backend := NewVaultBackend(server.URL, "secret", "clef", "token")
+	testBackend(t, backend, func(name string) []byte {
+		lock.Lock()
+		defer lock.Unlock()
+		return secrets["clef/"+name]
+	})
+	// Unauthenticated access must fail, rather than report missing data
+	if _, err := NewVaultBackend(server.URL, "secret", "clef", "bad").Load("vault/credentials.json"); err == nil {
+		t.Error("Expected error with invalid token")
+	}
+}
+
+func TestCommandBackend(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("helper is a shell script")
+	}
+	t.Parallel()
+
+	// A helper keeping the files in a directory
+	dir := t.TempDir()
+	helper := filepath.Join(dir, "helper.sh")
+	script := `#!/bin/sh
+file="` + dir + `/$(echo "$2" | tr / _)"
+case "$1" in
+	load) [ -f "$file" ] && cat "$file"; exit 0 ;;
+	store) cat > "$file" ;;
+	*) echo "unknown command" >&2; exit 1 ;;
+esac
+`
+	if err := os.WriteFile(helper, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+	testBackend(t, CommandBackend(helper), func(name string) []byte {
+		data, _ := os.ReadFile(filepath.Join(dir, strings.ReplaceAll(name, "/", "_")))
+		return data
+	})
+}